@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"os"
 
 	"github.com/spf13/cobra"
 
@@ -17,6 +18,7 @@ type applyPackageOptions struct {
 	// existing cluster.
 	kubeConfig      string
 	bundlesOverride string
+	dryRun          bool
 }
 
 var apo = &applyPackageOptions{}
@@ -30,6 +32,8 @@ func init() {
 		"Path to an optional kubeconfig file to use.")
 	applyPackagesCommand.Flags().StringVar(&apo.bundlesOverride, "bundles-override", "",
 		"Override default Bundles manifest (not recommended)")
+	applyPackagesCommand.Flags().BoolVar(&apo.dryRun, dryRunFlag, false,
+		"Validate the packages and print the changes without applying them")
 
 	err := applyPackagesCommand.MarkFlagRequired("filename")
 	if err != nil {
@@ -66,6 +70,13 @@ func applyPackages(ctx context.Context) error {
 		deps.Kubectl,
 	)
 
+	if err = packages.ReviewPackages(ctx, apo.fileName, kubeConfig, os.Stdout); err != nil {
+		return err
+	}
+	if apo.dryRun {
+		return nil
+	}
+
 	curatedpackages.PrintLicense()
 	err = packages.ApplyPackages(ctx, apo.fileName, kubeConfig)
 	if err != nil {
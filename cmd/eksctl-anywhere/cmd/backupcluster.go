@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/aws/eks-anywhere/pkg/etcdbackup"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const defaultEtcdBackupDir = "etcd-backups"
+
+// etcdBackupOptions holds the flags shared by the commands that take and restore etcd snapshots.
+type etcdBackupOptions struct {
+	nodeSSHOptions
+	dir        string
+	s3Bucket   string
+	s3Prefix   string
+	s3Endpoint string
+	s3Region   string
+}
+
+func applyEtcdBackupFlags(flagSet *pflag.FlagSet, o *etcdBackupOptions) {
+	applyNodeSSHFlags(flagSet, &o.nodeSSHOptions)
+	flagSet.StringVar(&o.dir, "dir", "", "Local directory for etcd snapshots (default <cluster-name>/etcd-backups)")
+	flagSet.StringVar(&o.s3Bucket, "s3-bucket", "", "S3 bucket for etcd snapshots, used instead of a local directory")
+	flagSet.StringVar(&o.s3Prefix, "s3-prefix", "", "Key prefix for etcd snapshots in the S3 bucket")
+	flagSet.StringVar(&o.s3Endpoint, "s3-endpoint", "", "Endpoint for S3 compatible object stores")
+	flagSet.StringVar(&o.s3Region, "s3-region", "", "Region of the S3 bucket")
+}
+
+func (o *etcdBackupOptions) store(clusterName string) (etcdbackup.Store, error) {
+	if o.s3Bucket == "" {
+		dir := o.dir
+		if dir == "" {
+			dir = filepath.Join(clusterName, defaultEtcdBackupDir)
+		}
+		return etcdbackup.NewDirStore(dir), nil
+	}
+
+	var opts []etcdbackup.S3StoreOpt
+	if o.s3Endpoint != "" {
+		opts = append(opts, etcdbackup.WithS3Endpoint(o.s3Endpoint))
+	}
+	if o.s3Region != "" {
+		opts = append(opts, etcdbackup.WithS3Region(o.s3Region))
+	}
+
+	return etcdbackup.NewS3Store(o.s3Bucket, o.s3Prefix, opts...)
+}
+
+var bc = &etcdBackupOptions{}
+
+var backupClusterCmd = &cobra.Command{
+	Use:          "cluster -f <cluster-config-file>",
+	Short:        "Take an etcd snapshot of a cluster",
+	Long:         "This command takes an etcd snapshot from the control plane or external etcd nodes of a cluster and saves it to a local directory or an S3 compatible object store",
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := bc.backupCluster(cmd.Context()); err != nil {
+			return fmt.Errorf("failed to backup cluster: %v", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	backupCmd.AddCommand(backupClusterCmd)
+	applyEtcdBackupFlags(backupClusterCmd.Flags(), bc)
+	if err := backupClusterCmd.MarkFlagRequired("filename"); err != nil {
+		log.Fatalf("Error marking filename flag as required: %v", err)
+	}
+}
+
+func (o *etcdBackupOptions) backupCluster(ctx context.Context) error {
+	clusterSpec, err := newClusterSpec(o.clusterOptions)
+	if err != nil {
+		return err
+	}
+
+	store, err := o.store(clusterSpec.Cluster.Name)
+	if err != nil {
+		return err
+	}
+
+	deps, runner, err := o.dependencies(ctx, clusterSpec)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	logger.Info("Taking etcd snapshot")
+	snapshot, err := etcdbackup.NewBackupper(deps.NodeLister, runner, store).Backup(ctx, getManagementCluster(clusterSpec), clusterSpec)
+	if err != nil {
+		return err
+	}
+
+	logger.Info("Etcd snapshot saved", "snapshot", snapshot.Name, "location", snapshot.Location, "node", snapshot.Node, "bytes", snapshot.Size)
+	return nil
+}
@@ -4,19 +4,28 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"os"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 
 	"github.com/aws/eks-anywhere/cmd/eksctl-anywhere/cmd/internal/commands/artifacts"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/config"
+	"github.com/aws/eks-anywhere/pkg/curatedpackages"
 	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/registry"
 	"github.com/aws/eks-anywhere/pkg/registrymirror"
+	"github.com/aws/eks-anywhere/pkg/validations"
 	"github.com/aws/eks-anywhere/pkg/version"
+	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
 )
 
 type checkImagesOptions struct {
-	fileName string
+	signatureOptions
+	fileName        string
+	includePackages bool
 }
 
 var cio = &checkImagesOptions{}
@@ -28,12 +37,15 @@ func init() {
 	if err != nil {
 		log.Fatalf("Error marking filename flag as required: %v", err)
 	}
+	checkImagesCommand.Flags().BoolVar(&cio.includePackages, "include-packages", false, "Also check the curated packages images for the Kubernetes version of the cluster")
+	applySignatureFlags(checkImagesCommand.Flags(), &cio.signatureOptions)
 }
 
 var checkImagesCommand = &cobra.Command{
 	Use:   "check-images",
 	Short: "Check images used by EKS Anywhere do exist in the target registry",
-	Long:  "This command is used to check images used by EKS-Anywhere for cluster provisioning do exist in the target registry",
+	Long: `This command is used to check images used by EKS-Anywhere for cluster provisioning do exist in the target registry.
+With --signature-public-key, it also verifies the images are signed with that key and match the digests in the bundle.`,
 	PreRunE: func(cmd *cobra.Command, args []string) error {
 		cmd.Flags().VisitAll(func(flag *pflag.Flag) {
 			if err := viper.BindPFlag(flag.Name, flag); err != nil {
@@ -44,32 +56,87 @@ var checkImagesCommand = &cobra.Command{
 	},
 	SilenceUsage: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return checkImages(cmd.Context(), cio.fileName)
+		return cio.checkImages(cmd.Context())
 	},
 }
 
-func checkImages(context context.Context, clusterSpecPath string) error {
-	images, err := getImages(clusterSpecPath, "")
+func (c *checkImagesOptions) checkImages(ctx context.Context) error {
+	clusterSpec, err := readAndValidateClusterSpec(c.fileName, version.Get())
 	if err != nil {
 		return err
 	}
 
-	clusterSpec, err := readAndValidateClusterSpec(clusterSpecPath, version.Get())
+	mirror := registrymirror.FromCluster(clusterSpec.Cluster)
+	verifier, err := c.imageVerifier(mirror)
 	if err != nil {
 		return err
 	}
 
+	images := validations.ClusterImages(clusterSpec)
+	if c.includePackages {
+		packageImages, err := readPackageImages(ctx, clusterSpec, mirror)
+		if err != nil {
+			return err
+		}
+		images = append(images, packageImages...)
+	}
+
 	checkImageExistence := artifacts.CheckImageExistence{}
+	failedVerification := 0
 	for _, image := range images {
-		myImageURI := registrymirror.FromCluster(clusterSpec.Cluster).ReplaceRegistry(image.URI)
+		ref := image
+		if ref.Tag != "" {
+			ref.Digest = ""
+		}
+		myImageURI := ref.VersionedImage()
 		checkImageExistence.ImageUri = myImageURI
-		if err = checkImageExistence.Run(context); err != nil {
+		if err = checkImageExistence.Run(ctx); err != nil {
 			fmt.Println(err.Error())
 			logger.MarkFail(myImageURI)
-		} else {
-			logger.MarkPass(myImageURI)
+			continue
 		}
+
+		if verifier != nil {
+			if err = verifier.VerifyArtifact(ctx, image); err != nil {
+				fmt.Println(err.Error())
+				logger.MarkFail(myImageURI)
+				failedVerification++
+				continue
+			}
+		}
+		logger.MarkPass(myImageURI)
+	}
+
+	if failedVerification > 0 {
+		return fmt.Errorf("%d images failed the signature verification", failedVerification)
 	}
 
 	return nil
 }
+
+// readPackageImages returns the images of the curated packages bundle for the Kubernetes version
+// of the cluster, pulled from the registry mirror when the cluster has one.
+func readPackageImages(ctx context.Context, clusterSpec *cluster.Spec, mirror *registrymirror.RegistryMirror) ([]registry.Artifact, error) {
+	credentialStore := registry.NewCredentialStore()
+	if err := credentialStore.Init(); err != nil {
+		return nil, err
+	}
+
+	bundles := &releasev1.Bundles{
+		Spec: releasev1.BundlesSpec{
+			VersionsBundles: []releasev1.VersionsBundle{*clusterSpec.VersionsBundle.VersionsBundle},
+		},
+	}
+	reader := curatedpackages.NewPackageReader(registry.NewCache(), credentialStore, os.Getenv(config.EksaRegionEnv))
+	images, err := reader.ReadImagesFromBundles(ctx, bundles)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, image := range images {
+		mirrored := registry.NewArtifactFromURI(mirror.ReplaceRegistry(image.VersionedImage()))
+		mirrored.Tag = image.Tag
+		images[i] = mirrored
+	}
+	return images, nil
+}
@@ -3,12 +3,21 @@ package cmd
 const (
 	imagesTarFile               = "images.tar"
 	eksaToolsImageTarFile       = "tools-image.tar"
+	downloadImagesStateFile     = "download-images-state.json"
+	importImagesStateFile       = "import-images-state.json"
+	downloadPackagesStateFile   = "download-packages-state.json"
+	importPackagesStateFile     = "import-packages-state.json"
 	cpWaitTimeoutFlag           = "control-plane-wait-timeout"
 	externalEtcdWaitTimeoutFlag = "external-etcd-wait-timeout"
 	perMachineWaitTimeoutFlag   = "per-machine-wait-timeout"
 	unhealthyMachineTimeoutFlag = "unhealthy-machine-timeout"
 	nodeStartupTimeoutFlag      = "node-startup-timeout"
 	noTimeoutsFlag              = "no-timeouts"
+	eventsOutputFlag            = "events-output"
+	dryRunFlag                  = "dry-run"
+	dryRunOutputDirFlag         = "dry-run-output-dir"
+	signaturePublicKeyFlag      = "signature-public-key"
+	requireSBOMFlag             = "require-sbom"
 )
 
 type Operation int
@@ -17,9 +17,10 @@ import (
 
 // copyPackagesCmd is the context for the copy packages command.
 var copyPackagesCmd = &cobra.Command{
-	Use:          "packages <destination-registry>",
-	Short:        "Copy curated package images and charts from a source to a destination",
-	Long:         `Copy all the EKS Anywhere curated package images and helm charts from a source to a destination.`,
+	Use:   "packages <destination-registry>",
+	Short: "Copy curated package images and charts from a source to a destination",
+	Long: `Copy all the EKS Anywhere curated package images and helm charts from a source to a destination.
+The progress is recorded in a state file, so an interrupted copy resumes from where it stopped.`,
 	SilenceUsage: true,
 	RunE:         runCopyPackages,
 	Args: func(cmd *cobra.Command, args []string) error {
@@ -42,6 +43,8 @@ func init() {
 	copyPackagesCmd.Flags().BoolVar(&copyPackagesCommand.insecure, "insecure", false, "Skip TLS verification while copying images and charts")
 	copyPackagesCmd.Flags().BoolVar(&copyPackagesCommand.dryRun, "dry-run", false, "Dry run copy to print images that would be copied")
 	copyPackagesCmd.Flags().StringVarP(&copyPackagesCommand.awsRegion, "aws-region", "", os.Getenv(config.EksaRegionEnv), "Region to copy images from")
+	copyPackagesCmd.Flags().IntVar(&copyPackagesCommand.concurrency, "concurrency", 4, "Maximum number of images and charts copied at the same time")
+	copyPackagesCmd.Flags().StringVar(&copyPackagesCommand.stateFile, "state-file", "copy-packages-state.json", "File to record the progress of the copy, used to resume an interrupted copy. The charts and images are recorded in separate files with -charts and -images suffixes")
 }
 
 var copyPackagesCommand = CopyPackagesCommand{}
@@ -55,6 +58,8 @@ type CopyPackagesCommand struct {
 	insecure      bool
 	dryRun        bool
 	awsRegion     string
+	concurrency   int
+	stateFile     string
 	registryCache *registry.Cache
 }
 
@@ -102,7 +107,7 @@ func (c CopyPackagesCommand) call(ctx context.Context, credentialStore *registry
 	}
 
 	log.Printf("Copying curated packages helm charts from public ECR to %s", c.destination)
-	err = c.copyImages(ctx, dstRegistry, credentialStore, imageList)
+	err = c.copyImages(ctx, dstRegistry, credentialStore, imageList, registry.PhaseStateFile(c.stateFile, "charts"))
 	if err != nil {
 		return err
 	}
@@ -113,34 +118,25 @@ func (c CopyPackagesCommand) call(ctx context.Context, credentialStore *registry
 	}
 	dstRegistry.SetProject("curated-packages/")
 	log.Printf("Copying curated packages images from private ECR to %s", c.destination)
-	return c.copyImages(ctx, dstRegistry, credentialStore, imageList)
+	return c.copyImages(ctx, dstRegistry, credentialStore, imageList, registry.PhaseStateFile(c.stateFile, "images"))
 }
 
-func (c CopyPackagesCommand) copyImages(ctx context.Context, dstRegistry registry.StorageClient, credentialStore *registry.CredentialStore, imageList []registry.Artifact) error {
+func (c CopyPackagesCommand) copyImages(ctx context.Context, dstRegistry registry.StorageClient, credentialStore *registry.CredentialStore, imageList []registry.Artifact, stateFile string) error {
 	certificates, err := registry.GetCertificates(c.srcCert)
 	if err != nil {
 		return err
 	}
 
 	for _, image := range imageList {
-		host := image.Registry
-
-		srcContext := registry.NewStorageContext(host, credentialStore, certificates, c.insecure)
-		srcRegistry, err := c.registryCache.Get(srcContext)
-		if err != nil {
-			return fmt.Errorf("error with repository %s: %v", host, err)
-		}
-
-		artifact := registry.NewArtifact(image.Registry, image.Repository, image.Tag, image.Digest)
-		log.Println(dstRegistry.Destination(artifact))
-		if c.dryRun {
-			continue
-		}
-
-		err = registry.Copy(ctx, srcRegistry, dstRegistry, artifact)
-		if err != nil {
-			return err
-		}
+		log.Println(dstRegistry.Destination(image))
+	}
+	if c.dryRun {
+		return nil
 	}
-	return nil
+
+	mirror := registry.NewMirror(dstRegistry, c.registryCache.Sources(credentialStore, certificates, c.insecure),
+		registry.WithConcurrency(c.concurrency),
+		registry.WithStateFile(stateFile),
+	)
+	return mirror.Run(ctx, imageList)
 }
@@ -1,28 +1,40 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"os"
 
 	"github.com/spf13/cobra"
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/awsiamauth"
+	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/clustermanager"
+	"github.com/aws/eks-anywhere/pkg/config"
+	"github.com/aws/eks-anywhere/pkg/curatedpackages"
 	"github.com/aws/eks-anywhere/pkg/dependencies"
 	"github.com/aws/eks-anywhere/pkg/executables"
 	"github.com/aws/eks-anywhere/pkg/features"
 	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/registry"
+	"github.com/aws/eks-anywhere/pkg/registrymirror"
 	"github.com/aws/eks-anywhere/pkg/types"
 	"github.com/aws/eks-anywhere/pkg/validations"
 	"github.com/aws/eks-anywhere/pkg/validations/createvalidations"
 	"github.com/aws/eks-anywhere/pkg/workflow/management"
+	"github.com/aws/eks-anywhere/pkg/workflow/task/workload"
 	"github.com/aws/eks-anywhere/pkg/workflows"
 )
 
 type createClusterOptions struct {
 	clusterOptions
 	timeoutOptions
+	eventsOptions
+	dryRunOptions
+	signatureOptions
 	forceClean            bool
 	skipIpCheck           bool
 	hardwareCSVPath       string
@@ -38,13 +50,20 @@ var createClusterCmd = &cobra.Command{
 	Long:         "This command is used to create workload clusters",
 	PreRunE:      bindFlagsToViper,
 	SilenceUsage: true,
-	RunE:         cc.createCluster,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return cc.runWithEvents("create", func() error {
+			return cc.createCluster(cmd, args)
+		})
+	},
 }
 
 func init() {
 	createCmd.AddCommand(createClusterCmd)
 	applyClusterOptionFlags(createClusterCmd.Flags(), &cc.clusterOptions)
 	applyTimeoutFlags(createClusterCmd.Flags(), &cc.timeoutOptions)
+	applyEventsFlags(createClusterCmd.Flags(), &cc.eventsOptions)
+	applyDryRunFlags(createClusterCmd.Flags(), &cc.dryRunOptions)
+	applySignatureFlags(createClusterCmd.Flags(), &cc.signatureOptions)
 	applyTinkerbellHardwareFlag(createClusterCmd.Flags(), &cc.hardwareCSVPath)
 	createClusterCmd.Flags().StringVar(&cc.tinkerbellBootstrapIP, "tinkerbell-bootstrap-ip", "", "Override the local tinkerbell IP in the bootstrap cluster")
 	createClusterCmd.Flags().BoolVar(&cc.forceClean, "force-cleanup", false, "Force deletion of previously created bootstrap cluster")
@@ -116,7 +135,7 @@ func (cc *createClusterOptions) createCluster(cmd *cobra.Command, _ []string) er
 		WithCliConfig(cliConfig).
 		WithClusterManager(clusterSpec.Cluster, clusterManagerTimeoutOpts).
 		WithProvider(cc.fileName, clusterSpec.Cluster, cc.skipIpCheck, cc.hardwareCSVPath, cc.forceClean, cc.tinkerbellBootstrapIP).
-		WithGitOpsFlux(clusterSpec.Cluster, clusterSpec.FluxConfig, cliConfig).
+		WithGitOps(clusterSpec, cliConfig).
 		WithWriter().
 		WithEksdInstaller().
 		WithPackageInstaller(clusterSpec, cc.installPackages, cc.managementKubeconfig).
@@ -126,6 +145,10 @@ func (cc *createClusterOptions) createCluster(cmd *cobra.Command, _ []string) er
 		factory.WithNoTimeouts()
 	}
 
+	if cc.dryRun {
+		factory.WithDryRun()
+	}
+
 	deps, err := factory.Build(ctx)
 	if err != nil {
 		return err
@@ -136,7 +159,7 @@ func (cc *createClusterOptions) createCluster(cmd *cobra.Command, _ []string) er
 		deps.Bootstrapper,
 		deps.Provider,
 		deps.ClusterManager,
-		deps.GitOpsFlux,
+		deps.GitOps,
 		deps.Writer,
 		deps.EksdInstaller,
 		deps.PackageInstaller,
@@ -153,8 +176,28 @@ func (cc *createClusterOptions) createCluster(cmd *cobra.Command, _ []string) er
 		Provider:          deps.Provider,
 		CliConfig:         cliConfig,
 	}
+	imageVerifier, err := cc.imageVerifier(registrymirror.FromCluster(clusterSpec.Cluster))
+	if err != nil {
+		return err
+	}
+	if imageVerifier != nil {
+		validationOpts.ImageVerifier = imageVerifier
+		if cc.installPackages != "" {
+			validationOpts.PackageImages, err = readInstallPackagesImages(ctx, clusterSpec, cc.installPackages)
+			if err != nil {
+				return fmt.Errorf("reading the images of the packages to install: %v", err)
+			}
+		}
+	}
 	createValidations := createvalidations.New(validationOpts)
 
+	if cc.dryRun {
+		if err := createCluster.Validate(ctx, clusterSpec, createValidations); err != nil {
+			return err
+		}
+		return cc.renderCreate(ctx, factory, deps.Provider, clusterSpec, clusterManagerTimeoutOpts)
+	}
+
 	if features.UseNewWorkflows().IsActive() {
 		deps, err = factory.
 			WithCNIInstaller(clusterSpec, deps.Provider).
@@ -163,15 +206,23 @@ func (cc *createClusterOptions) createCluster(cmd *cobra.Command, _ []string) er
 			return err
 		}
 
+		clusterShim := clustermanager.NewCreateClusterShim(clusterSpec, deps.ClusterManager, deps.Provider)
 		wflw := &management.CreateCluster{
 			Spec:                          clusterSpec,
 			Bootstrapper:                  deps.Bootstrapper,
 			CreateBootstrapClusterOptions: deps.Provider,
 			CNIInstaller:                  deps.CNIInstaller,
-			Cluster:                       clustermanager.NewCreateClusterShim(clusterSpec, deps.ClusterManager, deps.Provider),
-			FS:                            deps.Writer,
+			Cluster:                       clusterShim,
+			CAPIInstaller:                 clusterShim,
+			ManagementMover:               clusterShim,
+			GitOpsInstaller: workload.GitOpsInstallerFunc(func(ctx context.Context, cluster *types.Cluster) error {
+				return deps.GitOps.InstallGitOps(ctx, cluster, clusterSpec, deps.Provider.DatacenterConfig(clusterSpec), deps.Provider.MachineConfigs(clusterSpec))
+			}),
+			PackagesInstaller: deps.PackageInstaller,
+			FS:                deps.Writer,
 		}
 		wflw.WithHookRegistrar(awsiamauth.NewHookRegistrar(deps.AwsIamAuth, clusterSpec))
+		wflw.WithHookRegistrar(validations.NewImageSignaturesHookRegistrar(clusterSpec, validationOpts.ImageVerifier, validationOpts.PackageImages))
 
 		// Not all provider implementations want to bind hooks so we explicitly check if they
 		// want to bind hooks before registering it.
@@ -187,3 +238,33 @@ func (cc *createClusterOptions) createCluster(cmd *cobra.Command, _ []string) er
 	cleanup(deps, &err)
 	return err
 }
+
+// readInstallPackagesImages returns the images of the curated packages declared in packagesFile,
+// from the packages bundle for the Kubernetes version of the cluster.
+func readInstallPackagesImages(ctx context.Context, clusterSpec *cluster.Spec, packagesFile string) ([]registry.Artifact, error) {
+	credentialStore := registry.NewCredentialStore()
+	if err := credentialStore.Init(); err != nil {
+		return nil, err
+	}
+
+	reader := curatedpackages.NewPackageReader(registry.NewCache(), credentialStore, os.Getenv(config.EksaRegionEnv))
+	return reader.ReadPackagesImages(ctx, *clusterSpec.VersionsBundle.VersionsBundle, packagesFile)
+}
+
+// renderCreate writes the manifests the create would apply, without creating the cluster.
+func (cc *createClusterOptions) renderCreate(ctx context.Context, factory *dependencies.Factory, provider providers.Provider, clusterSpec *cluster.Spec, timeoutOpts *dependencies.ClusterManagerTimeoutOptions) error {
+	deps, err := factory.
+		WithDryRunRenderer(clusterSpec, provider, cc.outputDirectory(clusterSpec.Cluster.Name), cc.installPackages, timeoutOpts).
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+
+	paths, err := deps.DryRunRenderer.RenderCreate(ctx, getManagementCluster(clusterSpec), clusterSpec)
+	if err != nil {
+		return fmt.Errorf("rendering manifests: %v", err)
+	}
+	logDryRunManifests(paths)
+
+	return nil
+}
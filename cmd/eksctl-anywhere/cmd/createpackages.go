@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"os"
 
 	"github.com/spf13/cobra"
 
@@ -17,6 +18,7 @@ type createPackageOptions struct {
 	// existing cluster.
 	kubeConfig      string
 	bundlesOverride string
+	dryRun          bool
 }
 
 var cpo = &createPackageOptions{}
@@ -30,6 +32,8 @@ func init() {
 		"Path to an optional kubeconfig file to use.")
 	createPackagesCommand.Flags().StringVar(&cpo.bundlesOverride, "bundles-override", "",
 		"Override default Bundles manifest (not recommended)")
+	createPackagesCommand.Flags().BoolVar(&cpo.dryRun, dryRunFlag, false,
+		"Validate the packages and print the changes without creating them")
 
 	err := createPackagesCommand.MarkFlagRequired("filename")
 	if err != nil {
@@ -65,6 +69,13 @@ func createPackages(ctx context.Context) error {
 		deps.Kubectl,
 	)
 
+	if err = packages.ReviewPackages(ctx, cpo.fileName, kubeConfig, os.Stdout); err != nil {
+		return err
+	}
+	if cpo.dryRun {
+		return nil
+	}
+
 	curatedpackages.PrintLicense()
 	err = packages.CreatePackages(ctx, cpo.fileName, kubeConfig)
 	if err != nil {
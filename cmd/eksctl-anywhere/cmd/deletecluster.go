@@ -15,6 +15,7 @@ import (
 
 type deleteClusterOptions struct {
 	clusterOptions
+	eventsOptions
 	wConfig               string
 	forceCleanup          bool
 	hardwareFileName      string
@@ -30,13 +31,15 @@ var deleteClusterCmd = &cobra.Command{
 	PreRunE:      bindFlagsToViper,
 	SilenceUsage: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		if err := dc.validate(cmd.Context(), args); err != nil {
-			return err
-		}
-		if err := dc.deleteCluster(cmd.Context()); err != nil {
-			return fmt.Errorf("failed to delete cluster: %v", err)
-		}
-		return nil
+		return dc.runWithEvents("delete", func() error {
+			if err := dc.validate(cmd.Context(), args); err != nil {
+				return err
+			}
+			if err := dc.deleteCluster(cmd.Context()); err != nil {
+				return fmt.Errorf("failed to delete cluster: %v", err)
+			}
+			return nil
+		})
 	},
 }
 
@@ -47,6 +50,7 @@ func init() {
 	deleteClusterCmd.Flags().BoolVar(&dc.forceCleanup, "force-cleanup", false, "Force deletion of previously created bootstrap cluster")
 	deleteClusterCmd.Flags().StringVar(&dc.managementKubeconfig, "kubeconfig", "", "kubeconfig file pointing to a management cluster")
 	deleteClusterCmd.Flags().StringVar(&dc.bundlesOverride, "bundles-override", "", "Override default Bundles manifest (not recommended)")
+	applyEventsFlags(deleteClusterCmd.Flags(), &dc.eventsOptions)
 }
 
 func (dc *deleteClusterOptions) validate(ctx context.Context, args []string) error {
@@ -95,7 +99,7 @@ func (dc *deleteClusterOptions) deleteCluster(ctx context.Context) error {
 		WithCliConfig(cliConfig).
 		WithClusterManager(clusterSpec.Cluster, nil).
 		WithProvider(dc.fileName, clusterSpec.Cluster, cc.skipIpCheck, dc.hardwareFileName, false, dc.tinkerbellBootstrapIP).
-		WithGitOpsFlux(clusterSpec.Cluster, clusterSpec.FluxConfig, cliConfig).
+		WithGitOps(clusterSpec, cliConfig).
 		WithWriter().
 		Build(ctx)
 	if err != nil {
@@ -107,7 +111,7 @@ func (dc *deleteClusterOptions) deleteCluster(ctx context.Context) error {
 		deps.Bootstrapper,
 		deps.Provider,
 		deps.ClusterManager,
-		deps.GitOpsFlux,
+		deps.GitOps,
 		deps.Writer,
 	)
 
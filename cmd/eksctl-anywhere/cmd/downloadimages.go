@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"path/filepath"
 	"strings"
@@ -14,6 +15,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/docker"
 	"github.com/aws/eks-anywhere/pkg/executables"
 	"github.com/aws/eks-anywhere/pkg/helm"
+	"github.com/aws/eks-anywhere/pkg/registry"
 	"github.com/aws/eks-anywhere/pkg/tar"
 	"github.com/aws/eks-anywhere/pkg/version"
 )
@@ -36,7 +38,7 @@ Kubernetes versions.`,
 func init() {
 	downloadCmd.AddCommand(downloadImagesCmd)
 
-	downloadImagesCmd.Flags().StringVarP(&downloadImagesRunner.outputFile, "output", "o", "", "Output tarball containing all downloaded images")
+	downloadImagesCmd.Flags().StringVarP(&downloadImagesRunner.outputFile, "output", "o", "", "Output tarball containing all downloaded images, or oci:<directory> to copy them to an OCI image layout without docker")
 	if err := downloadImagesCmd.MarkFlagRequired("output"); err != nil {
 		log.Fatalf("Cannot mark 'output' flag as required: %s", err)
 	}
@@ -45,11 +47,13 @@ func init() {
 	downloadImagesCmd.Flag("include-packages").Deprecated = "use copy packages command"
 	downloadImagesCmd.Flags().StringVarP(&downloadImagesRunner.bundlesOverride, "bundles-override", "", "", "Override default Bundles manifest (not recommended)")
 	downloadImagesCmd.Flags().BoolVar(&downloadImagesRunner.insecure, "insecure", false, "Flag to indicate skipping TLS verification while downloading helm charts")
+	applySignatureFlags(downloadImagesCmd.Flags(), &downloadImagesRunner.signatureOptions)
 }
 
 var downloadImagesRunner = downloadImagesCommand{}
 
 type downloadImagesCommand struct {
+	signatureOptions
 	outputFile      string
 	bundlesOverride string
 	includePackages bool
@@ -57,6 +61,19 @@ type downloadImagesCommand struct {
 }
 
 func (c downloadImagesCommand) Run(ctx context.Context) error {
+	verifier, err := c.signatureVerifier()
+	if err != nil {
+		return err
+	}
+
+	if registry.IsOCILayout(c.outputFile) {
+		return c.downloadToOCILayout(ctx, verifier)
+	}
+
+	if verifier != nil {
+		return fmt.Errorf("--%s requires an oci: output, image signatures are not kept in tarballs", signaturePublicKeyFlag)
+	}
+
 	factory := dependencies.NewFactory()
 	helmOpts := []executables.HelmOpt{}
 	if c.insecure {
@@ -101,6 +118,49 @@ func (c downloadImagesCommand) Run(ctx context.Context) error {
 	return downloadArtifacts.Run(ctx)
 }
 
+// downloadToOCILayout copies the images, charts and packages bundles straight from their
+// registries to an OCI image layout directory, keeping their digests. With a verifier, unsigned
+// artifacts are refused and the signatures are copied along with the artifacts.
+func (c downloadImagesCommand) downloadToOCILayout(ctx context.Context, verifier *registry.SignatureVerifier) error {
+	deps, err := dependencies.NewFactory().
+		WithFileReader().
+		WithManifestReader().
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer deps.Close(ctx)
+
+	b, err := artifacts.ReadBundles(deps.ManifestReader, deps.FileReader, version.Get(), c.bundlesOverride)
+	if err != nil {
+		return err
+	}
+
+	credentialStore := registry.NewCredentialStore()
+	if err = credentialStore.Init(); err != nil {
+		return err
+	}
+
+	cache := registry.NewCache()
+	layout, err := cache.Get(registry.NewStorageContext(c.outputFile, credentialStore, nil, c.insecure))
+	if err != nil {
+		return err
+	}
+
+	opts := []registry.MirrorOpt{registry.WithStateFile(downloadImagesStateFile)}
+	if verifier != nil {
+		opts = append(opts, registry.WithVerifier(verifier))
+	}
+
+	mirror := artifacts.Mirror{
+		Reader:         deps.ManifestReader,
+		Bundles:        b,
+		ArtifactMirror: registry.NewMirror(layout, cache.Sources(credentialStore, nil, c.insecure), opts...),
+	}
+
+	return mirror.Run(ctx)
+}
+
 type packager interface {
 	UnPackage(orgFile, dstFolder string) error
 	Package(sourceFolder, dstFile string) error
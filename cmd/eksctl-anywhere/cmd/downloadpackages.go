@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"context"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/cmd/eksctl-anywhere/cmd/internal/commands/artifacts"
+	"github.com/aws/eks-anywhere/pkg/config"
+	"github.com/aws/eks-anywhere/pkg/curatedpackages"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/registry"
+	"github.com/aws/eks-anywhere/pkg/version"
+)
+
+var downloadPackagesCmd = &cobra.Command{
+	Use:   "packages",
+	Short: "Download a curated packages bundle with its charts and images to disk",
+	Long: `Creates a tarball containing a curated packages bundle and all the helm charts
+and images of its packages, to import them with import packages into a registry
+without internet access.`,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return downloadPackagesRunner.Run(cmd.Context())
+	},
+}
+
+func init() {
+	downloadCmd.AddCommand(downloadPackagesCmd)
+
+	downloadPackagesCmd.Flags().StringVarP(&downloadPackagesRunner.bundleVersion, "bundle", "b", "", "Version of the curated packages bundle to download, e.g. v1-27-125")
+	if err := downloadPackagesCmd.MarkFlagRequired("bundle"); err != nil {
+		log.Fatalf("Cannot mark 'bundle' flag as required: %s", err)
+	}
+	downloadPackagesCmd.Flags().StringVarP(&downloadPackagesRunner.outputFile, "output", "o", "", "Output tarball containing the packages bundle, charts and images")
+	if err := downloadPackagesCmd.MarkFlagRequired("output"); err != nil {
+		log.Fatalf("Cannot mark 'output' flag as required: %s", err)
+	}
+	downloadPackagesCmd.Flags().StringVarP(&downloadPackagesRunner.bundlesOverride, "bundles-override", "", "", "Override default Bundles manifest (not recommended)")
+	downloadPackagesCmd.Flags().StringVarP(&downloadPackagesRunner.awsRegion, "aws-region", "", os.Getenv(config.EksaRegionEnv), "Region to download images from")
+	downloadPackagesCmd.Flags().BoolVar(&downloadPackagesRunner.insecure, "insecure", false, "Skip TLS verification while downloading charts and images")
+}
+
+var downloadPackagesRunner = downloadPackagesCommand{}
+
+type downloadPackagesCommand struct {
+	bundleVersion   string
+	outputFile      string
+	bundlesOverride string
+	awsRegion       string
+	insecure        bool
+}
+
+func (c downloadPackagesCommand) Run(ctx context.Context) error {
+	deps, err := dependencies.NewFactory().
+		WithFileReader().
+		WithManifestReader().
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer deps.Close(ctx)
+
+	b, err := artifacts.ReadBundles(deps.ManifestReader, deps.FileReader, version.Get(), c.bundlesOverride)
+	if err != nil {
+		return err
+	}
+
+	bundleURI, err := curatedpackages.GetPackageBundleRefForVersion(b, c.bundleVersion)
+	if err != nil {
+		return err
+	}
+
+	credentialStore := registry.NewCredentialStore()
+	if err = credentialStore.Init(); err != nil {
+		return err
+	}
+
+	downloadFolder := "tmp-eks-a-packages-download"
+	cache := registry.NewCache()
+	layoutDir := registry.OCILayoutScheme + filepath.Join(downloadFolder, artifacts.PackagesLayoutFolder)
+	layout, err := cache.Get(registry.NewStorageContext(layoutDir, credentialStore, nil, c.insecure))
+	if err != nil {
+		return err
+	}
+
+	downloadPackages := artifacts.DownloadPackages{
+		Reader:    curatedpackages.NewPackageReader(cache, credentialStore, c.awsRegion),
+		BundleURI: bundleURI,
+		ArtifactMirror: registry.NewMirror(layout, cache.Sources(credentialStore, nil, c.insecure),
+			registry.WithStateFile(downloadPackagesStateFile),
+		),
+		Packager:          packagerForFile(c.outputFile),
+		TmpDownloadFolder: downloadFolder,
+		DstFile:           c.outputFile,
+	}
+
+	return downloadPackages.Run(ctx)
+}
@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/certificates"
+)
+
+type getCertificatesOptions struct {
+	nodeSSHOptions
+	output string
+}
+
+var gco = &getCertificatesOptions{}
+
+var getCertificatesCmd = &cobra.Command{
+	Use:          "certificates -f <cluster-config-file>",
+	Aliases:      []string{"certificate", "certs"},
+	Short:        "Get the certificates of the control plane and etcd nodes",
+	Long:         "This command lists the certificates in every control plane and etcd node of a cluster with their expiration dates",
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := gco.getCertificates(cmd.Context()); err != nil {
+			return fmt.Errorf("failed to get certificates: %v", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	getCmd.AddCommand(getCertificatesCmd)
+	applyNodeSSHFlags(getCertificatesCmd.Flags(), &gco.nodeSSHOptions)
+	getCertificatesCmd.Flags().StringVarP(&gco.output, outputFlagName, "o", outputDefault, "Output format: text|json")
+	if err := getCertificatesCmd.MarkFlagRequired("filename"); err != nil {
+		log.Fatalf("Error marking filename flag as required: %v", err)
+	}
+}
+
+func (o *getCertificatesOptions) getCertificates(ctx context.Context) error {
+	clusterSpec, err := newClusterSpec(o.clusterOptions)
+	if err != nil {
+		return err
+	}
+
+	deps, runner, err := o.dependencies(ctx, clusterSpec)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	certs, err := certificates.NewInspector(deps.NodeLister, runner).Certificates(ctx, getManagementCluster(clusterSpec), clusterSpec)
+	if err != nil {
+		return err
+	}
+
+	serialized, err := serializeCertificates(certs, o.output, time.Now())
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(serialized)
+	return nil
+}
+
+func serializeCertificates(certs []certificates.Certificate, outputFormat string, now time.Time) (string, error) {
+	switch outputFormat {
+	case outputText:
+		return serializeCertificatesToText(certs, now)
+	case outputJson:
+		if certs == nil {
+			certs = []certificates.Certificate{}
+		}
+		out, err := json.Marshal(certs)
+		if err != nil {
+			return "", fmt.Errorf("failed serializing certificates to json: %v", err)
+		}
+		return string(out) + "\n", nil
+	default:
+		return "", fmt.Errorf("invalid output format [%s]", outputFormat)
+	}
+}
+
+func serializeCertificatesToText(certs []certificates.Certificate, now time.Time) (string, error) {
+	buffer := bytes.Buffer{}
+	w := tabwriter.NewWriter(&buffer, 10, 4, 3, ' ', 0)
+	fmt.Fprintln(w, "NODE\tROLE\tCERTIFICATE\tEXPIRES\tRESIDUAL TIME\tCA")
+	for _, c := range certs {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%t\n", c.Node, c.Role, c.Name, c.NotAfter.UTC().Format(time.RFC3339), residualTime(c.NotAfter, now), c.IsCA)
+	}
+	if err := w.Flush(); err != nil {
+		return "", fmt.Errorf("failed flushing table writer: %v", err)
+	}
+
+	return buffer.String(), nil
+}
+
+// residualTime formats the time left until notAfter in days, or hours when it is less than a day.
+func residualTime(notAfter, now time.Time) string {
+	left := notAfter.Sub(now)
+	switch {
+	case left <= 0:
+		return "<expired>"
+	case left < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(left.Hours()))
+	default:
+		return fmt.Sprintf("%dd", int(left.Hours()/24))
+	}
+}
@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/gitops/drift"
+)
+
+type getDriftOptions struct {
+	clusterOptions
+	setCondition bool
+	output       string
+}
+
+var gdo = &getDriftOptions{}
+
+var getDriftCmd = &cobra.Command{
+	Use:          "drift -f <cluster-config-file>",
+	Short:        "Compare the EKS-A objects of a cluster with the GitOps repository",
+	Long:         "This command reports the fields of the Cluster, datacenter, machine config and identity provider objects in the GitOps repository that have a different value in the management cluster",
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := gdo.getDrift(cmd.Context()); err != nil {
+			return fmt.Errorf("failed to get gitops drift: %v", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	getCmd.AddCommand(getDriftCmd)
+	applyClusterOptionFlags(getDriftCmd.Flags(), &gdo.clusterOptions)
+	getDriftCmd.Flags().BoolVar(&gdo.setCondition, "set-condition", false, "Set the GitOpsInSync condition on the Cluster object with the result")
+	getDriftCmd.Flags().StringVarP(&gdo.output, outputFlagName, "o", outputDefault, "Output format: text|json")
+	if err := getDriftCmd.MarkFlagRequired("filename"); err != nil {
+		log.Fatalf("Error marking filename flag as required: %v", err)
+	}
+}
+
+func (o *getDriftOptions) getDrift(ctx context.Context) error {
+	clusterSpec, err := newClusterSpec(o.clusterOptions)
+	if err != nil {
+		return err
+	}
+
+	fluxConfig := gitOpsRepositoryConfig(clusterSpec)
+	if fluxConfig == nil {
+		return fmt.Errorf("cluster %s does not have a GitOps configuration", clusterSpec.Cluster.Name)
+	}
+	// The Argo CD repository has the same layout as the Flux one.
+	clusterSpec.FluxConfig = fluxConfig
+
+	cliConfig := buildCliConfig(clusterSpec)
+	dirs, err := o.directoriesToMount(clusterSpec, cliConfig)
+	if err != nil {
+		return err
+	}
+
+	deps, err := dependencies.ForSpec(ctx, clusterSpec).WithExecutableMountDirs(dirs...).
+		WithCliConfig(cliConfig).
+		WithKubectl().
+		WithGitOpsFlux(clusterSpec.Cluster, fluxConfig, cliConfig).
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	managementCluster := getManagementCluster(clusterSpec)
+	detector := drift.NewDetector(deps.GitOpsFlux, deps.Kubectl)
+	report, err := detector.Detect(ctx, managementCluster, clusterSpec)
+	if err != nil {
+		return err
+	}
+
+	if o.setCondition {
+		if err := detector.SetCondition(ctx, managementCluster, clusterSpec, report); err != nil {
+			return err
+		}
+	}
+
+	serialized, err := serializeDriftReport(report, o.output)
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(serialized)
+	return nil
+}
+
+func serializeDriftReport(report *drift.Report, outputFormat string) (string, error) {
+	switch outputFormat {
+	case outputText:
+		return serializeDriftReportToText(report)
+	case outputJson:
+		out, err := json.Marshal(report)
+		if err != nil {
+			return "", fmt.Errorf("failed serializing drift report to json: %v", err)
+		}
+		return string(out) + "\n", nil
+	default:
+		return "", fmt.Errorf("invalid output format [%s]", outputFormat)
+	}
+}
+
+// serializeDriftReportToText prints a row per drifted field, and a single row when nothing drifted.
+func serializeDriftReportToText(report *drift.Report) (string, error) {
+	buffer := bytes.Buffer{}
+	w := tabwriter.NewWriter(&buffer, 10, 4, 3, ' ', 0)
+	fmt.Fprintln(w, "KIND\tNAME\tFIELD\tGIT\tCLUSTER")
+	if !report.Drifted() {
+		fmt.Fprintf(w, "%s\t%s\t<none>\t\t\n", "Cluster", report.Cluster)
+	}
+	for _, o := range report.Objects {
+		for _, f := range o.Fields {
+			field := f.Path
+			if field == "" {
+				field = "<object>"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", o.Kind, o.Name, field, f.Git, f.Cluster)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return "", fmt.Errorf("failed flushing table writer: %v", err)
+	}
+
+	return buffer.String(), nil
+}
@@ -0,0 +1,178 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/config"
+	"github.com/aws/eks-anywhere/pkg/curatedpackages"
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/imagereport"
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/registry"
+	"github.com/aws/eks-anywhere/pkg/registrymirror"
+	"github.com/aws/eks-anywhere/pkg/validations"
+	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+const trivyDBFlag = "trivy-db"
+
+type getImageReportOptions struct {
+	clusterOptions
+	kubeConfig string
+	trivyDB    string
+	output     string
+}
+
+var giro = &getImageReportOptions{}
+
+var getImageReportCmd = &cobra.Command{
+	Use:          "image-report -f <cluster-config-file> --trivy-db <trivy-db-file>",
+	Short:        "Get the vulnerabilities of the images used by a cluster",
+	Long:         "This command lists the images of the versions bundle of a cluster, of its installed curated packages and of its running pods, with the vulnerabilities of the packages in their SBOMs found in an offline Trivy DB",
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := giro.getImageReport(cmd.Context()); err != nil {
+			return fmt.Errorf("failed to get image report: %v", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	getCmd.AddCommand(getImageReportCmd)
+	getImageReportCmd.Flags().StringVarP(&giro.fileName, "filename", "f", "", "Filename that contains EKS-A cluster configuration")
+	getImageReportCmd.Flags().StringVar(&giro.bundlesOverride, "bundles-override", "", "Override default Bundles manifest (not recommended)")
+	getImageReportCmd.Flags().StringVar(&giro.kubeConfig, "kubeconfig", "", "Kubeconfig of the cluster, defaults to the one generated by EKS Anywhere")
+	getImageReportCmd.Flags().StringVar(&giro.trivyDB, trivyDBFlag, "", "Trivy DB file to look up the vulnerabilities, e.g. ~/.cache/trivy/db/trivy.db")
+	getImageReportCmd.Flags().StringVarP(&giro.output, outputFlagName, "o", outputDefault, "Output format: text|json")
+	for _, flag := range []string{"filename", trivyDBFlag} {
+		if err := getImageReportCmd.MarkFlagRequired(flag); err != nil {
+			log.Fatalf("Error marking %s flag as required: %v", flag, err)
+		}
+	}
+}
+
+func (o *getImageReportOptions) getImageReport(ctx context.Context) error {
+	clusterSpec, err := newClusterSpec(o.clusterOptions)
+	if err != nil {
+		return err
+	}
+
+	kubeConfig, err := kubeconfig.ResolveAndValidateFilename(getKubeconfigPath(clusterSpec.Cluster.Name, o.kubeConfig), "")
+	if err != nil {
+		return err
+	}
+
+	db, err := imagereport.OpenTrivyDB(o.trivyDB)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	deps, err := NewDependenciesForPackages(ctx, WithMountPaths(kubeConfig), WithBundlesOverride(o.bundlesOverride))
+	if err != nil {
+		return fmt.Errorf("unable to initialize executables: %v", err)
+	}
+	defer close(ctx, deps)
+
+	mirror := registrymirror.FromCluster(clusterSpec.Cluster)
+	images := imagereport.NewImages()
+	for _, image := range validations.ClusterImages(clusterSpec) {
+		images.Add(image.VersionedImage(), imagereport.BundleSource)
+	}
+
+	packageImages, err := installedPackageImages(ctx, deps.Kubectl, kubeConfig, clusterSpec.Cluster.Name, clusterSpec.VersionsBundle.VersionsBundle)
+	if err != nil {
+		logger.Info("Warning: skipping the curated packages images", "error", err)
+	}
+	for name, uris := range packageImages {
+		for _, uri := range uris {
+			images.Add(mirror.ReplaceRegistry(uri), imagereport.PackageSource(name))
+		}
+	}
+
+	pods, err := deps.Kubectl.GetPods(ctx, executables.WithKubeconfig(kubeConfig), executables.WithAllNamespaces())
+	if err != nil {
+		return err
+	}
+	images.AddPods(pods)
+
+	sources, err := registrySources(mirror)
+	if err != nil {
+		return err
+	}
+	reports, err := imagereport.NewReporter(db, sources).Report(ctx, images)
+	if err != nil {
+		return err
+	}
+
+	serialized, err := serializeImageReports(reports, o.output)
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(serialized)
+	return nil
+}
+
+func installedPackageImages(ctx context.Context, kubectl curatedpackages.KubectlRunner, kubeConfig, clusterName string, vb *releasev1.VersionsBundle) (map[string][]string, error) {
+	reader := curatedpackages.NewPackageReader(registry.NewCache(), registry.NewCredentialStore(), os.Getenv(config.EksaRegionEnv))
+	imageRegistry, err := reader.ImageRegistry(*vb)
+	if err != nil {
+		return nil, err
+	}
+	return curatedpackages.NewBundleReader(kubeConfig, clusterName, kubectl, nil, nil).InstalledPackageImages(ctx, imageRegistry)
+}
+
+func serializeImageReports(reports []imagereport.ImageReport, outputFormat string) (string, error) {
+	switch outputFormat {
+	case outputText:
+		return serializeImageReportsToText(reports)
+	case outputJson:
+		out, err := json.Marshal(reports)
+		if err != nil {
+			return "", fmt.Errorf("failed serializing image report to json: %v", err)
+		}
+		return string(out) + "\n", nil
+	default:
+		return "", fmt.Errorf("invalid output format [%s]", outputFormat)
+	}
+}
+
+// serializeImageReportsToText prints a row per vulnerability, and a single row for the images without
+// any vulnerability with why they couldn't be scanned, if so.
+func serializeImageReportsToText(reports []imagereport.ImageReport) (string, error) {
+	buffer := bytes.Buffer{}
+	w := tabwriter.NewWriter(&buffer, 10, 4, 3, ' ', 0)
+	fmt.Fprintln(w, "IMAGE\tSOURCE\tVULNERABILITY\tSEVERITY\tPACKAGE\tINSTALLED\tFIXED")
+	for _, r := range reports {
+		source := strings.Join(r.Sources, ",")
+		switch {
+		case r.Error != "":
+			fmt.Fprintf(w, "%s\t%s\t<error: %s>\t\t\t\t\n", r.Image, source, r.Error)
+		case !r.SBOM:
+			fmt.Fprintf(w, "%s\t%s\t<no SBOM>\t\t\t\t\n", r.Image, source)
+		case len(r.Vulnerabilities) == 0:
+			fmt.Fprintf(w, "%s\t%s\t<none>\t\t\t\t\n", r.Image, source)
+		}
+		for _, v := range r.Vulnerabilities {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", r.Image, source, v.ID, v.Severity, v.Package, v.InstalledVersion, v.FixedVersion)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return "", fmt.Errorf("failed flushing table writer: %v", err)
+	}
+
+	return buffer.String(), nil
+}
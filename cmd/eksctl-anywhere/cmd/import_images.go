@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"path/filepath"
 
@@ -16,8 +17,10 @@ import (
 	"github.com/aws/eks-anywhere/pkg/executables"
 	"github.com/aws/eks-anywhere/pkg/helm"
 	"github.com/aws/eks-anywhere/pkg/manifests/bundles"
+	"github.com/aws/eks-anywhere/pkg/registry"
 	"github.com/aws/eks-anywhere/pkg/registrymirror"
 	"github.com/aws/eks-anywhere/pkg/types"
+	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
 )
 
 // imagesCmd represents the images command.
@@ -36,7 +39,7 @@ Use this command in conjunction with download images, passing it output tarball
 func init() {
 	importCmd.AddCommand(importImagesCmd)
 
-	importImagesCmd.Flags().StringVarP(&importImagesCommand.InputFile, "input", "i", "", "Input tarball containing all images and charts to import")
+	importImagesCmd.Flags().StringVarP(&importImagesCommand.InputFile, "input", "i", "", "Input tarball containing all images and charts to import, or oci:<directory> to import them from an OCI image layout without docker")
 	if err := importImagesCmd.MarkFlagRequired("input"); err != nil {
 		log.Fatalf("Cannot mark 'input' as required: %s", err)
 	}
@@ -51,6 +54,7 @@ func init() {
 	importImagesCmd.Flags().BoolVar(&importImagesCommand.includePackages, "include-packages", false, "Flag to indicate inclusion of curated packages in imported images")
 	importImagesCmd.Flag("include-packages").Deprecated = "use copy packages command"
 	importImagesCmd.Flags().BoolVar(&importImagesCommand.insecure, "insecure", false, "Flag to indicate skipping TLS verification while pushing helm charts and bundles")
+	applySignatureFlags(importImagesCmd.Flags(), &importImagesCommand.signatureOptions)
 }
 
 var importImagesCommand = ImportImagesCommand{}
@@ -61,6 +65,7 @@ type ImportImagesCommand struct {
 	BundlesFile      string
 	includePackages  bool
 	insecure         bool
+	signatureOptions
 }
 
 func (c ImportImagesCommand) Call(ctx context.Context) error {
@@ -82,6 +87,19 @@ func (c ImportImagesCommand) Call(ctx context.Context) error {
 		return err
 	}
 
+	verifier, err := c.signatureVerifier()
+	if err != nil {
+		return err
+	}
+
+	if registry.IsOCILayout(c.InputFile) {
+		return c.importFromOCILayout(ctx, deps.ManifestReader, bundle, username, password, verifier)
+	}
+
+	if verifier != nil {
+		return fmt.Errorf("--%s requires an oci: input, image signatures are not kept in tarballs", signaturePublicKeyFlag)
+	}
+
 	artifactsFolder := "tmp-eks-a-artifacts"
 	dockerClient := executables.BuildDockerExecutable()
 	toolsImageFile := filepath.Join(artifactsFolder, eksaToolsImageTarFile)
@@ -151,3 +169,42 @@ func (c ImportImagesCommand) Call(ctx context.Context) error {
 
 	return importArtifacts.Run(context.WithValue(ctx, types.InsecureRegistry, c.insecure))
 }
+
+// importFromOCILayout copies the images, charts and packages bundles from an OCI image
+// layout directory to the registry, keeping their digests. With a verifier, unsigned or
+// tampered artifacts are refused.
+func (c ImportImagesCommand) importFromOCILayout(ctx context.Context, reader artifacts.Reader, bundle *releasev1.Bundles, username, password string, verifier *registry.SignatureVerifier) error {
+	credentialStore := registry.NewCredentialStore()
+	if err := credentialStore.Init(); err != nil {
+		return err
+	}
+	credentialStore.SetCredential(c.RegistryEndpoint, username, password)
+
+	cache := registry.NewCache()
+	layout, err := cache.Get(registry.NewStorageContext(c.InputFile, credentialStore, nil, c.insecure))
+	if err != nil {
+		return err
+	}
+
+	dst, err := cache.Get(registry.NewStorageContext(c.RegistryEndpoint, credentialStore, nil, c.insecure))
+	if err != nil {
+		return fmt.Errorf("error with repository %s: %v", c.RegistryEndpoint, err)
+	}
+
+	sources := func(registry.Artifact) (registry.StorageClient, error) {
+		return layout, nil
+	}
+
+	opts := []registry.MirrorOpt{registry.WithStateFile(importImagesStateFile)}
+	if verifier != nil {
+		opts = append(opts, registry.WithVerifier(verifier))
+	}
+
+	mirror := artifacts.Mirror{
+		Reader:         reader,
+		Bundles:        bundle,
+		ArtifactMirror: registry.NewMirror(dst, sources, opts...),
+	}
+
+	return mirror.Run(ctx)
+}
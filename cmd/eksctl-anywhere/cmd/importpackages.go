@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/cmd/eksctl-anywhere/cmd/internal/commands/artifacts"
+	"github.com/aws/eks-anywhere/pkg/config"
+	"github.com/aws/eks-anywhere/pkg/curatedpackages"
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+	"github.com/aws/eks-anywhere/pkg/registry"
+)
+
+var importPackagesCmd = &cobra.Command{
+	Use:   "packages",
+	Short: "Import a curated packages bundle with its charts and images to a registry from a tarball",
+	Long: `Import a curated packages bundle and all the helm charts and images of its packages into a registry.
+Use this command in conjunction with download packages, passing it output tarball as input to this command.
+When a kubeconfig is provided, the bundle is also registered with the package controller of the cluster.`,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return importPackagesCommand.Call(cmd.Context())
+	},
+}
+
+func init() {
+	importCmd.AddCommand(importPackagesCmd)
+
+	importPackagesCmd.Flags().StringVarP(&importPackagesCommand.inputFile, "input", "i", "", "Input tarball created by download packages")
+	if err := importPackagesCmd.MarkFlagRequired("input"); err != nil {
+		log.Fatalf("Cannot mark 'input' as required: %s", err)
+	}
+	importPackagesCmd.Flags().StringVarP(&importPackagesCommand.registryEndpoint, "registry", "r", "", "Registry where to import the packages bundle, charts and images")
+	if err := importPackagesCmd.MarkFlagRequired("registry"); err != nil {
+		log.Fatalf("Cannot mark 'registry' as required: %s", err)
+	}
+	importPackagesCmd.Flags().StringVar(&importPackagesCommand.kubeConfig, "kubeconfig", "", "Kubeconfig of the cluster to register the packages bundle with. The bundle is not registered if not set")
+	importPackagesCmd.Flags().StringVar(&importPackagesCommand.dstCert, "dst-cert", "", "TLS certificate for destination registry")
+	importPackagesCmd.Flags().BoolVar(&importPackagesCommand.insecure, "insecure", false, "Skip TLS verification while pushing charts and images")
+}
+
+var importPackagesCommand = importPackagesOptions{}
+
+type importPackagesOptions struct {
+	inputFile        string
+	registryEndpoint string
+	kubeConfig       string
+	dstCert          string
+	insecure         bool
+}
+
+func (c importPackagesOptions) Call(ctx context.Context) error {
+	username, password, err := config.ReadCredentials()
+	if err != nil {
+		return err
+	}
+
+	credentialStore := registry.NewCredentialStore()
+	if err = credentialStore.Init(); err != nil {
+		return err
+	}
+	credentialStore.SetCredential(c.registryEndpoint, username, password)
+
+	certificates, err := registry.GetCertificates(c.dstCert)
+	if err != nil {
+		return err
+	}
+
+	cache := registry.NewCache()
+	dst, err := cache.Get(registry.NewStorageContext(c.registryEndpoint, credentialStore, certificates, c.insecure))
+	if err != nil {
+		return fmt.Errorf("error with repository %s: %v", c.registryEndpoint, err)
+	}
+
+	artifactsFolder := "tmp-eks-a-packages"
+	layoutContext := registry.NewStorageContext(registry.OCILayoutScheme+filepath.Join(artifactsFolder, artifacts.PackagesLayoutFolder), credentialStore, nil, false)
+	// The layout is only read once the input file is unpackaged.
+	sources := func(registry.Artifact) (registry.StorageClient, error) {
+		return cache.Get(layoutContext)
+	}
+
+	importPackages := artifacts.ImportPackages{
+		Reader:             curatedpackages.NewPackageReader(cache, credentialStore, ""),
+		UnPackager:         packagerForFile(c.inputFile),
+		InputFile:          c.inputFile,
+		TmpArtifactsFolder: artifactsFolder,
+		ChartsMirror: registry.NewMirror(dst, sources,
+			registry.WithStateFile(registry.PhaseStateFile(importPackagesStateFile, "charts")),
+			registry.WithDestinationProject(""),
+		),
+		ImagesMirror: registry.NewMirror(dst, sources,
+			registry.WithStateFile(registry.PhaseStateFile(importPackagesStateFile, "images")),
+			registry.WithDestinationProject("curated-packages/"),
+		),
+	}
+
+	if c.kubeConfig != "" {
+		kubeConfig, err := kubeconfig.ResolveAndValidateFilename(c.kubeConfig, "")
+		if err != nil {
+			return err
+		}
+
+		deps, err := NewDependenciesForPackages(ctx, WithMountPaths(kubeConfig))
+		if err != nil {
+			return fmt.Errorf("unable to initialize executables: %v", err)
+		}
+		defer deps.Close(ctx)
+
+		importPackages.BundleRegistrar = curatedpackages.NewBundleReader(kubeConfig, "", deps.Kubectl, nil, nil)
+	}
+
+	return importPackages.Run(ctx)
+}
@@ -53,18 +53,9 @@ func (d Download) Run(ctx context.Context) error {
 		return fmt.Errorf("creating tmp artifact download folder: %v", err)
 	}
 
-	var b *releasev1.Bundles
-	var err error
-	if d.BundlesOverride != "" {
-		b, err = bundles.Read(d.FileReader, d.BundlesOverride)
-		if err != nil {
-			return fmt.Errorf("reading bundles override: %v", err)
-		}
-	} else {
-		b, err = d.Reader.ReadBundlesForVersion(d.Version.GitVersion)
-		if err != nil {
-			return fmt.Errorf("reading bundles for version %s: %v", d.Version.GitVersion, err)
-		}
+	b, err := ReadBundles(d.Reader, d.FileReader, d.Version, d.BundlesOverride)
+	if err != nil {
+		return err
 	}
 
 	toolsImage := b.DefaultEksAToolsImage().VersionedImage()
@@ -101,6 +92,23 @@ func (d Download) Run(ctx context.Context) error {
 	return nil
 }
 
+// ReadBundles reads the bundles from bundlesOverride if set or, if not, the bundles for the version.
+func ReadBundles(reader Reader, fileReader *files.Reader, version version.Info, bundlesOverride string) (*releasev1.Bundles, error) {
+	if bundlesOverride != "" {
+		b, err := bundles.Read(fileReader, bundlesOverride)
+		if err != nil {
+			return nil, fmt.Errorf("reading bundles override: %v", err)
+		}
+		return b, nil
+	}
+
+	b, err := reader.ReadBundlesForVersion(version.GitVersion)
+	if err != nil {
+		return nil, fmt.Errorf("reading bundles for version %s: %v", version.GitVersion, err)
+	}
+	return b, nil
+}
+
 func artifactNames(artifacts []releasev1.Image) []string {
 	taggedArtifacts := make([]string, 0, len(artifacts))
 	for _, a := range artifacts {
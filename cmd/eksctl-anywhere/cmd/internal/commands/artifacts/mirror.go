@@ -0,0 +1,58 @@
+package artifacts
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/eks-anywhere/pkg/curatedpackages/oras"
+	"github.com/aws/eks-anywhere/pkg/registry"
+	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+// ArtifactMirror copies artifacts between registries and OCI layouts.
+type ArtifactMirror interface {
+	Run(ctx context.Context, artifacts []registry.Artifact) error
+}
+
+// Mirror copies the images, helm charts and curated packages bundles of an EKS-A bundle
+// with an ArtifactMirror, keeping their digests. Unlike Download and Import, it doesn't
+// need a container runtime.
+type Mirror struct {
+	Reader         Reader
+	Bundles        *releasev1.Bundles
+	ArtifactMirror ArtifactMirror
+}
+
+// Run copies all the artifacts of the bundle.
+func (m Mirror) Run(ctx context.Context) error {
+	images, err := m.Reader.ReadImagesFromBundles(ctx, m.Bundles)
+	if err != nil {
+		return fmt.Errorf("reading images: %v", err)
+	}
+
+	charts := m.Reader.ReadChartsFromBundles(ctx, m.Bundles)
+
+	artifacts := make([]registry.Artifact, 0, len(images)+len(charts))
+	seen := map[string]struct{}{}
+	add := func(a registry.Artifact) {
+		if _, ok := seen[a.VersionedImage()]; ok {
+			return
+		}
+		seen[a.VersionedImage()] = struct{}{}
+		artifacts = append(artifacts, a)
+	}
+
+	for _, i := range append(images, charts...) {
+		a := registry.NewArtifactFromURI(i.VersionedImage())
+		if a.Digest == "" {
+			a.Digest = i.ImageDigest
+		}
+		add(a)
+	}
+
+	for _, b := range oras.UniqueCharts(oras.ReadFilesFromBundles(m.Bundles)) {
+		add(registry.NewArtifactFromURI(b))
+	}
+
+	return m.ArtifactMirror.Run(ctx, artifacts)
+}
@@ -0,0 +1,91 @@
+package artifacts_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/cmd/eksctl-anywhere/cmd/internal/commands/artifacts"
+	"github.com/aws/eks-anywhere/cmd/eksctl-anywhere/cmd/internal/commands/artifacts/mocks"
+	"github.com/aws/eks-anywhere/pkg/registry"
+	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+type mirrorArtifactsTest struct {
+	*WithT
+	ctx     context.Context
+	reader  *mocks.MockReader
+	mirror  *mocks.MockArtifactMirror
+	bundles *releasev1.Bundles
+	command *artifacts.Mirror
+}
+
+func newMirrorArtifactsTest(t *testing.T) *mirrorArtifactsTest {
+	ctrl := gomock.NewController(t)
+	reader := mocks.NewMockReader(ctrl)
+	mirror := mocks.NewMockArtifactMirror(ctrl)
+	bundles := &releasev1.Bundles{
+		Spec: releasev1.BundlesSpec{
+			VersionsBundles: []releasev1.VersionsBundle{
+				{
+					KubeVersion: "1.27",
+					PackageController: releasev1.PackageBundle{
+						Controller: releasev1.Image{URI: "public.ecr.aws/eks-anywhere/eks-anywhere-packages:v0.3.0"},
+					},
+				},
+			},
+		},
+	}
+
+	return &mirrorArtifactsTest{
+		WithT:   NewWithT(t),
+		ctx:     context.Background(),
+		reader:  reader,
+		mirror:  mirror,
+		bundles: bundles,
+		command: &artifacts.Mirror{
+			Reader:         reader,
+			Bundles:        bundles,
+			ArtifactMirror: mirror,
+		},
+	}
+}
+
+func TestMirrorRun(t *testing.T) {
+	tt := newMirrorArtifactsTest(t)
+	images := []releasev1.Image{
+		{URI: "public.ecr.aws/eks-anywhere/kube-vip:v0.5.5", ImageDigest: "sha256:6efe21500abbfbb6b3e37b80dd5dea0b11a0d1b145e84298fee5d7784a77e967"},
+		{URI: "public.ecr.aws/eks-anywhere/kube-vip:v0.5.5", ImageDigest: "sha256:6efe21500abbfbb6b3e37b80dd5dea0b11a0d1b145e84298fee5d7784a77e967"},
+	}
+	charts := []releasev1.Image{
+		{URI: "public.ecr.aws/eks-anywhere/cilium-chart:1.12.11"},
+	}
+	tt.reader.EXPECT().ReadImagesFromBundles(tt.ctx, tt.bundles).Return(images, nil)
+	tt.reader.EXPECT().ReadChartsFromBundles(tt.ctx, tt.bundles).Return(charts)
+	tt.mirror.EXPECT().Run(tt.ctx, []registry.Artifact{
+		registry.NewArtifact("public.ecr.aws", "eks-anywhere/kube-vip", "v0.5.5", "sha256:6efe21500abbfbb6b3e37b80dd5dea0b11a0d1b145e84298fee5d7784a77e967"),
+		registry.NewArtifact("public.ecr.aws", "eks-anywhere/cilium-chart", "1.12.11", ""),
+		registry.NewArtifact("public.ecr.aws", "eks-anywhere/eks-anywhere-packages-bundles", "v1-27-latest", ""),
+	})
+
+	tt.Expect(tt.command.Run(tt.ctx)).To(Succeed())
+}
+
+func TestMirrorRunReadImagesError(t *testing.T) {
+	tt := newMirrorArtifactsTest(t)
+	tt.reader.EXPECT().ReadImagesFromBundles(tt.ctx, tt.bundles).Return(nil, errors.New("invalid bundle"))
+
+	tt.Expect(tt.command.Run(tt.ctx)).To(MatchError("reading images: invalid bundle"))
+}
+
+func TestMirrorRunError(t *testing.T) {
+	tt := newMirrorArtifactsTest(t)
+	tt.reader.EXPECT().ReadImagesFromBundles(tt.ctx, tt.bundles).Return(nil, nil)
+	tt.reader.EXPECT().ReadChartsFromBundles(tt.ctx, tt.bundles).Return(nil)
+	tt.mirror.EXPECT().Run(tt.ctx, gomock.Any()).Return(errors.New("copying: timeout"))
+
+	tt.Expect(tt.command.Run(tt.ctx)).To(MatchError("copying: timeout"))
+}
@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: cmd/eksctl-anywhere/cmd/internal/commands/artifacts/mirror.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	registry "github.com/aws/eks-anywhere/pkg/registry"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockArtifactMirror is a mock of ArtifactMirror interface.
+type MockArtifactMirror struct {
+	ctrl     *gomock.Controller
+	recorder *MockArtifactMirrorMockRecorder
+}
+
+// MockArtifactMirrorMockRecorder is the mock recorder for MockArtifactMirror.
+type MockArtifactMirrorMockRecorder struct {
+	mock *MockArtifactMirror
+}
+
+// NewMockArtifactMirror creates a new mock instance.
+func NewMockArtifactMirror(ctrl *gomock.Controller) *MockArtifactMirror {
+	mock := &MockArtifactMirror{ctrl: ctrl}
+	mock.recorder = &MockArtifactMirrorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockArtifactMirror) EXPECT() *MockArtifactMirrorMockRecorder {
+	return m.recorder
+}
+
+// Run mocks base method.
+func (m *MockArtifactMirror) Run(ctx context.Context, artifacts []registry.Artifact) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Run", ctx, artifacts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockArtifactMirrorMockRecorder) Run(ctx, artifacts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockArtifactMirror)(nil).Run), ctx, artifacts)
+}
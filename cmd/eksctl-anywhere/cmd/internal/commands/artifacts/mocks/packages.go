@@ -0,0 +1,104 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: cmd/eksctl-anywhere/cmd/internal/commands/artifacts/packages.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	v1alpha1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
+	registry "github.com/aws/eks-anywhere/pkg/registry"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPackagesReader is a mock of PackagesReader interface.
+type MockPackagesReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockPackagesReaderMockRecorder
+}
+
+// MockPackagesReaderMockRecorder is the mock recorder for MockPackagesReader.
+type MockPackagesReaderMockRecorder struct {
+	mock *MockPackagesReader
+}
+
+// NewMockPackagesReader creates a new mock instance.
+func NewMockPackagesReader(ctrl *gomock.Controller) *MockPackagesReader {
+	mock := &MockPackagesReader{ctrl: ctrl}
+	mock.recorder = &MockPackagesReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPackagesReader) EXPECT() *MockPackagesReaderMockRecorder {
+	return m.recorder
+}
+
+// ReadBundle mocks base method.
+func (m *MockPackagesReader) ReadBundle(ctx context.Context, bundleURI string) (*v1alpha1.PackageBundle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadBundle", ctx, bundleURI)
+	ret0, _ := ret[0].(*v1alpha1.PackageBundle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadBundle indicates an expected call of ReadBundle.
+func (mr *MockPackagesReaderMockRecorder) ReadBundle(ctx, bundleURI interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadBundle", reflect.TypeOf((*MockPackagesReader)(nil).ReadBundle), ctx, bundleURI)
+}
+
+// ReadBundleArtifacts mocks base method.
+func (m *MockPackagesReader) ReadBundleArtifacts(bundleURI string, bundle *v1alpha1.PackageBundle) ([]registry.Artifact, []registry.Artifact) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadBundleArtifacts", bundleURI, bundle)
+	ret0, _ := ret[0].([]registry.Artifact)
+	ret1, _ := ret[1].([]registry.Artifact)
+	return ret0, ret1
+}
+
+// ReadBundleArtifacts indicates an expected call of ReadBundleArtifacts.
+func (mr *MockPackagesReaderMockRecorder) ReadBundleArtifacts(bundleURI, bundle interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadBundleArtifacts", reflect.TypeOf((*MockPackagesReader)(nil).ReadBundleArtifacts), bundleURI, bundle)
+}
+
+// MockBundleRegistrar is a mock of BundleRegistrar interface.
+type MockBundleRegistrar struct {
+	ctrl     *gomock.Controller
+	recorder *MockBundleRegistrarMockRecorder
+}
+
+// MockBundleRegistrarMockRecorder is the mock recorder for MockBundleRegistrar.
+type MockBundleRegistrarMockRecorder struct {
+	mock *MockBundleRegistrar
+}
+
+// NewMockBundleRegistrar creates a new mock instance.
+func NewMockBundleRegistrar(ctrl *gomock.Controller) *MockBundleRegistrar {
+	mock := &MockBundleRegistrar{ctrl: ctrl}
+	mock.recorder = &MockBundleRegistrarMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBundleRegistrar) EXPECT() *MockBundleRegistrarMockRecorder {
+	return m.recorder
+}
+
+// RegisterBundle mocks base method.
+func (m *MockBundleRegistrar) RegisterBundle(ctx context.Context, bundle *v1alpha1.PackageBundle) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterBundle", ctx, bundle)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RegisterBundle indicates an expected call of RegisterBundle.
+func (mr *MockBundleRegistrarMockRecorder) RegisterBundle(ctx, bundle interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterBundle", reflect.TypeOf((*MockBundleRegistrar)(nil).RegisterBundle), ctx, bundle)
+}
@@ -0,0 +1,166 @@
+package artifacts
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"sigs.k8s.io/yaml"
+
+	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/registry"
+)
+
+const (
+	// PackagesLayoutFolder is the folder of a curated packages archive with the OCI image
+	// layout holding the package bundle, the helm charts and the images.
+	PackagesLayoutFolder = "oci"
+	packagesBundleFile   = "bundle.yaml"
+	packagesMetadataFile = "metadata.json"
+)
+
+// PackagesReader reads curated packages bundles and the artifacts they reference.
+type PackagesReader interface {
+	ReadBundle(ctx context.Context, bundleURI string) (*packagesv1.PackageBundle, error)
+	ReadBundleArtifacts(bundleURI string, bundle *packagesv1.PackageBundle) (charts, images []registry.Artifact)
+}
+
+// BundleRegistrar registers a curated packages bundle with the package controller of a cluster.
+type BundleRegistrar interface {
+	RegisterBundle(ctx context.Context, bundle *packagesv1.PackageBundle) error
+}
+
+// packagesMetadata describes the package bundle in a curated packages archive.
+type packagesMetadata struct {
+	BundleURI string `json:"bundleURI"`
+}
+
+// DownloadPackages exports a curated packages bundle with its helm charts and images
+// to an archive, so they can be imported in a registry without internet access.
+type DownloadPackages struct {
+	Reader    PackagesReader
+	BundleURI string
+	// ArtifactMirror copies the artifacts to the OCI layout in the PackagesLayoutFolder
+	// of TmpDownloadFolder.
+	ArtifactMirror    ArtifactMirror
+	Packager          Packager
+	TmpDownloadFolder string
+	DstFile           string
+}
+
+// Run downloads the package bundle and its artifacts and packages them in DstFile.
+func (d DownloadPackages) Run(ctx context.Context) error {
+	if err := os.MkdirAll(d.TmpDownloadFolder, os.ModePerm); err != nil {
+		return fmt.Errorf("creating tmp packages download folder: %v", err)
+	}
+
+	bundle, err := d.Reader.ReadBundle(ctx, d.BundleURI)
+	if err != nil {
+		return fmt.Errorf("reading package bundle %s: %v", d.BundleURI, err)
+	}
+
+	charts, images := d.Reader.ReadBundleArtifacts(d.BundleURI, bundle)
+	logger.Info("Downloading curated packages", "bundle", d.BundleURI, "charts", len(charts), "images", len(images))
+	if err = d.ArtifactMirror.Run(ctx, append(charts, images...)); err != nil {
+		return err
+	}
+
+	bundleYaml, err := yaml.Marshal(bundle)
+	if err != nil {
+		return fmt.Errorf("marshalling package bundle: %v", err)
+	}
+	if err = os.WriteFile(filepath.Join(d.TmpDownloadFolder, packagesBundleFile), bundleYaml, 0o644); err != nil {
+		return fmt.Errorf("writing package bundle: %v", err)
+	}
+
+	metadata, err := json.Marshal(packagesMetadata{BundleURI: d.BundleURI})
+	if err != nil {
+		return fmt.Errorf("marshalling packages metadata: %v", err)
+	}
+	if err = os.WriteFile(filepath.Join(d.TmpDownloadFolder, packagesMetadataFile), metadata, 0o644); err != nil {
+		return fmt.Errorf("writing packages metadata: %v", err)
+	}
+
+	logger.Info("Packaging curated packages", "dst", d.DstFile)
+	if err = d.Packager.Package(d.TmpDownloadFolder, d.DstFile); err != nil {
+		return err
+	}
+
+	if err = os.RemoveAll(d.TmpDownloadFolder); err != nil {
+		return fmt.Errorf("deleting tmp packages download folder: %v", err)
+	}
+
+	return nil
+}
+
+// ImportPackages imports a curated packages archive created by DownloadPackages
+// into a registry and, optionally, registers its bundle with the package controller.
+type ImportPackages struct {
+	Reader             PackagesReader
+	UnPackager         UnPackager
+	InputFile          string
+	TmpArtifactsFolder string
+	// ChartsMirror copies the package bundle and the helm charts from the OCI layout
+	// in the PackagesLayoutFolder of TmpArtifactsFolder to the registry.
+	ChartsMirror ArtifactMirror
+	// ImagesMirror copies the images from the OCI layout to the registry.
+	ImagesMirror ArtifactMirror
+	// BundleRegistrar is optional. When set, the package bundle is registered after
+	// all the artifacts are imported.
+	BundleRegistrar BundleRegistrar
+}
+
+// Run unpackages InputFile and copies its artifacts to the registry.
+func (i ImportPackages) Run(ctx context.Context) error {
+	if err := os.MkdirAll(i.TmpArtifactsFolder, os.ModePerm); err != nil {
+		return fmt.Errorf("creating tmp packages import folder: %v", err)
+	}
+
+	logger.Info("Unpackaging curated packages", "dst", i.TmpArtifactsFolder)
+	if err := i.UnPackager.UnPackage(i.InputFile, i.TmpArtifactsFolder); err != nil {
+		return err
+	}
+
+	content, err := os.ReadFile(filepath.Join(i.TmpArtifactsFolder, packagesMetadataFile))
+	if err != nil {
+		return fmt.Errorf("reading packages metadata: %v", err)
+	}
+	metadata := &packagesMetadata{}
+	if err = json.Unmarshal(content, metadata); err != nil {
+		return fmt.Errorf("parsing packages metadata: %v", err)
+	}
+
+	content, err = os.ReadFile(filepath.Join(i.TmpArtifactsFolder, packagesBundleFile))
+	if err != nil {
+		return fmt.Errorf("reading package bundle: %v", err)
+	}
+	bundle := &packagesv1.PackageBundle{}
+	if err = yaml.Unmarshal(content, bundle); err != nil {
+		return fmt.Errorf("parsing package bundle: %v", err)
+	}
+
+	charts, images := i.Reader.ReadBundleArtifacts(metadata.BundleURI, bundle)
+	logger.Info("Importing curated packages", "bundle", metadata.BundleURI, "charts", len(charts), "images", len(images))
+	if err = i.ChartsMirror.Run(ctx, charts); err != nil {
+		return err
+	}
+	if err = i.ImagesMirror.Run(ctx, images); err != nil {
+		return err
+	}
+
+	if i.BundleRegistrar != nil {
+		logger.Info("Registering package bundle", "bundle", bundle.Name)
+		if err = i.BundleRegistrar.RegisterBundle(ctx, bundle); err != nil {
+			return err
+		}
+	}
+
+	if err = os.RemoveAll(i.TmpArtifactsFolder); err != nil {
+		return fmt.Errorf("deleting tmp packages import folder: %v", err)
+	}
+
+	return nil
+}
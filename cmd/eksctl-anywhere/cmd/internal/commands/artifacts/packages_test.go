@@ -0,0 +1,197 @@
+package artifacts_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
+	"github.com/aws/eks-anywhere/cmd/eksctl-anywhere/cmd/internal/commands/artifacts"
+	"github.com/aws/eks-anywhere/cmd/eksctl-anywhere/cmd/internal/commands/artifacts/mocks"
+	"github.com/aws/eks-anywhere/pkg/registry"
+)
+
+const packagesBundleURI = "public.ecr.aws/eks-anywhere/eks-anywhere-packages-bundles:v1-27-125"
+
+type packagesTest struct {
+	*WithT
+	ctx       context.Context
+	reader    *mocks.MockPackagesReader
+	registrar *mocks.MockBundleRegistrar
+	bundle    *packagesv1.PackageBundle
+	charts    []registry.Artifact
+	images    []registry.Artifact
+	folder    string
+}
+
+func newPackagesTest(t *testing.T) *packagesTest {
+	ctrl := gomock.NewController(t)
+	bundle := &packagesv1.PackageBundle{}
+	bundle.Name = "v1-27-125"
+	bundle.Spec.Packages = []packagesv1.BundlePackage{{Name: "harbor"}}
+
+	return &packagesTest{
+		WithT:     NewWithT(t),
+		ctx:       context.Background(),
+		reader:    mocks.NewMockPackagesReader(ctrl),
+		registrar: mocks.NewMockBundleRegistrar(ctrl),
+		bundle:    bundle,
+		charts: []registry.Artifact{
+			registry.NewArtifactFromURI(packagesBundleURI),
+			registry.NewArtifactFromURI("public.ecr.aws/eks-anywhere/harbor/harbor-helm@sha256:aaaa"),
+		},
+		images: []registry.Artifact{
+			registry.NewArtifactFromURI("783794618700.dkr.ecr.us-west-2.amazonaws.com/harbor/harbor-core@sha256:bbbb"),
+		},
+		folder: filepath.Join(t.TempDir(), "tmp-packages"),
+	}
+}
+
+func TestDownloadPackagesRun(t *testing.T) {
+	tt := newPackagesTest(t)
+	ctrl := gomock.NewController(t)
+	mirror := mocks.NewMockArtifactMirror(ctrl)
+	packager := mocks.NewMockPackager(ctrl)
+	var archived map[string][]byte
+
+	tt.reader.EXPECT().ReadBundle(tt.ctx, packagesBundleURI).Return(tt.bundle, nil)
+	tt.reader.EXPECT().ReadBundleArtifacts(packagesBundleURI, tt.bundle).Return(tt.charts, tt.images)
+	mirror.EXPECT().Run(tt.ctx, append(tt.charts, tt.images...))
+	packager.EXPECT().Package(tt.folder, "packages.tar").DoAndReturn(func(folder, _ string) error {
+		archived = map[string][]byte{}
+		for _, f := range []string{"bundle.yaml", "metadata.json"} {
+			content, err := os.ReadFile(filepath.Join(folder, f))
+			tt.Expect(err).NotTo(HaveOccurred())
+			archived[f] = content
+		}
+		return nil
+	})
+
+	command := artifacts.DownloadPackages{
+		Reader:            tt.reader,
+		BundleURI:         packagesBundleURI,
+		ArtifactMirror:    mirror,
+		Packager:          packager,
+		TmpDownloadFolder: tt.folder,
+		DstFile:           "packages.tar",
+	}
+
+	tt.Expect(command.Run(tt.ctx)).To(Succeed())
+	tt.Expect(string(archived["metadata.json"])).To(Equal(`{"bundleURI":"` + packagesBundleURI + `"}`))
+	tt.Expect(string(archived["bundle.yaml"])).To(ContainSubstring("name: v1-27-125"))
+	tt.Expect(tt.folder).NotTo(BeADirectory())
+}
+
+func TestDownloadPackagesRunReadBundleError(t *testing.T) {
+	tt := newPackagesTest(t)
+	tt.reader.EXPECT().ReadBundle(tt.ctx, packagesBundleURI).Return(nil, errors.New("not found"))
+
+	command := artifacts.DownloadPackages{
+		Reader:            tt.reader,
+		BundleURI:         packagesBundleURI,
+		TmpDownloadFolder: tt.folder,
+		DstFile:           "packages.tar",
+	}
+
+	tt.Expect(command.Run(tt.ctx)).To(MatchError("reading package bundle " + packagesBundleURI + ": not found"))
+}
+
+func TestDownloadPackagesRunMirrorError(t *testing.T) {
+	tt := newPackagesTest(t)
+	mirror := mocks.NewMockArtifactMirror(gomock.NewController(t))
+	tt.reader.EXPECT().ReadBundle(tt.ctx, packagesBundleURI).Return(tt.bundle, nil)
+	tt.reader.EXPECT().ReadBundleArtifacts(packagesBundleURI, tt.bundle).Return(tt.charts, tt.images)
+	mirror.EXPECT().Run(tt.ctx, gomock.Any()).Return(errors.New("copying failed"))
+
+	command := artifacts.DownloadPackages{
+		Reader:            tt.reader,
+		BundleURI:         packagesBundleURI,
+		ArtifactMirror:    mirror,
+		TmpDownloadFolder: tt.folder,
+		DstFile:           "packages.tar",
+	}
+
+	tt.Expect(command.Run(tt.ctx)).To(MatchError("copying failed"))
+	// The layout is kept, so the next run only copies what's missing.
+	tt.Expect(tt.folder).To(BeADirectory())
+}
+
+func (tt *packagesTest) importCommand(t *testing.T, registrar artifacts.BundleRegistrar) (artifacts.ImportPackages, *mocks.MockArtifactMirror, *mocks.MockArtifactMirror) {
+	ctrl := gomock.NewController(t)
+	unpackager := mocks.NewMockUnPackager(ctrl)
+	chartsMirror := mocks.NewMockArtifactMirror(ctrl)
+	imagesMirror := mocks.NewMockArtifactMirror(ctrl)
+
+	unpackager.EXPECT().UnPackage("packages.tar", tt.folder).DoAndReturn(func(_, folder string) error {
+		if err := os.WriteFile(filepath.Join(folder, "metadata.json"), []byte(`{"bundleURI":"`+packagesBundleURI+`"}`), 0o644); err != nil {
+			return err
+		}
+		return os.WriteFile(filepath.Join(folder, "bundle.yaml"), []byte("metadata:\n  name: v1-27-125\nspec:\n  packages:\n  - name: harbor\n"), 0o644)
+	})
+
+	return artifacts.ImportPackages{
+		Reader:             tt.reader,
+		UnPackager:         unpackager,
+		InputFile:          "packages.tar",
+		TmpArtifactsFolder: tt.folder,
+		ChartsMirror:       chartsMirror,
+		ImagesMirror:       imagesMirror,
+		BundleRegistrar:    registrar,
+	}, chartsMirror, imagesMirror
+}
+
+func TestImportPackagesRun(t *testing.T) {
+	tt := newPackagesTest(t)
+	command, chartsMirror, imagesMirror := tt.importCommand(t, tt.registrar)
+
+	tt.reader.EXPECT().ReadBundleArtifacts(packagesBundleURI, tt.bundle).Return(tt.charts, tt.images)
+	gomock.InOrder(
+		chartsMirror.EXPECT().Run(tt.ctx, tt.charts),
+		imagesMirror.EXPECT().Run(tt.ctx, tt.images),
+		tt.registrar.EXPECT().RegisterBundle(tt.ctx, tt.bundle),
+	)
+
+	tt.Expect(command.Run(tt.ctx)).To(Succeed())
+	tt.Expect(tt.folder).NotTo(BeADirectory())
+}
+
+func TestImportPackagesRunWithoutRegistrar(t *testing.T) {
+	tt := newPackagesTest(t)
+	command, chartsMirror, imagesMirror := tt.importCommand(t, nil)
+
+	tt.reader.EXPECT().ReadBundleArtifacts(packagesBundleURI, tt.bundle).Return(tt.charts, tt.images)
+	chartsMirror.EXPECT().Run(tt.ctx, tt.charts)
+	imagesMirror.EXPECT().Run(tt.ctx, tt.images)
+
+	tt.Expect(command.Run(tt.ctx)).To(Succeed())
+}
+
+func TestImportPackagesRunImagesMirrorError(t *testing.T) {
+	tt := newPackagesTest(t)
+	command, chartsMirror, imagesMirror := tt.importCommand(t, tt.registrar)
+
+	tt.reader.EXPECT().ReadBundleArtifacts(packagesBundleURI, tt.bundle).Return(tt.charts, tt.images)
+	chartsMirror.EXPECT().Run(tt.ctx, tt.charts)
+	imagesMirror.EXPECT().Run(tt.ctx, tt.images).Return(errors.New("pushing failed"))
+
+	tt.Expect(command.Run(tt.ctx)).To(MatchError("pushing failed"))
+}
+
+func TestImportPackagesRunMissingMetadata(t *testing.T) {
+	tt := newPackagesTest(t)
+	unpackager := mocks.NewMockUnPackager(gomock.NewController(t))
+	unpackager.EXPECT().UnPackage("images.tar", tt.folder)
+
+	command := artifacts.ImportPackages{
+		UnPackager:         unpackager,
+		InputFile:          "images.tar",
+		TmpArtifactsFolder: tt.folder,
+	}
+
+	tt.Expect(command.Run(tt.ctx)).To(MatchError(ContainSubstring("reading packages metadata")))
+}
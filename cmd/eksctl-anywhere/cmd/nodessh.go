@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/spf13/pflag"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/nodes"
+)
+
+const defaultSSHKeyFileName = "eks-a-id_rsa"
+
+// nodeSSHOptions holds the flags shared by the commands that run scripts in the control plane and etcd nodes.
+type nodeSSHOptions struct {
+	clusterOptions
+	sshKey      string
+	sshUsername string
+}
+
+func applyNodeSSHFlags(flagSet *pflag.FlagSet, o *nodeSSHOptions) {
+	applyClusterOptionFlags(flagSet, &o.clusterOptions)
+	flagSet.StringVar(&o.sshKey, "ssh-key", "", "Private key to SSH into the control plane and etcd nodes (default <cluster-name>/eks-a-id_rsa)")
+	flagSet.StringVar(&o.sshUsername, "ssh-username", "", "Username to SSH into the control plane and etcd nodes (default user from the machine config)")
+}
+
+func (o *nodeSSHOptions) privateKeyPath(clusterName string) string {
+	if o.sshKey != "" {
+		return o.sshKey
+	}
+	return filepath.Join(clusterName, defaultSSHKeyFileName)
+}
+
+// dependencies builds the dependencies needed to run commands in the cluster nodes.
+func (o *nodeSSHOptions) dependencies(ctx context.Context, clusterSpec *cluster.Spec) (*dependencies.Dependencies, *nodes.Runner, error) {
+	privateKeyPath := o.privateKeyPath(clusterSpec.Cluster.Name)
+	dirs := append(o.mountDirs(), filepath.Dir(privateKeyPath))
+
+	deps, err := dependencies.ForSpec(ctx, clusterSpec).
+		WithExecutableMountDirs(dirs...).
+		WithSSH().
+		WithNodeLister().
+		Build(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var runnerOpts []nodes.RunnerOpt
+	if o.sshUsername != "" {
+		runnerOpts = append(runnerOpts, nodes.WithUsername(o.sshUsername))
+	}
+
+	return deps, nodes.NewRunner(deps.SSH, privateKeyPath, runnerOpts...), nil
+}
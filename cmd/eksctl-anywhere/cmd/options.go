@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"crypto/x509"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -14,10 +15,13 @@ import (
 	"github.com/aws/eks-anywhere/pkg/clustermanager"
 	"github.com/aws/eks-anywhere/pkg/config"
 	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/events"
 	"github.com/aws/eks-anywhere/pkg/files"
 	"github.com/aws/eks-anywhere/pkg/kubeconfig"
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/decoder"
+	"github.com/aws/eks-anywhere/pkg/registry"
+	"github.com/aws/eks-anywhere/pkg/registrymirror"
 	"github.com/aws/eks-anywhere/pkg/types"
 	"github.com/aws/eks-anywhere/pkg/version"
 )
@@ -44,6 +48,138 @@ func applyTimeoutFlags(flagSet *pflag.FlagSet, t *timeoutOptions) {
 	flagSet.BoolVar(&t.noTimeouts, noTimeoutsFlag, false, "Disable timeout for all wait operations")
 }
 
+type dryRunOptions struct {
+	dryRun    bool
+	outputDir string
+}
+
+func applyDryRunFlags(flagSet *pflag.FlagSet, d *dryRunOptions) {
+	flagSet.BoolVar(&d.dryRun, dryRunFlag, false, "Run defaulting and validations and write the manifests that would be applied to a directory, without changing any cluster or infrastructure. The Cluster API provider components are not rendered")
+	flagSet.StringVar(&d.outputDir, dryRunOutputDirFlag, "", "Directory where the dry run manifests are written (defaults to <cluster-name>/dry-run)")
+}
+
+func (d dryRunOptions) outputDirectory(clusterName string) string {
+	if d.outputDir != "" {
+		return d.outputDir
+	}
+
+	return filepath.Join(clusterName, "dry-run")
+}
+
+func logDryRunManifests(paths []string) {
+	logger.Info("Dry run completed, no changes were made. Manifests that would be applied:")
+	for _, p := range paths {
+		logger.Info(p)
+	}
+}
+
+type signatureOptions struct {
+	publicKeyFile string
+	requireSBOM   bool
+}
+
+func applySignatureFlags(flagSet *pflag.FlagSet, s *signatureOptions) {
+	flagSet.StringVar(&s.publicKeyFile, signaturePublicKeyFlag, "", "Public key file to verify the cosign or notation signatures of the images with. Unsigned or tampered images are refused")
+	flagSet.BoolVar(&s.requireSBOM, requireSBOMFlag, false, "Also require an SBOM signed with the public key attached to every image")
+}
+
+// signatureVerifier returns the verifier of the images signatures, or nil if they are not verified.
+func (s signatureOptions) signatureVerifier() (*registry.SignatureVerifier, error) {
+	if s.publicKeyFile == "" {
+		if s.requireSBOM {
+			return nil, fmt.Errorf("--%s requires --%s", requireSBOMFlag, signaturePublicKeyFlag)
+		}
+		return nil, nil
+	}
+
+	publicKey, err := registry.LoadPublicKey(s.publicKeyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var opts []registry.SignatureVerifierOpt
+	if s.requireSBOM {
+		opts = append(opts, registry.WithRequiredSBOM())
+	}
+	return registry.NewSignatureVerifier(publicKey, opts...), nil
+}
+
+// imageVerifier returns the verifier of the signatures of the images pulled from their registries or
+// from the registry mirror, or nil if they are not verified.
+func (s signatureOptions) imageVerifier(mirror *registrymirror.RegistryMirror) (*registry.ArtifactsVerifier, error) {
+	verifier, err := s.signatureVerifier()
+	if err != nil || verifier == nil {
+		return nil, err
+	}
+
+	sources, err := registrySources(mirror)
+	if err != nil {
+		return nil, err
+	}
+	return registry.NewArtifactsVerifier(verifier, sources), nil
+}
+
+// registrySources returns the storage clients reading the images from their registries or, when
+// the images are mirror-replaced, from the registry mirror with its credentials and CA.
+func registrySources(mirror *registrymirror.RegistryMirror) (registry.SourceClientFunc, error) {
+	credentialStore := registry.NewCredentialStore()
+	if err := credentialStore.Init(); err != nil {
+		return nil, err
+	}
+
+	var certificates *x509.CertPool
+	insecure := false
+	if mirror != nil {
+		if mirror.Auth {
+			username, password, err := config.ReadCredentials()
+			if err != nil {
+				return nil, err
+			}
+			credentialStore.SetCredential(mirror.BaseRegistry, username, password)
+		}
+		if mirror.CACertContent != "" {
+			certificates = x509.NewCertPool()
+			certificates.AppendCertsFromPEM([]byte(mirror.CACertContent))
+		}
+		insecure = mirror.InsecureSkipVerify
+	}
+
+	return registry.NewCache().Sources(credentialStore, certificates, insecure), nil
+}
+
+type eventsOptions struct {
+	eventsOutput string
+}
+
+func applyEventsFlags(flagSet *pflag.FlagSet, e *eventsOptions) {
+	flagSet.StringVar(&e.eventsOutput, eventsOutputFlag, "", "Write JSON lines progress events to a file path or an open file descriptor number")
+}
+
+// runWithEvents runs an operation, emitting its start and final result to the events output when set.
+func (e eventsOptions) runWithEvents(operation string, run func() error) (err error) {
+	if e.eventsOutput == "" {
+		return run()
+	}
+
+	output, err := events.OpenOutput(e.eventsOutput)
+	if err != nil {
+		return err
+	}
+	events.Set(events.NewEmitter(output, operation))
+	start := time.Now()
+	events.OperationStart()
+
+	defer func() {
+		events.OperationEnd(time.Since(start), err)
+		events.Set(nil)
+		if closeErr := output.Close(); closeErr != nil {
+			logger.V(4).Info("Failed closing events output", "error", closeErr)
+		}
+	}()
+
+	return run()
+}
+
 // buildClusterManagerOpts builds options for constructing a ClusterManager from CLI flags.
 // datacenterKind is an API kind such as v1alpha1.TinkerbellDatacenterKind.
 func buildClusterManagerOpts(t timeoutOptions, datacenterKind string) (*dependencies.ClusterManagerTimeoutOptions, error) {
@@ -153,15 +289,30 @@ func markFlagHidden(flagSet *pflag.FlagSet, flagName string) {
 
 func buildCliConfig(clusterSpec *cluster.Spec) *config.CliConfig {
 	cliConfig := &config.CliConfig{}
-	if clusterSpec.FluxConfig != nil && clusterSpec.FluxConfig.Spec.Git != nil {
+	gitConfig := gitOpsRepositoryConfig(clusterSpec)
+	if gitConfig != nil && gitConfig.Spec.Git != nil {
 		cliConfig.GitSshKeyPassphrase = os.Getenv(config.EksaGitPassphraseTokenEnv)
 		cliConfig.GitPrivateKeyFile = os.Getenv(config.EksaGitPrivateKeyTokenEnv)
 		cliConfig.GitKnownHostsFile = os.Getenv(config.EksaGitKnownHostsFileEnv)
 	}
 
+	// GitLab and Gitea bootstrap with a generated deploy key, only the known hosts are provided.
+	if gitConfig != nil && (gitConfig.Spec.Gitlab != nil || gitConfig.Spec.Gitea != nil) {
+		cliConfig.GitKnownHostsFile = os.Getenv(config.EksaGitKnownHostsFileEnv)
+	}
+
 	return cliConfig
 }
 
+// gitOpsRepositoryConfig returns the FluxConfig of the git repository the cluster config is written to,
+// which for Argo CD is converted from the ArgoCDConfig.
+func gitOpsRepositoryConfig(clusterSpec *cluster.Spec) *v1alpha1.FluxConfig {
+	if clusterSpec.ArgoCDConfig != nil {
+		return clusterSpec.ArgoCDConfig.ConvertToFluxConfig()
+	}
+	return clusterSpec.FluxConfig
+}
+
 func getManagementCluster(clusterSpec *cluster.Spec) *types.Cluster {
 	if clusterSpec.ManagementCluster == nil {
 		return &types.Cluster{
@@ -178,11 +329,14 @@ func getManagementCluster(clusterSpec *cluster.Spec) *types.Cluster {
 
 func (c *clusterOptions) directoriesToMount(clusterSpec *cluster.Spec, cliConfig *config.CliConfig, addDirs ...string) ([]string, error) {
 	dirs := c.mountDirs()
-	fluxConfig := clusterSpec.FluxConfig
+	fluxConfig := gitOpsRepositoryConfig(clusterSpec)
 	if fluxConfig != nil && fluxConfig.Spec.Git != nil {
 		dirs = append(dirs, filepath.Dir(cliConfig.GitPrivateKeyFile))
 		dirs = append(dirs, filepath.Dir(cliConfig.GitKnownHostsFile))
 	}
+	if fluxConfig != nil && (fluxConfig.Spec.Gitlab != nil || fluxConfig.Spec.Gitea != nil) && cliConfig.GitKnownHostsFile != "" {
+		dirs = append(dirs, filepath.Dir(cliConfig.GitKnownHostsFile))
+	}
 
 	if clusterSpec.Config.Cluster.Spec.DatacenterRef.Kind == v1alpha1.CloudStackDatacenterKind {
 		if extraDirs, err := c.cloudStackDirectoriesToMount(); err == nil {
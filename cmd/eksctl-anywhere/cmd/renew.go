@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var renewCmd = &cobra.Command{
+	Use:   "renew",
+	Short: "Renew resources",
+	Long:  "Use eksctl anywhere renew to renew resources, such as certificates",
+}
+
+func init() {
+	rootCmd.AddCommand(renewCmd)
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/certificates"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+var rco = &nodeSSHOptions{}
+
+var renewCertificatesCmd = &cobra.Command{
+	Use:          "certificates -f <cluster-config-file>",
+	Aliases:      []string{"certificate", "certs"},
+	Short:        "Renew the certificates of the control plane and etcd nodes",
+	Long:         "This command renews the certificates in the control plane and etcd nodes of a cluster, one node at a time, and restarts the components using them",
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := renewCertificates(cmd.Context(), rco); err != nil {
+			return fmt.Errorf("failed to renew certificates: %v", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	renewCmd.AddCommand(renewCertificatesCmd)
+	applyNodeSSHFlags(renewCertificatesCmd.Flags(), rco)
+	if err := renewCertificatesCmd.MarkFlagRequired("filename"); err != nil {
+		log.Fatalf("Error marking filename flag as required: %v", err)
+	}
+}
+
+func renewCertificates(ctx context.Context, o *nodeSSHOptions) error {
+	clusterSpec, err := newClusterSpec(o.clusterOptions)
+	if err != nil {
+		return err
+	}
+
+	deps, runner, err := o.dependencies(ctx, clusterSpec)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	if err := certificates.NewRenewer(deps.NodeLister, runner).Renew(ctx, getManagementCluster(clusterSpec), clusterSpec); err != nil {
+		return err
+	}
+
+	logger.MarkSuccess("Certificates renewed")
+	return nil
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/etcdbackup"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+type restoreClusterOptions struct {
+	etcdBackupOptions
+	snapshot string
+}
+
+var rc = &restoreClusterOptions{}
+
+var restoreClusterCmd = &cobra.Command{
+	Use:          "cluster -f <cluster-config-file> --snapshot <snapshot-name>",
+	Short:        "Restore a cluster etcd from a snapshot",
+	Long:         "This command restores an etcd snapshot taken with backup cluster in all the control plane or external etcd nodes of a cluster",
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := rc.restoreCluster(cmd.Context()); err != nil {
+			return fmt.Errorf("failed to restore cluster: %v", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	restoreCmd.AddCommand(restoreClusterCmd)
+	applyEtcdBackupFlags(restoreClusterCmd.Flags(), &rc.etcdBackupOptions)
+	restoreClusterCmd.Flags().StringVar(&rc.snapshot, "snapshot", "", "Name of the etcd snapshot to restore")
+	for _, flag := range []string{"filename", "snapshot"} {
+		if err := restoreClusterCmd.MarkFlagRequired(flag); err != nil {
+			log.Fatalf("Error marking %s flag as required: %v", flag, err)
+		}
+	}
+}
+
+func (o *restoreClusterOptions) restoreCluster(ctx context.Context) error {
+	clusterSpec, err := newClusterSpec(o.clusterOptions)
+	if err != nil {
+		return err
+	}
+
+	if err := etcdbackup.ValidateRestore(clusterSpec); err != nil {
+		return err
+	}
+
+	store, err := o.store(clusterSpec.Cluster.Name)
+	if err != nil {
+		return err
+	}
+
+	deps, runner, err := o.dependencies(ctx, clusterSpec)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	logger.Info("Restoring etcd snapshot", "snapshot", store.Location(o.snapshot))
+	if err := etcdbackup.NewRestorer(deps.NodeLister, runner, store).Restore(ctx, getManagementCluster(clusterSpec), clusterSpec, o.snapshot); err != nil {
+		return err
+	}
+
+	logger.MarkSuccess("Etcd snapshot restored")
+	return nil
+}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/curatedpackages"
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+)
+
+type rollbackPackageOptions struct {
+	toBundle string
+	// kubeConfig is an optional kubeconfig file to use when querying an
+	// existing cluster.
+	kubeConfig      string
+	clusterName     string
+	bundlesOverride string
+}
+
+var rpo = &rollbackPackageOptions{}
+
+func init() {
+	rollbackCmd.AddCommand(rollbackPackagesCommand)
+
+	rollbackPackagesCommand.Flags().StringVar(&rpo.toBundle, "to-bundle",
+		"", "Bundle version to roll back to, usually the one active before the last upgrade")
+	rollbackPackagesCommand.Flags().StringVar(&rpo.kubeConfig, "kubeconfig",
+		"", "Path to an optional kubeconfig file to use.")
+	rollbackPackagesCommand.Flags().StringVar(&rpo.clusterName, "cluster",
+		"", "Cluster to roll back.")
+	rollbackPackagesCommand.Flags().StringVar(&rpo.bundlesOverride, "bundles-override", "",
+		"Override default Bundles manifest (not recommended)")
+
+	if err := rollbackPackagesCommand.MarkFlagRequired("to-bundle"); err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+	if err := rollbackPackagesCommand.MarkFlagRequired("cluster"); err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+var rollbackPackagesCommand = &cobra.Command{
+	Use:          "packages",
+	Short:        "Roll back all curated packages to a previous bundle",
+	Long:         "Moves the package bundle controller back to a package bundle already in the cluster, so all the curated packages go back to its versions",
+	PreRunE:      preRunPackages,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := rollbackPackages(cmd.Context()); err != nil {
+			return err
+		}
+		return nil
+	},
+}
+
+func rollbackPackages(ctx context.Context) error {
+	kubeConfig, err := kubeconfig.ResolveAndValidateFilename(rpo.kubeConfig, "")
+	if err != nil {
+		return err
+	}
+
+	deps, err := NewDependenciesForPackages(ctx, WithMountPaths(kubeConfig), WithBundlesOverride(rpo.bundlesOverride))
+	if err != nil {
+		return fmt.Errorf("unable to initialize executables: %v", err)
+	}
+
+	b := curatedpackages.NewBundleReader(kubeConfig, rpo.clusterName, deps.Kubectl, nil, nil)
+	activeController, err := b.GetActiveController(ctx)
+	if err != nil {
+		return err
+	}
+	return b.RollbackBundle(ctx, activeController, rpo.toBundle)
+}
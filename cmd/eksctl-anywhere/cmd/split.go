@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var splitCmd = &cobra.Command{
+	Use:   "split",
+	Short: "Split resources",
+	Long:  "Use eksctl anywhere split to reorganize a resource",
+}
+
+func init() {
+	rootCmd.AddCommand(splitCmd)
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/validations"
+)
+
+type splitGitOpsRepoOptions struct {
+	clusterOptions
+	gitOpsPullRequest        bool
+	gitOpsPullRequestTimeout time.Duration
+}
+
+var sgr = &splitGitOpsRepoOptions{}
+
+var splitGitOpsRepoCmd = &cobra.Command{
+	Use:          "gitops-repo -f <cluster-config-file>",
+	Short:        "Split the GitOps repository into shared bases and cluster overlays",
+	Long:         "This command moves the objects shared by the clusters in the GitOps repository of a management cluster, like machine configs or identity providers, into Kustomize bases and turns the cluster directories into overlays of those bases",
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := sgr.splitGitOpsRepo(cmd.Context()); err != nil {
+			return fmt.Errorf("failed to split gitops repository: %v", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	splitCmd.AddCommand(splitGitOpsRepoCmd)
+	applyClusterOptionFlags(splitGitOpsRepoCmd.Flags(), &sgr.clusterOptions)
+	splitGitOpsRepoCmd.Flags().BoolVar(&sgr.gitOpsPullRequest, "gitops-pull-request", false, "Open a pull request with the split repository instead of pushing it to the GitOps branch")
+	splitGitOpsRepoCmd.Flags().DurationVar(&sgr.gitOpsPullRequestTimeout, "gitops-pull-request-timeout", 0, "Time to wait for the GitOps pull request to be merged. When not set, the pull request URL is returned without waiting")
+	if err := splitGitOpsRepoCmd.MarkFlagRequired("filename"); err != nil {
+		log.Fatalf("Error marking filename flag as required: %v", err)
+	}
+}
+
+func (o *splitGitOpsRepoOptions) splitGitOpsRepo(ctx context.Context) error {
+	clusterSpec, err := newClusterSpec(o.clusterOptions)
+	if err != nil {
+		return err
+	}
+
+	fluxConfig := gitOpsRepositoryConfig(clusterSpec)
+	if fluxConfig == nil {
+		return fmt.Errorf("cluster %s does not have a GitOps configuration", clusterSpec.Cluster.Name)
+	}
+	if o.gitOpsPullRequest {
+		if err := validations.ValidateGitOpsPullRequest(clusterSpec); err != nil {
+			return err
+		}
+	}
+	// The Argo CD repository has the same layout as the Flux one.
+	clusterSpec.FluxConfig = fluxConfig
+
+	cliConfig := buildCliConfig(clusterSpec)
+	cliConfig.GitOpsPullRequest = o.gitOpsPullRequest
+	cliConfig.GitOpsPullRequestTimeout = o.gitOpsPullRequestTimeout
+	dirs, err := o.directoriesToMount(clusterSpec, cliConfig)
+	if err != nil {
+		return err
+	}
+
+	deps, err := dependencies.ForSpec(ctx, clusterSpec).WithExecutableMountDirs(dirs...).
+		WithCliConfig(cliConfig).
+		WithGitOpsFlux(clusterSpec.Cluster, fluxConfig, cliConfig).
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	return deps.GitOpsFlux.SplitRepository(ctx, clusterSpec)
+}
@@ -4,10 +4,12 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/dependencies"
 	"github.com/aws/eks-anywhere/pkg/kubeconfig"
 	"github.com/aws/eks-anywhere/pkg/types"
@@ -19,11 +21,15 @@ import (
 type upgradeClusterOptions struct {
 	clusterOptions
 	timeoutOptions
-	wConfig               string
-	forceClean            bool
-	hardwareCSVPath       string
-	tinkerbellBootstrapIP string
-	skipValidations       []string
+	eventsOptions
+	dryRunOptions
+	wConfig                  string
+	forceClean               bool
+	hardwareCSVPath          string
+	tinkerbellBootstrapIP    string
+	skipValidations          []string
+	gitOpsPullRequest        bool
+	gitOpsPullRequestTimeout time.Duration
 }
 
 var uc = &upgradeClusterOptions{}
@@ -35,10 +41,12 @@ var upgradeClusterCmd = &cobra.Command{
 	PreRunE:      bindFlagsToViper,
 	SilenceUsage: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		if err := uc.upgradeCluster(cmd); err != nil {
-			return fmt.Errorf("failed to upgrade cluster: %v", err)
-		}
-		return nil
+		return uc.runWithEvents("upgrade", func() error {
+			if err := uc.upgradeCluster(cmd); err != nil {
+				return fmt.Errorf("failed to upgrade cluster: %v", err)
+			}
+			return nil
+		})
 	},
 }
 
@@ -46,10 +54,14 @@ func init() {
 	upgradeCmd.AddCommand(upgradeClusterCmd)
 	applyClusterOptionFlags(upgradeClusterCmd.Flags(), &uc.clusterOptions)
 	applyTimeoutFlags(upgradeClusterCmd.Flags(), &uc.timeoutOptions)
+	applyEventsFlags(upgradeClusterCmd.Flags(), &uc.eventsOptions)
+	applyDryRunFlags(upgradeClusterCmd.Flags(), &uc.dryRunOptions)
 	applyTinkerbellHardwareFlag(upgradeClusterCmd.Flags(), &uc.hardwareCSVPath)
 	upgradeClusterCmd.Flags().StringVarP(&uc.wConfig, "w-config", "w", "", "Kubeconfig file to use when upgrading a workload cluster")
 	upgradeClusterCmd.Flags().BoolVar(&uc.forceClean, "force-cleanup", false, "Force deletion of previously created bootstrap cluster")
 	upgradeClusterCmd.Flags().StringArrayVar(&uc.skipValidations, "skip-validations", []string{}, "Bypass upgrade validations by name. Valid arguments you can pass are --skip-validations=pod-disruption")
+	upgradeClusterCmd.Flags().BoolVar(&uc.gitOpsPullRequest, "gitops-pull-request", false, "Open a pull request with the updated cluster config instead of pushing it to the GitOps branch")
+	upgradeClusterCmd.Flags().DurationVar(&uc.gitOpsPullRequestTimeout, "gitops-pull-request-timeout", 0, "Time to wait for the GitOps pull request to be merged. When not set, the pull request URL is returned without waiting and GitOps reconcile stays paused")
 
 	if err := upgradeClusterCmd.MarkFlagRequired("filename"); err != nil {
 		log.Fatalf("Error marking flag as required: %v", err)
@@ -87,7 +99,15 @@ func (uc *upgradeClusterOptions) upgradeCluster(cmd *cobra.Command) error {
 		return err
 	}
 
+	if uc.gitOpsPullRequest {
+		if err := validations.ValidateGitOpsPullRequest(clusterSpec); err != nil {
+			return err
+		}
+	}
+
 	cliConfig := buildCliConfig(clusterSpec)
+	cliConfig.GitOpsPullRequest = uc.gitOpsPullRequest
+	cliConfig.GitOpsPullRequestTimeout = uc.gitOpsPullRequestTimeout
 	dirs, err := uc.directoriesToMount(clusterSpec, cliConfig)
 	if err != nil {
 		return err
@@ -104,7 +124,7 @@ func (uc *upgradeClusterOptions) upgradeCluster(cmd *cobra.Command) error {
 		WithClusterManager(clusterSpec.Cluster, clusterManagerTimeoutOpts).
 		WithKubeProxyCLIUpgrader().
 		WithProvider(uc.fileName, clusterSpec.Cluster, cc.skipIpCheck, uc.hardwareCSVPath, uc.forceClean, uc.tinkerbellBootstrapIP).
-		WithGitOpsFlux(clusterSpec.Cluster, clusterSpec.FluxConfig, cliConfig).
+		WithGitOps(clusterSpec, cliConfig).
 		WithWriter().
 		WithCAPIManager().
 		WithEksdUpgrader().
@@ -116,6 +136,10 @@ func (uc *upgradeClusterOptions) upgradeCluster(cmd *cobra.Command) error {
 		factory.WithNoTimeouts()
 	}
 
+	if uc.dryRun {
+		factory.WithDryRun()
+	}
+
 	deps, err := factory.Build(ctx)
 	if err != nil {
 		return err
@@ -127,7 +151,7 @@ func (uc *upgradeClusterOptions) upgradeCluster(cmd *cobra.Command) error {
 		deps.Provider,
 		deps.CAPIManager,
 		deps.ClusterManager,
-		deps.GitOpsFlux,
+		deps.GitOps,
 		deps.Writer,
 		deps.EksdUpgrader,
 		deps.EksdInstaller,
@@ -163,11 +187,42 @@ func (uc *upgradeClusterOptions) upgradeCluster(cmd *cobra.Command) error {
 	}
 	upgradeValidations := upgradevalidations.New(validationOpts)
 
+	if uc.dryRun {
+		if err := upgradeCluster.Validate(ctx, clusterSpec, managementCluster, upgradeValidations); err != nil {
+			return err
+		}
+		return uc.renderUpgrade(ctx, factory, deps, clusterSpec, managementCluster, clusterManagerTimeoutOpts)
+	}
+
 	err = upgradeCluster.Run(ctx, clusterSpec, managementCluster, workloadCluster, upgradeValidations, uc.forceClean)
 	cleanup(deps, &err)
 	return err
 }
 
+// renderUpgrade writes the manifests the upgrade would apply, without upgrading the cluster.
+// The current state of the cluster is read from the management cluster.
+func (uc *upgradeClusterOptions) renderUpgrade(ctx context.Context, factory *dependencies.Factory, deps *dependencies.Dependencies, clusterSpec *cluster.Spec, managementCluster *types.Cluster, timeoutOpts *dependencies.ClusterManagerTimeoutOptions) error {
+	currentSpec, err := deps.ClusterManager.GetCurrentClusterSpec(ctx, managementCluster, clusterSpec.Cluster.Name)
+	if err != nil {
+		return fmt.Errorf("getting current cluster spec: %v", err)
+	}
+
+	deps, err = factory.
+		WithDryRunRenderer(clusterSpec, deps.Provider, uc.outputDirectory(clusterSpec.Cluster.Name), "", timeoutOpts).
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+
+	paths, err := deps.DryRunRenderer.RenderUpgrade(ctx, managementCluster, managementCluster, currentSpec, clusterSpec)
+	if err != nil {
+		return fmt.Errorf("rendering manifests: %v", err)
+	}
+	logDryRunManifests(paths)
+
+	return nil
+}
+
 func (uc *upgradeClusterOptions) commonValidations(ctx context.Context) (cluster *v1alpha1.Cluster, err error) {
 	clusterConfig, err := commonValidation(ctx, uc.fileName)
 	if err != nil {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"os"
 
 	"github.com/spf13/cobra"
 
@@ -18,6 +19,7 @@ type upgradePackageOptions struct {
 	kubeConfig      string
 	clusterName     string
 	bundlesOverride string
+	dryRun          bool
 }
 
 var upo = &upgradePackageOptions{}
@@ -33,6 +35,8 @@ func init() {
 		"", "Cluster to upgrade.")
 	upgradePackagesCommand.Flags().StringVar(&upo.bundlesOverride, "bundles-override", "",
 		"Override default Bundles manifest (not recommended)")
+	upgradePackagesCommand.Flags().BoolVar(&upo.dryRun, dryRunFlag, false,
+		"Validate the installed packages against the new bundle and print the changes without upgrading")
 
 	err := upgradePackagesCommand.MarkFlagRequired("bundle-version")
 	if err != nil {
@@ -73,5 +77,15 @@ func upgradePackages(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	plan, err := b.ReviewUpgrade(ctx, activeController, upo.bundleVersion, os.Stdout)
+	if err != nil {
+		return err
+	}
+	if err = plan.ValidateConfig(); err != nil {
+		return err
+	}
+	if upo.dryRun {
+		return nil
+	}
 	return b.UpgradeBundle(ctx, activeController, upo.bundleVersion)
 }
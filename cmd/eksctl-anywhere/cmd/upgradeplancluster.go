@@ -6,19 +6,17 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"strings"
 	"text/tabwriter"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 
-	capiupgrader "github.com/aws/eks-anywhere/pkg/clusterapi"
-	eksaupgrader "github.com/aws/eks-anywhere/pkg/clustermanager"
 	"github.com/aws/eks-anywhere/pkg/dependencies"
-	fluxupgrader "github.com/aws/eks-anywhere/pkg/gitops/flux"
 	"github.com/aws/eks-anywhere/pkg/logger"
-	"github.com/aws/eks-anywhere/pkg/networking/cilium"
 	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/upgradeplan"
 )
 
 const (
@@ -33,7 +31,7 @@ var output string
 var upgradePlanClusterCmd = &cobra.Command{
 	Use:          "cluster",
 	Short:        "Provides new release versions for the next cluster upgrade",
-	Long:         "Provides a list of target versions for upgrading the core components in the workload cluster",
+	Long:         "Provides a list of target versions for upgrading the core components in the workload cluster, the nodes that will be rolled out and why, and the changes to the CAPI and EKS-A objects",
 	PreRunE:      preRunUpgradePlanCluster,
 	SilenceUsage: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -76,11 +74,15 @@ func (uc *upgradeClusterOptions) upgradePlanCluster(ctx context.Context) error {
 		return err
 	}
 
+	// The provider reads the machine templates in the cluster to decide which ones need to be rotated.
+	// With dry run, it's not allowed to change anything in the cluster while doing so.
 	deps, err := dependencies.ForSpec(ctx, newClusterSpec).
+		WithDryRun().
 		WithClusterManager(newClusterSpec.Cluster, nil).
 		WithProvider(uc.fileName, newClusterSpec.Cluster, false, uc.hardwareCSVPath, uc.forceClean, uc.tinkerbellBootstrapIP).
 		WithGitOpsFlux(newClusterSpec.Cluster, newClusterSpec.FluxConfig, nil).
 		WithCAPIManager().
+		WithKubectl().
 		Build(ctx)
 	if err != nil {
 		return err
@@ -101,62 +103,104 @@ func (uc *upgradeClusterOptions) upgradePlanCluster(ctx context.Context) error {
 		return err
 	}
 
-	componentChangeDiffs := eksaupgrader.EksaChangeDiff(currentSpec, newClusterSpec)
-	if componentChangeDiffs == nil {
-		componentChangeDiffs = &types.ChangeDiff{}
+	plan, err := upgradeplan.NewPlanner(deps.Kubectl, deps.Provider).Plan(ctx, managementCluster, currentSpec, newClusterSpec)
+	if err != nil {
+		return fmt.Errorf("building upgrade plan: %v", err)
 	}
-	componentChangeDiffs.Append(fluxupgrader.FluxChangeDiff(currentSpec, newClusterSpec))
-	componentChangeDiffs.Append(capiupgrader.CapiChangeDiff(currentSpec, newClusterSpec, deps.Provider))
-	componentChangeDiffs.Append(cilium.ChangeDiff(currentSpec, newClusterSpec))
 
-	serializedDiff, err := serialize(componentChangeDiffs, output)
+	serializedPlan, err := serialize(plan, output)
 	if err != nil {
 		return err
 	}
 
-	fmt.Print(serializedDiff)
+	fmt.Print(serializedPlan)
 
 	return nil
 }
 
-func serialize(componentChangeDiffs *types.ChangeDiff, outputFormat string) (string, error) {
+func serialize(plan *upgradeplan.Plan, outputFormat string) (string, error) {
 	switch outputFormat {
 	case outputText:
-		return serializeToText(componentChangeDiffs)
+		return serializeToText(plan)
 	case outputJson:
-		return serializeToJson(componentChangeDiffs)
+		return serializeToJson(plan)
 	default:
 		return "", fmt.Errorf("invalid output format [%s]", outputFormat)
 	}
 }
 
-func serializeToText(componentChangeDiffs *types.ChangeDiff) (string, error) {
-	if componentChangeDiffs == nil {
-		return "All the components are up to date with the latest versions", nil
+func serializeToText(plan *upgradeplan.Plan) (string, error) {
+	if plan.UpToDate() {
+		return "All the components are up to date with the latest versions\n", nil
 	}
 
 	buffer := bytes.Buffer{}
 	w := tabwriter.NewWriter(&buffer, 10, 4, 3, ' ', 0)
 	fmt.Fprintln(w, "NAME\tCURRENT VERSION\tNEXT VERSION")
-	for i := range componentChangeDiffs.ComponentReports {
-		fmt.Fprintf(w, "%s\t%s\t%s\n", componentChangeDiffs.ComponentReports[i].ComponentName, componentChangeDiffs.ComponentReports[i].OldVersion, componentChangeDiffs.ComponentReports[i].NewVersion)
+	for _, c := range plan.Components {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", c.ComponentName, c.OldVersion, c.NewVersion)
+	}
+	if err := w.Flush(); err != nil {
+		return "", fmt.Errorf("failed flushing table writer: %v", err)
+	}
+
+	fmt.Fprintln(&buffer)
+	w = tabwriter.NewWriter(&buffer, 10, 4, 3, ' ', 0)
+	fmt.Fprintln(w, "NODES\tROLLOUT\tREASONS")
+	for _, r := range append([]upgradeplan.Rollout{plan.ControlPlane}, plan.WorkerNodeGroups...) {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", r.Name, rolloutStatus(r), rolloutReasons(r))
 	}
 	if err := w.Flush(); err != nil {
 		return "", fmt.Errorf("failed flushing table writer: %v", err)
 	}
 
+	fmt.Fprintln(&buffer)
+	fmt.Fprintf(&buffer, "CNI upgrade: %s\n", yesNo(plan.CNIUpgrade))
+	fmt.Fprintf(&buffer, "CAPI move to bootstrap cluster: %s\n", yesNo(plan.CAPIMove))
+
+	for _, d := range plan.Diffs {
+		fmt.Fprintf(&buffer, "\n%s %s %s/%s\n", d.Change, d.Kind, d.Namespace, d.Name)
+		buffer.WriteString(d.Diff)
+	}
+
 	return buffer.String(), nil
 }
 
-func serializeToJson(componentChangeDiffs *types.ChangeDiff) (string, error) {
-	if componentChangeDiffs == nil {
-		componentChangeDiffs = &types.ChangeDiff{ComponentReports: []types.ComponentChangeDiff{}}
+func rolloutStatus(r upgradeplan.Rollout) string {
+	switch {
+	case r.Removed:
+		return "removed"
+	case r.Rolls:
+		return "yes"
+	default:
+		return "no"
+	}
+}
+
+func rolloutReasons(r upgradeplan.Rollout) string {
+	reasons := make([]string, 0, len(r.Reasons))
+	for _, reason := range r.Reasons {
+		reasons = append(reasons, reason.Message)
+	}
+	return strings.Join(reasons, "; ")
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
+
+func serializeToJson(plan *upgradeplan.Plan) (string, error) {
+	if plan.Components == nil {
+		plan.Components = []types.ComponentChangeDiff{}
 	}
 
-	jsonDiff, err := json.Marshal(componentChangeDiffs)
+	jsonPlan, err := json.Marshal(plan)
 	if err != nil {
-		return "", fmt.Errorf("failed serializing the components diff to json: %v", err)
+		return "", fmt.Errorf("failed serializing the upgrade plan to json: %v", err)
 	}
 
-	return string(jsonDiff), nil
+	return string(jsonPlan), nil
 }
@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/curatedpackages"
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+)
+
+type upgradePlanPackagesOptions struct {
+	bundleVersion string
+	// kubeConfig is an optional kubeconfig file to use when querying an
+	// existing cluster.
+	kubeConfig      string
+	clusterName     string
+	bundlesOverride string
+	output          string
+}
+
+var uppo = &upgradePlanPackagesOptions{}
+
+func init() {
+	upgradePlanCmd.AddCommand(upgradePlanPackagesCommand)
+
+	upgradePlanPackagesCommand.Flags().StringVar(&uppo.bundleVersion, "bundle-version",
+		"", "Bundle version to upgrade to")
+	upgradePlanPackagesCommand.Flags().StringVar(&uppo.kubeConfig, "kubeconfig",
+		"", "Path to an optional kubeconfig file to use.")
+	upgradePlanPackagesCommand.Flags().StringVar(&uppo.clusterName, "cluster",
+		"", "Cluster to upgrade.")
+	upgradePlanPackagesCommand.Flags().StringVar(&uppo.bundlesOverride, "bundles-override", "",
+		"Override default Bundles manifest (not recommended)")
+	upgradePlanPackagesCommand.Flags().StringVarP(&uppo.output, outputFlagName, "o", outputDefault, "Output format: text|json")
+
+	if err := upgradePlanPackagesCommand.MarkFlagRequired("bundle-version"); err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+	if err := upgradePlanPackagesCommand.MarkFlagRequired("cluster"); err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+var upgradePlanPackagesCommand = &cobra.Command{
+	Use:          "packages",
+	Short:        "Provides the package versions for the next curated packages upgrade",
+	Long:         "Provides the current and target versions of the curated packages installed in the cluster when upgrading to a bundle version, and the config keys not supported by the target versions",
+	PreRunE:      preRunPackages,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := upgradePlanPackages(cmd.Context()); err != nil {
+			return fmt.Errorf("failed to display upgrade plan: %v", err)
+		}
+		return nil
+	},
+}
+
+func upgradePlanPackages(ctx context.Context) error {
+	kubeConfig, err := kubeconfig.ResolveAndValidateFilename(uppo.kubeConfig, "")
+	if err != nil {
+		return err
+	}
+
+	deps, err := NewDependenciesForPackages(ctx, WithMountPaths(kubeConfig), WithBundlesOverride(uppo.bundlesOverride))
+	if err != nil {
+		return fmt.Errorf("unable to initialize executables: %v", err)
+	}
+
+	b := curatedpackages.NewBundleReader(kubeConfig, uppo.clusterName, deps.Kubectl, nil, nil)
+	activeController, err := b.GetActiveController(ctx)
+	if err != nil {
+		return err
+	}
+
+	plan, err := b.PlanUpgrade(ctx, activeController, uppo.bundleVersion)
+	if err != nil {
+		return fmt.Errorf("building upgrade plan: %v", err)
+	}
+
+	serializedPlan, err := serializePackagesPlan(plan, uppo.output)
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(serializedPlan)
+
+	return nil
+}
+
+func serializePackagesPlan(plan *curatedpackages.PackagesUpgradePlan, outputFormat string) (string, error) {
+	switch outputFormat {
+	case outputText:
+		return serializePackagesPlanToText(plan)
+	case outputJson:
+		jsonPlan, err := json.Marshal(plan)
+		if err != nil {
+			return "", fmt.Errorf("failed serializing the upgrade plan to json: %v", err)
+		}
+		return string(jsonPlan), nil
+	default:
+		return "", fmt.Errorf("invalid output format [%s]", outputFormat)
+	}
+}
+
+func serializePackagesPlanToText(plan *curatedpackages.PackagesUpgradePlan) (string, error) {
+	buffer := bytes.Buffer{}
+	fmt.Fprintf(&buffer, "Bundle: %s -> %s\n", plan.ActiveBundle, plan.TargetBundle)
+	if plan.UpToDate() {
+		fmt.Fprintln(&buffer, "All the packages are up to date with the target bundle")
+		return buffer.String(), nil
+	}
+
+	fmt.Fprintln(&buffer)
+	w := tabwriter.NewWriter(&buffer, 10, 4, 3, ' ', 0)
+	fmt.Fprintln(w, "NAME\tPACKAGE\tCURRENT VERSION\tNEXT VERSION\tDROPPED CONFIG KEYS")
+	for _, p := range plan.Packages {
+		dropped := strings.Join(p.DroppedConfigKeys, ", ")
+		if dropped == "" {
+			dropped = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", p.Name, p.PackageName, p.CurrentVersion, p.TargetVersion, dropped)
+	}
+	if err := w.Flush(); err != nil {
+		return "", fmt.Errorf("failed flushing table writer: %v", err)
+	}
+
+	return buffer.String(), nil
+}
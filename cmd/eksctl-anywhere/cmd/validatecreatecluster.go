@@ -74,7 +74,7 @@ func (valOpt *validateOptions) validateCreateCluster(cmd *cobra.Command, _ []str
 		WithDocker().
 		WithKubectl().
 		WithProvider(valOpt.fileName, clusterSpec.Cluster, false, valOpt.hardwareCSVPath, true, valOpt.tinkerbellBootstrapIP).
-		WithGitOpsFlux(clusterSpec.Cluster, clusterSpec.FluxConfig, cliConfig).
+		WithGitOps(clusterSpec, cliConfig).
 		WithUnAuthKubeClient().
 		WithValidatorClients().
 		Build(ctx)
@@ -98,7 +98,7 @@ func (valOpt *validateOptions) validateCreateCluster(cmd *cobra.Command, _ []str
 
 	createValidations := createvalidations.New(validationOpts)
 
-	commandVal := createcluster.NewValidations(clusterSpec, deps.Provider, deps.GitOpsFlux, createValidations, deps.DockerClient)
+	commandVal := createcluster.NewValidations(clusterSpec, deps.Provider, deps.GitOps, createValidations, deps.DockerClient)
 	err = commandVal.Validate(ctx)
 
 	cleanupDirectory(tmpPath)
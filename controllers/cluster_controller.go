@@ -7,10 +7,12 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/tools/record"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/patch"
@@ -39,6 +41,16 @@ const (
 	defaultRequeueTime = time.Minute
 	// ClusterFinalizerName is the finalizer added to clusters to handle deletion.
 	ClusterFinalizerName = "clusters.anywhere.eks.amazonaws.com/finalizer"
+
+	// etcdBackupStatusRefreshInterval is how often the status of the scheduled etcd backups is refreshed.
+	etcdBackupStatusRefreshInterval = 5 * time.Minute
+
+	// certificatesStatusRefreshInterval is how often the certificates expiry status is refreshed.
+	certificatesStatusRefreshInterval = time.Hour
+
+	// registryCredentialsStatusRefreshInterval is how often the status of a registry credentials rotation
+	// is refreshed while it's in progress.
+	registryCredentialsStatusRefreshInterval = 30 * time.Second
 )
 
 // ClusterReconciler reconciles a Cluster object.
@@ -48,6 +60,9 @@ type ClusterReconciler struct {
 	awsIamAuth                 AWSIamConfigReconciler
 	clusterValidator           ClusterValidator
 	packagesClient             PackagesClient
+	etcdBackup                 EtcdBackupReconciler
+	registryCredentials        RegistryCredentialsReconciler
+	recorder                   record.EventRecorder
 
 	// experimentalSelfManagedUpgrade enables management cluster full upgrades.
 	// The default behavior for management cluster only reconciles the worker nodes.
@@ -63,6 +78,7 @@ type PackagesClient interface {
 	EnableFullLifecycle(ctx context.Context, log logr.Logger, clusterName, kubeConfig string, chart *v1alpha1.Image, registry *registrymirror.RegistryMirror, options ...curatedpackages.PackageControllerClientOpt) error
 	ReconcileDelete(context.Context, logr.Logger, curatedpackages.KubeDeleter, *anywherev1.Cluster) error
 	Reconcile(context.Context, logr.Logger, client.Client, *anywherev1.Cluster) error
+	ReconcilePackages(context.Context, logr.Logger, client.Client, *anywherev1.Cluster) error
 }
 
 type ProviderClusterReconcilerRegistry interface {
@@ -76,6 +92,19 @@ type AWSIamConfigReconciler interface {
 	ReconcileDelete(ctx context.Context, logger logr.Logger, cluster *anywherev1.Cluster) error
 }
 
+// EtcdBackupReconciler manages the scheduled etcd backups for an eks-a cluster.
+type EtcdBackupReconciler interface {
+	Reconcile(ctx context.Context, logger logr.Logger, cluster *anywherev1.Cluster) (controller.Result, error)
+	UpdateStatus(ctx context.Context, logger logr.Logger, cluster *anywherev1.Cluster) error
+}
+
+// RegistryCredentialsReconciler pushes the registry mirror credentials from the Secret referenced
+// by an eks-a cluster to its nodes.
+type RegistryCredentialsReconciler interface {
+	Reconcile(ctx context.Context, logger logr.Logger, cluster *anywherev1.Cluster) (controller.Result, error)
+	UpdateStatus(ctx context.Context, logger logr.Logger, cluster *anywherev1.Cluster) error
+}
+
 // ClusterValidator runs cluster level preflight validations before it goes to provider reconciler.
 type ClusterValidator interface {
 	ValidateManagementClusterName(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster) error
@@ -109,6 +138,30 @@ func WithExperimentalSelfManagedClusterUpgrades(exp bool) ClusterReconcilerOptio
 	}
 }
 
+// WithEtcdBackupReconciler allows to configure the reconciler for the scheduled etcd backups.
+// If not set, BackupConfiguration is ignored.
+func WithEtcdBackupReconciler(etcdBackup EtcdBackupReconciler) ClusterReconcilerOption {
+	return func(c *ClusterReconciler) {
+		c.etcdBackup = etcdBackup
+	}
+}
+
+// WithRegistryCredentialsReconciler allows to configure the reconciler for the registry mirror
+// credentials rotation. If not set, the registry mirror CredentialsRef is ignored.
+func WithRegistryCredentialsReconciler(registryCredentials RegistryCredentialsReconciler) ClusterReconcilerOption {
+	return func(c *ClusterReconciler) {
+		c.registryCredentials = registryCredentials
+	}
+}
+
+// WithEventRecorder allows to configure the recorder for the events emitted for clusters,
+// like when their certificates are about to expire. If not set, no events are emitted.
+func WithEventRecorder(recorder record.EventRecorder) ClusterReconcilerOption {
+	return func(c *ClusterReconciler) {
+		c.recorder = recorder
+	}
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *ClusterReconciler) SetupWithManager(mgr ctrl.Manager, log logr.Logger) error {
 	childObjectHandler := handlers.ChildObjectToClusters(log)
@@ -175,6 +228,10 @@ func (r *ClusterReconciler) SetupWithManager(mgr ctrl.Manager, log logr.Logger)
 			&source.Kind{Type: &anywherev1.NutanixMachineConfig{}},
 			handler.EnqueueRequestsFromMapFunc(childObjectHandler),
 		).
+		Watches(
+			&source.Kind{Type: &corev1.Secret{}},
+			handler.EnqueueRequestsFromMapFunc(handlers.RegistryCredentialsSecretToClusters(mgr.GetClient(), log)),
+		).
 		Complete(r)
 }
 
@@ -184,13 +241,14 @@ func (r *ClusterReconciler) SetupWithManager(mgr ctrl.Manager, log logr.Logger)
 // +kubebuilder:rbac:groups="",resources=namespaces,verbs=create;delete
 // +kubebuilder:rbac:groups="",resources=nodes,verbs=list
 // +kubebuilder:rbac:groups=addons.cluster.x-k8s.io,resources=clusterresourcesets,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups=anywhere.eks.amazonaws.com,resources=clusters;gitopsconfigs;snowmachineconfigs;snowdatacenterconfigs;snowippools;vspheredatacenterconfigs;vspheremachineconfigs;dockerdatacenterconfigs;tinkerbellmachineconfigs;tinkerbelldatacenterconfigs;cloudstackdatacenterconfigs;cloudstackmachineconfigs;nutanixdatacenterconfigs;nutanixmachineconfigs;awsiamconfigs;oidcconfigs;awsiamconfigs;fluxconfigs,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=anywhere.eks.amazonaws.com,resources=clusters;gitopsconfigs;snowmachineconfigs;snowdatacenterconfigs;snowippools;vspheredatacenterconfigs;vspheremachineconfigs;dockerdatacenterconfigs;tinkerbellmachineconfigs;tinkerbelldatacenterconfigs;cloudstackdatacenterconfigs;cloudstackmachineconfigs;nutanixdatacenterconfigs;nutanixmachineconfigs;awsiamconfigs;oidcconfigs;awsiamconfigs;fluxconfigs;argocdconfigs,verbs=get;list;watch;update;patch
 // +kubebuilder:rbac:groups=anywhere.eks.amazonaws.com,resources=clusters/status;snowmachineconfigs/status;snowippools/status;vspheredatacenterconfigs/status;vspheremachineconfigs/status;dockerdatacenterconfigs/status;tinkerbelldatacenterconfigs/status;tinkerbellmachineconfigs/status;cloudstackdatacenterconfigs/status;cloudstackmachineconfigs/status;awsiamconfigs/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=anywhere.eks.amazonaws.com,resources=bundles,verbs=get;list;watch
 // +kubebuilder:rbac:groups=anywhere.eks.amazonaws.com,resources=clusters/finalizers;snowmachineconfigs/finalizers;snowippools/finalizers;vspheredatacenterconfigs/finalizers;vspheremachineconfigs/finalizers;cloudstackdatacenterconfigs/finalizers;cloudstackmachineconfigs/finalizers;dockerdatacenterconfigs/finalizers;bundles/finalizers;awsiamconfigs/finalizers;tinkerbelldatacenterconfigs/finalizers;tinkerbellmachineconfigs/finalizers,verbs=update
 // +kubebuilder:rbac:groups=bootstrap.cluster.x-k8s.io,resources=kubeadmconfigtemplates,verbs=create;get;list;patch;update;watch
 // +kubebuilder:rbac:groups="cluster.x-k8s.io",resources=machinedeployments,verbs=list;watch;get;patch;update;create;delete
 // +kubebuilder:rbac:groups="cluster.x-k8s.io",resources=clusters,verbs=list;watch;get;patch;update;create;delete
+// +kubebuilder:rbac:groups="cluster.x-k8s.io",resources=machines,verbs=list;watch;get
 // +kubebuilder:rbac:groups=clusterctl.cluster.x-k8s.io,resources=providers,verbs=get;list;watch
 // +kubebuilder:rbac:groups=controlplane.cluster.x-k8s.io,resources=kubeadmcontrolplanes,verbs=list;get;watch;patch;update;create;delete
 // +kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=create;get;list;update;watch;delete
@@ -252,6 +310,24 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (re
 		if reterr == nil && !result.Requeue && result.RequeueAfter <= 0 && conditions.IsFalse(cluster, anywherev1.ReadyCondition) {
 			result = ctrl.Result{RequeueAfter: 10 * time.Second}
 		}
+
+		// Scheduled etcd backups run independently of the cluster reconciliation, so we requeue
+		// periodically to keep their status up to date.
+		if reterr == nil && !result.Requeue && result.RequeueAfter <= 0 && cluster.Spec.BackupConfiguration != nil {
+			result = ctrl.Result{RequeueAfter: etcdBackupStatusRefreshInterval}
+		}
+
+		// Certificates get closer to their expiry without any change in the cluster objects, so we
+		// requeue periodically to keep the CertificatesExpiringSoon condition up to date.
+		if reterr == nil && !result.Requeue && result.RequeueAfter <= 0 && cluster.Status.Certificates != nil {
+			result = ctrl.Result{RequeueAfter: certificatesStatusRefreshInterval}
+		}
+
+		// Registry credentials are rotated by a DaemonSet in the workload cluster, so we requeue
+		// until its rollout finishes to know when the RegistryCredentialsRotated condition is true.
+		if reterr == nil && !result.Requeue && result.RequeueAfter <= 0 && conditions.IsFalse(cluster, anywherev1.RegistryCredentialsRotatedCondition) {
+			result = ctrl.Result{RequeueAfter: registryCredentialsStatusRefreshInterval}
+		}
 	}()
 
 	if !cluster.DeletionTimestamp.IsZero() {
@@ -282,6 +358,17 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (re
 		return ctrl.Result{}, err
 	}
 
+	// Changes in the registry credentials Secret don't bump the generation of the cluster
+	// or its child objects, so they are reconciled before checking the generations.
+	// Waiting for the credentials to be applied shouldn't block the rest of the reconciliation.
+	if r.registryCredentials != nil {
+		if result, err := r.registryCredentials.Reconcile(ctx, log, cluster); err != nil {
+			return ctrl.Result{}, err
+		} else if result.Return() {
+			log.Info("Registry credentials not applied yet, waiting for the control plane")
+		}
+	}
+
 	aggregatedGeneration := aggregatedGeneration(config)
 
 	// If there is no difference between the aggregated generation and childrenReconciledGeneration,
@@ -361,6 +448,8 @@ func (r *ClusterReconciler) preClusterProviderReconcile(ctx context.Context, log
 	}
 
 	if cluster.RegistryAuth() {
+		// The credentials of the CredentialsRef are pushed to the nodes by the registry credentials reconciler.
+		// They are kept out of the machine templates, so rotating them doesn't roll out the machines.
 		rUsername, rPassword, err := config.ReadCredentialsFromSecret(ctx, r.client)
 		if err != nil {
 			return controller.Result{}, err
@@ -371,6 +460,19 @@ func (r *ClusterReconciler) preClusterProviderReconcile(ctx context.Context, log
 		}
 	}
 
+	if cluster.Spec.RegistryMirrorConfiguration != nil {
+		for _, upstream := range registrymirror.FromCluster(cluster).AuthenticatedUpstreams() {
+			rUsername, rPassword, err := config.ReadUpstreamMirrorCredentialsFromSecret(ctx, r.client, upstream)
+			if err != nil {
+				return controller.Result{}, err
+			}
+
+			if err := config.SetUpstreamMirrorCredentialsEnv(upstream, rUsername, rPassword); err != nil {
+				return controller.Result{}, err
+			}
+		}
+	}
+
 	return controller.Result{}, nil
 }
 
@@ -383,12 +485,26 @@ func (r *ClusterReconciler) postClusterProviderReconcile(ctx context.Context, lo
 		}
 	}
 
-	// Self-managed clusters can support curated packages, but that support
-	// comes from the CLI at this time.
+	if r.etcdBackup != nil {
+		if result, err := r.etcdBackup.Reconcile(ctx, log, cluster); err != nil {
+			return controller.Result{}, err
+		} else if result.Return() {
+			return result, nil
+		}
+	}
+
+	// Self-managed clusters can support curated packages, but the package
+	// controller is installed from the CLI at this time. Only their packages
+	// list is reconciled here. Without a packages config the list is empty, so
+	// the packages declared before are removed.
 	if cluster.IsManaged() && cluster.IsPackagesEnabled() {
 		if err := r.packagesClient.Reconcile(ctx, log, r.client, cluster); err != nil {
 			return controller.Result{}, err
 		}
+	} else if cluster.IsSelfManaged() && cluster.IsPackagesEnabled() {
+		if err := r.packagesClient.ReconcilePackages(ctx, log, r.client, cluster); err != nil {
+			return controller.Result{}, err
+		}
 	}
 
 	return controller.Result{}, nil
@@ -414,6 +530,22 @@ func (r *ClusterReconciler) updateStatus(ctx context.Context, log logr.Logger, c
 
 	clusters.UpdateClusterStatusForCNI(ctx, cluster)
 
+	if err := r.updateCertificatesStatus(ctx, cluster); err != nil {
+		return errors.Wrap(err, "updating status for certificates")
+	}
+
+	if r.etcdBackup != nil && cluster.DeletionTimestamp.IsZero() {
+		if err := r.etcdBackup.UpdateStatus(ctx, log, cluster); err != nil {
+			return errors.Wrap(err, "updating status for etcd backups")
+		}
+	}
+
+	if r.registryCredentials != nil && cluster.DeletionTimestamp.IsZero() {
+		if err := r.registryCredentials.UpdateStatus(ctx, log, cluster); err != nil {
+			return errors.Wrap(err, "updating status for registry credentials")
+		}
+	}
+
 	// Always update the readyCondition by summarizing the state of other conditions.
 	conditions.SetSummary(cluster,
 		conditions.WithConditions(
@@ -426,6 +558,26 @@ func (r *ClusterReconciler) updateStatus(ctx context.Context, log logr.Logger, c
 	return nil
 }
 
+// updateCertificatesStatus updates the certificates expiry status and emits a warning event when the
+// CertificatesExpiringSoon condition becomes true or changes its reason.
+func (r *ClusterReconciler) updateCertificatesStatus(ctx context.Context, cluster *anywherev1.Cluster) error {
+	previous := conditions.Get(cluster, anywherev1.CertificatesExpiringSoonCondition)
+	if err := clusters.UpdateClusterStatusForCertificates(ctx, r.client, cluster); err != nil {
+		return err
+	}
+
+	current := conditions.Get(cluster, anywherev1.CertificatesExpiringSoonCondition)
+	if r.recorder == nil || current == nil || current.Status != corev1.ConditionTrue {
+		return nil
+	}
+
+	if previous == nil || previous.Status != current.Status || previous.Reason != current.Reason {
+		r.recorder.Event(cluster, corev1.EventTypeWarning, current.Reason, current.Message)
+	}
+
+	return nil
+}
+
 func (r *ClusterReconciler) reconcileDelete(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster) (ctrl.Result, error) {
 	if cluster.IsSelfManaged() {
 		return ctrl.Result{}, errors.New("deleting self-managed clusters is not supported")
@@ -524,6 +676,9 @@ func patchCluster(ctx context.Context, patchHelper *patch.Helper, cluster *anywh
 			anywherev1.ControlPlaneReadyCondition,
 			anywherev1.WorkersReadyConditon,
 			anywherev1.DefaultCNIConfiguredCondition,
+			anywherev1.EtcdBackupReadyCondition,
+			anywherev1.CertificatesExpiringSoonCondition,
+			anywherev1.RegistryCredentialsRotatedCondition,
 		}},
 	}, patchOpts...)
 
@@ -165,6 +165,7 @@ func TestClusterReconcilerReconcileSelfManagedCluster(t *testing.T) {
 	registry := newRegistryMock(providerReconciler)
 	c := fake.NewClientBuilder().WithRuntimeObjects(selfManagedCluster, kcp).Build()
 	mockPkgs := mocks.NewMockPackagesClient(controller)
+	mockPkgs.EXPECT().ReconcilePackages(ctx, gomock.AssignableToTypeOf(logr.Logger{}), c, sameName(selfManagedCluster)).Return(nil)
 	providerReconciler.EXPECT().ReconcileWorkerNodes(ctx, gomock.AssignableToTypeOf(logr.Logger{}), sameName(selfManagedCluster))
 
 	r := controllers.NewClusterReconciler(c, registry, iam, clusterValidator, mockPkgs)
@@ -566,6 +567,7 @@ func TestClusterReconcilerReconcileSelfManagedClusterConditions(t *testing.T) {
 			iam.EXPECT().Reconcile(logCtx, log, sameName(config.Cluster)).Return(controller.Result{}, nil)
 
 			providerReconciler.EXPECT().ReconcileWorkerNodes(gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
+			mockPkgs.EXPECT().ReconcilePackages(logCtx, log, testClient, sameName(config.Cluster)).Return(nil)
 
 			r := controllers.NewClusterReconciler(testClient, registry, iam, clusterValidator, mockPkgs)
 
@@ -715,6 +717,7 @@ func TestClusterReconcilerReconcileGenerations(t *testing.T) {
 				iam.EXPECT().EnsureCASecret(ctx, gomock.AssignableToTypeOf(logr.Logger{}), gomock.AssignableToTypeOf(config.Cluster)).Return(controller.Result{}, nil)
 				iam.EXPECT().Reconcile(ctx, gomock.AssignableToTypeOf(logr.Logger{}), gomock.AssignableToTypeOf(config.Cluster)).Return(controller.Result{}, nil)
 				providerReconciler.EXPECT().ReconcileWorkerNodes(ctx, gomock.AssignableToTypeOf(logr.Logger{}), sameName(config.Cluster)).Times(1)
+				mockPkgs.EXPECT().ReconcilePackages(ctx, gomock.AssignableToTypeOf(logr.Logger{}), client, sameName(config.Cluster)).Return(nil)
 			} else {
 				providerReconciler.EXPECT().ReconcileWorkerNodes(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
 			}
@@ -772,6 +775,7 @@ func TestClusterReconcilerReconcileSelfManagedClusterWithExperimentalUpgrades(t
 	registry := newRegistryMock(providerReconciler)
 	c := fake.NewClientBuilder().WithRuntimeObjects(selfManagedCluster, kcp).Build()
 	mockPkgs := mocks.NewMockPackagesClient(controller)
+	mockPkgs.EXPECT().ReconcilePackages(ctx, gomock.AssignableToTypeOf(logr.Logger{}), c, sameName(selfManagedCluster)).Return(nil)
 	providerReconciler.EXPECT().Reconcile(ctx, gomock.AssignableToTypeOf(logr.Logger{}), sameName(selfManagedCluster))
 
 	r := controllers.NewClusterReconciler(c, registry, iam, clusterValidator, mockPkgs,
@@ -896,6 +900,56 @@ func TestClusterReconcilerReconcileSelfManagedClusterRegAuthFailNoSecret(t *test
 	g.Expect(err).To(MatchError(ContainSubstring("fetching registry auth secret")))
 }
 
+func TestClusterReconcilerReconcileSelfManagedClusterUpstreamMirrorAuthFailNoCredentials(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	selfManagedCluster := &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-management-cluster",
+		},
+		Spec: anywherev1.ClusterSpec{
+			BundlesRef: &anywherev1.BundlesRef{
+				Name: "my-bundles-ref",
+			},
+			ClusterNetwork: anywherev1.ClusterNetwork{
+				CNIConfig: &anywherev1.CNIConfig{
+					Cilium: &anywherev1.CiliumConfig{},
+				},
+			},
+			RegistryMirrorConfiguration: &anywherev1.RegistryMirrorConfiguration{
+				Mirrors: []anywherev1.UpstreamRegistryMirror{
+					{Upstream: "quay.io", Endpoint: "cache.internal:5000/quay", Authenticate: true},
+				},
+			},
+		},
+		Status: anywherev1.ClusterStatus{
+			ReconciledGeneration: 1,
+		},
+	}
+	secret := &apiv1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "registry-credentials",
+			Namespace: constants.EksaSystemNamespace,
+		},
+		Data: map[string][]byte{
+			"username": []byte("username"),
+			"password": []byte("password"),
+		},
+	}
+
+	controller := gomock.NewController(t)
+	providerReconciler := mocks.NewMockProviderClusterReconciler(controller)
+	iam := mocks.NewMockAWSIamConfigReconciler(controller)
+	clusterValidator := mocks.NewMockClusterValidator(controller)
+	registry := newRegistryMock(providerReconciler)
+	c := fake.NewClientBuilder().WithRuntimeObjects(selfManagedCluster, secret).Build()
+
+	r := controllers.NewClusterReconciler(c, registry, iam, clusterValidator, nil)
+	_, err := r.Reconcile(ctx, clusterRequest(selfManagedCluster))
+	g.Expect(err).To(MatchError(ContainSubstring("registry auth secret doesn't have REGISTRY_USERNAME_QUAY_IO")))
+}
+
 func TestClusterReconcilerDeleteExistingCAPIClusterSuccess(t *testing.T) {
 	secret := createSecret()
 	managementCluster := vsphereCluster()
@@ -1102,6 +1156,56 @@ func TestClusterReconcilerSkipDontInstallPackagesOnSelfManaged(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mockPkgs := mocks.NewMockPackagesClient(ctrl)
 	mockPkgs.EXPECT().ReconcileDelete(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	mockPkgs.EXPECT().Reconcile(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	// Without a packages config, the packages list is empty so the packages declared before are removed.
+	mockPkgs.EXPECT().ReconcilePackages(ctx, gomock.Any(), gomock.Any(), sameName(cluster)).Return(nil)
+	r := controllers.NewClusterReconciler(mockClient, nullRegistry, nil, nil, mockPkgs)
+	_, err := r.Reconcile(ctx, clusterRequest(cluster))
+	if err != nil {
+		t.Fatalf("expected err to be nil, got %s", err)
+	}
+}
+
+func TestClusterReconcilerReconcilePackagesListOnSelfManaged(t *testing.T) {
+	ctx := context.Background()
+	cluster := &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-cluster",
+			Namespace: "my-namespace",
+		},
+		Spec: anywherev1.ClusterSpec{
+			KubernetesVersion: "v1.25",
+			BundlesRef: &anywherev1.BundlesRef{
+				Name:      "my-bundles-ref",
+				Namespace: "my-namespace",
+			},
+			ClusterNetwork: anywherev1.ClusterNetwork{
+				CNIConfig: &anywherev1.CNIConfig{
+					Cilium: &anywherev1.CiliumConfig{},
+				},
+			},
+			ManagementCluster: anywherev1.ManagementCluster{
+				Name: "",
+			},
+			Packages: &anywherev1.PackageConfiguration{
+				Packages: []anywherev1.ClusterPackage{
+					{Name: "harbor", Version: "2.7.1"},
+				},
+			},
+		},
+		Status: anywherev1.ClusterStatus{
+			ReconciledGeneration: 1,
+		},
+	}
+	objs := []runtime.Object{cluster}
+	cb := fake.NewClientBuilder()
+	mockClient := cb.WithRuntimeObjects(objs...).Build()
+	nullRegistry := newRegistryForDummyProviderReconciler()
+
+	ctrl := gomock.NewController(t)
+	mockPkgs := mocks.NewMockPackagesClient(ctrl)
+	mockPkgs.EXPECT().Reconcile(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	mockPkgs.EXPECT().ReconcilePackages(ctx, gomock.Any(), gomock.Any(), sameName(cluster)).Return(nil)
 	r := controllers.NewClusterReconciler(mockClient, nullRegistry, nil, nil, mockPkgs)
 	_, err := r.Reconcile(ctx, clusterRequest(cluster))
 	if err != nil {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/golang/mock/gomock"
@@ -12,6 +13,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -149,6 +152,170 @@ func TestClusterReconcilerEnsureOwnerReferences(t *testing.T) {
 	g.Expect(newAWSIam.OwnerReferences[0]).To(Equal(awsIAM.OwnerReferences[0]))
 }
 
+func TestClusterReconcilerReconcileEtcdBackups(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	cluster, objs := etcdBackupsTestCluster()
+	cl := fake.NewClientBuilder().WithRuntimeObjects(objs...).Build()
+
+	validator := newMockClusterValidator(t)
+	validator.EXPECT().ValidateManagementClusterName(ctx, gomock.AssignableToTypeOf(logr.Logger{}), gomock.AssignableToTypeOf(cluster)).Return(nil)
+
+	etcdBackup := newMockEtcdBackupReconciler(t)
+	etcdBackup.EXPECT().Reconcile(ctx, gomock.AssignableToTypeOf(logr.Logger{}), gomock.AssignableToTypeOf(cluster)).Return(controller.Result{}, nil)
+	etcdBackup.EXPECT().UpdateStatus(ctx, gomock.AssignableToTypeOf(logr.Logger{}), gomock.AssignableToTypeOf(cluster)).Return(nil)
+
+	r := controllers.NewClusterReconciler(cl, newRegistryForDummyProviderReconciler(), newMockAWSIamConfigReconciler(t), validator, nil,
+		controllers.WithEtcdBackupReconciler(etcdBackup),
+	)
+	_, err := r.Reconcile(ctx, clusterRequest(cluster))
+	g.Expect(err).NotTo(HaveOccurred())
+}
+
+func TestClusterReconcilerReconcileEtcdBackupsError(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	cluster, objs := etcdBackupsTestCluster()
+	cl := fake.NewClientBuilder().WithRuntimeObjects(objs...).Build()
+
+	validator := newMockClusterValidator(t)
+	validator.EXPECT().ValidateManagementClusterName(ctx, gomock.AssignableToTypeOf(logr.Logger{}), gomock.AssignableToTypeOf(cluster)).Return(nil)
+
+	etcdBackup := newMockEtcdBackupReconciler(t)
+	etcdBackup.EXPECT().Reconcile(ctx, gomock.AssignableToTypeOf(logr.Logger{}), gomock.AssignableToTypeOf(cluster)).Return(controller.Result{}, errors.New("applying cronjob"))
+	etcdBackup.EXPECT().UpdateStatus(ctx, gomock.AssignableToTypeOf(logr.Logger{}), gomock.AssignableToTypeOf(cluster)).Return(errors.New("listing jobs"))
+
+	r := controllers.NewClusterReconciler(cl, newRegistryForDummyProviderReconciler(), newMockAWSIamConfigReconciler(t), validator, nil,
+		controllers.WithEtcdBackupReconciler(etcdBackup),
+	)
+	_, err := r.Reconcile(ctx, clusterRequest(cluster))
+	g.Expect(err).To(MatchError(ContainSubstring("applying cronjob")))
+	g.Expect(err).To(MatchError(ContainSubstring("updating status for etcd backups: listing jobs")))
+}
+
+func TestClusterReconcilerReconcileRegistryCredentialsWithReconciledGenerations(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	cluster, objs := etcdBackupsTestCluster()
+	cluster.Spec.BackupConfiguration = nil
+	cluster.Status.ReconciledGeneration = cluster.Generation
+	cl := fake.NewClientBuilder().WithRuntimeObjects(objs...).Build()
+
+	// Secret changes don't bump generations, so the credentials are reconciled anyway.
+	registryCredentials := newMockRegistryCredentialsReconciler(t)
+	registryCredentials.EXPECT().Reconcile(ctx, gomock.AssignableToTypeOf(logr.Logger{}), gomock.AssignableToTypeOf(cluster)).Return(controller.Result{}, nil)
+	registryCredentials.EXPECT().UpdateStatus(ctx, gomock.AssignableToTypeOf(logr.Logger{}), gomock.AssignableToTypeOf(cluster)).Return(nil)
+
+	r := controllers.NewClusterReconciler(cl, newRegistryForDummyProviderReconciler(), newMockAWSIamConfigReconciler(t), newMockClusterValidator(t), nil,
+		controllers.WithRegistryCredentialsReconciler(registryCredentials),
+	)
+	_, err := r.Reconcile(ctx, clusterRequest(cluster))
+	g.Expect(err).NotTo(HaveOccurred())
+}
+
+func TestClusterReconcilerReconcileRegistryCredentialsError(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	cluster, objs := etcdBackupsTestCluster()
+	cluster.Spec.BackupConfiguration = nil
+	cl := fake.NewClientBuilder().WithRuntimeObjects(objs...).Build()
+
+	registryCredentials := newMockRegistryCredentialsReconciler(t)
+	registryCredentials.EXPECT().Reconcile(ctx, gomock.AssignableToTypeOf(logr.Logger{}), gomock.AssignableToTypeOf(cluster)).Return(controller.Result{}, errors.New("reading secret"))
+	registryCredentials.EXPECT().UpdateStatus(ctx, gomock.AssignableToTypeOf(logr.Logger{}), gomock.AssignableToTypeOf(cluster)).Return(errors.New("reading daemonset"))
+
+	r := controllers.NewClusterReconciler(cl, newRegistryForDummyProviderReconciler(), newMockAWSIamConfigReconciler(t), newMockClusterValidator(t), nil,
+		controllers.WithRegistryCredentialsReconciler(registryCredentials),
+	)
+	_, err := r.Reconcile(ctx, clusterRequest(cluster))
+	g.Expect(err).To(MatchError(ContainSubstring("reading secret")))
+	g.Expect(err).To(MatchError(ContainSubstring("updating status for registry credentials: reading daemonset")))
+}
+
+func TestClusterReconcilerReconcileCertificatesExpiringSoonEvent(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	cluster, objs := etcdBackupsTestCluster()
+	cluster.Spec.BackupConfiguration = nil
+	machine := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-cluster-cp-1",
+			Namespace: constants.EksaSystemNamespace,
+			Labels: map[string]string{
+				clusterv1.ClusterNameLabel:         cluster.Name,
+				clusterv1.MachineControlPlaneLabel: "",
+			},
+		},
+		Status: clusterv1.MachineStatus{
+			CertificatesExpiryDate: &metav1.Time{Time: time.Now().Add(10 * 24 * time.Hour)},
+		},
+	}
+	cl := fake.NewClientBuilder().WithRuntimeObjects(append(objs, machine)...).Build()
+
+	validator := newMockClusterValidator(t)
+	validator.EXPECT().ValidateManagementClusterName(ctx, gomock.AssignableToTypeOf(logr.Logger{}), gomock.AssignableToTypeOf(cluster)).Return(nil)
+
+	recorder := record.NewFakeRecorder(10)
+	r := controllers.NewClusterReconciler(cl, newRegistryForDummyProviderReconciler(), newMockAWSIamConfigReconciler(t), validator, nil,
+		controllers.WithEventRecorder(recorder),
+	)
+	_, err := r.Reconcile(ctx, clusterRequest(cluster))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(recorder.Events).To(Receive(HavePrefix("Warning CertificatesExpiring Certificates in machine my-cluster-cp-1 expire at")))
+
+	api := envtest.NewAPIExpecter(t, cl)
+	c := envtest.CloneNameNamespace(cluster)
+	api.ShouldEventuallyMatch(ctx, c, func(g Gomega) {
+		g.Expect(c.Status.Certificates).NotTo(BeNil())
+		g.Expect(c.Status.Certificates.Machine).To(Equal("my-cluster-cp-1"))
+	})
+
+	// The event is only emitted when the condition changes
+	_, err = r.Reconcile(ctx, clusterRequest(cluster))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(recorder.Events).NotTo(Receive())
+}
+
+func etcdBackupsTestCluster() (*anywherev1.Cluster, []runtime.Object) {
+	managementCluster := &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-management-cluster",
+			Namespace: "my-namespace",
+		},
+	}
+
+	cluster := &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "my-cluster",
+			Namespace:  "my-namespace",
+			Generation: 1,
+		},
+		Spec: anywherev1.ClusterSpec{
+			KubernetesVersion: "v1.25",
+			BundlesRef: &anywherev1.BundlesRef{
+				Name:      "my-bundles-ref",
+				Namespace: "my-namespace",
+			},
+			Packages: &anywherev1.PackageConfiguration{
+				Disable: true,
+			},
+			BackupConfiguration: &anywherev1.BackupConfiguration{
+				Schedule: "@daily",
+			},
+		},
+	}
+	cluster.SetManagedBy("my-management-cluster")
+
+	bundles := &v1alpha1.Bundles{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-bundles-ref",
+			Namespace: cluster.Namespace,
+		},
+	}
+
+	return cluster, []runtime.Object{cluster, managementCluster, bundles}
+}
+
 func TestClusterReconcilerReconcileChildObjectNotFound(t *testing.T) {
 	g := NewWithT(t)
 	ctx := context.Background()
@@ -410,3 +577,13 @@ func newMockPackagesClient(t *testing.T) *mocks.MockPackagesClient {
 	ctrl := gomock.NewController(t)
 	return mocks.NewMockPackagesClient(ctrl)
 }
+
+func newMockEtcdBackupReconciler(t *testing.T) *mocks.MockEtcdBackupReconciler {
+	ctrl := gomock.NewController(t)
+	return mocks.NewMockEtcdBackupReconciler(ctrl)
+}
+
+func newMockRegistryCredentialsReconciler(t *testing.T) *mocks.MockRegistryCredentialsReconciler {
+	ctrl := gomock.NewController(t)
+	return mocks.NewMockRegistryCredentialsReconciler(ctrl)
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/crypto"
 	"github.com/aws/eks-anywhere/pkg/curatedpackages"
 	"github.com/aws/eks-anywhere/pkg/dependencies"
+	etcdbackupreconciler "github.com/aws/eks-anywhere/pkg/etcdbackup/reconciler"
 	"github.com/aws/eks-anywhere/pkg/executables"
 	"github.com/aws/eks-anywhere/pkg/executables/cmk"
 	ciliumreconciler "github.com/aws/eks-anywhere/pkg/networking/cilium/reconciler"
@@ -27,6 +28,7 @@ import (
 	snowreconciler "github.com/aws/eks-anywhere/pkg/providers/snow/reconciler"
 	tinkerbellreconciler "github.com/aws/eks-anywhere/pkg/providers/tinkerbell/reconciler"
 	vspherereconciler "github.com/aws/eks-anywhere/pkg/providers/vsphere/reconciler"
+	registrycredsreconciler "github.com/aws/eks-anywhere/pkg/registrycredentials/reconciler"
 )
 
 type Manager = manager.Manager
@@ -48,6 +50,8 @@ type Factory struct {
 	cniReconciler               *cnireconciler.Reconciler
 	ipValidator                 *clusters.IPValidator
 	awsIamConfigReconciler      *awsiamconfigreconciler.Reconciler
+	etcdBackupReconciler        *etcdbackupreconciler.Reconciler
+	registryCredsReconciler     *registrycredsreconciler.Reconciler
 	logger                      logr.Logger
 	deps                        *dependencies.Dependencies
 	packageControllerClient     *curatedpackages.PackageControllerClient
@@ -105,6 +109,8 @@ func (f *Factory) WithClusterReconciler(capiProviders []clusterctlv1.Provider, o
 	f.withTracker().
 		WithProviderClusterReconcilerRegistry(capiProviders).
 		withAWSIamConfigReconciler().
+		withEtcdBackupReconciler().
+		withRegistryCredentialsReconciler().
 		withPackageControllerClient()
 
 	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
@@ -118,7 +124,11 @@ func (f *Factory) WithClusterReconciler(capiProviders []clusterctlv1.Provider, o
 			f.awsIamConfigReconciler,
 			clusters.NewClusterValidator(f.manager.GetClient()),
 			f.packageControllerClient,
-			opts...,
+			append([]ClusterReconcilerOption{
+				WithEtcdBackupReconciler(f.etcdBackupReconciler),
+				WithRegistryCredentialsReconciler(f.registryCredsReconciler),
+				WithEventRecorder(f.manager.GetEventRecorderFor("cluster-controller")),
+			}, opts...)...,
 		)
 
 		return nil
@@ -512,6 +522,44 @@ func (f *Factory) withAWSIamConfigReconciler() *Factory {
 	return f
 }
 
+func (f *Factory) withEtcdBackupReconciler() *Factory {
+	f.withTracker()
+
+	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
+		if f.etcdBackupReconciler != nil {
+			return nil
+		}
+
+		f.etcdBackupReconciler = etcdbackupreconciler.New(
+			f.manager.GetClient(),
+			f.tracker,
+		)
+
+		return nil
+	})
+
+	return f
+}
+
+func (f *Factory) withRegistryCredentialsReconciler() *Factory {
+	f.withTracker()
+
+	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
+		if f.registryCredsReconciler != nil {
+			return nil
+		}
+
+		f.registryCredsReconciler = registrycredsreconciler.New(
+			f.manager.GetClient(),
+			f.tracker,
+		)
+
+		return nil
+	})
+
+	return f
+}
+
 func (f *Factory) withPackageControllerClient() *Factory {
 	f.dependencyFactory.WithHelm().WithKubectl()
 
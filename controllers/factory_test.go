@@ -100,6 +100,7 @@ func TestFactoryBuildAllNutanixReconciler(t *testing.T) {
 	manager := mocks.NewMockManager(ctrl)
 	manager.EXPECT().GetClient().AnyTimes()
 	manager.EXPECT().GetScheme().AnyTimes()
+	manager.EXPECT().GetEventRecorderFor(gomock.Any()).AnyTimes()
 
 	f := controllers.NewFactory(logger, manager).
 		WithNutanixDatacenterReconciler().
@@ -132,6 +133,7 @@ func TestFactoryBuildClusterReconciler(t *testing.T) {
 	manager := mocks.NewMockManager(ctrl)
 	manager.EXPECT().GetClient().AnyTimes()
 	manager.EXPECT().GetScheme().AnyTimes()
+	manager.EXPECT().GetEventRecorderFor(gomock.Any()).AnyTimes()
 
 	providers := []clusterctlv1.Provider{
 		{
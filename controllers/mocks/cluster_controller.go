@@ -89,6 +89,20 @@ func (mr *MockPackagesClientMockRecorder) ReconcileDelete(arg0, arg1, arg2, arg3
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileDelete", reflect.TypeOf((*MockPackagesClient)(nil).ReconcileDelete), arg0, arg1, arg2, arg3)
 }
 
+// ReconcilePackages mocks base method.
+func (m *MockPackagesClient) ReconcilePackages(arg0 context.Context, arg1 logr.Logger, arg2 client.Client, arg3 *v1alpha1.Cluster) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconcilePackages", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReconcilePackages indicates an expected call of ReconcilePackages.
+func (mr *MockPackagesClientMockRecorder) ReconcilePackages(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcilePackages", reflect.TypeOf((*MockPackagesClient)(nil).ReconcilePackages), arg0, arg1, arg2, arg3)
+}
+
 // MockProviderClusterReconcilerRegistry is a mock of ProviderClusterReconcilerRegistry interface.
 type MockProviderClusterReconcilerRegistry struct {
 	ctrl     *gomock.Controller
@@ -193,6 +207,110 @@ func (mr *MockAWSIamConfigReconcilerMockRecorder) ReconcileDelete(ctx, logger, c
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileDelete", reflect.TypeOf((*MockAWSIamConfigReconciler)(nil).ReconcileDelete), ctx, logger, cluster)
 }
 
+// MockEtcdBackupReconciler is a mock of EtcdBackupReconciler interface.
+type MockEtcdBackupReconciler struct {
+	ctrl     *gomock.Controller
+	recorder *MockEtcdBackupReconcilerMockRecorder
+}
+
+// MockEtcdBackupReconcilerMockRecorder is the mock recorder for MockEtcdBackupReconciler.
+type MockEtcdBackupReconcilerMockRecorder struct {
+	mock *MockEtcdBackupReconciler
+}
+
+// NewMockEtcdBackupReconciler creates a new mock instance.
+func NewMockEtcdBackupReconciler(ctrl *gomock.Controller) *MockEtcdBackupReconciler {
+	mock := &MockEtcdBackupReconciler{ctrl: ctrl}
+	mock.recorder = &MockEtcdBackupReconcilerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEtcdBackupReconciler) EXPECT() *MockEtcdBackupReconcilerMockRecorder {
+	return m.recorder
+}
+
+// Reconcile mocks base method.
+func (m *MockEtcdBackupReconciler) Reconcile(ctx context.Context, logger logr.Logger, cluster *v1alpha1.Cluster) (controller.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reconcile", ctx, logger, cluster)
+	ret0, _ := ret[0].(controller.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reconcile indicates an expected call of Reconcile.
+func (mr *MockEtcdBackupReconcilerMockRecorder) Reconcile(ctx, logger, cluster interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconcile", reflect.TypeOf((*MockEtcdBackupReconciler)(nil).Reconcile), ctx, logger, cluster)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockEtcdBackupReconciler) UpdateStatus(ctx context.Context, logger logr.Logger, cluster *v1alpha1.Cluster) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, logger, cluster)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockEtcdBackupReconcilerMockRecorder) UpdateStatus(ctx, logger, cluster interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockEtcdBackupReconciler)(nil).UpdateStatus), ctx, logger, cluster)
+}
+
+// MockRegistryCredentialsReconciler is a mock of RegistryCredentialsReconciler interface.
+type MockRegistryCredentialsReconciler struct {
+	ctrl     *gomock.Controller
+	recorder *MockRegistryCredentialsReconcilerMockRecorder
+}
+
+// MockRegistryCredentialsReconcilerMockRecorder is the mock recorder for MockRegistryCredentialsReconciler.
+type MockRegistryCredentialsReconcilerMockRecorder struct {
+	mock *MockRegistryCredentialsReconciler
+}
+
+// NewMockRegistryCredentialsReconciler creates a new mock instance.
+func NewMockRegistryCredentialsReconciler(ctrl *gomock.Controller) *MockRegistryCredentialsReconciler {
+	mock := &MockRegistryCredentialsReconciler{ctrl: ctrl}
+	mock.recorder = &MockRegistryCredentialsReconcilerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRegistryCredentialsReconciler) EXPECT() *MockRegistryCredentialsReconcilerMockRecorder {
+	return m.recorder
+}
+
+// Reconcile mocks base method.
+func (m *MockRegistryCredentialsReconciler) Reconcile(ctx context.Context, logger logr.Logger, cluster *v1alpha1.Cluster) (controller.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reconcile", ctx, logger, cluster)
+	ret0, _ := ret[0].(controller.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reconcile indicates an expected call of Reconcile.
+func (mr *MockRegistryCredentialsReconcilerMockRecorder) Reconcile(ctx, logger, cluster interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconcile", reflect.TypeOf((*MockRegistryCredentialsReconciler)(nil).Reconcile), ctx, logger, cluster)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockRegistryCredentialsReconciler) UpdateStatus(ctx context.Context, logger logr.Logger, cluster *v1alpha1.Cluster) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, logger, cluster)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockRegistryCredentialsReconcilerMockRecorder) UpdateStatus(ctx, logger, cluster interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockRegistryCredentialsReconciler)(nil).UpdateStatus), ctx, logger, cluster)
+}
+
 // MockClusterValidator is a mock of ClusterValidator interface.
 type MockClusterValidator struct {
 	ctrl     *gomock.Controller
@@ -0,0 +1,27 @@
+package v1alpha1
+
+const (
+	ArgoCDConfigKind = "ArgoCDConfig"
+
+	ArgoCDDefaultBranch = "main"
+)
+
+func validateArgoCDConfig(config *ArgoCDConfig) error {
+	// The git repository fields are the same as the FluxConfig ones.
+	return validateFluxConfig(config.ConvertToFluxConfig())
+}
+
+func setArgoCDConfigDefaults(argoCD *ArgoCDConfig) {
+	if argoCD == nil {
+		return
+	}
+
+	c := &argoCD.Spec
+	if len(c.Branch) == 0 {
+		c.Branch = ArgoCDDefaultBranch
+	}
+
+	if c.Gitlab != nil && len(c.Gitlab.Hostname) == 0 {
+		c.Gitlab.Hostname = FluxDefaultGitlabHostname
+	}
+}
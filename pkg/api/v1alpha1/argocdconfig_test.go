@@ -0,0 +1,134 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func argoCDConfig() *ArgoCDConfig {
+	return &ArgoCDConfig{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       ArgoCDConfigKind,
+			APIVersion: SchemeBuilder.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-argocd",
+			Namespace: "default",
+		},
+		Spec: ArgoCDConfigSpec{
+			Github: &GithubProviderConfig{
+				Owner:      "janedoe",
+				Repository: "argocd-fleet",
+			},
+		},
+	}
+}
+
+func TestValidateArgoCDConfig(t *testing.T) {
+	tests := []struct {
+		testName string
+		modify   func(*ArgoCDConfig)
+		wantErr  string
+	}{
+		{
+			testName: "valid github",
+			modify:   func(*ArgoCDConfig) {},
+		},
+		{
+			testName: "valid git",
+			modify: func(c *ArgoCDConfig) {
+				c.Spec.Github = nil
+				c.Spec.Git = &GitProviderConfig{RepositoryUrl: "ssh://git@example.com/janedoe/argocd-fleet.git"}
+			},
+		},
+		{
+			testName: "no provider",
+			modify: func(c *ArgoCDConfig) {
+				c.Spec.Github = nil
+			},
+			wantErr: "must specify a provider. Valid options are git, github, gitlab and gitea",
+		},
+		{
+			testName: "two providers",
+			modify: func(c *ArgoCDConfig) {
+				c.Spec.Gitea = &GiteaProviderConfig{Hostname: "gitea.example.com", Owner: "janedoe", Repository: "fleet"}
+			},
+			wantErr: "must specify only one provider",
+		},
+		{
+			testName: "invalid branch",
+			modify: func(c *ArgoCDConfig) {
+				c.Spec.Branch = "../main"
+			},
+			wantErr: "../main is not a valid git branch name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			g := NewWithT(t)
+			c := argoCDConfig()
+			tt.modify(c)
+			err := c.Validate()
+			if tt.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestSetArgoCDConfigDefaults(t *testing.T) {
+	g := NewWithT(t)
+	c := argoCDConfig()
+	c.Spec.Github = nil
+	c.Spec.Gitlab = &GitlabProviderConfig{Owner: "janedoe", Repository: "argocd-fleet"}
+
+	c.SetDefaults()
+
+	g.Expect(c.Spec.Branch).To(Equal(ArgoCDDefaultBranch))
+	g.Expect(c.Spec.Gitlab.Hostname).To(Equal(FluxDefaultGitlabHostname))
+}
+
+func TestArgoCDConfigConvertToFluxConfig(t *testing.T) {
+	g := NewWithT(t)
+	c := argoCDConfig()
+	c.Spec.Branch = "main"
+	c.Spec.ClusterConfigPath = "clusters/mgmt"
+
+	f := c.ConvertToFluxConfig()
+
+	g.Expect(f.Kind()).To(Equal(FluxConfigKind))
+	g.Expect(f.Name).To(Equal("test-argocd"))
+	g.Expect(f.Spec).To(Equal(FluxConfigSpec{
+		ClusterConfigPath: "clusters/mgmt",
+		Branch:            "main",
+		Github:            &GithubProviderConfig{Owner: "janedoe", Repository: "argocd-fleet"},
+	}))
+	g.Expect(f.Spec.Github).NotTo(BeIdenticalTo(c.Spec.Github))
+}
+
+func TestArgoCDConfigValidateUpdate(t *testing.T) {
+	g := NewWithT(t)
+	old := argoCDConfig()
+	old.SetDefaults()
+
+	unchanged := old.DeepCopy()
+	g.Expect(unchanged.ValidateUpdate(old)).To(Succeed())
+
+	moved := old.DeepCopy()
+	moved.Spec.Github.Repository = "another-fleet"
+	g.Expect(moved.ValidateUpdate(old)).To(MatchError(ContainSubstring("Forbidden: config is immutable")))
+}
+
+func TestValidateGitOpsArgoCDConfigKind(t *testing.T) {
+	g := NewWithT(t)
+	c := &Cluster{Spec: ClusterSpec{GitOpsRef: &Ref{Kind: ArgoCDConfigKind, Name: "test-argocd"}}}
+	g.Expect(validateGitOps(c)).To(Succeed())
+
+	c.Spec.GitOpsRef.Kind = "Fleet"
+	g.Expect(validateGitOps(c)).To(MatchError("only GitOpsConfig, FluxConfig or ArgoCDConfig Kind are supported at this time"))
+}
@@ -0,0 +1,155 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ArgoCDConfigSpec defines the desired state of ArgoCDConfig.
+type ArgoCDConfigSpec struct {
+	// ClusterConfigPath relative to the repository root, when specified the cluster sync will be scoped to this path.
+	ClusterConfigPath string `json:"clusterConfigPath,omitempty"`
+
+	// Git branch. Defaults to main.
+	// +kubebuilder:default:="main"
+	Branch string `json:"branch,omitempty"`
+
+	// Used to specify Github provider to host the Git repo and host the git files
+	Github *GithubProviderConfig `json:"github,omitempty"`
+
+	// Used to specify Git provider that will be used to host the git files
+	Git *GitProviderConfig `json:"git,omitempty"`
+
+	// Used to specify GitLab provider to host the Git repo and host the git files
+	Gitlab *GitlabProviderConfig `json:"gitlab,omitempty"`
+
+	// Used to specify Gitea provider to host the Git repo and host the git files
+	Gitea *GiteaProviderConfig `json:"gitea,omitempty"`
+}
+
+// ArgoCDConfigStatus defines the observed state of ArgoCDConfig.
+type ArgoCDConfigStatus struct{}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// ArgoCDConfig is the Schema for the argocdconfigs API and defines the configurations of Argo CD and
+// the Git repository it syncs the cluster configuration from.
+type ArgoCDConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ArgoCDConfigSpec   `json:"spec,omitempty"`
+	Status ArgoCDConfigStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:generate=false
+// Same as ArgoCDConfig except stripped down for generation of yaml file while writing to the git repo.
+type ArgoCDConfigGenerate struct {
+	metav1.TypeMeta `json:",inline"`
+	ObjectMeta      `json:"metadata,omitempty"`
+
+	Spec ArgoCDConfigSpec `json:"spec,omitempty"`
+}
+
+func (e *ArgoCDConfigSpec) Equal(n *ArgoCDConfigSpec) bool {
+	if e == n {
+		return true
+	}
+	if e == nil || n == nil {
+		return false
+	}
+	if e.Branch != n.Branch {
+		return false
+	}
+	if e.ClusterConfigPath != n.ClusterConfigPath {
+		return false
+	}
+	return e.Git.Equal(n.Git) && e.Github.Equal(n.Github) && e.Gitlab.Equal(n.Gitlab) && e.Gitea.Equal(n.Gitea)
+}
+
+//+kubebuilder:object:root=true
+
+// ArgoCDConfigList contains a list of ArgoCDConfig.
+type ArgoCDConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ArgoCDConfig `json:"items"`
+}
+
+func (c *ArgoCDConfig) Kind() string {
+	return c.TypeMeta.Kind
+}
+
+func (c *ArgoCDConfig) ExpectedKind() string {
+	return ArgoCDConfigKind
+}
+
+func (c *ArgoCDConfig) ConvertConfigToConfigGenerateStruct() *ArgoCDConfigGenerate {
+	namespace := defaultEksaNamespace
+	if c.Namespace != "" {
+		namespace = c.Namespace
+	}
+	config := &ArgoCDConfigGenerate{
+		TypeMeta: c.TypeMeta,
+		ObjectMeta: ObjectMeta{
+			Name:        c.Name,
+			Annotations: c.Annotations,
+			Namespace:   namespace,
+		},
+		Spec: c.Spec,
+	}
+
+	return config
+}
+
+// ConvertToFluxConfig returns a FluxConfig with the same git repository, branch and path.
+// Argo CD shares the repository layout of Flux, so the git tooling of Flux is reused to write the cluster config.
+func (c *ArgoCDConfig) ConvertToFluxConfig() *FluxConfig {
+	if c == nil {
+		return nil
+	}
+	return &FluxConfig{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       FluxConfigKind,
+			APIVersion: c.APIVersion,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      c.Name,
+			Namespace: c.Namespace,
+		},
+		Spec: FluxConfigSpec{
+			ClusterConfigPath: c.Spec.ClusterConfigPath,
+			Branch:            c.Spec.Branch,
+			Github:            c.Spec.Github.DeepCopy(),
+			Git:               c.Spec.Git.DeepCopy(),
+			Gitlab:            c.Spec.Gitlab.DeepCopy(),
+			Gitea:             c.Spec.Gitea.DeepCopy(),
+		},
+	}
+}
+
+func (c *ArgoCDConfig) Validate() error {
+	return validateArgoCDConfig(c)
+}
+
+func (c *ArgoCDConfig) SetDefaults() {
+	setArgoCDConfigDefaults(c)
+}
+
+func init() {
+	SchemeBuilder.Register(&ArgoCDConfig{}, &ArgoCDConfigList{})
+}
@@ -7,6 +7,7 @@ import (
 	"net"
 	"net/url"
 	"os"
+	"path"
 	"regexp"
 	"strconv"
 	"strings"
@@ -14,6 +15,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	utilvalidation "k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"sigs.k8s.io/yaml"
 
@@ -28,6 +30,11 @@ const (
 	YamlSeparator            = "\n---\n"
 	RegistryMirrorCAKey      = "EKSA_REGISTRY_MIRROR_CA"
 	podSubnetNodeMaskMaxDiff = 16
+
+	// DefaultBackupRetentionCount is the number of etcd snapshots kept when BackupConfiguration doesn't specify it.
+	DefaultBackupRetentionCount = 7
+	// DefaultBackupHostPath is the directory where etcd snapshots are stored when BackupConfiguration doesn't specify it.
+	DefaultBackupHostPath = "/var/lib/etcd-backups"
 )
 
 var re = regexp.MustCompile(constants.DefaultCuratedPackagesRegistryRegex)
@@ -202,7 +209,9 @@ var clusterConfigValidations = []func(*Cluster) error{
 	validateCPUpgradeRolloutStrategy,
 	validateControlPlaneLabels,
 	validatePackageControllerConfiguration,
+	validatePackages,
 	validateCloudStackK8sVersion,
+	validateBackupConfiguration,
 }
 
 // GetClusterConfig parses a Cluster object from a multiobject yaml file in disk
@@ -758,6 +767,50 @@ func validateMirrorConfig(clusterConfig *Cluster) error {
 			return errors.New("registry must be public.ecr.aws when only one mapping is specified")
 		}
 	}
+	if err := validateRegistryCredentialsRef(clusterConfig.Spec.RegistryMirrorConfiguration); err != nil {
+		return err
+	}
+	return validateUpstreamMirrors(clusterConfig.Spec.RegistryMirrorConfiguration.Mirrors)
+}
+
+func validateRegistryCredentialsRef(config *RegistryMirrorConfiguration) error {
+	ref := config.CredentialsRef
+	if ref == nil {
+		return nil
+	}
+	if ref.Kind != constants.SecretKind {
+		return fmt.Errorf("registry mirror credentialsRef kind %s is invalid, only %s is supported", ref.Kind, constants.SecretKind)
+	}
+	if ref.Name == "" {
+		return errors.New("registry mirror credentialsRef name can't be empty")
+	}
+	if !config.Authenticate {
+		return errors.New("registry mirror credentialsRef requires authenticate to be true")
+	}
+	return nil
+}
+
+func validateUpstreamMirrors(mirrors []UpstreamRegistryMirror) error {
+	seen := map[string]struct{}{}
+	for _, m := range mirrors {
+		if m.Upstream == "" {
+			return errors.New("upstream can't be set to empty in RegistryMirrorConfiguration.Mirrors")
+		}
+		if m.Endpoint == "" {
+			return fmt.Errorf("no value set for the endpoint of the %s registry mirror", m.Upstream)
+		}
+		if strings.Contains(m.Endpoint, "://") {
+			return fmt.Errorf("registry mirror endpoint %s is invalid, please provide it without scheme", m.Endpoint)
+		}
+		if m.Priority < 0 {
+			return fmt.Errorf("priority %d of the %s registry mirror %s is invalid, it must be greater than or equal to 0", m.Priority, m.Upstream, m.Endpoint)
+		}
+		key := m.Upstream + "/" + m.Endpoint
+		if _, ok := seen[key]; ok {
+			return fmt.Errorf("registry mirror %s is duplicated for %s", m.Endpoint, m.Upstream)
+		}
+		seen[key] = struct{}{}
+	}
 	return nil
 }
 
@@ -785,8 +838,8 @@ func validateGitOps(clusterConfig *Cluster) error {
 
 	gitOpsRefKind := gitOpsRef.Kind
 
-	if gitOpsRefKind != GitOpsConfigKind && gitOpsRefKind != FluxConfigKind {
-		return errors.New("only GitOpsConfig or FluxConfig Kind are supported at this time")
+	if gitOpsRefKind != GitOpsConfigKind && gitOpsRefKind != FluxConfigKind && gitOpsRefKind != ArgoCDConfigKind {
+		return errors.New("only GitOpsConfig, FluxConfig or ArgoCDConfig Kind are supported at this time")
 	}
 
 	if gitOpsRef.Name == "" {
@@ -859,6 +912,76 @@ func validatePackageControllerConfiguration(clusterConfig *Cluster) error {
 	return nil
 }
 
+func validatePackages(clusterConfig *Cluster) error {
+	if clusterConfig.Spec.Packages == nil || len(clusterConfig.Spec.Packages.Packages) == 0 {
+		return nil
+	}
+
+	if clusterConfig.Spec.Packages.Disable {
+		return errors.New("packages: packages can't be specified when the package controller is disabled")
+	}
+
+	names := map[string]struct{}{}
+	for _, p := range clusterConfig.Spec.Packages.Packages {
+		if p.Name == "" {
+			return errors.New("packages: name is required for every package")
+		}
+		if errs := utilvalidation.IsDNS1123Subdomain(p.Name); len(errs) > 0 {
+			return fmt.Errorf("packages: invalid package name %s: %s", p.Name, strings.Join(errs, ", "))
+		}
+		if _, ok := names[p.Name]; ok {
+			return fmt.Errorf("packages: package %s is specified more than once", p.Name)
+		}
+		names[p.Name] = struct{}{}
+
+		config := map[string]interface{}{}
+		if err := yaml.Unmarshal([]byte(p.Config), &config); err != nil {
+			return fmt.Errorf("packages: invalid config for package %s: %v", p.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func validateBackupConfiguration(clusterConfig *Cluster) error {
+	backup := clusterConfig.Spec.BackupConfiguration
+	if backup == nil {
+		return nil
+	}
+
+	if err := validateCronSchedule(backup.Schedule); err != nil {
+		return fmt.Errorf("backupConfiguration: %v", err)
+	}
+
+	if backup.RetentionCount < 0 {
+		return fmt.Errorf("backupConfiguration: retentionCount %d can't be negative", backup.RetentionCount)
+	}
+
+	if backup.HostPath != "" && !path.IsAbs(backup.HostPath) {
+		return fmt.Errorf("backupConfiguration: hostPath %s must be an absolute path", backup.HostPath)
+	}
+
+	return nil
+}
+
+// validateCronSchedule does a shallow validation of a CronJob schedule: it must be
+// either one of the predefined @ macros or have the five standard fields.
+func validateCronSchedule(schedule string) error {
+	if schedule == "" {
+		return errors.New("schedule is required")
+	}
+
+	if strings.HasPrefix(schedule, "@") {
+		return nil
+	}
+
+	if fields := strings.Fields(schedule); len(fields) != 5 {
+		return fmt.Errorf("schedule %q must have 5 fields, got %d", schedule, len(fields))
+	}
+
+	return nil
+}
+
 func validateCloudStackK8sVersion(cluster *Cluster) error {
 	if cluster.Spec.DatacenterRef.Kind == CloudStackDatacenterKind {
 		return ValidateCloudStackK8sVersion(cluster.Spec.KubernetesVersion)
@@ -13,6 +13,7 @@ var clusterDefaults = []func(*Cluster) error{
 	setRegistryMirrorConfigDefaults,
 	setWorkerNodeGroupDefaults,
 	setCNIConfigDefault,
+	setBackupConfigDefaults,
 }
 
 func setClusterDefaults(cluster *Cluster) error {
@@ -79,3 +80,19 @@ func setCNIConfigDefault(cluster *Cluster) error {
 	cluster.Spec.ClusterNetwork.CNI = ""
 	return nil
 }
+
+func setBackupConfigDefaults(cluster *Cluster) error {
+	if cluster.Spec.BackupConfiguration == nil {
+		return nil
+	}
+
+	if cluster.Spec.BackupConfiguration.RetentionCount == 0 {
+		cluster.Spec.BackupConfiguration.RetentionCount = DefaultBackupRetentionCount
+	}
+
+	if cluster.Spec.BackupConfiguration.HostPath == "" {
+		cluster.Spec.BackupConfiguration.HostPath = DefaultBackupHostPath
+	}
+
+	return nil
+}
@@ -259,3 +259,32 @@ func TestSetClusterDefaults(t *testing.T) {
 		})
 	}
 }
+
+func TestSetBackupConfigDefaults(t *testing.T) {
+	tests := []struct {
+		name     string
+		in, want *BackupConfiguration
+	}{
+		{
+			name: "no backup configuration",
+		},
+		{
+			name: "defaults",
+			in:   &BackupConfiguration{Schedule: "@daily"},
+			want: &BackupConfiguration{Schedule: "@daily", RetentionCount: 7, HostPath: "/var/lib/etcd-backups"},
+		},
+		{
+			name: "already set",
+			in:   &BackupConfiguration{Schedule: "@daily", RetentionCount: 2, HostPath: "/backups"},
+			want: &BackupConfiguration{Schedule: "@daily", RetentionCount: 2, HostPath: "/backups"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			cluster := &Cluster{Spec: ClusterSpec{BackupConfiguration: tt.in}}
+			g.Expect(setBackupConfigDefaults(cluster)).To(Succeed())
+			g.Expect(cluster.Spec.BackupConfiguration).To(Equal(tt.want))
+		})
+	}
+}
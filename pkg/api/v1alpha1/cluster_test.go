@@ -2827,6 +2827,155 @@ func TestValidateMirrorConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:    "valid credentialsRef",
+			wantErr: "",
+			cluster: &Cluster{
+				Spec: ClusterSpec{
+					RegistryMirrorConfiguration: &RegistryMirrorConfiguration{
+						Endpoint:       "1.2.3.4",
+						Port:           "443",
+						Authenticate:   true,
+						CredentialsRef: &Ref{Kind: "Secret", Name: "mirror-credentials"},
+					},
+				},
+			},
+		},
+		{
+			name:    "invalid credentialsRef kind",
+			wantErr: "registry mirror credentialsRef kind ConfigMap is invalid, only Secret is supported",
+			cluster: &Cluster{
+				Spec: ClusterSpec{
+					RegistryMirrorConfiguration: &RegistryMirrorConfiguration{
+						Endpoint:       "1.2.3.4",
+						Port:           "443",
+						Authenticate:   true,
+						CredentialsRef: &Ref{Kind: "ConfigMap", Name: "mirror-credentials"},
+					},
+				},
+			},
+		},
+		{
+			name:    "empty credentialsRef name",
+			wantErr: "registry mirror credentialsRef name can't be empty",
+			cluster: &Cluster{
+				Spec: ClusterSpec{
+					RegistryMirrorConfiguration: &RegistryMirrorConfiguration{
+						Endpoint:       "1.2.3.4",
+						Port:           "443",
+						Authenticate:   true,
+						CredentialsRef: &Ref{Kind: "Secret"},
+					},
+				},
+			},
+		},
+		{
+			name:    "credentialsRef without authenticate",
+			wantErr: "registry mirror credentialsRef requires authenticate to be true",
+			cluster: &Cluster{
+				Spec: ClusterSpec{
+					RegistryMirrorConfiguration: &RegistryMirrorConfiguration{
+						Endpoint:       "1.2.3.4",
+						Port:           "443",
+						Authenticate:   false,
+						CredentialsRef: &Ref{Kind: "Secret", Name: "mirror-credentials"},
+					},
+				},
+			},
+		},
+		{
+			name:    "valid upstream mirrors",
+			wantErr: "",
+			cluster: &Cluster{
+				Spec: ClusterSpec{
+					RegistryMirrorConfiguration: &RegistryMirrorConfiguration{
+						Endpoint: "1.2.3.4",
+						Port:     "443",
+						Mirrors: []UpstreamRegistryMirror{
+							{Upstream: "docker.io", Endpoint: "cache.internal:5000/dockerhub"},
+							{Upstream: "docker.io", Endpoint: "mirror.internal", Priority: 1},
+							{Upstream: "quay.io", Endpoint: "cache.internal:5000/quay"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "empty upstream in mirrors",
+			wantErr: "upstream can't be set to empty in RegistryMirrorConfiguration.Mirrors",
+			cluster: &Cluster{
+				Spec: ClusterSpec{
+					RegistryMirrorConfiguration: &RegistryMirrorConfiguration{
+						Endpoint: "1.2.3.4",
+						Port:     "443",
+						Mirrors: []UpstreamRegistryMirror{
+							{Endpoint: "cache.internal:5000/dockerhub"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "empty endpoint in mirrors",
+			wantErr: "no value set for the endpoint of the docker.io registry mirror",
+			cluster: &Cluster{
+				Spec: ClusterSpec{
+					RegistryMirrorConfiguration: &RegistryMirrorConfiguration{
+						Endpoint: "1.2.3.4",
+						Port:     "443",
+						Mirrors: []UpstreamRegistryMirror{
+							{Upstream: "docker.io"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "endpoint with scheme in mirrors",
+			wantErr: "registry mirror endpoint https://cache.internal is invalid",
+			cluster: &Cluster{
+				Spec: ClusterSpec{
+					RegistryMirrorConfiguration: &RegistryMirrorConfiguration{
+						Endpoint: "1.2.3.4",
+						Port:     "443",
+						Mirrors: []UpstreamRegistryMirror{
+							{Upstream: "docker.io", Endpoint: "https://cache.internal"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "negative priority in mirrors",
+			wantErr: "priority -1 of the docker.io registry mirror cache.internal is invalid",
+			cluster: &Cluster{
+				Spec: ClusterSpec{
+					RegistryMirrorConfiguration: &RegistryMirrorConfiguration{
+						Endpoint: "1.2.3.4",
+						Port:     "443",
+						Mirrors: []UpstreamRegistryMirror{
+							{Upstream: "docker.io", Endpoint: "cache.internal", Priority: -1},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "duplicated mirror",
+			wantErr: "registry mirror cache.internal is duplicated for docker.io",
+			cluster: &Cluster{
+				Spec: ClusterSpec{
+					RegistryMirrorConfiguration: &RegistryMirrorConfiguration{
+						Endpoint: "1.2.3.4",
+						Port:     "443",
+						Mirrors: []UpstreamRegistryMirror{
+							{Upstream: "docker.io", Endpoint: "cache.internal"},
+							{Upstream: "docker.io", Endpoint: "cache.internal", Priority: 1},
+						},
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -3499,3 +3648,137 @@ func TestCloudstackK8sVersion(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateBackupConfiguration(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr string
+		backup  *BackupConfiguration
+	}{
+		{
+			name:    "no backup configuration",
+			wantErr: "",
+		},
+		{
+			name:    "valid schedule",
+			wantErr: "",
+			backup:  &BackupConfiguration{Schedule: "0 */6 * * *", RetentionCount: 3, HostPath: "/var/lib/etcd-backups"},
+		},
+		{
+			name:    "valid macro schedule",
+			wantErr: "",
+			backup:  &BackupConfiguration{Schedule: "@daily"},
+		},
+		{
+			name:    "missing schedule",
+			wantErr: "backupConfiguration: schedule is required",
+			backup:  &BackupConfiguration{},
+		},
+		{
+			name:    "invalid schedule",
+			wantErr: "backupConfiguration: schedule \"0 * * *\" must have 5 fields, got 4",
+			backup:  &BackupConfiguration{Schedule: "0 * * *"},
+		},
+		{
+			name:    "negative retention count",
+			wantErr: "backupConfiguration: retentionCount -1 can't be negative",
+			backup:  &BackupConfiguration{Schedule: "@hourly", RetentionCount: -1},
+		},
+		{
+			name:    "relative host path",
+			wantErr: "backupConfiguration: hostPath backups must be an absolute path",
+			backup:  &BackupConfiguration{Schedule: "@hourly", HostPath: "backups"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			cluster := &Cluster{
+				Spec: ClusterSpec{
+					BackupConfiguration: tt.backup,
+				},
+			}
+			err := validateBackupConfiguration(cluster)
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestValidatePackages(t *testing.T) {
+	tests := []struct {
+		name     string
+		wantErr  string
+		packages *PackageConfiguration
+	}{
+		{
+			name:    "no package configuration",
+			wantErr: "",
+		},
+		{
+			name:    "valid packages",
+			wantErr: "",
+			packages: &PackageConfiguration{
+				Packages: []ClusterPackage{
+					{Name: "harbor", Version: "2.7.1", Config: "secretKey: use-a-secret-key", TargetNamespace: "harbor"},
+					{Name: "metrics-server"},
+				},
+			},
+		},
+		{
+			name:    "package controller disabled",
+			wantErr: "packages: packages can't be specified when the package controller is disabled",
+			packages: &PackageConfiguration{
+				Disable:  true,
+				Packages: []ClusterPackage{{Name: "harbor"}},
+			},
+		},
+		{
+			name:    "missing name",
+			wantErr: "packages: name is required for every package",
+			packages: &PackageConfiguration{
+				Packages: []ClusterPackage{{Version: "2.7.1"}},
+			},
+		},
+		{
+			name:    "invalid name",
+			wantErr: "packages: invalid package name Harbor",
+			packages: &PackageConfiguration{
+				Packages: []ClusterPackage{{Name: "Harbor"}},
+			},
+		},
+		{
+			name:    "duplicated package",
+			wantErr: "packages: package harbor is specified more than once",
+			packages: &PackageConfiguration{
+				Packages: []ClusterPackage{{Name: "harbor"}, {Name: "harbor", Version: "2.8.0"}},
+			},
+		},
+		{
+			name:    "invalid config",
+			wantErr: "packages: invalid config for package harbor",
+			packages: &PackageConfiguration{
+				Packages: []ClusterPackage{{Name: "harbor", Config: "- not a map"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			cluster := &Cluster{
+				Spec: ClusterSpec{
+					Packages: tt.packages,
+				},
+			}
+			err := validatePackages(cluster)
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
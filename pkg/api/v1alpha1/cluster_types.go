@@ -62,6 +62,8 @@ type ClusterSpec struct {
 	ManagementCluster           ManagementCluster            `json:"managementCluster,omitempty"`
 	PodIAMConfig                *PodIAMConfig                `json:"podIamConfig,omitempty"`
 	Packages                    *PackageConfiguration        `json:"packages,omitempty"`
+	// BackupConfiguration defines the scheduled etcd backups for the cluster.
+	BackupConfiguration *BackupConfiguration `json:"backupConfiguration,omitempty"`
 	// BundlesRef contains a reference to the Bundles containing the desired dependencies for the cluster.
 	// DEPRECATED: Use EksaVersion instead.
 	BundlesRef  *BundlesRef  `json:"bundlesRef,omitempty"`
@@ -143,6 +145,9 @@ func (n *Cluster) Equal(o *Cluster) bool {
 	if !n.Spec.Packages.Equal(o.Spec.Packages) {
 		return false
 	}
+	if !n.Spec.BackupConfiguration.Equal(o.Spec.BackupConfiguration) {
+		return false
+	}
 	if !n.ManagementClusterEqual(o) {
 		return false
 	}
@@ -205,6 +210,44 @@ type RegistryMirrorConfiguration struct {
 	// InsecureSkipVerify skips the registry certificate verification.
 	// Only use this solution for isolated testing or in a tightly controlled, air-gapped environment.
 	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+
+	// CredentialsRef is a reference to a Secret in the eksa-system namespace with the username and password
+	// of the registry mirror. When set, the controller watches it and updates the credentials of the running
+	// nodes in place when they change, without rolling out the machines. New machines are bootstrapped with
+	// the credentials the cluster was created with. Requires Authenticate.
+	CredentialsRef *Ref `json:"credentialsRef,omitempty"`
+
+	// Mirrors defines registry mirrors for any upstream registry, e.g. docker.io, quay.io or ghcr.io,
+	// in addition to the registry mirror endpoint. When set, containerd is configured with hosts.toml
+	// files instead of the CRI registry mirrors. On Bottlerocket, which is only supported for vSphere,
+	// they are set in the container registry settings of the nodes.
+	Mirrors []UpstreamRegistryMirror `json:"mirrors,omitempty"`
+}
+
+// UpstreamRegistryMirror defines a registry mirror for an upstream registry.
+type UpstreamRegistryMirror struct {
+	// Upstream is the registry the mirror serves images for, e.g. docker.io.
+	Upstream string `json:"upstream"`
+
+	// Endpoint is the address of the mirror with an optional port and namespace,
+	// e.g. cache.internal:5000/dockerhub.
+	Endpoint string `json:"endpoint"`
+
+	// Priority orders the mirrors of the same upstream, lowest first. Images are pulled from the next
+	// mirror when one fails and from the upstream when all of them fail. Defaults to 0.
+	Priority int `json:"priority,omitempty"`
+
+	// CACertContent defines the contents of the mirror CA certificate.
+	CACertContent string `json:"caCertContent,omitempty"`
+
+	// Authenticate defines if the mirror requires authentication. The credentials are read from the
+	// REGISTRY_USERNAME_<UPSTREAM> and REGISTRY_PASSWORD_<UPSTREAM> env vars, where <UPSTREAM> is the
+	// upstream in upper case with any character other than letters and digits replaced by _, e.g. DOCKER_IO.
+	Authenticate bool `json:"authenticate,omitempty"`
+
+	// InsecureSkipVerify skips the mirror certificate verification.
+	// Only use this solution for isolated testing or in a tightly controlled, air-gapped environment.
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
 }
 
 // OCINamespace represents an entity in a local reigstry to group related images.
@@ -224,7 +267,20 @@ func (n *RegistryMirrorConfiguration) Equal(o *RegistryMirrorConfiguration) bool
 	}
 	return n.Endpoint == o.Endpoint && n.Port == o.Port && n.CACertContent == o.CACertContent &&
 		n.InsecureSkipVerify == o.InsecureSkipVerify && n.Authenticate == o.Authenticate &&
-		OCINamespacesSliceEqual(n.OCINamespaces, o.OCINamespaces)
+		OCINamespacesSliceEqual(n.OCINamespaces, o.OCINamespaces) && upstreamRegistryMirrorsEqual(n.Mirrors, o.Mirrors) &&
+		n.CredentialsRef.Equal(o.CredentialsRef)
+}
+
+func upstreamRegistryMirrorsEqual(a, b []UpstreamRegistryMirror) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
 }
 
 // OCINamespacesSliceEqual is used to check equality of the OCINamespaces fields of two RegistryMirrorConfiguration.
@@ -860,6 +916,35 @@ type ClusterStatus struct {
 
 	// ObservedGeneration is the latest generation observed by the controller.
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// EtcdBackup reports the latest successful scheduled etcd backup.
+	// +optional
+	EtcdBackup *EtcdBackupStatus `json:"etcdBackup,omitempty"`
+
+	// Certificates reports the soonest expiry of the control plane and etcd machine certificates.
+	// +optional
+	Certificates *CertificatesStatus `json:"certificates,omitempty"`
+}
+
+// EtcdBackupStatus defines the observed state of the scheduled etcd backups.
+type EtcdBackupStatus struct {
+	// LastSuccessfulBackupTime is the time the latest successful backup finished.
+	LastSuccessfulBackupTime *metav1.Time `json:"lastSuccessfulBackupTime,omitempty"`
+
+	// LastSuccessfulBackupSize is the size in bytes of the latest successful snapshot.
+	LastSuccessfulBackupSize int64 `json:"lastSuccessfulBackupSize,omitempty"`
+
+	// LastSuccessfulBackupNode is the control plane node where the latest successful snapshot was stored.
+	LastSuccessfulBackupNode string `json:"lastSuccessfulBackupNode,omitempty"`
+}
+
+// CertificatesStatus defines the observed state of the control plane and etcd machine certificates.
+type CertificatesStatus struct {
+	// SoonestExpiryDate is the expiry date of the certificates that expire first.
+	SoonestExpiryDate *metav1.Time `json:"soonestExpiryDate,omitempty"`
+
+	// Machine is the name of the machine with the certificates that expire first.
+	Machine string `json:"machine,omitempty"`
 }
 
 type EksdReleaseRef struct {
@@ -929,6 +1014,25 @@ type PackageConfiguration struct {
 
 	// Cronjob for ecr token refresher
 	CronJob *PackageControllerCronJob `json:"cronjob,omitempty"`
+
+	// Packages is the list of curated packages installed in the cluster. The controller installs,
+	// upgrades and removes packages to match it.
+	Packages []ClusterPackage `json:"packages,omitempty"`
+}
+
+// ClusterPackage defines a curated package installed in the cluster.
+type ClusterPackage struct {
+	// Name of the package in the packages bundle. It's also the name of the Package object.
+	Name string `json:"name"`
+
+	// Version of the package. Defaults to the version in the active packages bundle.
+	Version string `json:"version,omitempty"`
+
+	// Config is the configuration of the package, in yaml.
+	Config string `json:"config,omitempty"`
+
+	// TargetNamespace is the namespace where the package resources are deployed.
+	TargetNamespace string `json:"targetNamespace,omitempty"`
 }
 
 // Equal for PackageConfiguration.
@@ -939,7 +1043,20 @@ func (n *PackageConfiguration) Equal(o *PackageConfiguration) bool {
 	if n == nil || o == nil {
 		return false
 	}
-	return n.Disable == o.Disable && n.Controller.Equal(o.Controller) && n.CronJob.Equal(o.CronJob)
+	return n.Disable == o.Disable && n.Controller.Equal(o.Controller) && n.CronJob.Equal(o.CronJob) &&
+		clusterPackagesEqual(n.Packages, o.Packages)
+}
+
+func clusterPackagesEqual(n, o []ClusterPackage) bool {
+	if len(n) != len(o) {
+		return false
+	}
+	for i := range n {
+		if n[i] != o[i] {
+			return false
+		}
+	}
+	return true
 }
 
 // PackageControllerConfiguration configure aspects of package controller.
@@ -1039,6 +1156,31 @@ func (n *PackageControllerCronJob) Equal(o *PackageControllerCronJob) bool {
 	return n.Repository == o.Repository && n.Tag == o.Tag && n.Digest == o.Digest && n.Disable == o.Disable
 }
 
+// BackupConfiguration defines the scheduled etcd backups for a cluster.
+type BackupConfiguration struct {
+	// Schedule is the cron expression, in the Kubernetes CronJob format, that defines when etcd snapshots are taken.
+	Schedule string `json:"schedule"`
+
+	// RetentionCount is the number of snapshots kept in HostPath. Older snapshots are removed
+	// after each successful backup. Defaults to 7.
+	RetentionCount int `json:"retentionCount,omitempty"`
+
+	// HostPath is the directory on the control plane node running the backup where snapshots are stored.
+	// Defaults to /var/lib/etcd-backups.
+	HostPath string `json:"hostPath,omitempty"`
+}
+
+// Equal for BackupConfiguration.
+func (n *BackupConfiguration) Equal(o *BackupConfiguration) bool {
+	if n == o {
+		return true
+	}
+	if n == nil || o == nil {
+		return false
+	}
+	return n.Schedule == o.Schedule && n.RetentionCount == o.RetentionCount && n.HostPath == o.HostPath
+}
+
 // ExternalEtcdConfiguration defines the configuration options for using unstacked etcd topology.
 type ExternalEtcdConfiguration struct {
 	Count int `json:"count,omitempty"`
@@ -1114,6 +1256,8 @@ type WorkerNodesRollingUpdateParams struct {
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Certificates Expiry",type="string",JSONPath=".status.certificates.soonestExpiryDate",description="Soonest expiry date of the control plane and etcd certificates"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 // Cluster is the Schema for the clusters API.
 type Cluster struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -1755,6 +1755,58 @@ func TestRegistryMirrorConfigurationEqual(t *testing.T) {
 			},
 			want: false,
 		},
+		{
+			testName: "both exist, mirrors diff (priority)",
+			cluster1Regi: &v1alpha1.RegistryMirrorConfiguration{
+				Mirrors: []v1alpha1.UpstreamRegistryMirror{
+					{
+						Upstream: "docker.io",
+						Endpoint: "cache.internal:5000/dockerhub",
+					},
+				},
+			},
+			cluster2Regi: &v1alpha1.RegistryMirrorConfiguration{
+				Mirrors: []v1alpha1.UpstreamRegistryMirror{
+					{
+						Upstream: "docker.io",
+						Endpoint: "cache.internal:5000/dockerhub",
+						Priority: 1,
+					},
+				},
+			},
+			want: false,
+		},
+		{
+			testName:     "both exist, mirrors diff (one nil, one exists)",
+			cluster1Regi: &v1alpha1.RegistryMirrorConfiguration{},
+			cluster2Regi: &v1alpha1.RegistryMirrorConfiguration{
+				Mirrors: []v1alpha1.UpstreamRegistryMirror{
+					{
+						Upstream: "quay.io",
+						Endpoint: "cache.internal:5000/quay",
+					},
+				},
+			},
+			want: false,
+		},
+		{
+			testName: "both exist, credentialsRef diff",
+			cluster1Regi: &v1alpha1.RegistryMirrorConfiguration{
+				CredentialsRef: &v1alpha1.Ref{Kind: "Secret", Name: "mirror-credentials"},
+			},
+			cluster2Regi: &v1alpha1.RegistryMirrorConfiguration{
+				CredentialsRef: &v1alpha1.Ref{Kind: "Secret", Name: "rotated-credentials"},
+			},
+			want: false,
+		},
+		{
+			testName:     "both exist, credentialsRef diff (one nil, one exists)",
+			cluster1Regi: &v1alpha1.RegistryMirrorConfiguration{},
+			cluster2Regi: &v1alpha1.RegistryMirrorConfiguration{
+				CredentialsRef: &v1alpha1.Ref{Kind: "Secret", Name: "mirror-credentials"},
+			},
+			want: false,
+		},
 	}
 	for _, tt := range testCases {
 		t.Run(tt.testName, func(t *testing.T) {
@@ -2132,6 +2184,34 @@ func TestPackageConfiguration_Equal(t *testing.T) {
 			},
 			want: true,
 		},
+		{
+			name: "equal packages",
+			pcn: &v1alpha1.PackageConfiguration{
+				Packages: []v1alpha1.ClusterPackage{{Name: "harbor", Version: "2.7.1"}},
+			},
+			pco: &v1alpha1.PackageConfiguration{
+				Packages: []v1alpha1.ClusterPackage{{Name: "harbor", Version: "2.7.1"}},
+			},
+			want: true,
+		},
+		{
+			name: "not equal packages version",
+			pcn: &v1alpha1.PackageConfiguration{
+				Packages: []v1alpha1.ClusterPackage{{Name: "harbor", Version: "2.7.1"}},
+			},
+			pco: &v1alpha1.PackageConfiguration{
+				Packages: []v1alpha1.ClusterPackage{{Name: "harbor", Version: "2.8.0"}},
+			},
+			want: false,
+		},
+		{
+			name: "not equal packages length",
+			pcn: &v1alpha1.PackageConfiguration{
+				Packages: []v1alpha1.ClusterPackage{{Name: "harbor"}},
+			},
+			pco: &v1alpha1.PackageConfiguration{},
+			want: false,
+		},
 		{
 			name: "same",
 			pcn:  same,
@@ -59,3 +59,51 @@ const (
 	// create a cluster.
 	SkipUpgradesForDefaultCNIConfiguredReason = "SkipUpgradesForDefaultCNIConfigured"
 )
+
+const (
+	// EtcdBackupReadyCondition reports the status of the scheduled etcd backups. It's true when the latest
+	// finished backup succeeded and its message includes when it was taken and its size.
+	EtcdBackupReadyCondition ConditionType = "EtcdBackupReady"
+
+	// EtcdBackupPendingReason reports that no backup has finished yet since the schedule was configured.
+	EtcdBackupPendingReason = "EtcdBackupPending"
+
+	// EtcdBackupFailedReason reports that the latest finished backup failed.
+	EtcdBackupFailedReason = "EtcdBackupFailed"
+)
+
+const (
+	// CertificatesExpiringSoonCondition reports whether the certificates of any control plane or etcd machine
+	// expire soon. It's true when they expire in less than 30 days or are already expired.
+	CertificatesExpiringSoonCondition ConditionType = "CertificatesExpiringSoon"
+
+	// CertificatesExpiringReason reports that the certificates of a machine expire within the threshold.
+	CertificatesExpiringReason = "CertificatesExpiring"
+
+	// CertificatesExpiredReason reports that the certificates of a machine are already expired.
+	CertificatesExpiredReason = "CertificatesExpired"
+
+	// CertificatesValidReason reports that the certificates of all machines are valid beyond the threshold.
+	CertificatesValidReason = "CertificatesValid"
+)
+
+const (
+	// RegistryCredentialsRotatedCondition reports whether the registry mirror credentials from the referenced
+	// Secret have been applied to all the running nodes. Its last transition time is when the latest rotation finished.
+	RegistryCredentialsRotatedCondition ConditionType = "RegistryCredentialsRotated"
+
+	// RegistryCredentialsRotationInProgressReason reports that the new credentials are still being applied to the nodes.
+	RegistryCredentialsRotationInProgressReason = "RegistryCredentialsRotationInProgress"
+
+	// RegistryCredentialsRotationFailedReason reports that the credentials couldn't be read or pushed to the nodes.
+	RegistryCredentialsRotationFailedReason = "RegistryCredentialsRotationFailed"
+)
+
+const (
+	// GitOpsInSyncCondition reports whether the EKS-A objects of the cluster match their copies in the GitOps
+	// repository. It's set by the CLI when checking for drift and false when any of the objects has drifted.
+	GitOpsInSyncCondition ConditionType = "GitOpsInSync"
+
+	// GitOpsDriftDetectedReason reports that some of the objects differ from the GitOps repository.
+	GitOpsDriftDetectedReason = "GitOpsDriftDetected"
+)
@@ -13,14 +13,22 @@ const (
 	RsaAlgorithm     = "rsa"
 	EcdsaAlgorithm   = "ecdsa"
 	Ed25519Algorithm = "ed25519"
+
+	FluxDefaultGitlabHostname = "gitlab.com"
 )
 
 func validateFluxConfig(config *FluxConfig) error {
-	if config.Spec.Git != nil && config.Spec.Github != nil {
+	providers := 0
+	for _, set := range []bool{config.Spec.Git != nil, config.Spec.Github != nil, config.Spec.Gitlab != nil, config.Spec.Gitea != nil} {
+		if set {
+			providers++
+		}
+	}
+	if providers > 1 {
 		return errors.New("must specify only one provider")
 	}
-	if config.Spec.Git == nil && config.Spec.Github == nil {
-		return errors.New("must specify a provider. Valid options are git and github")
+	if providers == 0 {
+		return errors.New("must specify a provider. Valid options are git, github, gitlab and gitea")
 	}
 	if config.Spec.Github != nil {
 		err := validateGithubProviderConfig(*config.Spec.Github)
@@ -28,6 +36,18 @@ func validateFluxConfig(config *FluxConfig) error {
 			return err
 		}
 	}
+	if config.Spec.Gitlab != nil {
+		err := validateGitlabProviderConfig(*config.Spec.Gitlab)
+		if err != nil {
+			return err
+		}
+	}
+	if config.Spec.Gitea != nil {
+		err := validateGiteaProviderConfig(*config.Spec.Gitea)
+		if err != nil {
+			return err
+		}
+	}
 	if config.Spec.Git != nil {
 		err := validateGitProviderConfig(*config.Spec.Git)
 		if err != nil {
@@ -74,6 +94,29 @@ func validateGithubProviderConfig(config GithubProviderConfig) error {
 	return nil
 }
 
+func validateGitlabProviderConfig(config GitlabProviderConfig) error {
+	if len(config.Owner) <= 0 {
+		return errors.New("'owner' is not set or empty in gitlabProviderConfig; owner is a required field")
+	}
+	if len(config.Repository) <= 0 {
+		return errors.New("'repository' is not set or empty in gitlabProviderConfig; repository is a required field")
+	}
+	return validateGitRepoName(config.Repository)
+}
+
+func validateGiteaProviderConfig(config GiteaProviderConfig) error {
+	if len(config.Hostname) <= 0 {
+		return errors.New("'hostname' is not set or empty in giteaProviderConfig; hostname is a required field")
+	}
+	if len(config.Owner) <= 0 {
+		return errors.New("'owner' is not set or empty in giteaProviderConfig; owner is a required field")
+	}
+	if len(config.Repository) <= 0 {
+		return errors.New("'repository' is not set or empty in giteaProviderConfig; repository is a required field")
+	}
+	return validateGitRepoName(config.Repository)
+}
+
 func validateRepositoryUrl(repositoryUrl string) error {
 	url, err := url.Parse(repositoryUrl)
 	if err != nil {
@@ -105,4 +148,8 @@ func setFluxConfigDefaults(flux *FluxConfig) {
 	if len(c.Branch) == 0 {
 		c.Branch = FluxDefaultBranch
 	}
+
+	if c.Gitlab != nil && len(c.Gitlab.Hostname) == 0 {
+		c.Gitlab.Hostname = FluxDefaultGitlabHostname
+	}
 }
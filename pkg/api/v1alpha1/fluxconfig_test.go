@@ -188,6 +188,99 @@ func TestValidateFluxConfig(t *testing.T) {
 			gitProvider: true,
 			error:       nil,
 		},
+		{
+			testName: "valid fluxconfig gitlab",
+			fluxConfig: &FluxConfig{
+				Spec: FluxConfigSpec{
+					Gitlab: &GitlabProviderConfig{
+						Hostname:   "gitlab.example.com",
+						Owner:      "platform/clusters",
+						Repository: "flux-fleet",
+					},
+				},
+			},
+			wantErr: false,
+			error:   nil,
+		},
+		{
+			testName: "valid fluxconfig gitea",
+			fluxConfig: &FluxConfig{
+				Spec: FluxConfigSpec{
+					Gitea: &GiteaProviderConfig{
+						Hostname:   "gitea.example.com",
+						Owner:      "janedoe",
+						Repository: "flux-fleet",
+						Personal:   true,
+					},
+				},
+			},
+			wantErr: false,
+			error:   nil,
+		},
+		{
+			testName: "gitlab empty owner",
+			fluxConfig: &FluxConfig{
+				Spec: FluxConfigSpec{
+					Gitlab: &GitlabProviderConfig{
+						Repository: "flux-fleet",
+					},
+				},
+			},
+			wantErr: true,
+			error:   errors.New("'owner' is not set or empty in gitlabProviderConfig; owner is a required field"),
+		},
+		{
+			testName: "gitea empty hostname",
+			fluxConfig: &FluxConfig{
+				Spec: FluxConfigSpec{
+					Gitea: &GiteaProviderConfig{
+						Owner:      "janedoe",
+						Repository: "flux-fleet",
+					},
+				},
+			},
+			wantErr: true,
+			error:   errors.New("'hostname' is not set or empty in giteaProviderConfig; hostname is a required field"),
+		},
+		{
+			testName: "gitea invalid repository",
+			fluxConfig: &FluxConfig{
+				Spec: FluxConfigSpec{
+					Gitea: &GiteaProviderConfig{
+						Hostname:   "gitea.example.com",
+						Owner:      "janedoe",
+						Repository: "flux/fleet",
+					},
+				},
+			},
+			wantErr: true,
+			error:   fmt.Errorf("%s is not a valid git repository name, name can contain only letters, digits, '_', '-' and '.'", "flux/fleet"),
+		},
+		{
+			testName: "gitlab and github",
+			fluxConfig: &FluxConfig{
+				Spec: FluxConfigSpec{
+					Github: &GithubProviderConfig{
+						Owner:      "janedoe",
+						Repository: "flux-fleet",
+					},
+					Gitlab: &GitlabProviderConfig{
+						Owner:      "janedoe",
+						Repository: "flux-fleet",
+					},
+				},
+			},
+			wantErr: true,
+			error:   errors.New("must specify only one provider"),
+		},
+		{
+			testName: "no provider",
+			fluxConfig: &FluxConfig{
+				Spec: FluxConfigSpec{},
+			},
+			wantErr: true,
+			error:   errors.New("must specify a provider. Valid options are git, github, gitlab and gitea"),
+		},
 	}
 
 	for _, tt := range tests {
@@ -208,3 +301,15 @@ func TestValidateFluxConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestSetFluxConfigDefaultsGitlabHostname(t *testing.T) {
+	c := &FluxConfig{
+		Spec: FluxConfigSpec{
+			Gitlab: &GitlabProviderConfig{Owner: "janedoe", Repository: "flux-fleet"},
+		},
+	}
+	c.SetDefaults()
+	if c.Spec.Gitlab.Hostname != FluxDefaultGitlabHostname {
+		t.Fatalf("FluxConfig.SetDefaults() gitlab hostname = %s, want %s", c.Spec.Gitlab.Hostname, FluxDefaultGitlabHostname)
+	}
+}
@@ -35,6 +35,12 @@ type FluxConfigSpec struct {
 
 	// Used to specify Git provider that will be used to host the git files
 	Git *GitProviderConfig `json:"git,omitempty"`
+
+	// Used to specify GitLab provider to host the Git repo and host the git files
+	Gitlab *GitlabProviderConfig `json:"gitlab,omitempty"`
+
+	// Used to specify Gitea provider to host the Git repo and host the git files
+	Gitea *GiteaProviderConfig `json:"gitea,omitempty"`
 }
 
 type GithubProviderConfig struct {
@@ -56,6 +62,34 @@ type GitProviderConfig struct {
 	SshKeyAlgorithm string `json:"sshKeyAlgorithm,omitempty"`
 }
 
+type GitlabProviderConfig struct {
+	// Hostname of the GitLab instance. Defaults to gitlab.com.
+	Hostname string `json:"hostname,omitempty"`
+
+	// Owner is the user or group name of the Git provider.
+	Owner string `json:"owner"`
+
+	// Repository name.
+	Repository string `json:"repository"`
+
+	// if true, the owner is assumed to be a Git user; otherwise a group.
+	Personal bool `json:"personal,omitempty"`
+}
+
+type GiteaProviderConfig struct {
+	// Hostname of the Gitea instance.
+	Hostname string `json:"hostname"`
+
+	// Owner is the user or organization name of the Git provider.
+	Owner string `json:"owner"`
+
+	// Repository name.
+	Repository string `json:"repository"`
+
+	// if true, the owner is assumed to be a Git user; otherwise an org.
+	Personal bool `json:"personal,omitempty"`
+}
+
 // FluxConfigStatus defines the observed state of FluxConfig.
 type FluxConfigStatus struct{}
 
@@ -97,7 +131,7 @@ func (e *FluxConfigSpec) Equal(n *FluxConfigSpec) bool {
 	if e.ClusterConfigPath != n.ClusterConfigPath {
 		return false
 	}
-	return e.Git.Equal(n.Git) && e.Github.Equal(n.Github)
+	return e.Git.Equal(n.Git) && e.Github.Equal(n.Github) && e.Gitlab.Equal(n.Gitlab) && e.Gitea.Equal(n.Gitea)
 }
 
 func (e *GithubProviderConfig) Equal(n *GithubProviderConfig) bool {
@@ -120,6 +154,26 @@ func (e *GitProviderConfig) Equal(n *GitProviderConfig) bool {
 	return *e == *n
 }
 
+func (e *GitlabProviderConfig) Equal(n *GitlabProviderConfig) bool {
+	if e == n {
+		return true
+	}
+	if e == nil || n == nil {
+		return false
+	}
+	return *e == *n
+}
+
+func (e *GiteaProviderConfig) Equal(n *GiteaProviderConfig) bool {
+	if e == n {
+		return true
+	}
+	if e == nil || n == nil {
+		return false
+	}
+	return *e == *n
+}
+
 //+kubebuilder:object:root=true
 
 // FluxConfigList contains a list of FluxConfig.
@@ -82,6 +82,15 @@ func (b *Bootstrapper) DeleteBootstrapCluster(ctx context.Context, cluster *type
 	return b.clusterClient.DeleteKindCluster(ctx, cluster)
 }
 
+// BootstrapClusterExists returns true if the kind cluster backing the bootstrap cluster exists.
+func (b *Bootstrapper) BootstrapClusterExists(ctx context.Context, cluster *types.Cluster) (bool, error) {
+	exists, err := b.clusterClient.KindClusterExists(ctx, cluster.Name)
+	if err != nil {
+		return false, fmt.Errorf("checking if bootstrap cluster exists: %v", err)
+	}
+	return exists, nil
+}
+
 func (b *Bootstrapper) managementInCluster(ctx context.Context, cluster *types.Cluster) (*types.CAPICluster, error) {
 	if cluster.KubeconfigFile == "" {
 		kubeconfig, err := b.clusterClient.GetKindClusterKubeconfig(ctx, cluster.Name)
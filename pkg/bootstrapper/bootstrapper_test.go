@@ -83,6 +83,36 @@ func TestBootstrapperDeleteBootstrapClusterNoBootstrap(t *testing.T) {
 	}
 }
 
+func TestBootstrapperBootstrapClusterExists(t *testing.T) {
+	cluster := &types.Cluster{
+		Name: "cluster-name",
+	}
+
+	ctx := context.Background()
+	b, client := newBootstrapper(t)
+	client.EXPECT().KindClusterExists(ctx, cluster.Name).Return(true, nil)
+	exists, err := b.BootstrapClusterExists(ctx, cluster)
+	if err != nil {
+		t.Fatalf("Bootstrapper.BootstrapClusterExists() error = %v, wantErr nil", err)
+	}
+	if !exists {
+		t.Fatal("Bootstrapper.BootstrapClusterExists() = false, want true")
+	}
+}
+
+func TestBootstrapperBootstrapClusterExistsError(t *testing.T) {
+	cluster := &types.Cluster{
+		Name: "cluster-name",
+	}
+
+	ctx := context.Background()
+	b, client := newBootstrapper(t)
+	client.EXPECT().KindClusterExists(ctx, cluster.Name).Return(false, errors.New("docker not running"))
+	if _, err := b.BootstrapClusterExists(ctx, cluster); err == nil {
+		t.Fatal("Bootstrapper.BootstrapClusterExists() error = nil, want not nil")
+	}
+}
+
 func TestBootstrapperDeleteBootstrapClusterNoKubeconfig(t *testing.T) {
 	cluster := &types.Cluster{
 		Name:           "cluster-name",
@@ -0,0 +1,170 @@
+// Package certificates inspects and renews the certificates of the control plane and etcd nodes of an EKS-A cluster.
+package certificates
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/nodes"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const fileSeparator = "==> "
+
+// NodeLister lists the control plane and etcd nodes of a cluster.
+type NodeLister interface {
+	ControlPlaneNodes(ctx context.Context, managementCluster *types.Cluster, spec *cluster.Spec) ([]nodes.Node, error)
+	EtcdNodes(ctx context.Context, managementCluster *types.Cluster, spec *cluster.Spec) ([]nodes.Node, error)
+}
+
+// CommandRunner runs shell scripts as root in cluster nodes.
+type CommandRunner interface {
+	Run(ctx context.Context, node nodes.Node, script string) (string, error)
+}
+
+// Certificate is a certificate file found in a cluster node.
+type Certificate struct {
+	Node     string     `json:"node"`
+	Role     nodes.Role `json:"role"`
+	Name     string     `json:"name"`
+	Path     string     `json:"path"`
+	IsCA     bool       `json:"isCA"`
+	NotAfter time.Time  `json:"notAfter"`
+}
+
+// ExpiresWithin returns true if the certificate expires before now plus d.
+func (c Certificate) ExpiresWithin(now time.Time, d time.Duration) bool {
+	return c.NotAfter.Before(now.Add(d))
+}
+
+// Inspector reads the certificates from the control plane and etcd nodes of a cluster.
+type Inspector struct {
+	nodes  NodeLister
+	runner CommandRunner
+}
+
+// NewInspector returns a new Inspector.
+func NewInspector(nodes NodeLister, runner CommandRunner) *Inspector {
+	return &Inspector{
+		nodes:  nodes,
+		runner: runner,
+	}
+}
+
+// Certificates returns the certificates of every control plane node and, for clusters with an
+// external etcd, of every etcd node. They are sorted by node and certificate name.
+func (i *Inspector) Certificates(ctx context.Context, managementCluster *types.Cluster, spec *cluster.Spec) ([]Certificate, error) {
+	clusterNodes, err := i.clusterNodes(ctx, managementCluster, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	var certs []Certificate
+	for _, node := range clusterNodes {
+		nodeCerts, err := i.NodeCertificates(ctx, node)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, nodeCerts...)
+	}
+
+	return certs, nil
+}
+
+// NodeCertificates returns the certificates in the node certificates directory, sorted by name.
+func (i *Inspector) NodeCertificates(ctx context.Context, node nodes.Node) ([]Certificate, error) {
+	dir := certificatesDir(node)
+	out, err := i.runner.Run(ctx, node, listScript(dir))
+	if err != nil {
+		return nil, fmt.Errorf("reading certificates: %v", err)
+	}
+
+	certs, err := parseCertificates(node, dir, out)
+	if err != nil {
+		return nil, fmt.Errorf("reading certificates from node %s: %v", node.Name, err)
+	}
+
+	return certs, nil
+}
+
+func (i *Inspector) clusterNodes(ctx context.Context, managementCluster *types.Cluster, spec *cluster.Spec) ([]nodes.Node, error) {
+	clusterNodes, err := i.nodes.ControlPlaneNodes(ctx, managementCluster, spec)
+	if err != nil {
+		return nil, fmt.Errorf("getting control plane nodes: %v", err)
+	}
+
+	if spec.Cluster.Spec.ExternalEtcdConfiguration == nil {
+		return clusterNodes, nil
+	}
+
+	etcdNodes, err := i.nodes.EtcdNodes(ctx, managementCluster, spec)
+	if err != nil {
+		return nil, fmt.Errorf("getting etcd nodes: %v", err)
+	}
+
+	return append(etcdNodes, clusterNodes...), nil
+}
+
+// certificatesDir returns the directory where kubeadm, for control plane nodes, or etcdadm,
+// for etcd nodes, keep the node certificates.
+func certificatesDir(node nodes.Node) string {
+	switch {
+	case node.Role == nodes.Etcd && node.OSFamily == anywherev1.Bottlerocket:
+		return "/var/lib/etcd/pki"
+	case node.Role == nodes.Etcd:
+		return "/etc/etcd/pki"
+	case node.OSFamily == anywherev1.Bottlerocket:
+		return "/var/lib/kubeadm/pki"
+	default:
+		return "/etc/kubernetes/pki"
+	}
+}
+
+// listScript prints every certificate file under dir preceded by a line with its path.
+// Certificates are parsed locally since openssl is not available in Bottlerocket hosts.
+func listScript(dir string) string {
+	return fmt.Sprintf(`set -e; for f in $(find %s -name '*.crt' | sort); do echo "%s$f"; cat "$f"; done`, dir, fileSeparator)
+}
+
+func parseCertificates(node nodes.Node, dir, out string) ([]Certificate, error) {
+	var certs []Certificate
+	for _, file := range strings.Split(out, fileSeparator) {
+		if strings.TrimSpace(file) == "" {
+			continue
+		}
+
+		filePath, content, _ := strings.Cut(file, "\n")
+		block, _ := pem.Decode([]byte(content))
+		if block == nil {
+			return nil, fmt.Errorf("no PEM data found in %s", filePath)
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s: %v", filePath, err)
+		}
+
+		certs = append(certs, Certificate{
+			Node:     node.Name,
+			Role:     node.Role,
+			Name:     strings.TrimSuffix(strings.TrimPrefix(filePath, dir+"/"), path.Ext(filePath)),
+			Path:     filePath,
+			IsCA:     cert.IsCA,
+			NotAfter: cert.NotAfter,
+		})
+	}
+
+	sort.Slice(certs, func(i, j int) bool {
+		return certs[i].Name < certs[j].Name
+	})
+
+	return certs, nil
+}
@@ -0,0 +1,154 @@
+package certificates_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/certificates"
+	"github.com/aws/eks-anywhere/pkg/certificates/mocks"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/nodes"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+type certificatesTest struct {
+	*WithT
+	ctx               context.Context
+	nodes             *mocks.MockNodeLister
+	runner            *mocks.MockRenewerRunner
+	managementCluster *types.Cluster
+	spec              *cluster.Spec
+	cp, brCP, etcd    nodes.Node
+}
+
+func newCertificatesTest(t *testing.T) *certificatesTest {
+	ctrl := gomock.NewController(t)
+	return &certificatesTest{
+		WithT:             NewWithT(t),
+		ctx:               context.Background(),
+		nodes:             mocks.NewMockNodeLister(ctrl),
+		runner:            mocks.NewMockRenewerRunner(ctrl),
+		managementCluster: &types.Cluster{Name: "mgmt"},
+		spec: test.NewClusterSpec(func(s *cluster.Spec) {
+			s.Cluster.Name = "my-cluster"
+		}),
+		cp:   nodes.Node{Name: "cp-1", Address: "1.2.3.4", Role: nodes.ControlPlane, OSFamily: anywherev1.Ubuntu},
+		brCP: nodes.Node{Name: "cp-2", Address: "1.2.3.5", Role: nodes.ControlPlane, OSFamily: anywherev1.Bottlerocket},
+		etcd: nodes.Node{Name: "etcd-1", Address: "1.2.3.6", Role: nodes.Etcd, OSFamily: anywherev1.Ubuntu},
+	}
+}
+
+func certPEM(t *testing.T, isCA bool, notAfter time.Time) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test"},
+		NotBefore:             notAfter.Add(-time.Hour),
+		NotAfter:              notAfter,
+		IsCA:                  isCA,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func listOutput(files ...string) string {
+	out := ""
+	for i := 0; i < len(files); i += 2 {
+		out += fmt.Sprintf("==> %s\n%s", files[i], files[i+1])
+	}
+	return out
+}
+
+func TestInspectorCertificatesStackedEtcd(t *testing.T) {
+	tt := newCertificatesTest(t)
+	expiry := time.Date(2024, 5, 4, 3, 2, 1, 0, time.UTC)
+	caExpiry := time.Date(2033, 5, 4, 3, 2, 1, 0, time.UTC)
+
+	tt.nodes.EXPECT().ControlPlaneNodes(tt.ctx, tt.managementCluster, tt.spec).Return([]nodes.Node{tt.cp, tt.brCP}, nil)
+	tt.runner.EXPECT().Run(tt.ctx, tt.cp, `set -e; for f in $(find /etc/kubernetes/pki -name '*.crt' | sort); do echo "==> $f"; cat "$f"; done`).Return(listOutput(
+		"/etc/kubernetes/pki/etcd/ca.crt", certPEM(t, true, caExpiry),
+		"/etc/kubernetes/pki/apiserver.crt", certPEM(t, false, expiry),
+	), nil)
+	tt.runner.EXPECT().Run(tt.ctx, tt.brCP, `set -e; for f in $(find /var/lib/kubeadm/pki -name '*.crt' | sort); do echo "==> $f"; cat "$f"; done`).Return(listOutput(
+		"/var/lib/kubeadm/pki/apiserver.crt", certPEM(t, false, expiry),
+	), nil)
+
+	got, err := certificates.NewInspector(tt.nodes, tt.runner).Certificates(tt.ctx, tt.managementCluster, tt.spec)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(got).To(Equal([]certificates.Certificate{
+		{Node: "cp-1", Role: nodes.ControlPlane, Name: "apiserver", Path: "/etc/kubernetes/pki/apiserver.crt", NotAfter: expiry},
+		{Node: "cp-1", Role: nodes.ControlPlane, Name: "etcd/ca", Path: "/etc/kubernetes/pki/etcd/ca.crt", IsCA: true, NotAfter: caExpiry},
+		{Node: "cp-2", Role: nodes.ControlPlane, Name: "apiserver", Path: "/var/lib/kubeadm/pki/apiserver.crt", NotAfter: expiry},
+	}))
+}
+
+func TestInspectorCertificatesExternalEtcd(t *testing.T) {
+	tt := newCertificatesTest(t)
+	tt.spec.Cluster.Spec.ExternalEtcdConfiguration = &anywherev1.ExternalEtcdConfiguration{Count: 1}
+	expiry := time.Date(2024, 5, 4, 3, 2, 1, 0, time.UTC)
+
+	tt.nodes.EXPECT().ControlPlaneNodes(tt.ctx, tt.managementCluster, tt.spec).Return([]nodes.Node{tt.cp}, nil)
+	tt.nodes.EXPECT().EtcdNodes(tt.ctx, tt.managementCluster, tt.spec).Return([]nodes.Node{tt.etcd}, nil)
+	tt.runner.EXPECT().Run(tt.ctx, tt.etcd, gomock.Any()).Return(listOutput("/etc/etcd/pki/server.crt", certPEM(t, false, expiry)), nil)
+	tt.runner.EXPECT().Run(tt.ctx, tt.cp, gomock.Any()).Return(listOutput("/etc/kubernetes/pki/apiserver.crt", certPEM(t, false, expiry)), nil)
+
+	got, err := certificates.NewInspector(tt.nodes, tt.runner).Certificates(tt.ctx, tt.managementCluster, tt.spec)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(got).To(HaveLen(2))
+	tt.Expect(got[0].Node).To(Equal("etcd-1"))
+	tt.Expect(got[0].Name).To(Equal("server"))
+	tt.Expect(got[1].Node).To(Equal("cp-1"))
+}
+
+func TestInspectorCertificatesInvalidCertificate(t *testing.T) {
+	tt := newCertificatesTest(t)
+
+	tt.nodes.EXPECT().ControlPlaneNodes(tt.ctx, tt.managementCluster, tt.spec).Return([]nodes.Node{tt.cp}, nil)
+	tt.runner.EXPECT().Run(tt.ctx, tt.cp, gomock.Any()).Return(listOutput("/etc/kubernetes/pki/apiserver.crt", "not a cert\n"), nil)
+
+	_, err := certificates.NewInspector(tt.nodes, tt.runner).Certificates(tt.ctx, tt.managementCluster, tt.spec)
+	tt.Expect(err).To(MatchError(ContainSubstring("reading certificates from node cp-1: no PEM data found in /etc/kubernetes/pki/apiserver.crt")))
+}
+
+func TestInspectorCertificatesRunError(t *testing.T) {
+	tt := newCertificatesTest(t)
+
+	tt.nodes.EXPECT().ControlPlaneNodes(tt.ctx, tt.managementCluster, tt.spec).Return([]nodes.Node{tt.cp}, nil)
+	tt.runner.EXPECT().Run(tt.ctx, tt.cp, gomock.Any()).Return("", errors.New("unreachable"))
+
+	_, err := certificates.NewInspector(tt.nodes, tt.runner).Certificates(tt.ctx, tt.managementCluster, tt.spec)
+	tt.Expect(err).To(MatchError(ContainSubstring("reading certificates: unreachable")))
+}
+
+func TestCertificateExpiresWithin(t *testing.T) {
+	g := NewWithT(t)
+	now := time.Date(2024, 5, 4, 3, 2, 1, 0, time.UTC)
+	c := certificates.Certificate{NotAfter: now.Add(24 * time.Hour)}
+
+	g.Expect(c.ExpiresWithin(now, 48*time.Hour)).To(BeTrue())
+	g.Expect(c.ExpiresWithin(now, time.Hour)).To(BeFalse())
+}
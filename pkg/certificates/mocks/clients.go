@@ -0,0 +1,106 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/certificates/certificates.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	cluster "github.com/aws/eks-anywhere/pkg/cluster"
+	nodes "github.com/aws/eks-anywhere/pkg/nodes"
+	types "github.com/aws/eks-anywhere/pkg/types"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockNodeLister is a mock of NodeLister interface.
+type MockNodeLister struct {
+	ctrl     *gomock.Controller
+	recorder *MockNodeListerMockRecorder
+}
+
+// MockNodeListerMockRecorder is the mock recorder for MockNodeLister.
+type MockNodeListerMockRecorder struct {
+	mock *MockNodeLister
+}
+
+// NewMockNodeLister creates a new mock instance.
+func NewMockNodeLister(ctrl *gomock.Controller) *MockNodeLister {
+	mock := &MockNodeLister{ctrl: ctrl}
+	mock.recorder = &MockNodeListerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNodeLister) EXPECT() *MockNodeListerMockRecorder {
+	return m.recorder
+}
+
+// ControlPlaneNodes mocks base method.
+func (m *MockNodeLister) ControlPlaneNodes(ctx context.Context, managementCluster *types.Cluster, spec *cluster.Spec) ([]nodes.Node, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ControlPlaneNodes", ctx, managementCluster, spec)
+	ret0, _ := ret[0].([]nodes.Node)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ControlPlaneNodes indicates an expected call of ControlPlaneNodes.
+func (mr *MockNodeListerMockRecorder) ControlPlaneNodes(ctx, managementCluster, spec interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ControlPlaneNodes", reflect.TypeOf((*MockNodeLister)(nil).ControlPlaneNodes), ctx, managementCluster, spec)
+}
+
+// EtcdNodes mocks base method.
+func (m *MockNodeLister) EtcdNodes(ctx context.Context, managementCluster *types.Cluster, spec *cluster.Spec) ([]nodes.Node, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EtcdNodes", ctx, managementCluster, spec)
+	ret0, _ := ret[0].([]nodes.Node)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EtcdNodes indicates an expected call of EtcdNodes.
+func (mr *MockNodeListerMockRecorder) EtcdNodes(ctx, managementCluster, spec interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EtcdNodes", reflect.TypeOf((*MockNodeLister)(nil).EtcdNodes), ctx, managementCluster, spec)
+}
+
+// MockCommandRunner is a mock of CommandRunner interface.
+type MockCommandRunner struct {
+	ctrl     *gomock.Controller
+	recorder *MockCommandRunnerMockRecorder
+}
+
+// MockCommandRunnerMockRecorder is the mock recorder for MockCommandRunner.
+type MockCommandRunnerMockRecorder struct {
+	mock *MockCommandRunner
+}
+
+// NewMockCommandRunner creates a new mock instance.
+func NewMockCommandRunner(ctrl *gomock.Controller) *MockCommandRunner {
+	mock := &MockCommandRunner{ctrl: ctrl}
+	mock.recorder = &MockCommandRunnerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCommandRunner) EXPECT() *MockCommandRunnerMockRecorder {
+	return m.recorder
+}
+
+// Run mocks base method.
+func (m *MockCommandRunner) Run(ctx context.Context, node nodes.Node, script string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Run", ctx, node, script)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockCommandRunnerMockRecorder) Run(ctx, node, script interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockCommandRunner)(nil).Run), ctx, node, script)
+}
@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/certificates/renew.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	nodes "github.com/aws/eks-anywhere/pkg/nodes"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRenewerRunner is a mock of RenewerRunner interface.
+type MockRenewerRunner struct {
+	ctrl     *gomock.Controller
+	recorder *MockRenewerRunnerMockRecorder
+}
+
+// MockRenewerRunnerMockRecorder is the mock recorder for MockRenewerRunner.
+type MockRenewerRunnerMockRecorder struct {
+	mock *MockRenewerRunner
+}
+
+// NewMockRenewerRunner creates a new mock instance.
+func NewMockRenewerRunner(ctrl *gomock.Controller) *MockRenewerRunner {
+	mock := &MockRenewerRunner{ctrl: ctrl}
+	mock.recorder = &MockRenewerRunnerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRenewerRunner) EXPECT() *MockRenewerRunnerMockRecorder {
+	return m.recorder
+}
+
+// Run mocks base method.
+func (m *MockRenewerRunner) Run(ctx context.Context, node nodes.Node, script string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Run", ctx, node, script)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockRenewerRunnerMockRecorder) Run(ctx, node, script interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockRenewerRunner)(nil).Run), ctx, node, script)
+}
+
+// RunWithStdin mocks base method.
+func (m *MockRenewerRunner) RunWithStdin(ctx context.Context, node nodes.Node, in []byte, script string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunWithStdin", ctx, node, in, script)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RunWithStdin indicates an expected call of RunWithStdin.
+func (mr *MockRenewerRunnerMockRecorder) RunWithStdin(ctx, node, in, script interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunWithStdin", reflect.TypeOf((*MockRenewerRunner)(nil).RunWithStdin), ctx, node, in, script)
+}
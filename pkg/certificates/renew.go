@@ -0,0 +1,276 @@
+package certificates
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/nodes"
+	"github.com/aws/eks-anywhere/pkg/retrier"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const (
+	apiServerPort  = 6443
+	etcdClientPort = 2379
+
+	kubeadmManifestsDir        = "/etc/kubernetes/manifests"
+	kubeadmManifestsDirStashed = "/etc/kubernetes/manifests.eksa-certs"
+
+	// etcdadm needs an endpoint for the join phase but it is not used to generate certificates.
+	etcdadmDummyEndpoint = "http://eks-a-etcd-dumb-url"
+
+	// bottlerocketBootstrapImage is the kubeadm bootstrap host container image, which ships kubeadm and etcdadm.
+	bottlerocketBootstrapImage = `$(apiclient get | apiclient exec admin jq -r '.settings["host-containers"]["kubeadm-bootstrap"].source')`
+	bottlerocketStaticPods     = `$(apiclient get | apiclient exec admin jq -r '.settings.kubernetes["static-pods"] | keys[]')`
+)
+
+// RenewerRunner runs shell scripts as root in cluster nodes, optionally sending data to their stdin.
+type RenewerRunner interface {
+	Run(ctx context.Context, node nodes.Node, script string) (string, error)
+	RunWithStdin(ctx context.Context, node nodes.Node, in []byte, script string) (string, error)
+}
+
+// Renewer renews the certificates of the control plane and etcd nodes of a cluster.
+type Renewer struct {
+	nodes     NodeLister
+	runner    RenewerRunner
+	inspector *Inspector
+	retrier   *retrier.Retrier
+}
+
+// RenewerOpt allows to customize a Renewer on construction.
+type RenewerOpt func(*Renewer)
+
+// WithRetrier sets the retrier used to wait for the node components to be back after a restart.
+func WithRetrier(retrier *retrier.Retrier) RenewerOpt {
+	return func(r *Renewer) {
+		r.retrier = retrier
+	}
+}
+
+// NewRenewer returns a new Renewer.
+func NewRenewer(nodes NodeLister, runner RenewerRunner, opts ...RenewerOpt) *Renewer {
+	r := &Renewer{
+		nodes:     nodes,
+		runner:    runner,
+		inspector: NewInspector(nodes, runner),
+		retrier:   retrier.New(5*time.Minute, retrier.WithMaxRetries(60, 5*time.Second)),
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// Renew renews the certificates one node at a time, so the control plane and etcd keep quorum.
+// External etcd nodes are renewed first, since the control plane nodes need the new etcd
+// client certificate. After each node, the renewal is verified before moving to the next one.
+func (r *Renewer) Renew(ctx context.Context, managementCluster *types.Cluster, spec *cluster.Spec) error {
+	var etcdClientCert, etcdClientKey []byte
+	if spec.Cluster.Spec.ExternalEtcdConfiguration != nil {
+		etcdNodes, err := r.nodes.EtcdNodes(ctx, managementCluster, spec)
+		if err != nil {
+			return fmt.Errorf("getting etcd nodes: %v", err)
+		}
+
+		for _, node := range etcdNodes {
+			if err := r.renewNode(ctx, node, nil, nil); err != nil {
+				return err
+			}
+		}
+
+		if len(etcdNodes) > 0 {
+			if etcdClientCert, etcdClientKey, err = r.etcdClientCertificate(ctx, etcdNodes[0]); err != nil {
+				return err
+			}
+		}
+	}
+
+	controlPlaneNodes, err := r.nodes.ControlPlaneNodes(ctx, managementCluster, spec)
+	if err != nil {
+		return fmt.Errorf("getting control plane nodes: %v", err)
+	}
+
+	for _, node := range controlPlaneNodes {
+		if err := r.renewNode(ctx, node, etcdClientCert, etcdClientKey); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *Renewer) renewNode(ctx context.Context, node nodes.Node, etcdClientCert, etcdClientKey []byte) error {
+	logger.Info("Renewing certificates", "node", node.Name)
+	before, err := r.inspector.NodeCertificates(ctx, node)
+	if err != nil {
+		return err
+	}
+
+	if _, err := r.runner.Run(ctx, node, renewScript(node)); err != nil {
+		return fmt.Errorf("renewing certificates: %v", err)
+	}
+
+	if etcdClientCert != nil {
+		certFile, keyFile := etcdClientCertificateFiles(node)
+		logger.V(3).Info("Copying etcd client certificate", "node", node.Name, "certificate", certFile)
+		if _, err := r.runner.RunWithStdin(ctx, node, etcdClientCert, writeFileScript(certFile, "644")); err != nil {
+			return fmt.Errorf("copying etcd client certificate: %v", err)
+		}
+		if _, err := r.runner.RunWithStdin(ctx, node, etcdClientKey, writeFileScript(keyFile, "600")); err != nil {
+			return fmt.Errorf("copying etcd client key: %v", err)
+		}
+	}
+
+	logger.V(3).Info("Restarting components to load the new certificates", "node", node.Name)
+	if _, err := r.runner.Run(ctx, node, restartScript(node)); err != nil {
+		return fmt.Errorf("restarting components: %v", err)
+	}
+
+	err = r.retrier.Retry(func() error {
+		_, err := r.runner.Run(ctx, node, portOpenScript(componentPort(node)))
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("waiting for components in node %s to be running: %v", node.Name, err)
+	}
+
+	after, err := r.inspector.NodeCertificates(ctx, node)
+	if err != nil {
+		return err
+	}
+
+	if err := verifyRenewed(node, before, after); err != nil {
+		return err
+	}
+
+	logger.V(0).Info("Certificates renewed", "node", node.Name)
+	return nil
+}
+
+func (r *Renewer) etcdClientCertificate(ctx context.Context, node nodes.Node) (cert, key []byte, err error) {
+	dir := certificatesDir(node)
+	certData, err := r.runner.Run(ctx, node, fmt.Sprintf("cat %s/apiserver-etcd-client.crt", dir))
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading etcd client certificate: %v", err)
+	}
+
+	keyData, err := r.runner.Run(ctx, node, fmt.Sprintf("cat %s/apiserver-etcd-client.key", dir))
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading etcd client key: %v", err)
+	}
+
+	return []byte(certData), []byte(keyData), nil
+}
+
+// verifyRenewed checks that every non CA certificate in the node expires later than before the renewal.
+func verifyRenewed(node nodes.Node, before, after []Certificate) error {
+	previous := make(map[string]Certificate, len(before))
+	for _, c := range before {
+		previous[c.Path] = c
+	}
+
+	for _, c := range after {
+		if c.IsCA {
+			continue
+		}
+
+		if p, ok := previous[c.Path]; ok && !c.NotAfter.After(p.NotAfter) {
+			return fmt.Errorf("certificate %s in node %s was not renewed, it still expires at %s", c.Name, node.Name, c.NotAfter.UTC().Format(time.RFC3339))
+		}
+	}
+
+	return nil
+}
+
+func componentPort(node nodes.Node) int {
+	if node.Role == nodes.Etcd {
+		return etcdClientPort
+	}
+
+	return apiServerPort
+}
+
+// etcdClientCertificateFiles returns the files the kube-apiserver uses to authenticate with an external etcd.
+func etcdClientCertificateFiles(node nodes.Node) (certFile, keyFile string) {
+	if node.OSFamily == anywherev1.Bottlerocket {
+		return "/var/lib/kubeadm/pki/server-etcd-client.crt", "/var/lib/kubeadm/pki/apiserver-etcd-client.key"
+	}
+
+	return "/etc/kubernetes/pki/apiserver-etcd-client.crt", "/etc/kubernetes/pki/apiserver-etcd-client.key"
+}
+
+// renewScript regenerates the node certificates with kubeadm, for control plane nodes, or etcdadm, for
+// etcd nodes. Bottlerocket hosts don't ship these binaries, so they are run from the bootstrap image.
+func renewScript(node nodes.Node) string {
+	dir := certificatesDir(node)
+	switch {
+	case node.Role == nodes.Etcd && node.OSFamily == anywherev1.Bottlerocket:
+		return fmt.Sprintf(
+			"set -e; IMAGE=%s; ctr image pull $IMAGE >/dev/null; %s; "+
+				"ctr run --mount type=bind,src=%s,dst=/etc/etcd/pki,options=rbind:rw --net-host --rm $IMAGE eksa-certs-renew /opt/bin/etcdadm join phase certificates %s --init-system kubelet",
+			bottlerocketBootstrapImage, removeEtcdCertificatesScript(dir), dir, etcdadmDummyEndpoint,
+		)
+	case node.Role == nodes.Etcd:
+		return fmt.Sprintf(
+			"set -e; %s; etcdadm join phase certificates %s --init-system systemd",
+			removeEtcdCertificatesScript(dir), etcdadmDummyEndpoint,
+		)
+	case node.OSFamily == anywherev1.Bottlerocket:
+		return fmt.Sprintf(
+			"set -e; IMAGE=%s; ctr image pull $IMAGE >/dev/null; "+
+				"ctr run --mount type=bind,src=/var/lib/kubeadm,dst=/var/lib/kubeadm,options=rbind:rw --mount type=bind,src=/var/lib/kubeadm,dst=/etc/kubernetes,options=rbind:rw --rm $IMAGE eksa-certs-renew /opt/bin/kubeadm certs renew all",
+			bottlerocketBootstrapImage,
+		)
+	default:
+		return "set -e; kubeadm certs renew all"
+	}
+}
+
+// removeEtcdCertificatesScript backs up the etcd certificates and removes the ones signed by the CA,
+// since etcdadm only generates certificates that don't exist.
+func removeEtcdCertificatesScript(dir string) string {
+	return fmt.Sprintf(
+		"rm -rf %[1]s.eksa-backup; cp -r %[1]s %[1]s.eksa-backup; rm -f %[1]s/server.* %[1]s/peer.* %[1]s/apiserver-etcd-client.* %[1]s/etcdctl-etcd-client.*",
+		dir,
+	)
+}
+
+// restartScript restarts the components running in the node so they load the new certificates.
+// Static pods are stopped and the script waits for their port to be closed before starting them again.
+func restartScript(node nodes.Node) string {
+	port := componentPort(node)
+	switch {
+	case node.OSFamily == anywherev1.Bottlerocket:
+		return fmt.Sprintf(
+			"set -e; PODS=%[1]s; for p in $PODS; do apiclient set settings.kubernetes.static-pods.$p.enabled=false; done; %[2]s; "+
+				"for p in $PODS; do apiclient set settings.kubernetes.static-pods.$p.enabled=true; done",
+			bottlerocketStaticPods, waitForPortClosedScript(port),
+		)
+	case node.Role == nodes.Etcd:
+		return "systemctl restart etcd"
+	default:
+		return fmt.Sprintf(
+			"set -e; mkdir -p %[1]s; mv %[2]s/*.yaml %[1]s/; %[3]s; mv %[1]s/*.yaml %[2]s/; rmdir %[1]s",
+			kubeadmManifestsDirStashed, kubeadmManifestsDir, waitForPortClosedScript(port),
+		)
+	}
+}
+
+func portOpenScript(port int) string {
+	return fmt.Sprintf("timeout 1 bash -c '</dev/tcp/127.0.0.1/%d'", port)
+}
+
+func waitForPortClosedScript(port int) string {
+	return fmt.Sprintf("for i in $(seq 60); do %s 2>/dev/null || break; sleep 2; done", portOpenScript(port))
+}
+
+func writeFileScript(file, mode string) string {
+	return fmt.Sprintf("set -e; cat > %[1]s.eksa-new; chmod %[2]s %[1]s.eksa-new; mv %[1]s.eksa-new %[1]s", file, mode)
+}
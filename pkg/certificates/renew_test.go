@@ -0,0 +1,97 @@
+package certificates_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/certificates"
+	"github.com/aws/eks-anywhere/pkg/nodes"
+	"github.com/aws/eks-anywhere/pkg/retrier"
+)
+
+func TestRenewerRenewStackedEtcdUbuntu(t *testing.T) {
+	tt := newCertificatesTest(t)
+	oldExpiry := time.Date(2024, 5, 4, 3, 2, 1, 0, time.UTC)
+	newExpiry := oldExpiry.Add(365 * 24 * time.Hour)
+	r := certificates.NewRenewer(tt.nodes, tt.runner, certificates.WithRetrier(retrier.NewWithMaxRetries(2, 0)))
+
+	tt.nodes.EXPECT().ControlPlaneNodes(tt.ctx, tt.managementCluster, tt.spec).Return([]nodes.Node{tt.cp}, nil)
+	gomock.InOrder(
+		tt.runner.EXPECT().Run(tt.ctx, tt.cp, gomock.Any()).Return(listOutput("/etc/kubernetes/pki/apiserver.crt", certPEM(t, false, oldExpiry)), nil),
+		tt.runner.EXPECT().Run(tt.ctx, tt.cp, "set -e; kubeadm certs renew all").Return("", nil),
+		tt.runner.EXPECT().Run(tt.ctx, tt.cp, "set -e; mkdir -p /etc/kubernetes/manifests.eksa-certs; mv /etc/kubernetes/manifests/*.yaml /etc/kubernetes/manifests.eksa-certs/; "+
+			"for i in $(seq 60); do timeout 1 bash -c '</dev/tcp/127.0.0.1/6443' 2>/dev/null || break; sleep 2; done; "+
+			"mv /etc/kubernetes/manifests.eksa-certs/*.yaml /etc/kubernetes/manifests/; rmdir /etc/kubernetes/manifests.eksa-certs").Return("", nil),
+		tt.runner.EXPECT().Run(tt.ctx, tt.cp, "timeout 1 bash -c '</dev/tcp/127.0.0.1/6443'").Return("", errors.New("connection refused")),
+		tt.runner.EXPECT().Run(tt.ctx, tt.cp, "timeout 1 bash -c '</dev/tcp/127.0.0.1/6443'").Return("", nil),
+		tt.runner.EXPECT().Run(tt.ctx, tt.cp, gomock.Any()).Return(listOutput("/etc/kubernetes/pki/apiserver.crt", certPEM(t, false, newExpiry)), nil),
+	)
+
+	tt.Expect(r.Renew(tt.ctx, tt.managementCluster, tt.spec)).To(Succeed())
+}
+
+func TestRenewerRenewExternalEtcd(t *testing.T) {
+	tt := newCertificatesTest(t)
+	tt.spec.Cluster.Spec.ExternalEtcdConfiguration = &anywherev1.ExternalEtcdConfiguration{Count: 1}
+	oldExpiry := time.Date(2024, 5, 4, 3, 2, 1, 0, time.UTC)
+	newExpiry := oldExpiry.Add(365 * 24 * time.Hour)
+	r := certificates.NewRenewer(tt.nodes, tt.runner, certificates.WithRetrier(retrier.NewWithMaxRetries(1, 0)))
+
+	tt.nodes.EXPECT().EtcdNodes(tt.ctx, tt.managementCluster, tt.spec).Return([]nodes.Node{tt.etcd}, nil)
+	tt.nodes.EXPECT().ControlPlaneNodes(tt.ctx, tt.managementCluster, tt.spec).Return([]nodes.Node{tt.brCP}, nil)
+	gomock.InOrder(
+		tt.runner.EXPECT().Run(tt.ctx, tt.etcd, gomock.Any()).Return(listOutput("/etc/etcd/pki/server.crt", certPEM(t, false, oldExpiry)), nil),
+		tt.runner.EXPECT().Run(tt.ctx, tt.etcd, "set -e; rm -rf /etc/etcd/pki.eksa-backup; cp -r /etc/etcd/pki /etc/etcd/pki.eksa-backup; "+
+			"rm -f /etc/etcd/pki/server.* /etc/etcd/pki/peer.* /etc/etcd/pki/apiserver-etcd-client.* /etc/etcd/pki/etcdctl-etcd-client.*; "+
+			"etcdadm join phase certificates http://eks-a-etcd-dumb-url --init-system systemd").Return("", nil),
+		tt.runner.EXPECT().Run(tt.ctx, tt.etcd, "systemctl restart etcd").Return("", nil),
+		tt.runner.EXPECT().Run(tt.ctx, tt.etcd, "timeout 1 bash -c '</dev/tcp/127.0.0.1/2379'").Return("", nil),
+		tt.runner.EXPECT().Run(tt.ctx, tt.etcd, gomock.Any()).Return(listOutput("/etc/etcd/pki/server.crt", certPEM(t, false, newExpiry)), nil),
+		tt.runner.EXPECT().Run(tt.ctx, tt.etcd, "cat /etc/etcd/pki/apiserver-etcd-client.crt").Return("cert", nil),
+		tt.runner.EXPECT().Run(tt.ctx, tt.etcd, "cat /etc/etcd/pki/apiserver-etcd-client.key").Return("key", nil),
+		tt.runner.EXPECT().Run(tt.ctx, tt.brCP, gomock.Any()).Return(listOutput("/var/lib/kubeadm/pki/apiserver.crt", certPEM(t, false, oldExpiry)), nil),
+		tt.runner.EXPECT().Run(tt.ctx, tt.brCP, gomock.Any()).Return("", nil),
+		tt.runner.EXPECT().RunWithStdin(tt.ctx, tt.brCP, []byte("cert"), "set -e; cat > /var/lib/kubeadm/pki/server-etcd-client.crt.eksa-new; "+
+			"chmod 644 /var/lib/kubeadm/pki/server-etcd-client.crt.eksa-new; mv /var/lib/kubeadm/pki/server-etcd-client.crt.eksa-new /var/lib/kubeadm/pki/server-etcd-client.crt").Return("", nil),
+		tt.runner.EXPECT().RunWithStdin(tt.ctx, tt.brCP, []byte("key"), gomock.Any()).Return("", nil),
+		tt.runner.EXPECT().Run(tt.ctx, tt.brCP, gomock.Any()).Return("", nil),
+		tt.runner.EXPECT().Run(tt.ctx, tt.brCP, "timeout 1 bash -c '</dev/tcp/127.0.0.1/6443'").Return("", nil),
+		tt.runner.EXPECT().Run(tt.ctx, tt.brCP, gomock.Any()).Return(listOutput("/var/lib/kubeadm/pki/apiserver.crt", certPEM(t, false, newExpiry)), nil),
+	)
+
+	tt.Expect(r.Renew(tt.ctx, tt.managementCluster, tt.spec)).To(Succeed())
+}
+
+func TestRenewerRenewNotRenewed(t *testing.T) {
+	tt := newCertificatesTest(t)
+	expiry := time.Date(2024, 5, 4, 3, 2, 1, 0, time.UTC)
+	r := certificates.NewRenewer(tt.nodes, tt.runner, certificates.WithRetrier(retrier.NewWithMaxRetries(1, 0)))
+	certs := listOutput(
+		"/var/lib/kubeadm/pki/ca.crt", certPEM(t, true, expiry),
+		"/var/lib/kubeadm/pki/apiserver.crt", certPEM(t, false, expiry),
+	)
+
+	tt.nodes.EXPECT().ControlPlaneNodes(tt.ctx, tt.managementCluster, tt.spec).Return([]nodes.Node{tt.brCP, tt.cp}, nil)
+	tt.runner.EXPECT().Run(tt.ctx, tt.brCP, gomock.Any()).Return(certs, nil).Times(5)
+
+	tt.Expect(r.Renew(tt.ctx, tt.managementCluster, tt.spec)).To(MatchError(ContainSubstring("certificate apiserver in node cp-2 was not renewed")))
+}
+
+func TestRenewerRenewRestartError(t *testing.T) {
+	tt := newCertificatesTest(t)
+	r := certificates.NewRenewer(tt.nodes, tt.runner)
+
+	tt.nodes.EXPECT().ControlPlaneNodes(tt.ctx, tt.managementCluster, tt.spec).Return([]nodes.Node{tt.cp}, nil)
+	gomock.InOrder(
+		tt.runner.EXPECT().Run(tt.ctx, tt.cp, gomock.Any()).Return("", nil),
+		tt.runner.EXPECT().Run(tt.ctx, tt.cp, "set -e; kubeadm certs renew all").Return("", nil),
+		tt.runner.EXPECT().Run(tt.ctx, tt.cp, gomock.Any()).Return("", errors.New("no manifests")),
+	)
+
+	tt.Expect(r.Renew(tt.ctx, tt.managementCluster, tt.spec)).To(MatchError(ContainSubstring("restarting components: no manifests")))
+}
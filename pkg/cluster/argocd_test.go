@@ -0,0 +1,109 @@
+package cluster_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/cluster/mocks"
+)
+
+func TestParseConfigArgoCD(t *testing.T) {
+	g := NewWithT(t)
+	config, err := cluster.ParseConfigFromFile("testdata/docker_cluster_argocd.yaml")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(config.FluxConfig).To(BeNil())
+	g.Expect(config.ArgoCDConfig).NotTo(BeNil())
+	g.Expect(config.ArgoCDConfig.Spec.Github).To(Equal(&anywherev1.GithubProviderConfig{Owner: "janedoe", Repository: "argocd-fleet"}))
+	g.Expect(config.ChildObjects()).To(ContainElement(config.ArgoCDConfig))
+
+	g.Expect(cluster.SetConfigDefaults(config)).To(Succeed())
+	g.Expect(config.ArgoCDConfig.Spec.Branch).To(Equal(anywherev1.ArgoCDDefaultBranch))
+	g.Expect(config.ArgoCDConfig.Spec.ClusterConfigPath).To(Equal("clusters/m-docker"))
+	g.Expect(cluster.ValidateConfig(config)).To(Succeed())
+}
+
+func TestSetDefaultArgoCDConfigPath(t *testing.T) {
+	tests := []struct {
+		name     string
+		cluster  *anywherev1.Cluster
+		path     string
+		wantPath string
+	}{
+		{
+			name:     "self-managed cluster",
+			cluster:  &anywherev1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "mgmt"}},
+			wantPath: "clusters/mgmt",
+		},
+		{
+			name: "workload cluster",
+			cluster: &anywherev1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "workload"},
+				Spec:       anywherev1.ClusterSpec{ManagementCluster: anywherev1.ManagementCluster{Name: "mgmt"}},
+			},
+			wantPath: "clusters/mgmt",
+		},
+		{
+			name:     "path already set",
+			cluster:  &anywherev1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "mgmt"}},
+			path:     "fleet/mgmt",
+			wantPath: "fleet/mgmt",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			config := &cluster.Config{
+				Cluster:      tt.cluster,
+				ArgoCDConfig: &anywherev1.ArgoCDConfig{Spec: anywherev1.ArgoCDConfigSpec{ClusterConfigPath: tt.path}},
+			}
+			g.Expect(cluster.SetDefaultArgoCDConfigPath(config)).To(Succeed())
+			g.Expect(config.ArgoCDConfig.Spec.ClusterConfigPath).To(Equal(tt.wantPath))
+		})
+	}
+}
+
+func TestDefaultConfigClientBuilderArgoCDConfig(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	b := cluster.NewDefaultConfigClientBuilder()
+	ctrl := gomock.NewController(t)
+	client := mocks.NewMockClient(ctrl)
+	cluster := &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-cluster",
+			Namespace: "default",
+		},
+		Spec: anywherev1.ClusterSpec{
+			GitOpsRef: &anywherev1.Ref{
+				Kind: anywherev1.ArgoCDConfigKind,
+				Name: "my-argocd",
+			},
+		},
+	}
+	argoCDConfig := &anywherev1.ArgoCDConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-argocd",
+			Namespace: "default",
+		},
+	}
+
+	client.EXPECT().Get(ctx, "my-argocd", "default", &anywherev1.ArgoCDConfig{}).DoAndReturn(
+		func(ctx context.Context, name, namespace string, obj runtime.Object) error {
+			c := obj.(*anywherev1.ArgoCDConfig)
+			c.ObjectMeta = argoCDConfig.ObjectMeta
+			return nil
+		},
+	)
+
+	config, err := b.Build(ctx, client, cluster)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(config.FluxConfig).To(BeNil())
+	g.Expect(config.ArgoCDConfig).To(Equal(argoCDConfig))
+}
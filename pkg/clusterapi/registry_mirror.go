@@ -82,6 +82,8 @@ func registryMirrorConfigContent(registryMirror *registrymirror.RegistryMirror)
 		"mirrorBase":        registryMirror.BaseRegistry,
 		"registryCACert":    registryMirror.CACertContent,
 		"insecureSkip":      registryMirror.InsecureSkipVerify,
+		// containerd doesn't allow to set both the CRI registry mirrors and the hosts config path.
+		"registryMirrorHosts": len(registryMirror.UpstreamMirrors) > 0,
 	}
 
 	config, err := templater.Execute(containerdConfig, val)
@@ -113,6 +115,20 @@ func registryMirrorConfig(registryMirrorConfig *v1alpha1.RegistryMirrorConfigura
 		})
 	}
 
+	if len(registryMirror.UpstreamMirrors) > 0 {
+		hosts, err := containerd.HostsFiles(registryMirror)
+		if err != nil {
+			return nil, err
+		}
+		for _, h := range hosts {
+			files = append(files, bootstrapv1.File{
+				Path:    h.Path,
+				Owner:   "root:root",
+				Content: h.Content,
+			})
+		}
+	}
+
 	return files, nil
 }
 
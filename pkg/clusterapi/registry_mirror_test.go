@@ -126,6 +126,57 @@ var registryMirrorTests = []struct {
 			CACert:   "xyz",
 		},
 	},
+	{
+		name: "with upstream mirrors",
+		registryMirrorConfig: &v1alpha1.RegistryMirrorConfiguration{
+			Endpoint:      "1.2.3.4",
+			Port:          "443",
+			CACertContent: "xyz",
+			Mirrors: []v1alpha1.UpstreamRegistryMirror{
+				{
+					Upstream: "docker.io",
+					Endpoint: "cache.internal:5000/dockerhub",
+				},
+			},
+		},
+		wantFiles: []bootstrapv1.File{
+			{
+				Path:  "/etc/containerd/config_append.toml",
+				Owner: "root:root",
+				Content: `[plugins."io.containerd.grpc.v1.cri".registry]
+  config_path = "/etc/containerd/certs.d"`,
+			},
+			{
+				Path:    "/etc/containerd/certs.d/1.2.3.4:443/ca.crt",
+				Owner:   "root:root",
+				Content: "xyz",
+			},
+			{
+				Path:  "/etc/containerd/certs.d/docker.io/hosts.toml",
+				Owner: "root:root",
+				Content: `server = "https://registry-1.docker.io"
+[host."https://cache.internal:5000/v2/dockerhub"]
+  capabilities = ["pull", "resolve"]
+  override_path = true`,
+			},
+			{
+				Path:  "/etc/containerd/certs.d/public.ecr.aws/hosts.toml",
+				Owner: "root:root",
+				Content: `server = "https://public.ecr.aws"
+[host."https://1.2.3.4:443"]
+  capabilities = ["pull", "resolve"]
+  ca = "/etc/containerd/certs.d/1.2.3.4:443/ca.crt"`,
+			},
+		},
+		wantRegistryConfig: bootstrapv1.RegistryMirrorConfiguration{
+			Endpoint: "1.2.3.4:443",
+			CACert:   "xyz",
+		},
+		wantRegistryConfigEtcd: &etcdbootstrapv1.RegistryMirrorConfiguration{
+			Endpoint: "1.2.3.4:443",
+			CACert:   "xyz",
+		},
+	},
 }
 
 func TestSetRegistryMirrorInKubeadmControlPlaneBottleRocket(t *testing.T) {
@@ -99,6 +99,7 @@ type ClusterManager struct {
 type ClusterClient interface {
 	KubernetesClient
 	BackupManagement(ctx context.Context, cluster *types.Cluster, managementStatePath string) error
+	RestoreManagement(ctx context.Context, to *types.Cluster, clusterName, managementStatePath string) error
 	MoveManagement(ctx context.Context, from, target *types.Cluster, clusterName string) error
 	WaitForClusterReady(ctx context.Context, cluster *types.Cluster, timeout string, clusterName string) error
 	WaitForControlPlaneAvailable(ctx context.Context, cluster *types.Cluster, timeout string, newClusterName string) error
@@ -308,6 +309,18 @@ func (c *ClusterManager) BackupCAPI(ctx context.Context, cluster *types.Cluster,
 	return nil
 }
 
+// RestoreCAPI restores the backup of the management cluster's resources taken with BackupCAPI into the cluster `to`.
+func (c *ClusterManager) RestoreCAPI(ctx context.Context, to *types.Cluster, clusterName, managementStatePath string) error {
+	r := retrier.New(c.clusterctlMoveTimeout, retrier.WithRetryPolicy(clusterctlMoveRetryPolicy))
+	err := r.Retry(func() error {
+		return c.clusterClient.RestoreManagement(ctx, to, clusterName, managementStatePath)
+	})
+	if err != nil {
+		return fmt.Errorf("restoring CAPI resources of management cluster from backup: %v", err)
+	}
+	return nil
+}
+
 func (c *ClusterManager) MoveCAPI(ctx context.Context, from, to *types.Cluster, clusterName string, clusterSpec *cluster.Spec, checkers ...types.NodeReadyChecker) error {
 	logger.V(3).Info("Waiting for management machines to be ready before move")
 	labels := []string{clusterv1.MachineControlPlaneNameLabel, clusterv1.MachineDeploymentNameLabel}
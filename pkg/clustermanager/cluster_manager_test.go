@@ -1342,6 +1342,36 @@ func TestClusterManagerBackupCAPIRetrySuccess(t *testing.T) {
 	}
 }
 
+func TestClusterManagerRestoreCAPISuccess(t *testing.T) {
+	to := &types.Cluster{
+		Name: "bootstrap-cluster",
+	}
+
+	ctx := context.Background()
+
+	c, m := newClusterManager(t)
+	m.client.EXPECT().RestoreManagement(ctx, to, "cluster-name", managementStatePath)
+
+	if err := c.RestoreCAPI(ctx, to, "cluster-name", managementStatePath); err != nil {
+		t.Errorf("ClusterManager.RestoreCAPI() error = %v, wantErr nil", err)
+	}
+}
+
+func TestClusterManagerRestoreCAPIError(t *testing.T) {
+	to := &types.Cluster{
+		Name: "bootstrap-cluster",
+	}
+
+	ctx := context.Background()
+
+	c, m := newClusterManager(t)
+	m.client.EXPECT().RestoreManagement(ctx, to, "cluster-name", managementStatePath).Return(errors.New("backup not found"))
+
+	if err := c.RestoreCAPI(ctx, to, "cluster-name", managementStatePath); err == nil {
+		t.Error("ClusterManager.RestoreCAPI() error = nil, wantErr not nil")
+	}
+}
+
 func TestClusterctlWaitRetryPolicy(t *testing.T) {
 	connectionRefusedError := fmt.Errorf("Error: failed to connect to the management cluster: action failed after 9 attempts: Get \"https://127.0.0.1:53733/api?timeout=30s\": dial tcp 127.0.0.1:53733: connect: connection refused")
 	ioTimeoutError := fmt.Errorf("Error: failed to connect to the management cluster: action failed after 9 attempts: Get \"https://127.0.0.1:61994/api?timeout=30s\": net/http: TLS handshake timeout")
@@ -21,6 +21,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/features"
 	"github.com/aws/eks-anywhere/pkg/manifests"
 	"github.com/aws/eks-anywhere/pkg/manifests/bundles"
+	"github.com/aws/eks-anywhere/pkg/templater"
 	"github.com/aws/eks-anywhere/pkg/types"
 	"github.com/aws/eks-anywhere/pkg/yamlutil"
 )
@@ -59,19 +60,13 @@ func WithEKSAInstallerNoTimeouts() EKSAInstallerOpt {
 
 // Install configures and applies eks-a components in a cluster accordingly to a spec.
 func (i *EKSAInstaller) Install(ctx context.Context, log logr.Logger, cluster *types.Cluster, spec *cluster.Spec) error {
-	generator := EKSAComponentGenerator{log: log, reader: i.reader}
+	generator := NewEKSAComponentGenerator(log, i.reader)
 	components, err := generator.buildEKSAComponentsSpec(spec)
 	if err != nil {
 		return err
 	}
 
-	objs := make([]runtime.Object, 0, len(components.rest)+1)
-	objs = append(objs, components.deployment)
-	for _, o := range components.rest {
-		objs = append(objs, o)
-	}
-
-	for _, o := range objs {
+	for _, o := range components.objects() {
 		if err = i.client.Apply(ctx, cluster.KubeconfigFile, o); err != nil {
 			return fmt.Errorf("applying eksa components: %v", err)
 		}
@@ -113,6 +108,30 @@ type EKSAComponentGenerator struct {
 	reader manifests.FileReader
 }
 
+// NewEKSAComponentGenerator constructs a new EKSAComponentGenerator.
+func NewEKSAComponentGenerator(log logr.Logger, reader manifests.FileReader) *EKSAComponentGenerator {
+	return &EKSAComponentGenerator{
+		log:    log,
+		reader: reader,
+	}
+}
+
+// GenerateManifest returns the yaml manifest with the eks-a components configured for a spec,
+// the same objects Install applies to a cluster.
+func (g *EKSAComponentGenerator) GenerateManifest(spec *cluster.Spec) ([]byte, error) {
+	components, err := g.buildEKSAComponentsSpec(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest, err := templater.ObjectsToYaml(components.objects()...)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling eksa components: %v", err)
+	}
+
+	return manifest, nil
+}
+
 func (g *EKSAComponentGenerator) buildEKSAComponentsSpec(spec *cluster.Spec) (*eksaComponents, error) {
 	components, err := g.parseEKSAComponentsSpec(spec)
 	if err != nil {
@@ -217,6 +236,16 @@ type eksaComponents struct {
 	rest       []*unstructured.Unstructured
 }
 
+func (c *eksaComponents) objects() []runtime.Object {
+	objs := make([]runtime.Object, 0, len(c.rest)+1)
+	objs = append(objs, c.deployment)
+	for _, o := range c.rest {
+		objs = append(objs, o)
+	}
+
+	return objs
+}
+
 func (c *eksaComponents) BuildFromParsed(lookup yamlutil.ObjectLookup) error {
 	for _, obj := range lookup {
 		if obj.GetObjectKind().GroupVersionKind().Kind == "Deployment" {
@@ -70,7 +70,7 @@ func TestEKSAInstallerInstallSuccessWithRealManifest(t *testing.T) {
 	tt := newInstallerTest(t)
 	tt.newSpec.VersionsBundle.Eksa.Components.URI = "../../config/manifest/eksa-components.yaml"
 	tt.client.EXPECT().Apply(tt.ctx, tt.cluster.KubeconfigFile, gomock.AssignableToTypeOf(&appsv1.Deployment{}))
-	tt.client.EXPECT().Apply(tt.ctx, tt.cluster.KubeconfigFile, gomock.Any()).Times(34) // there are 34 objects in the manifest
+	tt.client.EXPECT().Apply(tt.ctx, tt.cluster.KubeconfigFile, gomock.Any()).Times(35) // there are 35 objects in the manifest
 	tt.client.EXPECT().WaitForDeployment(tt.ctx, tt.cluster, "30m0s", "Available", "eksa-controller-manager", "eksa-system")
 
 	tt.Expect(tt.installer.Install(tt.ctx, test.NewNullLogger(), tt.cluster, tt.newSpec)).To(Succeed())
@@ -147,12 +147,33 @@ func TestEKSAInstallerInstallSuccessWithNoTimeout(t *testing.T) {
 	tt := newInstallerTest(t, clustermanager.WithEKSAInstallerNoTimeouts())
 	tt.newSpec.VersionsBundle.Eksa.Components.URI = "../../config/manifest/eksa-components.yaml"
 	tt.client.EXPECT().Apply(tt.ctx, tt.cluster.KubeconfigFile, gomock.AssignableToTypeOf(&appsv1.Deployment{}))
-	tt.client.EXPECT().Apply(tt.ctx, tt.cluster.KubeconfigFile, gomock.Any()).Times(34) // there are 34 objects in the manifest
+	tt.client.EXPECT().Apply(tt.ctx, tt.cluster.KubeconfigFile, gomock.Any()).Times(35) // there are 35 objects in the manifest
 	tt.client.EXPECT().WaitForDeployment(tt.ctx, tt.cluster, maxTime.String(), "Available", "eksa-controller-manager", "eksa-system")
 
 	tt.Expect(tt.installer.Install(tt.ctx, test.NewNullLogger(), tt.cluster, tt.newSpec)).To(Succeed())
 }
 
+func TestEKSAComponentGeneratorGenerateManifest(t *testing.T) {
+	tt := newInstallerTest(t)
+	tt.newSpec.VersionsBundle.Eksa.Components.URI = "testdata/eksa_components.yaml"
+	tt.newSpec.Cluster.Spec.DatacenterRef.Kind = anywherev1.VSphereDatacenterKind
+	g := clustermanager.NewEKSAComponentGenerator(tt.log, files.NewReader())
+
+	manifest, err := g.GenerateManifest(tt.newSpec)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(string(manifest)).To(ContainSubstring("--feature-gates=FullLifecycleAPI=true"))
+	tt.Expect(string(manifest)).To(ContainSubstring("kind: Namespace"))
+}
+
+func TestEKSAComponentGeneratorGenerateManifestReadError(t *testing.T) {
+	tt := newInstallerTest(t)
+	tt.newSpec.VersionsBundle.Eksa.Components.URI = "testdata/missing.yaml"
+	g := clustermanager.NewEKSAComponentGenerator(tt.log, files.NewReader())
+
+	_, err := g.GenerateManifest(tt.newSpec)
+	tt.Expect(err).To(MatchError(ContainSubstring("loading manifest for eksa components")))
+}
+
 func TestInstallerUpgradeNoSelfManaged(t *testing.T) {
 	tt := newInstallerTest(t)
 	tt.newSpec.Cluster.SetManagedBy("management-cluster")
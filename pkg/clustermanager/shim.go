@@ -27,8 +27,9 @@ func NewCreateClusterShim(
 	provider providers.Provider,
 ) *CreateClusterShim {
 	return &CreateClusterShim{
-		spec:    spec,
-		manager: manager,
+		spec:     spec,
+		manager:  manager,
+		provider: provider,
 	}
 }
 
@@ -45,6 +46,16 @@ func (s CreateClusterShim) CreateAsync(ctx context.Context, management *types.Cl
 	return nil
 }
 
+// InstallCAPI satisfies the workload.CAPIInstaller interface.
+func (s CreateClusterShim) InstallCAPI(ctx context.Context, cluster *types.Cluster) error {
+	return s.manager.InstallCAPI(ctx, s.spec, cluster, s.provider)
+}
+
+// MoveManagement satisfies the workload.ManagementMover interface.
+func (s CreateClusterShim) MoveManagement(ctx context.Context, from, to *types.Cluster) error {
+	return s.manager.MoveCAPI(ctx, from, to, s.spec.Cluster.Name, s.spec, types.WithNodeRef())
+}
+
 // GetName satisfies the workload.Cluster interface.
 func (s CreateClusterShim) GetName() string {
 	return s.spec.Cluster.Name
@@ -30,7 +30,12 @@ func MarshalClusterSpec(clusterSpec *cluster.Spec, datacenterConfig providers.Da
 		marshallables = append(marshallables, clusterSpec.GitOpsConfig.ConvertConfigToConfigGenerateStruct())
 	}
 
-	if clusterSpec.FluxConfig != nil && clusterSpec.GitOpsConfig == nil {
+	// The Argo CD manager also uses a FluxConfig internally to write to git, so the ArgoCDConfig takes precedence too.
+	if clusterSpec.ArgoCDConfig != nil {
+		marshallables = append(marshallables, clusterSpec.ArgoCDConfig.ConvertConfigToConfigGenerateStruct())
+	}
+
+	if clusterSpec.FluxConfig != nil && clusterSpec.GitOpsConfig == nil && clusterSpec.ArgoCDConfig == nil {
 		marshallables = append(marshallables, clusterSpec.FluxConfig.ConvertConfigToConfigGenerateStruct())
 	}
 
@@ -402,3 +402,45 @@ func TestWriteClusterConfigSnow(t *testing.T) {
 	g.Expect(clustermarshaller.WriteClusterConfig(clusterSpec, datacenterConfig, machineConfigs, writer)).To(Succeed())
 	test.AssertFilesEquals(t, filepath.Join(folder, "testcluster-eks-a-cluster.yaml"), "testdata/expected_marshalled_snow.yaml")
 }
+
+func TestMarshalClusterSpecWithArgoCDConfig(t *testing.T) {
+	g := NewWithT(t)
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = "mycluster"
+		s.Cluster.Spec.GitOpsRef = &v1alpha1.Ref{
+			Kind: v1alpha1.ArgoCDConfigKind,
+			Name: "config",
+		}
+		s.ArgoCDConfig = &v1alpha1.ArgoCDConfig{
+			TypeMeta: v1.TypeMeta{
+				Kind:       v1alpha1.ArgoCDConfigKind,
+				APIVersion: v1alpha1.GroupVersion.String(),
+			},
+			ObjectMeta: v1.ObjectMeta{
+				Name: "config",
+			},
+			Spec: v1alpha1.ArgoCDConfigSpec{
+				Github: &v1alpha1.GithubProviderConfig{
+					Owner:      "test",
+					Repository: "test",
+				},
+			},
+		}
+		// The Argo CD manager writes to git with the equivalent FluxConfig.
+		s.FluxConfig = s.ArgoCDConfig.ConvertToFluxConfig()
+	})
+	datacenterConfig := &v1alpha1.DockerDatacenterConfig{
+		TypeMeta: v1.TypeMeta{
+			Kind:       v1alpha1.DockerDatacenterKind,
+			APIVersion: v1alpha1.GroupVersion.String(),
+		},
+		ObjectMeta: v1.ObjectMeta{
+			Name: "config",
+		},
+	}
+
+	got, err := clustermarshaller.MarshalClusterSpec(clusterSpec, datacenterConfig, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(got)).To(ContainSubstring("kind: ArgoCDConfig"))
+	g.Expect(string(got)).NotTo(ContainSubstring("kind: FluxConfig"))
+}
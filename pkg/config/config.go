@@ -1,5 +1,7 @@
 package config
 
+import "time"
+
 const (
 	EksaGitPassphraseTokenEnv = "EKSA_GIT_SSH_KEY_PASSPHRASE"
 	EksaGitPrivateKeyTokenEnv = "EKSA_GIT_PRIVATE_KEY"
@@ -16,4 +18,10 @@ type CliConfig struct {
 	GitSshKeyPassphrase string
 	GitPrivateKeyFile   string
 	GitKnownHostsFile   string
+	// GitOpsPullRequest makes the CLI push cluster config updates to a feature branch
+	// and open a pull request against the GitOps branch instead of pushing to it directly.
+	GitOpsPullRequest bool
+	// GitOpsPullRequestTimeout is how long to wait for the pull request to be merged.
+	// When zero, the CLI hands back the pull request URL without waiting.
+	GitOpsPullRequestTimeout time.Duration
 }
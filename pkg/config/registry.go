@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"regexp"
+	"strings"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
@@ -29,11 +31,60 @@ func ReadCredentials() (username, password string, err error) {
 	return username, password, nil
 }
 
+var nonAlphanumeric = regexp.MustCompile("[^A-Z0-9]")
+
+// UpstreamMirrorCredentialsEnv returns the names of the env vars with the username and password
+// of the registry mirrors of an upstream registry, e.g. REGISTRY_USERNAME_DOCKER_IO for docker.io.
+func UpstreamMirrorCredentialsEnv(upstream string) (usernameEnv, passwordEnv string) {
+	suffix := "_" + nonAlphanumeric.ReplaceAllString(strings.ToUpper(upstream), "_")
+	return constants.RegistryUsername + suffix, constants.RegistryPassword + suffix
+}
+
+// ReadUpstreamMirrorCredentials reads the username and password of the registry mirrors of an upstream registry
+// from the env vars returned by UpstreamMirrorCredentialsEnv.
+func ReadUpstreamMirrorCredentials(upstream string) (username, password string, err error) {
+	usernameEnv, passwordEnv := UpstreamMirrorCredentialsEnv(upstream)
+	username, ok := os.LookupEnv(usernameEnv)
+	if !ok {
+		return "", "", fmt.Errorf("please set %s env var", usernameEnv)
+	}
+
+	password, ok = os.LookupEnv(passwordEnv)
+	if !ok {
+		return "", "", fmt.Errorf("please set %s env var", passwordEnv)
+	}
+
+	return username, password, nil
+}
+
+// UpstreamMirrorCredentials reads the username and password of the registry mirrors of the given upstream
+// registries from the env. They are returned by the name of their env vars, which are also the keys they are
+// stored with in the Kubernetes secret registry-credentials.
+func UpstreamMirrorCredentials(upstreams []string) (map[string]string, error) {
+	credentials := make(map[string]string, 2*len(upstreams))
+	for _, upstream := range upstreams {
+		username, password, err := ReadUpstreamMirrorCredentials(upstream)
+		if err != nil {
+			return nil, err
+		}
+		usernameEnv, passwordEnv := UpstreamMirrorCredentialsEnv(upstream)
+		credentials[usernameEnv] = username
+		credentials[passwordEnv] = password
+	}
+	return credentials, nil
+}
+
 // ReadCredentialsFromSecret reads from Kubernetes secret registry-credentials.
 // Returns the username and password, or error.
 func ReadCredentialsFromSecret(ctx context.Context, client client.Client) (username, password string, err error) {
+	return ReadCredentialsFromNamedSecret(ctx, client, registryAuthSecretName)
+}
+
+// ReadCredentialsFromNamedSecret reads the registry username and password from a Kubernetes secret
+// in the eksa-system namespace.
+func ReadCredentialsFromNamedSecret(ctx context.Context, client client.Client, name string) (username, password string, err error) {
 	registryAuthSecret := &corev1.Secret{}
-	key := types.NamespacedName{Name: registryAuthSecretName, Namespace: constants.EksaSystemNamespace}
+	key := types.NamespacedName{Name: name, Namespace: constants.EksaSystemNamespace}
 	if err := client.Get(ctx, key, registryAuthSecret); err != nil {
 		return "", "", errors.Wrap(err, "fetching registry auth secret")
 	}
@@ -44,6 +95,28 @@ func ReadCredentialsFromSecret(ctx context.Context, client client.Client) (usern
 	return string(rUsername), string(rPassword), nil
 }
 
+// ReadUpstreamMirrorCredentialsFromSecret reads the username and password of the registry mirrors of an upstream
+// registry from Kubernetes secret registry-credentials, where they are stored with the names of their env vars.
+func ReadUpstreamMirrorCredentialsFromSecret(ctx context.Context, client client.Client, upstream string) (username, password string, err error) {
+	registryAuthSecret := &corev1.Secret{}
+	key := types.NamespacedName{Name: registryAuthSecretName, Namespace: constants.EksaSystemNamespace}
+	if err := client.Get(ctx, key, registryAuthSecret); err != nil {
+		return "", "", errors.Wrap(err, "fetching registry auth secret")
+	}
+
+	usernameEnv, passwordEnv := UpstreamMirrorCredentialsEnv(upstream)
+	rUsername, ok := registryAuthSecret.Data[usernameEnv]
+	if !ok {
+		return "", "", fmt.Errorf("registry auth secret doesn't have %s", usernameEnv)
+	}
+	rPassword, ok := registryAuthSecret.Data[passwordEnv]
+	if !ok {
+		return "", "", fmt.Errorf("registry auth secret doesn't have %s", passwordEnv)
+	}
+
+	return string(rUsername), string(rPassword), nil
+}
+
 // SetCredentialsEnv sets the registry username and password env variables.
 func SetCredentialsEnv(username, password string) error {
 	if err := os.Setenv(constants.RegistryUsername, username); err != nil {
@@ -56,3 +129,18 @@ func SetCredentialsEnv(username, password string) error {
 
 	return nil
 }
+
+// SetUpstreamMirrorCredentialsEnv sets the env variables with the username and password of the registry mirrors
+// of an upstream registry.
+func SetUpstreamMirrorCredentialsEnv(upstream, username, password string) error {
+	usernameEnv, passwordEnv := UpstreamMirrorCredentialsEnv(upstream)
+	if err := os.Setenv(usernameEnv, username); err != nil {
+		return fmt.Errorf("failed setting env %s: %v", usernameEnv, err)
+	}
+
+	if err := os.Setenv(passwordEnv, password); err != nil {
+		return fmt.Errorf("failed setting env %s: %v", passwordEnv, err)
+	}
+
+	return nil
+}
@@ -31,6 +31,27 @@ func TestReadConfig(t *testing.T) {
 	assert.Equal(t, expectedPassword, password)
 }
 
+func TestReadUpstreamMirrorCredentials(t *testing.T) {
+	_, _, err := ReadUpstreamMirrorCredentials("quay.io")
+	assert.EqualError(t, err, "please set REGISTRY_USERNAME_QUAY_IO env var")
+
+	t.Setenv("REGISTRY_USERNAME_QUAY_IO", "testuser")
+	_, _, err = ReadUpstreamMirrorCredentials("quay.io")
+	assert.EqualError(t, err, "please set REGISTRY_PASSWORD_QUAY_IO env var")
+
+	t.Setenv("REGISTRY_PASSWORD_QUAY_IO", "testpass")
+	username, password, err := ReadUpstreamMirrorCredentials("quay.io")
+	assert.NoError(t, err)
+	assert.Equal(t, "testuser", username)
+	assert.Equal(t, "testpass", password)
+}
+
+func TestUpstreamMirrorCredentialsEnv(t *testing.T) {
+	usernameEnv, passwordEnv := UpstreamMirrorCredentialsEnv("registry.k8s.io:443")
+	assert.Equal(t, "REGISTRY_USERNAME_REGISTRY_K8S_IO_443", usernameEnv)
+	assert.Equal(t, "REGISTRY_PASSWORD_REGISTRY_K8S_IO_443", passwordEnv)
+}
+
 func TestSetCredentialsEnv(t *testing.T) {
 	uName := ""
 	uPass := ""
@@ -71,3 +92,77 @@ func TestReadCredentialsFromSecretNotFound(t *testing.T) {
 	assert.Empty(t, u)
 	assert.Empty(t, p)
 }
+
+func TestReadCredentialsFromNamedSecret(t *testing.T) {
+	ctx := context.Background()
+	sec := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "mirror-credentials",
+			Namespace: constants.EksaSystemNamespace,
+		},
+		Data: map[string][]byte{
+			"username": []byte("rotated-user"),
+			"password": []byte("rotated-pass"),
+		},
+	}
+
+	cl := fake.NewClientBuilder().WithRuntimeObjects(sec).Build()
+	u, p, err := ReadCredentialsFromNamedSecret(ctx, cl, "mirror-credentials")
+	assert.NoError(t, err)
+	assert.Equal(t, "rotated-user", u)
+	assert.Equal(t, "rotated-pass", p)
+}
+
+func TestUpstreamMirrorCredentials(t *testing.T) {
+	_, err := UpstreamMirrorCredentials([]string{"quay.io"})
+	assert.EqualError(t, err, "please set REGISTRY_USERNAME_QUAY_IO env var")
+
+	t.Setenv("REGISTRY_USERNAME_QUAY_IO", "testuser")
+	t.Setenv("REGISTRY_PASSWORD_QUAY_IO", "testpass")
+	credentials, err := UpstreamMirrorCredentials([]string{"quay.io"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"REGISTRY_USERNAME_QUAY_IO": "testuser",
+		"REGISTRY_PASSWORD_QUAY_IO": "testpass",
+	}, credentials)
+}
+
+func TestReadUpstreamMirrorCredentialsFromSecret(t *testing.T) {
+	ctx := context.Background()
+	sec := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      registryAuthSecretName,
+			Namespace: constants.EksaSystemNamespace,
+		},
+		Data: map[string][]byte{
+			"REGISTRY_USERNAME_QUAY_IO": []byte("testuser"),
+			"REGISTRY_PASSWORD_QUAY_IO": []byte("testpass"),
+		},
+	}
+
+	cl := fake.NewClientBuilder().WithRuntimeObjects(sec).Build()
+	u, p, err := ReadUpstreamMirrorCredentialsFromSecret(ctx, cl, "quay.io")
+	assert.NoError(t, err)
+	assert.Equal(t, "testuser", u)
+	assert.Equal(t, "testpass", p)
+
+	_, _, err = ReadUpstreamMirrorCredentialsFromSecret(ctx, cl, "docker.io")
+	assert.EqualError(t, err, "registry auth secret doesn't have REGISTRY_USERNAME_DOCKER_IO")
+}
+
+func TestReadUpstreamMirrorCredentialsFromSecretNotFound(t *testing.T) {
+	cl := fake.NewClientBuilder().Build()
+	_, _, err := ReadUpstreamMirrorCredentialsFromSecret(context.Background(), cl, "quay.io")
+	assert.ErrorContains(t, err, "fetching registry auth secret")
+}
+
+func TestSetUpstreamMirrorCredentialsEnv(t *testing.T) {
+	t.Setenv("REGISTRY_USERNAME_QUAY_IO", "")
+	t.Setenv("REGISTRY_PASSWORD_QUAY_IO", "")
+	assert.NoError(t, SetUpstreamMirrorCredentialsEnv("quay.io", "testuser", "testpass"))
+
+	username, password, err := ReadUpstreamMirrorCredentials("quay.io")
+	assert.NoError(t, err)
+	assert.Equal(t, "testuser", username)
+	assert.Equal(t, "testpass", password)
+}
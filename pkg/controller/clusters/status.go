@@ -2,17 +2,27 @@ package clusters
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
 	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/controller"
 )
 
+const (
+	// certificatesExpiryThreshold is how long before the certificates expire the cluster is
+	// marked with the CertificatesExpiringSoon condition.
+	certificatesExpiryThreshold = 30 * 24 * time.Hour
+)
+
 // UpdateClusterStatusForControlPlane checks the current state of the Cluster's control plane and updates the
 // Cluster status information.
 func UpdateClusterStatusForControlPlane(ctx context.Context, client client.Client, cluster *anywherev1.Cluster) error {
@@ -63,6 +73,92 @@ func UpdateClusterStatusForCNI(ctx context.Context, cluster *anywherev1.Cluster)
 	}
 }
 
+// UpdateClusterStatusForCertificates checks the certificates expiry of the Cluster's control plane and etcd
+// machines and updates the CertificatesExpiringSoon condition and the soonest expiry in the Cluster status.
+func UpdateClusterStatusForCertificates(ctx context.Context, c client.Client, cluster *anywherev1.Cluster) error {
+	machines := &clusterv1.MachineList{}
+	err := c.List(ctx, machines, client.MatchingLabels{clusterv1.ClusterNameLabel: cluster.Name}, client.InNamespace(constants.EksaSystemNamespace))
+	if err != nil {
+		return errors.Wrap(err, "listing machines")
+	}
+
+	updateCertificatesExpiringSoonCondition(cluster, machines.Items, time.Now())
+	return nil
+}
+
+// updateCertificatesExpiringSoonCondition updates the CertificatesExpiringSoon condition and the certificates status
+// with the machine whose certificates expire first. If no machine reports its certificates expiry yet, the
+// condition and status are left untouched.
+func updateCertificatesExpiringSoonCondition(cluster *anywherev1.Cluster, machines []clusterv1.Machine, now time.Time) {
+	var soonest time.Time
+	var soonestMachine string
+	for _, m := range machines {
+		if !m.DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		expiry, ok := machineCertificatesExpiry(m)
+		if !ok {
+			continue
+		}
+
+		if soonestMachine == "" || expiry.Before(soonest) {
+			soonest, soonestMachine = expiry, m.Name
+		}
+	}
+
+	if soonestMachine == "" {
+		return
+	}
+
+	cluster.Status.Certificates = &anywherev1.CertificatesStatus{
+		SoonestExpiryDate: &metav1.Time{Time: soonest},
+		Machine:           soonestMachine,
+	}
+
+	expiry := soonest.UTC().Format(time.RFC3339)
+	switch {
+	case !soonest.After(now):
+		conditions.Set(cluster, certificatesExpiringSoonCondition(anywherev1.CertificatesExpiredReason, "Certificates in machine %s expired at %s", soonestMachine, expiry))
+	case soonest.Before(now.Add(certificatesExpiryThreshold)):
+		conditions.Set(cluster, certificatesExpiringSoonCondition(anywherev1.CertificatesExpiringReason, "Certificates in machine %s expire at %s", soonestMachine, expiry))
+	default:
+		conditions.MarkFalse(cluster, anywherev1.CertificatesExpiringSoonCondition, anywherev1.CertificatesValidReason, clusterv1.ConditionSeverityInfo, "Certificates valid until %s", expiry)
+	}
+}
+
+// machineCertificatesExpiry returns the certificates expiry for control plane and etcd machines. Like in CAPI,
+// the expiry annotation takes precedence over the one in the status. Etcd machines don't report it in their
+// status, so their expiry is only known when the annotation is set.
+func machineCertificatesExpiry(m clusterv1.Machine) (time.Time, bool) {
+	_, controlPlane := m.Labels[clusterv1.MachineControlPlaneLabel]
+	_, etcd := m.Labels[clusterv1.MachineEtcdClusterLabelName]
+	if !controlPlane && !etcd {
+		return time.Time{}, false
+	}
+
+	if annotation, ok := m.Annotations[clusterv1.MachineCertificatesExpiryDateAnnotation]; ok {
+		if expiry, err := time.Parse(time.RFC3339, annotation); err == nil {
+			return expiry, true
+		}
+	}
+
+	if m.Status.CertificatesExpiryDate != nil {
+		return m.Status.CertificatesExpiryDate.Time, true
+	}
+
+	return time.Time{}, false
+}
+
+// certificatesExpiringSoonCondition returns a new "True" CertificatesExpiringSoon condition. Severity is not set,
+// since it's only allowed for "False" conditions.
+func certificatesExpiringSoonCondition(reason, messageFormat string, messageArgs ...interface{}) *anywherev1.Condition {
+	condition := conditions.TrueCondition(anywherev1.CertificatesExpiringSoonCondition)
+	condition.Reason = reason
+	condition.Message = fmt.Sprintf(messageFormat, messageArgs...)
+	return condition
+}
+
 // updateControlPlaneReadyCondition updates the ControlPlaneReady condition, after checking the state of the control plane
 // in the cluster.
 func updateControlPlaneReadyCondition(cluster *anywherev1.Cluster, kcp *controlplanev1.KubeadmControlPlane) {
@@ -3,8 +3,10 @@ package clusters_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
@@ -778,3 +780,141 @@ func TestUpdateClusterStatusForCNI(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateClusterStatusForCertificates(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+
+	tests := []struct {
+		name          string
+		machines      []*clusterv1.Machine
+		wantCondition *anywherev1.Condition
+		wantStatus    *anywherev1.CertificatesStatus
+	}{
+		{
+			name:     "no machines report expiry",
+			machines: []*clusterv1.Machine{certificatesTestMachine("cp-1", clusterv1.MachineControlPlaneLabel)},
+		},
+		{
+			name: "certificates valid",
+			machines: []*clusterv1.Machine{
+				certificatesTestMachine("cp-1", clusterv1.MachineControlPlaneLabel, func(m *clusterv1.Machine) {
+					m.Status.CertificatesExpiryDate = &metav1.Time{Time: now.Add(300 * 24 * time.Hour)}
+				}),
+				certificatesTestMachine("cp-2", clusterv1.MachineControlPlaneLabel, func(m *clusterv1.Machine) {
+					m.Status.CertificatesExpiryDate = &metav1.Time{Time: now.Add(200 * 24 * time.Hour)}
+				}),
+			},
+			wantCondition: &anywherev1.Condition{
+				Type:     anywherev1.CertificatesExpiringSoonCondition,
+				Status:   "False",
+				Severity: clusterv1.ConditionSeverityInfo,
+				Reason:   anywherev1.CertificatesValidReason,
+				Message:  "Certificates valid until",
+			},
+			wantStatus: &anywherev1.CertificatesStatus{
+				SoonestExpiryDate: &metav1.Time{Time: now.Add(200 * 24 * time.Hour)},
+				Machine:           "cp-2",
+			},
+		},
+		{
+			name: "certificates expiring soon from annotation",
+			machines: []*clusterv1.Machine{
+				certificatesTestMachine("cp-1", clusterv1.MachineControlPlaneLabel, func(m *clusterv1.Machine) {
+					m.Status.CertificatesExpiryDate = &metav1.Time{Time: now.Add(300 * 24 * time.Hour)}
+					m.Annotations = map[string]string{
+						clusterv1.MachineCertificatesExpiryDateAnnotation: now.Add(10 * 24 * time.Hour).Format(time.RFC3339),
+					}
+				}),
+			},
+			wantCondition: &anywherev1.Condition{
+				Type:    anywherev1.CertificatesExpiringSoonCondition,
+				Status:  "True",
+				Reason:  anywherev1.CertificatesExpiringReason,
+				Message: "Certificates in machine cp-1 expire at",
+			},
+			wantStatus: &anywherev1.CertificatesStatus{
+				SoonestExpiryDate: &metav1.Time{Time: now.Add(10 * 24 * time.Hour)},
+				Machine:           "cp-1",
+			},
+		},
+		{
+			name: "etcd certificates expired, only known from annotation",
+			machines: []*clusterv1.Machine{
+				certificatesTestMachine("cp-1", clusterv1.MachineControlPlaneLabel, func(m *clusterv1.Machine) {
+					m.Status.CertificatesExpiryDate = &metav1.Time{Time: now.Add(300 * 24 * time.Hour)}
+				}),
+				certificatesTestMachine("etcd-1", clusterv1.MachineEtcdClusterLabelName, func(m *clusterv1.Machine) {
+					m.Annotations = map[string]string{
+						clusterv1.MachineCertificatesExpiryDateAnnotation: now.Add(-35 * 24 * time.Hour).Format(time.RFC3339),
+					}
+				}),
+				certificatesTestMachine("etcd-2", clusterv1.MachineEtcdClusterLabelName, func(m *clusterv1.Machine) {
+					m.CreationTimestamp = metav1.Time{Time: now.Add(-800 * 24 * time.Hour)}
+				}),
+				certificatesTestMachine("worker-1", clusterv1.MachineDeploymentNameLabel, func(m *clusterv1.Machine) {
+					m.Status.CertificatesExpiryDate = &metav1.Time{Time: now.Add(-500 * 24 * time.Hour)}
+				}),
+			},
+			wantCondition: &anywherev1.Condition{
+				Type:    anywherev1.CertificatesExpiringSoonCondition,
+				Status:  "True",
+				Reason:  anywherev1.CertificatesExpiredReason,
+				Message: "Certificates in machine etcd-1 expired at",
+			},
+			wantStatus: &anywherev1.CertificatesStatus{
+				SoonestExpiryDate: &metav1.Time{Time: now.Add(-35 * 24 * time.Hour)},
+				Machine:           "etcd-1",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := context.Background()
+			cluster := test.NewClusterSpec().Cluster
+			cluster.Name = "test-cluster"
+
+			objs := []runtime.Object{}
+			for _, m := range tt.machines {
+				objs = append(objs, m)
+			}
+			client := fake.NewClientBuilder().WithRuntimeObjects(objs...).Build()
+
+			g.Expect(clusters.UpdateClusterStatusForCertificates(ctx, client, cluster)).To(Succeed())
+
+			if tt.wantCondition == nil {
+				g.Expect(conditions.Has(cluster, anywherev1.CertificatesExpiringSoonCondition)).To(BeFalse())
+				g.Expect(cluster.Status.Certificates).To(BeNil())
+				return
+			}
+
+			condition := conditions.Get(cluster, tt.wantCondition.Type)
+			g.Expect(condition).ToNot(BeNil())
+			g.Expect(condition.Severity).To(Equal(tt.wantCondition.Severity))
+			g.Expect(condition.Status).To(Equal(tt.wantCondition.Status))
+			g.Expect(condition.Reason).To(Equal(tt.wantCondition.Reason))
+			g.Expect(condition.Message).To(ContainSubstring(tt.wantCondition.Message))
+
+			g.Expect(cluster.Status.Certificates.Machine).To(Equal(tt.wantStatus.Machine))
+			g.Expect(cluster.Status.Certificates.SoonestExpiryDate.Equal(tt.wantStatus.SoonestExpiryDate)).To(BeTrue())
+		})
+	}
+}
+
+func certificatesTestMachine(name, roleLabel string, opts ...func(*clusterv1.Machine)) *clusterv1.Machine {
+	m := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: constants.EksaSystemNamespace,
+			Labels: map[string]string{
+				clusterv1.ClusterNameLabel: "test-cluster",
+				roleLabel:                  "",
+			},
+		},
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
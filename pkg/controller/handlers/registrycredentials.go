@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+// RegistryCredentialsSecretToClusters returns a request handler that enqueues a reconcile request
+// for every EKS-A Cluster whose registry mirror CredentialsRef points to the Secret.
+func RegistryCredentialsSecretToClusters(c client.Reader, log logr.Logger) handler.MapFunc {
+	return func(o client.Object) []reconcile.Request {
+		if o.GetNamespace() != constants.EksaSystemNamespace {
+			return nil
+		}
+
+		clusters := &anywherev1.ClusterList{}
+		if err := c.List(context.Background(), clusters); err != nil {
+			log.Error(err, "Listing clusters for registry credentials secret", "name", o.GetName())
+			return nil
+		}
+
+		var requests []reconcile.Request
+		for _, cluster := range clusters.Items {
+			config := cluster.Spec.RegistryMirrorConfiguration
+			if config == nil || config.CredentialsRef == nil || config.CredentialsRef.Name != o.GetName() {
+				continue
+			}
+
+			log.Info("Enqueuing Cluster request coming from registry credentials secret", "name", o.GetName(), "cluster", cluster.Name)
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Namespace: cluster.Namespace,
+					Name:      cluster.Name,
+				},
+			})
+		}
+
+		return requests
+	}
+}
@@ -0,0 +1,85 @@
+package handlers_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/controller/handlers"
+)
+
+func registryCredentialsCluster(name, secretName string) *anywherev1.Cluster {
+	c := &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+	}
+	if secretName != "" {
+		c.Spec.RegistryMirrorConfiguration = &anywherev1.RegistryMirrorConfiguration{
+			Endpoint:       "1.2.3.4",
+			Authenticate:   true,
+			CredentialsRef: &anywherev1.Ref{Kind: constants.SecretKind, Name: secretName},
+		}
+	}
+	return c
+}
+
+func TestRegistryCredentialsSecretToClusters(t *testing.T) {
+	testCases := []struct {
+		testName     string
+		secret       *corev1.Secret
+		wantRequests []reconcile.Request
+	}{
+		{
+			testName: "referenced secret",
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "mirror-credentials", Namespace: constants.EksaSystemNamespace},
+			},
+			wantRequests: []reconcile.Request{
+				{NamespacedName: types.NamespacedName{Name: "cluster-a", Namespace: "default"}},
+				{NamespacedName: types.NamespacedName{Name: "cluster-b", Namespace: "default"}},
+			},
+		},
+		{
+			testName: "not referenced secret",
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: constants.EksaSystemNamespace},
+			},
+			wantRequests: nil,
+		},
+		{
+			testName: "secret in other namespace",
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "mirror-credentials", Namespace: "default"},
+			},
+			wantRequests: nil,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.testName, func(t *testing.T) {
+			g := NewWithT(t)
+			scheme := runtime.NewScheme()
+			g.Expect(anywherev1.AddToScheme(scheme)).To(Succeed())
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+				registryCredentialsCluster("cluster-a", "mirror-credentials"),
+				registryCredentialsCluster("cluster-b", "mirror-credentials"),
+				registryCredentialsCluster("cluster-c", "other-credentials"),
+				registryCredentialsCluster("cluster-d", ""),
+			).Build()
+
+			handle := handlers.RegistryCredentialsSecretToClusters(c, logf.Log)
+			g.Expect(handle(tt.secret)).To(Equal(tt.wantRequests))
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"strings"
 
 	"sigs.k8s.io/yaml"
@@ -123,6 +124,137 @@ func (b *BundleReader) UpgradeBundle(ctx context.Context, controller *packagesv1
 	return nil
 }
 
+// ReviewUpgrade compares the packages installed in the cluster with their version in the new bundle.
+// It writes to w the diff of the packages whose version changes and returns the upgrade plan, with the
+// errors of the configs that are not valid for their target version. Packages without a version use the
+// default version of the bundle.
+func (b *BundleReader) ReviewUpgrade(ctx context.Context, controller *packagesv1.PackageBundleController, newBundleVersion string, w io.Writer) (*PackagesUpgradePlan, error) {
+	newBundle, err := b.getPackageBundle(ctx, newBundleVersion)
+	if err != nil {
+		return nil, fmt.Errorf("getting package bundle %s: %v", newBundleVersion, err)
+	}
+	activeBundle, err := b.getPackageBundle(ctx, controller.Spec.ActiveBundle)
+	if err != nil {
+		return nil, fmt.Errorf("getting package bundle %s: %v", controller.Spec.ActiveBundle, err)
+	}
+
+	packages, err := b.getPackages(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &PackagesUpgradePlan{
+		ActiveBundle: controller.Spec.ActiveBundle,
+		TargetBundle: newBundleVersion,
+		Packages:     make([]PackageUpgrade, 0, len(packages.Items)),
+	}
+	fmt.Fprintf(w, "activeBundle: %s -> %s\n", controller.Spec.ActiveBundle, newBundleVersion)
+	for i := range packages.Items {
+		installed := &packages.Items[i]
+		upgrade, err := planPackageUpgrade(installed, activeBundle, newBundle)
+		if err != nil {
+			return nil, err
+		}
+		plan.Packages = append(plan.Packages, *upgrade)
+		if installed.Spec.PackageVersion != "" {
+			continue
+		}
+
+		current := installed.DeepCopy()
+		current.Spec.PackageVersion = upgrade.CurrentVersion
+		desired := installed.DeepCopy()
+		desired.Spec.PackageVersion = upgrade.TargetVersion
+		diff, err := PackageDiff(current, desired)
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprint(w, diff)
+	}
+
+	return plan, nil
+}
+
+// InstalledPackageImages returns the images of the packages installed in the cluster by package name,
+// for the version of the active bundle they run, pulled from imageRegistry.
+func (b *BundleReader) InstalledPackageImages(ctx context.Context, imageRegistry string) (map[string][]string, error) {
+	controller, err := b.GetActiveController(ctx)
+	if err != nil {
+		return nil, err
+	}
+	bundle, err := b.getPackageBundle(ctx, controller.Spec.ActiveBundle)
+	if err != nil {
+		return nil, fmt.Errorf("getting package bundle %s: %v", controller.Spec.ActiveBundle, err)
+	}
+	packages, err := b.getPackages(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	images := map[string][]string{}
+	for _, installed := range packages.Items {
+		bp, err := findBundlePackage(bundle, installed.Spec.PackageName)
+		if err != nil {
+			return nil, err
+		}
+		v, err := findSourceVersion(bp, installed.Spec.PackageVersion)
+		if err != nil {
+			return nil, err
+		}
+		for _, image := range v.Images {
+			images[installed.Name] = append(images[installed.Name], fmt.Sprintf("%s/%s@%s", imageRegistry, image.Repository, image.Digest))
+		}
+	}
+	return images, nil
+}
+
+func (b *BundleReader) getPackages(ctx context.Context) (*packagesv1.PackageList, error) {
+	params := []string{"get", "packages", "-o", "json", "--kubeconfig", b.kubeConfig, "--namespace", constants.EksaPackagesName + "-" + b.clusterName}
+	stdOut, err := b.kubectl.ExecuteCommand(ctx, params...)
+	if err != nil {
+		return nil, err
+	}
+	packages := &packagesv1.PackageList{}
+	if err = json.Unmarshal(stdOut.Bytes(), packages); err != nil {
+		return nil, fmt.Errorf("unmarshaling packages: %w", err)
+	}
+	return packages, nil
+}
+
+// RegisterBundle creates or updates a package bundle in the cluster, so the package controller
+// can use it without pulling it from a registry.
+func (b *BundleReader) RegisterBundle(ctx context.Context, bundle *packagesv1.PackageBundle) error {
+	bundle = bundle.DeepCopy()
+	bundle.APIVersion = packagesv1.GroupVersion.String()
+	bundle.Kind = packagesv1.PackageBundleKind
+	bundle.Namespace = constants.EksaPackagesName
+	bundleYaml, err := yaml.Marshal(bundle)
+	if err != nil {
+		return err
+	}
+	params := []string{"apply", "-f", "-", "--kubeconfig", b.kubeConfig}
+	if _, err = b.kubectl.ExecuteFromYaml(ctx, bundleYaml, params...); err != nil {
+		return fmt.Errorf("registering package bundle %s: %v", bundle.Name, err)
+	}
+	return nil
+}
+
+// GetPackageBundleRefForVersion returns the URI of the package bundle with the given version,
+// e.g. v1-27-125, in the registry of the package controller for its Kubernetes version.
+func GetPackageBundleRefForVersion(b *releasev1.Bundles, bundleVersion string) (string, error) {
+	for _, vb := range b.Spec.VersionsBundles {
+		major, minor, err := parseKubeVersion(vb.KubeVersion)
+		if err != nil || !strings.HasPrefix(bundleVersion, fmt.Sprintf("v%s-%s-", major, minor)) {
+			continue
+		}
+		latestRef, err := GetPackageBundleRef(vb)
+		if err != nil {
+			return "", err
+		}
+		return latestRef[:strings.LastIndex(latestRef, ":")+1] + bundleVersion, nil
+	}
+	return "", fmt.Errorf("no kubernetes version in the bundles matches package bundle version %s", bundleVersion)
+}
+
 func GetPackageBundleRef(vb releasev1.VersionsBundle) (string, error) {
 	packageController := vb.PackageController
 	// Use package controller registry to fetch packageBundles.
@@ -15,6 +15,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/curatedpackages"
 	"github.com/aws/eks-anywhere/pkg/curatedpackages/mocks"
 	"github.com/aws/eks-anywhere/pkg/version"
+	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
 )
 
 type bundleTest struct {
@@ -178,7 +179,168 @@ func TestUpgradeBundleFails(t *testing.T) {
 	tt.Expect(err).NotTo(BeNil())
 }
 
+func (tt *bundleTest) expectUpgradeReview(t *testing.T, config string) {
+	activeBundle := &packagesv1.PackageBundle{}
+	activeBundle.Spec.Packages = []packagesv1.BundlePackage{*harborBundlePackage(t)}
+	activeBundle.Spec.Packages[0].Source.Versions = activeBundle.Spec.Packages[0].Source.Versions[1:]
+	newBundle := &packagesv1.PackageBundle{}
+	newBundle.Spec.Packages = []packagesv1.BundlePackage{*harborBundlePackage(t)}
+	pinned := packagesv1.Package{Spec: packagesv1.PackageSpec{PackageName: "harbor", PackageVersion: "2.5.0"}}
+	pinned.Name = "pinned-harbor"
+	unpinned := packagesv1.Package{Spec: packagesv1.PackageSpec{PackageName: "harbor", Config: config}}
+	unpinned.Name = "my-harbor"
+	packages := &packagesv1.PackageList{Items: []packagesv1.Package{pinned, unpinned}}
+
+	gomock.InOrder(
+		tt.kubectl.EXPECT().ExecuteCommand(tt.ctx, "get", "packageBundle", "-o", "json", "--kubeconfig", tt.kubeConfig, "--namespace", "eksa-packages", "new-bundle").Return(convertJsonToBytes(newBundle), nil),
+		tt.kubectl.EXPECT().ExecuteCommand(tt.ctx, "get", "packageBundle", "-o", "json", "--kubeconfig", tt.kubeConfig, "--namespace", "eksa-packages", tt.activeBundle).Return(convertJsonToBytes(activeBundle), nil),
+		tt.kubectl.EXPECT().ExecuteCommand(tt.ctx, "get", "packages", "-o", "json", "--kubeconfig", tt.kubeConfig, "--namespace", "eksa-packages-billy").Return(convertJsonToBytes(packages), nil),
+	)
+}
+
+func TestReviewUpgradeSucceeds(t *testing.T) {
+	tt := newBundleTest(t)
+	tt.expectUpgradeReview(t, "secretKey: use-a-secret-key\n")
+	tt.Command = curatedpackages.NewBundleReader(tt.kubeConfig, tt.cluster, tt.kubectl, tt.bundleManager, tt.registry)
+	out := &bytes.Buffer{}
+
+	plan, err := tt.Command.ReviewUpgrade(tt.ctx, tt.bundleCtrl, "new-bundle", out)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(plan.ValidateConfig()).To(Succeed())
+	tt.Expect(out.String()).To(Equal(`activeBundle: v1.21-1000 -> new-bundle
+--- installed/my-harbor
++++ desired/my-harbor
+@@ -1,4 +1,4 @@
+ config:
+   secretKey: use-a-secret-key
+ packageName: harbor
+-packageVersion: 2.5.0
++packageVersion: 2.7.1
+`))
+	tt.Expect(tt.bundleCtrl.Spec.ActiveBundle).To(Equal(tt.activeBundle))
+}
+
+func TestReviewUpgradeInvalidConfig(t *testing.T) {
+	tt := newBundleTest(t)
+	tt.expectUpgradeReview(t, "secretKey: short\n")
+	tt.Command = curatedpackages.NewBundleReader(tt.kubeConfig, tt.cluster, tt.kubectl, tt.bundleManager, tt.registry)
+
+	plan, err := tt.Command.ReviewUpgrade(tt.ctx, tt.bundleCtrl, "new-bundle", &bytes.Buffer{})
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(plan.ValidateConfig()).To(MatchError(ContainSubstring("invalid config for package harbor: secretKey in body should be at least 16 chars long")))
+}
+
+func TestReviewUpgradeUnknownBundle(t *testing.T) {
+	tt := newBundleTest(t)
+	tt.kubectl.EXPECT().ExecuteCommand(tt.ctx, gomock.Any()).Return(bytes.Buffer{}, errors.New("not found"))
+	tt.Command = curatedpackages.NewBundleReader(tt.kubeConfig, tt.cluster, tt.kubectl, tt.bundleManager, tt.registry)
+
+	_, err := tt.Command.ReviewUpgrade(tt.ctx, tt.bundleCtrl, "new-bundle", &bytes.Buffer{})
+	tt.Expect(err).To(MatchError("getting package bundle new-bundle: not found"))
+}
+
+func TestRegisterBundleSucceeds(t *testing.T) {
+	tt := newBundleTest(t)
+	params := []string{"apply", "-f", "-", "--kubeconfig", tt.kubeConfig}
+	bundle := &packagesv1.PackageBundle{}
+	bundle.Name = "v1-27-125"
+	expected := bundle.DeepCopy()
+	expected.APIVersion = "packages.eks.amazonaws.com/v1alpha1"
+	expected.Kind = "PackageBundle"
+	expected.Namespace = "eksa-packages"
+	bundleYaml, err := yaml.Marshal(expected)
+	tt.Expect(err).To(BeNil())
+	tt.kubectl.EXPECT().ExecuteFromYaml(tt.ctx, bundleYaml, params).Return(bytes.Buffer{}, nil)
+
+	tt.Command = curatedpackages.NewBundleReader(tt.kubeConfig, tt.cluster, tt.kubectl, tt.bundleManager, tt.registry)
+
+	tt.Expect(tt.Command.RegisterBundle(tt.ctx, bundle)).To(Succeed())
+	tt.Expect(bundle.Namespace).To(BeEmpty())
+}
+
+func TestRegisterBundleFails(t *testing.T) {
+	tt := newBundleTest(t)
+	bundle := &packagesv1.PackageBundle{}
+	bundle.Name = "v1-27-125"
+	tt.kubectl.EXPECT().ExecuteFromYaml(tt.ctx, gomock.Any(), gomock.Any()).Return(bytes.Buffer{}, errors.New("unable to apply yaml"))
+
+	tt.Command = curatedpackages.NewBundleReader(tt.kubeConfig, tt.cluster, tt.kubectl, tt.bundleManager, tt.registry)
+
+	err := tt.Command.RegisterBundle(tt.ctx, bundle)
+	tt.Expect(err).To(MatchError("registering package bundle v1-27-125: unable to apply yaml"))
+}
+
+func TestGetPackageBundleRefForVersion(t *testing.T) {
+	g := NewWithT(t)
+	b := &releasev1.Bundles{
+		Spec: releasev1.BundlesSpec{
+			VersionsBundles: []releasev1.VersionsBundle{
+				{
+					KubeVersion: "1.26",
+					PackageController: releasev1.PackageBundle{
+						Controller: releasev1.Image{URI: "public.ecr.aws/eks-anywhere/eks-anywhere-packages:v0.3.9"},
+					},
+				},
+				{
+					KubeVersion: "1.27",
+					PackageController: releasev1.PackageBundle{
+						Controller: releasev1.Image{URI: "public.ecr.aws/l0g8r8j6/eks-anywhere-packages:v0.3.9"},
+					},
+				},
+			},
+		},
+	}
+
+	ref, err := curatedpackages.GetPackageBundleRefForVersion(b, "v1-27-125")
+	g.Expect(err).To(BeNil())
+	g.Expect(ref).To(Equal("public.ecr.aws/l0g8r8j6/eks-anywhere-packages-bundles:v1-27-125"))
+
+	_, err = curatedpackages.GetPackageBundleRefForVersion(b, "v1-28-1")
+	g.Expect(err).To(MatchError("no kubernetes version in the bundles matches package bundle version v1-28-1"))
+}
+
 func convertJsonToBytes(obj interface{}) bytes.Buffer {
 	b, _ := json.Marshal(obj)
 	return *bytes.NewBuffer(b)
 }
+
+func TestInstalledPackageImagesSucceeds(t *testing.T) {
+	tt := newBundleTest(t)
+	bundle := &packagesv1.PackageBundle{}
+	bundle.Spec.Packages = []packagesv1.BundlePackage{*harborBundlePackage(t)}
+	bundle.Spec.Packages[0].Source.Versions[0].Images = []packagesv1.VersionImages{{Repository: "harbor/harbor-core", Digest: "sha256:271"}}
+	bundle.Spec.Packages[0].Source.Versions[1].Images = []packagesv1.VersionImages{{Repository: "harbor/harbor-core", Digest: "sha256:250"}}
+	pinned := packagesv1.Package{Spec: packagesv1.PackageSpec{PackageName: "harbor", PackageVersion: "2.5.0"}}
+	pinned.Name = "pinned-harbor"
+	unpinned := packagesv1.Package{Spec: packagesv1.PackageSpec{PackageName: "harbor"}}
+	unpinned.Name = "my-harbor"
+	packages := &packagesv1.PackageList{Items: []packagesv1.Package{pinned, unpinned}}
+	gomock.InOrder(
+		tt.kubectl.EXPECT().ExecuteCommand(tt.ctx, "get", "packageBundleController", "-o", "json", "--kubeconfig", tt.kubeConfig, "--namespace", "eksa-packages", tt.cluster).Return(convertJsonToBytes(tt.bundleCtrl), nil),
+		tt.kubectl.EXPECT().ExecuteCommand(tt.ctx, "get", "packageBundle", "-o", "json", "--kubeconfig", tt.kubeConfig, "--namespace", "eksa-packages", tt.activeBundle).Return(convertJsonToBytes(bundle), nil),
+		tt.kubectl.EXPECT().ExecuteCommand(tt.ctx, "get", "packages", "-o", "json", "--kubeconfig", tt.kubeConfig, "--namespace", "eksa-packages-billy").Return(convertJsonToBytes(packages), nil),
+	)
+	tt.Command = curatedpackages.NewBundleReader(tt.kubeConfig, tt.cluster, tt.kubectl, tt.bundleManager, tt.registry)
+
+	images, err := tt.Command.InstalledPackageImages(tt.ctx, "public.ecr.aws/eks-anywhere")
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(images).To(Equal(map[string][]string{
+		"pinned-harbor": {"public.ecr.aws/eks-anywhere/harbor/harbor-core@sha256:250"},
+		"my-harbor":     {"public.ecr.aws/eks-anywhere/harbor/harbor-core@sha256:271"},
+	}))
+}
+
+func TestInstalledPackageImagesUnknownPackage(t *testing.T) {
+	tt := newBundleTest(t)
+	installed := packagesv1.Package{Spec: packagesv1.PackageSpec{PackageName: "unknown"}}
+	packages := &packagesv1.PackageList{Items: []packagesv1.Package{installed}}
+	gomock.InOrder(
+		tt.kubectl.EXPECT().ExecuteCommand(tt.ctx, "get", "packageBundleController", "-o", "json", "--kubeconfig", tt.kubeConfig, "--namespace", "eksa-packages", tt.cluster).Return(convertJsonToBytes(tt.bundleCtrl), nil),
+		tt.kubectl.EXPECT().ExecuteCommand(tt.ctx, "get", "packageBundle", "-o", "json", "--kubeconfig", tt.kubeConfig, "--namespace", "eksa-packages", tt.activeBundle).Return(convertJsonToBytes(tt.packageBundle), nil),
+		tt.kubectl.EXPECT().ExecuteCommand(tt.ctx, "get", "packages", "-o", "json", "--kubeconfig", tt.kubeConfig, "--namespace", "eksa-packages-billy").Return(convertJsonToBytes(packages), nil),
+	)
+	tt.Command = curatedpackages.NewBundleReader(tt.kubeConfig, tt.cluster, tt.kubectl, tt.bundleManager, tt.registry)
+
+	_, err := tt.Command.InstalledPackageImages(tt.ctx, "public.ecr.aws/eks-anywhere")
+	tt.Expect(err).To(MatchError("package unknown not found in bundle "))
+}
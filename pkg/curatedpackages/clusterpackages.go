@@ -0,0 +1,146 @@
+package curatedpackages
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+// ClusterPackagesLabel is set in the Packages created from the packages list of a cluster
+// with the name of the cluster. Packages without it are never modified nor removed.
+const ClusterPackagesLabel = "anywhere.eks.amazonaws.com/cluster-packages"
+
+// ReconcilePackages installs, upgrades and removes the curated packages of a cluster to match the
+// packages list in its PackageConfiguration. Packages created by other means are left untouched.
+func (pc *PackageControllerClient) ReconcilePackages(ctx context.Context, log logr.Logger, c client.Client, cluster *anywherev1.Cluster) error {
+	namespace := constants.EksaPackagesName + "-" + cluster.Name
+	desired := ClusterPackages(cluster)
+
+	if len(desired) > 0 {
+		if err := ensureNamespace(ctx, c, namespace); err != nil {
+			return err
+		}
+	}
+
+	names := make(map[string]struct{}, len(desired))
+	for i := range desired {
+		p := &desired[i]
+		names[p.Name] = struct{}{}
+		if err := applyPackage(ctx, log, c, p); err != nil {
+			return err
+		}
+	}
+
+	existing := &packagesv1.PackageList{}
+	err := c.List(ctx, existing, client.InNamespace(namespace), client.MatchingLabels{ClusterPackagesLabel: cluster.Name})
+	if apimeta.IsNoMatchError(err) && len(desired) == 0 {
+		// The package controller hasn't been installed, so there is nothing to remove.
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("listing packages of cluster %s: %w", cluster.Name, err)
+	}
+
+	for i := range existing.Items {
+		p := &existing.Items[i]
+		if _, ok := names[p.Name]; ok {
+			continue
+		}
+		log.Info("Removing package", "package", p.Name, "namespace", p.Namespace)
+		if err := c.Delete(ctx, p); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("removing package %s: %w", p.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// ClusterPackages returns the Packages defined in the packages list of a cluster.
+func ClusterPackages(cluster *anywherev1.Cluster) []packagesv1.Package {
+	if cluster.Spec.Packages == nil {
+		return nil
+	}
+
+	packages := make([]packagesv1.Package, 0, len(cluster.Spec.Packages.Packages))
+	for _, p := range cluster.Spec.Packages.Packages {
+		packages = append(packages, packagesv1.Package{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: packagesv1.GroupVersion.String(),
+				Kind:       kind,
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      p.Name,
+				Namespace: constants.EksaPackagesName + "-" + cluster.Name,
+				Labels: map[string]string{
+					ClusterPackagesLabel: cluster.Name,
+				},
+			},
+			Spec: packagesv1.PackageSpec{
+				PackageName:     p.Name,
+				PackageVersion:  p.Version,
+				Config:          p.Config,
+				TargetNamespace: p.TargetNamespace,
+			},
+		})
+	}
+
+	return packages
+}
+
+func applyPackage(ctx context.Context, log logr.Logger, c client.Client, p *packagesv1.Package) error {
+	current := &packagesv1.Package{}
+	err := c.Get(ctx, client.ObjectKeyFromObject(p), current)
+	if apierrors.IsNotFound(err) {
+		log.Info("Installing package", "package", p.Name, "version", p.Spec.PackageVersion)
+		if err := c.Create(ctx, p); err != nil {
+			return fmt.Errorf("installing package %s: %w", p.Name, err)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("reading package %s: %w", p.Name, err)
+	}
+
+	if current.Labels[ClusterPackagesLabel] == p.Labels[ClusterPackagesLabel] && reflect.DeepEqual(current.Spec, p.Spec) {
+		return nil
+	}
+
+	log.Info("Updating package", "package", p.Name, "version", p.Spec.PackageVersion)
+	if current.Labels == nil {
+		current.Labels = map[string]string{}
+	}
+	current.Labels[ClusterPackagesLabel] = p.Labels[ClusterPackagesLabel]
+	current.Spec = p.Spec
+	if err := c.Update(ctx, current); err != nil {
+		return fmt.Errorf("updating package %s: %w", p.Name, err)
+	}
+
+	return nil
+}
+
+func ensureNamespace(ctx context.Context, c client.Client, name string) error {
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	err := c.Get(ctx, client.ObjectKeyFromObject(ns), ns)
+	if apierrors.IsNotFound(err) {
+		if err := c.Create(ctx, ns); err != nil && !apierrors.IsAlreadyExists(err) {
+			return fmt.Errorf("creating namespace %s: %w", name, err)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("reading namespace %s: %w", name, err)
+	}
+
+	return nil
+}
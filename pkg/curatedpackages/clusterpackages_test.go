@@ -0,0 +1,157 @@
+package curatedpackages_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr/testr"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/curatedpackages"
+	artifactsv1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+func packagesScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = anywherev1.AddToScheme(scheme)
+	_ = artifactsv1.AddToScheme(scheme)
+	_ = packagesv1.AddToScheme(scheme)
+	return scheme
+}
+
+func clusterWithPackages(packages ...anywherev1.ClusterPackage) *anywherev1.Cluster {
+	return &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-workload-cluster",
+			Namespace: "my-namespace",
+		},
+		Spec: anywherev1.ClusterSpec{
+			Packages: &anywherev1.PackageConfiguration{
+				Packages: packages,
+			},
+		},
+	}
+}
+
+func existingPackage(name, clusterLabel string, spec packagesv1.PackageSpec) *packagesv1.Package {
+	p := &packagesv1.Package{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "eksa-packages-my-workload-cluster",
+		},
+		Spec: spec,
+	}
+	if clusterLabel != "" {
+		p.Labels = map[string]string{curatedpackages.ClusterPackagesLabel: clusterLabel}
+	}
+	return p
+}
+
+func getPackage(g *WithT, c client.Client, name string) *packagesv1.Package {
+	p := &packagesv1.Package{}
+	g.Expect(c.Get(context.Background(), client.ObjectKey{Name: name, Namespace: "eksa-packages-my-workload-cluster"}, p)).To(Succeed())
+	return p
+}
+
+func TestReconcilePackagesInstallsAndCreatesNamespace(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	cluster := clusterWithPackages(anywherev1.ClusterPackage{
+		Name:            "harbor",
+		Version:         "2.7.1",
+		Config:          "secretKey: use-a-secret-key",
+		TargetNamespace: "harbor",
+	})
+	c := fake.NewClientBuilder().WithScheme(packagesScheme()).Build()
+	pcc := curatedpackages.NewPackageControllerClient(nil, nil, cluster.Name, "", nil, nil)
+
+	g.Expect(pcc.ReconcilePackages(ctx, testr.New(t), c, cluster)).To(Succeed())
+
+	g.Expect(c.Get(ctx, client.ObjectKey{Name: "eksa-packages-my-workload-cluster"}, &corev1.Namespace{})).To(Succeed())
+	p := getPackage(g, c, "harbor")
+	g.Expect(p.Labels).To(HaveKeyWithValue(curatedpackages.ClusterPackagesLabel, "my-workload-cluster"))
+	g.Expect(p.Spec).To(Equal(packagesv1.PackageSpec{
+		PackageName:     "harbor",
+		PackageVersion:  "2.7.1",
+		Config:          "secretKey: use-a-secret-key",
+		TargetNamespace: "harbor",
+	}))
+}
+
+func TestReconcilePackagesUpgradesAndAdoptsExisting(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	cluster := clusterWithPackages(anywherev1.ClusterPackage{Name: "harbor", Version: "2.8.0"})
+	c := fake.NewClientBuilder().WithScheme(packagesScheme()).WithObjects(
+		existingPackage("harbor", "", packagesv1.PackageSpec{PackageName: "harbor", PackageVersion: "2.7.1"}),
+	).Build()
+	pcc := curatedpackages.NewPackageControllerClient(nil, nil, cluster.Name, "", nil, nil)
+
+	g.Expect(pcc.ReconcilePackages(ctx, testr.New(t), c, cluster)).To(Succeed())
+
+	p := getPackage(g, c, "harbor")
+	g.Expect(p.Labels).To(HaveKeyWithValue(curatedpackages.ClusterPackagesLabel, "my-workload-cluster"))
+	g.Expect(p.Spec.PackageVersion).To(Equal("2.8.0"))
+}
+
+func TestReconcilePackagesRemovesOnlyManagedPackages(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	cluster := clusterWithPackages()
+	c := fake.NewClientBuilder().WithScheme(packagesScheme()).WithObjects(
+		existingPackage("harbor", "my-workload-cluster", packagesv1.PackageSpec{PackageName: "harbor"}),
+		existingPackage("generated-prometheus", "", packagesv1.PackageSpec{PackageName: "prometheus"}),
+	).Build()
+	pcc := curatedpackages.NewPackageControllerClient(nil, nil, cluster.Name, "", nil, nil)
+
+	g.Expect(pcc.ReconcilePackages(ctx, testr.New(t), c, cluster)).To(Succeed())
+
+	err := c.Get(ctx, client.ObjectKey{Name: "harbor", Namespace: "eksa-packages-my-workload-cluster"}, &packagesv1.Package{})
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	getPackage(g, c, "generated-prometheus")
+}
+
+func TestReconcilePackagesNoPackageConfigurationRemovesManagedPackages(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	cluster := clusterWithPackages()
+	cluster.Spec.Packages = nil
+	c := fake.NewClientBuilder().WithScheme(packagesScheme()).WithObjects(
+		existingPackage("harbor", "my-workload-cluster", packagesv1.PackageSpec{PackageName: "harbor"}),
+	).Build()
+	pcc := curatedpackages.NewPackageControllerClient(nil, nil, cluster.Name, "", nil, nil)
+
+	g.Expect(pcc.ReconcilePackages(ctx, testr.New(t), c, cluster)).To(Succeed())
+
+	err := c.Get(ctx, client.ObjectKey{Name: "harbor", Namespace: "eksa-packages-my-workload-cluster"}, &packagesv1.Package{})
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+}
+
+func TestReconcilePackagesListError(t *testing.T) {
+	g := NewWithT(t)
+	cluster := clusterWithPackages()
+	// Without the packages types in the scheme, listing packages fails.
+	c := fake.NewClientBuilder().WithScheme(runtime.NewScheme()).Build()
+	pcc := curatedpackages.NewPackageControllerClient(nil, nil, cluster.Name, "", nil, nil)
+
+	err := pcc.ReconcilePackages(context.Background(), testr.New(t), c, cluster)
+	g.Expect(err).To(MatchError(ContainSubstring("listing packages of cluster my-workload-cluster")))
+}
+
+func TestClusterPackagesNoPackageConfiguration(t *testing.T) {
+	g := NewWithT(t)
+	cluster := clusterWithPackages()
+	cluster.Spec.Packages = nil
+
+	g.Expect(curatedpackages.ClusterPackages(cluster)).To(BeEmpty())
+}
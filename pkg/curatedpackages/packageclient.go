@@ -1,13 +1,18 @@
 package curatedpackages
 
 import (
+	"bufio"
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"io"
+	"os"
 	"strings"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apiyaml "k8s.io/apimachinery/pkg/util/yaml"
 	"sigs.k8s.io/yaml"
 
 	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
@@ -123,6 +128,10 @@ func (pc *PackageClient) InstallPackage(ctx context.Context, bp *packagesv1.Bund
 		return err
 	}
 
+	if err = ValidatePackageConfig(bp, "", configString); err != nil {
+		return err
+	}
+
 	p := convertBundlePackageToPackage(*bp, customName, clusterName, pc.bundle.APIVersion, configString)
 	displayPackage := NewDisplayablePackage(&p)
 	params := []string{"create", "-f", "-", "--kubeconfig", kubeConfig}
@@ -146,6 +155,99 @@ func (pc *PackageClient) getInstallConfigurations() (string, error) {
 	return GenerateAllValidConfigurations(installConfigs)
 }
 
+// ReviewPackages validates the config of the packages in a file against the schema of their
+// version in the active bundle of their cluster and writes to w the diff between the installed
+// packages and the ones in the file.
+func (pc *PackageClient) ReviewPackages(ctx context.Context, fileName string, kubeConfig string, w io.Writer) error {
+	packages, err := readPackagesFile(fileName)
+	if err != nil {
+		return err
+	}
+
+	bundles := map[string]*packagesv1.PackageBundle{}
+	for _, p := range packages {
+		clusterName := strings.TrimPrefix(p.Namespace, constants.EksaPackagesName+"-")
+		if clusterName == p.Namespace || clusterName == "" {
+			return fmt.Errorf("package %s must be in the namespace %s-<cluster name>", p.Name, constants.EksaPackagesName)
+		}
+
+		bundle, ok := bundles[clusterName]
+		if !ok {
+			bundle, err = NewBundleReader(kubeConfig, clusterName, pc.kubectl, nil, nil).getActiveBundleFromCluster(ctx)
+			if err != nil {
+				return fmt.Errorf("getting active package bundle of cluster %s: %v", clusterName, err)
+			}
+			bundles[clusterName] = bundle
+		}
+
+		bp, err := findBundlePackage(bundle, p.Spec.PackageName)
+		if err != nil {
+			return err
+		}
+		if err = ValidatePackageConfig(bp, p.Spec.PackageVersion, p.Spec.Config); err != nil {
+			return err
+		}
+
+		installed := &packagesv1.Package{}
+		if err = pc.kubectl.GetObject(ctx, "package", p.Name, p.Namespace, kubeConfig, installed); apierrors.IsNotFound(err) {
+			installed = nil
+		} else if err != nil {
+			return fmt.Errorf("getting installed package %s: %v", p.Name, err)
+		}
+
+		diff, err := PackageDiff(installed, p)
+		if err != nil {
+			return err
+		}
+		if diff == "" {
+			fmt.Fprintf(w, "Package %s/%s is unchanged\n", p.Namespace, p.Name)
+			continue
+		}
+		fmt.Fprint(w, diff)
+	}
+
+	return nil
+}
+
+func readPackagesFile(fileName string) ([]*packagesv1.Package, error) {
+	content, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("reading packages file: %v", err)
+	}
+
+	reader := apiyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(content)))
+	var packages []*packagesv1.Package
+	for {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading packages file: %v", err)
+		}
+
+		p := &packagesv1.Package{}
+		if err = yaml.Unmarshal(doc, p); err != nil {
+			return nil, fmt.Errorf("parsing packages file: %v", err)
+		}
+		if p.Kind != kind {
+			continue
+		}
+		packages = append(packages, p)
+	}
+
+	return packages, nil
+}
+
+func findBundlePackage(bundle *packagesv1.PackageBundle, packageName string) (*packagesv1.BundlePackage, error) {
+	for i := range bundle.Spec.Packages {
+		if strings.EqualFold(bundle.Spec.Packages[i].Name, packageName) {
+			return &bundle.Spec.Packages[i], nil
+		}
+	}
+	return nil, fmt.Errorf("package %s not found in bundle %s", packageName, bundle.Name)
+}
+
 func (pc *PackageClient) ApplyPackages(ctx context.Context, fileName string, kubeConfig string) error {
 	params := []string{"apply", "-f", fileName, "--kubeconfig", kubeConfig}
 	stdOut, err := pc.kubectl.ExecuteCommand(ctx, params...)
@@ -6,10 +6,14 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/constants"
@@ -270,3 +274,143 @@ func TestDisplayPackages(t *testing.T) {
 	expected := "Package\t\tVersion(s)\t\n-------\t\t----------\t\nharbor-test\t0.0.1, 0.0.2\t\nredis-test\t0.0.3, 0.0.4\t\n"
 	tt.Expect(buf.String()).To(Equal(expected))
 }
+
+func TestInstallPackagesFailsWhenConfigNotInSchema(t *testing.T) {
+	tt := newPackageTest(t)
+	bp := harborBundlePackage(t)
+	tt.command = curatedpackages.NewPackageClient(tt.kubectl, curatedpackages.WithBundle(tt.bundle), curatedpackages.WithCustomConfigs([]string{"secretkey=use-a-secret-key"}))
+
+	err := tt.command.InstallPackage(tt.ctx, bp, "my-harbor", "billy", "")
+	tt.Expect(err).To(MatchError("invalid config for package harbor: unknown keys secretkey"))
+}
+
+const reviewPackagesFile = `apiVersion: packages.eks.amazonaws.com/v1alpha1
+kind: Package
+metadata:
+  name: my-harbor
+  namespace: eksa-packages-billy
+spec:
+  packageName: harbor
+  config: |
+    secretKey: another-secret-key
+---
+apiVersion: packages.eks.amazonaws.com/v1alpha1
+kind: Package
+metadata:
+  name: my-other-harbor
+  namespace: eksa-packages-billy
+spec:
+  packageName: harbor
+  targetNamespace: harbor
+`
+
+func (tt *packageTest) writePackagesFile(t *testing.T, content string) string {
+	fileName := filepath.Join(t.TempDir(), "packages.yaml")
+	tt.Expect(os.WriteFile(fileName, []byte(content), 0o644)).To(Succeed())
+	return fileName
+}
+
+func (tt *packageTest) expectActiveBundle(bundle *packagesv1.PackageBundle) {
+	ctrl := &packagesv1.PackageBundleController{}
+	ctrl.Spec.ActiveBundle = "v1-27-125"
+	gomock.InOrder(
+		tt.kubectl.EXPECT().ExecuteCommand(tt.ctx, "get", "packageBundleController", "-o", "json", "--kubeconfig", tt.kubeConfig, "--namespace", "eksa-packages", "billy").Return(convertJsonToBytes(ctrl), nil),
+		tt.kubectl.EXPECT().ExecuteCommand(tt.ctx, "get", "packageBundle", "-o", "json", "--kubeconfig", tt.kubeConfig, "--namespace", "eksa-packages", "v1-27-125").Return(convertJsonToBytes(bundle), nil),
+	)
+}
+
+func TestReviewPackages(t *testing.T) {
+	tt := newPackageTest(t)
+	fileName := tt.writePackagesFile(t, reviewPackagesFile)
+	bundle := &packagesv1.PackageBundle{}
+	bundle.Spec.Packages = []packagesv1.BundlePackage{*harborBundlePackage(t)}
+	tt.expectActiveBundle(bundle)
+	tt.kubectl.EXPECT().GetObject(tt.ctx, "package", "my-harbor", "eksa-packages-billy", tt.kubeConfig, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _, _, _, _ string, obj *packagesv1.Package) error {
+			obj.Spec.PackageName = "harbor"
+			obj.Spec.Config = "secretKey: use-a-secret-key\n"
+			return nil
+		},
+	)
+	tt.kubectl.EXPECT().GetObject(tt.ctx, "package", "my-other-harbor", "eksa-packages-billy", tt.kubeConfig, gomock.Any()).Return(
+		apierrors.NewNotFound(schema.GroupResource{Group: "packages.eks.amazonaws.com", Resource: "packages"}, "my-other-harbor"),
+	)
+	tt.command = curatedpackages.NewPackageClient(tt.kubectl)
+	out := &bytes.Buffer{}
+
+	tt.Expect(tt.command.ReviewPackages(tt.ctx, fileName, tt.kubeConfig, out)).To(Succeed())
+	tt.Expect(out.String()).To(Equal(`--- installed/my-harbor
++++ desired/my-harbor
+@@ -1,3 +1,3 @@
+ config:
+-  secretKey: use-a-secret-key
++  secretKey: another-secret-key
+ packageName: harbor
+--- installed/my-other-harbor
++++ desired/my-other-harbor
+@@ -0,0 +1,2 @@
++packageName: harbor
++targetNamespace: harbor
+`))
+}
+
+func TestReviewPackagesUnchanged(t *testing.T) {
+	tt := newPackageTest(t)
+	fileName := tt.writePackagesFile(t, strings.Split(reviewPackagesFile, "---\n")[1])
+	bundle := &packagesv1.PackageBundle{}
+	bundle.Spec.Packages = []packagesv1.BundlePackage{*harborBundlePackage(t)}
+	tt.expectActiveBundle(bundle)
+	tt.kubectl.EXPECT().GetObject(tt.ctx, "package", "my-other-harbor", "eksa-packages-billy", tt.kubeConfig, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _, _, _, _ string, obj *packagesv1.Package) error {
+			obj.Spec.PackageName = "harbor"
+			obj.Spec.TargetNamespace = "harbor"
+			return nil
+		},
+	)
+	tt.command = curatedpackages.NewPackageClient(tt.kubectl)
+	out := &bytes.Buffer{}
+
+	tt.Expect(tt.command.ReviewPackages(tt.ctx, fileName, tt.kubeConfig, out)).To(Succeed())
+	tt.Expect(out.String()).To(Equal("Package eksa-packages-billy/my-other-harbor is unchanged\n"))
+}
+
+func TestReviewPackagesInvalidConfig(t *testing.T) {
+	tt := newPackageTest(t)
+	fileName := tt.writePackagesFile(t, strings.Replace(reviewPackagesFile, "secretKey", "secretkey", 1))
+	bundle := &packagesv1.PackageBundle{}
+	bundle.Spec.Packages = []packagesv1.BundlePackage{*harborBundlePackage(t)}
+	tt.expectActiveBundle(bundle)
+	tt.command = curatedpackages.NewPackageClient(tt.kubectl)
+
+	err := tt.command.ReviewPackages(tt.ctx, fileName, tt.kubeConfig, &bytes.Buffer{})
+	tt.Expect(err).To(MatchError("invalid config for package harbor: unknown keys secretkey"))
+}
+
+func TestReviewPackagesUnknownPackage(t *testing.T) {
+	tt := newPackageTest(t)
+	fileName := tt.writePackagesFile(t, reviewPackagesFile)
+	bundle := &packagesv1.PackageBundle{}
+	bundle.Name = "v1-27-125"
+	tt.expectActiveBundle(bundle)
+	tt.command = curatedpackages.NewPackageClient(tt.kubectl)
+
+	err := tt.command.ReviewPackages(tt.ctx, fileName, tt.kubeConfig, &bytes.Buffer{})
+	tt.Expect(err).To(MatchError("package harbor not found in bundle v1-27-125"))
+}
+
+func TestReviewPackagesWrongNamespace(t *testing.T) {
+	tt := newPackageTest(t)
+	fileName := tt.writePackagesFile(t, strings.Replace(reviewPackagesFile, "eksa-packages-billy", "default", 1))
+	tt.command = curatedpackages.NewPackageClient(tt.kubectl)
+
+	err := tt.command.ReviewPackages(tt.ctx, fileName, tt.kubeConfig, &bytes.Buffer{})
+	tt.Expect(err).To(MatchError("package my-harbor must be in the namespace eksa-packages-<cluster name>"))
+}
+
+func TestReviewPackagesMissingFile(t *testing.T) {
+	tt := newPackageTest(t)
+	tt.command = curatedpackages.NewPackageClient(tt.kubectl)
+
+	err := tt.command.ReviewPackages(tt.ctx, "non_existing.yaml", tt.kubeConfig, &bytes.Buffer{})
+	tt.Expect(err).To(MatchError(ContainSubstring("reading packages file")))
+}
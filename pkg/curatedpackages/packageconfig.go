@@ -0,0 +1,189 @@
+package curatedpackages
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+	"k8s.io/kube-openapi/pkg/validation/strfmt"
+	"k8s.io/kube-openapi/pkg/validation/validate"
+	"sigs.k8s.io/yaml"
+
+	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
+)
+
+// ValidatePackageConfig checks the config of a package against the JSON schema shipped with
+// its version in the bundle. Besides the schema constraints, keys not defined in the schema are
+// rejected unless the schema explicitly allows additional properties. An empty version is the
+// default version of the package. Versions without a schema are not validated.
+func ValidatePackageConfig(bp *packagesv1.BundlePackage, version, config string) error {
+	sourceVersion, err := findSourceVersion(bp, version)
+	if err != nil {
+		return err
+	}
+	schema, err := packageSchema(bp, sourceVersion)
+	if err != nil || schema == nil {
+		return err
+	}
+
+	values := map[string]interface{}{}
+	if err = yaml.Unmarshal([]byte(config), &values); err != nil {
+		return fmt.Errorf("invalid config for package %s: %v", bp.Name, err)
+	}
+
+	if unknown := unknownKeys(schema, values, ""); len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("invalid config for package %s: unknown keys %s", bp.Name, strings.Join(unknown, ", "))
+	}
+
+	result := validate.NewSchemaValidator(schema, schema, "", strfmt.Default).Validate(values)
+	if !result.IsValid() {
+		return fmt.Errorf("invalid config for package %s: %v", bp.Name, utilerrors.NewAggregate(result.Errors))
+	}
+
+	return nil
+}
+
+// packageSchema returns the JSON schema of the config of a package version, or nil if the version
+// doesn't have one.
+func packageSchema(bp *packagesv1.BundlePackage, sourceVersion *packagesv1.SourceVersion) (*spec.Schema, error) {
+	if sourceVersion.Schema == "" {
+		return nil, nil
+	}
+
+	rawSchema, err := bp.GetJsonSchema(sourceVersion)
+	if err != nil {
+		return nil, fmt.Errorf("reading schema of package %s %s: %v", bp.Name, sourceVersion.Name, err)
+	}
+	schema := &spec.Schema{}
+	if err = json.Unmarshal(rawSchema, schema); err != nil {
+		return nil, fmt.Errorf("parsing schema of package %s %s: %v", bp.Name, sourceVersion.Name, err)
+	}
+	return schema, nil
+}
+
+func findSourceVersion(bp *packagesv1.BundlePackage, version string) (*packagesv1.SourceVersion, error) {
+	if len(bp.Source.Versions) == 0 {
+		return nil, fmt.Errorf("package %s doesn't have any version", bp.Name)
+	}
+	if version == "" {
+		return &bp.Source.Versions[0], nil
+	}
+	for i := range bp.Source.Versions {
+		v := &bp.Source.Versions[i]
+		if v.Name == version || v.Digest == version {
+			return v, nil
+		}
+	}
+	return nil, fmt.Errorf("package %s doesn't have version %s", bp.Name, version)
+}
+
+// unknownKeys returns the paths of the keys in value not defined in the properties of the schema.
+// Objects without properties are free-form, so any key is accepted.
+func unknownKeys(schema *spec.Schema, value interface{}, path string) []string {
+	var unknown []string
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			childPath := key
+			if path != "" {
+				childPath = path + "." + key
+			}
+			if prop, ok := schema.Properties[key]; ok {
+				unknown = append(unknown, unknownKeys(&prop, child, childPath)...)
+				continue
+			}
+			if additional := schema.AdditionalProperties; additional != nil {
+				if additional.Schema != nil {
+					unknown = append(unknown, unknownKeys(additional.Schema, child, childPath)...)
+					continue
+				}
+				if additional.Allows {
+					continue
+				}
+			}
+			if len(schema.Properties) > 0 || schema.AdditionalProperties != nil {
+				unknown = append(unknown, childPath)
+			}
+		}
+	case []interface{}:
+		if schema.Items == nil || schema.Items.Schema == nil {
+			return nil
+		}
+		for i, child := range v {
+			unknown = append(unknown, unknownKeys(schema.Items.Schema, child, fmt.Sprintf("%s[%d]", path, i))...)
+		}
+	}
+	return unknown
+}
+
+// packageValues is the part of a Package that determines what gets installed, with the config
+// parsed so its keys are sorted when compared.
+type packageValues struct {
+	PackageName     string                 `json:"packageName"`
+	PackageVersion  string                 `json:"packageVersion,omitempty"`
+	TargetNamespace string                 `json:"targetNamespace,omitempty"`
+	Config          map[string]interface{} `json:"config,omitempty"`
+}
+
+func newPackageValues(p *packagesv1.Package) (*packageValues, error) {
+	values := &packageValues{
+		PackageName:     p.Spec.PackageName,
+		PackageVersion:  p.Spec.PackageVersion,
+		TargetNamespace: p.Spec.TargetNamespace,
+	}
+	if err := yaml.Unmarshal([]byte(p.Spec.Config), &values.Config); err != nil {
+		return nil, fmt.Errorf("invalid config for package %s: %v", p.Name, err)
+	}
+	return values, nil
+}
+
+// PackageDiff returns the unified diff between the installed and the desired values of a package,
+// or an empty string if they are the same. A nil installed package is a new package.
+func PackageDiff(installed, desired *packagesv1.Package) (string, error) {
+	var installedYaml []byte
+	if installed != nil {
+		values, err := newPackageValues(installed)
+		if err != nil {
+			return "", err
+		}
+		if installedYaml, err = yaml.Marshal(values); err != nil {
+			return "", err
+		}
+	}
+
+	values, err := newPackageValues(desired)
+	if err != nil {
+		return "", err
+	}
+	desiredYaml, err := yaml.Marshal(values)
+	if err != nil {
+		return "", err
+	}
+
+	if bytes.Equal(installedYaml, desiredYaml) {
+		return "", nil
+	}
+
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitLines(installedYaml),
+		B:        splitLines(desiredYaml),
+		FromFile: "installed/" + desired.Name,
+		ToFile:   "desired/" + desired.Name,
+		Context:  3,
+	})
+}
+
+// splitLines splits a yaml document in lines, without the empty line
+// difflib.SplitLines adds after the final line break.
+func splitLines(content []byte) []string {
+	if len(content) == 0 {
+		return nil
+	}
+	return difflib.SplitLines(strings.TrimSuffix(string(content), "\n"))
+}
@@ -0,0 +1,212 @@
+package curatedpackages_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/curatedpackages"
+)
+
+const harborSchema = `{
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "type": "object",
+  "properties": {
+    "secretKey": {"type": "string", "minLength": 16},
+    "expose": {
+      "type": "object",
+      "properties": {
+        "type": {"type": "string", "enum": ["clusterIP", "nodePort", "loadBalancer"]},
+        "tls": {
+          "type": "object",
+          "properties": {
+            "enabled": {"type": "boolean"}
+          }
+        }
+      }
+    },
+    "podAnnotations": {
+      "type": "object",
+      "additionalProperties": {"type": "string"}
+    },
+    "extraEnv": {
+      "type": "array",
+      "items": {
+        "type": "object",
+        "properties": {
+          "name": {"type": "string"},
+          "value": {"type": "string"}
+        }
+      }
+    },
+    "resources": {"type": "object"}
+  }
+}`
+
+func encodeSchema(t *testing.T, schema string) string {
+	b := &bytes.Buffer{}
+	w := gzip.NewWriter(b)
+	if _, err := w.Write([]byte(schema)); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return base64.StdEncoding.EncodeToString(b.Bytes())
+}
+
+func harborBundlePackage(t *testing.T) *packagesv1.BundlePackage {
+	return &packagesv1.BundlePackage{
+		Name: "harbor",
+		Source: packagesv1.BundlePackageSource{
+			Versions: []packagesv1.SourceVersion{
+				{Name: "2.7.1", Schema: encodeSchema(t, harborSchema)},
+				{Name: "2.5.0"},
+			},
+		},
+	}
+}
+
+func TestValidatePackageConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		version string
+		config  string
+		wantErr string
+	}{
+		{
+			name:   "valid config",
+			config: "secretKey: use-a-secret-key\nexpose:\n  type: nodePort\n  tls:\n    enabled: false\n",
+		},
+		{
+			name:   "empty config",
+			config: "",
+		},
+		{
+			name:   "additional properties",
+			config: "podAnnotations:\n  prometheus.io/scrape: \"true\"\n",
+		},
+		{
+			name:   "free-form object",
+			config: "resources:\n  limits:\n    cpu: 100m\n",
+		},
+		{
+			name:    "unknown keys",
+			config:  "secretkey: use-a-secret-key\nexpose:\n  tls:\n    enable: true\nextraEnv:\n- name: A\n  valeu: B\n",
+			wantErr: "invalid config for package harbor: unknown keys expose.tls.enable, extraEnv[0].valeu, secretkey",
+		},
+		{
+			name:    "schema violation",
+			config:  "expose:\n  type: ingress\n",
+			wantErr: "invalid config for package harbor: expose.type in body should be one of [clusterIP nodePort loadBalancer]",
+		},
+		{
+			name:    "wrong type",
+			config:  "secretKey: 42\n",
+			wantErr: "invalid config for package harbor: secretKey in body must be of type string",
+		},
+		{
+			name:    "version without schema",
+			version: "2.5.0",
+			config:  "anything: goes\n",
+		},
+		{
+			name:    "unknown version",
+			version: "3.0.0",
+			wantErr: "package harbor doesn't have version 3.0.0",
+		},
+		{
+			name:    "invalid yaml",
+			config:  "- a list",
+			wantErr: "invalid config for package harbor",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := curatedpackages.ValidatePackageConfig(harborBundlePackage(t), tt.version, tt.config)
+			if tt.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestValidatePackageConfigInvalidSchema(t *testing.T) {
+	g := NewWithT(t)
+	bp := harborBundlePackage(t)
+	bp.Source.Versions[0].Schema = "not-base64"
+
+	err := curatedpackages.ValidatePackageConfig(bp, "", "")
+	g.Expect(err).To(MatchError(ContainSubstring("reading schema of package harbor 2.7.1")))
+}
+
+func TestPackageDiff(t *testing.T) {
+	g := NewWithT(t)
+	installed := &packagesv1.Package{
+		Spec: packagesv1.PackageSpec{
+			PackageName:    "harbor",
+			PackageVersion: "2.7.1",
+			Config:         "secretKey: use-a-secret-key\nexpose:\n  type: nodePort\n",
+		},
+	}
+	installed.Name = "my-harbor"
+	desired := installed.DeepCopy()
+	desired.Spec.Config = "expose:\n  type: nodePort\nsecretKey: another-secret-key\n"
+
+	diff, err := curatedpackages.PackageDiff(installed, desired)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(diff).To(Equal(`--- installed/my-harbor
++++ desired/my-harbor
+@@ -1,6 +1,6 @@
+ config:
+   expose:
+     type: nodePort
+-  secretKey: use-a-secret-key
++  secretKey: another-secret-key
+ packageName: harbor
+ packageVersion: 2.7.1
+`))
+}
+
+func TestPackageDiffNoChanges(t *testing.T) {
+	g := NewWithT(t)
+	installed := &packagesv1.Package{
+		Spec: packagesv1.PackageSpec{
+			PackageName: "harbor",
+			Config:      "secretKey: use-a-secret-key\nexpose: {type: nodePort}\n",
+		},
+	}
+	desired := installed.DeepCopy()
+	desired.Spec.Config = "expose:\n  type: nodePort\nsecretKey: use-a-secret-key\n"
+
+	diff, err := curatedpackages.PackageDiff(installed, desired)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(diff).To(BeEmpty())
+}
+
+func TestPackageDiffNewPackage(t *testing.T) {
+	g := NewWithT(t)
+	desired := &packagesv1.Package{
+		Spec: packagesv1.PackageSpec{
+			PackageName:     "harbor",
+			TargetNamespace: "harbor",
+		},
+	}
+	desired.Name = "my-harbor"
+
+	diff, err := curatedpackages.PackageDiff(nil, desired)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(diff).To(Equal(`--- installed/my-harbor
++++ desired/my-harbor
+@@ -0,0 +1,2 @@
++packageName: harbor
++targetNamespace: harbor
+`))
+}
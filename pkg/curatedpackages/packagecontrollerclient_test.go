@@ -1250,7 +1250,7 @@ func TestReconcile(s *testing.T) {
 			},
 		}
 		objs := []runtime.Object{cluster, bundles, secret}
-		fakeClient := fake.NewClientBuilder().WithRuntimeObjects(objs...).Build()
+		fakeClient := fake.NewClientBuilder().WithScheme(packagesScheme()).WithRuntimeObjects(objs...).Build()
 		cm.EXPECT().InstallChart(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 
 		pcc := curatedpackages.NewPackageControllerClientFullLifecycle(log, cm, k, nil)
@@ -4,6 +4,8 @@ import (
 	"context"
 	_ "embed"
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -12,6 +14,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"oras.land/oras-go/v2/registry/remote"
 
+	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/curatedpackages"
 	"github.com/aws/eks-anywhere/pkg/registry"
 	registrymocks "github.com/aws/eks-anywhere/pkg/registry/mocks"
@@ -195,3 +198,189 @@ func TestPackageReader_ReadChartsFromBundlesBundlePullError(t *testing.T) {
 
 	tt.Expect(images).To(BeEmpty())
 }
+
+func TestPackageReader_ReadBundle(t *testing.T) {
+	tt := newPackageReaderTest(t)
+	artifact := registry.NewArtifactFromURI("public.ecr.aws/eks-anywhere/eks-anywhere-packages-bundles:v1-21-125")
+	repo, err := remote.NewRepository("owner/name")
+	assert.NoError(t, err)
+	tt.storageClient.EXPECT().GetStorage(tt.ctx, gomock.Any()).Return(repo, nil)
+	tt.storageClient.EXPECT().FetchBytes(tt.ctx, gomock.Any(), artifact).Return(desc, imageManifest, nil)
+	tt.storageClient.EXPECT().FetchBlob(tt.ctx, gomock.Any(), gomock.Any()).Return(packageBundle, nil)
+
+	bundle, err := tt.command.ReadBundle(tt.ctx, artifact.VersionedImage())
+
+	tt.Expect(err).To(BeNil())
+	tt.Expect(bundle.Spec.Packages).NotTo(BeEmpty())
+}
+
+func TestPackageReader_ReadBundlePullError(t *testing.T) {
+	tt := newPackageReaderTest(t)
+	repo, err := remote.NewRepository("owner/name")
+	assert.NoError(t, err)
+	tt.storageClient.EXPECT().GetStorage(tt.ctx, gomock.Any()).Return(repo, nil)
+	tt.storageClient.EXPECT().FetchBytes(tt.ctx, gomock.Any(), gomock.Any()).Return(desc, []byte{}, fmt.Errorf("oops"))
+
+	_, err = tt.command.ReadBundle(tt.ctx, "public.ecr.aws/eks-anywhere/eks-anywhere-packages-bundles:v1-21-125")
+
+	tt.Expect(err).To(MatchError(ContainSubstring("oops")))
+}
+
+func TestPackageReader_ReadBundleArtifacts(t *testing.T) {
+	tt := newPackageReaderTest(t)
+	bundleURI := "public.ecr.aws/eks-anywhere/eks-anywhere-packages-bundles:v1-21-125"
+	bundle := &packagesv1.PackageBundle{
+		Spec: packagesv1.PackageBundleSpec{
+			Packages: []packagesv1.BundlePackage{
+				{
+					Name: "harbor",
+					Source: packagesv1.BundlePackageSource{
+						Repository: "harbor/harbor-helm",
+						Versions: []packagesv1.SourceVersion{
+							{
+								Name:   "2.7.1",
+								Digest: "sha256:aaaa",
+								Images: []packagesv1.VersionImages{
+									{Repository: "harbor/harbor-core", Digest: "sha256:bbbb"},
+									{Repository: "harbor/harbor-db", Digest: "sha256:cccc"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	charts, images := tt.command.ReadBundleArtifacts(bundleURI, bundle)
+
+	tt.Expect(charts).To(ConsistOf(
+		registry.NewArtifactFromURI(bundleURI),
+		registry.Artifact{Registry: "public.ecr.aws", Repository: "eks-anywhere/harbor/harbor-helm", Tag: "2.7.1", Digest: "sha256:aaaa"},
+	))
+	tt.Expect(images).To(ConsistOf(
+		registry.Artifact{Registry: "783794618700.dkr.ecr.us-east-1.amazonaws.com", Repository: "harbor/harbor-core", Digest: "sha256:bbbb"},
+		registry.Artifact{Registry: "783794618700.dkr.ecr.us-east-1.amazonaws.com", Repository: "harbor/harbor-db", Digest: "sha256:cccc"},
+	))
+}
+
+func TestPackageReader_ImageRegistry(t *testing.T) {
+	tt := newPackageReaderTest(t)
+	tt.bundles.Spec.VersionsBundles[0].PackageController.Controller.URI = tt.registryName + "/eks-anywhere/ctrl:v1"
+
+	imageRegistry, err := tt.command.ImageRegistry(tt.bundles.Spec.VersionsBundles[0])
+
+	tt.Expect(err).To(BeNil())
+	tt.Expect(imageRegistry).To(Equal("783794618700.dkr.ecr.us-east-1.amazonaws.com"))
+}
+
+func TestPackageReader_ImageRegistryBadKubeVersion(t *testing.T) {
+	tt := newPackageReaderTest(t)
+	tt.bundles.Spec.VersionsBundles[0].KubeVersion = "1"
+
+	_, err := tt.command.ImageRegistry(tt.bundles.Spec.VersionsBundles[0])
+
+	tt.Expect(err).NotTo(BeNil())
+}
+
+func writePackagesFile(t *testing.T, content string) string {
+	fileName := filepath.Join(t.TempDir(), "packages.yaml")
+	if err := os.WriteFile(fileName, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return fileName
+}
+
+func (tt *packageReaderTest) expectPullBundle(t *testing.T) {
+	repo, err := remote.NewRepository("owner/name")
+	assert.NoError(t, err)
+	tt.storageClient.EXPECT().GetStorage(tt.ctx, gomock.Any()).Return(repo, nil)
+	tt.storageClient.EXPECT().FetchBytes(tt.ctx, gomock.Any(), gomock.Any()).Return(desc, imageManifest, nil)
+	tt.storageClient.EXPECT().FetchBlob(tt.ctx, gomock.Any(), gomock.Any()).Return(packageBundle, nil)
+}
+
+func TestPackageReader_ReadPackagesImages(t *testing.T) {
+	tt := newPackageReaderTest(t)
+	tt.expectPullBundle(t)
+	packagesFile := writePackagesFile(t, `apiVersion: packages.eks.amazonaws.com/v1alpha1
+kind: Package
+metadata:
+  name: my-hello
+  namespace: eksa-packages-billy
+spec:
+  packageName: hello-eks-anywhere
+---
+apiVersion: packages.eks.amazonaws.com/v1alpha1
+kind: Package
+metadata:
+  name: my-other-hello
+  namespace: eksa-packages-billy
+spec:
+  packageName: hello-eks-anywhere
+  packageVersion: 0.1.2-a6847010915747a9fc8a412b233a2b1ee608ae76
+`)
+
+	images, err := tt.command.ReadPackagesImages(tt.ctx, tt.bundles.Spec.VersionsBundles[0], packagesFile)
+
+	tt.Expect(err).To(BeNil())
+	tt.Expect(images).To(ConsistOf(
+		registry.Artifact{
+			Registry:   "857151390494.dkr.ecr.us-east-1.amazonaws.com",
+			Repository: "hello-eks-anywhere",
+			Digest:     "sha256:0d21da192be638a44fba3d34237e3f39f0161bec39e9ca33c0c435e0d74102ea",
+		},
+	))
+}
+
+func TestPackageReader_ReadPackagesImagesUnknownPackage(t *testing.T) {
+	tt := newPackageReaderTest(t)
+	tt.expectPullBundle(t)
+	packagesFile := writePackagesFile(t, `apiVersion: packages.eks.amazonaws.com/v1alpha1
+kind: Package
+metadata:
+  name: my-nope
+  namespace: eksa-packages-billy
+spec:
+  packageName: nope
+`)
+
+	_, err := tt.command.ReadPackagesImages(tt.ctx, tt.bundles.Spec.VersionsBundles[0], packagesFile)
+
+	tt.Expect(err).To(MatchError(ContainSubstring("package nope not found in bundle")))
+}
+
+func TestPackageReader_ReadPackagesImagesUnknownVersion(t *testing.T) {
+	tt := newPackageReaderTest(t)
+	tt.expectPullBundle(t)
+	packagesFile := writePackagesFile(t, `apiVersion: packages.eks.amazonaws.com/v1alpha1
+kind: Package
+metadata:
+  name: my-hello
+  namespace: eksa-packages-billy
+spec:
+  packageName: hello-eks-anywhere
+  packageVersion: 9.9.9
+`)
+
+	_, err := tt.command.ReadPackagesImages(tt.ctx, tt.bundles.Spec.VersionsBundles[0], packagesFile)
+
+	tt.Expect(err).To(MatchError(ContainSubstring("package hello-eks-anywhere doesn't have version 9.9.9")))
+}
+
+func TestPackageReader_ReadPackagesImagesBundlePullError(t *testing.T) {
+	tt := newPackageReaderTest(t)
+	packagesFile := writePackagesFile(t, "")
+	tt.storageClient.EXPECT().GetStorage(tt.ctx, gomock.Any()).Return(nil, fmt.Errorf("oops"))
+
+	_, err := tt.command.ReadPackagesImages(tt.ctx, tt.bundles.Spec.VersionsBundles[0], packagesFile)
+
+	tt.Expect(err).To(MatchError(ContainSubstring("getting packages bundle")))
+}
+
+func TestPackageReader_ReadPackagesImagesMissingFile(t *testing.T) {
+	tt := newPackageReaderTest(t)
+
+	_, err := tt.command.ReadPackagesImages(tt.ctx, tt.bundles.Spec.VersionsBundles[0], filepath.Join(t.TempDir(), "missing.yaml"))
+
+	tt.Expect(err).To(MatchError(ContainSubstring("reading packages file")))
+}
@@ -0,0 +1,123 @@
+package curatedpackages
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/yaml"
+
+	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
+)
+
+// PackagesUpgradePlan describes how moving the package bundle controller of a cluster
+// to another bundle changes its installed packages.
+type PackagesUpgradePlan struct {
+	ActiveBundle string           `json:"activeBundle"`
+	TargetBundle string           `json:"targetBundle"`
+	Packages     []PackageUpgrade `json:"packages"`
+}
+
+// PackageUpgrade is the change of an installed package in a PackagesUpgradePlan.
+type PackageUpgrade struct {
+	Name           string `json:"name"`
+	PackageName    string `json:"packageName"`
+	CurrentVersion string `json:"currentVersion"`
+	TargetVersion  string `json:"targetVersion"`
+	// DroppedConfigKeys are the keys of the package config not defined in the
+	// schema of the target version.
+	DroppedConfigKeys []string `json:"droppedConfigKeys,omitempty"`
+	// ConfigError is set when the package config isn't valid for the target version.
+	ConfigError string `json:"configError,omitempty"`
+}
+
+// Upgrades is true if the version of the package changes.
+func (p PackageUpgrade) Upgrades() bool {
+	return p.CurrentVersion != p.TargetVersion
+}
+
+// UpToDate is true if no installed package changes with the target bundle.
+func (p *PackagesUpgradePlan) UpToDate() bool {
+	for _, pkg := range p.Packages {
+		if pkg.Upgrades() || len(pkg.DroppedConfigKeys) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// PlanUpgrade returns the upgrade plan of ReviewUpgrade, without writing the diffs of the packages.
+func (b *BundleReader) PlanUpgrade(ctx context.Context, controller *packagesv1.PackageBundleController, targetBundle string) (*PackagesUpgradePlan, error) {
+	return b.ReviewUpgrade(ctx, controller, targetBundle, io.Discard)
+}
+
+// planPackageUpgrade returns the current and target versions of an installed package when the controller
+// moves from activeBundle to newBundle, the config keys it would drop and whether its config is valid.
+func planPackageUpgrade(installed *packagesv1.Package, activeBundle, newBundle *packagesv1.PackageBundle) (*PackageUpgrade, error) {
+	upgrade := &PackageUpgrade{
+		Name:           installed.Name,
+		PackageName:    installed.Spec.PackageName,
+		CurrentVersion: installed.Status.CurrentVersion,
+	}
+
+	if upgrade.CurrentVersion == "" {
+		if activeBp, err := findBundlePackage(activeBundle, installed.Spec.PackageName); err == nil {
+			if v, err := findSourceVersion(activeBp, installed.Spec.PackageVersion); err == nil {
+				upgrade.CurrentVersion = v.Name
+			}
+		}
+	}
+
+	bp, err := findBundlePackage(newBundle, installed.Spec.PackageName)
+	if err != nil {
+		return nil, err
+	}
+	targetVersion, err := findSourceVersion(bp, installed.Spec.PackageVersion)
+	if err != nil {
+		return nil, err
+	}
+	upgrade.TargetVersion = targetVersion.Name
+
+	if err = ValidatePackageConfig(bp, installed.Spec.PackageVersion, installed.Spec.Config); err != nil {
+		upgrade.ConfigError = err.Error()
+	}
+
+	schema, err := packageSchema(bp, targetVersion)
+	if err != nil || schema == nil {
+		return upgrade, err
+	}
+	values := map[string]interface{}{}
+	if err = yaml.Unmarshal([]byte(installed.Spec.Config), &values); err != nil {
+		return nil, fmt.Errorf("invalid config for package %s: %v", installed.Name, err)
+	}
+	upgrade.DroppedConfigKeys = unknownKeys(schema, values, "")
+	sort.Strings(upgrade.DroppedConfigKeys)
+
+	return upgrade, nil
+}
+
+// ValidateConfig returns an error if the config of any package isn't valid for its target version.
+func (p *PackagesUpgradePlan) ValidateConfig() error {
+	var errs []error
+	for _, pkg := range p.Packages {
+		if pkg.ConfigError != "" {
+			errs = append(errs, errors.New(pkg.ConfigError))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// RollbackBundle moves the controller back to a bundle already in the cluster,
+// usually the one that was active before the last upgrade.
+func (b *BundleReader) RollbackBundle(ctx context.Context, controller *packagesv1.PackageBundleController, bundleName string) error {
+	if bundleName == controller.Spec.ActiveBundle {
+		return fmt.Errorf("package bundle %s is already active", bundleName)
+	}
+	if _, err := b.getPackageBundle(ctx, bundleName); err != nil {
+		return fmt.Errorf("getting package bundle %s: %v", bundleName, err)
+	}
+	return b.UpgradeBundle(ctx, controller, bundleName)
+}
@@ -0,0 +1,101 @@
+package curatedpackages_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/yaml"
+
+	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/curatedpackages"
+)
+
+func TestPlanUpgradeSucceeds(t *testing.T) {
+	tt := newBundleTest(t)
+	tt.expectUpgradeReview(t, "secretKey: use-a-secret-key\nexpose:\n  tls:\n    enable: true\nsecretkey: typo\n")
+	tt.Command = curatedpackages.NewBundleReader(tt.kubeConfig, tt.cluster, tt.kubectl, tt.bundleManager, tt.registry)
+
+	plan, err := tt.Command.PlanUpgrade(tt.ctx, tt.bundleCtrl, "new-bundle")
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(plan).To(Equal(&curatedpackages.PackagesUpgradePlan{
+		ActiveBundle: tt.activeBundle,
+		TargetBundle: "new-bundle",
+		Packages: []curatedpackages.PackageUpgrade{
+			{Name: "pinned-harbor", PackageName: "harbor", CurrentVersion: "2.5.0", TargetVersion: "2.5.0"},
+			{
+				Name:              "my-harbor",
+				PackageName:       "harbor",
+				CurrentVersion:    "2.5.0",
+				TargetVersion:     "2.7.1",
+				DroppedConfigKeys: []string{"expose.tls.enable", "secretkey"},
+				ConfigError:       "invalid config for package harbor: unknown keys expose.tls.enable, secretkey",
+			},
+		},
+	}))
+	tt.Expect(plan.UpToDate()).To(BeFalse())
+	tt.Expect(plan.ValidateConfig()).To(MatchError("invalid config for package harbor: unknown keys expose.tls.enable, secretkey"))
+}
+
+func TestPlanUpgradeUpToDate(t *testing.T) {
+	plan := &curatedpackages.PackagesUpgradePlan{
+		Packages: []curatedpackages.PackageUpgrade{
+			{Name: "my-harbor", PackageName: "harbor", CurrentVersion: "2.7.1", TargetVersion: "2.7.1"},
+		},
+	}
+	NewWithT(t).Expect(plan.UpToDate()).To(BeTrue())
+}
+
+func TestPlanUpgradePackageNotInBundle(t *testing.T) {
+	tt := newBundleTest(t)
+	installed := packagesv1.Package{Spec: packagesv1.PackageSpec{PackageName: "redis"}}
+	installed.Name = "my-redis"
+	newBundle := tt.packageBundle.DeepCopy()
+	newBundle.Name = "new-bundle"
+	gomock.InOrder(
+		tt.kubectl.EXPECT().ExecuteCommand(tt.ctx, gomock.Any()).Return(convertJsonToBytes(newBundle), nil),
+		tt.kubectl.EXPECT().ExecuteCommand(tt.ctx, gomock.Any()).Return(convertJsonToBytes(tt.packageBundle), nil),
+		tt.kubectl.EXPECT().ExecuteCommand(tt.ctx, gomock.Any()).Return(convertJsonToBytes(packagesv1.PackageList{Items: []packagesv1.Package{installed}}), nil),
+	)
+	tt.Command = curatedpackages.NewBundleReader(tt.kubeConfig, tt.cluster, tt.kubectl, tt.bundleManager, tt.registry)
+
+	_, err := tt.Command.PlanUpgrade(tt.ctx, tt.bundleCtrl, "new-bundle")
+	tt.Expect(err).To(MatchError("package redis not found in bundle new-bundle"))
+}
+
+func TestRollbackBundleSucceeds(t *testing.T) {
+	tt := newBundleTest(t)
+	previousBundle := "v1.21-999"
+	expectedCtrl := tt.bundleCtrl.DeepCopy()
+	expectedCtrl.Spec.ActiveBundle = previousBundle
+	ctrl, err := yaml.Marshal(expectedCtrl)
+	tt.Expect(err).To(BeNil())
+	gomock.InOrder(
+		tt.kubectl.EXPECT().ExecuteCommand(tt.ctx, "get", "packageBundle", "-o", "json", "--kubeconfig", tt.kubeConfig, "--namespace", "eksa-packages", previousBundle).Return(convertJsonToBytes(tt.packageBundle), nil),
+		tt.kubectl.EXPECT().ExecuteFromYaml(tt.ctx, ctrl, "apply", "-f", "-", "--kubeconfig", tt.kubeConfig).Return(bytes.Buffer{}, nil),
+	)
+	tt.Command = curatedpackages.NewBundleReader(tt.kubeConfig, tt.cluster, tt.kubectl, tt.bundleManager, tt.registry)
+
+	tt.Expect(tt.Command.RollbackBundle(tt.ctx, tt.bundleCtrl, previousBundle)).To(Succeed())
+	tt.Expect(tt.bundleCtrl.Spec.ActiveBundle).To(Equal(previousBundle))
+}
+
+func TestRollbackBundleAlreadyActive(t *testing.T) {
+	tt := newBundleTest(t)
+	tt.Command = curatedpackages.NewBundleReader(tt.kubeConfig, tt.cluster, tt.kubectl, tt.bundleManager, tt.registry)
+
+	err := tt.Command.RollbackBundle(tt.ctx, tt.bundleCtrl, tt.activeBundle)
+	tt.Expect(err).To(MatchError("package bundle v1.21-1000 is already active"))
+}
+
+func TestRollbackBundleUnknownBundle(t *testing.T) {
+	tt := newBundleTest(t)
+	tt.kubectl.EXPECT().ExecuteCommand(tt.ctx, gomock.Any()).Return(bytes.Buffer{}, errors.New("not found"))
+	tt.Command = curatedpackages.NewBundleReader(tt.kubeConfig, tt.cluster, tt.kubectl, tt.bundleManager, tt.registry)
+
+	err := tt.Command.RollbackBundle(tt.ctx, tt.bundleCtrl, "v1.21-999")
+	tt.Expect(err).To(MatchError("getting package bundle v1.21-999: not found"))
+	tt.Expect(tt.bundleCtrl.Spec.ActiveBundle).To(Equal(tt.activeBundle))
+}
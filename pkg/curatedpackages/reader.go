@@ -79,28 +79,86 @@ func (r *PackageReader) ReadChartsFromBundles(ctx context.Context, b *releasev1.
 	return removeDuplicateImages(images)
 }
 
-func (r *PackageReader) getBundle(ctx context.Context, vb releasev1.VersionsBundle) (string, *packagesv1.PackageBundle, error) {
-	bundleURI, err := GetPackageBundleRef(vb)
-	if err != nil {
-		return "", nil, err
-	}
-
+// ReadBundle pulls the package bundle with the given URI from its registry.
+func (r *PackageReader) ReadBundle(ctx context.Context, bundleURI string) (*packagesv1.PackageBundle, error) {
 	artifact := registry.NewArtifactFromURI(bundleURI)
 	sc, err := r.cache.Get(registry.NewStorageContext(artifact.Registry, r.credentialStore, nil, false))
 	if err != nil {
-		return "", nil, err
+		return nil, err
 	}
 
 	data, err := registry.PullBytes(ctx, sc, artifact)
+	if err != nil {
+		return nil, err
+	}
+	bundle := &packagesv1.PackageBundle{}
+	if err = yaml.Unmarshal(data, bundle); err != nil {
+		return nil, err
+	}
+	return bundle, nil
+}
+
+// ReadBundleArtifacts returns the artifacts of a package bundle: the bundle itself and the
+// helm charts of its packages, and the images of its packages.
+func (r *PackageReader) ReadBundleArtifacts(bundleURI string, bundle *packagesv1.PackageBundle) (charts, images []registry.Artifact) {
+	charts = append([]registry.Artifact{registry.NewArtifactFromURI(bundleURI)}, r.fetchPackagesHelmChart(bundleURI, bundle)...)
+	return removeDuplicateImages(charts), removeDuplicateImages(r.fetchImagesFromBundle(bundleURI, bundle))
+}
+
+// ReadPackagesImages returns the images of the packages declared in packagesFile, as
+// listed in the packages bundle of the versions bundle.
+func (r *PackageReader) ReadPackagesImages(ctx context.Context, vb releasev1.VersionsBundle, packagesFile string) ([]registry.Artifact, error) {
+	packages, err := readPackagesFile(packagesFile)
+	if err != nil {
+		return nil, err
+	}
+
+	bundleURI, bundle, err := r.getBundle(ctx, vb)
+	if err != nil {
+		return nil, fmt.Errorf("getting packages bundle: %v", err)
+	}
+
+	bundleRegistry := getImageRegistry(bundleURI, r.awsRegion)
+	var images []registry.Artifact
+	for _, p := range packages {
+		bp, err := findBundlePackage(bundle, p.Spec.PackageName)
+		if err != nil {
+			return nil, err
+		}
+		version, err := findSourceVersion(bp, p.Spec.PackageVersion)
+		if err != nil {
+			return nil, err
+		}
+		for _, vi := range version.Images {
+			image := registry.NewArtifactFromURI(fmt.Sprintf("%s/%s@%s", bundleRegistry, vi.Repository, vi.Digest))
+			image.Tag = ""
+			images = append(images, image)
+		}
+	}
+	return removeDuplicateImages(images), nil
+}
+
+// ImageRegistry returns the registry of the images of the packages bundle of a versions bundle.
+func (r *PackageReader) ImageRegistry(vb releasev1.VersionsBundle) (string, error) {
+	bundleURI, err := GetPackageBundleRef(vb)
+	if err != nil {
+		return "", err
+	}
+	return getImageRegistry(bundleURI, r.awsRegion), nil
+}
+
+func (r *PackageReader) getBundle(ctx context.Context, vb releasev1.VersionsBundle) (string, *packagesv1.PackageBundle, error) {
+	bundleURI, err := GetPackageBundleRef(vb)
 	if err != nil {
 		return "", nil, err
 	}
-	bundle := packagesv1.PackageBundle{}
-	err = yaml.Unmarshal(data, &bundle)
+
+	bundle, err := r.ReadBundle(ctx, bundleURI)
 	if err != nil {
 		return "", nil, err
 	}
-	return artifact.VersionedImage(), &bundle, nil
+	artifact := registry.NewArtifactFromURI(bundleURI)
+	return artifact.VersionedImage(), bundle, nil
 }
 
 func (r *PackageReader) fetchPackagesHelmChart(bundleURI string, bundle *packagesv1.PackageBundle) []registry.Artifact {
@@ -24,12 +24,14 @@ import (
 	"github.com/aws/eks-anywhere/pkg/crypto"
 	"github.com/aws/eks-anywhere/pkg/curatedpackages"
 	"github.com/aws/eks-anywhere/pkg/diagnostics"
+	"github.com/aws/eks-anywhere/pkg/dryrun"
 	"github.com/aws/eks-anywhere/pkg/eksd"
 	"github.com/aws/eks-anywhere/pkg/executables"
 	"github.com/aws/eks-anywhere/pkg/executables/cmk"
 	"github.com/aws/eks-anywhere/pkg/files"
 	"github.com/aws/eks-anywhere/pkg/filewriter"
 	gitfactory "github.com/aws/eks-anywhere/pkg/git/factory"
+	"github.com/aws/eks-anywhere/pkg/gitops/argocd"
 	"github.com/aws/eks-anywhere/pkg/gitops/flux"
 	"github.com/aws/eks-anywhere/pkg/govmomi"
 	"github.com/aws/eks-anywhere/pkg/kubeconfig"
@@ -39,6 +41,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/networking/cilium"
 	"github.com/aws/eks-anywhere/pkg/networking/kindnetd"
 	"github.com/aws/eks-anywhere/pkg/networkutils"
+	"github.com/aws/eks-anywhere/pkg/nodes"
 	"github.com/aws/eks-anywhere/pkg/providers"
 	"github.com/aws/eks-anywhere/pkg/providers/cloudstack"
 	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/decoder"
@@ -72,6 +75,7 @@ type Dependencies struct {
 	Flux                        *executables.Flux
 	Troubleshoot                *executables.Troubleshoot
 	Helm                        *executables.Helm
+	SSH                         *executables.SSH
 	UnAuthKubeClient            *kubernetes.UnAuthClient
 	Networking                  clustermanager.Networking
 	CNIInstaller                workload.CNIInstaller
@@ -80,6 +84,8 @@ type Dependencies struct {
 	ClusterManager              *clustermanager.ClusterManager
 	Bootstrapper                *bootstrapper.Bootstrapper
 	GitOpsFlux                  *flux.Flux
+	GitOpsArgoCD                *argocd.ArgoCD
+	GitOps                      interfaces.GitOpsManager
 	Git                         *gitfactory.GitTools
 	EksdInstaller               *eksd.Installer
 	EksdUpgrader                *eksd.Upgrader
@@ -103,7 +109,9 @@ type Dependencies struct {
 	NutanixValidator            *nutanix.Validator
 	SnowValidator               *snow.Validator
 	IPValidator                 *validator.IPValidator
+	DryRunRenderer              *dryrun.Renderer
 	UnAuthKubectlClient         KubeClients
+	NodeLister                  *nodes.Lister
 }
 
 // KubeClients defines super struct that exposes all behavior.
@@ -156,6 +164,7 @@ type executablesConfig struct {
 type config struct {
 	bundlesOverride string
 	noTimeouts      bool
+	dryRun          bool
 }
 
 type buildStep func(ctx context.Context) error
@@ -370,6 +379,10 @@ func (f *Factory) WithExecutableBuilder() *Factory {
 		}
 		f.dependencies.closers = append(f.dependencies.closers, closer)
 
+		if f.config.dryRun {
+			f.executablesConfig.builder = f.executablesConfig.builder.ReadOnly()
+		}
+
 		return nil
 	})
 
@@ -544,6 +557,10 @@ func (f *Factory) WithDocker() *Factory {
 		if f.executablesConfig.dockerClient == nil {
 			f.executablesConfig.dockerClient = f.dependencies.DockerClient
 		}
+		if f.config.dryRun {
+			// The executables container keeps using the docker client that can run it.
+			f.dependencies.DockerClient = executables.BuildReadOnlyDockerExecutable()
+		}
 
 		return nil
 	})
@@ -725,6 +742,38 @@ func (f *Factory) WithTroubleshoot() *Factory {
 	return f
 }
 
+// WithSSH builds a SSH executable.
+func (f *Factory) WithSSH() *Factory {
+	f.WithExecutableBuilder()
+
+	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
+		if f.dependencies.SSH != nil {
+			return nil
+		}
+
+		f.dependencies.SSH = f.executablesConfig.builder.BuildSSHExecutable()
+		return nil
+	})
+
+	return f
+}
+
+// WithNodeLister builds a nodes.Lister to find the control plane and etcd nodes of a cluster.
+func (f *Factory) WithNodeLister() *Factory {
+	f.WithKubectl()
+
+	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
+		if f.dependencies.NodeLister != nil {
+			return nil
+		}
+
+		f.dependencies.NodeLister = nodes.NewLister(f.dependencies.Kubectl)
+		return nil
+	})
+
+	return f
+}
+
 func (f *Factory) WithHelm(opts ...executables.HelmOpt) *Factory {
 	f.WithExecutableBuilder().WithProxyConfiguration()
 
@@ -998,6 +1047,59 @@ func (f *Factory) WithNoTimeouts() *Factory {
 	return f
 }
 
+// WithDryRun makes every dependency that runs executables, like kubectl, govc or cmk, only read from
+// clusters and infrastructure: the commands that would mutate them are skipped. It needs to be called
+// before building any of those dependencies.
+func (f *Factory) WithDryRun() *Factory {
+	f.config.dryRun = true
+	return f
+}
+
+// WithDryRunRenderer builds a renderer that writes to outputDir the manifests applied to create or
+// upgrade the cluster in spec. packagesLocation is the optional file with the curated packages to install.
+func (f *Factory) WithDryRunRenderer(spec *cluster.Spec, provider providers.Provider, outputDir, packagesLocation string, timeoutOpts *ClusterManagerTimeoutOptions) *Factory {
+	if spec.Cluster.Spec.ClusterNetwork.CNIConfig.Kindnetd != nil {
+		f.WithFileReader()
+	} else {
+		f.WithCiliumTemplater()
+	}
+	f.WithLogger().WithFileReader()
+
+	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
+		if f.dependencies.DryRunRenderer != nil {
+			return nil
+		}
+
+		writer, err := filewriter.NewWriter(outputDir)
+		if err != nil {
+			return err
+		}
+
+		var cni dryrun.CNIGenerator
+		if spec.Cluster.Spec.ClusterNetwork.CNIConfig.Kindnetd != nil {
+			cni = dryrun.KindnetdGenerator(f.dependencies.FileReader)
+		} else {
+			cni = dryrun.CiliumGenerator(f.dependencies.CiliumTemplater, provider)
+		}
+
+		opts := []dryrun.RendererOpt{dryrun.WithPackages(packagesLocation)}
+		if timeoutOpts != nil {
+			opts = append(opts, dryrun.WithMachineHealthCheckTimeouts(timeoutOpts.UnhealthyMachineWait, timeoutOpts.NodeStartupWait))
+		}
+
+		f.dependencies.DryRunRenderer = dryrun.NewRenderer(
+			provider,
+			cni,
+			clustermanager.NewEKSAComponentGenerator(f.dependencies.Logger, f.dependencies.FileReader),
+			writer,
+			opts...,
+		)
+		return nil
+	})
+
+	return f
+}
+
 // WithCliConfig builds a cli config.
 func (f *Factory) WithCliConfig(cliConfig *cliconfig.CliConfig) *Factory {
 	f.dependencies.CliConfig = cliConfig
@@ -1159,6 +1261,50 @@ func (f *Factory) WithGitOpsFlux(clusterConfig *v1alpha1.Cluster, fluxConfig *v1
 	return f
 }
 
+// WithGitOpsArgoCD builds a gitops Argo CD manager. It writes to git with a Flux manager that doesn't bootstrap Flux.
+func (f *Factory) WithGitOpsArgoCD(clusterConfig *v1alpha1.Cluster, argoCDConfig *v1alpha1.ArgoCDConfig, cliConfig *cliconfig.CliConfig) *Factory {
+	f.WithWriter().WithKubectl().WithGit(clusterConfig, argoCDConfig.ConvertToFluxConfig()).WithFileReader()
+
+	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
+		if f.dependencies.GitOpsArgoCD != nil {
+			return nil
+		}
+
+		repository := flux.NewFlux(nil, f.dependencies.Kubectl, f.dependencies.Git, cliConfig)
+		f.dependencies.GitOpsArgoCD = argocd.New(f.dependencies.Kubectl, repository, f.dependencies.FileReader, cliConfig)
+
+		return nil
+	})
+
+	return f
+}
+
+// WithGitOps builds the GitOps manager of the engine configured in the cluster spec, Argo CD or Flux.
+func (f *Factory) WithGitOps(clusterSpec *cluster.Spec, cliConfig *cliconfig.CliConfig) *Factory {
+	argoCD := clusterSpec.ArgoCDConfig != nil
+	if argoCD {
+		f.WithGitOpsArgoCD(clusterSpec.Cluster, clusterSpec.ArgoCDConfig, cliConfig)
+	} else {
+		f.WithGitOpsFlux(clusterSpec.Cluster, clusterSpec.FluxConfig, cliConfig)
+	}
+
+	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
+		if f.dependencies.GitOps != nil {
+			return nil
+		}
+
+		if argoCD {
+			f.dependencies.GitOps = f.dependencies.GitOpsArgoCD
+		} else {
+			f.dependencies.GitOps = f.dependencies.GitOpsFlux
+		}
+
+		return nil
+	})
+
+	return f
+}
+
 func (f *Factory) WithPackageInstaller(spec *cluster.Spec, packagesLocation, kubeConfig string) *Factory {
 	f.WithKubectl().WithPackageControllerClient(spec, kubeConfig).WithPackageClient()
 	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
@@ -215,6 +215,8 @@ func TestFactoryBuildWithMultipleDependencies(t *testing.T) {
 		WithIPValidator().
 		WithKubeProxyCLIUpgrader().
 		WithValidatorClients().
+		WithSSH().
+		WithNodeLister().
 		Build(context.Background())
 
 	tt.Expect(err).To(BeNil())
@@ -237,6 +239,8 @@ func TestFactoryBuildWithMultipleDependencies(t *testing.T) {
 	tt.Expect(deps.IPValidator).NotTo(BeNil())
 	tt.Expect(deps.KubeProxyCLIUpgrader).NotTo(BeNil())
 	tt.Expect(deps.UnAuthKubectlClient).NotTo(BeNil())
+	tt.Expect(deps.SSH).NotTo(BeNil())
+	tt.Expect(deps.NodeLister).NotTo(BeNil())
 }
 
 func TestFactoryBuildWithProxyConfiguration(t *testing.T) {
@@ -534,6 +538,27 @@ func TestFactoryBuildWithCNIInstallerKindnetd(t *testing.T) {
 	tt.Expect(deps.CNIInstaller).NotTo(BeNil())
 }
 
+func TestFactoryBuildWithDryRunRenderer(t *testing.T) {
+	tt := newTest(t, vsphere)
+
+	factory := dependencies.NewFactory()
+	deps, err := factory.
+		WithLocalExecutables().
+		WithDryRun().
+		WithProvider(tt.clusterConfigFile, tt.clusterSpec.Cluster, false, tt.hardwareConfigFile, false, tt.tinkerbellBootstrapIP).
+		Build(tt.ctx)
+	tt.Expect(err).To(BeNil())
+
+	deps, err = factory.
+		WithDryRunRenderer(tt.clusterSpec, deps.Provider, t.TempDir(), "", &dependencies.ClusterManagerTimeoutOptions{}).
+		WithDryRunRenderer(tt.clusterSpec, deps.Provider, t.TempDir(), "", nil). // idempotency
+		Build(tt.ctx)
+
+	tt.Expect(err).To(BeNil())
+	tt.Expect(deps.Kubectl).NotTo(BeNil())
+	tt.Expect(deps.DryRunRenderer).NotTo(BeNil())
+}
+
 func TestFactoryBuildWithKubeProxyCLIUpgraderNoTimeout(t *testing.T) {
 	tt := newTest(t, vsphere)
 	deps, err := dependencies.NewFactory().
@@ -607,3 +632,16 @@ func (b dummyDockerClient) Execute(ctx context.Context, args ...string) (stdout
 func (b dummyDockerClient) Login(ctx context.Context, endpoint, username, password string) error {
 	return nil
 }
+
+func TestFactoryBuildWithGitOpsFlux(t *testing.T) {
+	tt := newTest(t, vsphere)
+	deps, err := dependencies.NewFactory().
+		WithLocalExecutables().
+		WithGitOps(tt.clusterSpec, nil).
+		Build(context.Background())
+
+	tt.Expect(err).To(BeNil())
+	tt.Expect(deps.GitOpsFlux).NotTo(BeNil())
+	tt.Expect(deps.GitOpsArgoCD).To(BeNil())
+	tt.Expect(deps.GitOps).To(BeIdenticalTo(deps.GitOpsFlux))
+}
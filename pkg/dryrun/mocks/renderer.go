@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/dryrun/renderer.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	cluster "github.com/aws/eks-anywhere/pkg/cluster"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockEKSAComponentsGenerator is a mock of EKSAComponentsGenerator interface.
+type MockEKSAComponentsGenerator struct {
+	ctrl     *gomock.Controller
+	recorder *MockEKSAComponentsGeneratorMockRecorder
+}
+
+// MockEKSAComponentsGeneratorMockRecorder is the mock recorder for MockEKSAComponentsGenerator.
+type MockEKSAComponentsGeneratorMockRecorder struct {
+	mock *MockEKSAComponentsGenerator
+}
+
+// NewMockEKSAComponentsGenerator creates a new mock instance.
+func NewMockEKSAComponentsGenerator(ctrl *gomock.Controller) *MockEKSAComponentsGenerator {
+	mock := &MockEKSAComponentsGenerator{ctrl: ctrl}
+	mock.recorder = &MockEKSAComponentsGeneratorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEKSAComponentsGenerator) EXPECT() *MockEKSAComponentsGeneratorMockRecorder {
+	return m.recorder
+}
+
+// GenerateManifest mocks base method.
+func (m *MockEKSAComponentsGenerator) GenerateManifest(spec *cluster.Spec) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateManifest", spec)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateManifest indicates an expected call of GenerateManifest.
+func (mr *MockEKSAComponentsGeneratorMockRecorder) GenerateManifest(spec interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateManifest", reflect.TypeOf((*MockEKSAComponentsGenerator)(nil).GenerateManifest), spec)
+}
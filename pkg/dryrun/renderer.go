@@ -0,0 +1,267 @@
+// Package dryrun renders the manifests a cluster operation would apply, so they can be reviewed
+// before running the operation against real infrastructure.
+package dryrun
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"golang.org/x/exp/maps"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	apiyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+	"github.com/aws/eks-anywhere/pkg/clustermanager"
+	"github.com/aws/eks-anywhere/pkg/clustermarshaller"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/manifests"
+	"github.com/aws/eks-anywhere/pkg/networking/cilium"
+	"github.com/aws/eks-anywhere/pkg/networking/kindnetd"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/templater"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const redactMask = "*****"
+
+// Names of the files written by the Renderer.
+const (
+	ControlPlaneFile         = "capi-control-plane.yaml"
+	WorkersFile              = "capi-workers.yaml"
+	MachineHealthChecksFile  = "capi-machine-health-checks.yaml"
+	CNIFile                  = "cni.yaml"
+	EKSAComponentsFile       = "eksa-components.yaml"
+	BundlesFile              = "eksa-bundles.yaml"
+	EKSAClusterResourcesFile = "eksa-cluster.yaml"
+	PackagesFile             = "packages.yaml"
+)
+
+// EKSAComponentsGenerator generates the manifest with the eks-a controller and CRDs.
+type EKSAComponentsGenerator interface {
+	GenerateManifest(spec *cluster.Spec) ([]byte, error)
+}
+
+// CNIGenerator generates the CNI manifest for a cluster.
+type CNIGenerator func(ctx context.Context, spec *cluster.Spec) ([]byte, error)
+
+// CiliumGenerator returns a CNIGenerator that renders the Cilium manifest, allowing traffic
+// to the provider namespaces when the cluster uses a network policy.
+func CiliumGenerator(t *cilium.Templater, provider providers.Provider) CNIGenerator {
+	return func(ctx context.Context, spec *cluster.Spec) ([]byte, error) {
+		return t.GenerateManifest(ctx, spec, cilium.WithPolicyAllowedNamespaces(maps.Keys(provider.GetDeployments())))
+	}
+}
+
+// KindnetdGenerator returns a CNIGenerator that renders the kindnetd manifest.
+func KindnetdGenerator(reader manifests.FileReader) CNIGenerator {
+	return func(_ context.Context, spec *cluster.Spec) ([]byte, error) {
+		return kindnetd.GenerateManifest(reader, spec)
+	}
+}
+
+// Renderer writes to a directory the manifests that creating or upgrading a cluster would apply.
+// The data of Secrets is redacted, so the output can be shared and committed for review.
+// The Cluster API provider components aren't rendered: clusterctl processes them when it installs
+// or upgrades the providers, so only their versions are reviewable, in the Bundles manifest.
+type Renderer struct {
+	provider                providers.Provider
+	cni                     CNIGenerator
+	eksaComponents          EKSAComponentsGenerator
+	writer                  filewriter.FileWriter
+	packagesLocation        string
+	unhealthyMachineTimeout time.Duration
+	nodeStartupTimeout      time.Duration
+}
+
+// RendererOpt allows to customize a Renderer on construction.
+type RendererOpt func(*Renderer)
+
+// WithPackages sets the file with the curated packages to install in the cluster.
+func WithPackages(location string) RendererOpt {
+	return func(r *Renderer) {
+		r.packagesLocation = location
+	}
+}
+
+// WithMachineHealthCheckTimeouts sets the timeouts used in the rendered MachineHealthChecks.
+func WithMachineHealthCheckTimeouts(unhealthyMachineTimeout, nodeStartupTimeout time.Duration) RendererOpt {
+	return func(r *Renderer) {
+		r.unhealthyMachineTimeout = unhealthyMachineTimeout
+		r.nodeStartupTimeout = nodeStartupTimeout
+	}
+}
+
+// NewRenderer constructs a new Renderer that writes the manifests with writer.
+func NewRenderer(provider providers.Provider, cni CNIGenerator, eksaComponents EKSAComponentsGenerator, writer filewriter.FileWriter, opts ...RendererOpt) *Renderer {
+	r := &Renderer{
+		provider:                provider,
+		cni:                     cni,
+		eksaComponents:          eksaComponents,
+		writer:                  writer,
+		unhealthyMachineTimeout: clustermanager.DefaultUnhealthyMachineTimeout,
+		nodeStartupTimeout:      clustermanager.DefaultNodeStartupTimeout,
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// RenderCreate writes the manifests applied when creating a cluster. managementCluster is the cluster
+// that will hold the CAPI objects, the only one the provider is allowed to read from.
+// It returns the paths of the written files.
+func (r *Renderer) RenderCreate(ctx context.Context, managementCluster *types.Cluster, spec *cluster.Spec) ([]string, error) {
+	controlPlane, workers, err := r.provider.GenerateCAPISpecForCreate(ctx, managementCluster, spec)
+	if err != nil {
+		return nil, fmt.Errorf("generating capi spec: %v", err)
+	}
+
+	return r.render(ctx, spec, controlPlane, workers)
+}
+
+// RenderUpgrade writes the manifests applied when upgrading a cluster from currentSpec to newSpec.
+// managementCluster is the cluster holding the CAPI objects, which the provider reads from to
+// decide which machine templates need to be rotated.
+// It returns the paths of the written files.
+func (r *Renderer) RenderUpgrade(ctx context.Context, managementCluster, workloadCluster *types.Cluster, currentSpec, newSpec *cluster.Spec) ([]string, error) {
+	controlPlane, workers, err := r.provider.GenerateCAPISpecForUpgrade(ctx, managementCluster, workloadCluster, currentSpec, newSpec)
+	if err != nil {
+		return nil, fmt.Errorf("generating capi spec: %v", err)
+	}
+
+	return r.render(ctx, newSpec, controlPlane, workers)
+}
+
+func (r *Renderer) render(ctx context.Context, spec *cluster.Spec, controlPlane, workers []byte) ([]string, error) {
+	manifests := []manifest{
+		{name: ControlPlaneFile, content: controlPlane},
+		{name: WorkersFile, content: workers},
+	}
+
+	mhc, err := templater.ObjectsToYaml(clusterapi.MachineHealthCheckObjects(spec, r.unhealthyMachineTimeout, r.nodeStartupTimeout)...)
+	if err != nil {
+		return nil, fmt.Errorf("generating machine health checks: %v", err)
+	}
+	manifests = append(manifests, manifest{name: MachineHealthChecksFile, content: mhc})
+
+	cni, err := r.cni(ctx, spec)
+	if err != nil {
+		return nil, fmt.Errorf("generating cni manifest: %v", err)
+	}
+	manifests = append(manifests, manifest{name: CNIFile, content: cni})
+
+	eksaComponents, err := r.eksaComponents.GenerateManifest(spec)
+	if err != nil {
+		return nil, fmt.Errorf("generating eks-a components manifest: %v", err)
+	}
+	manifests = append(manifests, manifest{name: EKSAComponentsFile, content: eksaComponents})
+
+	bundles, err := yaml.Marshal(spec.Bundles)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling bundles: %v", err)
+	}
+	manifests = append(manifests, manifest{name: BundlesFile, content: bundles})
+
+	resources, err := clustermarshaller.MarshalClusterSpec(spec, r.provider.DatacenterConfig(spec), r.provider.MachineConfigs(spec))
+	if err != nil {
+		return nil, fmt.Errorf("marshalling eks-a cluster resources: %v", err)
+	}
+	manifests = append(manifests, manifest{name: EKSAClusterResourcesFile, content: resources})
+
+	if r.packagesLocation != "" {
+		packages, err := os.ReadFile(r.packagesLocation)
+		if err != nil {
+			return nil, fmt.Errorf("reading packages: %v", err)
+		}
+		manifests = append(manifests, manifest{name: PackagesFile, content: packages})
+	}
+
+	paths := make([]string, 0, len(manifests))
+	for _, m := range manifests {
+		path, err := r.write(m)
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, path)
+	}
+	r.writer.CleanUpTemp()
+
+	return paths, nil
+}
+
+type manifest struct {
+	name    string
+	content []byte
+}
+
+func (r *Renderer) write(m manifest) (string, error) {
+	content, err := redactSecrets(m.content)
+	if err != nil {
+		return "", fmt.Errorf("redacting secrets in %s: %v", m.name, err)
+	}
+
+	path, err := r.writer.Write(m.name, content, filewriter.PersistentFile)
+	if err != nil {
+		return "", err
+	}
+	logger.V(4).Info("Rendered manifest", "path", path)
+
+	return path, nil
+}
+
+// redactSecrets replaces the values of the data and stringData of every Secret in a yaml manifest.
+// Other documents are kept as they are.
+func redactSecrets(manifest []byte) ([]byte, error) {
+	reader := apiyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(manifest)))
+	var docs [][]byte
+	for {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+
+		u := &unstructured.Unstructured{}
+		if err := yaml.Unmarshal(doc, &u.Object); err != nil {
+			return nil, err
+		}
+		if u.GetKind() != "Secret" {
+			docs = append(docs, bytes.TrimSuffix(doc, []byte("\n")))
+			continue
+		}
+
+		for _, field := range []string{"data", "stringData"} {
+			values, ok := u.Object[field].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			for k := range values {
+				values[k] = redactMask
+			}
+		}
+
+		redacted, err := yaml.Marshal(u.Object)
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, bytes.TrimSuffix(redacted, []byte("\n")))
+	}
+
+	return templater.AppendYamlResources(docs...), nil
+}
@@ -0,0 +1,181 @@
+package dryrun_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/dryrun"
+	"github.com/aws/eks-anywhere/pkg/dryrun/mocks"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	providermocks "github.com/aws/eks-anywhere/pkg/providers/mocks"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const controlPlane = `apiVersion: cluster.x-k8s.io/v1beta1
+kind: Cluster
+metadata:
+  name: my-cluster
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: my-cluster-vsphere-credentials
+stringData:
+  password: secret-password
+  username: admin
+`
+
+type rendererTest struct {
+	*WithT
+	ctx               context.Context
+	dir               string
+	writer            filewriter.FileWriter
+	provider          *providermocks.MockProvider
+	eksaComponents    *mocks.MockEKSAComponentsGenerator
+	cni               dryrun.CNIGenerator
+	managementCluster *types.Cluster
+	workloadCluster   *types.Cluster
+	spec              *cluster.Spec
+}
+
+func newRendererTest(t *testing.T) *rendererTest {
+	ctrl := gomock.NewController(t)
+	dir, writer := test.NewWriter(t)
+	return &rendererTest{
+		WithT:          NewWithT(t),
+		ctx:            context.Background(),
+		dir:            dir,
+		writer:         writer,
+		provider:       providermocks.NewMockProvider(ctrl),
+		eksaComponents: mocks.NewMockEKSAComponentsGenerator(ctrl),
+		cni: func(_ context.Context, _ *cluster.Spec) ([]byte, error) {
+			return []byte("kind: DaemonSet\n"), nil
+		},
+		managementCluster: &types.Cluster{Name: "mgmt", KubeconfigFile: "mgmt.kubeconfig"},
+		workloadCluster:   &types.Cluster{Name: "my-cluster", KubeconfigFile: "my-cluster.kubeconfig"},
+		spec: test.NewClusterSpec(func(s *cluster.Spec) {
+			s.Cluster.Name = "my-cluster"
+			s.Cluster.Spec.DatacenterRef = anywherev1.Ref{Kind: anywherev1.DockerDatacenterKind, Name: "my-cluster"}
+			s.Cluster.Spec.WorkerNodeGroupConfigurations = []anywherev1.WorkerNodeGroupConfiguration{{Name: "md-0"}}
+		}),
+	}
+}
+
+func (tt *rendererTest) expectManifests() {
+	tt.provider.EXPECT().DatacenterConfig(tt.spec).Return(&anywherev1.DockerDatacenterConfig{})
+	tt.provider.EXPECT().MachineConfigs(tt.spec).Return([]providers.MachineConfig{})
+	tt.eksaComponents.EXPECT().GenerateManifest(tt.spec).Return([]byte("kind: Deployment\n"), nil)
+}
+
+func (tt *rendererTest) readFile(name string) string {
+	content, err := os.ReadFile(filepath.Join(tt.dir, name))
+	tt.Expect(err).NotTo(HaveOccurred())
+	return string(content)
+}
+
+func TestRendererRenderCreate(t *testing.T) {
+	tt := newRendererTest(t)
+	packages := filepath.Join(t.TempDir(), "packages.yaml")
+	tt.Expect(os.WriteFile(packages, []byte("kind: Package\n"), 0o600)).To(Succeed())
+	r := dryrun.NewRenderer(tt.provider, tt.cni, tt.eksaComponents, tt.writer,
+		dryrun.WithPackages(packages),
+		dryrun.WithMachineHealthCheckTimeouts(time.Minute, 2*time.Minute),
+	)
+
+	tt.provider.EXPECT().GenerateCAPISpecForCreate(tt.ctx, tt.managementCluster, tt.spec).Return([]byte(controlPlane), []byte("kind: MachineDeployment\n"), nil)
+	tt.expectManifests()
+
+	paths, err := r.RenderCreate(tt.ctx, tt.managementCluster, tt.spec)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(paths).To(Equal([]string{
+		filepath.Join(tt.dir, dryrun.ControlPlaneFile),
+		filepath.Join(tt.dir, dryrun.WorkersFile),
+		filepath.Join(tt.dir, dryrun.MachineHealthChecksFile),
+		filepath.Join(tt.dir, dryrun.CNIFile),
+		filepath.Join(tt.dir, dryrun.EKSAComponentsFile),
+		filepath.Join(tt.dir, dryrun.BundlesFile),
+		filepath.Join(tt.dir, dryrun.EKSAClusterResourcesFile),
+		filepath.Join(tt.dir, dryrun.PackagesFile),
+	}))
+
+	tt.Expect(tt.readFile(dryrun.ControlPlaneFile)).To(Equal(`apiVersion: cluster.x-k8s.io/v1beta1
+kind: Cluster
+metadata:
+  name: my-cluster
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: my-cluster-vsphere-credentials
+stringData:
+  password: '*****'
+  username: '*****'
+---
+`))
+	tt.Expect(tt.readFile(dryrun.WorkersFile)).To(Equal("kind: MachineDeployment\n---\n"))
+	tt.Expect(tt.readFile(dryrun.MachineHealthChecksFile)).To(ContainSubstring("name: my-cluster-md-0-worker-unhealthy"))
+	tt.Expect(tt.readFile(dryrun.MachineHealthChecksFile)).To(ContainSubstring("nodeStartupTimeout: 2m0s"))
+	tt.Expect(tt.readFile(dryrun.CNIFile)).To(Equal("kind: DaemonSet\n---\n"))
+	tt.Expect(tt.readFile(dryrun.EKSAClusterResourcesFile)).To(ContainSubstring("kind: DockerDatacenterConfig"))
+	tt.Expect(tt.readFile(dryrun.PackagesFile)).To(Equal("kind: Package\n---\n"))
+}
+
+func TestRendererRenderUpgrade(t *testing.T) {
+	tt := newRendererTest(t)
+	currentSpec := tt.spec.DeepCopy()
+	r := dryrun.NewRenderer(tt.provider, tt.cni, tt.eksaComponents, tt.writer)
+
+	tt.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, tt.managementCluster, tt.workloadCluster, currentSpec, tt.spec).Return([]byte("kind: KubeadmControlPlane\n"), []byte("kind: MachineDeployment\n"), nil)
+	tt.expectManifests()
+
+	paths, err := r.RenderUpgrade(tt.ctx, tt.managementCluster, tt.workloadCluster, currentSpec, tt.spec)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(paths).To(HaveLen(7))
+	tt.Expect(tt.readFile(dryrun.ControlPlaneFile)).To(Equal("kind: KubeadmControlPlane\n---\n"))
+	tt.Expect(tt.readFile(dryrun.MachineHealthChecksFile)).To(ContainSubstring("nodeStartupTimeout: 10m0s"))
+}
+
+func TestRendererRenderCreateCAPIError(t *testing.T) {
+	tt := newRendererTest(t)
+	r := dryrun.NewRenderer(tt.provider, tt.cni, tt.eksaComponents, tt.writer)
+
+	tt.provider.EXPECT().GenerateCAPISpecForCreate(tt.ctx, tt.managementCluster, tt.spec).Return(nil, nil, errors.New("invalid template"))
+
+	_, err := r.RenderCreate(tt.ctx, tt.managementCluster, tt.spec)
+	tt.Expect(err).To(MatchError("generating capi spec: invalid template"))
+}
+
+func TestRendererRenderCreateCNIError(t *testing.T) {
+	tt := newRendererTest(t)
+	cni := func(_ context.Context, _ *cluster.Spec) ([]byte, error) {
+		return nil, errors.New("helm failed")
+	}
+	r := dryrun.NewRenderer(tt.provider, cni, tt.eksaComponents, tt.writer)
+
+	tt.provider.EXPECT().GenerateCAPISpecForCreate(tt.ctx, tt.managementCluster, tt.spec).Return([]byte(controlPlane), nil, nil)
+
+	_, err := r.RenderCreate(tt.ctx, tt.managementCluster, tt.spec)
+	tt.Expect(err).To(MatchError("generating cni manifest: helm failed"))
+}
+
+func TestRendererRenderCreatePackagesError(t *testing.T) {
+	tt := newRendererTest(t)
+	r := dryrun.NewRenderer(tt.provider, tt.cni, tt.eksaComponents, tt.writer, dryrun.WithPackages(filepath.Join(tt.dir, "missing.yaml")))
+
+	tt.provider.EXPECT().GenerateCAPISpecForCreate(tt.ctx, tt.managementCluster, tt.spec).Return([]byte(controlPlane), nil, nil)
+	tt.expectManifests()
+
+	_, err := r.RenderCreate(tt.ctx, tt.managementCluster, tt.spec)
+	tt.Expect(err).To(MatchError(ContainSubstring("reading packages")))
+}
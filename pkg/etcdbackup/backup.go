@@ -0,0 +1,97 @@
+// Package etcdbackup takes etcd snapshots from the nodes of an EKS-A cluster and restores them.
+package etcdbackup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/nodes"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const snapshotTimeFormat = "20060102150405"
+
+// NodeLister lists the nodes running etcd members for a cluster.
+type NodeLister interface {
+	EtcdNodes(ctx context.Context, managementCluster *types.Cluster, spec *cluster.Spec) ([]nodes.Node, error)
+}
+
+// CommandRunner runs shell scripts as root in cluster nodes.
+type CommandRunner interface {
+	Run(ctx context.Context, node nodes.Node, script string) (string, error)
+	RunWithStdin(ctx context.Context, node nodes.Node, in []byte, script string) (string, error)
+}
+
+// Snapshot is an etcd snapshot taken from a cluster node.
+type Snapshot struct {
+	Name     string
+	Node     string
+	Size     int
+	Location string
+}
+
+// Backupper takes etcd snapshots and saves them in a Store.
+type Backupper struct {
+	nodes  NodeLister
+	runner CommandRunner
+	store  Store
+	now    func() time.Time
+}
+
+// NewBackupper returns a new Backupper.
+func NewBackupper(nodes NodeLister, runner CommandRunner, store Store) *Backupper {
+	return &Backupper{
+		nodes:  nodes,
+		runner: runner,
+		store:  store,
+		now:    time.Now,
+	}
+}
+
+// Backup takes a snapshot of the cluster etcd and saves it in the store. Since all members
+// hold the same data, only one snapshot is taken, trying the members in order until one succeeds.
+func (b *Backupper) Backup(ctx context.Context, managementCluster *types.Cluster, spec *cluster.Spec) (*Snapshot, error) {
+	etcdNodes, err := b.nodes.EtcdNodes(ctx, managementCluster, spec)
+	if err != nil {
+		return nil, fmt.Errorf("getting etcd nodes: %v", err)
+	}
+
+	name := SnapshotName(spec.Cluster.Name, b.now())
+	var errs []error
+	for _, node := range etcdNodes {
+		logger.V(3).Info("Taking etcd snapshot", "node", node.Name)
+		data, err := b.runner.Run(ctx, node, snapshotScript(memberFor(node)))
+		if err == nil && len(data) == 0 {
+			err = errors.New("etcd snapshot is empty")
+		}
+		if err != nil {
+			logger.V(3).Info("Failed taking etcd snapshot", "node", node.Name, "error", err)
+			errs = append(errs, err)
+			continue
+		}
+
+		if err := b.store.Save(ctx, name, []byte(data)); err != nil {
+			return nil, fmt.Errorf("saving etcd snapshot: %v", err)
+		}
+
+		return &Snapshot{
+			Name:     name,
+			Node:     node.Name,
+			Size:     len(data),
+			Location: b.store.Location(name),
+		}, nil
+	}
+
+	return nil, fmt.Errorf("taking etcd snapshot: %v", kerrors.NewAggregate(errs))
+}
+
+// SnapshotName returns the name for a snapshot of clusterName taken at t.
+func SnapshotName(clusterName string, t time.Time) string {
+	return fmt.Sprintf("%s-etcd-%s.db", clusterName, t.UTC().Format(snapshotTimeFormat))
+}
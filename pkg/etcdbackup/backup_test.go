@@ -0,0 +1,119 @@
+package etcdbackup_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/etcdbackup"
+	"github.com/aws/eks-anywhere/pkg/etcdbackup/mocks"
+	"github.com/aws/eks-anywhere/pkg/nodes"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+type etcdBackupTest struct {
+	*WithT
+	ctx               context.Context
+	nodes             *mocks.MockNodeLister
+	runner            *mocks.MockCommandRunner
+	store             *mocks.MockStore
+	managementCluster *types.Cluster
+	spec              *cluster.Spec
+	cp1, cp2          nodes.Node
+}
+
+func newEtcdBackupTest(t *testing.T) *etcdBackupTest {
+	ctrl := gomock.NewController(t)
+	return &etcdBackupTest{
+		WithT:             NewWithT(t),
+		ctx:               context.Background(),
+		nodes:             mocks.NewMockNodeLister(ctrl),
+		runner:            mocks.NewMockCommandRunner(ctrl),
+		store:             mocks.NewMockStore(ctrl),
+		managementCluster: &types.Cluster{Name: "mgmt"},
+		spec: test.NewClusterSpec(func(s *cluster.Spec) {
+			s.Cluster.Name = "my-cluster"
+		}),
+		cp1: nodes.Node{Name: "cp-1", Address: "1.2.3.4", Role: nodes.ControlPlane, OSFamily: anywherev1.Ubuntu},
+		cp2: nodes.Node{Name: "cp-2", Address: "1.2.3.5", Role: nodes.ControlPlane, OSFamily: anywherev1.Ubuntu},
+	}
+}
+
+func TestSnapshotName(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(etcdbackup.SnapshotName("my-cluster", time.Date(2023, 5, 4, 3, 2, 1, 0, time.UTC))).To(Equal("my-cluster-etcd-20230504030201.db"))
+}
+
+func TestBackupperBackupSuccess(t *testing.T) {
+	tt := newEtcdBackupTest(t)
+	b := etcdbackup.NewBackupper(tt.nodes, tt.runner, tt.store)
+
+	tt.nodes.EXPECT().EtcdNodes(tt.ctx, tt.managementCluster, tt.spec).Return([]nodes.Node{tt.cp1, tt.cp2}, nil)
+	tt.runner.EXPECT().Run(tt.ctx, tt.cp1, gomock.Any()).Return("", errors.New("unreachable"))
+	tt.runner.EXPECT().Run(tt.ctx, tt.cp2, gomock.Any()).Return("snapshot", nil)
+	tt.store.EXPECT().Save(tt.ctx, gomock.Any(), []byte("snapshot")).Return(nil)
+	tt.store.EXPECT().Location(gomock.Any()).Return("backups/my-cluster.db")
+
+	got, err := b.Backup(tt.ctx, tt.managementCluster, tt.spec)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(got.Name).To(HavePrefix("my-cluster-etcd-"))
+	tt.Expect(got.Node).To(Equal("cp-2"))
+	tt.Expect(got.Size).To(Equal(8))
+	tt.Expect(got.Location).To(Equal("backups/my-cluster.db"))
+}
+
+func TestBackupperBackupStackedUbuntuCommand(t *testing.T) {
+	tt := newEtcdBackupTest(t)
+	b := etcdbackup.NewBackupper(tt.nodes, tt.runner, tt.store)
+
+	tt.nodes.EXPECT().EtcdNodes(tt.ctx, tt.managementCluster, tt.spec).Return([]nodes.Node{tt.cp1}, nil)
+	tt.runner.EXPECT().Run(tt.ctx, tt.cp1, "set -e; crictl exec $(crictl ps -q --name '^etcd$') etcdctl --endpoints=https://127.0.0.1:2379 "+
+		"--cacert=/etc/kubernetes/pki/etcd/ca.crt --cert=/etc/kubernetes/pki/etcd/server.crt --key=/etc/kubernetes/pki/etcd/server.key "+
+		"snapshot save /var/lib/etcd/eksa-snapshot.db >&2; cat /var/lib/etcd/eksa-snapshot.db; rm -f /var/lib/etcd/eksa-snapshot.db").Return("snapshot", nil)
+	tt.store.EXPECT().Save(tt.ctx, gomock.Any(), []byte("snapshot")).Return(nil)
+	tt.store.EXPECT().Location(gomock.Any())
+
+	_, err := b.Backup(tt.ctx, tt.managementCluster, tt.spec)
+	tt.Expect(err).NotTo(HaveOccurred())
+}
+
+func TestBackupperBackupAllNodesFail(t *testing.T) {
+	tt := newEtcdBackupTest(t)
+	b := etcdbackup.NewBackupper(tt.nodes, tt.runner, tt.store)
+
+	tt.nodes.EXPECT().EtcdNodes(tt.ctx, tt.managementCluster, tt.spec).Return([]nodes.Node{tt.cp1, tt.cp2}, nil)
+	tt.runner.EXPECT().Run(tt.ctx, tt.cp1, gomock.Any()).Return("", errors.New("unreachable"))
+	tt.runner.EXPECT().Run(tt.ctx, tt.cp2, gomock.Any()).Return("", nil)
+
+	_, err := b.Backup(tt.ctx, tt.managementCluster, tt.spec)
+	tt.Expect(err).To(MatchError(ContainSubstring("taking etcd snapshot: [unreachable, etcd snapshot is empty]")))
+}
+
+func TestBackupperBackupSaveError(t *testing.T) {
+	tt := newEtcdBackupTest(t)
+	b := etcdbackup.NewBackupper(tt.nodes, tt.runner, tt.store)
+
+	tt.nodes.EXPECT().EtcdNodes(tt.ctx, tt.managementCluster, tt.spec).Return([]nodes.Node{tt.cp1}, nil)
+	tt.runner.EXPECT().Run(tt.ctx, tt.cp1, gomock.Any()).Return("snapshot", nil)
+	tt.store.EXPECT().Save(tt.ctx, gomock.Any(), []byte("snapshot")).Return(errors.New("disk full"))
+
+	_, err := b.Backup(tt.ctx, tt.managementCluster, tt.spec)
+	tt.Expect(err).To(MatchError("saving etcd snapshot: disk full"))
+}
+
+func TestBackupperBackupNodesError(t *testing.T) {
+	tt := newEtcdBackupTest(t)
+	b := etcdbackup.NewBackupper(tt.nodes, tt.runner, tt.store)
+
+	tt.nodes.EXPECT().EtcdNodes(tt.ctx, tt.managementCluster, tt.spec).Return(nil, errors.New("no machines"))
+
+	_, err := b.Backup(tt.ctx, tt.managementCluster, tt.spec)
+	tt.Expect(err).To(MatchError("getting etcd nodes: no machines"))
+}
@@ -0,0 +1,208 @@
+package etcdbackup
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
+)
+
+const (
+	// CronJobName is the name of the CronJob that takes the scheduled etcd snapshots in the workload cluster.
+	CronJobName = "eksa-etcd-backup"
+	// CronJobNamespace is the namespace of the scheduled etcd backup CronJob.
+	CronJobNamespace = constants.KubeSystemNamespace
+	// ClusterLabel is set in the scheduled backup Jobs with the name of the cluster they back up.
+	ClusterLabel = "anywhere.eks.amazonaws.com/etcd-backup"
+
+	defaultCertificatesDir  = "/etc/kubernetes/pki"
+	stackedEtcdEndpoint     = "https://127.0.0.1:2379"
+	backupsMountPath        = "/backups"
+	partialSnapshotName     = ".eksa-etcd-snapshot.db.part"
+	snapshotContainerName   = "snapshot"
+	retentionContainerName  = "retention"
+	controlPlaneNodeRoleKey = "node-role.kubernetes.io/control-plane"
+)
+
+// etcdClientConfig holds the endpoint and client certificates the kube-apiserver uses to talk to etcd.
+type etcdClientConfig struct {
+	endpoint        string
+	caFile          string
+	certFile        string
+	keyFile         string
+	certificatesDir string
+}
+
+// CronJob builds the CronJob that takes scheduled etcd snapshots following the cluster BackupConfiguration.
+// The Job runs in a control plane node, using the same etcd client certificates as the kube-apiserver,
+// and stores the snapshots in a host path of that node, keeping only the configured number of copies.
+// On success, the size in bytes of the new snapshot is reported as the termination message of the
+// retention container.
+func CronJob(spec *cluster.Spec, kcp *controlplanev1.KubeadmControlPlane) (*batchv1.CronJob, error) {
+	backup := spec.Cluster.Spec.BackupConfiguration
+	if backup == nil {
+		return nil, fmt.Errorf("cluster %s doesn't have a backup configuration", spec.Cluster.Name)
+	}
+
+	etcd, err := etcdClientConfigFor(kcp)
+	if err != nil {
+		return nil, err
+	}
+
+	labels := map[string]string{ClusterLabel: spec.Cluster.Name}
+	hostPathDirectoryOrCreate := corev1.HostPathDirectoryOrCreate
+	hostPathDirectory := corev1.HostPathDirectory
+
+	return &batchv1.CronJob{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: batchv1.SchemeGroupVersion.String(),
+			Kind:       "CronJob",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      CronJobName,
+			Namespace: CronJobNamespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.CronJobSpec{
+			Schedule:                   backup.Schedule,
+			ConcurrencyPolicy:          batchv1.ForbidConcurrent,
+			SuccessfulJobsHistoryLimit: ptr.Int32(1),
+			FailedJobsHistoryLimit:     ptr.Int32(1),
+			JobTemplate: batchv1.JobTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: batchv1.JobSpec{
+					BackoffLimit: ptr.Int32(0),
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels: labels,
+						},
+						Spec: corev1.PodSpec{
+							HostNetwork:   true,
+							RestartPolicy: corev1.RestartPolicyNever,
+							NodeSelector: map[string]string{
+								controlPlaneNodeRoleKey: "",
+							},
+							Tolerations: []corev1.Toleration{
+								{
+									Key:    controlPlaneNodeRoleKey,
+									Effect: corev1.TaintEffectNoSchedule,
+								},
+								{
+									Key:    "node-role.kubernetes.io/master",
+									Effect: corev1.TaintEffectNoSchedule,
+								},
+							},
+							SecurityContext: &corev1.PodSecurityContext{
+								RunAsUser: ptr.Int64(0),
+							},
+							InitContainers: []corev1.Container{
+								{
+									Name:  snapshotContainerName,
+									Image: spec.VersionsBundle.KubeDistro.EtcdImage.VersionedImage(),
+									Command: []string{
+										"etcdctl",
+										"--endpoints=" + etcd.endpoint,
+										"--cacert=" + etcd.caFile,
+										"--cert=" + etcd.certFile,
+										"--key=" + etcd.keyFile,
+										"snapshot", "save", path.Join(backupsMountPath, partialSnapshotName),
+									},
+									Env: []corev1.EnvVar{
+										{Name: "ETCDCTL_API", Value: "3"},
+									},
+									VolumeMounts: []corev1.VolumeMount{
+										{Name: "backups", MountPath: backupsMountPath},
+										{Name: "certs", MountPath: etcd.certificatesDir, ReadOnly: true},
+									},
+								},
+							},
+							Containers: []corev1.Container{
+								{
+									Name:    retentionContainerName,
+									Image:   spec.VersionsBundle.Eksa.CliTools.VersionedImage(),
+									Command: []string{"bash", "-c", retentionScript},
+									Env: []corev1.EnvVar{
+										{Name: "CLUSTER_NAME", Value: spec.Cluster.Name},
+										{Name: "RETENTION_COUNT", Value: strconv.Itoa(backup.RetentionCount)},
+									},
+									TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+									VolumeMounts: []corev1.VolumeMount{
+										{Name: "backups", MountPath: backupsMountPath},
+									},
+								},
+							},
+							Volumes: []corev1.Volume{
+								{
+									Name: "backups",
+									VolumeSource: corev1.VolumeSource{
+										HostPath: &corev1.HostPathVolumeSource{
+											Path: backup.HostPath,
+											Type: &hostPathDirectoryOrCreate,
+										},
+									},
+								},
+								{
+									Name: "certs",
+									VolumeSource: corev1.VolumeSource{
+										HostPath: &corev1.HostPathVolumeSource{
+											Path: etcd.certificatesDir,
+											Type: &hostPathDirectory,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+// retentionScript names the new snapshot after the cluster and the current time, following the
+// same format as SnapshotName, and removes the oldest snapshots over the retention count.
+const retentionScript = `set -euo pipefail
+name="${CLUSTER_NAME}-etcd-$(date -u +%Y%m%d%H%M%S).db"
+mv "` + backupsMountPath + `/` + partialSnapshotName + `" "` + backupsMountPath + `/${name}"
+ls -1t ` + backupsMountPath + `/"${CLUSTER_NAME}"-etcd-*.db | tail -n +$((RETENTION_COUNT + 1)) | xargs -r rm -f
+stat -c %s "` + backupsMountPath + `/${name}" > /dev/termination-log
+`
+
+// etcdClientConfigFor reads the etcd endpoint and client certificates from the kubeadm
+// cluster configuration. With an external etcd, snapshots are taken from its first member,
+// since etcdctl can only request a snapshot to one endpoint.
+func etcdClientConfigFor(kcp *controlplanev1.KubeadmControlPlane) (*etcdClientConfig, error) {
+	c := &etcdClientConfig{certificatesDir: defaultCertificatesDir}
+	clusterConfig := kcp.Spec.KubeadmConfigSpec.ClusterConfiguration
+	if clusterConfig != nil && clusterConfig.CertificatesDir != "" {
+		c.certificatesDir = clusterConfig.CertificatesDir
+	}
+
+	if clusterConfig != nil && clusterConfig.Etcd.External != nil {
+		external := clusterConfig.Etcd.External
+		if len(external.Endpoints) == 0 {
+			return nil, fmt.Errorf("kubeadm control plane %s doesn't have external etcd endpoints", kcp.Name)
+		}
+		c.endpoint = external.Endpoints[0]
+		c.caFile = external.CAFile
+		c.certFile = external.CertFile
+		c.keyFile = external.KeyFile
+		return c, nil
+	}
+
+	c.endpoint = stackedEtcdEndpoint
+	c.caFile = path.Join(c.certificatesDir, "etcd", "ca.crt")
+	c.certFile = path.Join(c.certificatesDir, "apiserver-etcd-client.crt")
+	c.keyFile = path.Join(c.certificatesDir, "apiserver-etcd-client.key")
+	return c, nil
+}
@@ -0,0 +1,135 @@
+package etcdbackup_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/etcdbackup"
+	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+func cronJobSpec() *cluster.Spec {
+	return test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = "my-cluster"
+		s.Cluster.Spec.BackupConfiguration = &anywherev1.BackupConfiguration{
+			Schedule:       "0 */6 * * *",
+			RetentionCount: 3,
+			HostPath:       "/var/lib/etcd-backups",
+		}
+		s.VersionsBundle.KubeDistro.EtcdImage = releasev1.Image{URI: "public.ecr.aws/eks-distro/etcd-io/etcd:v3.5.6"}
+		s.VersionsBundle.Eksa.CliTools = releasev1.Image{URI: "public.ecr.aws/eks-anywhere/cli-tools:v0.15.0"}
+	})
+}
+
+func TestCronJobStackedEtcd(t *testing.T) {
+	g := NewWithT(t)
+	kcp := &controlplanev1.KubeadmControlPlane{
+		Spec: controlplanev1.KubeadmControlPlaneSpec{
+			KubeadmConfigSpec: bootstrapv1.KubeadmConfigSpec{
+				ClusterConfiguration: &bootstrapv1.ClusterConfiguration{},
+			},
+		},
+	}
+
+	cronJob, err := etcdbackup.CronJob(cronJobSpec(), kcp)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(cronJob.Name).To(Equal("eksa-etcd-backup"))
+	g.Expect(cronJob.Namespace).To(Equal("kube-system"))
+	g.Expect(cronJob.Spec.Schedule).To(Equal("0 */6 * * *"))
+	g.Expect(cronJob.Spec.ConcurrencyPolicy).To(Equal(batchv1.ForbidConcurrent))
+	g.Expect(cronJob.Spec.JobTemplate.Labels).To(HaveKeyWithValue(etcdbackup.ClusterLabel, "my-cluster"))
+
+	pod := cronJob.Spec.JobTemplate.Spec.Template.Spec
+	g.Expect(pod.HostNetwork).To(BeTrue())
+	g.Expect(pod.NodeSelector).To(HaveKey("node-role.kubernetes.io/control-plane"))
+	g.Expect(pod.InitContainers).To(HaveLen(1))
+	g.Expect(pod.InitContainers[0].Image).To(Equal("public.ecr.aws/eks-distro/etcd-io/etcd:v3.5.6"))
+	g.Expect(pod.InitContainers[0].Command).To(Equal([]string{
+		"etcdctl",
+		"--endpoints=https://127.0.0.1:2379",
+		"--cacert=/etc/kubernetes/pki/etcd/ca.crt",
+		"--cert=/etc/kubernetes/pki/apiserver-etcd-client.crt",
+		"--key=/etc/kubernetes/pki/apiserver-etcd-client.key",
+		"snapshot", "save", "/backups/.eksa-etcd-snapshot.db.part",
+	}))
+	g.Expect(pod.Containers).To(HaveLen(1))
+	g.Expect(pod.Containers[0].Image).To(Equal("public.ecr.aws/eks-anywhere/cli-tools:v0.15.0"))
+	g.Expect(pod.Containers[0].Env).To(ContainElements(
+		corev1.EnvVar{Name: "CLUSTER_NAME", Value: "my-cluster"},
+		corev1.EnvVar{Name: "RETENTION_COUNT", Value: "3"},
+	))
+	g.Expect(pod.Volumes).To(HaveLen(2))
+	g.Expect(pod.Volumes[0].HostPath.Path).To(Equal("/var/lib/etcd-backups"))
+	g.Expect(pod.Volumes[1].HostPath.Path).To(Equal("/etc/kubernetes/pki"))
+}
+
+func TestCronJobExternalEtcd(t *testing.T) {
+	g := NewWithT(t)
+	kcp := &controlplanev1.KubeadmControlPlane{
+		Spec: controlplanev1.KubeadmControlPlaneSpec{
+			KubeadmConfigSpec: bootstrapv1.KubeadmConfigSpec{
+				ClusterConfiguration: &bootstrapv1.ClusterConfiguration{
+					CertificatesDir: "/var/lib/kubeadm/pki",
+					Etcd: bootstrapv1.Etcd{
+						External: &bootstrapv1.ExternalEtcd{
+							Endpoints: []string{"https://10.0.0.1:2379", "https://10.0.0.2:2379"},
+							CAFile:    "/var/lib/kubeadm/pki/etcd/ca.crt",
+							CertFile:  "/var/lib/kubeadm/pki/server-etcd-client.crt",
+							KeyFile:   "/var/lib/kubeadm/pki/apiserver-etcd-client.key",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	cronJob, err := etcdbackup.CronJob(cronJobSpec(), kcp)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	pod := cronJob.Spec.JobTemplate.Spec.Template.Spec
+	g.Expect(pod.InitContainers[0].Command).To(Equal([]string{
+		"etcdctl",
+		"--endpoints=https://10.0.0.1:2379",
+		"--cacert=/var/lib/kubeadm/pki/etcd/ca.crt",
+		"--cert=/var/lib/kubeadm/pki/server-etcd-client.crt",
+		"--key=/var/lib/kubeadm/pki/apiserver-etcd-client.key",
+		"snapshot", "save", "/backups/.eksa-etcd-snapshot.db.part",
+	}))
+	g.Expect(pod.Volumes[1].HostPath.Path).To(Equal("/var/lib/kubeadm/pki"))
+}
+
+func TestCronJobExternalEtcdNoEndpoints(t *testing.T) {
+	g := NewWithT(t)
+	kcp := &controlplanev1.KubeadmControlPlane{
+		Spec: controlplanev1.KubeadmControlPlaneSpec{
+			KubeadmConfigSpec: bootstrapv1.KubeadmConfigSpec{
+				ClusterConfiguration: &bootstrapv1.ClusterConfiguration{
+					Etcd: bootstrapv1.Etcd{
+						External: &bootstrapv1.ExternalEtcd{},
+					},
+				},
+			},
+		},
+	}
+	kcp.Name = "my-cluster"
+
+	_, err := etcdbackup.CronJob(cronJobSpec(), kcp)
+	g.Expect(err).To(MatchError(ContainSubstring("kubeadm control plane my-cluster doesn't have external etcd endpoints")))
+}
+
+func TestCronJobNoBackupConfiguration(t *testing.T) {
+	g := NewWithT(t)
+	spec := cronJobSpec()
+	spec.Cluster.Spec.BackupConfiguration = nil
+
+	_, err := etcdbackup.CronJob(spec, &controlplanev1.KubeadmControlPlane{})
+	g.Expect(err).To(MatchError(ContainSubstring("cluster my-cluster doesn't have a backup configuration")))
+}
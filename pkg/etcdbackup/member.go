@@ -0,0 +1,100 @@
+package etcdbackup
+
+import (
+	"fmt"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/nodes"
+)
+
+const (
+	etcdDataDir         = "/var/lib/etcd"
+	snapshotPath        = etcdDataDir + "/eksa-snapshot.db"
+	restoreSnapshotPath = etcdDataDir + "/eksa-restore.db"
+	restoreDataDir      = etcdDataDir + "/eksa-restore"
+	memberDir           = etcdDataDir + "/member"
+	backupMemberDir     = etcdDataDir + "/member.eksa-backup"
+
+	etcdPeerPort        = 2380
+	initialClusterToken = "etcd-cluster"
+
+	kubeadmEtcdManifest        = "/etc/kubernetes/manifests/etcd.yaml"
+	kubeadmEtcdManifestStashed = "/etc/kubernetes/etcd.yaml.eksa-restore"
+)
+
+// etcdMember holds the shell snippets needed to operate the etcd member running in a node.
+// Stacked members run as a kubeadm static pod, external members are managed by etcdadm.
+type etcdMember struct {
+	// etcdctl invokes etcdctl with client certificates for the local member.
+	etcdctl string
+	// stop and start stop and start the local member. They are empty when
+	// restoring is not supported for the node.
+	stop  string
+	start string
+}
+
+func memberFor(node nodes.Node) etcdMember {
+	switch {
+	case node.Role == nodes.Etcd && node.OSFamily == anywherev1.Bottlerocket:
+		return etcdMember{
+			etcdctl: ctrEtcdctl("/var/lib/etcd/pki/ca.crt", "/var/lib/etcd/pki/server.crt", "/var/lib/etcd/pki/server.key"),
+		}
+	case node.Role == nodes.Etcd:
+		return etcdMember{
+			etcdctl: etcdctlWithCerts("ETCDCTL_API=3 /opt/bin/etcdctl", "/etc/etcd/pki/ca.crt", "/etc/etcd/pki/etcdctl-etcd-client.crt", "/etc/etcd/pki/etcdctl-etcd-client.key"),
+			stop:    "systemctl stop etcd",
+			start:   "systemctl start etcd",
+		}
+	case node.OSFamily == anywherev1.Bottlerocket:
+		return etcdMember{
+			etcdctl: ctrEtcdctl("/var/lib/kubeadm/pki/etcd/ca.crt", "/var/lib/kubeadm/pki/etcd/server.crt", "/var/lib/kubeadm/pki/etcd/server.key"),
+		}
+	default:
+		return etcdMember{
+			etcdctl: etcdctlWithCerts("crictl exec $(crictl ps -q --name '^etcd$') etcdctl", "/etc/kubernetes/pki/etcd/ca.crt", "/etc/kubernetes/pki/etcd/server.crt", "/etc/kubernetes/pki/etcd/server.key"),
+			stop:    fmt.Sprintf("mv %s %s && while crictl ps -q --name '^etcd$' | grep -q .; do sleep 2; done", kubeadmEtcdManifest, kubeadmEtcdManifestStashed),
+			start:   fmt.Sprintf("mv %s %s", kubeadmEtcdManifestStashed, kubeadmEtcdManifest),
+		}
+	}
+}
+
+func (m etcdMember) supportsRestore() bool {
+	return m.stop != "" && m.start != ""
+}
+
+// ctrEtcdctl runs etcdctl inside the etcd container. Bottlerocket hosts don't ship crictl,
+// so the container is looked up directly in containerd.
+func ctrEtcdctl(cacert, cert, key string) string {
+	container := "$(ctr -n k8s.io c ls | awk '/etcd-io\\/etcd/ {print $1}' | tail -1)"
+	return etcdctlWithCerts(fmt.Sprintf("ctr -n k8s.io t exec --exec-id eksa-etcdctl %s etcdctl", container), cacert, cert, key)
+}
+
+func etcdctlWithCerts(etcdctl, cacert, cert, key string) string {
+	return fmt.Sprintf("%s --endpoints=https://127.0.0.1:2379 --cacert=%s --cert=%s --key=%s", etcdctl, cacert, cert, key)
+}
+
+func snapshotScript(m etcdMember) string {
+	return fmt.Sprintf("set -e; %s snapshot save %s >&2; cat %s; rm -f %s", m.etcdctl, snapshotPath, snapshotPath, snapshotPath)
+}
+
+func uploadScript() string {
+	return fmt.Sprintf("cat > %s", restoreSnapshotPath)
+}
+
+func restoreScript(m etcdMember, name, peerURL, initialCluster string) string {
+	return fmt.Sprintf(
+		"set -e; rm -rf %s; %s snapshot restore %s --name %s --initial-cluster %s --initial-cluster-token %s --initial-advertise-peer-urls %s --data-dir %s >&2",
+		restoreDataDir, m.etcdctl, restoreSnapshotPath, name, initialCluster, initialClusterToken, peerURL, restoreDataDir,
+	)
+}
+
+func swapDataDirScript() string {
+	return fmt.Sprintf(
+		"set -e; rm -rf %s; mv %s %s; mv %s/member %s; rm -rf %s %s",
+		backupMemberDir, memberDir, backupMemberDir, restoreDataDir, memberDir, restoreDataDir, restoreSnapshotPath,
+	)
+}
+
+func peerURL(node nodes.Node) string {
+	return fmt.Sprintf("https://%s:%d", node.Address, etcdPeerPort)
+}
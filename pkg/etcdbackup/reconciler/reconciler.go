@@ -0,0 +1,272 @@
+package reconciler
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	anywhereCluster "github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/controller"
+	"github.com/aws/eks-anywhere/pkg/controller/clientutil"
+	"github.com/aws/eks-anywhere/pkg/controller/clusters"
+	"github.com/aws/eks-anywhere/pkg/controller/serverside"
+	"github.com/aws/eks-anywhere/pkg/etcdbackup"
+)
+
+// RemoteClientRegistry defines methods for remote cluster controller clients.
+type RemoteClientRegistry interface {
+	GetClient(ctx context.Context, cluster client.ObjectKey) (client.Client, error)
+}
+
+// Reconciler reconciles the scheduled etcd backups defined in a cluster BackupConfiguration.
+type Reconciler struct {
+	client               client.Client
+	remoteClientRegistry RemoteClientRegistry
+}
+
+// New returns a new Reconciler.
+func New(client client.Client, remoteClientRegistry RemoteClientRegistry) *Reconciler {
+	return &Reconciler{
+		client:               client,
+		remoteClientRegistry: remoteClientRegistry,
+	}
+}
+
+// Reconcile applies the etcd backup CronJob to the cluster when it has a BackupConfiguration
+// and removes it if the configuration has been removed.
+// It uses a controller.Result to indicate when requeues are needed.
+func (r *Reconciler) Reconcile(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster) (controller.Result, error) {
+	if cluster.Spec.BackupConfiguration == nil {
+		return controller.Result{}, r.reconcileDisabled(ctx, log, cluster)
+	}
+
+	clusterSpec, err := anywhereCluster.BuildSpec(ctx, clientutil.NewKubeClient(r.client), cluster)
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	result, err := clusters.CheckControlPlaneReady(ctx, r.client, log, cluster)
+	if err != nil {
+		return controller.Result{}, errors.Wrap(err, "checking controlplane ready")
+	}
+	if result.Return() {
+		return result, nil
+	}
+
+	kcp, err := controller.KubeadmControlPlane(ctx, r.client, cluster)
+	if err != nil {
+		return controller.Result{}, errors.Wrap(err, "getting kubeadmcontrolplane")
+	}
+
+	cronJob, err := etcdbackup.CronJob(clusterSpec, kcp)
+	if err != nil {
+		return controller.Result{}, errors.Wrap(err, "generating etcd backup cronjob")
+	}
+
+	rClient, err := r.remoteClientRegistry.GetClient(ctx, controller.CapiClusterObjectKey(cluster))
+	if err != nil {
+		return controller.Result{}, errors.Wrap(err, "getting workload cluster's client to reconcile etcd backups")
+	}
+
+	log.Info("Applying etcd backup cronjob", "schedule", cluster.Spec.BackupConfiguration.Schedule)
+	if err := serverside.ReconcileObjects(ctx, rClient, []client.Object{cronJob}); err != nil {
+		return controller.Result{}, errors.Wrap(err, "applying etcd backup cronjob")
+	}
+
+	return controller.Result{}, nil
+}
+
+// reconcileDisabled deletes the etcd backup CronJob and clears the backup status if backups were
+// previously configured for the cluster. The EtcdBackupReady condition is used to track this.
+func (r *Reconciler) reconcileDisabled(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster) error {
+	if !conditions.Has(cluster, anywherev1.EtcdBackupReadyCondition) {
+		return nil
+	}
+
+	rClient, err := r.remoteClientRegistry.GetClient(ctx, controller.CapiClusterObjectKey(cluster))
+	if err != nil {
+		return errors.Wrap(err, "getting workload cluster's client to remove etcd backups")
+	}
+
+	cronJob := &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      etcdbackup.CronJobName,
+			Namespace: etcdbackup.CronJobNamespace,
+		},
+	}
+
+	log.Info("Deleting etcd backup cronjob")
+	err = rClient.Delete(ctx, cronJob, client.PropagationPolicy(metav1.DeletePropagationBackground))
+	if err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrap(err, "deleting etcd backup cronjob")
+	}
+
+	conditions.Delete(cluster, anywherev1.EtcdBackupReadyCondition)
+	cluster.Status.EtcdBackup = nil
+
+	return nil
+}
+
+// UpdateStatus checks the latest etcd backup Jobs in the cluster and updates the EtcdBackupReady
+// condition and the backup status with the time and size of the latest successful snapshot.
+func (r *Reconciler) UpdateStatus(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster) error {
+	if cluster.Spec.BackupConfiguration == nil {
+		return nil
+	}
+
+	// While the control plane is not ready, the status of previous backups is kept as is.
+	if !conditions.IsTrue(cluster, anywherev1.ControlPlaneReadyCondition) {
+		if !conditions.Has(cluster, anywherev1.EtcdBackupReadyCondition) {
+			conditions.MarkFalse(cluster, anywherev1.EtcdBackupReadyCondition, anywherev1.ControlPlaneNotReadyReason, clusterv1.ConditionSeverityInfo, "")
+		}
+		return nil
+	}
+
+	rClient, err := r.remoteClientRegistry.GetClient(ctx, controller.CapiClusterObjectKey(cluster))
+	if err != nil {
+		return errors.Wrap(err, "getting workload cluster's client to update etcd backup status")
+	}
+
+	jobs := &batchv1.JobList{}
+	if err := rClient.List(ctx, jobs, client.InNamespace(etcdbackup.CronJobNamespace), client.MatchingLabels{etcdbackup.ClusterLabel: cluster.Name}); err != nil {
+		return errors.Wrap(err, "listing etcd backup jobs")
+	}
+
+	lastFinished, lastSucceeded := latestFinishedJobs(jobs.Items)
+	if lastSucceeded != nil && !backupStatusIsFor(cluster.Status.EtcdBackup, lastSucceeded) {
+		status, err := backupStatusFor(ctx, rClient, lastSucceeded)
+		if err != nil {
+			return err
+		}
+		log.Info("Found new etcd backup", "job", lastSucceeded.Name, "size", status.LastSuccessfulBackupSize)
+		cluster.Status.EtcdBackup = status
+	}
+
+	if lastFinished != nil && lastFinished != lastSucceeded {
+		conditions.MarkFalse(cluster, anywherev1.EtcdBackupReadyCondition, anywherev1.EtcdBackupFailedReason, clusterv1.ConditionSeverityWarning, "Backup job %s failed: %s", lastFinished.Name, jobFailureMessage(lastFinished))
+		return nil
+	}
+
+	if cluster.Status.EtcdBackup == nil {
+		conditions.MarkFalse(cluster, anywherev1.EtcdBackupReadyCondition, anywherev1.EtcdBackupPendingReason, clusterv1.ConditionSeverityInfo, "Waiting for the first scheduled backup")
+		return nil
+	}
+
+	ready := conditions.TrueCondition(anywherev1.EtcdBackupReadyCondition)
+	ready.Message = fmt.Sprintf("Last successful backup at %s, %d bytes",
+		cluster.Status.EtcdBackup.LastSuccessfulBackupTime.UTC().Format(time.RFC3339),
+		cluster.Status.EtcdBackup.LastSuccessfulBackupSize,
+	)
+	conditions.Set(cluster, ready)
+
+	return nil
+}
+
+// latestFinishedJobs returns the most recent finished Job, whether it succeeded or failed, and
+// the most recent successful one.
+func latestFinishedJobs(jobs []batchv1.Job) (lastFinished, lastSucceeded *batchv1.Job) {
+	var lastFinishedTime, lastSucceededTime time.Time
+	for i := range jobs {
+		job := &jobs[i]
+		finishedAt, succeeded, finished := jobFinishTime(job)
+		if !finished {
+			continue
+		}
+
+		if finishedAt.After(lastFinishedTime) {
+			lastFinished, lastFinishedTime = job, finishedAt
+		}
+
+		if succeeded && finishedAt.After(lastSucceededTime) {
+			lastSucceeded, lastSucceededTime = job, finishedAt
+		}
+	}
+
+	return lastFinished, lastSucceeded
+}
+
+func jobFinishTime(job *batchv1.Job) (finishedAt time.Time, succeeded, finished bool) {
+	for _, c := range job.Status.Conditions {
+		if c.Status != corev1.ConditionTrue {
+			continue
+		}
+
+		switch c.Type {
+		case batchv1.JobComplete:
+			if job.Status.CompletionTime != nil {
+				return job.Status.CompletionTime.Time, true, true
+			}
+			return c.LastTransitionTime.Time, true, true
+		case batchv1.JobFailed:
+			return c.LastTransitionTime.Time, false, true
+		}
+	}
+
+	return time.Time{}, false, false
+}
+
+func jobFailureMessage(job *batchv1.Job) string {
+	for _, c := range job.Status.Conditions {
+		if c.Type == batchv1.JobFailed && c.Status == corev1.ConditionTrue {
+			return c.Message
+		}
+	}
+
+	return ""
+}
+
+func backupStatusIsFor(status *anywherev1.EtcdBackupStatus, job *batchv1.Job) bool {
+	if status == nil || status.LastSuccessfulBackupTime == nil {
+		return false
+	}
+
+	finishedAt, _, _ := jobFinishTime(job)
+	return status.LastSuccessfulBackupTime.Time.Equal(finishedAt)
+}
+
+// backupStatusFor reads the snapshot size from the termination message of the Job's retention container.
+func backupStatusFor(ctx context.Context, c client.Client, job *batchv1.Job) (*anywherev1.EtcdBackupStatus, error) {
+	pods := &corev1.PodList{}
+	if err := c.List(ctx, pods, client.InNamespace(job.Namespace), client.MatchingLabels{"job-name": job.Name}); err != nil {
+		return nil, errors.Wrapf(err, "listing pods for etcd backup job %s", job.Name)
+	}
+
+	finishedAt, _, _ := jobFinishTime(job)
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != corev1.PodSucceeded {
+			continue
+		}
+
+		for _, s := range pod.Status.ContainerStatuses {
+			if s.State.Terminated == nil {
+				continue
+			}
+
+			size, err := strconv.ParseInt(strings.TrimSpace(s.State.Terminated.Message), 10, 64)
+			if err != nil {
+				return nil, errors.Wrapf(err, "reading snapshot size from etcd backup pod %s", pod.Name)
+			}
+
+			return &anywherev1.EtcdBackupStatus{
+				LastSuccessfulBackupTime: &metav1.Time{Time: finishedAt},
+				LastSuccessfulBackupSize: size,
+				LastSuccessfulBackupNode: pod.Spec.NodeName,
+			}, nil
+		}
+	}
+
+	return nil, errors.Errorf("no succeeded pod found for etcd backup job %s", job.Name)
+}
@@ -0,0 +1,369 @@
+package reconciler_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	eksdv1 "github.com/aws/eks-distro-build-tooling/release/api/v1alpha1"
+	"github.com/go-logr/logr"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/controller"
+	"github.com/aws/eks-anywhere/pkg/etcdbackup"
+	"github.com/aws/eks-anywhere/pkg/etcdbackup/reconciler"
+	"github.com/aws/eks-anywhere/pkg/etcdbackup/reconciler/mocks"
+	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+type reconcilerTest struct {
+	*WithT
+	ctx            context.Context
+	remoteClients  *mocks.MockRemoteClientRegistry
+	cluster        *anywherev1.Cluster
+	managementObjs []runtime.Object
+}
+
+func newReconcilerTest(t *testing.T) *reconcilerTest {
+	ctrl := gomock.NewController(t)
+	bundle := test.Bundle()
+	cluster := &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-cluster",
+			Namespace: "eksa-system",
+		},
+		Spec: anywherev1.ClusterSpec{
+			KubernetesVersion: "1.22",
+			BundlesRef: &anywherev1.BundlesRef{
+				Name:       bundle.Name,
+				Namespace:  bundle.Namespace,
+				APIVersion: bundle.APIVersion,
+			},
+			BackupConfiguration: &anywherev1.BackupConfiguration{
+				Schedule:       "@daily",
+				RetentionCount: 7,
+				HostPath:       "/var/lib/etcd-backups",
+			},
+		},
+	}
+
+	return &reconcilerTest{
+		WithT:          NewWithT(t),
+		ctx:            context.Background(),
+		remoteClients:  mocks.NewMockRemoteClientRegistry(ctrl),
+		cluster:        cluster,
+		managementObjs: []runtime.Object{bundle, test.EksdRelease()},
+	}
+}
+
+func (tt *reconcilerTest) reconciler() *reconciler.Reconciler {
+	scheme := runtime.NewScheme()
+	_ = anywherev1.AddToScheme(scheme)
+	_ = releasev1.AddToScheme(scheme)
+	_ = eksdv1.AddToScheme(scheme)
+	_ = clusterv1.AddToScheme(scheme)
+	_ = controlplanev1.AddToScheme(scheme)
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(tt.managementObjs...).Build()
+
+	return reconciler.New(cl, tt.remoteClients)
+}
+
+func (tt *reconcilerTest) expectRemoteClient(objs ...runtime.Object) client.Client {
+	rClient := fake.NewClientBuilder().WithRuntimeObjects(objs...).Build()
+	tt.remoteClients.EXPECT().GetClient(tt.ctx, controller.CapiClusterObjectKey(tt.cluster)).Return(rClient, nil)
+	return rClient
+}
+
+func (tt *reconcilerTest) markControlPlaneReady() {
+	conditions.MarkTrue(tt.cluster, anywherev1.ControlPlaneReadyCondition)
+}
+
+func nullLog() logr.Logger {
+	return logr.New(logf.NullLogSink{})
+}
+
+func job(name string, finishedAt time.Time, jobCondition batchv1.JobConditionType) *batchv1.Job {
+	j := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: etcdbackup.CronJobNamespace,
+			Labels:    map[string]string{etcdbackup.ClusterLabel: "my-cluster"},
+		},
+		Status: batchv1.JobStatus{
+			Conditions: []batchv1.JobCondition{
+				{
+					Type:               jobCondition,
+					Status:             corev1.ConditionTrue,
+					LastTransitionTime: metav1.NewTime(finishedAt),
+					Message:            "BackoffLimitExceeded",
+				},
+			},
+		},
+	}
+	if jobCondition == batchv1.JobComplete {
+		j.Status.CompletionTime = &metav1.Time{Time: finishedAt}
+	}
+
+	return j
+}
+
+func jobPod(jobName, size string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      jobName + "-abcde",
+			Namespace: etcdbackup.CronJobNamespace,
+			Labels:    map[string]string{"job-name": jobName},
+		},
+		Spec: corev1.PodSpec{
+			NodeName: "my-cluster-cp-1",
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodSucceeded,
+			ContainerStatuses: []corev1.ContainerStatus{
+				{
+					Name: "retention",
+					State: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							Message: size,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestReconcileNoBackupConfiguration(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.cluster.Spec.BackupConfiguration = nil
+
+	result, err := tt.reconciler().Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+}
+
+func TestReconcileBackupConfigurationRemoved(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.cluster.Spec.BackupConfiguration = nil
+	tt.cluster.Status.EtcdBackup = &anywherev1.EtcdBackupStatus{LastSuccessfulBackupSize: 10}
+	conditions.MarkTrue(tt.cluster, anywherev1.EtcdBackupReadyCondition)
+	cronJob := &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      etcdbackup.CronJobName,
+			Namespace: etcdbackup.CronJobNamespace,
+		},
+	}
+	rClient := tt.expectRemoteClient(cronJob)
+
+	result, err := tt.reconciler().Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+	tt.Expect(conditions.Has(tt.cluster, anywherev1.EtcdBackupReadyCondition)).To(BeFalse())
+	tt.Expect(tt.cluster.Status.EtcdBackup).To(BeNil())
+
+	err = rClient.Get(tt.ctx, client.ObjectKeyFromObject(cronJob), &batchv1.CronJob{})
+	tt.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+}
+
+func TestReconcileBackupConfigurationRemovedCronJobNotFound(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.cluster.Spec.BackupConfiguration = nil
+	conditions.MarkTrue(tt.cluster, anywherev1.EtcdBackupReadyCondition)
+	tt.expectRemoteClient()
+
+	_, err := tt.reconciler().Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(conditions.Has(tt.cluster, anywherev1.EtcdBackupReadyCondition)).To(BeFalse())
+}
+
+func TestReconcileBackupConfigurationRemovedRemoteClientError(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.cluster.Spec.BackupConfiguration = nil
+	conditions.MarkTrue(tt.cluster, anywherev1.EtcdBackupReadyCondition)
+	tt.remoteClients.EXPECT().GetClient(tt.ctx, gomock.AssignableToTypeOf(client.ObjectKey{})).Return(nil, errors.New("unreachable"))
+
+	_, err := tt.reconciler().Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).To(MatchError(ContainSubstring("getting workload cluster's client to remove etcd backups: unreachable")))
+	tt.Expect(conditions.Has(tt.cluster, anywherev1.EtcdBackupReadyCondition)).To(BeTrue())
+}
+
+func TestReconcileBuildClusterSpecError(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.managementObjs = nil
+
+	_, err := tt.reconciler().Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).To(HaveOccurred())
+}
+
+func TestReconcileCAPIClusterNotFound(t *testing.T) {
+	tt := newReconcilerTest(t)
+
+	result, err := tt.reconciler().Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.ResultWithRequeue(5 * time.Second)))
+}
+
+func TestReconcileKubeadmControlPlaneNotFound(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.managementObjs = append(tt.managementObjs, test.CAPICluster(func(c *clusterv1.Cluster) {
+		c.Name = tt.cluster.Name
+	}))
+
+	_, err := tt.reconciler().Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).To(MatchError(ContainSubstring("getting kubeadmcontrolplane")))
+}
+
+func TestReconcileRemoteClientError(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.managementObjs = append(tt.managementObjs,
+		test.CAPICluster(func(c *clusterv1.Cluster) {
+			c.Name = tt.cluster.Name
+		}),
+		test.KubeadmControlPlane(func(kcp *controlplanev1.KubeadmControlPlane) {
+			kcp.Name = tt.cluster.Name
+		}),
+	)
+	tt.remoteClients.EXPECT().GetClient(tt.ctx, gomock.AssignableToTypeOf(client.ObjectKey{})).Return(nil, errors.New("unreachable"))
+
+	_, err := tt.reconciler().Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).To(MatchError(ContainSubstring("getting workload cluster's client to reconcile etcd backups: unreachable")))
+}
+
+func TestReconcileApplyError(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.managementObjs = append(tt.managementObjs,
+		test.CAPICluster(func(c *clusterv1.Cluster) {
+			c.Name = tt.cluster.Name
+		}),
+		test.KubeadmControlPlane(func(kcp *controlplanev1.KubeadmControlPlane) {
+			kcp.Name = tt.cluster.Name
+		}),
+	)
+	// The fake client doesn't support server side apply
+	tt.expectRemoteClient()
+
+	_, err := tt.reconciler().Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).To(MatchError(ContainSubstring("applying etcd backup cronjob")))
+}
+
+func TestUpdateStatusNoBackupConfiguration(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.cluster.Spec.BackupConfiguration = nil
+
+	tt.Expect(tt.reconciler().UpdateStatus(tt.ctx, nullLog(), tt.cluster)).To(Succeed())
+	tt.Expect(conditions.Has(tt.cluster, anywherev1.EtcdBackupReadyCondition)).To(BeFalse())
+}
+
+func TestUpdateStatusControlPlaneNotReady(t *testing.T) {
+	tt := newReconcilerTest(t)
+
+	tt.Expect(tt.reconciler().UpdateStatus(tt.ctx, nullLog(), tt.cluster)).To(Succeed())
+	tt.Expect(conditions.GetReason(tt.cluster, anywherev1.EtcdBackupReadyCondition)).To(Equal(anywherev1.ControlPlaneNotReadyReason))
+}
+
+func TestUpdateStatusNoJobs(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.markControlPlaneReady()
+	tt.expectRemoteClient()
+
+	tt.Expect(tt.reconciler().UpdateStatus(tt.ctx, nullLog(), tt.cluster)).To(Succeed())
+	tt.Expect(conditions.IsFalse(tt.cluster, anywherev1.EtcdBackupReadyCondition)).To(BeTrue())
+	tt.Expect(conditions.GetReason(tt.cluster, anywherev1.EtcdBackupReadyCondition)).To(Equal(anywherev1.EtcdBackupPendingReason))
+	tt.Expect(tt.cluster.Status.EtcdBackup).To(BeNil())
+}
+
+func TestUpdateStatusSucceededJob(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.markControlPlaneReady()
+	finishedAt := time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)
+	tt.expectRemoteClient(
+		job("eksa-etcd-backup-1", finishedAt.Add(-time.Hour), batchv1.JobFailed),
+		job("eksa-etcd-backup-2", finishedAt, batchv1.JobComplete),
+		jobPod("eksa-etcd-backup-2", "1024\n"),
+	)
+
+	tt.Expect(tt.reconciler().UpdateStatus(tt.ctx, nullLog(), tt.cluster)).To(Succeed())
+	tt.Expect(conditions.IsTrue(tt.cluster, anywherev1.EtcdBackupReadyCondition)).To(BeTrue())
+	tt.Expect(conditions.GetMessage(tt.cluster, anywherev1.EtcdBackupReadyCondition)).To(Equal("Last successful backup at 2023-05-01T10:00:00Z, 1024 bytes"))
+	tt.Expect(tt.cluster.Status.EtcdBackup.LastSuccessfulBackupTime.Equal(&metav1.Time{Time: finishedAt})).To(BeTrue())
+	tt.Expect(tt.cluster.Status.EtcdBackup.LastSuccessfulBackupSize).To(Equal(int64(1024)))
+	tt.Expect(tt.cluster.Status.EtcdBackup.LastSuccessfulBackupNode).To(Equal("my-cluster-cp-1"))
+}
+
+func TestUpdateStatusLatestJobFailed(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.markControlPlaneReady()
+	finishedAt := time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)
+	tt.cluster.Status.EtcdBackup = &anywherev1.EtcdBackupStatus{
+		LastSuccessfulBackupTime: &metav1.Time{Time: finishedAt},
+		LastSuccessfulBackupSize: 1024,
+		LastSuccessfulBackupNode: "my-cluster-cp-1",
+	}
+	tt.expectRemoteClient(
+		job("eksa-etcd-backup-1", finishedAt, batchv1.JobComplete),
+		job("eksa-etcd-backup-2", finishedAt.Add(time.Hour), batchv1.JobFailed),
+	)
+
+	tt.Expect(tt.reconciler().UpdateStatus(tt.ctx, nullLog(), tt.cluster)).To(Succeed())
+	tt.Expect(conditions.GetReason(tt.cluster, anywherev1.EtcdBackupReadyCondition)).To(Equal(anywherev1.EtcdBackupFailedReason))
+	tt.Expect(conditions.GetMessage(tt.cluster, anywherev1.EtcdBackupReadyCondition)).To(Equal("Backup job eksa-etcd-backup-2 failed: BackoffLimitExceeded"))
+	tt.Expect(tt.cluster.Status.EtcdBackup.LastSuccessfulBackupSize).To(Equal(int64(1024)))
+}
+
+func TestUpdateStatusJobRunning(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.markControlPlaneReady()
+	running := job("eksa-etcd-backup-1", time.Now(), batchv1.JobComplete)
+	running.Status = batchv1.JobStatus{}
+	tt.expectRemoteClient(running)
+
+	tt.Expect(tt.reconciler().UpdateStatus(tt.ctx, nullLog(), tt.cluster)).To(Succeed())
+	tt.Expect(conditions.GetReason(tt.cluster, anywherev1.EtcdBackupReadyCondition)).To(Equal(anywherev1.EtcdBackupPendingReason))
+}
+
+func TestUpdateStatusInvalidSize(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.markControlPlaneReady()
+	tt.expectRemoteClient(
+		job("eksa-etcd-backup-1", time.Now(), batchv1.JobComplete),
+		jobPod("eksa-etcd-backup-1", "not a size"),
+	)
+
+	err := tt.reconciler().UpdateStatus(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).To(MatchError(ContainSubstring("reading snapshot size from etcd backup pod eksa-etcd-backup-1-abcde")))
+}
+
+func TestUpdateStatusNoSucceededPod(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.markControlPlaneReady()
+	tt.expectRemoteClient(
+		job("eksa-etcd-backup-1", time.Now(), batchv1.JobComplete),
+	)
+
+	err := tt.reconciler().UpdateStatus(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).To(MatchError(ContainSubstring("no succeeded pod found for etcd backup job eksa-etcd-backup-1")))
+}
+
+func TestUpdateStatusRemoteClientError(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.markControlPlaneReady()
+	tt.remoteClients.EXPECT().GetClient(tt.ctx, gomock.AssignableToTypeOf(client.ObjectKey{})).Return(nil, errors.New("unreachable"))
+
+	err := tt.reconciler().UpdateStatus(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).To(MatchError(ContainSubstring("getting workload cluster's client to update etcd backup status: unreachable")))
+}
@@ -0,0 +1,133 @@
+package etcdbackup
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/nodes"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+// Restorer restores an etcd snapshot from a Store in all the etcd members of a cluster.
+type Restorer struct {
+	nodes  NodeLister
+	runner CommandRunner
+	store  Store
+}
+
+// NewRestorer returns a new Restorer.
+func NewRestorer(nodes NodeLister, runner CommandRunner, store Store) *Restorer {
+	return &Restorer{
+		nodes:  nodes,
+		runner: runner,
+		store:  store,
+	}
+}
+
+// ValidateRestore checks the etcd nodes of the cluster support restoring a snapshot, before anything is
+// uploaded to them. Bottlerocket nodes don't, since there is no host shell to stop etcd and replace its data dir.
+func ValidateRestore(spec *cluster.Spec) error {
+	osFamily, err := nodes.EtcdOSFamily(spec)
+	if err != nil {
+		return err
+	}
+
+	if osFamily == anywherev1.Bottlerocket {
+		return fmt.Errorf("restoring etcd snapshots is not supported for %s nodes", anywherev1.Bottlerocket)
+	}
+
+	return nil
+}
+
+type restoreTarget struct {
+	node     nodes.Node
+	member   etcdMember
+	hostname string
+}
+
+// Restore rebuilds the etcd cluster from the snapshot. The snapshot is restored to a staging
+// data dir in every member first, then all members are stopped, their data dirs are replaced and
+// they are started again, so the cluster never runs with members from different data sets.
+// The previous data dir of each member is kept next to the new one.
+func (r *Restorer) Restore(ctx context.Context, managementCluster *types.Cluster, spec *cluster.Spec, snapshotName string) error {
+	data, err := r.store.Load(ctx, snapshotName)
+	if err != nil {
+		return fmt.Errorf("loading etcd snapshot: %v", err)
+	}
+
+	targets, err := r.targets(ctx, managementCluster, spec)
+	if err != nil {
+		return err
+	}
+
+	initialCluster := make([]string, 0, len(targets))
+	for _, t := range targets {
+		initialCluster = append(initialCluster, fmt.Sprintf("%s=%s", t.hostname, peerURL(t.node)))
+	}
+
+	for _, t := range targets {
+		logger.V(3).Info("Preparing etcd snapshot restore", "node", t.node.Name)
+		if _, err := r.runner.RunWithStdin(ctx, t.node, data, uploadScript()); err != nil {
+			return fmt.Errorf("uploading etcd snapshot: %v", err)
+		}
+
+		script := restoreScript(t.member, t.hostname, peerURL(t.node), strings.Join(initialCluster, ","))
+		if _, err := r.runner.Run(ctx, t.node, script); err != nil {
+			return fmt.Errorf("restoring etcd snapshot: %v", err)
+		}
+	}
+
+	for _, t := range targets {
+		logger.V(3).Info("Stopping etcd member", "node", t.node.Name)
+		if _, err := r.runner.Run(ctx, t.node, t.member.stop); err != nil {
+			return fmt.Errorf("stopping etcd member: %v", err)
+		}
+	}
+
+	for _, t := range targets {
+		if _, err := r.runner.Run(ctx, t.node, swapDataDirScript()); err != nil {
+			return fmt.Errorf("replacing etcd data dir: %v", err)
+		}
+	}
+
+	for _, t := range targets {
+		logger.V(3).Info("Starting etcd member", "node", t.node.Name)
+		if _, err := r.runner.Run(ctx, t.node, t.member.start); err != nil {
+			return fmt.Errorf("starting etcd member: %v", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *Restorer) targets(ctx context.Context, managementCluster *types.Cluster, spec *cluster.Spec) ([]restoreTarget, error) {
+	etcdNodes, err := r.nodes.EtcdNodes(ctx, managementCluster, spec)
+	if err != nil {
+		return nil, fmt.Errorf("getting etcd nodes: %v", err)
+	}
+
+	targets := make([]restoreTarget, 0, len(etcdNodes))
+	for _, node := range etcdNodes {
+		member := memberFor(node)
+		if !member.supportsRestore() {
+			return nil, fmt.Errorf("restoring etcd snapshots is not supported for %s %s nodes", node.OSFamily, node.Role)
+		}
+
+		hostname, err := r.runner.Run(ctx, node, "hostname")
+		if err != nil {
+			return nil, fmt.Errorf("getting etcd member name: %v", err)
+		}
+
+		targets = append(targets, restoreTarget{
+			node:     node,
+			member:   member,
+			hostname: strings.TrimSpace(hostname),
+		})
+	}
+
+	return targets, nil
+}
@@ -0,0 +1,115 @@
+package etcdbackup_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/etcdbackup"
+	"github.com/aws/eks-anywhere/pkg/nodes"
+)
+
+func TestRestorerRestoreExternalEtcd(t *testing.T) {
+	tt := newEtcdBackupTest(t)
+	r := etcdbackup.NewRestorer(tt.nodes, tt.runner, tt.store)
+	etcd1 := nodes.Node{Name: "etcd-1", Address: "1.2.3.4", Role: nodes.Etcd, OSFamily: anywherev1.Ubuntu}
+	etcd2 := nodes.Node{Name: "etcd-2", Address: "1.2.3.5", Role: nodes.Etcd, OSFamily: anywherev1.Ubuntu}
+	data := []byte("snapshot")
+
+	tt.store.EXPECT().Load(tt.ctx, "snapshot.db").Return(data, nil)
+	tt.nodes.EXPECT().EtcdNodes(tt.ctx, tt.managementCluster, tt.spec).Return([]nodes.Node{etcd1, etcd2}, nil)
+	gomock.InOrder(
+		tt.runner.EXPECT().Run(tt.ctx, etcd1, "hostname").Return("etcd-1\n", nil),
+		tt.runner.EXPECT().Run(tt.ctx, etcd2, "hostname").Return("etcd-2\n", nil),
+		tt.runner.EXPECT().RunWithStdin(tt.ctx, etcd1, data, "cat > /var/lib/etcd/eksa-restore.db"),
+		tt.runner.EXPECT().Run(tt.ctx, etcd1, "set -e; rm -rf /var/lib/etcd/eksa-restore; ETCDCTL_API=3 /opt/bin/etcdctl --endpoints=https://127.0.0.1:2379 "+
+			"--cacert=/etc/etcd/pki/ca.crt --cert=/etc/etcd/pki/etcdctl-etcd-client.crt --key=/etc/etcd/pki/etcdctl-etcd-client.key "+
+			"snapshot restore /var/lib/etcd/eksa-restore.db --name etcd-1 --initial-cluster etcd-1=https://1.2.3.4:2380,etcd-2=https://1.2.3.5:2380 "+
+			"--initial-cluster-token etcd-cluster --initial-advertise-peer-urls https://1.2.3.4:2380 --data-dir /var/lib/etcd/eksa-restore >&2"),
+		tt.runner.EXPECT().RunWithStdin(tt.ctx, etcd2, data, "cat > /var/lib/etcd/eksa-restore.db"),
+		tt.runner.EXPECT().Run(tt.ctx, etcd2, gomock.Any()),
+		tt.runner.EXPECT().Run(tt.ctx, etcd1, "systemctl stop etcd"),
+		tt.runner.EXPECT().Run(tt.ctx, etcd2, "systemctl stop etcd"),
+		tt.runner.EXPECT().Run(tt.ctx, etcd1, "set -e; rm -rf /var/lib/etcd/member.eksa-backup; mv /var/lib/etcd/member /var/lib/etcd/member.eksa-backup; "+
+			"mv /var/lib/etcd/eksa-restore/member /var/lib/etcd/member; rm -rf /var/lib/etcd/eksa-restore /var/lib/etcd/eksa-restore.db"),
+		tt.runner.EXPECT().Run(tt.ctx, etcd2, gomock.Any()),
+		tt.runner.EXPECT().Run(tt.ctx, etcd1, "systemctl start etcd"),
+		tt.runner.EXPECT().Run(tt.ctx, etcd2, "systemctl start etcd"),
+	)
+
+	tt.Expect(r.Restore(tt.ctx, tt.managementCluster, tt.spec, "snapshot.db")).To(Succeed())
+}
+
+func TestRestorerRestoreStackedEtcd(t *testing.T) {
+	tt := newEtcdBackupTest(t)
+	r := etcdbackup.NewRestorer(tt.nodes, tt.runner, tt.store)
+	data := []byte("snapshot")
+
+	tt.store.EXPECT().Load(tt.ctx, "snapshot.db").Return(data, nil)
+	tt.nodes.EXPECT().EtcdNodes(tt.ctx, tt.managementCluster, tt.spec).Return([]nodes.Node{tt.cp1}, nil)
+	gomock.InOrder(
+		tt.runner.EXPECT().Run(tt.ctx, tt.cp1, "hostname").Return("cp-1", nil),
+		tt.runner.EXPECT().RunWithStdin(tt.ctx, tt.cp1, data, gomock.Any()),
+		tt.runner.EXPECT().Run(tt.ctx, tt.cp1, gomock.Any()),
+		tt.runner.EXPECT().Run(tt.ctx, tt.cp1, "mv /etc/kubernetes/manifests/etcd.yaml /etc/kubernetes/etcd.yaml.eksa-restore && "+
+			"while crictl ps -q --name '^etcd$' | grep -q .; do sleep 2; done"),
+		tt.runner.EXPECT().Run(tt.ctx, tt.cp1, gomock.Any()),
+		tt.runner.EXPECT().Run(tt.ctx, tt.cp1, "mv /etc/kubernetes/etcd.yaml.eksa-restore /etc/kubernetes/manifests/etcd.yaml"),
+	)
+
+	tt.Expect(r.Restore(tt.ctx, tt.managementCluster, tt.spec, "snapshot.db")).To(Succeed())
+}
+
+func TestRestorerRestoreBottlerocketNotSupported(t *testing.T) {
+	tt := newEtcdBackupTest(t)
+	r := etcdbackup.NewRestorer(tt.nodes, tt.runner, tt.store)
+	tt.cp1.OSFamily = anywherev1.Bottlerocket
+
+	tt.store.EXPECT().Load(tt.ctx, "snapshot.db").Return([]byte("snapshot"), nil)
+	tt.nodes.EXPECT().EtcdNodes(tt.ctx, tt.managementCluster, tt.spec).Return([]nodes.Node{tt.cp1}, nil)
+
+	tt.Expect(r.Restore(tt.ctx, tt.managementCluster, tt.spec, "snapshot.db")).To(
+		MatchError("restoring etcd snapshots is not supported for bottlerocket control-plane nodes"),
+	)
+}
+
+func TestRestorerRestoreLoadError(t *testing.T) {
+	tt := newEtcdBackupTest(t)
+	r := etcdbackup.NewRestorer(tt.nodes, tt.runner, tt.store)
+
+	tt.store.EXPECT().Load(tt.ctx, "snapshot.db").Return(nil, errors.New("not found"))
+
+	tt.Expect(r.Restore(tt.ctx, tt.managementCluster, tt.spec, "snapshot.db")).To(MatchError("loading etcd snapshot: not found"))
+}
+
+func TestRestorerRestoreStopError(t *testing.T) {
+	tt := newEtcdBackupTest(t)
+	r := etcdbackup.NewRestorer(tt.nodes, tt.runner, tt.store)
+	data := []byte("snapshot")
+
+	tt.store.EXPECT().Load(tt.ctx, "snapshot.db").Return(data, nil)
+	tt.nodes.EXPECT().EtcdNodes(tt.ctx, tt.managementCluster, tt.spec).Return([]nodes.Node{tt.cp1}, nil)
+	gomock.InOrder(
+		tt.runner.EXPECT().Run(tt.ctx, tt.cp1, "hostname").Return("cp-1", nil),
+		tt.runner.EXPECT().RunWithStdin(tt.ctx, tt.cp1, data, gomock.Any()),
+		tt.runner.EXPECT().Run(tt.ctx, tt.cp1, gomock.Any()),
+		tt.runner.EXPECT().Run(tt.ctx, tt.cp1, gomock.Any()).Return("", errors.New("timeout")),
+	)
+
+	tt.Expect(r.Restore(tt.ctx, tt.managementCluster, tt.spec, "snapshot.db")).To(MatchError("stopping etcd member: timeout"))
+}
+
+func TestValidateRestore(t *testing.T) {
+	tt := newEtcdBackupTest(t)
+	tt.spec.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef = &anywherev1.Ref{Name: "cp"}
+	tt.spec.VSphereMachineConfigs = map[string]*anywherev1.VSphereMachineConfig{
+		"cp": {Spec: anywherev1.VSphereMachineConfigSpec{OSFamily: anywherev1.Ubuntu}},
+	}
+	tt.Expect(etcdbackup.ValidateRestore(tt.spec)).To(Succeed())
+
+	tt.spec.VSphereMachineConfigs["cp"].Spec.OSFamily = anywherev1.Bottlerocket
+	tt.Expect(etcdbackup.ValidateRestore(tt.spec)).To(MatchError("restoring etcd snapshots is not supported for bottlerocket nodes"))
+}
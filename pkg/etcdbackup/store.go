@@ -0,0 +1,125 @@
+package etcdbackup
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+
+	"github.com/aws/eks-anywhere/internal/pkg/s3"
+)
+
+// Store persists etcd snapshots.
+type Store interface {
+	Save(ctx context.Context, name string, data []byte) error
+	Load(ctx context.Context, name string) ([]byte, error)
+	// Location returns a human readable location for the snapshot.
+	Location(name string) string
+}
+
+// DirStore stores snapshots as files in a local directory.
+type DirStore struct {
+	dir string
+}
+
+// NewDirStore returns a new DirStore for dir.
+func NewDirStore(dir string) *DirStore {
+	return &DirStore{dir: dir}
+}
+
+// Save writes the snapshot to a file in the store directory, creating the directory if needed.
+func (s *DirStore) Save(_ context.Context, name string, data []byte) error {
+	if err := os.MkdirAll(s.dir, 0o700); err != nil {
+		return fmt.Errorf("creating snapshot directory: %v", err)
+	}
+
+	if err := os.WriteFile(s.Location(name), data, 0o600); err != nil {
+		return fmt.Errorf("writing snapshot: %v", err)
+	}
+
+	return nil
+}
+
+// Load reads a snapshot from the store directory.
+func (s *DirStore) Load(_ context.Context, name string) ([]byte, error) {
+	data, err := os.ReadFile(s.Location(name))
+	if err != nil {
+		return nil, fmt.Errorf("reading snapshot: %v", err)
+	}
+
+	return data, nil
+}
+
+// Location returns the path of the snapshot file.
+func (s *DirStore) Location(name string) string {
+	return filepath.Join(s.dir, name)
+}
+
+// S3Store stores snapshots in an S3 compatible object store.
+type S3Store struct {
+	session *session.Session
+	bucket  string
+	prefix  string
+}
+
+// S3StoreOpt allows to customize a S3Store on construction.
+type S3StoreOpt func(*aws.Config)
+
+// WithS3Endpoint configures a custom endpoint, used for S3 compatible object stores.
+// Path style addressing is enabled since most of them don't support virtual hosted buckets.
+func WithS3Endpoint(endpoint string) S3StoreOpt {
+	return func(c *aws.Config) {
+		c.Endpoint = aws.String(endpoint)
+		c.S3ForcePathStyle = aws.Bool(true)
+	}
+}
+
+// WithS3Region sets the region of the bucket.
+func WithS3Region(region string) S3StoreOpt {
+	return func(c *aws.Config) {
+		c.Region = aws.String(region)
+	}
+}
+
+// NewS3Store returns a new S3Store that saves snapshots under prefix in bucket.
+// Credentials are read from the default AWS credentials chain.
+func NewS3Store(bucket, prefix string, opts ...S3StoreOpt) (*S3Store, error) {
+	config := &aws.Config{}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	sess, err := session.NewSession(config)
+	if err != nil {
+		return nil, fmt.Errorf("creating aws session for s3 snapshot store: %v", err)
+	}
+
+	return &S3Store{
+		session: sess,
+		bucket:  bucket,
+		prefix:  prefix,
+	}, nil
+}
+
+// Save uploads the snapshot to the bucket.
+func (s *S3Store) Save(_ context.Context, name string, data []byte) error {
+	return s3.Upload(s.session, data, s.key(name), s.bucket)
+}
+
+// Load downloads the snapshot from the bucket.
+func (s *S3Store) Load(_ context.Context, name string) ([]byte, error) {
+	return s3.Download(s.session, s.key(name), s.bucket)
+}
+
+// Location returns the s3 url of the snapshot.
+func (s *S3Store) Location(name string) string {
+	return fmt.Sprintf("s3://%s/%s", s.bucket, s.key(name))
+}
+
+func (s *S3Store) key(name string) string {
+	return path.Join(s.prefix, name)
+}
@@ -0,0 +1,37 @@
+package etcdbackup_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/etcdbackup"
+)
+
+func TestDirStoreSaveAndLoad(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	dir := filepath.Join(t.TempDir(), "backups")
+	s := etcdbackup.NewDirStore(dir)
+
+	g.Expect(s.Save(ctx, "snapshot.db", []byte("snapshot"))).To(Succeed())
+	g.Expect(s.Location("snapshot.db")).To(Equal(filepath.Join(dir, "snapshot.db")))
+	g.Expect(s.Load(ctx, "snapshot.db")).To(Equal([]byte("snapshot")))
+}
+
+func TestDirStoreLoadMissing(t *testing.T) {
+	g := NewWithT(t)
+	s := etcdbackup.NewDirStore(t.TempDir())
+
+	_, err := s.Load(context.Background(), "snapshot.db")
+	g.Expect(err).To(MatchError(ContainSubstring("reading snapshot")))
+}
+
+func TestS3StoreLocation(t *testing.T) {
+	g := NewWithT(t)
+	s, err := etcdbackup.NewS3Store("my-bucket", "clusters/my-cluster", etcdbackup.WithS3Endpoint("https://minio.local"), etcdbackup.WithS3Region("us-west-2"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(s.Location("snapshot.db")).To(Equal("s3://my-bucket/clusters/my-cluster/snapshot.db"))
+}
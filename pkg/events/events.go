@@ -0,0 +1,215 @@
+// Package events writes a machine readable stream of the progress of a CLI operation.
+// Each event is written as a JSON object in its own line, so consumers like CI pipelines
+// can follow tasks, retries and applied resources without parsing the human readable logs.
+//
+// Like the logger, the emitter is package state: it is set once by the command and every
+// package can emit events. Emitting is a no-op when no emitter has been set.
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+// Type is the kind of event.
+type Type string
+
+const (
+	// OperationStarted is emitted when a command starts.
+	OperationStarted Type = "OperationStarted"
+	// OperationFinished is emitted when a command finishes, with its final result.
+	OperationFinished Type = "OperationFinished"
+	// TaskStarted is emitted when a workflow task starts running.
+	TaskStarted Type = "TaskStarted"
+	// TaskFinished is emitted when a workflow task finishes running, with its duration.
+	TaskFinished Type = "TaskFinished"
+	// TaskRestored is emitted when a workflow task is skipped because it was restored from a checkpoint.
+	TaskRestored Type = "TaskRestored"
+	// Retry is emitted every time an attempt of a retried operation fails.
+	Retry Type = "Retry"
+	// ResourcesApplied is emitted when kubernetes resources are applied to a cluster.
+	ResourcesApplied Type = "ResourcesApplied"
+)
+
+// Result is the outcome of an operation or task.
+type Result string
+
+const (
+	// Success means the operation or task finished without errors.
+	Success Result = "Success"
+	// Failure means the operation or task failed.
+	Failure Result = "Failure"
+)
+
+// Event is a single entry of the events stream.
+type Event struct {
+	Time            time.Time  `json:"time"`
+	Type            Type       `json:"type"`
+	Operation       string     `json:"operation,omitempty"`
+	Cluster         string     `json:"cluster,omitempty"`
+	Task            string     `json:"task,omitempty"`
+	Result          Result     `json:"result,omitempty"`
+	DurationSeconds float64    `json:"durationSeconds,omitempty"`
+	Attempt         int        `json:"attempt,omitempty"`
+	Manifest        string     `json:"manifest,omitempty"`
+	Resources       []Resource `json:"resources,omitempty"`
+	Error           string     `json:"error,omitempty"`
+}
+
+// Resource identifies a kubernetes object.
+type Resource struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+}
+
+// Emitter writes events as JSON lines. It's safe for concurrent use.
+type Emitter struct {
+	mu        sync.Mutex
+	encoder   *json.Encoder
+	operation string
+	now       func() time.Time
+}
+
+// EmitterOpt allows to customize an Emitter on construction.
+type EmitterOpt func(*Emitter)
+
+// WithClock sets the function used to get the time of the events.
+func WithClock(now func() time.Time) EmitterOpt {
+	return func(e *Emitter) {
+		e.now = now
+	}
+}
+
+// NewEmitter returns an Emitter that writes to w. Every event is tagged with operation.
+func NewEmitter(w io.Writer, operation string, opts ...EmitterOpt) *Emitter {
+	e := &Emitter{
+		encoder:   json.NewEncoder(w),
+		operation: operation,
+		now:       time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	return e
+}
+
+// Emit writes an event, setting its time and operation. Write errors are logged and
+// ignored, since the events stream should never make an operation fail.
+func (e *Emitter) Emit(event Event) {
+	event.Time = e.now().UTC()
+	event.Operation = e.operation
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if err := e.encoder.Encode(event); err != nil {
+		logger.V(4).Info("Failed writing event", "type", event.Type, "error", err)
+	}
+}
+
+var (
+	pkgEmitter    *Emitter
+	pkgEmitterMtx sync.RWMutex
+)
+
+// Set sets the package emitter. Passing nil disables events.
+func Set(e *Emitter) {
+	pkgEmitterMtx.Lock()
+	defer pkgEmitterMtx.Unlock()
+	pkgEmitter = e
+}
+
+func get() *Emitter {
+	pkgEmitterMtx.RLock()
+	defer pkgEmitterMtx.RUnlock()
+	return pkgEmitter
+}
+
+// Enabled returns true if a package emitter has been set. It allows to skip
+// computing the event fields when nobody is listening.
+func Enabled() bool {
+	return get() != nil
+}
+
+// Emit writes an event with the package emitter, if set.
+func Emit(event Event) {
+	if e := get(); e != nil {
+		e.Emit(event)
+	}
+}
+
+// OpenOutput opens the destination of the events stream. output can be a file path,
+// which is created or truncated, or the number of a file descriptor already open
+// for writing, inherited from the parent process.
+func OpenOutput(output string) (io.WriteCloser, error) {
+	if fd, err := strconv.Atoi(output); err == nil {
+		if fd < 0 {
+			return nil, fmt.Errorf("invalid events output file descriptor %d", fd)
+		}
+		return os.NewFile(uintptr(fd), fmt.Sprintf("fd%d", fd)), nil
+	}
+
+	f, err := os.Create(output)
+	if err != nil {
+		return nil, fmt.Errorf("opening events output: %v", err)
+	}
+
+	return f, nil
+}
+
+// OperationStart emits an OperationStarted event.
+func OperationStart() {
+	Emit(Event{Type: OperationStarted})
+}
+
+// OperationEnd emits an OperationFinished event with the final result of the operation.
+func OperationEnd(duration time.Duration, err error) {
+	Emit(withResult(Event{Type: OperationFinished, DurationSeconds: duration.Seconds()}, err))
+}
+
+// TaskStart emits a TaskStarted event.
+func TaskStart(cluster, task string) {
+	Emit(Event{Type: TaskStarted, Cluster: cluster, Task: task})
+}
+
+// TaskEnd emits a TaskFinished event with the result of the task.
+func TaskEnd(cluster, task string, duration time.Duration, err error) {
+	Emit(withResult(Event{Type: TaskFinished, Cluster: cluster, Task: task, DurationSeconds: duration.Seconds()}, err))
+}
+
+// TaskRestore emits a TaskRestored event.
+func TaskRestore(cluster, task string) {
+	Emit(Event{Type: TaskRestored, Cluster: cluster, Task: task})
+}
+
+// RetryAttemptFailed emits a Retry event for a failed attempt.
+func RetryAttemptFailed(attempt int, err error) {
+	Emit(Event{Type: Retry, Attempt: attempt, Error: err.Error()})
+}
+
+// Applied emits a ResourcesApplied event. Either the applied resources or, when they are
+// only known by kubectl, the manifest file or url should be set.
+func Applied(cluster, manifest string, resources []Resource) {
+	Emit(Event{Type: ResourcesApplied, Cluster: cluster, Manifest: manifest, Resources: resources})
+}
+
+func withResult(event Event, err error) Event {
+	if err != nil {
+		event.Result = Failure
+		event.Error = err.Error()
+	} else {
+		event.Result = Success
+	}
+
+	return event
+}
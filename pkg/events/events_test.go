@@ -0,0 +1,132 @@
+package events_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/events"
+)
+
+var testTime = time.Date(2023, 5, 4, 3, 2, 1, 0, time.UTC)
+
+func setEmitter(t *testing.T, buf *bytes.Buffer) {
+	events.Set(events.NewEmitter(buf, "upgrade", events.WithClock(func() time.Time { return testTime })))
+	t.Cleanup(func() { events.Set(nil) })
+}
+
+func readEvents(g *WithT, buf *bytes.Buffer) []events.Event {
+	var got []events.Event
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		e := events.Event{}
+		g.Expect(json.Unmarshal([]byte(line), &e)).To(Succeed())
+		got = append(got, e)
+	}
+	return got
+}
+
+func TestEmitterEmit(t *testing.T) {
+	g := NewWithT(t)
+	buf := &bytes.Buffer{}
+	e := events.NewEmitter(buf, "create", events.WithClock(func() time.Time { return testTime }))
+
+	e.Emit(events.Event{Type: events.TaskStarted, Cluster: "my-cluster", Task: "setup-validate"})
+
+	g.Expect(buf.String()).To(Equal(`{"time":"2023-05-04T03:02:01Z","type":"TaskStarted","operation":"create","cluster":"my-cluster","task":"setup-validate"}` + "\n"))
+}
+
+func TestEmitNoEmitter(t *testing.T) {
+	g := NewWithT(t)
+	events.Set(nil)
+
+	g.Expect(events.Enabled()).To(BeFalse())
+	events.TaskStart("my-cluster", "setup-validate")
+}
+
+func TestEmitHelpers(t *testing.T) {
+	g := NewWithT(t)
+	buf := &bytes.Buffer{}
+	setEmitter(t, buf)
+
+	g.Expect(events.Enabled()).To(BeTrue())
+	events.OperationStart()
+	events.TaskStart("my-cluster", "setup-validate")
+	events.RetryAttemptFailed(1, errors.New("connection refused"))
+	events.Applied("my-cluster", "", []events.Resource{{APIVersion: "v1", Kind: "ConfigMap", Namespace: "eksa-system", Name: "cm"}})
+	events.TaskEnd("my-cluster", "setup-validate", 2*time.Second, nil)
+	events.TaskRestore("my-cluster", "bootstrap-cluster-init")
+	events.OperationEnd(3*time.Second, errors.New("failed"))
+
+	g.Expect(readEvents(g, buf)).To(Equal([]events.Event{
+		{Time: testTime, Type: events.OperationStarted, Operation: "upgrade"},
+		{Time: testTime, Type: events.TaskStarted, Operation: "upgrade", Cluster: "my-cluster", Task: "setup-validate"},
+		{Time: testTime, Type: events.Retry, Operation: "upgrade", Attempt: 1, Error: "connection refused"},
+		{
+			Time: testTime, Type: events.ResourcesApplied, Operation: "upgrade", Cluster: "my-cluster",
+			Resources: []events.Resource{{APIVersion: "v1", Kind: "ConfigMap", Namespace: "eksa-system", Name: "cm"}},
+		},
+		{Time: testTime, Type: events.TaskFinished, Operation: "upgrade", Cluster: "my-cluster", Task: "setup-validate", Result: events.Success, DurationSeconds: 2},
+		{Time: testTime, Type: events.TaskRestored, Operation: "upgrade", Cluster: "my-cluster", Task: "bootstrap-cluster-init"},
+		{Time: testTime, Type: events.OperationFinished, Operation: "upgrade", Result: events.Failure, DurationSeconds: 3, Error: "failed"},
+	}))
+}
+
+func TestOpenOutputFile(t *testing.T) {
+	g := NewWithT(t)
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+
+	w, err := events.OpenOutput(path)
+	g.Expect(err).NotTo(HaveOccurred())
+	_, err = w.Write([]byte("{}\n"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(w.Close()).To(Succeed())
+
+	content, err := os.ReadFile(path)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(content)).To(Equal("{}\n"))
+}
+
+func TestOpenOutputFileDescriptor(t *testing.T) {
+	g := NewWithT(t)
+	r, w, err := os.Pipe()
+	g.Expect(err).NotTo(HaveOccurred())
+	defer r.Close()
+	defer w.Close()
+	// The events output takes ownership of the descriptor, so it's given a copy of the pipe's.
+	fd, err := syscall.Dup(int(w.Fd()))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	out, err := events.OpenOutput(strconv.Itoa(fd))
+	g.Expect(err).NotTo(HaveOccurred())
+	_, err = out.Write([]byte("{}\n"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(out.Close()).To(Succeed())
+
+	content := make([]byte, 3)
+	_, err = r.Read(content)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(content)).To(Equal("{}\n"))
+}
+
+func TestOpenOutputInvalidFileDescriptor(t *testing.T) {
+	g := NewWithT(t)
+
+	_, err := events.OpenOutput("-1")
+	g.Expect(err).To(MatchError("invalid events output file descriptor -1"))
+}
+
+func TestOpenOutputError(t *testing.T) {
+	g := NewWithT(t)
+
+	_, err := events.OpenOutput(filepath.Join(t.TempDir(), "missing", "events.jsonl"))
+	g.Expect(err).To(MatchError(ContainSubstring("opening events output")))
+}
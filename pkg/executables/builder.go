@@ -88,6 +88,12 @@ func (b *ExecutablesBuilder) Init(ctx context.Context) (Closer, error) {
 	return b.executableBuilder.Init(ctx)
 }
 
+// ReadOnly returns an ExecutablesBuilder for the same binaries whose executables only run the commands
+// that don't mutate clusters or infrastructure. The other commands are skipped.
+func (b *ExecutablesBuilder) ReadOnly() *ExecutablesBuilder {
+	return NewExecutablesBuilder(NewReadOnlyExecutableBuilder(b.executableBuilder))
+}
+
 func BuildSonobuoyExecutable() *Sonobuoy {
 	return NewSonobuoy(&executable{
 		cli: sonobuoyPath,
@@ -100,6 +106,14 @@ func BuildDockerExecutable() *Docker {
 	})
 }
 
+// BuildReadOnlyDockerExecutable builds a docker executable for the host that only runs the commands
+// that don't mutate it.
+func BuildReadOnlyDockerExecutable() *Docker {
+	return NewDocker(NewReadOnlyExecutable(&executable{
+		cli: dockerPath,
+	}, dockerPath))
+}
+
 // RunExecutablesInDocker determines if binary executables should be ran
 // from a docker container or native binaries from the host path
 // It reads MR_TOOLS_DISABLE variable.
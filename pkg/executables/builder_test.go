@@ -32,6 +32,8 @@ func TestLocalExecutablesBuilderAllExecutables(t *testing.T) {
 	g.Expect(clusterctl).NotTo(BeNil())
 	kubectl := b.BuildKubectlExecutable()
 	g.Expect(kubectl).NotTo(BeNil())
+	readOnlyKubectl := b.ReadOnly().BuildKubectlExecutable()
+	g.Expect(readOnlyKubectl).NotTo(BeNil())
 	govc := b.BuildGovcExecutable(writer)
 	g.Expect(govc).NotTo(BeNil())
 	cmk, err := b.BuildCmkExecutable(writer, &decoder.CloudStackExecConfig{
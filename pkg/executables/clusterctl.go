@@ -172,6 +172,26 @@ func (c *Clusterctl) BackupManagement(ctx context.Context, cluster *types.Cluste
 	return nil
 }
 
+// RestoreManagement restores the CAPI resources of a workload cluster saved by BackupManagement into the cluster `to`.
+// It's used to recover the management resources when the bootstrap cluster they were moved to is lost during upgrade.
+func (c *Clusterctl) RestoreManagement(ctx context.Context, to *types.Cluster, clusterName, managementStatePath string) error {
+	filePath := filepath.Join(".", clusterName, managementStatePath)
+	if _, err := os.Stat(filePath); err != nil {
+		return fmt.Errorf("reading backup of CAPI objects: %v", err)
+	}
+
+	_, err := c.Execute(
+		ctx, "move",
+		"--from-directory", filePath,
+		"--to-kubeconfig", to.KubeconfigFile,
+		"--namespace", constants.EksaSystemNamespace,
+	)
+	if err != nil {
+		return fmt.Errorf("failed restoring backup of CAPI objects: %v", err)
+	}
+	return nil
+}
+
 // MoveManagement moves management components `from` cluster `to` cluster
 // If `clusterName` is provided, it filters and moves only the provided cluster.
 func (c *Clusterctl) MoveManagement(ctx context.Context, from, to *types.Cluster, clusterName string) error {
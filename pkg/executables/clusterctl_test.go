@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -255,6 +256,26 @@ func TestClusterctlBackupManagement(t *testing.T) {
 	}
 }
 
+func TestClusterctlRestoreManagement(t *testing.T) {
+	tt := newClusterctlTest(t)
+	managementClusterState := "cluster-state-backup-restore-test"
+	backupDir := filepath.Join("cluster", managementClusterState)
+	if err := os.MkdirAll(backupDir, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll("cluster") })
+
+	tt.e.EXPECT().Execute(tt.ctx, "move", "--from-directory", backupDir, "--to-kubeconfig", tt.cluster.KubeconfigFile, "--namespace", constants.EksaSystemNamespace)
+
+	tt.Expect(tt.clusterctl.RestoreManagement(tt.ctx, tt.cluster, "cluster", managementClusterState)).To(Succeed())
+}
+
+func TestClusterctlRestoreManagementNoBackup(t *testing.T) {
+	tt := newClusterctlTest(t)
+
+	tt.Expect(tt.clusterctl.RestoreManagement(tt.ctx, tt.cluster, "cluster", "missing-backup")).To(MatchError(ContainSubstring("reading backup of CAPI objects")))
+}
+
 func TestClusterctlBackupManagementFailed(t *testing.T) {
 	managementClusterState := fmt.Sprintf("cluster-state-backup-%s", time.Now().Format("2006-01-02T15_04_05"))
 	tt := newClusterctlTest(t)
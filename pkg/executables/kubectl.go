@@ -38,12 +38,14 @@ import (
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
 	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/events"
 	"github.com/aws/eks-anywhere/pkg/filewriter"
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/rufiounreleased"
 	"github.com/aws/eks-anywhere/pkg/retrier"
 	"github.com/aws/eks-anywhere/pkg/types"
 	"github.com/aws/eks-anywhere/pkg/utils/ptr"
+	unstructuredutil "github.com/aws/eks-anywhere/pkg/utils/unstructured"
 	releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
 )
 
@@ -290,6 +292,7 @@ func (k *Kubectl) ApplyManifest(ctx context.Context, kubeconfigPath, manifestPat
 	if _, err := k.Execute(ctx, "apply", "-f", manifestPath, "--kubeconfig", kubeconfigPath); err != nil {
 		return fmt.Errorf("executing apply manifest: %v", err)
 	}
+	events.Applied("", manifestPath, nil)
 	return nil
 }
 
@@ -302,6 +305,7 @@ func (k *Kubectl) ApplyKubeSpecWithNamespace(ctx context.Context, cluster *types
 	if err != nil {
 		return fmt.Errorf("executing apply: %v", err)
 	}
+	events.Applied(cluster.Name, spec, nil)
 	return nil
 }
 
@@ -314,6 +318,7 @@ func (k *Kubectl) ApplyKubeSpecFromBytes(ctx context.Context, cluster *types.Clu
 	if err != nil {
 		return fmt.Errorf("executing apply: %v", err)
 	}
+	emitApplied(cluster.Name, data, "")
 	return nil
 }
 
@@ -331,6 +336,7 @@ func (k *Kubectl) ApplyKubeSpecFromBytesWithNamespace(ctx context.Context, clust
 	if err != nil {
 		return fmt.Errorf("executing apply: %v", err)
 	}
+	emitApplied(cluster.Name, data, namespace)
 	return nil
 }
 
@@ -343,9 +349,40 @@ func (k *Kubectl) ApplyKubeSpecFromBytesForce(ctx context.Context, cluster *type
 	if err != nil {
 		return fmt.Errorf("executing apply --force: %v", err)
 	}
+	emitApplied(cluster.Name, data, "")
 	return nil
 }
 
+// emitApplied emits the resources of an applied manifest. Objects without a namespace are
+// reported in defaultNamespace, the one passed to kubectl.
+func emitApplied(clusterName string, data []byte, defaultNamespace string) {
+	if !events.Enabled() {
+		return
+	}
+
+	objs, err := unstructuredutil.YamlToUnstructured(data)
+	if err != nil {
+		logger.V(4).Info("Failed parsing applied manifest for events", "error", err)
+		return
+	}
+
+	resources := make([]events.Resource, 0, len(objs))
+	for _, o := range objs {
+		namespace := o.GetNamespace()
+		if namespace == "" {
+			namespace = defaultNamespace
+		}
+		resources = append(resources, events.Resource{
+			APIVersion: o.GetAPIVersion(),
+			Kind:       o.GetKind(),
+			Namespace:  namespace,
+			Name:       o.GetName(),
+		})
+	}
+
+	events.Applied(clusterName, "", resources)
+}
+
 // DeleteManifest uses client-side logic to delete objects defined in a yaml manifest.
 func (k *Kubectl) DeleteManifest(ctx context.Context, kubeconfigPath, manifestPath string, opts ...KubectlOpt) error {
 	params := []string{
@@ -2035,6 +2072,18 @@ func (k *Kubectl) MergePatchResource(ctx context.Context, resource, name, patch,
 	return nil
 }
 
+// MergePatchResourceStatus patches the status subresource of the named resource using merge patch.
+func (k *Kubectl) MergePatchResourceStatus(ctx context.Context, resource, name, patch, kubeconfig, namespace string) error {
+	params := []string{
+		"patch", resource, name, "--subresource=status", "--type=merge", "-p", patch, "--kubeconfig", kubeconfig, "--namespace", namespace,
+	}
+
+	if _, err := k.Execute(ctx, params...); err != nil {
+		return err
+	}
+	return nil
+}
+
 func (k *Kubectl) KubeconfigSecretAvailable(ctx context.Context, kubeconfig string, clusterName string, namespace string) (bool, error) {
 	return k.HasResource(ctx, "secret", fmt.Sprintf("%s-kubeconfig", clusterName), kubeconfig, namespace)
 }
@@ -2315,6 +2364,7 @@ func (k *Kubectl) Apply(ctx context.Context, kubeconfig string, obj runtime.Obje
 	if _, err := k.ExecuteWithStdin(ctx, b, "apply", "-f", "-", "--kubeconfig", kubeconfig); err != nil {
 		return fmt.Errorf("applying object with kubectl: %v", err)
 	}
+	emitApplied("", b, "")
 	return nil
 }
 
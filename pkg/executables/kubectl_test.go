@@ -30,6 +30,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
 	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/events"
 	"github.com/aws/eks-anywhere/pkg/executables"
 	mockexecutables "github.com/aws/eks-anywhere/pkg/executables/mocks"
 	"github.com/aws/eks-anywhere/pkg/types"
@@ -192,6 +193,38 @@ func TestKubectlApplyKubeSpecFromBytesSuccess(t *testing.T) {
 	}
 }
 
+// Not parallel since it sets the package events emitter.
+func TestKubectlApplyKubeSpecFromBytesWithNamespaceEmitsEvent(t *testing.T) {
+	g := NewWithT(t)
+	buf := &bytes.Buffer{}
+	events.Set(events.NewEmitter(buf, "create"))
+	t.Cleanup(func() { events.Set(nil) })
+	data := []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: secret
+  namespace: other
+`)
+
+	k, ctx, cluster, e := newKubectl(t)
+	e.EXPECT().ExecuteWithStdin(ctx, data, "apply", "-f", "-", "--namespace", "eksa-system", "--kubeconfig", cluster.KubeconfigFile).Return(bytes.Buffer{}, nil)
+
+	g.Expect(k.ApplyKubeSpecFromBytesWithNamespace(ctx, cluster, data, "eksa-system")).To(Succeed())
+	got := events.Event{}
+	g.Expect(json.Unmarshal(buf.Bytes(), &got)).To(Succeed())
+	g.Expect(got.Type).To(Equal(events.ResourcesApplied))
+	g.Expect(got.Cluster).To(Equal("test-cluster"))
+	g.Expect(got.Resources).To(Equal([]events.Resource{
+		{APIVersion: "v1", Kind: "ConfigMap", Namespace: "eksa-system", Name: "cm"},
+		{APIVersion: "v1", Kind: "Secret", Namespace: "other", Name: "secret"},
+	}))
+}
+
 func TestKubectlApplyKubeSpecFromBytesError(t *testing.T) {
 	t.Parallel()
 	var data []byte
@@ -2292,6 +2325,21 @@ func TestKubectlMergePatchResourceError(t *testing.T) {
 	tt.Expect(err).To(HaveOccurred())
 }
 
+func TestKubectlMergePatchResourceStatus(t *testing.T) {
+	t.Parallel()
+	tt := newKubectlTest(t)
+	patch := "{\"status\":{\"failureMessage\":null}}"
+
+	tt.e.EXPECT().Execute(
+		tt.ctx,
+		"patch", "clusters.anywhere.eks.amazonaws.com", "test-cluster", "--subresource=status", "--type=merge", "-p", patch,
+		"--kubeconfig", tt.cluster.KubeconfigFile, "--namespace", tt.namespace,
+	).Return(bytes.Buffer{}, nil)
+
+	tt.Expect(tt.k.MergePatchResourceStatus(tt.ctx, "clusters.anywhere.eks.amazonaws.com", "test-cluster", patch,
+		tt.cluster.KubeconfigFile, tt.namespace)).To(Succeed())
+}
+
 func TestKubectlGetConfigMap(t *testing.T) {
 	t.Parallel()
 	tt := newKubectlTest(t)
@@ -0,0 +1,255 @@
+package executables
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+// readOnlyCommandSet is the set of commands of a binary that don't mutate clusters or infrastructure.
+type readOnlyCommandSet struct {
+	commands []string
+	// valueFlags are the global flags that can precede the command and take the next argument as value.
+	valueFlags []string
+	// boolFlags are the global flags that can precede the command without a value.
+	boolFlags []string
+}
+
+// readOnlyCommands are the read-only commands of the binaries run by the CLI. Binaries not listed here
+// don't have any, so all their commands are skipped.
+var readOnlyCommands = map[string]readOnlyCommandSet{
+	kubectlPath: {
+		commands: []string{
+			"api-resources",
+			"api-versions",
+			"auth",
+			"cluster-info",
+			"config",
+			"describe",
+			"explain",
+			"get",
+			"logs",
+			"version",
+		},
+		valueFlags: []string{
+			"--as",
+			"--as-group",
+			"--as-uid",
+			"--cache-dir",
+			"--certificate-authority",
+			"--client-certificate",
+			"--client-key",
+			"--cluster",
+			"--context",
+			"--kubeconfig",
+			"--log-file",
+			"--namespace",
+			"--output",
+			"--password",
+			"--profile",
+			"--request-timeout",
+			"--server",
+			"--tls-server-name",
+			"--token",
+			"--user",
+			"--username",
+			"--v",
+			"--vmodule",
+			"-n",
+			"-o",
+			"-s",
+			"-v",
+		},
+		boolFlags: []string{
+			"--disable-compression",
+			"--insecure-skip-tls-verify",
+			"--match-server-version",
+			"--warnings-as-errors",
+		},
+	},
+	govcPath: {
+		commands: []string{
+			"about",
+			"about.cert",
+			"datacenter.info",
+			"datastore.info",
+			"device.info",
+			"find",
+			"folder.info",
+			"library.info",
+			"library.ls",
+			"ls",
+			"permissions.ls",
+			"role.ls",
+			"session.ls",
+			// Logging out only ends the session opened by the CLI.
+			"session.logout",
+			"snapshot.tree",
+			"sso.group.ls",
+			"sso.user.ls",
+			"tags.attached.ls",
+			"tags.category.ls",
+			"tags.ls",
+			"version",
+			"vm.info",
+		},
+	},
+	cmkPath: {
+		commands:   []string{"list"},
+		valueFlags: []string{"-c", "--config", "-o", "--output", "-p", "--profile"},
+		boolFlags:  []string{"-d", "--debug"},
+	},
+	dockerPath: {
+		commands:   []string{"images", "info", "inspect", "ps", "version"},
+		valueFlags: []string{"--config", "-c", "--context", "-H", "--host", "-l", "--log-level", "--tlscacert", "--tlscert", "--tlskey"},
+		boolFlags:  []string{"-D", "--debug", "--tls", "--tlsverify"},
+	},
+	helmPath: {
+		commands: []string{"list", "search", "show", "template", "version"},
+		valueFlags: []string{
+			"--burst-limit",
+			"--kube-apiserver",
+			"--kube-as-group",
+			"--kube-as-user",
+			"--kube-ca-file",
+			"--kube-context",
+			"--kube-tls-server-name",
+			"--kube-token",
+			"--kubeconfig",
+			"--namespace",
+			"--registry-config",
+			"--repository-cache",
+			"--repository-config",
+			"-n",
+		},
+		boolFlags: []string{"--debug", "--kube-insecure-skip-tls-verify"},
+	},
+	fluxPath: {
+		commands:   []string{"check", "get", "version"},
+		valueFlags: []string{"--cache-dir", "--context", "--kubeconfig", "--namespace", "--timeout", "-n"},
+		boolFlags:  []string{"--verbose"},
+	},
+	kindPath: {
+		commands:   []string{"get", "version"},
+		valueFlags: []string{"--verbosity", "-v"},
+		boolFlags:  []string{"--quiet", "-q"},
+	},
+	clusterCtlPath: {
+		commands:   []string{"describe", "version"},
+		valueFlags: []string{"--config", "--v", "-v"},
+	},
+	clusterAwsAdminPath: {
+		commands:   []string{"version"},
+		valueFlags: []string{"--v", "-v"},
+	},
+}
+
+type readOnlyExecutable struct {
+	executable      Executable
+	binaryPath      string
+	allowedCommands map[string]struct{}
+	valueFlags      map[string]struct{}
+	boolFlags       map[string]struct{}
+}
+
+// NewReadOnlyExecutable returns an Executable for binaryPath that only runs the commands that don't mutate
+// clusters or infrastructure. Any other command is skipped and returns an empty output without error,
+// which allows to run a whole operation against real infrastructure without mutating it. A command
+// preceded by a flag that isn't a known global flag of the binary can't be classified and returns an error.
+func NewReadOnlyExecutable(executable Executable, binaryPath string) Executable {
+	set := readOnlyCommands[binaryPath]
+	return &readOnlyExecutable{
+		executable:      executable,
+		binaryPath:      binaryPath,
+		allowedCommands: toSet(set.commands),
+		valueFlags:      toSet(set.valueFlags),
+		boolFlags:       toSet(set.boolFlags),
+	}
+}
+
+func (e *readOnlyExecutable) Execute(ctx context.Context, args ...string) (stdout bytes.Buffer, err error) {
+	return e.Command(ctx, args...).Run()
+}
+
+func (e *readOnlyExecutable) ExecuteWithStdin(ctx context.Context, in []byte, args ...string) (stdout bytes.Buffer, err error) {
+	return e.Command(ctx, args...).WithStdIn(in).Run()
+}
+
+func (e *readOnlyExecutable) ExecuteWithEnv(ctx context.Context, envs map[string]string, args ...string) (stdout bytes.Buffer, err error) {
+	return e.Command(ctx, args...).WithEnvVars(envs).Run()
+}
+
+func (e *readOnlyExecutable) Command(ctx context.Context, args ...string) *Command {
+	return NewCommand(ctx, e, args...)
+}
+
+func (e *readOnlyExecutable) Run(cmd *Command) (stdout bytes.Buffer, err error) {
+	allowed, err := e.allowed(cmd.args)
+	if err != nil {
+		return bytes.Buffer{}, fmt.Errorf("checking if %s command %v is read-only: %v", e.binaryPath, cmd.args, err)
+	}
+	if !allowed {
+		logger.V(3).Info("Skipping command in dry run", "args", cmd.args)
+		return bytes.Buffer{}, nil
+	}
+
+	return e.executable.Command(cmd.ctx, cmd.args...).WithStdIn(cmd.stdIn).WithEnvVars(cmd.envVars).Run()
+}
+
+// allowed checks the first argument that is not a flag, or the value of one, is a read-only command.
+// It returns an error if a flag preceding the command isn't a known global flag, since its value could
+// be mistaken for the command.
+func (e *readOnlyExecutable) allowed(args []string) (bool, error) {
+	if len(e.allowedCommands) == 0 {
+		return false, nil
+	}
+
+	for i := 0; i < len(args); i++ {
+		if !strings.HasPrefix(args[i], "-") {
+			_, ok := e.allowedCommands[args[i]]
+			return ok, nil
+		}
+
+		flag, _, hasValue := strings.Cut(args[i], "=")
+		if _, ok := e.valueFlags[flag]; ok {
+			if !hasValue {
+				i++
+			}
+			continue
+		}
+		if _, ok := e.boolFlags[flag]; ok {
+			continue
+		}
+		return false, fmt.Errorf("unknown flag %s before the command", args[i])
+	}
+	return false, nil
+}
+
+type readOnlyExecutableBuilder struct {
+	builder ExecutableBuilder
+}
+
+// NewReadOnlyExecutableBuilder returns an ExecutableBuilder whose executables only run the commands
+// that don't mutate clusters or infrastructure. The other commands are skipped.
+func NewReadOnlyExecutableBuilder(builder ExecutableBuilder) ExecutableBuilder {
+	return &readOnlyExecutableBuilder{builder: builder}
+}
+
+func (b *readOnlyExecutableBuilder) Init(ctx context.Context) (Closer, error) {
+	return b.builder.Init(ctx)
+}
+
+func (b *readOnlyExecutableBuilder) Build(binaryPath string) Executable {
+	return NewReadOnlyExecutable(b.builder.Build(binaryPath), binaryPath)
+}
+
+func toSet(values []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(values))
+	for _, v := range values {
+		set[v] = struct{}{}
+	}
+	return set
+}
@@ -0,0 +1,140 @@
+package executables_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/executables/mocks"
+)
+
+func TestReadOnlyExecutableRunsAllowedCommands(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	e := mocks.NewMockExecutable(gomock.NewController(t))
+	r := executables.NewReadOnlyExecutable(e, "kubectl")
+	cmd := executables.NewCommand(ctx, e, "get", "pods")
+
+	e.EXPECT().Command(ctx, "get", "pods").Return(cmd)
+	e.EXPECT().Run(cmd).Return(*bytes.NewBufferString("pod-1"), nil)
+
+	out, err := r.Execute(ctx, "get", "pods")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(out.String()).To(Equal("pod-1"))
+}
+
+func TestReadOnlyExecutableSkipsOtherCommands(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	e := mocks.NewMockExecutable(gomock.NewController(t))
+	r := executables.NewReadOnlyExecutable(e, "kubectl")
+
+	out, err := r.ExecuteWithStdin(ctx, []byte("kind: ConfigMap"), "apply", "-f", "-")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(out.Len()).To(Equal(0))
+
+	out, err = r.Execute(ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(out.Len()).To(Equal(0))
+}
+
+func TestReadOnlyExecutableSkipsFlagValues(t *testing.T) {
+	tests := []struct {
+		name       string
+		binaryPath string
+		args       []string
+		allowed    bool
+	}{
+		{
+			name:       "kubectl read after kubeconfig",
+			binaryPath: "kubectl",
+			args:       []string{"--kubeconfig", "k.kubeconfig", "get", "pods"},
+			allowed:    true,
+		},
+		{
+			name:       "kubectl write after kubeconfig",
+			binaryPath: "kubectl",
+			args:       []string{"--kubeconfig", "k.kubeconfig", "delete", "pods"},
+		},
+		{
+			name:       "kubectl read after namespace and context",
+			binaryPath: "kubectl",
+			args:       []string{"-n", "eksa-system", "--context", "admin", "get", "pods"},
+			allowed:    true,
+		},
+		{
+			name:       "kubectl write after namespace named as a read-only command",
+			binaryPath: "kubectl",
+			args:       []string{"--namespace", "get", "delete", "pods"},
+		},
+		{
+			name:       "kubectl write after flags with values",
+			binaryPath: "kubectl",
+			args:       []string{"--kubeconfig=k.kubeconfig", "-o", "yaml", "--insecure-skip-tls-verify", "apply", "-f", "-"},
+		},
+		{
+			name:       "cmk list",
+			binaryPath: "cmk",
+			args:       []string{"-c", "cmk_global.ini", "list", "zones"},
+			allowed:    true,
+		},
+		{
+			name:       "cmk destroy",
+			binaryPath: "cmk",
+			args:       []string{"-c", "cmk_global.ini", "destroy", "virtualmachine"},
+		},
+		{
+			name:       "govc info",
+			binaryPath: "govc",
+			args:       []string{"datacenter.info", "SDDC-Datacenter"},
+			allowed:    true,
+		},
+		{
+			name:       "govc create",
+			binaryPath: "govc",
+			args:       []string{"folder.create", "/SDDC-Datacenter/vm/eksa"},
+		},
+		{
+			name:       "binary without read-only commands",
+			binaryPath: "ssh",
+			args:       []string{"-i", "key", "ec2-user@1.2.3.4", "ls"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := context.Background()
+			e := mocks.NewMockExecutable(gomock.NewController(t))
+			r := executables.NewReadOnlyExecutable(e, tc.binaryPath)
+
+			if tc.allowed {
+				cmd := executables.NewCommand(ctx, e, tc.args...)
+				e.EXPECT().Command(ctx, tc.args).Return(cmd)
+				e.EXPECT().Run(cmd).Return(*bytes.NewBufferString("out"), nil)
+			}
+
+			out, err := r.Execute(ctx, tc.args...)
+			g.Expect(err).NotTo(HaveOccurred())
+			if tc.allowed {
+				g.Expect(out.String()).To(Equal("out"))
+			} else {
+				g.Expect(out.Len()).To(Equal(0))
+			}
+		})
+	}
+}
+
+func TestReadOnlyExecutableUnknownFlagBeforeCommand(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	e := mocks.NewMockExecutable(gomock.NewController(t))
+	r := executables.NewReadOnlyExecutable(e, "kubectl")
+
+	_, err := r.Execute(ctx, "--unknown", "get", "delete", "pods")
+	g.Expect(err).To(MatchError(ContainSubstring("checking if kubectl command [--unknown get delete pods] is read-only: unknown flag --unknown before the command")))
+}
@@ -47,3 +47,33 @@ func TestSSHRunCommandError(t *testing.T) {
 	_, err := ssh.RunCommand(ctx, privateKeyPath, username, ip, command...)
 	g.Expect(err).To(MatchError(fmt.Sprintf("running SSH command: %s", errMsg)))
 }
+
+func TestSSHRunCommandWithStdinNoError(t *testing.T) {
+	ctx := context.Background()
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	executable := mockexecutables.NewMockExecutable(mockCtrl)
+	ssh := executables.NewSSH(executable)
+	in := []byte("input")
+
+	executable.EXPECT().ExecuteWithStdin(ctx, in, "-i", privateKeyPath, "-o", "StrictHostKeyChecking=no", fmt.Sprintf("%s@%s", username, ip), "some", "random", "test", "command").Return(*bytes.NewBufferString("output"), nil)
+
+	out, err := ssh.RunCommandWithStdin(ctx, in, privateKeyPath, username, ip, command...)
+	g.Expect(err).To(Not(HaveOccurred()))
+	g.Expect(out).To(Equal("output"))
+}
+
+func TestSSHRunCommandWithStdinError(t *testing.T) {
+	ctx := context.Background()
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	executable := mockexecutables.NewMockExecutable(mockCtrl)
+	ssh := executables.NewSSH(executable)
+	in := []byte("input")
+	errMsg := "sshKey invalid"
+
+	executable.EXPECT().ExecuteWithStdin(ctx, in, "-i", privateKeyPath, "-o", "StrictHostKeyChecking=no", fmt.Sprintf("%s@%s", username, ip), "some", "random", "test", "command").Return(bytes.Buffer{}, errors.New(errMsg))
+
+	_, err := ssh.RunCommandWithStdin(ctx, in, privateKeyPath, username, ip, command...)
+	g.Expect(err).To(MatchError(fmt.Sprintf("running SSH command: %s", errMsg)))
+}
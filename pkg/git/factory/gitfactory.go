@@ -19,9 +19,14 @@ import (
 	"github.com/aws/eks-anywhere/pkg/git"
 	"github.com/aws/eks-anywhere/pkg/git/gitclient"
 	"github.com/aws/eks-anywhere/pkg/git/gogithub"
+	"github.com/aws/eks-anywhere/pkg/git/providers/gitea"
 	"github.com/aws/eks-anywhere/pkg/git/providers/github"
+	"github.com/aws/eks-anywhere/pkg/git/providers/gitlab"
 )
 
+// tokenAuthUsername is the username of the basic auth with an access token to GitLab and Gitea over https.
+const tokenAuthUsername = "oauth2"
+
 type GitTools struct {
 	Provider            git.ProviderClient
 	Client              git.Client
@@ -53,6 +58,36 @@ func Build(ctx context.Context, cluster *v1alpha1.Cluster, fluxConfig *v1alpha1.
 		gitAuth = &http.BasicAuth{Password: githubToken, Username: fluxConfig.Spec.Github.Owner}
 		repo = fluxConfig.Spec.Github.Repository
 		repoUrl = github.RepoUrl(fluxConfig.Spec.Github.Owner, repo)
+	case fluxConfig.Spec.Gitlab != nil:
+		gitlabToken, err := gitlab.GetGitlabAccessTokenFromEnv()
+		if err != nil {
+			return nil, err
+		}
+
+		auth := git.TokenAuth{Token: gitlabToken, Username: fluxConfig.Spec.Gitlab.Owner}
+		tools.Provider, err = gitlab.New(fluxConfig.Spec.Gitlab, auth)
+		if err != nil {
+			return nil, fmt.Errorf("building gitlab provider: %v", err)
+		}
+
+		gitAuth = &http.BasicAuth{Password: gitlabToken, Username: tokenAuthUsername}
+		repo = fluxConfig.Spec.Gitlab.Repository
+		repoUrl = gitlab.RepoUrl(fluxConfig.Spec.Gitlab)
+	case fluxConfig.Spec.Gitea != nil:
+		giteaToken, err := gitea.GetGiteaAccessTokenFromEnv()
+		if err != nil {
+			return nil, err
+		}
+
+		auth := git.TokenAuth{Token: giteaToken, Username: fluxConfig.Spec.Gitea.Owner}
+		tools.Provider, err = gitea.New(fluxConfig.Spec.Gitea, auth)
+		if err != nil {
+			return nil, fmt.Errorf("building gitea provider: %v", err)
+		}
+
+		gitAuth = &http.BasicAuth{Password: giteaToken, Username: tokenAuthUsername}
+		repo = fluxConfig.Spec.Gitea.Repository
+		repoUrl = gitea.RepoUrl(fluxConfig.Spec.Gitea)
 	case fluxConfig.Spec.Git != nil:
 		privateKeyFile := os.Getenv(config.EksaGitPrivateKeyTokenEnv)
 		privateKeyPassphrase := os.Getenv(config.EksaGitPassphraseTokenEnv)
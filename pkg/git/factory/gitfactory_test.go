@@ -9,7 +9,9 @@ import (
 	"github.com/aws/eks-anywhere/internal/test"
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	gitFactory "github.com/aws/eks-anywhere/pkg/git/factory"
+	"github.com/aws/eks-anywhere/pkg/git/providers/gitea"
 	"github.com/aws/eks-anywhere/pkg/git/providers/github"
+	"github.com/aws/eks-anywhere/pkg/git/providers/gitlab"
 )
 
 const (
@@ -65,6 +67,58 @@ func TestGitFactoryHappyPath(t *testing.T) {
 	}
 }
 
+func TestGitFactoryTokenProviders(t *testing.T) {
+	tests := []struct {
+		testName   string
+		tokenEnv   string
+		fluxConfig v1alpha1.FluxConfigSpec
+	}{
+		{
+			testName: "gitlab",
+			tokenEnv: gitlab.EksaGitlabTokenEnv,
+			fluxConfig: v1alpha1.FluxConfigSpec{
+				Gitlab: &v1alpha1.GitlabProviderConfig{Hostname: "gitlab.example.com", Owner: "platform", Repository: "testRepo"},
+			},
+		},
+		{
+			testName: "gitea",
+			tokenEnv: gitea.EksaGiteaTokenEnv,
+			fluxConfig: v1alpha1.FluxConfigSpec{
+				Gitea: &v1alpha1.GiteaProviderConfig{Hostname: "gitea.example.com", Owner: "platform", Repository: "testRepo"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			cluster := &v1alpha1.Cluster{
+				ObjectMeta: v1.ObjectMeta{
+					Name: "testCluster",
+				},
+			}
+			fluxConfig := &v1alpha1.FluxConfig{Spec: tt.fluxConfig}
+			_, w := test.NewWriter(t)
+
+			t.Setenv(tt.tokenEnv, "")
+			if _, err := gitFactory.Build(context.Background(), cluster, fluxConfig, w); err == nil {
+				t.Errorf("gitfactory.Build returned nil err without %s, wanted an error", tt.tokenEnv)
+			}
+
+			t.Setenv(tt.tokenEnv, "token")
+			tools, err := gitFactory.Build(context.Background(), cluster, fluxConfig, w)
+			if err != nil {
+				t.Fatalf("gitfactory.Build returned err, wanted nil. err: %v", err)
+			}
+			if tools.Provider == nil {
+				t.Errorf("gitfactory.Build returned a nil provider")
+			}
+			if tools.RepositoryDirectory != "testCluster/git/testRepo" {
+				t.Errorf("gitfactory.Build returned repository directory %s, wanted testCluster/git/testRepo", tools.RepositoryDirectory)
+			}
+		})
+	}
+}
+
 func setupContext(t *testing.T) {
 	t.Setenv(github.EksaGithubTokenEnv, validPATValue)
 	t.Setenv(github.GithubTokenEnv, validPATValue)
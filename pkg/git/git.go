@@ -3,6 +3,7 @@ package git
 import (
 	"context"
 	"fmt"
+	"strings"
 )
 
 type Client interface {
@@ -24,6 +25,8 @@ type ProviderClient interface {
 	AddDeployKeyToRepo(ctx context.Context, opts AddDeployKeyOpts) error
 	Validate(ctx context.Context) error
 	PathExists(ctx context.Context, owner, repo, branch, path string) (bool, error)
+	CreatePullRequest(ctx context.Context, opts CreatePullRequestOpts) (*PullRequest, error)
+	GetPullRequest(ctx context.Context, opts GetPullRequestOpts) (*PullRequest, error)
 }
 
 type CreateRepoOpts struct {
@@ -53,11 +56,51 @@ type AddDeployKeyOpts struct {
 	ReadOnly   bool
 }
 
+type CreatePullRequestOpts struct {
+	Owner       string
+	Repository  string
+	Title       string
+	Description string
+	// Head is the branch with the changes, and Base the branch they are merged into.
+	Head string
+	Base string
+}
+
+type GetPullRequestOpts struct {
+	Owner      string
+	Repository string
+	Number     int
+}
+
+// PullRequest is a pull request, or a merge request in GitLab.
+type PullRequest struct {
+	Number int
+	Url    string
+	Merged bool
+	Closed bool
+}
+
 type Repository struct {
 	Name         string
 	Owner        string
 	Organization string
 	CloneUrl     string
+	// SshUrl is the ssh:// url of the repository, when the provider serves it over SSH.
+	SshUrl string
+}
+
+// SshUrl returns the ssh:// form of a scp-like repository url, e.g. git@example.com:owner/repo.git,
+// which is the form Flux expects. Other urls are returned unchanged.
+func SshUrl(url string) string {
+	if strings.Contains(url, "://") {
+		return url
+	}
+	at := strings.Index(url, "@")
+	colon := strings.Index(url, ":")
+	if at < 0 || colon < at {
+		return url
+	}
+	return "ssh://" + url[:colon] + "/" + url[colon+1:]
 }
 
 type TokenAuth struct {
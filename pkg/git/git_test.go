@@ -0,0 +1,26 @@
+package git_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/git"
+)
+
+func TestSshUrl(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{url: "git@gitlab.example.com:group/repo.git", want: "ssh://git@gitlab.example.com/group/repo.git"},
+		{url: "ssh://git@gitea.example.com:2222/owner/repo.git", want: "ssh://git@gitea.example.com:2222/owner/repo.git"},
+		{url: "https://gitlab.example.com/group/repo.git", want: "https://gitlab.example.com/group/repo.git"},
+		{url: "", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			NewWithT(t).Expect(git.SshUrl(tt.url)).To(Equal(tt.want))
+		})
+	}
+}
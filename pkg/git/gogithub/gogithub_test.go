@@ -445,6 +445,40 @@ func TestPathExistsItDoesNot(t *testing.T) {
 	tt.Expect(tt.g.PathExists(tt.ctx, owner, repo, branch, path)).To(BeTrue())
 }
 
+func TestGoGithubCreatePullRequest(t *testing.T) {
+	tt := newTest(t)
+	opts := git.CreatePullRequestOpts{Owner: "aws", Repository: "eksa-gitops", Title: "title", Description: "body", Head: "feature", Base: "main"}
+	pull := &github.NewPullRequest{Title: &opts.Title, Body: &opts.Description, Head: &opts.Head, Base: &opts.Base}
+	tt.client.EXPECT().CreatePullRequest(tt.ctx, "aws", "eksa-gitops", pull).Return(&github.PullRequest{
+		Number:  github.Int(3),
+		HTMLURL: github.String("https://github.com/aws/eksa-gitops/pull/3"),
+		State:   github.String("open"),
+	}, nil, nil)
+
+	tt.Expect(tt.g.CreatePullRequest(tt.ctx, opts)).To(Equal(&git.PullRequest{Number: 3, Url: "https://github.com/aws/eksa-gitops/pull/3"}))
+}
+
+func TestGoGithubGetPullRequestMerged(t *testing.T) {
+	tt := newTest(t)
+	opts := git.GetPullRequestOpts{Owner: "aws", Repository: "eksa-gitops", Number: 3}
+	tt.client.EXPECT().GetPullRequest(tt.ctx, "aws", "eksa-gitops", 3).Return(&github.PullRequest{
+		Number: github.Int(3),
+		State:  github.String("closed"),
+		Merged: github.Bool(true),
+	}, nil, nil)
+
+	tt.Expect(tt.g.GetPullRequest(tt.ctx, opts)).To(Equal(&git.PullRequest{Number: 3, Merged: true, Closed: true}))
+}
+
+func TestGoGithubGetPullRequestError(t *testing.T) {
+	tt := newTest(t)
+	opts := git.GetPullRequestOpts{Owner: "aws", Repository: "eksa-gitops", Number: 3}
+	tt.client.EXPECT().GetPullRequest(tt.ctx, "aws", "eksa-gitops", 3).Return(nil, nil, errors.New("rate limited"))
+
+	_, err := tt.g.GetPullRequest(tt.ctx, opts)
+	tt.Expect(err).To(MatchError("describing pull request 3 in repository eksa-gitops: rate limited"))
+}
+
 type gogithubTest struct {
 	*WithT
 	g      *gogithub.GoGithub
@@ -0,0 +1,295 @@
+package gitea
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const (
+	GitProviderName   = "gitea"
+	EksaGiteaTokenEnv = "EKSA_GITEA_TOKEN"
+	giteaUrlTemplate  = "https://%v/%v/%v.git"
+	apiPath           = "/api/v1"
+)
+
+type giteaProvider struct {
+	client  *http.Client
+	baseUrl string
+	config  *v1alpha1.GiteaProviderConfig
+	auth    git.TokenAuth
+}
+
+// Opt configures the Gitea provider.
+type Opt func(*giteaProvider)
+
+// WithBaseUrl sets the url of the Gitea instance, instead of https://<hostname>.
+func WithBaseUrl(baseUrl string) Opt {
+	return func(g *giteaProvider) {
+		g.baseUrl = strings.TrimSuffix(baseUrl, "/")
+	}
+}
+
+// WithHTTPClient sets the http client used to call the Gitea API.
+func WithHTTPClient(client *http.Client) Opt {
+	return func(g *giteaProvider) {
+		g.client = client
+	}
+}
+
+// New returns a git.ProviderClient for a Gitea instance, authenticated with an access token.
+func New(config *v1alpha1.GiteaProviderConfig, auth git.TokenAuth, opts ...Opt) (*giteaProvider, error) {
+	g := &giteaProvider{
+		client:  http.DefaultClient,
+		baseUrl: "https://" + config.Hostname,
+		config:  config,
+		auth:    auth,
+	}
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g, nil
+}
+
+type user struct {
+	Login string `json:"login"`
+}
+
+type repository struct {
+	Name     string `json:"name"`
+	CloneUrl string `json:"clone_url"`
+	SshUrl   string `json:"ssh_url"`
+	Owner    user   `json:"owner"`
+}
+
+func (r *repository) repository(personal bool) *git.Repository {
+	repo := &git.Repository{
+		Name:     r.Name,
+		Owner:    r.Owner.Login,
+		CloneUrl: r.CloneUrl,
+		SshUrl:   git.SshUrl(r.SshUrl),
+	}
+	if !personal {
+		repo.Organization = r.Owner.Login
+	}
+	return repo
+}
+
+// GetRepo describes the configured remote repository.
+// If the repo does not exist, a nil repo is returned.
+func (g *giteaProvider) GetRepo(ctx context.Context) (*git.Repository, error) {
+	r := g.config.Repository
+	o := g.config.Owner
+	logger.V(3).Info("Describing Gitea repository", "name", r, "owner", o)
+	repo := &repository{}
+	err := g.do(ctx, http.MethodGet, repoPath(o, r), nil, repo)
+	if isNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unexpected error when describing repository %s: %w", r, err)
+	}
+	return repo.repository(g.config.Personal), nil
+}
+
+// CreateRepo creates an empty Gitea repository in the owner's organization, or for the user if it's personal.
+func (g *giteaProvider) CreateRepo(ctx context.Context, opts git.CreateRepoOpts) (*git.Repository, error) {
+	logger.V(3).Info("Creating Gitea repository", "name", opts.Name, "owner", opts.Owner)
+	body := map[string]interface{}{
+		"name":        opts.Name,
+		"description": opts.Description,
+		"private":     opts.Privacy,
+		"auto_init":   opts.AutoInit,
+	}
+	p := "/user/repos"
+	if !opts.Personal {
+		p = "/orgs/" + url.PathEscape(opts.Owner) + "/repos"
+	}
+
+	repo := &repository{}
+	if err := g.do(ctx, http.MethodPost, p, body, repo); err != nil {
+		return nil, fmt.Errorf("creating repository %s: %v", opts.Name, err)
+	}
+	return repo.repository(opts.Personal), nil
+}
+
+// DeleteRepo deletes a Gitea repository.
+func (g *giteaProvider) DeleteRepo(ctx context.Context, opts git.DeleteRepoOpts) error {
+	logger.V(3).Info("Deleting Gitea repository", "name", opts.Repository, "owner", opts.Owner)
+	if err := g.do(ctx, http.MethodDelete, repoPath(opts.Owner, opts.Repository), nil, nil); err != nil {
+		return fmt.Errorf("deleting repository %s: %v", opts.Repository, err)
+	}
+	return nil
+}
+
+// AddDeployKeyToRepo registers a deploy key in a Gitea repository.
+func (g *giteaProvider) AddDeployKeyToRepo(ctx context.Context, opts git.AddDeployKeyOpts) error {
+	logger.V(3).Info("Adding deploy key to repository", "repository", opts.Repository, "owner", opts.Owner)
+	body := map[string]interface{}{
+		"title":     opts.Title,
+		"key":       opts.Key,
+		"read_only": opts.ReadOnly,
+	}
+	if err := g.do(ctx, http.MethodPost, repoPath(opts.Owner, opts.Repository)+"/keys", body, nil); err != nil {
+		return fmt.Errorf("adding deploy key to repository %s: %v", opts.Repository, err)
+	}
+	return nil
+}
+
+// Validate validates the Gitea access token and that its user can access the owner.
+func (g *giteaProvider) Validate(ctx context.Context) error {
+	u := &user{}
+	if err := g.do(ctx, http.MethodGet, "/user", nil, u); err != nil {
+		return fmt.Errorf("failed while getting the authenticated gitea user: %v", err)
+	}
+	if g.config.Personal {
+		if !strings.EqualFold(g.config.Owner, u.Login) {
+			return fmt.Errorf("the authenticated Gitea user and owner %s specified in the EKS-A gitops spec don't match; confirm access token owner is %s", g.config.Owner, g.config.Owner)
+		}
+		return nil
+	}
+	if err := g.do(ctx, http.MethodGet, "/orgs/"+url.PathEscape(g.config.Owner), nil, nil); err != nil {
+		return fmt.Errorf("the authenticated gitea user doesn't have proper access to gitea organization %s, %v", g.config.Owner, err)
+	}
+	return nil
+}
+
+// PathExists checks if a path exists in the remote repository. If the owner, repository or branch doesn't exist,
+// it returns false and no error.
+func (g *giteaProvider) PathExists(ctx context.Context, owner, repo, branch, path string) (bool, error) {
+	query := url.Values{"ref": {branch}}
+	err := g.do(ctx, http.MethodGet, repoPath(owner, repo)+"/contents/"+strings.Trim(path, "/")+"?"+query.Encode(), nil, nil)
+	if isNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed checking if path %s exists in remote gitea repository: %v", path, err)
+	}
+	return true, nil
+}
+
+type pullRequest struct {
+	Number  int    `json:"number"`
+	HtmlUrl string `json:"html_url"`
+	State   string `json:"state"`
+	Merged  bool   `json:"merged"`
+}
+
+func (pr *pullRequest) pullRequest() *git.PullRequest {
+	return &git.PullRequest{
+		Number: pr.Number,
+		Url:    pr.HtmlUrl,
+		Merged: pr.Merged,
+		Closed: pr.State == "closed",
+	}
+}
+
+// CreatePullRequest opens a pull request to merge the head branch into the base branch.
+func (g *giteaProvider) CreatePullRequest(ctx context.Context, opts git.CreatePullRequestOpts) (*git.PullRequest, error) {
+	logger.V(3).Info("Creating Gitea pull request", "repository", opts.Repository, "owner", opts.Owner, "head", opts.Head, "base", opts.Base)
+	body := map[string]interface{}{
+		"title": opts.Title,
+		"body":  opts.Description,
+		"head":  opts.Head,
+		"base":  opts.Base,
+	}
+	pr := &pullRequest{}
+	if err := g.do(ctx, http.MethodPost, repoPath(opts.Owner, opts.Repository)+"/pulls", body, pr); err != nil {
+		return nil, fmt.Errorf("creating pull request in repository %s: %v", opts.Repository, err)
+	}
+	return pr.pullRequest(), nil
+}
+
+// GetPullRequest describes a pull request.
+func (g *giteaProvider) GetPullRequest(ctx context.Context, opts git.GetPullRequestOpts) (*git.PullRequest, error) {
+	pr := &pullRequest{}
+	if err := g.do(ctx, http.MethodGet, fmt.Sprintf("%s/pulls/%d", repoPath(opts.Owner, opts.Repository), opts.Number), nil, pr); err != nil {
+		return nil, fmt.Errorf("describing pull request %d in repository %s: %v", opts.Number, opts.Repository, err)
+	}
+	return pr.pullRequest(), nil
+}
+
+func (g *giteaProvider) do(ctx context.Context, method, p string, body, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("marshalling request: %v", err)
+		}
+		reader = bytes.NewReader(b)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, g.baseUrl+apiPath+p, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "token "+g.auth.Token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("reading response: %v", err)
+	}
+	if resp.StatusCode >= 300 {
+		return &apiError{method: method, path: p, status: resp.StatusCode, message: strings.TrimSpace(string(content))}
+	}
+	if out == nil || len(content) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(content, out); err != nil {
+		return fmt.Errorf("unmarshalling response of %s %s: %v", method, p, err)
+	}
+	return nil
+}
+
+type apiError struct {
+	method  string
+	path    string
+	status  int
+	message string
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("%s %s: %d %s", e.method, e.path, e.status, e.message)
+}
+
+func isNotFound(err error) bool {
+	var e *apiError
+	return errors.As(err, &e) && e.status == http.StatusNotFound
+}
+
+func repoPath(owner, repo string) string {
+	return "/repos/" + url.PathEscape(owner) + "/" + url.PathEscape(repo)
+}
+
+// GetGiteaAccessTokenFromEnv returns the Gitea access token from EKSA_GITEA_TOKEN.
+func GetGiteaAccessTokenFromEnv() (string, error) {
+	val, ok := os.LookupEnv(EksaGiteaTokenEnv)
+	if !ok || len(val) == 0 {
+		return "", fmt.Errorf("gitea access token environment variable %s is invalid; could not get var from environment", EksaGiteaTokenEnv)
+	}
+	return val, nil
+}
+
+// RepoUrl returns the https url of a repository in a Gitea instance.
+func RepoUrl(config *v1alpha1.GiteaProviderConfig) string {
+	return fmt.Sprintf(giteaUrlTemplate, config.Hostname, config.Owner, config.Repository)
+}
@@ -0,0 +1,343 @@
+package gitea_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/git/providers/gitea"
+)
+
+const testToken = "gitea-test"
+
+// fakeGitea is a stand-in for the Gitea v1 API, with a user, its organizations and their repositories.
+type fakeGitea struct {
+	sync.Mutex
+	login      string
+	orgs       map[string]bool
+	repos      map[string]map[string]interface{}
+	files      map[string][]string
+	deployKeys map[string][]map[string]interface{}
+	pulls      map[string][]map[string]interface{}
+}
+
+func newFakeGitea(t *testing.T) (*fakeGitea, *httptest.Server) {
+	f := &fakeGitea{
+		login:      "jeff",
+		orgs:       map[string]bool{"platform": true},
+		repos:      map[string]map[string]interface{}{},
+		files:      map[string][]string{},
+		deployKeys: map[string][]map[string]interface{}{},
+		pulls:      map[string][]map[string]interface{}{},
+	}
+	s := httptest.NewServer(f)
+	t.Cleanup(s.Close)
+	return f, s
+}
+
+func (f *fakeGitea) addRepo(owner, name string) {
+	f.repos[owner+"/"+name] = map[string]interface{}{
+		"name":      name,
+		"clone_url": "https://gitea.example.com/" + owner + "/" + name + ".git",
+		"ssh_url":   "git@gitea.example.com:" + owner + "/" + name + ".git",
+		"owner":     map[string]string{"login": owner},
+	}
+}
+
+func (f *fakeGitea) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.Lock()
+	defer f.Unlock()
+	if r.Header.Get("Authorization") != "token "+testToken {
+		http.Error(w, `{"message":"token is required"}`, http.StatusUnauthorized)
+		return
+	}
+
+	p := strings.TrimPrefix(r.URL.Path, "/api/v1")
+	segments := strings.Split(strings.TrimPrefix(p, "/"), "/")
+	switch {
+	case r.Method == http.MethodGet && p == "/user":
+		writeJSON(w, map[string]string{"login": f.login})
+	case r.Method == http.MethodGet && len(segments) == 2 && segments[0] == "orgs":
+		if !f.orgs[segments[1]] {
+			http.Error(w, `{"message":"GetOrgByName"}`, http.StatusNotFound)
+			return
+		}
+		writeJSON(w, map[string]string{"username": segments[1]})
+	case r.Method == http.MethodPost && (p == "/user/repos" || len(segments) == 3 && segments[0] == "orgs" && segments[2] == "repos"):
+		owner := f.login
+		if segments[0] == "orgs" {
+			if !f.orgs[segments[1]] {
+				http.Error(w, `{"message":"GetOrgByName"}`, http.StatusNotFound)
+				return
+			}
+			owner = segments[1]
+		}
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if body["private"] != true {
+			http.Error(w, `{"message":"expected a private repository"}`, http.StatusUnprocessableEntity)
+			return
+		}
+		f.addRepo(owner, body["name"].(string))
+		w.WriteHeader(http.StatusCreated)
+		writeJSON(w, f.repos[owner+"/"+body["name"].(string)])
+	case len(segments) >= 3 && segments[0] == "repos":
+		id := segments[1] + "/" + segments[2]
+		repo, ok := f.repos[id]
+		if !ok {
+			http.Error(w, `{"message":"GetRepositoryByName"}`, http.StatusNotFound)
+			return
+		}
+		rest := strings.Join(segments[3:], "/")
+		switch {
+		case r.Method == http.MethodGet && rest == "":
+			writeJSON(w, repo)
+		case r.Method == http.MethodDelete && rest == "":
+			delete(f.repos, id)
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodPost && rest == "keys":
+			var body map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			f.deployKeys[id] = append(f.deployKeys[id], body)
+			w.WriteHeader(http.StatusCreated)
+			writeJSON(w, body)
+		case r.Method == http.MethodPost && rest == "pulls":
+			var body map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			number := len(f.pulls[id]) + 1
+			body["number"] = number
+			body["html_url"] = fmt.Sprintf("https://gitea.example.com/%s/pulls/%d", id, number)
+			body["state"] = "open"
+			body["merged"] = false
+			f.pulls[id] = append(f.pulls[id], body)
+			w.WriteHeader(http.StatusCreated)
+			writeJSON(w, body)
+		case r.Method == http.MethodGet && strings.HasPrefix(rest, "pulls/"):
+			number, _ := strconv.Atoi(strings.TrimPrefix(rest, "pulls/"))
+			if number < 1 || number > len(f.pulls[id]) {
+				http.Error(w, `{"message":"GetPullRequestByIndex"}`, http.StatusNotFound)
+				return
+			}
+			writeJSON(w, f.pulls[id][number-1])
+		case r.Method == http.MethodGet && strings.HasPrefix(rest, "contents/"):
+			path := strings.TrimPrefix(rest, "contents/")
+			for _, file := range f.files[id+"@"+r.URL.Query().Get("ref")] {
+				if file == path || strings.HasPrefix(file, path+"/") {
+					writeJSON(w, map[string]string{"path": path})
+					return
+				}
+			}
+			http.Error(w, `{"message":"GetContentsOrList"}`, http.StatusNotFound)
+		default:
+			http.NotFound(w, r)
+		}
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func newProvider(t *testing.T, s *httptest.Server, config *v1alpha1.GiteaProviderConfig) git.ProviderClient {
+	p, err := gitea.New(config, git.TokenAuth{Token: testToken, Username: config.Owner}, gitea.WithBaseUrl(s.URL), gitea.WithHTTPClient(s.Client()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestGetRepo(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	f, s := newFakeGitea(t)
+	f.addRepo("platform", "fleet")
+
+	repo, err := newProvider(t, s, &v1alpha1.GiteaProviderConfig{Owner: "platform", Repository: "fleet"}).GetRepo(ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(repo).To(Equal(&git.Repository{
+		Name:         "fleet",
+		Owner:        "platform",
+		Organization: "platform",
+		CloneUrl:     "https://gitea.example.com/platform/fleet.git",
+		SshUrl:       "ssh://git@gitea.example.com/platform/fleet.git",
+	}))
+
+	repo, err = newProvider(t, s, &v1alpha1.GiteaProviderConfig{Owner: "platform", Repository: "missing"}).GetRepo(ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(repo).To(BeNil())
+}
+
+func TestGetRepoUnauthorized(t *testing.T) {
+	g := NewWithT(t)
+	_, s := newFakeGitea(t)
+	p, err := gitea.New(&v1alpha1.GiteaProviderConfig{Owner: "platform", Repository: "fleet"}, git.TokenAuth{Token: "wrong"}, gitea.WithBaseUrl(s.URL))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	_, err = p.GetRepo(context.Background())
+	g.Expect(err).To(MatchError(ContainSubstring("401")))
+}
+
+func TestCreateRepo(t *testing.T) {
+	tests := []struct {
+		name     string
+		owner    string
+		personal bool
+		wantOrg  string
+	}{
+		{name: "organization", owner: "platform", wantOrg: "platform"},
+		{name: "personal", owner: "jeff", personal: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := context.Background()
+			f, s := newFakeGitea(t)
+			config := &v1alpha1.GiteaProviderConfig{Owner: tt.owner, Repository: "fleet", Personal: tt.personal}
+			p := newProvider(t, s, config)
+
+			repo, err := p.CreateRepo(ctx, git.CreateRepoOpts{Name: "fleet", Owner: tt.owner, Personal: tt.personal, Privacy: true})
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(repo.Owner).To(Equal(tt.owner))
+			g.Expect(repo.Organization).To(Equal(tt.wantOrg))
+			g.Expect(f.repos).To(HaveKey(tt.owner + "/fleet"))
+
+			got, err := p.GetRepo(ctx)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(got).To(Equal(repo))
+		})
+	}
+}
+
+func TestCreateRepoUnknownOrganization(t *testing.T) {
+	g := NewWithT(t)
+	_, s := newFakeGitea(t)
+	p := newProvider(t, s, &v1alpha1.GiteaProviderConfig{Owner: "unknown", Repository: "fleet"})
+
+	_, err := p.CreateRepo(context.Background(), git.CreateRepoOpts{Name: "fleet", Owner: "unknown", Privacy: true})
+	g.Expect(err).To(MatchError(ContainSubstring("creating repository fleet")))
+}
+
+func TestDeleteRepo(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	f, s := newFakeGitea(t)
+	f.addRepo("platform", "fleet")
+	p := newProvider(t, s, &v1alpha1.GiteaProviderConfig{Owner: "platform", Repository: "fleet"})
+
+	g.Expect(p.DeleteRepo(ctx, git.DeleteRepoOpts{Owner: "platform", Repository: "fleet"})).To(Succeed())
+	g.Expect(f.repos).To(BeEmpty())
+	g.Expect(p.DeleteRepo(ctx, git.DeleteRepoOpts{Owner: "platform", Repository: "fleet"})).To(MatchError(ContainSubstring("404")))
+}
+
+func TestAddDeployKeyToRepo(t *testing.T) {
+	g := NewWithT(t)
+	f, s := newFakeGitea(t)
+	f.addRepo("platform", "fleet")
+	p := newProvider(t, s, &v1alpha1.GiteaProviderConfig{Owner: "platform", Repository: "fleet"})
+
+	err := p.AddDeployKeyToRepo(context.Background(), git.AddDeployKeyOpts{Owner: "platform", Repository: "fleet", Key: "ssh-rsa AAAA", Title: "eks-anywhere-mgmt"})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(f.deployKeys["platform/fleet"]).To(ConsistOf(map[string]interface{}{"title": "eks-anywhere-mgmt", "key": "ssh-rsa AAAA", "read_only": false}))
+}
+
+func TestPullRequest(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	f, s := newFakeGitea(t)
+	f.addRepo("platform", "fleet")
+	p := newProvider(t, s, &v1alpha1.GiteaProviderConfig{Owner: "platform", Repository: "fleet"})
+
+	pr, err := p.CreatePullRequest(ctx, git.CreatePullRequestOpts{Owner: "platform", Repository: "fleet", Title: "Upgrade", Head: "eksa-upgrade", Base: "main"})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(pr).To(Equal(&git.PullRequest{Number: 1, Url: "https://gitea.example.com/platform/fleet/pulls/1"}))
+	g.Expect(f.pulls["platform/fleet"][0]).To(HaveKeyWithValue("head", "eksa-upgrade"))
+	g.Expect(f.pulls["platform/fleet"][0]).To(HaveKeyWithValue("base", "main"))
+
+	f.pulls["platform/fleet"][0]["merged"] = true
+	f.pulls["platform/fleet"][0]["state"] = "closed"
+	pr, err = p.GetPullRequest(ctx, git.GetPullRequestOpts{Owner: "platform", Repository: "fleet", Number: 1})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(pr).To(Equal(&git.PullRequest{Number: 1, Url: "https://gitea.example.com/platform/fleet/pulls/1", Merged: true, Closed: true}))
+
+	_, err = p.GetPullRequest(ctx, git.GetPullRequestOpts{Owner: "platform", Repository: "fleet", Number: 2})
+	g.Expect(err).To(MatchError(ContainSubstring("describing pull request 2 in repository fleet")))
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		owner    string
+		personal bool
+		wantErr  string
+	}{
+		{name: "personal", owner: "Jeff", personal: true},
+		{name: "organization", owner: "platform"},
+		{name: "wrong personal owner", owner: "nobody", personal: true, wantErr: "the authenticated Gitea user and owner nobody specified in the EKS-A gitops spec don't match"},
+		{name: "unknown organization", owner: "hidden", wantErr: "the authenticated gitea user doesn't have proper access to gitea organization hidden"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			_, s := newFakeGitea(t)
+			err := newProvider(t, s, &v1alpha1.GiteaProviderConfig{Owner: tt.owner, Repository: "fleet", Personal: tt.personal}).Validate(context.Background())
+			if tt.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestPathExists(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	f, s := newFakeGitea(t)
+	f.addRepo("platform", "fleet")
+	f.files["platform/fleet@main"] = []string{"clusters/mgmt/eksa-system/eksa-cluster.yaml"}
+	p := newProvider(t, s, &v1alpha1.GiteaProviderConfig{Owner: "platform", Repository: "fleet"})
+
+	for path, want := range map[string]bool{
+		"clusters/mgmt": true,
+		"clusters/mgmt/eksa-system/eksa-cluster.yaml": true,
+		"clusters/other": false,
+	} {
+		exists, err := p.PathExists(ctx, "platform", "fleet", "main", path)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(exists).To(Equal(want), path)
+	}
+
+	exists, err := p.PathExists(ctx, "platform", "missing", "main", "clusters")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(exists).To(BeFalse())
+}
+
+func TestRepoUrl(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(gitea.RepoUrl(&v1alpha1.GiteaProviderConfig{Hostname: "gitea.example.com", Owner: "platform", Repository: "fleet"})).To(Equal("https://gitea.example.com/platform/fleet.git"))
+}
+
+func TestGetGiteaAccessTokenFromEnv(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv(gitea.EksaGiteaTokenEnv, "")
+	_, err := gitea.GetGiteaAccessTokenFromEnv()
+	g.Expect(err).To(HaveOccurred())
+
+	t.Setenv(gitea.EksaGiteaTokenEnv, testToken)
+	token, err := gitea.GetGiteaAccessTokenFromEnv()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(token).To(Equal(testToken))
+}
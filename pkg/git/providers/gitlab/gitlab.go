@@ -0,0 +1,336 @@
+package gitlab
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const (
+	GitProviderName    = "gitlab"
+	EksaGitlabTokenEnv = "EKSA_GITLAB_TOKEN"
+	gitlabUrlTemplate  = "https://%v/%v/%v.git"
+	apiPath            = "/api/v4"
+	tokenHeader        = "PRIVATE-TOKEN"
+)
+
+type gitlabProvider struct {
+	client  *http.Client
+	baseUrl string
+	config  *v1alpha1.GitlabProviderConfig
+	auth    git.TokenAuth
+}
+
+// Opt configures the GitLab provider.
+type Opt func(*gitlabProvider)
+
+// WithBaseUrl sets the url of the GitLab instance, instead of https://<hostname>.
+func WithBaseUrl(baseUrl string) Opt {
+	return func(g *gitlabProvider) {
+		g.baseUrl = strings.TrimSuffix(baseUrl, "/")
+	}
+}
+
+// WithHTTPClient sets the http client used to call the GitLab API.
+func WithHTTPClient(client *http.Client) Opt {
+	return func(g *gitlabProvider) {
+		g.client = client
+	}
+}
+
+// New returns a git.ProviderClient for a GitLab instance, authenticated with a personal, group or project access token.
+func New(config *v1alpha1.GitlabProviderConfig, auth git.TokenAuth, opts ...Opt) (*gitlabProvider, error) {
+	g := &gitlabProvider{
+		client:  http.DefaultClient,
+		baseUrl: "https://" + hostname(config),
+		config:  config,
+		auth:    auth,
+	}
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g, nil
+}
+
+type project struct {
+	ID            int    `json:"id"`
+	Name          string `json:"name"`
+	SshUrlToRepo  string `json:"ssh_url_to_repo"`
+	HttpUrlToRepo string `json:"http_url_to_repo"`
+	Namespace     struct {
+		FullPath string `json:"full_path"`
+		Kind     string `json:"kind"`
+	} `json:"namespace"`
+}
+
+func (p *project) repository() *git.Repository {
+	r := &git.Repository{
+		Name:     p.Name,
+		Owner:    p.Namespace.FullPath,
+		CloneUrl: p.HttpUrlToRepo,
+		SshUrl:   git.SshUrl(p.SshUrlToRepo),
+	}
+	if p.Namespace.Kind == "group" {
+		r.Organization = p.Namespace.FullPath
+	}
+	return r
+}
+
+type namespace struct {
+	ID int `json:"id"`
+}
+
+type user struct {
+	Username string `json:"username"`
+}
+
+type treeEntry struct {
+	Name string `json:"name"`
+}
+
+// GetRepo describes the configured remote repository.
+// If the repo does not exist, a nil repo is returned.
+func (g *gitlabProvider) GetRepo(ctx context.Context) (*git.Repository, error) {
+	r := g.config.Repository
+	o := g.config.Owner
+	logger.V(3).Info("Describing GitLab repository", "name", r, "owner", o)
+	p := &project{}
+	err := g.do(ctx, http.MethodGet, projectPath(o, r), nil, p)
+	if isNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unexpected error when describing repository %s: %w", r, err)
+	}
+	return p.repository(), nil
+}
+
+// CreateRepo creates an empty GitLab project in the owner's group, or in the user's namespace if it's personal.
+func (g *gitlabProvider) CreateRepo(ctx context.Context, opts git.CreateRepoOpts) (*git.Repository, error) {
+	logger.V(3).Info("Creating GitLab repository", "name", opts.Name, "owner", opts.Owner)
+	visibility := "public"
+	if opts.Privacy {
+		visibility = "private"
+	}
+	body := map[string]interface{}{
+		"name":                   opts.Name,
+		"path":                   opts.Name,
+		"description":            opts.Description,
+		"visibility":             visibility,
+		"initialize_with_readme": opts.AutoInit,
+	}
+	if !opts.Personal {
+		ns := &namespace{}
+		if err := g.do(ctx, http.MethodGet, "/namespaces/"+url.PathEscape(opts.Owner), nil, ns); err != nil {
+			return nil, fmt.Errorf("getting gitlab namespace %s: %v", opts.Owner, err)
+		}
+		body["namespace_id"] = ns.ID
+	}
+
+	p := &project{}
+	if err := g.do(ctx, http.MethodPost, "/projects", body, p); err != nil {
+		return nil, fmt.Errorf("creating repository %s: %v", opts.Name, err)
+	}
+	return p.repository(), nil
+}
+
+// DeleteRepo deletes a GitLab project.
+func (g *gitlabProvider) DeleteRepo(ctx context.Context, opts git.DeleteRepoOpts) error {
+	logger.V(3).Info("Deleting GitLab repository", "name", opts.Repository, "owner", opts.Owner)
+	if err := g.do(ctx, http.MethodDelete, projectPath(opts.Owner, opts.Repository), nil, nil); err != nil {
+		return fmt.Errorf("deleting repository %s: %v", opts.Repository, err)
+	}
+	return nil
+}
+
+// AddDeployKeyToRepo registers a deploy key in a GitLab project, with write access unless it's read only.
+func (g *gitlabProvider) AddDeployKeyToRepo(ctx context.Context, opts git.AddDeployKeyOpts) error {
+	logger.V(3).Info("Adding deploy key to repository", "repository", opts.Repository, "owner", opts.Owner)
+	body := map[string]interface{}{
+		"title":    opts.Title,
+		"key":      opts.Key,
+		"can_push": !opts.ReadOnly,
+	}
+	if err := g.do(ctx, http.MethodPost, projectPath(opts.Owner, opts.Repository)+"/deploy_keys", body, nil); err != nil {
+		return fmt.Errorf("adding deploy key to repository %s: %v", opts.Repository, err)
+	}
+	return nil
+}
+
+// Validate validates the GitLab access token and that its user can access the owner.
+func (g *gitlabProvider) Validate(ctx context.Context) error {
+	u := &user{}
+	if err := g.do(ctx, http.MethodGet, "/user", nil, u); err != nil {
+		return fmt.Errorf("failed while getting the authenticated gitlab user: %v", err)
+	}
+	if g.config.Personal {
+		if !strings.EqualFold(g.config.Owner, u.Username) {
+			return fmt.Errorf("the authenticated GitLab user and owner %s specified in the EKS-A gitops spec don't match; confirm access token owner is %s", g.config.Owner, g.config.Owner)
+		}
+		return nil
+	}
+	if err := g.do(ctx, http.MethodGet, "/groups/"+url.PathEscape(g.config.Owner), nil, nil); err != nil {
+		return fmt.Errorf("the authenticated gitlab user doesn't have proper access to gitlab group %s, %v", g.config.Owner, err)
+	}
+	return nil
+}
+
+// PathExists checks if a path exists in the remote repository. If the owner, repository or branch doesn't exist,
+// it returns false and no error.
+func (g *gitlabProvider) PathExists(ctx context.Context, owner, repo, branch, p string) (bool, error) {
+	p = strings.Trim(path.Clean(p), "/")
+	query := url.Values{"ref": {branch}, "path": {p}}
+	var entries []treeEntry
+	err := g.do(ctx, http.MethodGet, projectPath(owner, repo)+"/repository/tree?"+query.Encode(), nil, &entries)
+	if err != nil && !isNotFound(err) {
+		return false, fmt.Errorf("failed checking if path %s exists in remote gitlab repository: %v", p, err)
+	}
+	if len(entries) > 0 {
+		return true, nil
+	}
+
+	// The tree of a file is empty, so check if the path is a file instead.
+	query = url.Values{"ref": {branch}}
+	err = g.do(ctx, http.MethodHead, projectPath(owner, repo)+"/repository/files/"+url.PathEscape(p)+"?"+query.Encode(), nil, nil)
+	if isNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed checking if path %s exists in remote gitlab repository: %v", p, err)
+	}
+	return true, nil
+}
+
+type mergeRequest struct {
+	Iid    int    `json:"iid"`
+	WebUrl string `json:"web_url"`
+	State  string `json:"state"`
+}
+
+func (mr *mergeRequest) pullRequest() *git.PullRequest {
+	return &git.PullRequest{
+		Number: mr.Iid,
+		Url:    mr.WebUrl,
+		Merged: mr.State == "merged",
+		Closed: mr.State == "closed",
+	}
+}
+
+// CreatePullRequest opens a merge request to merge the head branch into the base branch.
+func (g *gitlabProvider) CreatePullRequest(ctx context.Context, opts git.CreatePullRequestOpts) (*git.PullRequest, error) {
+	logger.V(3).Info("Creating GitLab merge request", "repository", opts.Repository, "owner", opts.Owner, "head", opts.Head, "base", opts.Base)
+	body := map[string]interface{}{
+		"title":                opts.Title,
+		"description":          opts.Description,
+		"source_branch":        opts.Head,
+		"target_branch":        opts.Base,
+		"remove_source_branch": true,
+	}
+	mr := &mergeRequest{}
+	if err := g.do(ctx, http.MethodPost, projectPath(opts.Owner, opts.Repository)+"/merge_requests", body, mr); err != nil {
+		return nil, fmt.Errorf("creating merge request in repository %s: %v", opts.Repository, err)
+	}
+	return mr.pullRequest(), nil
+}
+
+// GetPullRequest describes a merge request by its project-scoped number (iid).
+func (g *gitlabProvider) GetPullRequest(ctx context.Context, opts git.GetPullRequestOpts) (*git.PullRequest, error) {
+	mr := &mergeRequest{}
+	if err := g.do(ctx, http.MethodGet, fmt.Sprintf("%s/merge_requests/%d", projectPath(opts.Owner, opts.Repository), opts.Number), nil, mr); err != nil {
+		return nil, fmt.Errorf("describing merge request %d in repository %s: %v", opts.Number, opts.Repository, err)
+	}
+	return mr.pullRequest(), nil
+}
+
+func (g *gitlabProvider) do(ctx context.Context, method, p string, body, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("marshalling request: %v", err)
+		}
+		reader = bytes.NewReader(b)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, g.baseUrl+apiPath+p, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set(tokenHeader, g.auth.Token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("reading response: %v", err)
+	}
+	if resp.StatusCode >= 300 {
+		return &apiError{method: method, path: p, status: resp.StatusCode, message: strings.TrimSpace(string(content))}
+	}
+	if out == nil || len(content) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(content, out); err != nil {
+		return fmt.Errorf("unmarshalling response of %s %s: %v", method, p, err)
+	}
+	return nil
+}
+
+type apiError struct {
+	method  string
+	path    string
+	status  int
+	message string
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("%s %s: %d %s", e.method, e.path, e.status, e.message)
+}
+
+func isNotFound(err error) bool {
+	var e *apiError
+	return errors.As(err, &e) && e.status == http.StatusNotFound
+}
+
+func projectPath(owner, repo string) string {
+	return "/projects/" + url.PathEscape(owner+"/"+repo)
+}
+
+func hostname(config *v1alpha1.GitlabProviderConfig) string {
+	if config.Hostname == "" {
+		return v1alpha1.FluxDefaultGitlabHostname
+	}
+	return config.Hostname
+}
+
+// GetGitlabAccessTokenFromEnv returns the GitLab access token from EKSA_GITLAB_TOKEN.
+func GetGitlabAccessTokenFromEnv() (string, error) {
+	val, ok := os.LookupEnv(EksaGitlabTokenEnv)
+	if !ok || len(val) == 0 {
+		return "", fmt.Errorf("gitlab access token environment variable %s is invalid; could not get var from environment", EksaGitlabTokenEnv)
+	}
+	return val, nil
+}
+
+// RepoUrl returns the https url of a repository in a GitLab instance.
+func RepoUrl(config *v1alpha1.GitlabProviderConfig) string {
+	return fmt.Sprintf(gitlabUrlTemplate, hostname(config), config.Owner, config.Repository)
+}
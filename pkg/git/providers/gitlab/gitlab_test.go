@@ -0,0 +1,382 @@
+package gitlab_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/git/providers/gitlab"
+)
+
+const testToken = "glpat-test"
+
+// fakeGitlab is a stand-in for the GitLab v4 API, with a user, its groups and their projects.
+type fakeGitlab struct {
+	sync.Mutex
+	username   string
+	groups     map[string]int
+	projects   map[string]map[string]interface{}
+	files      map[string][]string
+	deployKeys map[string][]map[string]interface{}
+	mrs        map[string][]map[string]interface{}
+	nextID     int
+}
+
+func newFakeGitlab(t *testing.T) (*fakeGitlab, *httptest.Server) {
+	f := &fakeGitlab{
+		username:   "jeff",
+		groups:     map[string]int{"platform": 10, "platform/clusters": 11},
+		projects:   map[string]map[string]interface{}{},
+		files:      map[string][]string{},
+		deployKeys: map[string][]map[string]interface{}{},
+		mrs:        map[string][]map[string]interface{}{},
+		nextID:     100,
+	}
+	s := httptest.NewServer(f)
+	t.Cleanup(s.Close)
+	return f, s
+}
+
+func (f *fakeGitlab) addProject(namespace, kind, name string) {
+	f.nextID++
+	f.projects[namespace+"/"+name] = map[string]interface{}{
+		"id":               f.nextID,
+		"name":             name,
+		"ssh_url_to_repo":  "git@gitlab.example.com:" + namespace + "/" + name + ".git",
+		"http_url_to_repo": "https://gitlab.example.com/" + namespace + "/" + name + ".git",
+		"namespace":        map[string]interface{}{"full_path": namespace, "kind": kind},
+	}
+}
+
+func (f *fakeGitlab) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.Lock()
+	defer f.Unlock()
+	if r.Header.Get("PRIVATE-TOKEN") != testToken {
+		http.Error(w, `{"message":"401 Unauthorized"}`, http.StatusUnauthorized)
+		return
+	}
+
+	p := strings.TrimPrefix(r.URL.EscapedPath(), "/api/v4")
+	segments := strings.Split(strings.TrimPrefix(p, "/"), "/")
+	unescape := func(s string) string {
+		u, _ := url.PathUnescape(s)
+		return u
+	}
+	switch {
+	case r.Method == http.MethodGet && p == "/user":
+		writeJSON(w, map[string]string{"username": f.username})
+	case r.Method == http.MethodGet && len(segments) == 2 && (segments[0] == "groups" || segments[0] == "namespaces"):
+		id, ok := f.groups[unescape(segments[1])]
+		if !ok {
+			http.Error(w, `{"message":"404 Group Not Found"}`, http.StatusNotFound)
+			return
+		}
+		writeJSON(w, map[string]int{"id": id})
+	case r.Method == http.MethodPost && p == "/projects":
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		namespace, kind := f.username, "user"
+		if id, ok := body["namespace_id"]; ok {
+			for name, groupID := range f.groups {
+				if float64(groupID) == id {
+					namespace, kind = name, "group"
+				}
+			}
+		}
+		if body["visibility"] != "private" {
+			http.Error(w, `{"message":"expected a private project"}`, http.StatusBadRequest)
+			return
+		}
+		f.addProject(namespace, kind, body["path"].(string))
+		w.WriteHeader(http.StatusCreated)
+		writeJSON(w, f.projects[namespace+"/"+body["path"].(string)])
+	case len(segments) >= 2 && segments[0] == "projects":
+		id := unescape(segments[1])
+		project, ok := f.projects[id]
+		if !ok {
+			http.Error(w, `{"message":"404 Project Not Found"}`, http.StatusNotFound)
+			return
+		}
+		rest := strings.Join(segments[2:], "/")
+		switch {
+		case r.Method == http.MethodGet && rest == "":
+			writeJSON(w, project)
+		case r.Method == http.MethodDelete && rest == "":
+			delete(f.projects, id)
+			w.WriteHeader(http.StatusAccepted)
+		case r.Method == http.MethodPost && rest == "deploy_keys":
+			var body map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			f.deployKeys[id] = append(f.deployKeys[id], body)
+			w.WriteHeader(http.StatusCreated)
+			writeJSON(w, body)
+		case r.Method == http.MethodPost && rest == "merge_requests":
+			var body map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			iid := len(f.mrs[id]) + 1
+			body["iid"] = iid
+			body["web_url"] = fmt.Sprintf("https://gitlab.example.com/%s/-/merge_requests/%d", id, iid)
+			body["state"] = "opened"
+			f.mrs[id] = append(f.mrs[id], body)
+			w.WriteHeader(http.StatusCreated)
+			writeJSON(w, body)
+		case r.Method == http.MethodGet && strings.HasPrefix(rest, "merge_requests/"):
+			iid, _ := strconv.Atoi(strings.TrimPrefix(rest, "merge_requests/"))
+			if iid < 1 || iid > len(f.mrs[id]) {
+				http.Error(w, `{"message":"404 Not found"}`, http.StatusNotFound)
+				return
+			}
+			writeJSON(w, f.mrs[id][iid-1])
+		case r.Method == http.MethodGet && rest == "repository/tree":
+			f.tree(w, id, r.URL.Query().Get("ref"), r.URL.Query().Get("path"))
+		case r.Method == http.MethodHead && strings.HasPrefix(rest, "repository/files/"):
+			for _, file := range f.files[id+"@"+r.URL.Query().Get("ref")] {
+				if file == unescape(strings.TrimPrefix(rest, "repository/files/")) {
+					return
+				}
+			}
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			http.NotFound(w, r)
+		}
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// tree lists the entries of a directory, like GitLab an unknown directory is not found and a file has an empty tree.
+func (f *fakeGitlab) tree(w http.ResponseWriter, id, ref, dir string) {
+	entries := []map[string]string{}
+	found := false
+	for _, file := range f.files[id+"@"+ref] {
+		if file == dir {
+			found = true
+		}
+		if strings.HasPrefix(file, dir+"/") {
+			found = true
+			entries = append(entries, map[string]string{"name": strings.Split(strings.TrimPrefix(file, dir+"/"), "/")[0]})
+		}
+	}
+	if !found {
+		http.Error(w, `{"message":"404 Tree Not Found"}`, http.StatusNotFound)
+		return
+	}
+	writeJSON(w, entries)
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func newProvider(t *testing.T, s *httptest.Server, config *v1alpha1.GitlabProviderConfig) git.ProviderClient {
+	p, err := gitlab.New(config, git.TokenAuth{Token: testToken, Username: config.Owner}, gitlab.WithBaseUrl(s.URL), gitlab.WithHTTPClient(s.Client()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestGetRepo(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	f, s := newFakeGitlab(t)
+	f.addProject("platform/clusters", "group", "fleet")
+
+	repo, err := newProvider(t, s, &v1alpha1.GitlabProviderConfig{Owner: "platform/clusters", Repository: "fleet"}).GetRepo(ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(repo).To(Equal(&git.Repository{
+		Name:         "fleet",
+		Owner:        "platform/clusters",
+		Organization: "platform/clusters",
+		CloneUrl:     "https://gitlab.example.com/platform/clusters/fleet.git",
+		SshUrl:       "ssh://git@gitlab.example.com/platform/clusters/fleet.git",
+	}))
+
+	repo, err = newProvider(t, s, &v1alpha1.GitlabProviderConfig{Owner: "platform", Repository: "missing"}).GetRepo(ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(repo).To(BeNil())
+}
+
+func TestGetRepoUnauthorized(t *testing.T) {
+	g := NewWithT(t)
+	_, s := newFakeGitlab(t)
+	p, err := gitlab.New(&v1alpha1.GitlabProviderConfig{Owner: "platform", Repository: "fleet"}, git.TokenAuth{Token: "wrong"}, gitlab.WithBaseUrl(s.URL))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	_, err = p.GetRepo(context.Background())
+	g.Expect(err).To(MatchError(ContainSubstring("401")))
+}
+
+func TestCreateRepo(t *testing.T) {
+	tests := []struct {
+		name      string
+		owner     string
+		personal  bool
+		wantOwner string
+		wantOrg   string
+	}{
+		{name: "group", owner: "platform", wantOwner: "platform", wantOrg: "platform"},
+		{name: "subgroup", owner: "platform/clusters", wantOwner: "platform/clusters", wantOrg: "platform/clusters"},
+		{name: "personal", owner: "jeff", personal: true, wantOwner: "jeff"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := context.Background()
+			f, s := newFakeGitlab(t)
+			config := &v1alpha1.GitlabProviderConfig{Owner: tt.owner, Repository: "fleet", Personal: tt.personal}
+			p := newProvider(t, s, config)
+
+			repo, err := p.CreateRepo(ctx, git.CreateRepoOpts{Name: "fleet", Owner: tt.owner, Personal: tt.personal, Privacy: true})
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(repo.Owner).To(Equal(tt.wantOwner))
+			g.Expect(repo.Organization).To(Equal(tt.wantOrg))
+			g.Expect(f.projects).To(HaveKey(tt.wantOwner + "/fleet"))
+
+			got, err := p.GetRepo(ctx)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(got).To(Equal(repo))
+		})
+	}
+}
+
+func TestCreateRepoUnknownGroup(t *testing.T) {
+	g := NewWithT(t)
+	_, s := newFakeGitlab(t)
+	p := newProvider(t, s, &v1alpha1.GitlabProviderConfig{Owner: "unknown", Repository: "fleet"})
+
+	_, err := p.CreateRepo(context.Background(), git.CreateRepoOpts{Name: "fleet", Owner: "unknown", Privacy: true})
+	g.Expect(err).To(MatchError(ContainSubstring("getting gitlab namespace unknown")))
+}
+
+func TestDeleteRepo(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	f, s := newFakeGitlab(t)
+	f.addProject("platform", "group", "fleet")
+	p := newProvider(t, s, &v1alpha1.GitlabProviderConfig{Owner: "platform", Repository: "fleet"})
+
+	g.Expect(p.DeleteRepo(ctx, git.DeleteRepoOpts{Owner: "platform", Repository: "fleet"})).To(Succeed())
+	g.Expect(f.projects).To(BeEmpty())
+	g.Expect(p.DeleteRepo(ctx, git.DeleteRepoOpts{Owner: "platform", Repository: "fleet"})).To(MatchError(ContainSubstring("404")))
+}
+
+func TestAddDeployKeyToRepo(t *testing.T) {
+	g := NewWithT(t)
+	f, s := newFakeGitlab(t)
+	f.addProject("platform", "group", "fleet")
+	p := newProvider(t, s, &v1alpha1.GitlabProviderConfig{Owner: "platform", Repository: "fleet"})
+
+	err := p.AddDeployKeyToRepo(context.Background(), git.AddDeployKeyOpts{Owner: "platform", Repository: "fleet", Key: "ssh-rsa AAAA", Title: "eks-anywhere-mgmt"})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(f.deployKeys["platform/fleet"]).To(ConsistOf(map[string]interface{}{"title": "eks-anywhere-mgmt", "key": "ssh-rsa AAAA", "can_push": true}))
+}
+
+func TestPullRequest(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	f, s := newFakeGitlab(t)
+	f.addProject("platform/clusters", "group", "fleet")
+	p := newProvider(t, s, &v1alpha1.GitlabProviderConfig{Owner: "platform/clusters", Repository: "fleet"})
+
+	pr, err := p.CreatePullRequest(ctx, git.CreatePullRequestOpts{Owner: "platform/clusters", Repository: "fleet", Title: "Upgrade", Head: "eksa-upgrade", Base: "main"})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(pr).To(Equal(&git.PullRequest{Number: 1, Url: "https://gitlab.example.com/platform/clusters/fleet/-/merge_requests/1"}))
+	g.Expect(f.mrs["platform/clusters/fleet"][0]).To(HaveKeyWithValue("source_branch", "eksa-upgrade"))
+	g.Expect(f.mrs["platform/clusters/fleet"][0]).To(HaveKeyWithValue("target_branch", "main"))
+
+	f.mrs["platform/clusters/fleet"][0]["state"] = "merged"
+	pr, err = p.GetPullRequest(ctx, git.GetPullRequestOpts{Owner: "platform/clusters", Repository: "fleet", Number: 1})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(pr.Merged).To(BeTrue())
+	g.Expect(pr.Closed).To(BeFalse())
+
+	_, err = p.GetPullRequest(ctx, git.GetPullRequestOpts{Owner: "platform/clusters", Repository: "fleet", Number: 2})
+	g.Expect(err).To(MatchError(ContainSubstring("describing merge request 2 in repository fleet")))
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		owner    string
+		personal bool
+		wantErr  string
+	}{
+		{name: "personal", owner: "Jeff", personal: true},
+		{name: "group", owner: "platform/clusters"},
+		{name: "wrong personal owner", owner: "nobody", personal: true, wantErr: "the authenticated GitLab user and owner nobody specified in the EKS-A gitops spec don't match"},
+		{name: "unknown group", owner: "hidden", wantErr: "the authenticated gitlab user doesn't have proper access to gitlab group hidden"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			_, s := newFakeGitlab(t)
+			err := newProvider(t, s, &v1alpha1.GitlabProviderConfig{Owner: tt.owner, Repository: "fleet", Personal: tt.personal}).Validate(context.Background())
+			if tt.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestPathExists(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	f, s := newFakeGitlab(t)
+	f.addProject("platform", "group", "fleet")
+	f.files["platform/fleet@main"] = []string{"clusters/mgmt/eksa-system/eksa-cluster.yaml", "README.md"}
+	p := newProvider(t, s, &v1alpha1.GitlabProviderConfig{Owner: "platform", Repository: "fleet"})
+
+	for path, want := range map[string]bool{
+		"clusters":      true,
+		"clusters/mgmt": true,
+		"clusters/mgmt/eksa-system/eksa-cluster.yaml": true,
+		"README.md":      true,
+		"clusters/other": false,
+		"missing.yaml":   false,
+	} {
+		exists, err := p.PathExists(ctx, "platform", "fleet", "main", path)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(exists).To(Equal(want), path)
+	}
+
+	exists, err := p.PathExists(ctx, "platform", "fleet", "other-branch", "clusters")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(exists).To(BeFalse())
+
+	exists, err = p.PathExists(ctx, "platform", "missing", "main", "clusters")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(exists).To(BeFalse())
+}
+
+func TestRepoUrl(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(gitlab.RepoUrl(&v1alpha1.GitlabProviderConfig{Owner: "platform", Repository: "fleet"})).To(Equal("https://gitlab.com/platform/fleet.git"))
+	g.Expect(gitlab.RepoUrl(&v1alpha1.GitlabProviderConfig{Hostname: "gitlab.example.com", Owner: "platform/clusters", Repository: "fleet"})).To(Equal("https://gitlab.example.com/platform/clusters/fleet.git"))
+}
+
+func TestGetGitlabAccessTokenFromEnv(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv(gitlab.EksaGitlabTokenEnv, "")
+	_, err := gitlab.GetGitlabAccessTokenFromEnv()
+	g.Expect(err).To(HaveOccurred())
+
+	t.Setenv(gitlab.EksaGitlabTokenEnv, testToken)
+	token, err := gitlab.GetGitlabAccessTokenFromEnv()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(token).To(Equal(testToken))
+}
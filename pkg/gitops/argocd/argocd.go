@@ -0,0 +1,392 @@
+package argocd
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"os"
+	"path"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/config"
+	"github.com/aws/eks-anywhere/pkg/git/providers/gitea"
+	"github.com/aws/eks-anywhere/pkg/git/providers/github"
+	"github.com/aws/eks-anywhere/pkg/git/providers/gitlab"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/manifests"
+	"github.com/aws/eks-anywhere/pkg/manifests/bundles"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/templater"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations"
+)
+
+//go:embed manifests/application.yaml
+var applicationTemplate string
+
+const (
+	// Namespace is the namespace Argo CD is installed in. The upstream install manifest hardcodes it.
+	Namespace = "argocd"
+
+	applicationResourceType = "applications.argoproj.io"
+	refreshAnnotation       = "argocd.argoproj.io/refresh"
+	secretTypeLabel         = "argocd.argoproj.io/secret-type"
+	knownHostsConfigMapName = "argocd-ssh-known-hosts-cm"
+
+	eksaSystemDirName  = "eksa-system"
+	tokenAuthUsername  = "oauth2"
+	inClusterServer    = "https://kubernetes.default.svc"
+	deploymentWaitTime = "10m"
+
+	pauseSyncPatch  = `{"spec":{"syncPolicy":{"automated":null}}}`
+	resumeSyncPatch = `{"spec":{"syncPolicy":{"automated":{"selfHeal":true}}}}`
+)
+
+var deployments = []string{"argocd-repo-server", "argocd-server"}
+
+// KubeClient is an interface that abstracts the kubectl commands used to install and drive Argo CD.
+type KubeClient interface {
+	CreateNamespaceIfNotPresent(ctx context.Context, kubeconfig string, namespace string) error
+	ApplyKubeSpecFromBytesWithNamespace(ctx context.Context, cluster *types.Cluster, data []byte, namespace string) error
+	ApplyKubeSpecFromBytes(ctx context.Context, cluster *types.Cluster, data []byte) error
+	WaitForDeployment(ctx context.Context, cluster *types.Cluster, timeout string, condition string, target string, namespace string) error
+	MergePatchResource(ctx context.Context, resource, name, patch, kubeconfig, namespace string) error
+	UpdateAnnotationInNamespace(ctx context.Context, resourceType, objectName string, annotations map[string]string, cluster *types.Cluster, namespace string) error
+	Delete(ctx context.Context, resourceType, kubeconfig string, opts ...kubernetes.KubectlDeleteOption) error
+}
+
+// Repository writes the cluster configuration to the git repository Argo CD syncs from.
+// The Flux GitOps manager implements it, since both engines share the same repository layout.
+type Repository interface {
+	CommitClusterConfig(ctx context.Context, clusterSpec *cluster.Spec, datacenterConfig providers.DatacenterConfig, machineConfigs []providers.MachineConfig) error
+	UpdateGitEksaSpec(ctx context.Context, clusterSpec *cluster.Spec, datacenterConfig providers.DatacenterConfig, machineConfigs []providers.MachineConfig) error
+	CleanupGitRepo(ctx context.Context, clusterSpec *cluster.Spec) error
+	Validations(ctx context.Context, clusterSpec *cluster.Spec) []validations.Validation
+	PendingPullRequest() string
+}
+
+// ArgoCD is a GitOps manager that syncs the cluster configuration with Argo CD.
+type ArgoCD struct {
+	kubeClient KubeClient
+	repository Repository
+	reader     manifests.FileReader
+	cliConfig  *config.CliConfig
+}
+
+// New builds an Argo CD GitOps manager. The reader reads the Argo CD install manifest of the bundle.
+func New(kubeClient KubeClient, repository Repository, reader manifests.FileReader, cliConfig *config.CliConfig) *ArgoCD {
+	return &ArgoCD{
+		kubeClient: kubeClient,
+		repository: repository,
+		reader:     reader,
+		cliConfig:  cliConfig,
+	}
+}
+
+// InstallGitOps commits the cluster config to git, installs Argo CD on a new management cluster and
+// creates the Application that syncs the cluster config.
+func (a *ArgoCD) InstallGitOps(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec, datacenterConfig providers.DatacenterConfig, machineConfigs []providers.MachineConfig) error {
+	if clusterSpec.ArgoCDConfig == nil {
+		logger.Info("GitOps field not specified, install Argo CD skipped")
+		return nil
+	}
+
+	if err := a.repository.CommitClusterConfig(ctx, repositorySpec(clusterSpec), datacenterConfig, machineConfigs); err != nil {
+		return err
+	}
+
+	if !cluster.ExistingManagement {
+		if err := a.install(ctx, cluster, clusterSpec); err != nil {
+			return err
+		}
+	}
+
+	// Workload clusters are synced by the Argo CD of their management cluster.
+	argoCDCluster := cluster
+	if clusterSpec.Cluster.IsManaged() && clusterSpec.ManagementCluster != nil {
+		argoCDCluster = clusterSpec.ManagementCluster
+	}
+
+	if err := a.applyRepositorySecret(ctx, argoCDCluster, clusterSpec.ArgoCDConfig); err != nil {
+		return err
+	}
+
+	return a.applyApplication(ctx, argoCDCluster, clusterSpec)
+}
+
+// install applies the Argo CD install manifest of the bundle, which references the images of the bundle,
+// so it works with registry mirrors and air-gapped installs like the other components.
+func (a *ArgoCD) install(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	bundle := clusterSpec.VersionsBundle.ArgoCD
+	if bundle.Manifest.URI == "" {
+		return fmt.Errorf("the bundle of Kubernetes version %s does not include Argo CD", clusterSpec.VersionsBundle.KubeVersion)
+	}
+
+	logger.V(3).Info("Installing Argo CD", "version", bundle.Version)
+	manifest, err := bundles.ReadManifest(a.reader, bundle.Manifest)
+	if err != nil {
+		return fmt.Errorf("reading Argo CD install manifest: %v", err)
+	}
+
+	if err := a.kubeClient.CreateNamespaceIfNotPresent(ctx, cluster.KubeconfigFile, Namespace); err != nil {
+		return fmt.Errorf("creating Argo CD namespace: %v", err)
+	}
+
+	if err := a.kubeClient.ApplyKubeSpecFromBytesWithNamespace(ctx, cluster, manifest.Content, Namespace); err != nil {
+		return fmt.Errorf("installing Argo CD: %v", err)
+	}
+
+	for _, d := range deployments {
+		if err := a.kubeClient.WaitForDeployment(ctx, cluster, deploymentWaitTime, "Available", d, Namespace); err != nil {
+			return fmt.Errorf("waiting for Argo CD deployment %s: %v", d, err)
+		}
+	}
+	return nil
+}
+
+func (a *ArgoCD) applyRepositorySecret(ctx context.Context, cluster *types.Cluster, argoCDConfig *v1alpha1.ArgoCDConfig) error {
+	objs, err := a.repositoryObjects(argoCDConfig)
+	if err != nil {
+		return err
+	}
+
+	content, err := templater.ObjectsToYaml(objs...)
+	if err != nil {
+		return fmt.Errorf("generating Argo CD repository secret: %v", err)
+	}
+
+	if err := a.kubeClient.ApplyKubeSpecFromBytes(ctx, cluster, content); err != nil {
+		return fmt.Errorf("applying Argo CD repository secret: %v", err)
+	}
+	return nil
+}
+
+func (a *ArgoCD) repositoryObjects(argoCDConfig *v1alpha1.ArgoCDConfig) ([]runtime.Object, error) {
+	secret := &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      repositorySecretName(argoCDConfig),
+			Namespace: Namespace,
+			Labels:    map[string]string{secretTypeLabel: "repository"},
+		},
+		StringData: map[string]string{
+			"type": "git",
+			"url":  RepositoryUrl(argoCDConfig),
+		},
+	}
+	objs := []runtime.Object{secret}
+
+	spec := argoCDConfig.Spec
+	switch {
+	case spec.Github != nil:
+		token, err := github.GetGithubAccessTokenFromEnv()
+		if err != nil {
+			return nil, err
+		}
+		secret.StringData["username"] = spec.Github.Owner
+		secret.StringData["password"] = token
+	case spec.Gitlab != nil:
+		token, err := gitlab.GetGitlabAccessTokenFromEnv()
+		if err != nil {
+			return nil, err
+		}
+		secret.StringData["username"] = tokenAuthUsername
+		secret.StringData["password"] = token
+	case spec.Gitea != nil:
+		token, err := gitea.GetGiteaAccessTokenFromEnv()
+		if err != nil {
+			return nil, err
+		}
+		secret.StringData["username"] = tokenAuthUsername
+		secret.StringData["password"] = token
+	case spec.Git != nil:
+		if a.cliConfig == nil {
+			return nil, fmt.Errorf("git private key file is required for the Argo CD git provider")
+		}
+		if a.cliConfig.GitSshKeyPassphrase != "" {
+			return nil, fmt.Errorf("Argo CD does not support git private keys with a passphrase")
+		}
+		key, err := os.ReadFile(a.cliConfig.GitPrivateKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading git private key file: %v", err)
+		}
+		secret.StringData["sshPrivateKey"] = string(key)
+
+		if a.cliConfig.GitKnownHostsFile != "" {
+			knownHosts, err := os.ReadFile(a.cliConfig.GitKnownHostsFile)
+			if err != nil {
+				return nil, fmt.Errorf("reading git known hosts file: %v", err)
+			}
+			objs = append(objs, &corev1.ConfigMap{
+				TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      knownHostsConfigMapName,
+					Namespace: Namespace,
+					Labels: map[string]string{
+						"app.kubernetes.io/name":    knownHostsConfigMapName,
+						"app.kubernetes.io/part-of": "argocd",
+					},
+				},
+				Data: map[string]string{"ssh_known_hosts": string(knownHosts)},
+			})
+		}
+	}
+
+	return objs, nil
+}
+
+func (a *ArgoCD) applyApplication(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	argoCDConfig := clusterSpec.ArgoCDConfig
+	values := map[string]string{
+		"Name":                 ApplicationName(clusterSpec.Cluster.Name),
+		"Namespace":            Namespace,
+		"RepositoryUrl":        RepositoryUrl(argoCDConfig),
+		"Branch":               argoCDConfig.Spec.Branch,
+		"Path":                 path.Join(argoCDConfig.Spec.ClusterConfigPath, clusterSpec.Cluster.Name, eksaSystemDirName),
+		"Server":               inClusterServer,
+		"DestinationNamespace": clusterSpec.Cluster.Namespace,
+	}
+
+	content, err := templater.Execute(applicationTemplate, values)
+	if err != nil {
+		return fmt.Errorf("generating Argo CD application: %v", err)
+	}
+
+	if err := a.kubeClient.ApplyKubeSpecFromBytes(ctx, cluster, content); err != nil {
+		return fmt.Errorf("applying Argo CD application: %v", err)
+	}
+	return nil
+}
+
+// PauseClusterResourcesReconcile disables the automated sync of the cluster Application,
+// so Argo CD doesn't revert the changes the CLI makes to the cluster objects.
+func (a *ArgoCD) PauseClusterResourcesReconcile(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec, provider providers.Provider) error {
+	if clusterSpec.ArgoCDConfig == nil {
+		logger.V(4).Info("GitOps field not specified, pause cluster resources reconcile skipped")
+		return nil
+	}
+
+	logger.V(3).Info("Pause Argo CD sync of cluster resources")
+	if err := a.kubeClient.MergePatchResource(ctx, applicationResourceType, ApplicationName(clusterSpec.Cluster.Name), pauseSyncPatch, cluster.KubeconfigFile, Namespace); err != nil {
+		return fmt.Errorf("pausing Argo CD application sync: %v", err)
+	}
+	return nil
+}
+
+// ResumeClusterResourcesReconcile enables back the automated sync of the cluster Application.
+func (a *ArgoCD) ResumeClusterResourcesReconcile(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec, provider providers.Provider) error {
+	if clusterSpec.ArgoCDConfig == nil {
+		logger.V(4).Info("GitOps field not specified, resume cluster resources reconcile skipped")
+		return nil
+	}
+
+	if pr := a.repository.PendingPullRequest(); pr != "" {
+		logger.Info("Argo CD sync of cluster resources stays paused until the pull request is merged; run upgrade again once merged", "url", pr)
+		return nil
+	}
+
+	logger.V(3).Info("Resume Argo CD sync of cluster resources")
+	if err := a.kubeClient.MergePatchResource(ctx, applicationResourceType, ApplicationName(clusterSpec.Cluster.Name), resumeSyncPatch, cluster.KubeconfigFile, Namespace); err != nil {
+		return fmt.Errorf("resuming Argo CD application sync: %v", err)
+	}
+	return nil
+}
+
+// ForceReconcileGitRepo requests a hard refresh of the cluster Application, so Argo CD fetches the git repository again.
+func (a *ArgoCD) ForceReconcileGitRepo(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	if clusterSpec.ArgoCDConfig == nil {
+		logger.Info("GitOps not configured, force refresh Argo CD application skipped")
+		return nil
+	}
+
+	if pr := a.repository.PendingPullRequest(); pr != "" {
+		logger.V(3).Info("Pull request with cluster config updates not merged yet, force refresh Argo CD application skipped", "url", pr)
+		return nil
+	}
+
+	annotations := map[string]string{refreshAnnotation: "hard"}
+	return a.kubeClient.UpdateAnnotationInNamespace(ctx, applicationResourceType, ApplicationName(clusterSpec.Cluster.Name), annotations, cluster, Namespace)
+}
+
+// UpdateGitEksaSpec writes the updated cluster config to git.
+func (a *ArgoCD) UpdateGitEksaSpec(ctx context.Context, clusterSpec *cluster.Spec, datacenterConfig providers.DatacenterConfig, machineConfigs []providers.MachineConfig) error {
+	if clusterSpec.ArgoCDConfig == nil {
+		logger.Info("GitOps field not specified, update git repo skipped")
+		return nil
+	}
+
+	return a.repository.UpdateGitEksaSpec(ctx, repositorySpec(clusterSpec), datacenterConfig, machineConfigs)
+}
+
+// Validations returns the validations of the git repository.
+func (a *ArgoCD) Validations(ctx context.Context, clusterSpec *cluster.Spec) []validations.Validation {
+	if clusterSpec.ArgoCDConfig == nil {
+		return nil
+	}
+
+	return a.repository.Validations(ctx, repositorySpec(clusterSpec))
+}
+
+// CleanupGitRepo removes the cluster config from git and, for workload clusters, the cluster Application
+// from the management cluster.
+func (a *ArgoCD) CleanupGitRepo(ctx context.Context, clusterSpec *cluster.Spec) error {
+	if clusterSpec.ArgoCDConfig == nil {
+		logger.Info("GitOps field not specified, clean up git repo skipped")
+		return nil
+	}
+
+	if clusterSpec.Cluster.IsManaged() && clusterSpec.ManagementCluster != nil {
+		opts := &kubernetes.KubectlDeleteOptions{
+			Name:      ApplicationName(clusterSpec.Cluster.Name),
+			Namespace: Namespace,
+		}
+		err := a.kubeClient.Delete(ctx, applicationResourceType, clusterSpec.ManagementCluster.KubeconfigFile, opts)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("deleting Argo CD application: %v", err)
+		}
+	}
+
+	return a.repository.CleanupGitRepo(ctx, repositorySpec(clusterSpec))
+}
+
+// ApplicationName returns the name of the Argo CD Application that syncs the config of a cluster.
+func ApplicationName(clusterName string) string {
+	return "eksa-" + clusterName
+}
+
+// RepositoryUrl returns the url of the git repository Argo CD syncs from.
+func RepositoryUrl(argoCDConfig *v1alpha1.ArgoCDConfig) string {
+	spec := argoCDConfig.Spec
+	switch {
+	case spec.Github != nil:
+		return github.RepoUrl(spec.Github.Owner, spec.Github.Repository)
+	case spec.Gitlab != nil:
+		return gitlab.RepoUrl(spec.Gitlab)
+	case spec.Gitea != nil:
+		return gitea.RepoUrl(spec.Gitea)
+	case spec.Git != nil:
+		return spec.Git.RepositoryUrl
+	}
+	return ""
+}
+
+func repositorySecretName(argoCDConfig *v1alpha1.ArgoCDConfig) string {
+	return "eksa-repo-" + argoCDConfig.Name
+}
+
+// repositorySpec returns a shallow copy of the spec with a FluxConfig pointing to the same git repository,
+// which is what the repository writer reads.
+func repositorySpec(clusterSpec *cluster.Spec) *cluster.Spec {
+	s := *clusterSpec
+	c := *clusterSpec.Config
+	c.FluxConfig = clusterSpec.ArgoCDConfig.ConvertToFluxConfig()
+	s.Config = &c
+	return &s
+}
@@ -0,0 +1,372 @@
+package argocd_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/config"
+	"github.com/aws/eks-anywhere/pkg/files"
+	"github.com/aws/eks-anywhere/pkg/git/providers/gitea"
+	"github.com/aws/eks-anywhere/pkg/gitops/argocd"
+	"github.com/aws/eks-anywhere/pkg/gitops/argocd/mocks"
+	"github.com/aws/eks-anywhere/pkg/types"
+	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+const (
+	applications = "applications.argoproj.io"
+	appName      = "eksa-management-cluster"
+	manifestPath = "testdata/install.yaml"
+)
+
+type argoCDTest struct {
+	*WithT
+	ctx        context.Context
+	kubeClient *mocks.MockKubeClient
+	repository *mocks.MockRepository
+	argoCD     *argocd.ArgoCD
+	cluster    *types.Cluster
+	spec       *cluster.Spec
+}
+
+func newArgoCDTest(t *testing.T) *argoCDTest {
+	ctrl := gomock.NewController(t)
+	kubeClient := mocks.NewMockKubeClient(ctrl)
+	repository := mocks.NewMockRepository(ctrl)
+
+	spec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster = &v1alpha1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "management-cluster",
+				Namespace: "default",
+			},
+			Spec: v1alpha1.ClusterSpec{
+				GitOpsRef: &v1alpha1.Ref{
+					Kind: v1alpha1.ArgoCDConfigKind,
+					Name: "test-argocd",
+				},
+			},
+		}
+		s.ArgoCDConfig = &v1alpha1.ArgoCDConfig{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-argocd",
+				Namespace: "default",
+			},
+			Spec: v1alpha1.ArgoCDConfigSpec{
+				ClusterConfigPath: "clusters/management-cluster",
+				Branch:            "main",
+				Gitea: &v1alpha1.GiteaProviderConfig{
+					Hostname:   "gitea.example.com",
+					Owner:      "janedoe",
+					Repository: "fleet",
+				},
+			},
+		}
+		s.VersionsBundle.ArgoCD = releasev1.ArgoCDBundle{
+			Version:  "v2.8.4+abcdef1",
+			Manifest: releasev1.Manifest{URI: manifestPath},
+		}
+	})
+
+	return &argoCDTest{
+		WithT:      NewWithT(t),
+		ctx:        context.Background(),
+		kubeClient: kubeClient,
+		repository: repository,
+		argoCD:     argocd.New(kubeClient, repository, files.NewReader(), &config.CliConfig{}),
+		cluster: &types.Cluster{
+			Name:           "management-cluster",
+			KubeconfigFile: "k.kubeconfig",
+		},
+		spec: spec,
+	}
+}
+
+func installManifest(t *testing.T) []byte {
+	t.Helper()
+	content, err := os.ReadFile(manifestPath)
+	if err != nil {
+		t.Fatalf("reading Argo CD install manifest: %v", err)
+	}
+	return content
+}
+
+// repositorySpecMatcher matches the spec passed to the repository, which carries a FluxConfig for the same git repository.
+type repositorySpecMatcher struct {
+	argoCDConfig *v1alpha1.ArgoCDConfig
+}
+
+func repositorySpec(argoCDConfig *v1alpha1.ArgoCDConfig) gomock.Matcher {
+	return repositorySpecMatcher{argoCDConfig: argoCDConfig}
+}
+
+func (m repositorySpecMatcher) Matches(x interface{}) bool {
+	s, ok := x.(*cluster.Spec)
+	return ok && s.FluxConfig != nil && s.FluxConfig.Spec.Gitea.Equal(m.argoCDConfig.Spec.Gitea) &&
+		s.FluxConfig.Spec.ClusterConfigPath == m.argoCDConfig.Spec.ClusterConfigPath
+}
+
+func (m repositorySpecMatcher) String() string {
+	return "is a spec with a FluxConfig for the git repository of " + m.argoCDConfig.Name
+}
+
+func TestInstallGitOps(t *testing.T) {
+	tt := newArgoCDTest(t)
+	t.Setenv(gitea.EksaGiteaTokenEnv, "token")
+
+	tt.repository.EXPECT().CommitClusterConfig(tt.ctx, repositorySpec(tt.spec.ArgoCDConfig), nil, nil)
+	tt.kubeClient.EXPECT().CreateNamespaceIfNotPresent(tt.ctx, "k.kubeconfig", argocd.Namespace)
+	tt.kubeClient.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, tt.cluster, installManifest(t), argocd.Namespace)
+	tt.kubeClient.EXPECT().WaitForDeployment(tt.ctx, tt.cluster, "10m", "Available", "argocd-repo-server", argocd.Namespace)
+	tt.kubeClient.EXPECT().WaitForDeployment(tt.ctx, tt.cluster, "10m", "Available", "argocd-server", argocd.Namespace)
+	tt.kubeClient.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ *types.Cluster, data []byte) error {
+			tt.Expect(string(data)).To(ContainSubstring("argocd.argoproj.io/secret-type: repository"))
+			tt.Expect(string(data)).To(ContainSubstring("url: https://gitea.example.com/janedoe/fleet.git"))
+			tt.Expect(string(data)).To(ContainSubstring("username: oauth2"))
+			tt.Expect(string(data)).To(ContainSubstring("password: token"))
+			return nil
+		},
+	)
+	tt.kubeClient.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ *types.Cluster, data []byte) error {
+			tt.Expect(string(data)).To(ContainSubstring("name: eksa-management-cluster"))
+			tt.Expect(string(data)).To(ContainSubstring("path: clusters/management-cluster/management-cluster/eksa-system"))
+			tt.Expect(string(data)).To(ContainSubstring("targetRevision: main"))
+			tt.Expect(string(data)).To(ContainSubstring("selfHeal: true"))
+			return nil
+		},
+	)
+
+	tt.Expect(tt.argoCD.InstallGitOps(tt.ctx, tt.cluster, tt.spec, nil, nil)).To(Succeed())
+}
+
+func TestInstallGitOpsExistingManagement(t *testing.T) {
+	tt := newArgoCDTest(t)
+	t.Setenv(gitea.EksaGiteaTokenEnv, "token")
+	tt.cluster.ExistingManagement = true
+
+	tt.repository.EXPECT().CommitClusterConfig(tt.ctx, gomock.Any(), nil, nil)
+	tt.kubeClient.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, gomock.Any()).Times(2)
+
+	tt.Expect(tt.argoCD.InstallGitOps(tt.ctx, tt.cluster, tt.spec, nil, nil)).To(Succeed())
+}
+
+func TestInstallGitOpsInstallError(t *testing.T) {
+	tt := newArgoCDTest(t)
+
+	tt.repository.EXPECT().CommitClusterConfig(tt.ctx, gomock.Any(), nil, nil)
+	tt.kubeClient.EXPECT().CreateNamespaceIfNotPresent(tt.ctx, "k.kubeconfig", argocd.Namespace)
+	tt.kubeClient.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, tt.cluster, installManifest(t), argocd.Namespace).Return(errors.New("error in apply"))
+
+	tt.Expect(tt.argoCD.InstallGitOps(tt.ctx, tt.cluster, tt.spec, nil, nil)).To(MatchError("installing Argo CD: error in apply"))
+}
+
+func TestInstallGitOpsSSHKeyWithPassphrase(t *testing.T) {
+	tt := newArgoCDTest(t)
+	tt.cluster.ExistingManagement = true
+	tt.spec.ArgoCDConfig.Spec.Gitea = nil
+	tt.spec.ArgoCDConfig.Spec.Git = &v1alpha1.GitProviderConfig{RepositoryUrl: "ssh://git@example.com/janedoe/fleet.git"}
+	tt.argoCD = argocd.New(tt.kubeClient, tt.repository, files.NewReader(), &config.CliConfig{GitSshKeyPassphrase: "secret"})
+
+	tt.repository.EXPECT().CommitClusterConfig(tt.ctx, gomock.Any(), nil, nil)
+
+	tt.Expect(tt.argoCD.InstallGitOps(tt.ctx, tt.cluster, tt.spec, nil, nil)).To(MatchError("Argo CD does not support git private keys with a passphrase"))
+}
+
+func TestInstallGitOpsSSHKnownHosts(t *testing.T) {
+	tt := newArgoCDTest(t)
+	tt.cluster.ExistingManagement = true
+	tt.spec.ArgoCDConfig.Spec.Gitea = nil
+	tt.spec.ArgoCDConfig.Spec.Git = &v1alpha1.GitProviderConfig{RepositoryUrl: "ssh://git@example.com/janedoe/fleet.git"}
+
+	dir := t.TempDir()
+	keyFile := filepath.Join(dir, "id_ed25519")
+	knownHostsFile := filepath.Join(dir, "known_hosts")
+	tt.Expect(os.WriteFile(keyFile, []byte("private-key"), 0o600)).To(Succeed())
+	tt.Expect(os.WriteFile(knownHostsFile, []byte("example.com ssh-ed25519 AAAA"), 0o600)).To(Succeed())
+	tt.argoCD = argocd.New(tt.kubeClient, tt.repository, files.NewReader(), &config.CliConfig{GitPrivateKeyFile: keyFile, GitKnownHostsFile: knownHostsFile})
+
+	tt.repository.EXPECT().CommitClusterConfig(tt.ctx, gomock.Any(), nil, nil)
+	tt.kubeClient.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ *types.Cluster, data []byte) error {
+			tt.Expect(string(data)).To(ContainSubstring("sshPrivateKey: private-key"))
+			tt.Expect(string(data)).To(ContainSubstring("name: argocd-ssh-known-hosts-cm"))
+			tt.Expect(string(data)).To(ContainSubstring("ssh_known_hosts: example.com ssh-ed25519 AAAA"))
+			return nil
+		},
+	)
+	tt.kubeClient.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, gomock.Any())
+
+	tt.Expect(tt.argoCD.InstallGitOps(tt.ctx, tt.cluster, tt.spec, nil, nil)).To(Succeed())
+}
+
+func TestInstallGitOpsSkip(t *testing.T) {
+	tt := newArgoCDTest(t)
+	tt.spec.ArgoCDConfig = nil
+
+	tt.Expect(tt.argoCD.InstallGitOps(tt.ctx, tt.cluster, tt.spec, nil, nil)).To(Succeed())
+}
+
+func TestPauseClusterResourcesReconcile(t *testing.T) {
+	tt := newArgoCDTest(t)
+
+	tt.kubeClient.EXPECT().MergePatchResource(tt.ctx, applications, appName, `{"spec":{"syncPolicy":{"automated":null}}}`, "k.kubeconfig", argocd.Namespace)
+
+	tt.Expect(tt.argoCD.PauseClusterResourcesReconcile(tt.ctx, tt.cluster, tt.spec, nil)).To(Succeed())
+}
+
+func TestPauseClusterResourcesReconcileError(t *testing.T) {
+	tt := newArgoCDTest(t)
+
+	tt.kubeClient.EXPECT().MergePatchResource(tt.ctx, applications, appName, gomock.Any(), "k.kubeconfig", argocd.Namespace).Return(errors.New("error in patch"))
+
+	tt.Expect(tt.argoCD.PauseClusterResourcesReconcile(tt.ctx, tt.cluster, tt.spec, nil)).To(MatchError("pausing Argo CD application sync: error in patch"))
+}
+
+func TestResumeClusterResourcesReconcile(t *testing.T) {
+	tt := newArgoCDTest(t)
+
+	tt.repository.EXPECT().PendingPullRequest().Return("")
+	tt.kubeClient.EXPECT().MergePatchResource(tt.ctx, applications, appName, `{"spec":{"syncPolicy":{"automated":{"selfHeal":true}}}}`, "k.kubeconfig", argocd.Namespace)
+
+	tt.Expect(tt.argoCD.ResumeClusterResourcesReconcile(tt.ctx, tt.cluster, tt.spec, nil)).To(Succeed())
+}
+
+func TestResumeClusterResourcesReconcilePendingPullRequest(t *testing.T) {
+	tt := newArgoCDTest(t)
+
+	tt.repository.EXPECT().PendingPullRequest().Return("https://github.com/owner/repo/pull/1")
+
+	tt.Expect(tt.argoCD.ResumeClusterResourcesReconcile(tt.ctx, tt.cluster, tt.spec, nil)).To(Succeed())
+}
+
+func TestPauseAndResumeSkip(t *testing.T) {
+	tt := newArgoCDTest(t)
+	tt.spec.ArgoCDConfig = nil
+
+	tt.Expect(tt.argoCD.PauseClusterResourcesReconcile(tt.ctx, tt.cluster, tt.spec, nil)).To(Succeed())
+	tt.Expect(tt.argoCD.ResumeClusterResourcesReconcile(tt.ctx, tt.cluster, tt.spec, nil)).To(Succeed())
+}
+
+func TestForceReconcileGitRepo(t *testing.T) {
+	tt := newArgoCDTest(t)
+
+	tt.repository.EXPECT().PendingPullRequest().Return("")
+	tt.kubeClient.EXPECT().UpdateAnnotationInNamespace(tt.ctx, applications, appName, map[string]string{"argocd.argoproj.io/refresh": "hard"}, tt.cluster, argocd.Namespace)
+
+	tt.Expect(tt.argoCD.ForceReconcileGitRepo(tt.ctx, tt.cluster, tt.spec)).To(Succeed())
+}
+
+func TestForceReconcileGitRepoPendingPullRequest(t *testing.T) {
+	tt := newArgoCDTest(t)
+
+	tt.repository.EXPECT().PendingPullRequest().Return("https://github.com/owner/repo/pull/1")
+
+	tt.Expect(tt.argoCD.ForceReconcileGitRepo(tt.ctx, tt.cluster, tt.spec)).To(Succeed())
+}
+
+func TestUpdateGitEksaSpec(t *testing.T) {
+	tt := newArgoCDTest(t)
+
+	tt.repository.EXPECT().UpdateGitEksaSpec(tt.ctx, repositorySpec(tt.spec.ArgoCDConfig), nil, nil)
+
+	tt.Expect(tt.argoCD.UpdateGitEksaSpec(tt.ctx, tt.spec, nil, nil)).To(Succeed())
+	tt.Expect(tt.spec.FluxConfig).To(BeNil())
+}
+
+func TestValidations(t *testing.T) {
+	tt := newArgoCDTest(t)
+
+	tt.repository.EXPECT().Validations(tt.ctx, repositorySpec(tt.spec.ArgoCDConfig)).Return(nil)
+
+	tt.Expect(tt.argoCD.Validations(tt.ctx, tt.spec)).To(BeEmpty())
+}
+
+func TestCleanupGitRepoSelfManaged(t *testing.T) {
+	tt := newArgoCDTest(t)
+
+	tt.repository.EXPECT().CleanupGitRepo(tt.ctx, repositorySpec(tt.spec.ArgoCDConfig))
+
+	tt.Expect(tt.argoCD.CleanupGitRepo(tt.ctx, tt.spec)).To(Succeed())
+}
+
+func TestCleanupGitRepoWorkloadCluster(t *testing.T) {
+	tt := newArgoCDTest(t)
+	tt.spec.Cluster.Name = "workload-cluster"
+	tt.spec.Cluster.SetManagedBy("management-cluster")
+	tt.spec.ManagementCluster = tt.cluster
+	opts := &kubernetes.KubectlDeleteOptions{Name: "eksa-workload-cluster", Namespace: argocd.Namespace}
+
+	tt.kubeClient.EXPECT().Delete(tt.ctx, applications, "k.kubeconfig", opts).Return(apierrors.NewNotFound(schema.GroupResource{}, "eksa-workload-cluster"))
+	tt.repository.EXPECT().CleanupGitRepo(tt.ctx, gomock.Any())
+
+	tt.Expect(tt.argoCD.CleanupGitRepo(tt.ctx, tt.spec)).To(Succeed())
+}
+
+func TestCleanupGitRepoDeleteApplicationError(t *testing.T) {
+	tt := newArgoCDTest(t)
+	tt.spec.Cluster.SetManagedBy("another-cluster")
+	tt.spec.ManagementCluster = tt.cluster
+
+	tt.kubeClient.EXPECT().Delete(tt.ctx, applications, "k.kubeconfig", gomock.Any()).Return(errors.New("error in delete"))
+
+	tt.Expect(tt.argoCD.CleanupGitRepo(tt.ctx, tt.spec)).To(MatchError("deleting Argo CD application: error in delete"))
+}
+
+func TestInstallGitOpsBundleWithoutArgoCD(t *testing.T) {
+	tt := newArgoCDTest(t)
+	tt.spec.VersionsBundle.ArgoCD = releasev1.ArgoCDBundle{}
+	tt.spec.VersionsBundle.KubeVersion = "1.27"
+
+	tt.repository.EXPECT().CommitClusterConfig(tt.ctx, gomock.Any(), nil, nil)
+
+	tt.Expect(tt.argoCD.InstallGitOps(tt.ctx, tt.cluster, tt.spec, nil, nil)).To(MatchError("the bundle of Kubernetes version 1.27 does not include Argo CD"))
+}
+
+func TestInstallGitOpsManifestReadError(t *testing.T) {
+	tt := newArgoCDTest(t)
+	tt.spec.VersionsBundle.ArgoCD.Manifest.URI = "testdata/missing.yaml"
+
+	tt.repository.EXPECT().CommitClusterConfig(tt.ctx, gomock.Any(), nil, nil)
+
+	tt.Expect(tt.argoCD.InstallGitOps(tt.ctx, tt.cluster, tt.spec, nil, nil)).To(MatchError(ContainSubstring("reading Argo CD install manifest")))
+}
+
+func TestInstallGitOpsWorkloadCluster(t *testing.T) {
+	tt := newArgoCDTest(t)
+	t.Setenv(gitea.EksaGiteaTokenEnv, "token")
+	tt.spec.Cluster.Name = "workload-cluster"
+	tt.spec.Cluster.SetManagedBy("management-cluster")
+	tt.spec.ManagementCluster = tt.cluster
+	workloadCluster := &types.Cluster{
+		Name:               "workload-cluster",
+		KubeconfigFile:     "w.kubeconfig",
+		ExistingManagement: true,
+	}
+
+	tt.repository.EXPECT().CommitClusterConfig(tt.ctx, gomock.Any(), nil, nil)
+	tt.kubeClient.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, gomock.Any())
+	tt.kubeClient.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ *types.Cluster, data []byte) error {
+			tt.Expect(string(data)).To(ContainSubstring("name: eksa-workload-cluster"))
+			tt.Expect(string(data)).To(ContainSubstring("path: clusters/management-cluster/workload-cluster/eksa-system"))
+			return nil
+		},
+	)
+
+	tt.Expect(tt.argoCD.InstallGitOps(tt.ctx, workloadCluster, tt.spec, nil, nil)).To(Succeed())
+}
@@ -0,0 +1,65 @@
+package argocd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+// Upgrade re-applies the Argo CD install manifest when its version changed in the bundle.
+func (a *ArgoCD) Upgrade(ctx context.Context, managementCluster *types.Cluster, currentSpec *cluster.Spec, newSpec *cluster.Spec) (*types.ChangeDiff, error) {
+	logger.V(1).Info("Checking for Argo CD upgrades")
+
+	changeDiff := ArgoCDChangeDiff(currentSpec, newSpec)
+	if changeDiff == nil {
+		logger.V(1).Info("Nothing to upgrade for Argo CD")
+		return nil, nil
+	}
+
+	logger.V(1).Info("Starting Argo CD upgrades")
+	if err := a.install(ctx, managementCluster, newSpec); err != nil {
+		return nil, fmt.Errorf("upgrading Argo CD from %s to %s: %v", currentSpec.VersionsBundle.ArgoCD.Version, newSpec.VersionsBundle.ArgoCD.Version, err)
+	}
+
+	return changeDiff, nil
+}
+
+// ArgoCDChangeDiff returns the Argo CD version change between two specs of a self-managed cluster, if any.
+func ArgoCDChangeDiff(currentSpec, newSpec *cluster.Spec) *types.ChangeDiff {
+	if !newSpec.Cluster.IsSelfManaged() {
+		logger.V(1).Info("Skipping Argo CD upgrades, not a self-managed cluster")
+		return nil
+	}
+	if currentSpec.ArgoCDConfig == nil || newSpec.ArgoCDConfig == nil {
+		logger.V(1).Info("Skipping Argo CD upgrades, Argo CD not enabled")
+		return nil
+	}
+
+	oldVersion := currentSpec.VersionsBundle.ArgoCD.Version
+	newVersion := newSpec.VersionsBundle.ArgoCD.Version
+	if oldVersion == newVersion {
+		return nil
+	}
+
+	logger.V(1).Info("Argo CD change diff ", "oldVersion ", oldVersion, "newVersion ", newVersion)
+	return &types.ChangeDiff{
+		ComponentReports: []types.ComponentChangeDiff{
+			{
+				ComponentName: "Argo CD",
+				NewVersion:    newVersion,
+				OldVersion:    oldVersion,
+			},
+		},
+	}
+}
+
+// Install sets up Argo CD when GitOps is enabled for an existing cluster.
+func (a *ArgoCD) Install(ctx context.Context, cluster *types.Cluster, oldSpec, newSpec *cluster.Spec) error {
+	if oldSpec.Cluster.Spec.GitOpsRef == nil && newSpec.Cluster.Spec.GitOpsRef != nil {
+		return a.InstallGitOps(ctx, cluster, newSpec, nil, nil)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package argocd_test
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/gitops/argocd"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+func TestArgoCDUpgradeNoChanges(t *testing.T) {
+	tt := newArgoCDTest(t)
+
+	tt.Expect(tt.argoCD.Upgrade(tt.ctx, tt.cluster, tt.spec, tt.spec.DeepCopy())).To(BeNil())
+}
+
+func TestArgoCDUpgradeNoSelfManaged(t *testing.T) {
+	tt := newArgoCDTest(t)
+	newSpec := tt.spec.DeepCopy()
+	newSpec.Cluster.SetManagedBy("another-cluster")
+	newSpec.VersionsBundle.ArgoCD.Version = "v2.9.0+abcdef2"
+
+	tt.Expect(tt.argoCD.Upgrade(tt.ctx, tt.cluster, tt.spec, newSpec)).To(BeNil())
+}
+
+func TestArgoCDUpgradeVersion(t *testing.T) {
+	tt := newArgoCDTest(t)
+	newSpec := tt.spec.DeepCopy()
+	newSpec.VersionsBundle.ArgoCD.Version = "v2.9.0+abcdef2"
+
+	tt.kubeClient.EXPECT().CreateNamespaceIfNotPresent(tt.ctx, "k.kubeconfig", argocd.Namespace)
+	tt.kubeClient.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, tt.cluster, installManifest(t), argocd.Namespace)
+	tt.kubeClient.EXPECT().WaitForDeployment(tt.ctx, tt.cluster, "10m", "Available", "argocd-repo-server", argocd.Namespace)
+	tt.kubeClient.EXPECT().WaitForDeployment(tt.ctx, tt.cluster, "10m", "Available", "argocd-server", argocd.Namespace)
+
+	wantDiff := &types.ChangeDiff{
+		ComponentReports: []types.ComponentChangeDiff{
+			{ComponentName: "Argo CD", OldVersion: "v2.8.4+abcdef1", NewVersion: "v2.9.0+abcdef2"},
+		},
+	}
+	tt.Expect(tt.argoCD.Upgrade(tt.ctx, tt.cluster, tt.spec, newSpec)).To(Equal(wantDiff))
+}
+
+func TestArgoCDUpgradeError(t *testing.T) {
+	tt := newArgoCDTest(t)
+	newSpec := tt.spec.DeepCopy()
+	newSpec.VersionsBundle.ArgoCD.Version = "v2.9.0+abcdef2"
+
+	tt.kubeClient.EXPECT().CreateNamespaceIfNotPresent(tt.ctx, "k.kubeconfig", argocd.Namespace).Return(errors.New("error in create"))
+
+	_, err := tt.argoCD.Upgrade(tt.ctx, tt.cluster, tt.spec, newSpec)
+	tt.Expect(err).To(MatchError("upgrading Argo CD from v2.8.4+abcdef1 to v2.9.0+abcdef2: creating Argo CD namespace: error in create"))
+}
+
+func TestArgoCDInstallSkipsExistingGitOps(t *testing.T) {
+	tt := newArgoCDTest(t)
+
+	tt.Expect(tt.argoCD.Install(tt.ctx, tt.cluster, tt.spec, tt.spec.DeepCopy())).To(Succeed())
+}
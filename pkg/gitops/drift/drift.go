@@ -0,0 +1,300 @@
+package drift
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const (
+	// MissingInCluster is the cluster value of the fields of an object that is in git but not in the cluster.
+	MissingInCluster = "<missing in cluster>"
+	// MissingInGit is the git value of the fields of an object referenced by the cluster but not in git.
+	MissingInGit = "<missing in git>"
+
+	clusterResourceType = "clusters.anywhere.eks.amazonaws.com"
+)
+
+// Repository reads the EKS-A objects of a cluster from the GitOps repository.
+type Repository interface {
+	ReadClusterConfig(ctx context.Context, clusterSpec *cluster.Spec) ([]unstructured.Unstructured, error)
+}
+
+// KubeClient reads the live EKS-A objects and updates the Cluster status.
+type KubeClient interface {
+	GetObject(ctx context.Context, resourceType, name, namespace, kubeconfig string, obj runtime.Object) error
+	MergePatchResourceStatus(ctx context.Context, resource, name, patch, kubeconfig, namespace string) error
+}
+
+// Detector compares the EKS-A objects in a management cluster with their copies in the GitOps repository.
+type Detector struct {
+	repository Repository
+	kubeClient KubeClient
+}
+
+// NewDetector builds a Detector.
+func NewDetector(repository Repository, kubeClient KubeClient) *Detector {
+	return &Detector{
+		repository: repository,
+		kubeClient: kubeClient,
+	}
+}
+
+// Report is the drift of the EKS-A objects of a cluster.
+type Report struct {
+	Cluster string        `json:"cluster"`
+	Objects []ObjectDrift `json:"objects"`
+}
+
+// ObjectDrift is the drift of an EKS-A object.
+type ObjectDrift struct {
+	Kind   string       `json:"kind"`
+	Name   string       `json:"name"`
+	Fields []FieldDrift `json:"fields"`
+}
+
+// FieldDrift is a field with a different value in git and in the cluster.
+type FieldDrift struct {
+	Path    string `json:"path"`
+	Git     string `json:"git"`
+	Cluster string `json:"cluster"`
+}
+
+// Drifted returns true if any of the objects differs from git.
+func (r *Report) Drifted() bool {
+	return len(r.Objects) > 0
+}
+
+// Detect reports the fields of the Cluster, datacenter, machine config and identity provider objects in git that
+// have a different value in the management cluster. Fields only set in the cluster, like defaults, are not drift since
+// GitOps doesn't manage them. Objects referenced by the cluster that are not in git are reported too.
+func (d *Detector) Detect(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec) (*Report, error) {
+	gitObjs, err := d.repository.ReadClusterConfig(ctx, clusterSpec)
+	if err != nil {
+		return nil, fmt.Errorf("reading cluster config from git: %v", err)
+	}
+
+	report := &Report{Cluster: clusterSpec.Cluster.Name, Objects: []ObjectDrift{}}
+	inGit := map[string]struct{}{}
+	var liveCluster *v1alpha1.Cluster
+	for _, gitObj := range gitObjs {
+		if !tracked(gitObj.GetKind()) {
+			continue
+		}
+		inGit[objectKey(gitObj.GetKind(), gitObj.GetName())] = struct{}{}
+
+		liveObj, err := d.getObject(ctx, managementCluster, gitObj.GetKind(), gitObj.GetName(), namespace(gitObj, clusterSpec))
+		if apierrors.IsNotFound(err) {
+			report.add(gitObj.GetKind(), gitObj.GetName(), []FieldDrift{{Path: "", Git: "<object>", Cluster: MissingInCluster}})
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if gitObj.GetKind() == v1alpha1.ClusterKind && gitObj.GetName() == clusterSpec.Cluster.Name {
+			liveCluster = &v1alpha1.Cluster{}
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(liveObj.Object, liveCluster); err != nil {
+				return nil, fmt.Errorf("converting cluster %s: %v", liveObj.GetName(), err)
+			}
+		}
+
+		report.add(gitObj.GetKind(), gitObj.GetName(), diff("spec", gitObj.Object["spec"], liveObj.Object["spec"]))
+	}
+
+	if liveCluster != nil {
+		for _, ref := range clusterRefs(liveCluster) {
+			if _, ok := inGit[objectKey(ref.Kind, ref.Name)]; !ok {
+				report.add(ref.Kind, ref.Name, []FieldDrift{{Path: "", Git: MissingInGit, Cluster: "<object>"}})
+			}
+		}
+	}
+
+	return report, nil
+}
+
+// SetCondition sets the GitOpsInSync condition of the Cluster in the management cluster from the drift report.
+func (d *Detector) SetCondition(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec, report *Report) error {
+	liveObj, err := d.getObject(ctx, managementCluster, v1alpha1.ClusterKind, clusterSpec.Cluster.Name, clusterSpec.Cluster.Namespace)
+	if err != nil {
+		return err
+	}
+	liveCluster := &v1alpha1.Cluster{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(liveObj.Object, liveCluster); err != nil {
+		return fmt.Errorf("converting cluster %s: %v", liveObj.GetName(), err)
+	}
+
+	if report.Drifted() {
+		conditions.MarkFalse(liveCluster, v1alpha1.GitOpsInSyncCondition, v1alpha1.GitOpsDriftDetectedReason, clusterv1.ConditionSeverityWarning, report.summary())
+	} else {
+		conditions.MarkTrue(liveCluster, v1alpha1.GitOpsInSyncCondition)
+	}
+
+	// The resource version makes the patch fail if the controller updated the conditions since we read them.
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{"resourceVersion": liveCluster.ResourceVersion},
+		"status":   map[string]interface{}{"conditions": liveCluster.Status.Conditions},
+	})
+	if err != nil {
+		return fmt.Errorf("marshalling cluster status patch: %v", err)
+	}
+
+	logger.V(3).Info("Setting GitOps in sync condition", "cluster", liveCluster.Name, "drifted", report.Drifted())
+	if err := d.kubeClient.MergePatchResourceStatus(ctx, clusterResourceType, liveCluster.Name, string(patch), managementCluster.KubeconfigFile, clusterSpec.Cluster.Namespace); err != nil {
+		return fmt.Errorf("setting %s condition on cluster %s: %v", v1alpha1.GitOpsInSyncCondition, liveCluster.Name, err)
+	}
+	return nil
+}
+
+func (d *Detector) getObject(ctx context.Context, managementCluster *types.Cluster, kind, name, namespace string) (*unstructured.Unstructured, error) {
+	obj := &unstructured.Unstructured{}
+	if err := d.kubeClient.GetObject(ctx, resourceType(kind), name, namespace, managementCluster.KubeconfigFile, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("getting %s %s: %v", kind, name, err)
+	}
+	return obj, nil
+}
+
+func (r *Report) add(kind, name string, fields []FieldDrift) {
+	if len(fields) == 0 {
+		return
+	}
+	r.Objects = append(r.Objects, ObjectDrift{Kind: kind, Name: name, Fields: fields})
+}
+
+// summary lists the drifted objects, to keep the condition message short.
+func (r *Report) summary() string {
+	objs := make([]string, 0, len(r.Objects))
+	for _, o := range r.Objects {
+		objs = append(objs, fmt.Sprintf("%s %s", o.Kind, o.Name))
+	}
+	return fmt.Sprintf("Objects differ from the GitOps repository: %s", strings.Join(objs, ", "))
+}
+
+// tracked returns true for the kinds of the objects compared with git.
+func tracked(kind string) bool {
+	return kind == v1alpha1.ClusterKind ||
+		strings.HasSuffix(kind, "DatacenterConfig") ||
+		strings.HasSuffix(kind, "MachineConfig") ||
+		kind == v1alpha1.OIDCConfigKind ||
+		kind == v1alpha1.AWSIamConfigKind
+}
+
+// clusterRefs returns the datacenter, machine config and identity provider objects referenced by the cluster.
+func clusterRefs(c *v1alpha1.Cluster) []v1alpha1.Ref {
+	refs := []v1alpha1.Ref{c.Spec.DatacenterRef}
+	refs = append(refs, c.MachineConfigRefs()...)
+	refs = append(refs, c.Spec.IdentityProviderRefs...)
+
+	sort.SliceStable(refs, func(i, j int) bool {
+		return objectKey(refs[i].Kind, refs[i].Name) < objectKey(refs[j].Kind, refs[j].Name)
+	})
+	return refs
+}
+
+func resourceType(kind string) string {
+	return fmt.Sprintf("%ss.%s", strings.ToLower(kind), v1alpha1.GroupVersion.Group)
+}
+
+func namespace(obj unstructured.Unstructured, clusterSpec *cluster.Spec) string {
+	if ns := obj.GetNamespace(); ns != "" {
+		return ns
+	}
+	return clusterSpec.Cluster.Namespace
+}
+
+func objectKey(kind, name string) string {
+	return kind + "/" + name
+}
+
+// diff compares the fields set in git with the cluster ones. Maps are compared field by field
+// and lists element by element, everything else by value.
+func diff(path string, git, live interface{}) []FieldDrift {
+	switch g := git.(type) {
+	case map[string]interface{}:
+		l, ok := live.(map[string]interface{})
+		if !ok {
+			return valueDrift(path, git, live)
+		}
+		keys := make([]string, 0, len(g))
+		for k := range g {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		var fields []FieldDrift
+		for _, k := range keys {
+			fields = append(fields, diff(path+"."+k, g[k], l[k])...)
+		}
+		return fields
+	case []interface{}:
+		l, ok := live.([]interface{})
+		if !ok || len(l) != len(g) {
+			return valueDrift(path, git, live)
+		}
+		var fields []FieldDrift
+		for i := range g {
+			fields = append(fields, diff(fmt.Sprintf("%s[%d]", path, i), g[i], l[i])...)
+		}
+		return fields
+	default:
+		if live == nil && isZero(git) {
+			// Zero values are omitted by the API server, like by the CLI in git.
+			return nil
+		}
+		if value(git) != value(live) {
+			return valueDrift(path, git, live)
+		}
+		return nil
+	}
+}
+
+func valueDrift(path string, git, live interface{}) []FieldDrift {
+	return []FieldDrift{{Path: path, Git: value(git), Cluster: value(live)}}
+}
+
+// value serializes a field to JSON, so numbers read from YAML and from the API server compare equal.
+func value(v interface{}) string {
+	if v == nil {
+		return "<unset>"
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(b)
+}
+
+func isZero(v interface{}) bool {
+	switch t := v.(type) {
+	case nil:
+		return true
+	case string:
+		return t == ""
+	case bool:
+		return !t
+	case int64:
+		return t == 0
+	case float64:
+		return t == 0
+	case map[string]interface{}:
+		return len(t) == 0
+	case []interface{}:
+		return len(t) == 0
+	}
+	return false
+}
@@ -0,0 +1,245 @@
+package drift_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/gitops/drift"
+	"github.com/aws/eks-anywhere/pkg/gitops/drift/mocks"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const kubeconfig = "mgmt.kubeconfig"
+
+type driftTest struct {
+	*WithT
+	ctx         context.Context
+	repository  *mocks.MockRepository
+	kubeClient  *mocks.MockKubeClient
+	detector    *drift.Detector
+	cluster     *types.Cluster
+	clusterSpec *cluster.Spec
+}
+
+func newDriftTest(t *testing.T) *driftTest {
+	mockCtrl := gomock.NewController(t)
+	repository := mocks.NewMockRepository(mockCtrl)
+	kubeClient := mocks.NewMockKubeClient(mockCtrl)
+
+	return &driftTest{
+		WithT:      NewWithT(t),
+		ctx:        context.Background(),
+		repository: repository,
+		kubeClient: kubeClient,
+		detector:   drift.NewDetector(repository, kubeClient),
+		cluster:    &types.Cluster{Name: "mgmt", KubeconfigFile: kubeconfig},
+		clusterSpec: test.NewClusterSpec(func(s *cluster.Spec) {
+			s.Cluster.Name = "mgmt"
+			s.Cluster.Namespace = "default"
+		}),
+	}
+}
+
+func object(kind, name string, spec map[string]interface{}) unstructured.Unstructured {
+	obj := unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": v1alpha1.GroupVersion.String(),
+		"kind":       kind,
+		"metadata": map[string]interface{}{
+			"name": name,
+		},
+		"spec": spec,
+	}}
+	return obj
+}
+
+func clusterObject(spec map[string]interface{}) unstructured.Unstructured {
+	return object(v1alpha1.ClusterKind, "mgmt", spec)
+}
+
+func clusterSpecFields(kubernetesVersion string, count interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"kubernetesVersion": kubernetesVersion,
+		"datacenterRef": map[string]interface{}{
+			"kind": v1alpha1.VSphereDatacenterKind,
+			"name": "mgmt",
+		},
+		"controlPlaneConfiguration": map[string]interface{}{
+			"count": count,
+		},
+	}
+}
+
+func (tt *driftTest) expectGet(resourceType, name string, live *unstructured.Unstructured) {
+	tt.kubeClient.EXPECT().GetObject(tt.ctx, resourceType, name, "default", kubeconfig, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _, _, _, _ string, obj runtime.Object) error {
+			live.DeepCopyInto(obj.(*unstructured.Unstructured))
+			return nil
+		},
+	)
+}
+
+func (tt *driftTest) expectNotFound(resourceType, name string) {
+	tt.kubeClient.EXPECT().GetObject(tt.ctx, resourceType, name, "default", kubeconfig, gomock.Any()).Return(
+		apierrors.NewNotFound(schema.GroupResource{Resource: resourceType}, name),
+	)
+}
+
+func TestDetectNoDrift(t *testing.T) {
+	tt := newDriftTest(t)
+	gitCluster := clusterObject(clusterSpecFields("1.27", float64(1)))
+	gitDatacenter := object(v1alpha1.VSphereDatacenterKind, "mgmt", map[string]interface{}{"server": "vsphere", "insecure": false})
+	configMap := object("ConfigMap", "other", nil)
+	tt.repository.EXPECT().ReadClusterConfig(tt.ctx, tt.clusterSpec).Return([]unstructured.Unstructured{gitCluster, gitDatacenter, configMap}, nil)
+
+	liveCluster := clusterObject(clusterSpecFields("1.27", int64(1)))
+	liveCluster.Object["spec"].(map[string]interface{})["clusterNetwork"] = map[string]interface{}{"dns": map[string]interface{}{}}
+	tt.expectGet("clusters.anywhere.eks.amazonaws.com", "mgmt", &liveCluster)
+	liveDatacenter := object(v1alpha1.VSphereDatacenterKind, "mgmt", map[string]interface{}{"server": "vsphere"})
+	tt.expectGet("vspheredatacenterconfigs.anywhere.eks.amazonaws.com", "mgmt", &liveDatacenter)
+
+	report, err := tt.detector.Detect(tt.ctx, tt.cluster, tt.clusterSpec)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(report.Drifted()).To(BeFalse())
+	tt.Expect(report.Cluster).To(Equal("mgmt"))
+}
+
+func TestDetectFieldDrift(t *testing.T) {
+	tt := newDriftTest(t)
+	gitCluster := clusterObject(clusterSpecFields("1.27", float64(3)))
+	gitCluster.Object["spec"].(map[string]interface{})["workerNodeGroupConfigurations"] = []interface{}{
+		map[string]interface{}{"name": "md-0", "count": float64(2)},
+	}
+	gitDatacenter := object(v1alpha1.VSphereDatacenterKind, "mgmt", map[string]interface{}{"server": "vsphere"})
+	tt.repository.EXPECT().ReadClusterConfig(tt.ctx, tt.clusterSpec).Return([]unstructured.Unstructured{gitCluster, gitDatacenter}, nil)
+
+	liveCluster := clusterObject(clusterSpecFields("1.28", int64(3)))
+	liveCluster.Object["spec"].(map[string]interface{})["workerNodeGroupConfigurations"] = []interface{}{
+		map[string]interface{}{"name": "md-0", "count": int64(5)},
+	}
+	tt.expectGet("clusters.anywhere.eks.amazonaws.com", "mgmt", &liveCluster)
+	liveDatacenter := object(v1alpha1.VSphereDatacenterKind, "mgmt", map[string]interface{}{"server": "other"})
+	tt.expectGet("vspheredatacenterconfigs.anywhere.eks.amazonaws.com", "mgmt", &liveDatacenter)
+
+	report, err := tt.detector.Detect(tt.ctx, tt.cluster, tt.clusterSpec)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(report.Objects).To(Equal([]drift.ObjectDrift{
+		{
+			Kind: v1alpha1.ClusterKind,
+			Name: "mgmt",
+			Fields: []drift.FieldDrift{
+				{Path: "spec.kubernetesVersion", Git: `"1.27"`, Cluster: `"1.28"`},
+				{Path: "spec.workerNodeGroupConfigurations[0].count", Git: "2", Cluster: "5"},
+			},
+		},
+		{
+			Kind: v1alpha1.VSphereDatacenterKind,
+			Name: "mgmt",
+			Fields: []drift.FieldDrift{
+				{Path: "spec.server", Git: `"vsphere"`, Cluster: `"other"`},
+			},
+		},
+	}))
+}
+
+func TestDetectMissingObjects(t *testing.T) {
+	tt := newDriftTest(t)
+	gitCluster := clusterObject(clusterSpecFields("1.27", float64(1)))
+	gitOIDC := object(v1alpha1.OIDCConfigKind, "oidc", map[string]interface{}{"clientId": "id"})
+	tt.repository.EXPECT().ReadClusterConfig(tt.ctx, tt.clusterSpec).Return([]unstructured.Unstructured{gitCluster, gitOIDC}, nil)
+
+	liveCluster := clusterObject(clusterSpecFields("1.27", int64(1)))
+	tt.expectGet("clusters.anywhere.eks.amazonaws.com", "mgmt", &liveCluster)
+	tt.expectNotFound("oidcconfigs.anywhere.eks.amazonaws.com", "oidc")
+
+	report, err := tt.detector.Detect(tt.ctx, tt.cluster, tt.clusterSpec)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(report.Objects).To(Equal([]drift.ObjectDrift{
+		{
+			Kind:   v1alpha1.OIDCConfigKind,
+			Name:   "oidc",
+			Fields: []drift.FieldDrift{{Git: "<object>", Cluster: drift.MissingInCluster}},
+		},
+		{
+			Kind:   v1alpha1.VSphereDatacenterKind,
+			Name:   "mgmt",
+			Fields: []drift.FieldDrift{{Git: drift.MissingInGit, Cluster: "<object>"}},
+		},
+	}))
+}
+
+func TestDetectReadError(t *testing.T) {
+	tt := newDriftTest(t)
+	tt.repository.EXPECT().ReadClusterConfig(tt.ctx, tt.clusterSpec).Return(nil, errors.New("clone failed"))
+
+	_, err := tt.detector.Detect(tt.ctx, tt.cluster, tt.clusterSpec)
+	tt.Expect(err).To(MatchError("reading cluster config from git: clone failed"))
+}
+
+func TestDetectGetError(t *testing.T) {
+	tt := newDriftTest(t)
+	tt.repository.EXPECT().ReadClusterConfig(tt.ctx, tt.clusterSpec).Return([]unstructured.Unstructured{clusterObject(nil)}, nil)
+	tt.kubeClient.EXPECT().GetObject(tt.ctx, "clusters.anywhere.eks.amazonaws.com", "mgmt", "default", kubeconfig, gomock.Any()).Return(errors.New("connection refused"))
+
+	_, err := tt.detector.Detect(tt.ctx, tt.cluster, tt.clusterSpec)
+	tt.Expect(err).To(MatchError("getting Cluster mgmt: connection refused"))
+}
+
+func TestSetConditionDrifted(t *testing.T) {
+	tt := newDriftTest(t)
+	liveCluster := clusterObject(clusterSpecFields("1.27", int64(1)))
+	liveCluster.SetResourceVersion("42")
+	tt.expectGet("clusters.anywhere.eks.amazonaws.com", "mgmt", &liveCluster)
+	tt.kubeClient.EXPECT().MergePatchResourceStatus(tt.ctx, "clusters.anywhere.eks.amazonaws.com", "mgmt", gomock.Any(), kubeconfig, "default").DoAndReturn(
+		func(_ context.Context, _, _, patch, _, _ string) error {
+			tt.Expect(patch).To(ContainSubstring(`"resourceVersion":"42"`))
+			tt.Expect(patch).To(ContainSubstring(`"type":"GitOpsInSync"`))
+			tt.Expect(patch).To(ContainSubstring(`"status":"False"`))
+			tt.Expect(patch).To(ContainSubstring(`"severity":"Warning"`))
+			tt.Expect(patch).To(ContainSubstring(`"reason":"GitOpsDriftDetected"`))
+			tt.Expect(patch).To(ContainSubstring("Objects differ from the GitOps repository: Cluster mgmt"))
+			return nil
+		},
+	)
+
+	report := &drift.Report{Cluster: "mgmt", Objects: []drift.ObjectDrift{{Kind: v1alpha1.ClusterKind, Name: "mgmt"}}}
+	tt.Expect(tt.detector.SetCondition(tt.ctx, tt.cluster, tt.clusterSpec, report)).To(Succeed())
+}
+
+func TestSetConditionInSync(t *testing.T) {
+	tt := newDriftTest(t)
+	liveCluster := clusterObject(clusterSpecFields("1.27", int64(1)))
+	tt.expectGet("clusters.anywhere.eks.amazonaws.com", "mgmt", &liveCluster)
+	tt.kubeClient.EXPECT().MergePatchResourceStatus(tt.ctx, "clusters.anywhere.eks.amazonaws.com", "mgmt", gomock.Any(), kubeconfig, "default").DoAndReturn(
+		func(_ context.Context, _, _, patch, _, _ string) error {
+			tt.Expect(patch).To(ContainSubstring(`"type":"GitOpsInSync"`))
+			tt.Expect(patch).To(ContainSubstring(`"status":"True"`))
+			tt.Expect(patch).NotTo(ContainSubstring(`"severity"`))
+			tt.Expect(patch).NotTo(ContainSubstring(`"reason"`))
+			return nil
+		},
+	)
+
+	report := &drift.Report{Cluster: "mgmt", Objects: []drift.ObjectDrift{}}
+	tt.Expect(tt.detector.SetCondition(tt.ctx, tt.cluster, tt.clusterSpec, report)).To(Succeed())
+}
+
+func TestSetConditionPatchError(t *testing.T) {
+	tt := newDriftTest(t)
+	liveCluster := clusterObject(clusterSpecFields("1.27", int64(1)))
+	tt.expectGet("clusters.anywhere.eks.amazonaws.com", "mgmt", &liveCluster)
+	tt.kubeClient.EXPECT().MergePatchResourceStatus(tt.ctx, "clusters.anywhere.eks.amazonaws.com", "mgmt", gomock.Any(), kubeconfig, "default").Return(errors.New("conflict"))
+
+	report := &drift.Report{Cluster: "mgmt", Objects: []drift.ObjectDrift{}}
+	tt.Expect(tt.detector.SetCondition(tt.ctx, tt.cluster, tt.clusterSpec, report)).To(MatchError("setting GitOpsInSync condition on cluster mgmt: conflict"))
+}
@@ -38,6 +38,11 @@ func newFluxForCluster(flux *Flux, clusterSpec *cluster.Spec, datacenterConfig p
 // It will generate the kustomization file and marshal the cluster configuration file to the required locations in the repo.
 // These will later be used by Flux and our controllers to reconcile the repository contents and the cluster configuration.
 func (fc *fluxForCluster) commitFluxAndClusterConfigToGit(ctx context.Context) error {
+	return fc.commitConfigToGit(ctx, fc.clusterSpec.Cluster.IsSelfManaged())
+}
+
+// commitConfigToGit commits the cluster configuration file and, if withFluxSystem, the flux system files.
+func (fc *fluxForCluster) commitConfigToGit(ctx context.Context, withFluxSystem bool) error {
 	logger.Info("Adding cluster configuration files to Git")
 	config := fc.clusterSpec.FluxConfig
 
@@ -54,7 +59,11 @@ func (fc *fluxForCluster) commitFluxAndClusterConfigToGit(ctx context.Context) e
 		return fmt.Errorf("writing eks-a config files: %v", err)
 	}
 
-	if fc.clusterSpec.Cluster.IsSelfManaged() {
+	if err := fc.writeEksaOverlay(); err != nil {
+		return fmt.Errorf("writing eks-a overlay: %v", err)
+	}
+
+	if withFluxSystem {
 		if err := g.WriteFluxSystemFiles(fc.clusterSpec); err != nil {
 			return fmt.Errorf("writing flux system files: %v", err)
 		}
@@ -151,7 +160,7 @@ func (fc *fluxForCluster) clone(ctx context.Context) error {
 
 // createRemoteRepository will create a repository in the remote git provider with the user-provided configuration.
 func (fc *fluxForCluster) createRemoteRepository(ctx context.Context) error {
-	logger.V(3).Info("Remote Github repo does not exist; will create and initialize", "repo", fc.repository(), "owner", fc.owner())
+	logger.V(3).Info("Remote git repo does not exist; will create and initialize", "repo", fc.repository(), "owner", fc.owner())
 
 	opts := git.CreateRepoOpts{
 		Name:        fc.repository(),
@@ -161,7 +170,7 @@ func (fc *fluxForCluster) createRemoteRepository(ctx context.Context) error {
 		Privacy:     true,
 	}
 
-	logger.V(4).Info("Creating remote Github repo", "options", opts)
+	logger.V(4).Info("Creating remote git repo", "options", opts)
 	if err := fc.gitClient.CreateRepo(ctx, opts); err != nil {
 		return fmt.Errorf("creating repo: %v", err)
 	}
@@ -224,6 +233,12 @@ func (fc *fluxForCluster) repository() string {
 	if fc.clusterSpec.FluxConfig.Spec.Github != nil {
 		return fc.clusterSpec.FluxConfig.Spec.Github.Repository
 	}
+	if fc.clusterSpec.FluxConfig.Spec.Gitlab != nil {
+		return fc.clusterSpec.FluxConfig.Spec.Gitlab.Repository
+	}
+	if fc.clusterSpec.FluxConfig.Spec.Gitea != nil {
+		return fc.clusterSpec.FluxConfig.Spec.Gitea.Repository
+	}
 	if fc.clusterSpec.FluxConfig.Spec.Git != nil {
 		r := fc.clusterSpec.FluxConfig.Spec.Git.RepositoryUrl
 		return path.Base(strings.TrimSuffix(r, filepath.Ext(r)))
@@ -235,6 +250,12 @@ func (fc *fluxForCluster) owner() string {
 	if fc.clusterSpec.FluxConfig.Spec.Github != nil {
 		return fc.clusterSpec.FluxConfig.Spec.Github.Owner
 	}
+	if fc.clusterSpec.FluxConfig.Spec.Gitlab != nil {
+		return fc.clusterSpec.FluxConfig.Spec.Gitlab.Owner
+	}
+	if fc.clusterSpec.FluxConfig.Spec.Gitea != nil {
+		return fc.clusterSpec.FluxConfig.Spec.Gitea.Owner
+	}
 	return ""
 }
 
@@ -246,6 +267,12 @@ func (fc *fluxForCluster) personal() bool {
 	if fc.clusterSpec.FluxConfig.Spec.Github != nil {
 		return fc.clusterSpec.FluxConfig.Spec.Github.Personal
 	}
+	if fc.clusterSpec.FluxConfig.Spec.Gitlab != nil {
+		return fc.clusterSpec.FluxConfig.Spec.Gitlab.Personal
+	}
+	if fc.clusterSpec.FluxConfig.Spec.Gitea != nil {
+		return fc.clusterSpec.FluxConfig.Spec.Gitea.Personal
+	}
 	return false
 }
 
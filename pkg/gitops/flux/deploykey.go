@@ -0,0 +1,69 @@
+package flux
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/config"
+	"github.com/aws/eks-anywhere/pkg/crypto"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const deployKeyFileName = "flux-deploy-key"
+
+// bootstrapWithDeployKey bootstraps Flux over SSH for the git providers where flux doesn't create the deploy key itself.
+// It registers a new deploy key in the repository and bootstraps Flux as a generic git repository with it.
+func (f *Flux) bootstrapWithDeployKey(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	fc := newFluxForCluster(f, clusterSpec, nil, nil)
+	repo, err := f.gitClient.GetRepo(ctx)
+	if err != nil {
+		return fmt.Errorf("describing repo: %v", err)
+	}
+	if repo == nil || repo.SshUrl == "" {
+		return fmt.Errorf("repository %s doesn't have an ssh url", fc.repository())
+	}
+
+	// The host key of the git server is never trusted on first use, it must be in the known hosts file.
+	if f.cliConfig == nil || f.cliConfig.GitKnownHostsFile == "" {
+		return fmt.Errorf("%s must be set to verify the host key of %s", config.EksaGitKnownHostsFileEnv, repo.SshUrl)
+	}
+	cliConfig := &config.CliConfig{GitKnownHostsFile: f.cliConfig.GitKnownHostsFile}
+
+	var private, public bytes.Buffer
+	if err = crypto.NewSshKeyPair(&private, &public); err != nil {
+		return fmt.Errorf("generating deploy key: %v", err)
+	}
+	cliConfig.GitPrivateKeyFile, err = f.writer.Write(deployKeyFileName, private.Bytes(), filewriter.Permission0600)
+	if err != nil {
+		return fmt.Errorf("writing deploy key: %v", err)
+	}
+	defer os.Remove(cliConfig.GitPrivateKeyFile)
+
+	logger.V(3).Info("Adding deploy key to repository", "repository", fc.repository(), "owner", fc.owner())
+	err = f.gitClient.AddDeployKey(ctx, git.AddDeployKeyOpts{
+		Owner:      fc.owner(),
+		Repository: fc.repository(),
+		Key:        string(public.Bytes()),
+		Title:      "eks-anywhere-" + clusterSpec.Cluster.Name,
+	})
+	if err != nil {
+		return fmt.Errorf("adding deploy key: %v", err)
+	}
+
+	fluxConfig := clusterSpec.FluxConfig.DeepCopy()
+	fluxConfig.Spec.Gitlab = nil
+	fluxConfig.Spec.Gitea = nil
+	fluxConfig.Spec.Git = &v1alpha1.GitProviderConfig{
+		RepositoryUrl:   repo.SshUrl,
+		SshKeyAlgorithm: v1alpha1.RsaAlgorithm,
+	}
+
+	return f.fluxClient.BootstrapGit(ctx, cluster, fluxConfig, cliConfig)
+}
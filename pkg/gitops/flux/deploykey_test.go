@@ -0,0 +1,146 @@
+package flux_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/config"
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/gitops/flux"
+	fluxMocks "github.com/aws/eks-anywhere/pkg/gitops/flux/mocks"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+// newDeployKeyFluxTest returns a fluxTest whose Flux has a known hosts file, required to bootstrap
+// with a deploy key.
+func newDeployKeyFluxTest(t *testing.T) fluxTest {
+	g := newFluxTest(t)
+	g.gitOpsFlux = flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, g.writer, &config.CliConfig{GitKnownHostsFile: "known_hosts"})
+	return g
+}
+
+func newGitlabClusterSpec(t *testing.T) *cluster.Spec {
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+	clusterSpec.FluxConfig.Spec.Github = nil
+	clusterSpec.FluxConfig.Spec.Gitlab = &v1alpha1.GitlabProviderConfig{
+		Hostname:   "gitlab.example.com",
+		Owner:      "platform",
+		Repository: "fleet",
+	}
+	return clusterSpec
+}
+
+func TestBootstrapGitlab(t *testing.T) {
+	g := newDeployKeyFluxTest(t)
+	c := &types.Cluster{}
+	clusterSpec := newGitlabClusterSpec(t)
+	sshUrl := "ssh://git@gitlab.example.com/platform/fleet.git"
+
+	g.git.EXPECT().GetRepo(g.ctx).Return(&git.Repository{Name: "fleet", SshUrl: sshUrl}, nil)
+	g.git.EXPECT().AddDeployKey(g.ctx, gomock.Any()).DoAndReturn(func(_ context.Context, opts git.AddDeployKeyOpts) error {
+		g.Expect(opts.Owner).To(Equal("platform"))
+		g.Expect(opts.Repository).To(Equal("fleet"))
+		g.Expect(opts.Title).To(Equal("eks-anywhere-management-cluster"))
+		g.Expect(opts.ReadOnly).To(BeFalse())
+		g.Expect(opts.Key).To(HavePrefix("ssh-rsa "))
+		return nil
+	})
+	var privateKeyFile string
+	g.flux.EXPECT().BootstrapGit(g.ctx, c, gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ *types.Cluster, fluxConfig *v1alpha1.FluxConfig, cliConfig *config.CliConfig) error {
+			g.Expect(fluxConfig.Spec.Git).To(Equal(&v1alpha1.GitProviderConfig{RepositoryUrl: sshUrl, SshKeyAlgorithm: v1alpha1.RsaAlgorithm}))
+			g.Expect(fluxConfig.Spec.Gitlab).To(BeNil())
+			g.Expect(fluxConfig.Spec.Branch).To(Equal(clusterSpec.FluxConfig.Spec.Branch))
+
+			privateKeyFile = cliConfig.GitPrivateKeyFile
+			g.Expect(os.ReadFile(privateKeyFile)).To(ContainSubstring("RSA PRIVATE KEY"))
+			g.Expect(cliConfig.GitKnownHostsFile).To(Equal("known_hosts"))
+			return nil
+		},
+	)
+
+	g.Expect(g.gitOpsFlux.Bootstrap(g.ctx, c, clusterSpec)).To(Succeed())
+	g.Expect(clusterSpec.FluxConfig.Spec.Git).To(BeNil())
+	g.Expect(privateKeyFile).NotTo(BeAnExistingFile())
+}
+
+func TestBootstrapGitlabNoKnownHosts(t *testing.T) {
+	g := newFluxTest(t)
+	c := &types.Cluster{}
+	clusterSpec := newGitlabClusterSpec(t)
+
+	g.git.EXPECT().GetRepo(g.ctx).Return(&git.Repository{Name: "fleet", SshUrl: "ssh://git@gitlab.example.com/platform/fleet.git"}, nil)
+	g.flux.EXPECT().Uninstall(g.ctx, c, clusterSpec.FluxConfig).Return(nil)
+
+	g.Expect(g.gitOpsFlux.Bootstrap(g.ctx, c, clusterSpec)).To(MatchError(
+		"installing GitLab gitops: EKSA_GIT_KNOWN_HOSTS must be set to verify the host key of ssh://git@gitlab.example.com/platform/fleet.git",
+	))
+}
+
+func TestBootstrapGiteaKnownHostsFromCliConfig(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	fluxClient := fluxMocks.NewMockGitOpsFluxClient(mockCtrl)
+	gitClient := fluxMocks.NewMockGitClient(mockCtrl)
+	_, w := test.NewWriter(t)
+	cliConfig := &config.CliConfig{GitKnownHostsFile: "known_hosts"}
+	f := flux.NewFluxFromGitOpsFluxClient(fluxClient, gitClient, w, cliConfig)
+
+	c := &types.Cluster{}
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+	clusterSpec.FluxConfig.Spec.Github = nil
+	clusterSpec.FluxConfig.Spec.Gitea = &v1alpha1.GiteaProviderConfig{Hostname: "gitea.example.com", Owner: "jeff", Repository: "fleet", Personal: true}
+	sshUrl := "ssh://git@gitea.example.com/jeff/fleet.git"
+
+	gitClient.EXPECT().GetRepo(ctx).Return(&git.Repository{Name: "fleet", SshUrl: sshUrl}, nil)
+	gitClient.EXPECT().AddDeployKey(ctx, gomock.Any()).Return(nil)
+	fluxClient.EXPECT().BootstrapGit(ctx, c, gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ *types.Cluster, fluxConfig *v1alpha1.FluxConfig, got *config.CliConfig) error {
+			g.Expect(fluxConfig.Spec.Git.RepositoryUrl).To(Equal(sshUrl))
+			g.Expect(fluxConfig.Spec.Gitea).To(BeNil())
+			g.Expect(got.GitKnownHostsFile).To(Equal("known_hosts"))
+			return nil
+		},
+	)
+
+	g.Expect(f.Bootstrap(ctx, c, clusterSpec)).To(Succeed())
+}
+
+func TestBootstrapGitlabSkipExistingManagement(t *testing.T) {
+	g := newFluxTest(t)
+	c := &types.Cluster{ExistingManagement: true}
+
+	g.Expect(g.gitOpsFlux.Bootstrap(g.ctx, c, newGitlabClusterSpec(t))).To(Succeed())
+}
+
+func TestBootstrapGitlabNoSshUrl(t *testing.T) {
+	g := newFluxTest(t)
+	c := &types.Cluster{}
+	clusterSpec := newGitlabClusterSpec(t)
+
+	g.git.EXPECT().GetRepo(g.ctx).Return(&git.Repository{Name: "fleet"}, nil)
+	g.flux.EXPECT().Uninstall(g.ctx, c, clusterSpec.FluxConfig).Return(nil)
+
+	g.Expect(g.gitOpsFlux.Bootstrap(g.ctx, c, clusterSpec)).To(MatchError("installing GitLab gitops: repository fleet doesn't have an ssh url"))
+}
+
+func TestBootstrapGitlabAddDeployKeyError(t *testing.T) {
+	g := newDeployKeyFluxTest(t)
+	c := &types.Cluster{}
+	clusterSpec := newGitlabClusterSpec(t)
+
+	g.git.EXPECT().GetRepo(g.ctx).Return(&git.Repository{Name: "fleet", SshUrl: "ssh://git@gitlab.example.com/platform/fleet.git"}, nil)
+	g.git.EXPECT().AddDeployKey(g.ctx, gomock.Any()).Return(errors.New("403 Forbidden"))
+	g.flux.EXPECT().Uninstall(g.ctx, c, clusterSpec.FluxConfig).Return(nil)
+
+	g.Expect(g.gitOpsFlux.Bootstrap(g.ctx, c, clusterSpec)).To(MatchError(ContainSubstring("adding deploy key: 403 Forbidden")))
+}
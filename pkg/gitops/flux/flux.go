@@ -2,9 +2,12 @@ package flux
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"path"
 
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/config"
@@ -44,11 +47,14 @@ type GitClient interface {
 	Push(ctx context.Context) error
 	Pull(ctx context.Context, branch string) error
 	PathExists(ctx context.Context, owner, repo, branch, path string) (exists bool, err error)
+	AddDeployKey(ctx context.Context, opts git.AddDeployKeyOpts) error
 	Add(filename string) error
 	Remove(filename string) error
 	Commit(message string) error
 	Branch(name string) error
 	Init() error
+	CreatePullRequest(ctx context.Context, opts git.CreatePullRequestOpts) (*git.PullRequest, error)
+	GetPullRequest(ctx context.Context, opts git.GetPullRequestOpts) (*git.PullRequest, error)
 }
 
 type Flux struct {
@@ -56,6 +62,8 @@ type Flux struct {
 	gitClient  GitClient
 	writer     filewriter.FileWriter
 	cliConfig  *config.CliConfig
+	// pendingPullRequest is the URL of a pull request with cluster config updates that has not been merged yet.
+	pendingPullRequest string
 }
 
 func NewFlux(fluxClient FluxClient, kubeClient KubeClient, gitTools *gitFactory.GitTools, cliConfig *config.CliConfig) *Flux {
@@ -111,6 +119,23 @@ func (f *Flux) InstallGitOps(ctx context.Context, cluster *types.Cluster, cluste
 	return nil
 }
 
+// CommitClusterConfig sets up the repository and commits the cluster config files to it, without the Flux system files.
+// It lets other GitOps engines reuse the repository layout of Flux without bootstrapping Flux.
+func (f *Flux) CommitClusterConfig(ctx context.Context, clusterSpec *cluster.Spec, datacenterConfig providers.DatacenterConfig, machineConfigs []providers.MachineConfig) error {
+	if f.shouldSkipFlux() {
+		logger.Info("GitOps field not specified, commit cluster config skipped")
+		return nil
+	}
+
+	fc := newFluxForCluster(f, clusterSpec, datacenterConfig, machineConfigs)
+
+	if err := fc.setupRepository(ctx); err != nil {
+		return err
+	}
+
+	return fc.commitConfigToGit(ctx, false)
+}
+
 func (f *Flux) Bootstrap(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error {
 	if err := f.BootstrapGithub(ctx, cluster, clusterSpec); err != nil {
 		_ = f.Uninstall(ctx, cluster, clusterSpec)
@@ -122,6 +147,16 @@ func (f *Flux) Bootstrap(ctx context.Context, cluster *types.Cluster, clusterSpe
 		return fmt.Errorf("installing generic git gitops: %v", err)
 	}
 
+	if err := f.BootstrapGitlab(ctx, cluster, clusterSpec); err != nil {
+		_ = f.Uninstall(ctx, cluster, clusterSpec)
+		return fmt.Errorf("installing GitLab gitops: %v", err)
+	}
+
+	if err := f.BootstrapGitea(ctx, cluster, clusterSpec); err != nil {
+		_ = f.Uninstall(ctx, cluster, clusterSpec)
+		return fmt.Errorf("installing Gitea gitops: %v", err)
+	}
+
 	return nil
 }
 
@@ -141,6 +176,24 @@ func (f *Flux) BootstrapGit(ctx context.Context, cluster *types.Cluster, cluster
 	return f.fluxClient.BootstrapGit(ctx, cluster, clusterSpec.FluxConfig, f.cliConfig)
 }
 
+// BootstrapGitlab bootstraps Flux with a GitLab repository, over SSH with a new deploy key.
+func (f *Flux) BootstrapGitlab(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	if cluster.ExistingManagement || clusterSpec.FluxConfig.Spec.Gitlab == nil {
+		return nil
+	}
+
+	return f.bootstrapWithDeployKey(ctx, cluster, clusterSpec)
+}
+
+// BootstrapGitea bootstraps Flux with a Gitea repository, over SSH with a new deploy key.
+func (f *Flux) BootstrapGitea(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	if cluster.ExistingManagement || clusterSpec.FluxConfig.Spec.Gitea == nil {
+		return nil
+	}
+
+	return f.bootstrapWithDeployKey(ctx, cluster, clusterSpec)
+}
+
 func (f *Flux) Uninstall(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error {
 	if err := f.fluxClient.Uninstall(ctx, cluster, clusterSpec.FluxConfig); err != nil {
 		logger.Info("Could not uninstall flux components", "error", err)
@@ -182,6 +235,11 @@ func (f *Flux) ResumeClusterResourcesReconcile(ctx context.Context, cluster *typ
 		return nil
 	}
 
+	if f.pendingPullRequest != "" {
+		logger.Info("Flux EKS-A resources reconcile stays paused until the pull request is merged; run upgrade again once merged", "url", f.pendingPullRequest)
+		return nil
+	}
+
 	logger.V(3).Info("Resume Flux EKS-A resources reconcile")
 
 	if err := f.fluxClient.EnableResourceReconcile(ctx, cluster, clusterSpec.Cluster.ResourceType(), clusterSpec.Cluster.Name, clusterSpec.Cluster.Namespace); err != nil {
@@ -209,6 +267,11 @@ func (f *Flux) ForceReconcileGitRepo(ctx context.Context, cluster *types.Cluster
 		return nil
 	}
 
+	if f.pendingPullRequest != "" {
+		logger.V(3).Info("Pull request with cluster config updates not merged yet, force reconcile flux git repo skipped", "url", f.pendingPullRequest)
+		return nil
+	}
+
 	return f.fluxClient.ForceReconcile(ctx, cluster, clusterSpec.FluxConfig.Spec.SystemNamespace)
 }
 
@@ -218,6 +281,10 @@ func (f *Flux) UpdateGitEksaSpec(ctx context.Context, clusterSpec *cluster.Spec,
 		return nil
 	}
 
+	if f.pullRequestMode() && clusterSpec.FluxConfig.Spec.Git != nil {
+		return errors.New("pull request mode is not supported by the generic git provider")
+	}
+
 	fc := newFluxForCluster(f, clusterSpec, datacenterConfig, machineConfigs)
 
 	if err := fc.syncGitRepo(ctx); err != nil {
@@ -233,11 +300,19 @@ func (f *Flux) UpdateGitEksaSpec(ctx context.Context, clusterSpec *cluster.Spec,
 		return err
 	}
 
+	if err := fc.writeEksaOverlay(); err != nil {
+		return fmt.Errorf("writing eks-a overlay: %v", err)
+	}
+
 	path := fc.eksaSystemDir()
 	if err := f.gitClient.Add(path); err != nil {
 		return fmt.Errorf("adding %s to git: %v", path, err)
 	}
 
+	if f.pullRequestMode() {
+		return fc.pushToPullRequest(ctx, path, updateClusterconfigCommitMessage)
+	}
+
 	if err := f.pushToRemoteRepo(ctx, path, updateClusterconfigCommitMessage); err != nil {
 		return err
 	}
@@ -245,6 +320,25 @@ func (f *Flux) UpdateGitEksaSpec(ctx context.Context, clusterSpec *cluster.Spec,
 	return nil
 }
 
+// ReadClusterConfig returns the EKS-A objects of the cluster in the git repository. In a split repository,
+// the objects of the shared bases are renamed to the cluster object names.
+func (f *Flux) ReadClusterConfig(ctx context.Context, clusterSpec *cluster.Spec) ([]unstructured.Unstructured, error) {
+	if f.shouldSkipFlux() {
+		return nil, errors.New("GitOps not configured, can't read cluster config from git")
+	}
+
+	fc := newFluxForCluster(f, clusterSpec, nil, nil)
+	if err := fc.syncGitRepo(ctx); err != nil {
+		return nil, err
+	}
+
+	if !validations.FileExists(path.Join(f.writer.Dir(), fc.eksaSystemDir(), kustomizeFileName)) {
+		return nil, fmt.Errorf("cluster config not found in git repository %s under %s", fc.repository(), fc.eksaSystemDir())
+	}
+
+	return readEksaObjects(f.writer.Dir(), fc.eksaSystemDir())
+}
+
 func (f *Flux) Validations(ctx context.Context, clusterSpec *cluster.Spec) []validations.Validation {
 	if f.shouldSkipFlux() {
 		return nil
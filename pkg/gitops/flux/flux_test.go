@@ -445,6 +445,34 @@ func TestInstallGitOpsCommitFilesError(t *testing.T) {
 	g.Expect(g.gitOpsFlux.InstallGitOps(g.ctx, cluster, clusterSpec, nil, nil)).To(MatchError(ContainSubstring("error in clone")))
 }
 
+func TestCommitClusterConfigWithoutFluxSystemFiles(t *testing.T) {
+	clusterName := "management-cluster"
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster(clusterName), "")
+
+	g.git.EXPECT().GetRepo(g.ctx).Return(&git.Repository{Name: clusterSpec.FluxConfig.Spec.Github.Repository}, nil)
+	g.git.EXPECT().Clone(g.ctx).Return(nil)
+	g.git.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+	g.git.EXPECT().Add("clusters").Return(nil)
+	g.git.EXPECT().Commit(test.OfType("string")).Return(nil)
+	g.git.EXPECT().Push(g.ctx).Return(nil)
+
+	datacenterConfig := datacenterConfig(clusterName)
+	machineConfig := machineConfig(clusterName)
+	g.Expect(g.gitOpsFlux.CommitClusterConfig(g.ctx, clusterSpec, datacenterConfig, []providers.MachineConfig{machineConfig})).To(Succeed())
+
+	expectedEksaClusterConfigPath := path.Join(g.writer.Dir(), "clusters/management-cluster/management-cluster/eksa-system", defaultEksaClusterConfigFileName)
+	test.AssertFilesEquals(t, expectedEksaClusterConfigPath, "./testdata/cluster-config-default-path-management.yaml")
+	g.Expect(path.Join(g.writer.Dir(), "clusters/management-cluster/flux-system", defaultFluxSyncFileName)).NotTo(BeAnExistingFile())
+}
+
+func TestCommitClusterConfigSkip(t *testing.T) {
+	g := NewWithT(t)
+	f := flux.NewFluxFromGitOpsFluxClient(nil, nil, nil, nil)
+
+	g.Expect(f.CommitClusterConfig(context.Background(), nil, nil, nil)).To(Succeed())
+}
+
 func TestInstallGitOpsNoPrexistingRepo(t *testing.T) {
 	tests := []struct {
 		testName                      string
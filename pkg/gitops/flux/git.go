@@ -2,6 +2,7 @@ package flux
 
 import (
 	"context"
+	"errors"
 
 	"github.com/aws/eks-anywhere/pkg/git"
 	gitFactory "github.com/aws/eks-anywhere/pkg/git/factory"
@@ -91,6 +92,46 @@ func (c *gitClient) PathExists(ctx context.Context, owner, repo, branch, path st
 	return exists, err
 }
 
+func (c *gitClient) AddDeployKey(ctx context.Context, opts git.AddDeployKeyOpts) error {
+	if c.gitProvider == nil {
+		return nil
+	}
+
+	return c.Retry(
+		func() error {
+			return c.gitProvider.AddDeployKeyToRepo(ctx, opts)
+		},
+	)
+}
+
+func (c *gitClient) CreatePullRequest(ctx context.Context, opts git.CreatePullRequestOpts) (pr *git.PullRequest, err error) {
+	if c.gitProvider == nil {
+		return nil, errors.New("pull requests are not supported by the generic git provider")
+	}
+
+	err = c.Retry(
+		func() error {
+			pr, err = c.gitProvider.CreatePullRequest(ctx, opts)
+			return err
+		},
+	)
+	return pr, err
+}
+
+func (c *gitClient) GetPullRequest(ctx context.Context, opts git.GetPullRequestOpts) (pr *git.PullRequest, err error) {
+	if c.gitProvider == nil {
+		return nil, errors.New("pull requests are not supported by the generic git provider")
+	}
+
+	err = c.Retry(
+		func() error {
+			pr, err = c.gitProvider.GetPullRequest(ctx, opts)
+			return err
+		},
+	)
+	return pr, err
+}
+
 func (c *gitClient) Add(filename string) error {
 	return c.git.Add(filename)
 }
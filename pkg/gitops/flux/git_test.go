@@ -170,6 +170,57 @@ func TestGitClientPathExistsError(t *testing.T) {
 	tt.Expect(err).To(MatchError(ContainSubstring("error in get repo")), "gitClient.PathExists() should fail after 5 tries")
 }
 
+func TestGitClientAddDeployKeySuccess(t *testing.T) {
+	tt := newGitClientTest(t)
+	opts := git.AddDeployKeyOpts{Owner: "owner", Repository: "repo", Key: "key"}
+	tt.p.EXPECT().AddDeployKeyToRepo(tt.ctx, opts).Return(errors.New("error in add deploy key")).Times(4)
+	tt.p.EXPECT().AddDeployKeyToRepo(tt.ctx, opts).Return(nil).Times(1)
+
+	tt.Expect(tt.c.AddDeployKey(tt.ctx, opts)).To(Succeed(), "gitClient.AddDeployKey() should succeed with 5 tries")
+}
+
+func TestGitClientAddDeployKeySkip(t *testing.T) {
+	tt := newGitClientTest(t)
+
+	c := newGitClient(&gitFactory.GitTools{Provider: nil, Client: tt.g})
+	tt.Expect(c.AddDeployKey(tt.ctx, git.AddDeployKeyOpts{})).To(Succeed())
+}
+
+func TestGitClientAddDeployKeyError(t *testing.T) {
+	tt := newGitClientTest(t)
+	tt.p.EXPECT().AddDeployKeyToRepo(tt.ctx, git.AddDeployKeyOpts{}).Return(errors.New("error in add deploy key")).Times(5)
+
+	tt.Expect(tt.c.AddDeployKey(tt.ctx, git.AddDeployKeyOpts{})).To(MatchError(ContainSubstring("error in add deploy key")), "gitClient.AddDeployKey() should fail after 5 tries")
+}
+
+func TestGitClientCreatePullRequestSuccess(t *testing.T) {
+	tt := newGitClientTest(t)
+	opts := git.CreatePullRequestOpts{Owner: "owner", Repository: "repo", Head: "feature", Base: "main"}
+	tt.p.EXPECT().CreatePullRequest(tt.ctx, opts).Return(nil, errors.New("error in create pull request")).Times(4)
+	tt.p.EXPECT().CreatePullRequest(tt.ctx, opts).Return(&git.PullRequest{Number: 1}, nil).Times(1)
+
+	pr, err := tt.c.CreatePullRequest(tt.ctx, opts)
+	tt.Expect(err).To(Succeed(), "gitClient.CreatePullRequest() should succeed with 5 tries")
+	tt.Expect(pr.Number).To(Equal(1))
+}
+
+func TestGitClientCreatePullRequestNoProvider(t *testing.T) {
+	tt := newGitClientTest(t)
+
+	c := newGitClient(&gitFactory.GitTools{Provider: nil, Client: tt.g})
+	_, err := c.CreatePullRequest(tt.ctx, git.CreatePullRequestOpts{})
+	tt.Expect(err).To(MatchError("pull requests are not supported by the generic git provider"))
+}
+
+func TestGitClientGetPullRequestError(t *testing.T) {
+	tt := newGitClientTest(t)
+	opts := git.GetPullRequestOpts{Owner: "owner", Repository: "repo", Number: 1}
+	tt.p.EXPECT().GetPullRequest(tt.ctx, opts).Return(nil, errors.New("error in get pull request")).Times(5)
+
+	_, err := tt.c.GetPullRequest(tt.ctx, opts)
+	tt.Expect(err).To(MatchError(ContainSubstring("error in get pull request")), "gitClient.GetPullRequest() should fail after 5 tries")
+}
+
 func TestGitClientAddSuccess(t *testing.T) {
 	tt := newGitClientTest(t)
 	tt.g.EXPECT().Add("").Return(nil)
@@ -0,0 +1,430 @@
+package flux
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	unstructuredutil "github.com/aws/eks-anywhere/pkg/utils/unstructured"
+	"github.com/aws/eks-anywhere/pkg/validations"
+)
+
+const (
+	// basesDirName is the directory, next to the cluster config path, with the Kustomize bases shared by the clusters.
+	// It has to be outside of the cluster config path, otherwise Flux would apply the bases on their own.
+	basesDirName         = "eksa-bases"
+	baseResourceFileName = "resource.yaml"
+
+	splitClusterconfigCommitMessage = "Split cluster configuration into shared bases and cluster overlays; generated by EKS-A CLI"
+
+	kustomizationAPIVersion = "kustomize.config.k8s.io/v1beta1"
+	kustomizationKind       = "Kustomization"
+)
+
+// kustomization is the subset of a Kustomize kustomization file used by the cluster overlays.
+type kustomization struct {
+	APIVersion string           `json:"apiVersion"`
+	Kind       string           `json:"kind"`
+	Resources  []string         `json:"resources"`
+	Patches    []kustomizePatch `json:"patches,omitempty"`
+}
+
+type kustomizePatch struct {
+	Target  kustomizeTarget `json:"target"`
+	Patch   string          `json:"patch"`
+	Options map[string]bool `json:"options,omitempty"`
+}
+
+type kustomizeTarget struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+}
+
+type jsonPatchOperation struct {
+	Op    string `json:"op"`
+	Path  string `json:"path"`
+	Value string `json:"value"`
+}
+
+// clusterBase is a Kustomize base with a single object shared by several clusters.
+type clusterBase struct {
+	dir    string
+	object unstructured.Unstructured
+}
+
+// SplitRepository moves the objects shared by the clusters in the GitOps repository, like machine configs or
+// identity providers, into Kustomize bases and turns the cluster eksa-system directories into overlays of those bases.
+// Once the repository is split, cluster config updates reuse the existing bases.
+func (f *Flux) SplitRepository(ctx context.Context, clusterSpec *cluster.Spec) error {
+	if f.shouldSkipFlux() {
+		logger.Info("GitOps field not specified, split git repo skipped")
+		return nil
+	}
+
+	fc := newFluxForCluster(f, clusterSpec, nil, nil)
+	if err := fc.syncGitRepo(ctx); err != nil {
+		return err
+	}
+
+	bases, err := splitClusterConfigs(f.writer, fc.path(), fc.basesDir())
+	if err != nil {
+		return fmt.Errorf("splitting cluster configs in %s: %v", fc.path(), err)
+	}
+	logger.Info("Split cluster configs into shared bases", "bases", bases, "directory", fc.basesDir())
+
+	p := path.Dir(fc.path())
+	if err := f.gitClient.Add(p); err != nil {
+		return fmt.Errorf("adding %s to git: %v", p, err)
+	}
+
+	if f.pullRequestMode() {
+		return fc.pushToPullRequest(ctx, p, splitClusterconfigCommitMessage)
+	}
+
+	return f.pushToRemoteRepo(ctx, p, splitClusterconfigCommitMessage)
+}
+
+func (fc *fluxForCluster) basesDir() string {
+	return path.Join(path.Dir(fc.path()), basesDirName)
+}
+
+// writeEksaOverlay turns the cluster eksa-system directory into an overlay of the existing shared bases,
+// if the repository has been split.
+func (fc *fluxForCluster) writeEksaOverlay() error {
+	if !validations.FileExists(filepath.Join(fc.writer.Dir(), fc.basesDir())) ||
+		!validations.FileExists(filepath.Join(fc.writer.Dir(), fc.eksaSystemDir(), kustomizeFileName)) {
+		return nil
+	}
+
+	bases, err := readBases(fc.writer.Dir(), fc.basesDir())
+	if err != nil {
+		return err
+	}
+
+	objs, err := readEksaObjects(fc.writer.Dir(), fc.eksaSystemDir())
+	if err != nil {
+		return err
+	}
+
+	return writeOverlay(fc.writer, fc.eksaSystemDir(), objs, bases)
+}
+
+// splitClusterConfigs creates a base for each object shared by at least two clusters under configPath
+// and rewrites all the clusters as overlays. It returns the number of bases created.
+func splitClusterConfigs(writer filewriter.FileWriter, configPath, basesDir string) (int, error) {
+	eksaSystemDirs, err := filepath.Glob(filepath.Join(writer.Dir(), configPath, "*", eksaSystemDirName, kustomizeFileName))
+	if err != nil {
+		return 0, err
+	}
+	sort.Strings(eksaSystemDirs)
+
+	clusterObjs := make(map[string][]unstructured.Unstructured, len(eksaSystemDirs))
+	dirs := make([]string, 0, len(eksaSystemDirs))
+	for _, k := range eksaSystemDirs {
+		dir, err := filepath.Rel(writer.Dir(), filepath.Dir(k))
+		if err != nil {
+			return 0, err
+		}
+		objs, err := readEksaObjects(writer.Dir(), dir)
+		if err != nil {
+			return 0, err
+		}
+		dirs = append(dirs, dir)
+		clusterObjs[dir] = objs
+	}
+
+	bases, err := readBases(writer.Dir(), basesDir)
+	if err != nil {
+		return 0, err
+	}
+
+	// The base takes the object of the first cluster, in name order, that has it.
+	candidates := map[string]unstructured.Unstructured{}
+	users := map[string]map[string]struct{}{}
+	for _, dir := range dirs {
+		for _, obj := range clusterObjs[dir] {
+			if !shareable(obj) {
+				continue
+			}
+			fp, err := fingerprint(obj)
+			if err != nil {
+				return 0, err
+			}
+			if _, ok := candidates[fp]; !ok {
+				candidates[fp] = obj
+				users[fp] = map[string]struct{}{}
+			}
+			users[fp][dir] = struct{}{}
+		}
+	}
+
+	created := 0
+	for fp, obj := range candidates {
+		if _, ok := bases[fp]; ok || len(users[fp]) < 2 {
+			continue
+		}
+		base, err := writeBase(writer, basesDir, fp, obj)
+		if err != nil {
+			return 0, err
+		}
+		bases[fp] = base
+		created++
+	}
+
+	for _, dir := range dirs {
+		if err := writeOverlay(writer, dir, clusterObjs[dir], bases); err != nil {
+			return 0, err
+		}
+	}
+
+	return created, nil
+}
+
+// shareable returns false for the objects that are specific to a cluster and always stay in its overlay.
+func shareable(obj unstructured.Unstructured) bool {
+	return obj.GetKind() != v1alpha1.ClusterKind
+}
+
+// fingerprint identifies the content of an object regardless of its name.
+func fingerprint(obj unstructured.Unstructured) (string, error) {
+	o := obj.DeepCopy()
+	unstructured.RemoveNestedField(o.Object, "metadata", "name")
+	b, err := json.Marshal(o.Object)
+	if err != nil {
+		return "", fmt.Errorf("marshalling %s %s: %v", obj.GetKind(), obj.GetName(), err)
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])[:10], nil
+}
+
+func writeBase(writer filewriter.FileWriter, basesDir, fp string, obj unstructured.Unstructured) (*clusterBase, error) {
+	dir := path.Join(basesDir, fmt.Sprintf("%s-%s", strings.ToLower(obj.GetKind()), fp))
+	w, err := writer.WithDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("initializing base writer: %v", err)
+	}
+	w.CleanUpTemp()
+
+	content, err := unstructuredutil.UnstructuredToYaml([]unstructured.Unstructured{obj})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(baseResourceFileName, content, filewriter.PersistentFile); err != nil {
+		return nil, fmt.Errorf("writing base %s: %v", dir, err)
+	}
+
+	if err := writeKustomization(w, &kustomization{Resources: []string{baseResourceFileName}}); err != nil {
+		return nil, err
+	}
+
+	return &clusterBase{dir: dir, object: obj}, nil
+}
+
+// readBases returns the shared bases by the fingerprint of their object.
+func readBases(repoDir, basesDir string) (map[string]*clusterBase, error) {
+	bases := map[string]*clusterBase{}
+	entries, err := os.ReadDir(filepath.Join(repoDir, basesDir))
+	if errors.Is(err, os.ErrNotExist) {
+		return bases, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading bases: %v", err)
+	}
+
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		dir := path.Join(basesDir, e.Name())
+		objs, err := readObjectsFile(filepath.Join(repoDir, dir, baseResourceFileName))
+		if err != nil {
+			return nil, err
+		}
+		if len(objs) != 1 {
+			return nil, fmt.Errorf("base %s must have exactly one object, found %d", dir, len(objs))
+		}
+		fp, err := fingerprint(objs[0])
+		if err != nil {
+			return nil, err
+		}
+		bases[fp] = &clusterBase{dir: dir, object: objs[0]}
+	}
+
+	return bases, nil
+}
+
+// readEksaObjects returns the objects of a cluster eksa-system directory, with the objects of its bases
+// renamed by the overlay patches. Other patches are not applied.
+func readEksaObjects(repoDir, eksaSystemDir string) ([]unstructured.Unstructured, error) {
+	k, err := readKustomization(filepath.Join(repoDir, eksaSystemDir, kustomizeFileName))
+	if err != nil {
+		return nil, err
+	}
+
+	renames, err := k.renames()
+	if err != nil {
+		return nil, fmt.Errorf("reading patches of %s: %v", eksaSystemDir, err)
+	}
+
+	var objs []unstructured.Unstructured
+	for _, r := range k.Resources {
+		p := filepath.Join(repoDir, eksaSystemDir, r)
+		if info, err := os.Stat(p); err == nil && info.IsDir() {
+			p = filepath.Join(p, baseResourceFileName)
+		}
+		resourceObjs, err := readObjectsFile(p)
+		if err != nil {
+			return nil, err
+		}
+		for _, obj := range resourceObjs {
+			if name, ok := renames[kustomizeTarget{Kind: obj.GetKind(), Name: obj.GetName()}]; ok {
+				obj.SetName(name)
+			}
+			objs = append(objs, obj)
+		}
+	}
+
+	return objs, nil
+}
+
+// writeOverlay writes the cluster objects that don't match a base to the cluster config file,
+// and a kustomization with those bases, renamed to the cluster object names.
+func writeOverlay(writer filewriter.FileWriter, eksaSystemDir string, objs []unstructured.Unstructured, bases map[string]*clusterBase) error {
+	k := &kustomization{Resources: []string{clusterConfigFileName}}
+	var own []unstructured.Unstructured
+	used := map[string]struct{}{}
+	var baseDirs []string
+
+	for _, obj := range objs {
+		fp, err := fingerprint(obj)
+		if err != nil {
+			return err
+		}
+		base, ok := bases[fp]
+		if _, inUse := used[fp]; !ok || inUse || !shareable(obj) {
+			own = append(own, obj)
+			continue
+		}
+		used[fp] = struct{}{}
+
+		rel, err := filepath.Rel(eksaSystemDir, base.dir)
+		if err != nil {
+			return err
+		}
+		baseDirs = append(baseDirs, filepath.ToSlash(rel))
+
+		if obj.GetName() != base.object.GetName() {
+			patch, err := renamePatch(base.object, obj.GetName())
+			if err != nil {
+				return err
+			}
+			k.Patches = append(k.Patches, *patch)
+		}
+	}
+
+	sort.Strings(baseDirs)
+	k.Resources = append(k.Resources, baseDirs...)
+	sort.Slice(k.Patches, func(i, j int) bool {
+		if k.Patches[i].Target.Kind != k.Patches[j].Target.Kind {
+			return k.Patches[i].Target.Kind < k.Patches[j].Target.Kind
+		}
+		return k.Patches[i].Target.Name < k.Patches[j].Target.Name
+	})
+
+	w, err := writer.WithDir(eksaSystemDir)
+	if err != nil {
+		return fmt.Errorf("initializing eks-a system writer: %v", err)
+	}
+	w.CleanUpTemp()
+
+	content, err := unstructuredutil.UnstructuredToYaml(own)
+	if err != nil {
+		return err
+	}
+	if filePath, err := w.Write(clusterConfigFileName, content, filewriter.PersistentFile); err != nil {
+		return fmt.Errorf("writing eks-a cluster config file into %s: %v", filePath, err)
+	}
+
+	return writeKustomization(w, k)
+}
+
+func renamePatch(base unstructured.Unstructured, name string) (*kustomizePatch, error) {
+	ops, err := yaml.Marshal([]jsonPatchOperation{{Op: "replace", Path: "/metadata/name", Value: name}})
+	if err != nil {
+		return nil, fmt.Errorf("marshalling rename patch: %v", err)
+	}
+	return &kustomizePatch{
+		Target:  kustomizeTarget{Kind: base.GetKind(), Name: base.GetName()},
+		Patch:   string(ops),
+		Options: map[string]bool{"allowNameChange": true},
+	}, nil
+}
+
+// renames returns the new names set by the rename patches of the kustomization.
+func (k *kustomization) renames() (map[kustomizeTarget]string, error) {
+	renames := map[kustomizeTarget]string{}
+	for _, p := range k.Patches {
+		var ops []jsonPatchOperation
+		if err := yaml.Unmarshal([]byte(p.Patch), &ops); err != nil {
+			return nil, err
+		}
+		for _, op := range ops {
+			if op.Op == "replace" && op.Path == "/metadata/name" {
+				renames[p.Target] = op.Value
+			}
+		}
+	}
+	return renames, nil
+}
+
+func readKustomization(file string) (*kustomization, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("reading kustomization: %v", err)
+	}
+	k := &kustomization{}
+	if err := yaml.Unmarshal(content, k); err != nil {
+		return nil, fmt.Errorf("parsing kustomization %s: %v", file, err)
+	}
+	return k, nil
+}
+
+func writeKustomization(w filewriter.FileWriter, k *kustomization) error {
+	k.APIVersion = kustomizationAPIVersion
+	k.Kind = kustomizationKind
+	content, err := yaml.Marshal(k)
+	if err != nil {
+		return fmt.Errorf("marshalling kustomization: %v", err)
+	}
+	if filePath, err := w.Write(kustomizeFileName, content, filewriter.PersistentFile); err != nil {
+		return fmt.Errorf("writing kustomization manifest file into %s: %v", filePath, err)
+	}
+	return nil
+}
+
+func readObjectsFile(file string) ([]unstructured.Unstructured, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %v", file, err)
+	}
+	objs, err := unstructuredutil.YamlToUnstructured(content)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %v", file, err)
+	}
+	return objs, nil
+}
@@ -0,0 +1,165 @@
+package flux_test
+
+import (
+	"context"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/gitops/flux"
+	fluxMocks "github.com/aws/eks-anywhere/pkg/gitops/flux/mocks"
+	"github.com/aws/eks-anywhere/pkg/providers"
+)
+
+const layoutConfigPath = "clusters/management-cluster"
+
+type layoutTest struct {
+	*WithT
+	ctx     context.Context
+	repoDir string
+	git     *fluxMocks.MockGitClient
+	flux    *flux.Flux
+}
+
+func newLayoutTest(t *testing.T) *layoutTest {
+	mockGit := fluxMocks.NewMockGitClient(gomock.NewController(t))
+	repoDir, w := test.NewWriter(t)
+	if _, err := w.WithDir(".git"); err != nil {
+		t.Fatalf("failed to add .git dir: %v", err)
+	}
+
+	return &layoutTest{
+		WithT:   NewWithT(t),
+		ctx:     context.Background(),
+		repoDir: repoDir,
+		git:     mockGit,
+		flux:    flux.NewFluxFromGitOpsFluxClient(nil, mockGit, w, nil),
+	}
+}
+
+func (tt *layoutTest) writeCluster(t *testing.T, name string) {
+	t.Helper()
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster(name), layoutConfigPath)
+	tt.git.EXPECT().Branch("testBranch").Return(nil)
+	tt.git.EXPECT().Add(path.Join(layoutConfigPath, name, "eksa-system")).Return(nil)
+	tt.git.EXPECT().Commit(test.OfType("string")).Return(nil)
+	tt.git.EXPECT().Push(tt.ctx).Return(nil)
+
+	tt.Expect(tt.flux.UpdateGitEksaSpec(tt.ctx, clusterSpec, datacenterConfig(name), []providers.MachineConfig{machineConfig(name)})).To(Succeed())
+}
+
+func (tt *layoutTest) split(t *testing.T) {
+	t.Helper()
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), layoutConfigPath)
+	tt.git.EXPECT().Branch("testBranch").Return(nil)
+	tt.git.EXPECT().Add("clusters").Return(nil)
+	tt.git.EXPECT().Commit(test.OfType("string")).Return(nil)
+	tt.git.EXPECT().Push(tt.ctx).Return(nil)
+
+	tt.Expect(tt.flux.SplitRepository(tt.ctx, clusterSpec)).To(Succeed())
+}
+
+func (tt *layoutTest) eksaSystemFile(cluster, file string) string {
+	return path.Join(tt.repoDir, layoutConfigPath, cluster, "eksa-system", file)
+}
+
+func TestSplitRepository(t *testing.T) {
+	tt := newLayoutTest(t)
+	tt.writeCluster(t, "workload-1")
+	tt.writeCluster(t, "workload-2")
+
+	tt.split(t)
+
+	bases, err := os.ReadDir(path.Join(tt.repoDir, "clusters/eksa-bases"))
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(bases).To(HaveLen(3))
+	test.AssertFilesEquals(t, tt.eksaSystemFile("workload-1", "kustomization.yaml"), "./testdata/split-workload-1-kustomization.yaml")
+	test.AssertFilesEquals(t, tt.eksaSystemFile("workload-2", "kustomization.yaml"), "./testdata/split-workload-2-kustomization.yaml")
+	test.AssertFilesEquals(t, tt.eksaSystemFile("workload-2", "eksa-cluster.yaml"), "./testdata/split-workload-2-eksa-cluster.yaml")
+}
+
+func TestSplitRepositoryTwice(t *testing.T) {
+	tt := newLayoutTest(t)
+	tt.writeCluster(t, "workload-1")
+	tt.writeCluster(t, "workload-2")
+	tt.split(t)
+
+	tt.split(t)
+
+	bases, err := os.ReadDir(path.Join(tt.repoDir, "clusters/eksa-bases"))
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(bases).To(HaveLen(3))
+	test.AssertFilesEquals(t, tt.eksaSystemFile("workload-2", "kustomization.yaml"), "./testdata/split-workload-2-kustomization.yaml")
+	test.AssertFilesEquals(t, tt.eksaSystemFile("workload-2", "eksa-cluster.yaml"), "./testdata/split-workload-2-eksa-cluster.yaml")
+}
+
+func TestSplitRepositoryNothingShared(t *testing.T) {
+	tt := newLayoutTest(t)
+	tt.writeCluster(t, "workload-1")
+
+	tt.split(t)
+
+	tt.Expect(path.Join(tt.repoDir, "clusters/eksa-bases")).NotTo(BeADirectory())
+	test.AssertFilesEquals(t, tt.eksaSystemFile("workload-1", "eksa-cluster.yaml"), "./testdata/split-workload-1-eksa-cluster.yaml")
+}
+
+func TestUpdateGitEksaSpecReusesBases(t *testing.T) {
+	tt := newLayoutTest(t)
+	tt.writeCluster(t, "workload-1")
+	tt.writeCluster(t, "workload-2")
+	tt.split(t)
+
+	tt.writeCluster(t, "workload-3")
+
+	test.AssertFilesEquals(t, tt.eksaSystemFile("workload-3", "kustomization.yaml"), "./testdata/split-workload-3-kustomization.yaml")
+	test.AssertFilesEquals(t, tt.eksaSystemFile("workload-3", "eksa-cluster.yaml"), "./testdata/split-workload-3-eksa-cluster.yaml")
+}
+
+func TestSplitRepositorySkip(t *testing.T) {
+	g := NewWithT(t)
+	f := flux.NewFlux(nil, nil, nil, nil)
+
+	g.Expect(f.SplitRepository(context.Background(), nil)).To(Succeed())
+}
+
+func TestReadClusterConfigSplitRepository(t *testing.T) {
+	tt := newLayoutTest(t)
+	tt.writeCluster(t, "workload-1")
+	tt.writeCluster(t, "workload-2")
+	tt.split(t)
+	tt.git.EXPECT().Branch("testBranch").Return(nil)
+
+	objs, err := tt.flux.ReadClusterConfig(tt.ctx, newClusterSpec(t, v1alpha1.NewCluster("workload-2"), layoutConfigPath))
+	tt.Expect(err).NotTo(HaveOccurred())
+	names := map[string]string{}
+	for _, o := range objs {
+		names[o.GetKind()] = o.GetName()
+	}
+	tt.Expect(names).To(Equal(map[string]string{
+		v1alpha1.ClusterKind:              "workload-2",
+		v1alpha1.VSphereDatacenterKind:    "workload-2",
+		v1alpha1.VSphereMachineConfigKind: "workload-2",
+		v1alpha1.FluxConfigKind:           "test-gitops",
+	}))
+}
+
+func TestReadClusterConfigNotFound(t *testing.T) {
+	tt := newLayoutTest(t)
+	tt.git.EXPECT().Branch("testBranch").Return(nil)
+
+	_, err := tt.flux.ReadClusterConfig(tt.ctx, newClusterSpec(t, v1alpha1.NewCluster("workload-1"), layoutConfigPath))
+	tt.Expect(err).To(MatchError(ContainSubstring("cluster config not found in git repository testRepo")))
+}
+
+func TestReadClusterConfigSkip(t *testing.T) {
+	g := NewWithT(t)
+	f := flux.NewFlux(nil, nil, nil, nil)
+
+	_, err := f.ReadClusterConfig(context.Background(), nil)
+	g.Expect(err).To(MatchError("GitOps not configured, can't read cluster config from git"))
+}
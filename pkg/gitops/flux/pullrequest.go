@@ -0,0 +1,101 @@
+package flux
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/retrier"
+)
+
+const (
+	pullRequestBranchPrefix = "eksa-update"
+	pullRequestTitle        = "Update cluster configuration for %s"
+	pullRequestDescription  = "Cluster configuration update for cluster %s; generated by EKS-A CLI."
+	pullRequestPollPeriod   = 15 * time.Second
+)
+
+var errPullRequestClosed = errors.New("pull request was closed without being merged")
+
+func (f *Flux) pullRequestMode() bool {
+	return f.cliConfig != nil && f.cliConfig.GitOpsPullRequest
+}
+
+// waitForPullRequest returns false when the pull request is left open for the user to merge.
+func (f *Flux) waitForPullRequest() bool {
+	return f.cliConfig.GitOpsPullRequestTimeout > 0
+}
+
+// PendingPullRequest returns the URL of the pull request opened by UpdateGitEksaSpec
+// when the CLI did not wait for it to be merged, or an empty string.
+func (f *Flux) PendingPullRequest() string {
+	return f.pendingPullRequest
+}
+
+// pushToPullRequest pushes the committed changes to a new feature branch and opens a pull request
+// against the GitOps branch. It then either waits for the pull request to be merged or records it as pending.
+func (fc *fluxForCluster) pushToPullRequest(ctx context.Context, path, msg string) error {
+	head := fmt.Sprintf("%s-%s-%d", pullRequestBranchPrefix, fc.clusterSpec.Cluster.Name, time.Now().Unix())
+	if err := fc.gitClient.Branch(head); err != nil {
+		return fmt.Errorf("creating git branch %s: %v", head, err)
+	}
+
+	if err := fc.pushToRemoteRepo(ctx, path, msg); err != nil {
+		return err
+	}
+
+	pr, err := fc.gitClient.CreatePullRequest(ctx, git.CreatePullRequestOpts{
+		Owner:       fc.owner(),
+		Repository:  fc.repository(),
+		Title:       fmt.Sprintf(pullRequestTitle, fc.clusterSpec.Cluster.Name),
+		Description: fmt.Sprintf(pullRequestDescription, fc.clusterSpec.Cluster.Name),
+		Head:        head,
+		Base:        fc.branch(),
+	})
+	if err != nil {
+		return fmt.Errorf("creating pull request from %s to %s: %v", head, fc.branch(), err)
+	}
+	logger.Info("Opened pull request with the updated cluster config", "url", pr.Url)
+
+	if !fc.waitForPullRequest() {
+		fc.pendingPullRequest = pr.Url
+		return nil
+	}
+
+	logger.Info("Waiting for the pull request to be merged", "url", pr.Url, "timeout", fc.cliConfig.GitOpsPullRequestTimeout)
+	if err := fc.waitForPullRequestMerged(ctx, pr.Number); err != nil {
+		return fmt.Errorf("waiting for pull request %s to be merged: %v", pr.Url, err)
+	}
+
+	if err := fc.gitClient.Branch(fc.branch()); err != nil {
+		return fmt.Errorf("switching to git branch %s: %v", fc.branch(), err)
+	}
+	return nil
+}
+
+func (fc *fluxForCluster) waitForPullRequestMerged(ctx context.Context, number int) error {
+	r := retrier.New(fc.cliConfig.GitOpsPullRequestTimeout, retrier.WithRetryPolicy(func(_ int, err error) (bool, time.Duration) {
+		return !errors.Is(err, errPullRequestClosed), pullRequestPollPeriod
+	}))
+
+	return r.Retry(func() error {
+		pr, err := fc.gitClient.GetPullRequest(ctx, git.GetPullRequestOpts{
+			Owner:      fc.owner(),
+			Repository: fc.repository(),
+			Number:     number,
+		})
+		if err != nil {
+			return err
+		}
+		if pr.Merged {
+			return nil
+		}
+		if pr.Closed {
+			return errPullRequestClosed
+		}
+		return errors.New("pull request is not merged yet")
+	})
+}
@@ -0,0 +1,157 @@
+package flux_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/config"
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/gitops/flux"
+	fluxMocks "github.com/aws/eks-anywhere/pkg/gitops/flux/mocks"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const (
+	pullRequestUrl           = "https://github.com/mFolwer/testRepo/pull/7"
+	pullRequestEksaSystemDir = "clusters/management-cluster/management-cluster/eksa-system"
+	pullRequestClusterName   = "management-cluster"
+)
+
+type pullRequestTest struct {
+	*WithT
+	ctx         context.Context
+	git         *fluxMocks.MockGitClient
+	flux        *fluxMocks.MockGitOpsFluxClient
+	gitOpsFlux  *flux.Flux
+	clusterSpec *cluster.Spec
+}
+
+func newPullRequestTest(t *testing.T, timeout time.Duration) *pullRequestTest {
+	mockCtrl := gomock.NewController(t)
+	mockGit := fluxMocks.NewMockGitClient(mockCtrl)
+	mockFlux := fluxMocks.NewMockGitOpsFluxClient(mockCtrl)
+	_, w := test.NewWriter(t)
+	if _, err := w.WithDir(".git"); err != nil {
+		t.Fatalf("failed to add .git dir: %v", err)
+	}
+	cliConfig := &config.CliConfig{
+		GitOpsPullRequest:        true,
+		GitOpsPullRequestTimeout: timeout,
+	}
+
+	return &pullRequestTest{
+		WithT:       NewWithT(t),
+		ctx:         context.Background(),
+		git:         mockGit,
+		flux:        mockFlux,
+		gitOpsFlux:  flux.NewFluxFromGitOpsFluxClient(mockFlux, mockGit, w, cliConfig),
+		clusterSpec: newClusterSpec(t, v1alpha1.NewCluster(pullRequestClusterName), ""),
+	}
+}
+
+// pullRequestOptsMatcher matches pull request options opened from a feature branch into the GitOps branch.
+type pullRequestOptsMatcher struct {
+	base string
+}
+
+func (m pullRequestOptsMatcher) Matches(x interface{}) bool {
+	opts, ok := x.(git.CreatePullRequestOpts)
+	if !ok {
+		return false
+	}
+	return opts.Owner == "mFolwer" && opts.Repository == "testRepo" && opts.Base == m.base &&
+		strings.HasPrefix(opts.Head, "eksa-update-"+pullRequestClusterName+"-")
+}
+
+func (m pullRequestOptsMatcher) String() string {
+	return fmt.Sprintf("is a pull request from an eksa-update branch into %s", m.base)
+}
+
+func (tt *pullRequestTest) expectPushToFeatureBranch() {
+	tt.git.EXPECT().Branch("testBranch").Return(nil)
+	tt.git.EXPECT().Add(pullRequestEksaSystemDir).Return(nil)
+	tt.git.EXPECT().Branch(test.OfType("string")).Return(nil)
+	tt.git.EXPECT().Commit(test.OfType("string")).Return(nil)
+	tt.git.EXPECT().Push(tt.ctx).Return(nil)
+	tt.git.EXPECT().CreatePullRequest(tt.ctx, pullRequestOptsMatcher{base: "testBranch"}).Return(&git.PullRequest{Number: 7, Url: pullRequestUrl}, nil)
+}
+
+func (tt *pullRequestTest) updateGitEksaSpec() error {
+	return tt.gitOpsFlux.UpdateGitEksaSpec(tt.ctx, tt.clusterSpec, datacenterConfig(pullRequestClusterName), []providers.MachineConfig{machineConfig(pullRequestClusterName)})
+}
+
+func TestUpdateGitEksaSpecPullRequestNoWait(t *testing.T) {
+	tt := newPullRequestTest(t, 0)
+	tt.expectPushToFeatureBranch()
+
+	tt.Expect(tt.updateGitEksaSpec()).To(Succeed())
+	tt.Expect(tt.gitOpsFlux.PendingPullRequest()).To(Equal(pullRequestUrl))
+}
+
+func TestUpdateGitEksaSpecPullRequestPendingSkipsReconcile(t *testing.T) {
+	tt := newPullRequestTest(t, 0)
+	tt.expectPushToFeatureBranch()
+	c := &types.Cluster{}
+
+	tt.Expect(tt.updateGitEksaSpec()).To(Succeed())
+	tt.Expect(tt.gitOpsFlux.ForceReconcileGitRepo(tt.ctx, c, tt.clusterSpec)).To(Succeed())
+	tt.Expect(tt.gitOpsFlux.ResumeClusterResourcesReconcile(tt.ctx, c, tt.clusterSpec, nil)).To(Succeed())
+}
+
+func TestUpdateGitEksaSpecPullRequestWaitMerged(t *testing.T) {
+	tt := newPullRequestTest(t, time.Minute)
+	tt.expectPushToFeatureBranch()
+	getOpts := git.GetPullRequestOpts{Owner: "mFolwer", Repository: "testRepo", Number: 7}
+	tt.git.EXPECT().GetPullRequest(tt.ctx, getOpts).Return(&git.PullRequest{Number: 7, Url: pullRequestUrl, Merged: true}, nil)
+	tt.git.EXPECT().Branch("testBranch").Return(nil)
+
+	tt.Expect(tt.updateGitEksaSpec()).To(Succeed())
+	tt.Expect(tt.gitOpsFlux.PendingPullRequest()).To(BeEmpty())
+}
+
+func TestUpdateGitEksaSpecPullRequestClosed(t *testing.T) {
+	tt := newPullRequestTest(t, time.Minute)
+	tt.expectPushToFeatureBranch()
+	tt.git.EXPECT().GetPullRequest(tt.ctx, gomock.Any()).Return(&git.PullRequest{Number: 7, Url: pullRequestUrl, Closed: true}, nil)
+
+	tt.Expect(tt.updateGitEksaSpec()).To(MatchError(ContainSubstring("pull request was closed without being merged")))
+}
+
+func TestUpdateGitEksaSpecPullRequestTimeout(t *testing.T) {
+	tt := newPullRequestTest(t, time.Nanosecond)
+	tt.expectPushToFeatureBranch()
+	tt.git.EXPECT().GetPullRequest(tt.ctx, gomock.Any()).Return(&git.PullRequest{Number: 7, Url: pullRequestUrl}, nil)
+
+	tt.Expect(tt.updateGitEksaSpec()).To(MatchError(ContainSubstring("pull request is not merged yet")))
+}
+
+func TestUpdateGitEksaSpecPullRequestCreateError(t *testing.T) {
+	tt := newPullRequestTest(t, 0)
+	tt.git.EXPECT().Branch("testBranch").Return(nil)
+	tt.git.EXPECT().Add(pullRequestEksaSystemDir).Return(nil)
+	tt.git.EXPECT().Branch(test.OfType("string")).Return(nil)
+	tt.git.EXPECT().Commit(test.OfType("string")).Return(nil)
+	tt.git.EXPECT().Push(tt.ctx).Return(nil)
+	tt.git.EXPECT().CreatePullRequest(tt.ctx, gomock.Any()).Return(nil, errors.New("forbidden"))
+
+	tt.Expect(tt.updateGitEksaSpec()).To(MatchError(ContainSubstring("to testBranch: forbidden")))
+}
+
+func TestUpdateGitEksaSpecPullRequestGenericGit(t *testing.T) {
+	tt := newPullRequestTest(t, 0)
+	tt.clusterSpec.FluxConfig.Spec.Github = nil
+	tt.clusterSpec.FluxConfig.Spec.Git = &v1alpha1.GitProviderConfig{RepositoryUrl: "ssh://git@example.com/repo.git"}
+
+	tt.Expect(tt.updateGitEksaSpec()).To(MatchError("pull request mode is not supported by the generic git provider"))
+}
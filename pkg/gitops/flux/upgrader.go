@@ -53,6 +53,10 @@ func FluxChangeDiff(currentSpec, newSpec *cluster.Spec) *types.ChangeDiff {
 		logger.V(1).Info("Skipping Flux upgrades, GitOps not enabled")
 		return nil
 	}
+	if newSpec.ArgoCDConfig != nil {
+		logger.V(1).Info("Skipping Flux upgrades, GitOps managed by Argo CD")
+		return nil
+	}
 	oldVersion := currentSpec.VersionsBundle.Flux.Version
 	newVersion := newSpec.VersionsBundle.Flux.Version
 	if oldVersion != newVersion {
@@ -86,6 +86,15 @@ func TestFluxUpgradeNoChanges(t *testing.T) {
 	tt.Expect(g.gitOpsFlux.Upgrade(tt.ctx, tt.cluster, tt.currentSpec, tt.newSpec)).To(BeNil())
 }
 
+func TestFluxUpgradeArgoCD(t *testing.T) {
+	tt := newUpgraderTest(t)
+	g := newFluxTest(t)
+	tt.newSpec.VersionsBundle.Flux.Version = "v0.2.0"
+	tt.newSpec.ArgoCDConfig = &v1alpha1.ArgoCDConfig{}
+
+	tt.Expect(g.gitOpsFlux.Upgrade(tt.ctx, tt.cluster, tt.currentSpec, tt.newSpec)).To(BeNil())
+}
+
 func TestFluxUpgradeSuccess(t *testing.T) {
 	tt := newUpgraderTest(t)
 	tt.newSpec.VersionsBundle.Flux.Version = "v0.2.0"
@@ -0,0 +1,176 @@
+package imagereport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/aws/eks-anywhere/pkg/registry"
+)
+
+// Vulnerability is a vulnerability affecting a package of an image.
+type Vulnerability struct {
+	ID               string `json:"id"`
+	Severity         string `json:"severity"`
+	Package          string `json:"package"`
+	InstalledVersion string `json:"installedVersion"`
+	// FixedVersion is the version of the package fixing the vulnerability, empty when it's not fixed yet.
+	FixedVersion string `json:"fixedVersion,omitempty"`
+	Title        string `json:"title,omitempty"`
+}
+
+// ImageReport is the report of an image and of where it's used in the cluster.
+type ImageReport struct {
+	Image  string `json:"image"`
+	Digest string `json:"digest,omitempty"`
+	// Sources are where the image is referenced: the versions bundle, a curated package or a pod.
+	Sources []string `json:"sources"`
+	// SBOM is false when the image doesn't have any SBOM attached, so it couldn't be scanned.
+	SBOM            bool            `json:"sbom"`
+	Packages        int             `json:"packages"`
+	Vulnerabilities []Vulnerability `json:"vulnerabilities"`
+	// Error is why the image couldn't be scanned, e.g. the registry couldn't be reached.
+	Error string `json:"error,omitempty"`
+}
+
+// Source names.
+const (
+	BundleSource = "bundle"
+)
+
+// PackageSource is the source of the images of an installed curated package.
+func PackageSource(name string) string {
+	return "package " + name
+}
+
+// PodSource is the source of the images of a running pod.
+func PodSource(namespace, name string) string {
+	return "pod " + namespace + "/" + name
+}
+
+// Images are the images referenced by a cluster with their sources, in the order they were added.
+type Images struct {
+	sources map[string][]string
+	order   []string
+}
+
+// NewImages returns an empty set of images.
+func NewImages() *Images {
+	return &Images{sources: map[string][]string{}}
+}
+
+// Add adds an image referenced by a source.
+func (i *Images) Add(image, source string) {
+	if image == "" {
+		return
+	}
+	sources, ok := i.sources[image]
+	if !ok {
+		i.order = append(i.order, image)
+	}
+	for _, s := range sources {
+		if s == source {
+			return
+		}
+	}
+	i.sources[image] = append(sources, source)
+}
+
+// AddPods adds the images of the containers of the pods, pinned to the digest they run when it's known.
+func (i *Images) AddPods(pods []corev1.Pod) {
+	for _, pod := range pods {
+		source := PodSource(pod.Namespace, pod.Name)
+		digests := map[string]string{}
+		for _, statuses := range [][]corev1.ContainerStatus{pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses} {
+			for _, status := range statuses {
+				if at := strings.LastIndex(status.ImageID, "@"); at >= 0 {
+					digests[status.Name] = status.ImageID[at+1:]
+				}
+			}
+		}
+		for _, containers := range [][]corev1.Container{pod.Spec.InitContainers, pod.Spec.Containers} {
+			for _, container := range containers {
+				image := container.Image
+				if digest, ok := digests[container.Name]; ok && !strings.Contains(image, "@") {
+					image += "@" + digest
+				}
+				i.Add(image, source)
+			}
+		}
+	}
+}
+
+// Len returns the number of images.
+func (i *Images) Len() int {
+	return len(i.order)
+}
+
+// VulnerabilityDB looks up the vulnerabilities of a package.
+type VulnerabilityDB interface {
+	Vulnerabilities(p Package) ([]Vulnerability, error)
+}
+
+// Reporter reports the vulnerabilities of images from the packages in their SBOMs.
+type Reporter struct {
+	db      VulnerabilityDB
+	sources registry.SourceClientFunc
+}
+
+// NewReporter returns a Reporter reading the images from their registries with sources.
+func NewReporter(db VulnerabilityDB, sources registry.SourceClientFunc) *Reporter {
+	return &Reporter{db: db, sources: sources}
+}
+
+// Report returns the report of every image. Images that can't be read are reported with their error,
+// only errors looking up the vulnerability DB are returned.
+func (r *Reporter) Report(ctx context.Context, images *Images) ([]ImageReport, error) {
+	reports := make([]ImageReport, 0, images.Len())
+	for _, image := range images.order {
+		report := ImageReport{Image: image, Sources: images.sources[image], Vulnerabilities: []Vulnerability{}}
+		packages, err := r.imagePackages(ctx, &report)
+		if err != nil {
+			report.Error = err.Error()
+			reports = append(reports, report)
+			continue
+		}
+
+		report.Packages = len(packages)
+		for _, p := range packages {
+			vulnerabilities, err := r.db.Vulnerabilities(p)
+			if err != nil {
+				return nil, fmt.Errorf("looking up vulnerabilities of %s in %s: %v", p.PURL, image, err)
+			}
+			report.Vulnerabilities = append(report.Vulnerabilities, vulnerabilities...)
+		}
+		reports = append(reports, report)
+	}
+
+	sort.SliceStable(reports, func(i, j int) bool {
+		return reports[i].Image < reports[j].Image
+	})
+	return reports, nil
+}
+
+func (r *Reporter) imagePackages(ctx context.Context, report *ImageReport) ([]Package, error) {
+	artifact := registry.NewArtifactFromURI(report.Image)
+	client, err := r.sources(artifact)
+	if err != nil {
+		return nil, err
+	}
+
+	digest, content, err := registry.FetchSBOM(ctx, client, artifact)
+	report.Digest = digest
+	if errors.Is(err, registry.ErrSBOMNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	report.SBOM = true
+
+	return ParseSBOM(content)
+}
@@ -0,0 +1,160 @@
+package imagereport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/errdef"
+	orasregistry "oras.land/oras-go/v2/registry"
+
+	"github.com/aws/eks-anywhere/pkg/registry"
+)
+
+const testSBOM = `{"bomFormat":"CycloneDX","components":[
+	{"name":"libssl1.1","version":"1.1.1n-0+deb11u4","purl":"pkg:deb/debian/libssl1.1@1.1.1n-0+deb11u4?upstream=openssl&distro=debian-11"},
+	{"name":"bash","version":"5.1","purl":"pkg:deb/debian/bash@5.1?distro=debian-11"}]}`
+
+type reportTest struct {
+	*WithT
+	t      *testing.T
+	ctx    context.Context
+	client registry.StorageClient
+}
+
+func newReportTest(t *testing.T) *reportTest {
+	tt := &reportTest{
+		WithT:  NewWithT(t),
+		t:      t,
+		ctx:    context.Background(),
+		client: registry.NewOCILayout(registry.NewStorageContext("oci:"+t.TempDir(), nil, nil, false)),
+	}
+	tt.Expect(tt.client.Init()).To(Succeed())
+	return tt
+}
+
+func (tt *reportTest) sources(registry.Artifact) (registry.StorageClient, error) {
+	return tt.client, nil
+}
+
+func (tt *reportTest) pushBlob(repo orasregistry.Repository, mediaType string, blob []byte) ocispec.Descriptor {
+	desc := content.NewDescriptorFromBytes(mediaType, blob)
+	if err := repo.Push(tt.ctx, desc, bytes.NewReader(blob)); err != nil && !errors.Is(err, errdef.ErrAlreadyExists) {
+		tt.t.Fatal(err)
+	}
+	return desc
+}
+
+func (tt *reportTest) pushManifest(repo orasregistry.Repository, layer ocispec.Descriptor, tag string) ocispec.Descriptor {
+	manifest, err := json.Marshal(ocispec.Manifest{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Config:    tt.pushBlob(repo, ocispec.MediaTypeImageConfig, []byte("{}")),
+		Layers:    []ocispec.Descriptor{layer},
+	})
+	tt.Expect(err).NotTo(HaveOccurred())
+	manifest = append([]byte(`{"schemaVersion":2,`), manifest[1:]...)
+
+	desc, err := oras.TagBytes(tt.ctx, repo, ocispec.MediaTypeImageManifest, manifest, tag)
+	tt.Expect(err).NotTo(HaveOccurred())
+	return desc
+}
+
+// pushImage pushes an image and, when sbom isn't empty, attaches it with cosign.
+func (tt *reportTest) pushImage(uri, sbom string) string {
+	artifact := registry.NewArtifactFromURI(uri)
+	repo, err := tt.client.GetStorage(tt.ctx, artifact)
+	tt.Expect(err).NotTo(HaveOccurred())
+
+	desc := tt.pushManifest(repo, tt.pushBlob(repo, "application/vnd.oci.image.layer.v1.tar", []byte(uri)), artifact.Tag)
+	if sbom != "" {
+		tt.pushManifest(repo, tt.pushBlob(repo, "application/vnd.cyclonedx+json", []byte(sbom)), strings.Replace(desc.Digest.String(), ":", "-", 1)+".sbom")
+	}
+	return desc.Digest.String()
+}
+
+func TestReporterReport(t *testing.T) {
+	tt := newReportTest(t)
+	kubeVipDigest := tt.pushImage("public.ecr.aws/eks-anywhere/kube-vip:v0.5.5", testSBOM)
+	harborDigest := tt.pushImage("public.ecr.aws/eks-anywhere/harbor:v2.7.1", "")
+
+	images := NewImages()
+	images.Add("public.ecr.aws/eks-anywhere/kube-vip:v0.5.5", BundleSource)
+	images.Add("public.ecr.aws/eks-anywhere/harbor@"+harborDigest, PackageSource("my-harbor"))
+	images.Add("public.ecr.aws/eks-anywhere/kube-vip:v0.5.5", PodSource("kube-system", "kube-vip"))
+	images.Add("public.ecr.aws/eks-anywhere/missing:v1.0.0", BundleSource)
+
+	reports, err := NewReporter(newTestTrivyDB(t), tt.sources).Report(tt.ctx, images)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(reports).To(HaveLen(3))
+
+	tt.Expect(reports[0]).To(Equal(ImageReport{
+		Image:           "public.ecr.aws/eks-anywhere/harbor@" + harborDigest,
+		Digest:          harborDigest,
+		Sources:         []string{"package my-harbor"},
+		Vulnerabilities: []Vulnerability{},
+	}))
+	tt.Expect(reports[1].Image).To(Equal("public.ecr.aws/eks-anywhere/kube-vip:v0.5.5"))
+	tt.Expect(reports[1].Digest).To(Equal(kubeVipDigest))
+	tt.Expect(reports[1].Sources).To(Equal([]string{"bundle", "pod kube-system/kube-vip"}))
+	tt.Expect(reports[1].SBOM).To(BeTrue())
+	tt.Expect(reports[1].Packages).To(Equal(2))
+	tt.Expect(reports[1].Vulnerabilities).To(HaveLen(2))
+	tt.Expect(reports[1].Vulnerabilities[0].ID).To(Equal("CVE-2023-5678"))
+	tt.Expect(reports[2].Image).To(Equal("public.ecr.aws/eks-anywhere/missing:v1.0.0"))
+	tt.Expect(reports[2].Error).To(ContainSubstring("resolving public.ecr.aws/eks-anywhere/missing:v1.0.0"))
+}
+
+type failingDB struct{}
+
+func (failingDB) Vulnerabilities(Package) ([]Vulnerability, error) {
+	return nil, errors.New("corrupted db")
+}
+
+func TestReporterReportDBError(t *testing.T) {
+	tt := newReportTest(t)
+	tt.pushImage("public.ecr.aws/eks-anywhere/kube-vip:v0.5.5", testSBOM)
+	images := NewImages()
+	images.Add("public.ecr.aws/eks-anywhere/kube-vip:v0.5.5", BundleSource)
+
+	_, err := NewReporter(failingDB{}, tt.sources).Report(tt.ctx, images)
+	tt.Expect(err).To(MatchError(ContainSubstring("corrupted db")))
+}
+
+func TestImagesAddPods(t *testing.T) {
+	g := NewWithT(t)
+	images := NewImages()
+	images.AddPods([]corev1.Pod{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "cilium-abcde"},
+			Spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{{Name: "init", Image: "public.ecr.aws/isovalent/cilium:v1.12.15"}},
+				Containers: []corev1.Container{
+					{Name: "agent", Image: "public.ecr.aws/isovalent/cilium:v1.12.15"},
+					{Name: "sidecar", Image: "public.ecr.aws/eks-anywhere/sidecar@sha256:b"},
+				},
+			},
+			Status: corev1.PodStatus{
+				InitContainerStatuses: []corev1.ContainerStatus{{Name: "init", ImageID: "public.ecr.aws/isovalent/cilium@sha256:a"}},
+				ContainerStatuses: []corev1.ContainerStatus{
+					{Name: "agent", ImageID: "docker-pullable://public.ecr.aws/isovalent/cilium@sha256:a"},
+					{Name: "sidecar", ImageID: "public.ecr.aws/eks-anywhere/sidecar@sha256:b"},
+				},
+			},
+		},
+	})
+
+	g.Expect(images.order).To(Equal([]string{
+		"public.ecr.aws/isovalent/cilium:v1.12.15@sha256:a",
+		"public.ecr.aws/eks-anywhere/sidecar@sha256:b",
+	}))
+	g.Expect(images.sources["public.ecr.aws/isovalent/cilium:v1.12.15@sha256:a"]).To(Equal([]string{"pod kube-system/cilium-abcde"}))
+}
@@ -0,0 +1,219 @@
+package imagereport
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Package is a package installed in an image, as listed in its SBOM.
+type Package struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	// PURL is the package URL identifying the package and its ecosystem,
+	// e.g. pkg:deb/debian/openssl@1.1.1n-0+deb11u4?distro=debian-11.
+	PURL string `json:"purl"`
+}
+
+// sbom has the fields with the packages of the SPDX, CycloneDX and Syft JSON formats.
+type sbom struct {
+	SPDXVersion string `json:"spdxVersion"`
+	Packages    []struct {
+		Name         string `json:"name"`
+		VersionInfo  string `json:"versionInfo"`
+		ExternalRefs []struct {
+			ReferenceType    string `json:"referenceType"`
+			ReferenceLocator string `json:"referenceLocator"`
+		} `json:"externalRefs"`
+	} `json:"packages"`
+
+	BOMFormat  string `json:"bomFormat"`
+	Components []struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+		PURL    string `json:"purl"`
+	} `json:"components"`
+
+	Artifacts []struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+		PURL    string `json:"purl"`
+	} `json:"artifacts"`
+}
+
+// ParseSBOM returns the packages with a package URL in an SBOM, in SPDX, CycloneDX or Syft JSON format.
+func ParseSBOM(content []byte) ([]Package, error) {
+	s := &sbom{}
+	if err := json.Unmarshal(content, s); err != nil {
+		return nil, fmt.Errorf("parsing SBOM: %v", err)
+	}
+
+	var packages []Package
+	switch {
+	case s.SPDXVersion != "":
+		for _, p := range s.Packages {
+			for _, ref := range p.ExternalRefs {
+				if ref.ReferenceType == "purl" {
+					packages = append(packages, Package{Name: p.Name, Version: p.VersionInfo, PURL: ref.ReferenceLocator})
+					break
+				}
+			}
+		}
+	case s.BOMFormat == "CycloneDX":
+		for _, c := range s.Components {
+			if c.PURL != "" {
+				packages = append(packages, Package{Name: c.Name, Version: c.Version, PURL: c.PURL})
+			}
+		}
+	case s.Artifacts != nil:
+		for _, a := range s.Artifacts {
+			if a.PURL != "" {
+				packages = append(packages, Package{Name: a.Name, Version: a.Version, PURL: a.PURL})
+			}
+		}
+	default:
+		return nil, errors.New("parsing SBOM: unsupported format, only SPDX, CycloneDX and Syft JSON are supported")
+	}
+
+	return packages, nil
+}
+
+// purl is a parsed package URL, pkg:type/namespace/name@version?qualifiers.
+type purl struct {
+	kind, namespace, name, version string
+	qualifiers                     url.Values
+}
+
+func parsePURL(s string) (*purl, error) {
+	if !strings.HasPrefix(s, "pkg:") {
+		return nil, fmt.Errorf("invalid package URL %s", s)
+	}
+	s = strings.TrimPrefix(s, "pkg:")
+	if i := strings.Index(s, "#"); i >= 0 {
+		s = s[:i]
+	}
+
+	p := &purl{}
+	if i := strings.Index(s, "?"); i >= 0 {
+		qualifiers, err := url.ParseQuery(s[i+1:])
+		if err != nil {
+			return nil, fmt.Errorf("invalid package URL qualifiers %s: %v", s, err)
+		}
+		p.qualifiers = qualifiers
+		s = s[:i]
+	}
+	if i := strings.LastIndex(s, "@"); i >= 0 {
+		p.version, _ = url.PathUnescape(s[i+1:])
+		s = s[:i]
+	}
+
+	parts := strings.Split(s, "/")
+	if len(parts) < 2 {
+		return nil, fmt.Errorf("invalid package URL %s", s)
+	}
+	p.kind = strings.ToLower(parts[0])
+	p.name, _ = url.PathUnescape(parts[len(parts)-1])
+	p.namespace, _ = url.PathUnescape(strings.Join(parts[1:len(parts)-1], "/"))
+	return p, nil
+}
+
+// osPackageKinds are the package URL types of the packages of the OS of an image.
+var osPackageKinds = map[string]bool{"deb": true, "apk": true, "rpm": true}
+
+// languageSources are the prefixes of the Trivy DB sources of the language package URL types.
+var languageSources = map[string]string{
+	"golang":   "go::",
+	"pypi":     "pip::",
+	"npm":      "npm::",
+	"maven":    "maven::",
+	"cargo":    "cargo::",
+	"gem":      "rubygems::",
+	"nuget":    "nuget::",
+	"composer": "composer::",
+}
+
+func (p Package) languagePackage() bool {
+	parsed, err := parsePURL(p.PURL)
+	return err == nil && !osPackageKinds[parsed.kind]
+}
+
+// trivySource returns the Trivy DB source with the advisories of the package and the name of the
+// package in it, or an empty source if the ecosystem of the package isn't supported.
+func (p Package) trivySource() (source, name string) {
+	parsed, err := parsePURL(p.PURL)
+	if err != nil {
+		return "", ""
+	}
+
+	if prefix, ok := languageSources[parsed.kind]; ok {
+		switch {
+		case parsed.namespace == "":
+			return prefix, parsed.name
+		case parsed.kind == "maven":
+			return prefix, parsed.namespace + ":" + parsed.name
+		default:
+			return prefix, parsed.namespace + "/" + parsed.name
+		}
+	}
+
+	// OS advisories are by source package, which the SBOM records as upstream when
+	// it's not the same as the binary package.
+	name = parsed.name
+	if upstream := strings.Fields(strings.SplitN(parsed.qualifiers.Get("upstream"), "@", 2)[0]); len(upstream) > 0 {
+		name = upstream[0]
+	}
+
+	distro := parsed.qualifiers.Get("distro")
+	distroVersion := distro
+	if i := strings.Index(distro, "-"); i >= 0 {
+		distroVersion = distro[i+1:]
+	}
+	versionParts := strings.Split(distroVersion, ".")
+
+	switch {
+	case parsed.kind == "deb" && parsed.namespace == "debian":
+		return "debian " + versionParts[0], name
+	case parsed.kind == "deb" && parsed.namespace == "ubuntu" && len(versionParts) > 1:
+		return "ubuntu " + versionParts[0] + "." + versionParts[1], name
+	case parsed.kind == "apk" && parsed.namespace == "alpine" && len(versionParts) > 1:
+		return "alpine " + versionParts[0] + "." + versionParts[1], name
+	case parsed.kind == "apk" && (parsed.namespace == "wolfi" || parsed.namespace == "chainguard"):
+		return parsed.namespace, name
+	case parsed.kind == "rpm" && parsed.namespace == "amzn":
+		return "amazon linux " + versionParts[0], parsed.name
+	case parsed.kind == "rpm" && parsed.namespace == "rocky":
+		return "rocky " + versionParts[0], parsed.name
+	}
+	return "", ""
+}
+
+// installedVersion returns the version of the package to compare with the advisories, with the
+// epoch of RPM packages.
+func (p Package) installedVersion() string {
+	parsed, err := parsePURL(p.PURL)
+	if err != nil || parsed.version == "" {
+		return p.Version
+	}
+	if epoch := parsed.qualifiers.Get("epoch"); epoch != "" && epoch != "0" && parsed.kind == "rpm" {
+		return epoch + ":" + parsed.version
+	}
+	return parsed.version
+}
+
+// versionComparator returns the function comparing the versions of the ecosystem of the package.
+func (p Package) versionComparator() func(a, b string) int {
+	parsed, err := parsePURL(p.PURL)
+	if err != nil {
+		return compareVersions
+	}
+	switch parsed.kind {
+	case "rpm":
+		return compareRPMVersions
+	case "apk":
+		return compareAPKVersions
+	default:
+		return compareVersions
+	}
+}
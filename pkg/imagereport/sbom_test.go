@@ -0,0 +1,83 @@
+package imagereport
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestParseSBOM(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{
+			name: "spdx",
+			content: `{"spdxVersion":"SPDX-2.3","packages":[
+				{"name":"openssl","versionInfo":"1.1.1n-0+deb11u4","externalRefs":[{"referenceType":"cpe23Type","referenceLocator":"cpe:2.3:a:openssl"},{"referenceType":"purl","referenceLocator":"pkg:deb/debian/openssl@1.1.1n-0+deb11u4?distro=debian-11"}]},
+				{"name":"no-purl","versionInfo":"1.0"}]}`,
+		},
+		{
+			name:    "cyclonedx",
+			content: `{"bomFormat":"CycloneDX","components":[{"name":"openssl","version":"1.1.1n-0+deb11u4","purl":"pkg:deb/debian/openssl@1.1.1n-0+deb11u4?distro=debian-11"},{"name":"no-purl"}]}`,
+		},
+		{
+			name:    "syft",
+			content: `{"artifacts":[{"name":"openssl","version":"1.1.1n-0+deb11u4","purl":"pkg:deb/debian/openssl@1.1.1n-0+deb11u4?distro=debian-11"},{"name":"no-purl"}]}`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			packages, err := ParseSBOM([]byte(tc.content))
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(packages).To(Equal([]Package{
+				{Name: "openssl", Version: "1.1.1n-0+deb11u4", PURL: "pkg:deb/debian/openssl@1.1.1n-0+deb11u4?distro=debian-11"},
+			}))
+		})
+	}
+}
+
+func TestParseSBOMUnsupported(t *testing.T) {
+	g := NewWithT(t)
+	_, err := ParseSBOM([]byte(`{"spdx":"2.3"}`))
+	g.Expect(err).To(MatchError(ContainSubstring("unsupported format")))
+	_, err = ParseSBOM([]byte(`not json`))
+	g.Expect(err).To(MatchError(ContainSubstring("parsing SBOM")))
+}
+
+func TestPackageTrivySource(t *testing.T) {
+	tests := []struct {
+		purl       string
+		wantSource string
+		wantName   string
+	}{
+		{purl: "pkg:deb/debian/libssl1.1@1.1.1n-0+deb11u4?arch=amd64&upstream=openssl&distro=debian-11", wantSource: "debian 11", wantName: "openssl"},
+		{purl: "pkg:deb/debian/libc6@2.36-9?upstream=glibc%402.36-9&distro=debian-12.1", wantSource: "debian 12", wantName: "glibc"},
+		{purl: "pkg:deb/ubuntu/bash@5.1-6ubuntu1?distro=ubuntu-22.04", wantSource: "ubuntu 22.04", wantName: "bash"},
+		{purl: "pkg:apk/alpine/busybox@1.36.1-r0?distro=alpine-3.18.4", wantSource: "alpine 3.18", wantName: "busybox"},
+		{purl: "pkg:apk/wolfi/busybox@1.36.1-r0", wantSource: "wolfi", wantName: "busybox"},
+		{purl: "pkg:rpm/amzn/openssl-libs@3.0.8-1.amzn2023?epoch=1&distro=amzn-2023", wantSource: "amazon linux 2023", wantName: "openssl-libs"},
+		{purl: "pkg:golang/golang.org/x/net@v0.7.0", wantSource: "go::", wantName: "golang.org/x/net"},
+		{purl: "pkg:golang/stdlib@1.20.1", wantSource: "go::", wantName: "stdlib"},
+		{purl: "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1", wantSource: "maven::", wantName: "org.apache.logging.log4j:log4j-core"},
+		{purl: "pkg:pypi/requests@2.25.0", wantSource: "pip::", wantName: "requests"},
+		{purl: "pkg:deb/unknown/bash@5.1", wantSource: "", wantName: ""},
+		{purl: "not a purl", wantSource: "", wantName: ""},
+	}
+	for _, tc := range tests {
+		t.Run(tc.purl, func(t *testing.T) {
+			g := NewWithT(t)
+			source, name := Package{PURL: tc.purl}.trivySource()
+			g.Expect(source).To(Equal(tc.wantSource))
+			g.Expect(name).To(Equal(tc.wantName))
+		})
+	}
+}
+
+func TestPackageInstalledVersion(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(Package{Version: "3.0.8", PURL: "pkg:rpm/amzn/openssl-libs@3.0.8-1.amzn2023?epoch=1&distro=amzn-2023"}.installedVersion()).To(Equal("1:3.0.8-1.amzn2023"))
+	g.Expect(Package{Version: "v0.7.0", PURL: "pkg:golang/golang.org/x/net@v0.7.0"}.installedVersion()).To(Equal("v0.7.0"))
+	g.Expect(Package{Version: "1.0", PURL: "pkg:pypi/requests"}.installedVersion()).To(Equal("1.0"))
+}
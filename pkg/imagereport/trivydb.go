@@ -0,0 +1,213 @@
+package imagereport
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"go.etcd.io/bbolt"
+)
+
+const trivyVulnerabilityBucket = "vulnerability"
+
+// severities are the names of the severities in the Trivy DB, indexed by their value.
+var severities = []string{"UNKNOWN", "LOW", "MEDIUM", "HIGH", "CRITICAL"}
+
+// TrivyDB is an offline Trivy vulnerability database, the trivy.db file Trivy downloads to its cache.
+// Its top level buckets are the advisory sources, e.g. debian 11 or go::GitHub Security Advisory Go,
+// with a bucket per package whose keys are the IDs of the vulnerabilities of the package.
+type TrivyDB struct {
+	db *bbolt.DB
+}
+
+// OpenTrivyDB opens a Trivy DB file in read only mode.
+func OpenTrivyDB(path string) (*TrivyDB, error) {
+	db, err := bbolt.Open(filepath.Clean(path), 0o400, &bbolt.Options{ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("opening trivy db %s: %v", path, err)
+	}
+	return &TrivyDB{db: db}, nil
+}
+
+// Close closes the DB file.
+func (t *TrivyDB) Close() error {
+	return t.db.Close()
+}
+
+// advisory is how a vulnerability affects a package in a source. OS sources set the version fixing it,
+// empty when it's not fixed yet, and language sources the ranges of vulnerable and patched versions.
+type advisory struct {
+	FixedVersion       string   `json:"FixedVersion,omitempty"`
+	VulnerableVersions []string `json:"VulnerableVersions,omitempty"`
+	PatchedVersions    []string `json:"PatchedVersions,omitempty"`
+}
+
+// vulnerabilityDetail is the description of a vulnerability shared by all the sources.
+type vulnerabilityDetail struct {
+	Title          string         `json:"Title,omitempty"`
+	Severity       string         `json:"Severity,omitempty"`
+	VendorSeverity map[string]int `json:"VendorSeverity,omitempty"`
+}
+
+func (d *vulnerabilityDetail) severity() string {
+	if d.Severity != "" {
+		return d.Severity
+	}
+	highest := 0
+	for _, s := range d.VendorSeverity {
+		if s > highest && s < len(severities) {
+			highest = s
+		}
+	}
+	return severities[highest]
+}
+
+// sources returns the advisory sources with the given name or, when it ends with ::, with that prefix.
+func (t *TrivyDB) sources(name string) ([]string, error) {
+	if !strings.HasSuffix(name, "::") {
+		return []string{name}, nil
+	}
+
+	var sources []string
+	err := t.db.View(func(tx *bbolt.Tx) error {
+		return tx.ForEach(func(source []byte, _ *bbolt.Bucket) error {
+			if strings.HasPrefix(string(source), name) {
+				sources = append(sources, string(source))
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("reading trivy db sources: %v", err)
+	}
+	return sources, nil
+}
+
+// advisories returns the advisories of a package in a source by vulnerability ID.
+func (t *TrivyDB) advisories(source, pkg string) (map[string]advisory, error) {
+	advisories := map[string]advisory{}
+	err := t.db.View(func(tx *bbolt.Tx) error {
+		sourceBucket := tx.Bucket([]byte(source))
+		if sourceBucket == nil {
+			return nil
+		}
+		pkgBucket := sourceBucket.Bucket([]byte(pkg))
+		if pkgBucket == nil {
+			return nil
+		}
+
+		return pkgBucket.ForEach(func(id, value []byte) error {
+			a := advisory{}
+			if err := json.Unmarshal(value, &a); err != nil {
+				return fmt.Errorf("parsing advisory %s of %s in %s: %v", id, pkg, source, err)
+			}
+			advisories[string(id)] = a
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return advisories, nil
+}
+
+func (t *TrivyDB) vulnerability(id string) (*vulnerabilityDetail, error) {
+	detail := &vulnerabilityDetail{}
+	err := t.db.View(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket([]byte(trivyVulnerabilityBucket))
+		if bucket == nil {
+			return nil
+		}
+		value := bucket.Get([]byte(id))
+		if value == nil {
+			return nil
+		}
+		if err := json.Unmarshal(value, detail); err != nil {
+			return fmt.Errorf("parsing vulnerability %s: %v", id, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return detail, nil
+}
+
+// Vulnerabilities returns the vulnerabilities affecting the installed version of a package, sorted by ID.
+func (t *TrivyDB) Vulnerabilities(p Package) ([]Vulnerability, error) {
+	source, name := p.trivySource()
+	if source == "" {
+		return nil, nil
+	}
+	sources, err := t.sources(source)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[string]bool{}
+	var vulnerabilities []Vulnerability
+	for _, s := range sources {
+		advisories, err := t.advisories(s, name)
+		if err != nil {
+			return nil, err
+		}
+		for id, a := range advisories {
+			if seen[id] || !a.affects(p) {
+				continue
+			}
+			seen[id] = true
+			detail, err := t.vulnerability(id)
+			if err != nil {
+				return nil, err
+			}
+			vulnerabilities = append(vulnerabilities, Vulnerability{
+				ID:               id,
+				Severity:         detail.severity(),
+				Package:          p.Name,
+				InstalledVersion: p.Version,
+				FixedVersion:     a.fixedVersion(),
+				Title:            detail.Title,
+			})
+		}
+	}
+
+	sort.Slice(vulnerabilities, func(i, j int) bool {
+		return vulnerabilities[i].ID < vulnerabilities[j].ID
+	})
+	return vulnerabilities, nil
+}
+
+// affects returns true if the installed version of the package is vulnerable.
+func (a advisory) affects(p Package) bool {
+	if !p.languagePackage() {
+		compare := p.versionComparator()
+		return a.FixedVersion == "" || compare(p.installedVersion(), a.FixedVersion) < 0
+	}
+
+	version := semverToDebian(p.installedVersion())
+	vulnerable := len(a.VulnerableVersions) == 0
+	for _, c := range a.VulnerableVersions {
+		if matchesConstraint(version, c) {
+			vulnerable = true
+			break
+		}
+	}
+	if !vulnerable {
+		return false
+	}
+	for _, c := range a.PatchedVersions {
+		if matchesConstraint(version, c) {
+			return false
+		}
+	}
+	return true
+}
+
+func (a advisory) fixedVersion() string {
+	if a.FixedVersion != "" {
+		return a.FixedVersion
+	}
+	return strings.Join(a.PatchedVersions, ", ")
+}
@@ -0,0 +1,199 @@
+package imagereport
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"go.etcd.io/bbolt"
+)
+
+// testBucket is the content of a bucket, with values that are either a []byte or a nested testBucket.
+type testBucket map[string]interface{}
+
+func writeTestBucket(b *bbolt.Bucket, content testBucket) error {
+	for key, value := range content {
+		if nested, ok := value.(testBucket); ok {
+			child, err := b.CreateBucket([]byte(key))
+			if err != nil {
+				return err
+			}
+			if err = writeTestBucket(child, nested); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := b.Put([]byte(key), value.([]byte)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeTestTrivyDB(t *testing.T, sources testBucket) string {
+	path := filepath.Join(t.TempDir(), "trivy.db")
+	db, err := bbolt.Open(path, 0o600, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	err = db.Update(func(tx *bbolt.Tx) error {
+		for source, content := range sources {
+			b, err := tx.CreateBucket([]byte(source))
+			if err != nil {
+				return err
+			}
+			if err = writeTestBucket(b, content.(testBucket)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func newTestTrivyDB(t *testing.T) *TrivyDB {
+	path := writeTestTrivyDB(t, testBucket{
+		"debian 11": testBucket{
+			"openssl": testBucket{
+				"CVE-2023-0286": []byte(`{"FixedVersion":"1.1.1n-0+deb11u4"}`),
+				"CVE-2023-5678": []byte(`{"FixedVersion":"1.1.1w-0+deb11u1"}`),
+				"CVE-2024-0727": []byte(`{}`),
+			},
+		},
+		"alpine 3.18": testBucket{
+			"openssl": testBucket{
+				"CVE-2023-2650": []byte(`{"FixedVersion":"3.1.0-r0"}`),
+			},
+		},
+		"go::GitHub Security Advisory Go": testBucket{
+			"golang.org/x/net": testBucket{
+				"CVE-2023-44487": []byte(`{"PatchedVersions":["0.17.0"],"VulnerableVersions":["< 0.17.0"]}`),
+				"CVE-2022-41723": []byte(`{"PatchedVersions":["0.7.0"],"VulnerableVersions":["< 0.7.0"]}`),
+			},
+		},
+		"go::Official Go Vulnerability DB": testBucket{
+			"golang.org/x/net": testBucket{
+				"CVE-2023-44487": []byte(`{"PatchedVersions":["0.17.0"],"VulnerableVersions":[">= 0, < 0.17.0"]}`),
+			},
+		},
+		"vulnerability": testBucket{
+			"CVE-2023-5678":  []byte(`{"Title":"openssl: Generating excessively long X9.42 DH keys","Severity":"MEDIUM"}`),
+			"CVE-2024-0727":  []byte(`{"VendorSeverity":{"debian":1,"nvd":2}}`),
+			"CVE-2023-44487": []byte(`{"Title":"HTTP/2 Rapid Reset","Severity":"HIGH"}`),
+		},
+	})
+
+	db, err := OpenTrivyDB(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+func TestTrivyDBVulnerabilitiesOSPackage(t *testing.T) {
+	g := NewWithT(t)
+	db := newTestTrivyDB(t)
+
+	vulnerabilities, err := db.Vulnerabilities(Package{
+		Name:    "libssl1.1",
+		Version: "1.1.1n-0+deb11u4",
+		PURL:    "pkg:deb/debian/libssl1.1@1.1.1n-0+deb11u4?upstream=openssl&distro=debian-11",
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(vulnerabilities).To(Equal([]Vulnerability{
+		{
+			ID:               "CVE-2023-5678",
+			Severity:         "MEDIUM",
+			Package:          "libssl1.1",
+			InstalledVersion: "1.1.1n-0+deb11u4",
+			FixedVersion:     "1.1.1w-0+deb11u1",
+			Title:            "openssl: Generating excessively long X9.42 DH keys",
+		},
+		{
+			ID:               "CVE-2024-0727",
+			Severity:         "MEDIUM",
+			Package:          "libssl1.1",
+			InstalledVersion: "1.1.1n-0+deb11u4",
+		},
+	}))
+}
+
+func TestTrivyDBVulnerabilitiesAPKPreRelease(t *testing.T) {
+	g := NewWithT(t)
+	db := newTestTrivyDB(t)
+
+	vulnerabilities, err := db.Vulnerabilities(Package{
+		Name:    "libcrypto3",
+		Version: "3.1.0_rc1-r0",
+		PURL:    "pkg:apk/alpine/libcrypto3@3.1.0_rc1-r0?upstream=openssl&distro=3.18.2",
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(vulnerabilities).To(Equal([]Vulnerability{
+		{
+			ID:               "CVE-2023-2650",
+			Severity:         "UNKNOWN",
+			Package:          "libcrypto3",
+			InstalledVersion: "3.1.0_rc1-r0",
+			FixedVersion:     "3.1.0-r0",
+		},
+	}))
+}
+
+func TestTrivyDBVulnerabilitiesLanguagePackage(t *testing.T) {
+	g := NewWithT(t)
+	db := newTestTrivyDB(t)
+
+	vulnerabilities, err := db.Vulnerabilities(Package{
+		Name:    "golang.org/x/net",
+		Version: "v0.7.0",
+		PURL:    "pkg:golang/golang.org/x/net@v0.7.0",
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(vulnerabilities).To(Equal([]Vulnerability{
+		{
+			ID:               "CVE-2023-44487",
+			Severity:         "HIGH",
+			Package:          "golang.org/x/net",
+			InstalledVersion: "v0.7.0",
+			FixedVersion:     "0.17.0",
+			Title:            "HTTP/2 Rapid Reset",
+		},
+	}))
+}
+
+func TestTrivyDBVulnerabilitiesUnknownPackage(t *testing.T) {
+	g := NewWithT(t)
+	db := newTestTrivyDB(t)
+
+	for _, p := range []Package{
+		{Name: "bash", Version: "5.1", PURL: "pkg:deb/debian/bash@5.1?distro=debian-11"},
+		{Name: "bash", Version: "5.1", PURL: "pkg:deb/debian/bash@5.1?distro=debian-10"},
+		{Name: "bash", Version: "5.1", PURL: "pkg:generic/bash@5.1"},
+	} {
+		vulnerabilities, err := db.Vulnerabilities(p)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(vulnerabilities).To(BeEmpty())
+	}
+}
+
+func TestOpenTrivyDBInvalid(t *testing.T) {
+	g := NewWithT(t)
+	_, err := OpenTrivyDB("testdata/missing.db")
+	g.Expect(err).To(MatchError(ContainSubstring("opening trivy db")))
+}
+
+func TestOpenTrivyDBNotBolt(t *testing.T) {
+	g := NewWithT(t)
+	path := filepath.Join(t.TempDir(), "trivy.db")
+	g.Expect(os.WriteFile(path, make([]byte, 4096), 0o600)).To(Succeed())
+
+	_, err := OpenTrivyDB(path)
+	g.Expect(err).To(MatchError(ContainSubstring("opening trivy db")))
+}
@@ -0,0 +1,449 @@
+package imagereport
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// compareVersions compares two package versions with the Debian ordering: an optional epoch,
+// then the upstream version and the revision, comparing non digits lexically, with ~ sorting
+// before anything, and digits numerically. It also orders the versions of language packages
+// once their pre-releases are converted with semverToDebian.
+func compareVersions(a, b string) int {
+	epochA, restA := splitEpoch(a)
+	epochB, restB := splitEpoch(b)
+	if c := compareFragment(epochA, epochB); c != 0 {
+		return c
+	}
+
+	upstreamA, revisionA := splitRevision(restA)
+	upstreamB, revisionB := splitRevision(restB)
+	if c := compareFragment(upstreamA, upstreamB); c != 0 {
+		return c
+	}
+	return compareFragment(revisionA, revisionB)
+}
+
+func splitEpoch(v string) (string, string) {
+	if i := strings.Index(v, ":"); i >= 0 {
+		return v[:i], v[i+1:]
+	}
+	return "0", v
+}
+
+func splitRevision(v string) (string, string) {
+	if i := strings.LastIndex(v, "-"); i >= 0 {
+		return v[:i], v[i+1:]
+	}
+	return v, ""
+}
+
+func compareFragment(a, b string) int {
+	for a != "" || b != "" {
+		var nonDigitA, nonDigitB string
+		nonDigitA, a = splitNonDigits(a)
+		nonDigitB, b = splitNonDigits(b)
+		if c := compareNonDigits(nonDigitA, nonDigitB); c != 0 {
+			return c
+		}
+
+		var digitA, digitB string
+		digitA, a = splitDigits(a)
+		digitB, b = splitDigits(b)
+		if c := compareNumbers(digitA, digitB); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+func splitNonDigits(s string) (string, string) {
+	i := strings.IndexAny(s, "0123456789")
+	if i < 0 {
+		return s, ""
+	}
+	return s[:i], s[i:]
+}
+
+func splitDigits(s string) (string, string) {
+	i := strings.IndexFunc(s, func(r rune) bool { return r < '0' || r > '9' })
+	if i < 0 {
+		return s, ""
+	}
+	return s[:i], s[i:]
+}
+
+// order is the weight of a character in the non digits part of a version: ~ before the end
+// of the part, then letters and then everything else.
+func order(s string, i int) int {
+	if i >= len(s) {
+		return 0
+	}
+	c := s[i]
+	switch {
+	case c == '~':
+		return -1
+	case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z':
+		return int(c)
+	default:
+		return int(c) + 256
+	}
+}
+
+func compareNonDigits(a, b string) int {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		if oa, ob := order(a, i), order(b, i); oa != ob {
+			if oa < ob {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+func compareNumbers(a, b string) int {
+	a = strings.TrimLeft(a, "0")
+	b = strings.TrimLeft(b, "0")
+	if len(a) != len(b) {
+		if len(a) < len(b) {
+			return -1
+		}
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+// compareRPMVersions compares two RPM versions like rpm does: the epoch numerically, then the
+// version and, when both have one, the release with rpmvercmp.
+func compareRPMVersions(a, b string) int {
+	epochA, restA := splitEpoch(a)
+	epochB, restB := splitEpoch(b)
+	if c := compareNumbers(epochA, epochB); c != 0 {
+		return c
+	}
+
+	versionA, releaseA := splitRevision(restA)
+	versionB, releaseB := splitRevision(restB)
+	if c := rpmvercmp(versionA, versionB); c != 0 || releaseA == "" || releaseB == "" {
+		return c
+	}
+	return rpmvercmp(releaseA, releaseB)
+}
+
+// rpmSeparator returns true for the characters rpmvercmp skips between the segments of a version.
+func rpmSeparator(r rune) bool {
+	alphanumeric := r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z'
+	return !alphanumeric && r != '~' && r != '^'
+}
+
+// rpmvercmp compares the segments of two RPM versions or releases, ignoring the separators
+// between them. Digits are compared numerically and letters lexically, a numeric segment is
+// newer than an alphabetic one, ~ sorts before anything and ^ after the end of the version
+// but before anything else.
+func rpmvercmp(a, b string) int {
+	for a != "" || b != "" {
+		a = strings.TrimLeftFunc(a, rpmSeparator)
+		b = strings.TrimLeftFunc(b, rpmSeparator)
+
+		if strings.HasPrefix(a, "~") || strings.HasPrefix(b, "~") {
+			if !strings.HasPrefix(a, "~") {
+				return 1
+			}
+			if !strings.HasPrefix(b, "~") {
+				return -1
+			}
+			a, b = a[1:], b[1:]
+			continue
+		}
+
+		if strings.HasPrefix(a, "^") || strings.HasPrefix(b, "^") {
+			switch {
+			case a == "":
+				return -1
+			case b == "":
+				return 1
+			case !strings.HasPrefix(a, "^"):
+				return 1
+			case !strings.HasPrefix(b, "^"):
+				return -1
+			}
+			a, b = a[1:], b[1:]
+			continue
+		}
+
+		if a == "" || b == "" {
+			break
+		}
+
+		var segmentA, segmentB string
+		numeric := a[0] >= '0' && a[0] <= '9'
+		if numeric {
+			segmentA, a = splitDigits(a)
+			segmentB, b = splitDigits(b)
+		} else {
+			segmentA, a = splitLetters(a)
+			segmentB, b = splitLetters(b)
+		}
+
+		if segmentB == "" {
+			if numeric {
+				return 1
+			}
+			return -1
+		}
+
+		var c int
+		if numeric {
+			c = compareNumbers(segmentA, segmentB)
+		} else {
+			c = strings.Compare(segmentA, segmentB)
+		}
+		if c != 0 {
+			return c
+		}
+	}
+
+	switch {
+	case a == "" && b == "":
+		return 0
+	case a == "":
+		return -1
+	default:
+		return 1
+	}
+}
+
+func splitLetters(s string) (string, string) {
+	i := strings.IndexFunc(s, func(r rune) bool { return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z') })
+	if i < 0 {
+		return s, ""
+	}
+	return s[:i], s[i:]
+}
+
+// apkTokenType is the type of a part of an APK version. When two versions differ in the
+// type of a part, the one with the lowest type is newer, e.g. 1.0.1 > 1.0a > 1.0_p1 > 1.0-r1 > 1.0,
+// except for the pre-release suffixes which are older than anything, e.g. 1.0_rc1 < 1.0.
+type apkTokenType int
+
+const (
+	apkDigit apkTokenType = iota
+	apkLetter
+	apkSuffix
+	apkSuffixNumber
+	apkRevision
+	apkEnd
+)
+
+type apkToken struct {
+	kind  apkTokenType
+	value string
+	// suffix is the value of a suffix in apkSuffixes.
+	suffix int
+}
+
+// apkSuffixes are the suffixes of APK versions ordered by their value, negative for the
+// pre-release suffixes.
+var apkSuffixes = map[string]int{
+	"alpha": -4,
+	"beta":  -3,
+	"pre":   -2,
+	"rc":    -1,
+	"cvs":   1,
+	"svn":   2,
+	"git":   3,
+	"hg":    4,
+	"p":     5,
+}
+
+var apkVersionRegexp = regexp.MustCompile(`^([0-9]+(?:\.[0-9]+)*)([a-z]?)((?:_[a-z]+[0-9]*)*)(?:-r([0-9]+))?$`)
+
+var apkSuffixRegexp = regexp.MustCompile(`_([a-z]+)([0-9]*)`)
+
+// apkTokens splits an APK version, e.g. 1.2.3a_rc1-r2, in its parts. It returns false if v isn't
+// a valid APK version.
+func apkTokens(v string) ([]apkToken, bool) {
+	m := apkVersionRegexp.FindStringSubmatch(v)
+	if m == nil {
+		return nil, false
+	}
+
+	var tokens []apkToken
+	for _, digits := range strings.Split(m[1], ".") {
+		tokens = append(tokens, apkToken{kind: apkDigit, value: digits})
+	}
+	if m[2] != "" {
+		tokens = append(tokens, apkToken{kind: apkLetter, value: m[2]})
+	}
+	for _, suffix := range apkSuffixRegexp.FindAllStringSubmatch(m[3], -1) {
+		value, ok := apkSuffixes[suffix[1]]
+		if !ok {
+			return nil, false
+		}
+		tokens = append(tokens,
+			apkToken{kind: apkSuffix, suffix: value},
+			apkToken{kind: apkSuffixNumber, value: suffix[2]},
+		)
+	}
+	if m[4] != "" {
+		tokens = append(tokens, apkToken{kind: apkRevision, value: m[4]})
+	}
+	return append(tokens, apkToken{kind: apkEnd}), true
+}
+
+func (t apkToken) preRelease() bool {
+	return t.kind == apkSuffix && t.suffix < 0
+}
+
+// compareAPKVersions compares two APK versions like apk-tools does. Versions that aren't valid
+// APK versions are compared with the Debian ordering.
+func compareAPKVersions(a, b string) int {
+	tokensA, okA := apkTokens(a)
+	tokensB, okB := apkTokens(b)
+	if !okA || !okB {
+		return compareVersions(a, b)
+	}
+
+	for i := 0; i < len(tokensA) && i < len(tokensB); i++ {
+		ta, tb := tokensA[i], tokensB[i]
+		if ta.kind != tb.kind {
+			switch {
+			case ta.preRelease():
+				return -1
+			case tb.preRelease():
+				return 1
+			case ta.kind > tb.kind:
+				return -1
+			default:
+				return 1
+			}
+		}
+
+		var c int
+		switch ta.kind {
+		case apkLetter:
+			c = strings.Compare(ta.value, tb.value)
+		case apkSuffix:
+			c = ta.suffix - tb.suffix
+		default:
+			c = compareNumbers(ta.value, tb.value)
+		}
+		if c < 0 {
+			return -1
+		}
+		if c > 0 {
+			return 1
+		}
+	}
+	return 0
+}
+
+// semverToDebian converts a semantic version so compareVersions sorts its pre-release
+// before the release, e.g. v1.2.0-rc.1 becomes 1.2.0~rc.1. Build metadata is ignored.
+func semverToDebian(v string) string {
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	if i := strings.Index(v, "+"); i >= 0 {
+		v = v[:i]
+	}
+	return strings.Replace(v, "-", "~", 1)
+}
+
+var constraintRegexp = regexp.MustCompile(`(>=|<=|!=|==|=|>|<|\^|~>|~)?\s*([^\s,<>=!^~|]+)`)
+
+// matchesConstraint returns true if a version converted with semverToDebian matches a version
+// constraint, e.g. ">= 1.0.0, < 1.2.3 || >= 2.0.0, < 2.0.5". A version without an operator
+// only matches that version. Caret and tilde ranges follow npm: ^1.2.3 is >=1.2.3 <2.0.0,
+// ^0.2.3 is >=0.2.3 <0.3.0 and ~1.2.3 is >=1.2.3 <1.3.0. ~> is the pessimistic operator of
+// RubyGems: ~>1.2 is >=1.2 <2.0 and ~>1.2.3 is >=1.2.3 <1.3.0.
+func matchesConstraint(version, constraint string) bool {
+	for _, alternative := range strings.Split(constraint, "||") {
+		matches := constraintRegexp.FindAllStringSubmatch(alternative, -1)
+		if len(matches) == 0 {
+			continue
+		}
+		all := true
+		for _, m := range matches {
+			if !matchesOperator(version, m[1], semverToDebian(m[2])) {
+				all = false
+				break
+			}
+		}
+		if all {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesOperator(version, operator, target string) bool {
+	c := compareVersions(version, target)
+	switch operator {
+	case "^", "~", "~>":
+		return c >= 0 && compareVersions(version, rangeUpperBound(operator, target)) < 0
+	case ">=":
+		return c >= 0
+	case "<=":
+		return c <= 0
+	case ">":
+		return c > 0
+	case "<":
+		return c < 0
+	case "!=":
+		return c != 0
+	default:
+		return c == 0
+	}
+}
+
+// rangeUpperBound returns the exclusive upper bound of a caret, tilde or pessimistic range. The
+// bound ends with ~, so the pre-releases of the bound are out of the range too.
+func rangeUpperBound(operator, target string) string {
+	release := target
+	if i := strings.Index(release, "~"); i >= 0 {
+		release = release[:i]
+	}
+	parts := strings.Split(release, ".")
+	numbers := make([]int, len(parts))
+	for i, part := range parts {
+		digits, _ := splitDigits(part)
+		numbers[i], _ = strconv.Atoi(digits)
+	}
+
+	var bump int
+	switch operator {
+	case "^":
+		// The first non zero part is bumped or, when all of them are zero, the last one.
+		bump = len(numbers) - 1
+		for i, n := range numbers {
+			if n != 0 {
+				bump = i
+				break
+			}
+		}
+	case "~":
+		bump = 0
+		if len(numbers) > 1 {
+			bump = 1
+		}
+	case "~>":
+		bump = 0
+		if len(numbers) > 1 {
+			bump = len(numbers) - 2
+		}
+	}
+
+	bound := make([]string, len(numbers))
+	for i := range numbers {
+		switch {
+		case i < bump:
+			bound[i] = strconv.Itoa(numbers[i])
+		case i == bump:
+			bound[i] = strconv.Itoa(numbers[i] + 1)
+		default:
+			bound[i] = "0"
+		}
+	}
+	return strings.Join(bound, ".") + "~"
+}
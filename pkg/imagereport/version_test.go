@@ -0,0 +1,130 @@
+package imagereport
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{a: "1.1.1n-0+deb11u4", b: "1.1.1n-0+deb11u5", want: -1},
+		{a: "1.1.1n-0+deb11u5", b: "1.1.1n-0+deb11u5", want: 0},
+		{a: "1.2.10", b: "1.2.9", want: 1},
+		{a: "1.2.0~rc.1", b: "1.2.0", want: -1},
+		{a: "1:1.0", b: "2.0", want: 1},
+		{a: "2.36-9+deb12u3", b: "2.36-9+deb12u10", want: -1},
+		{a: "1.0a", b: "1.0", want: 1},
+		{a: "1.0-r1", b: "1.0-r0", want: 1},
+		{a: "007", b: "7", want: 0},
+	}
+	for _, tc := range tests {
+		t.Run(tc.a+" "+tc.b, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(compareVersions(tc.a, tc.b)).To(Equal(tc.want))
+			g.Expect(compareVersions(tc.b, tc.a)).To(Equal(-tc.want))
+		})
+	}
+}
+
+func TestCompareRPMVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{a: "1.0.2k-24.amzn2.0.7", b: "1.0.2k-24.amzn2.0.10", want: -1},
+		{a: "1.0.2k-24.amzn2.0.7", b: "1.0.2k-24.amzn2.0.7", want: 0},
+		{a: "1:1.0.2k-24.amzn2", b: "1.0.3-1.amzn2", want: 1},
+		{a: "1.0", b: "1.0a", want: -1},
+		{a: "1.0.1", b: "1.0a", want: 1},
+		{a: "1.0~rc1", b: "1.0", want: -1},
+		{a: "1.0^git1", b: "1.0", want: 1},
+		{a: "1.0^git1", b: "1.0.1", want: -1},
+		{a: "2.0_1", b: "2.0.1", want: 0},
+		{a: "1.0", b: "1.0-2.el8", want: 0},
+		{a: "010", b: "9", want: 1},
+	}
+	for _, tc := range tests {
+		t.Run(tc.a+" "+tc.b, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(compareRPMVersions(tc.a, tc.b)).To(Equal(tc.want))
+			g.Expect(compareRPMVersions(tc.b, tc.a)).To(Equal(-tc.want))
+		})
+	}
+}
+
+func TestCompareAPKVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{a: "3.0.8-r0", b: "3.0.8-r1", want: -1},
+		{a: "3.0.8-r10", b: "3.0.8-r9", want: 1},
+		{a: "1.0_rc1", b: "1.0", want: -1},
+		{a: "1.0_alpha2", b: "1.0_beta1", want: -1},
+		{a: "1.0_rc1", b: "1.0_rc2", want: -1},
+		{a: "1.0_p1", b: "1.0", want: 1},
+		{a: "1.0_p1", b: "1.0-r5", want: 1},
+		{a: "1.0_rc1-r3", b: "1.0-r0", want: -1},
+		{a: "1.0a", b: "1.0_p1", want: 1},
+		{a: "1.0.1", b: "1.0a", want: 1},
+		{a: "1.2.10", b: "1.2.9", want: 1},
+		{a: "1.2-r0", b: "1.2-r0", want: 0},
+	}
+	for _, tc := range tests {
+		t.Run(tc.a+" "+tc.b, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(compareAPKVersions(tc.a, tc.b)).To(Equal(tc.want))
+			g.Expect(compareAPKVersions(tc.b, tc.a)).To(Equal(-tc.want))
+		})
+	}
+}
+
+func TestSemverToDebian(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(semverToDebian("v1.2.0-rc.1+build.5")).To(Equal("1.2.0~rc.1"))
+	g.Expect(semverToDebian("0.7.0")).To(Equal("0.7.0"))
+}
+
+func TestMatchesConstraint(t *testing.T) {
+	tests := []struct {
+		version    string
+		constraint string
+		want       bool
+	}{
+		{version: "1.1.0", constraint: ">= 1.0.0, < 1.2.3", want: true},
+		{version: "1.2.3", constraint: ">= 1.0.0, < 1.2.3", want: false},
+		{version: "2.0.1", constraint: ">= 1.0.0, < 1.2.3 || >= 2.0.0, < 2.0.5", want: true},
+		{version: "0.9.0", constraint: ">=1.0.0", want: false},
+		{version: "1.2.0~rc.1", constraint: "<1.2.0", want: true},
+		{version: "1.2.3", constraint: "1.2.3", want: true},
+		{version: "1.2.4", constraint: "1.2.3", want: false},
+		{version: "1.2.4", constraint: "!=1.2.3", want: true},
+		{version: "1.3.0", constraint: "^1.2.3", want: true},
+		{version: "1.2.2", constraint: "^1.2.3", want: false},
+		{version: "2.0.0", constraint: "^1.2.3", want: false},
+		{version: "2.0.0~rc.1", constraint: "^1.2.3", want: false},
+		{version: "0.2.9", constraint: "^0.2.3", want: true},
+		{version: "0.3.0", constraint: "^0.2.3", want: false},
+		{version: "0.0.3", constraint: "^0.0.3", want: true},
+		{version: "0.0.4", constraint: "^0.0.3", want: false},
+		{version: "1.2.9", constraint: "~1.2.3", want: true},
+		{version: "1.3.0", constraint: "~1.2.3", want: false},
+		{version: "1.9.0", constraint: "~1", want: true},
+		{version: "2.0.0", constraint: "~1", want: false},
+		{version: "1.9.0", constraint: "~> 1.2", want: true},
+		{version: "2.0.0", constraint: "~> 1.2", want: false},
+		{version: "1.2.9", constraint: "~>1.2.3", want: true},
+		{version: "1.3.0", constraint: "~>1.2.3", want: false},
+		{version: "1.2.0", constraint: "~1.2.0-rc.1", want: true},
+		{version: "1.0.0", constraint: "", want: false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.version+" "+tc.constraint, func(t *testing.T) {
+			NewWithT(t).Expect(matchesConstraint(tc.version, tc.constraint)).To(Equal(tc.want))
+		})
+	}
+}
@@ -16,7 +16,8 @@ import (
 	"github.com/aws/eks-anywhere/pkg/templater"
 )
 
-func generateManifest(reader manifests.FileReader, clusterSpec *cluster.Spec) ([]byte, error) {
+// GenerateManifest returns the kindnetd manifest configured with the pods CIDR of the cluster.
+func GenerateManifest(reader manifests.FileReader, clusterSpec *cluster.Spec) ([]byte, error) {
 	kindnetdManifest, err := bundles.ReadManifest(reader, clusterSpec.VersionsBundle.Kindnetd.Manifest)
 	if err != nil {
 		return nil, fmt.Errorf("can't load kindnetd manifest: %v", err)
@@ -0,0 +1,26 @@
+package kindnetd_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/networking/kindnetd"
+)
+
+func TestGenerateManifestSuccess(t *testing.T) {
+	tt := newKindnetdTest(t)
+
+	manifest, err := kindnetd.GenerateManifest(tt.reader, tt.spec)
+	tt.Expect(err).NotTo(HaveOccurred())
+	test.AssertContentToFile(t, string(manifest), "testdata/expected_kindnetd_manifest.yaml")
+}
+
+func TestGenerateManifestError(t *testing.T) {
+	tt := newKindnetdTest(t)
+	tt.spec.VersionsBundle.Kindnetd.Manifest.URI = "testdata/missing_manifest.yaml"
+
+	_, err := kindnetd.GenerateManifest(tt.reader, tt.spec)
+	tt.Expect(err).To(MatchError(ContainSubstring("can't load kindnetd manifest")))
+}
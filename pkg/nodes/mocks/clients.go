@@ -0,0 +1,52 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/nodes/nodes.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	types "github.com/aws/eks-anywhere/pkg/types"
+	gomock "github.com/golang/mock/gomock"
+	v1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// MockMachineClient is a mock of MachineClient interface.
+type MockMachineClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockMachineClientMockRecorder
+}
+
+// MockMachineClientMockRecorder is the mock recorder for MockMachineClient.
+type MockMachineClientMockRecorder struct {
+	mock *MockMachineClient
+}
+
+// NewMockMachineClient creates a new mock instance.
+func NewMockMachineClient(ctrl *gomock.Controller) *MockMachineClient {
+	mock := &MockMachineClient{ctrl: ctrl}
+	mock.recorder = &MockMachineClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMachineClient) EXPECT() *MockMachineClientMockRecorder {
+	return m.recorder
+}
+
+// GetCAPIMachines mocks base method.
+func (m *MockMachineClient) GetCAPIMachines(ctx context.Context, cluster *types.Cluster, clusterName string) ([]v1beta1.Machine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCAPIMachines", ctx, cluster, clusterName)
+	ret0, _ := ret[0].([]v1beta1.Machine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCAPIMachines indicates an expected call of GetCAPIMachines.
+func (mr *MockMachineClientMockRecorder) GetCAPIMachines(ctx, cluster, clusterName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCAPIMachines", reflect.TypeOf((*MockMachineClient)(nil).GetCAPIMachines), ctx, cluster, clusterName)
+}
@@ -0,0 +1,75 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/nodes/runner.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockSSHClient is a mock of SSHClient interface.
+type MockSSHClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockSSHClientMockRecorder
+}
+
+// MockSSHClientMockRecorder is the mock recorder for MockSSHClient.
+type MockSSHClientMockRecorder struct {
+	mock *MockSSHClient
+}
+
+// NewMockSSHClient creates a new mock instance.
+func NewMockSSHClient(ctrl *gomock.Controller) *MockSSHClient {
+	mock := &MockSSHClient{ctrl: ctrl}
+	mock.recorder = &MockSSHClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSSHClient) EXPECT() *MockSSHClientMockRecorder {
+	return m.recorder
+}
+
+// RunCommand mocks base method.
+func (m *MockSSHClient) RunCommand(ctx context.Context, privateKeyPath, username, IP string, command ...string) (string, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, privateKeyPath, username, IP}
+	for _, a := range command {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RunCommand", varargs...)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RunCommand indicates an expected call of RunCommand.
+func (mr *MockSSHClientMockRecorder) RunCommand(ctx, privateKeyPath, username, IP interface{}, command ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, privateKeyPath, username, IP}, command...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunCommand", reflect.TypeOf((*MockSSHClient)(nil).RunCommand), varargs...)
+}
+
+// RunCommandWithStdin mocks base method.
+func (m *MockSSHClient) RunCommandWithStdin(ctx context.Context, in []byte, privateKeyPath, username, IP string, command ...string) (string, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in, privateKeyPath, username, IP}
+	for _, a := range command {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RunCommandWithStdin", varargs...)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RunCommandWithStdin indicates an expected call of RunCommandWithStdin.
+func (mr *MockSSHClientMockRecorder) RunCommandWithStdin(ctx, in, privateKeyPath, username, IP interface{}, command ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in, privateKeyPath, username, IP}, command...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunCommandWithStdin", reflect.TypeOf((*MockSSHClient)(nil).RunCommandWithStdin), varargs...)
+}
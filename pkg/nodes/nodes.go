@@ -0,0 +1,182 @@
+// Package nodes provides SSH access to the control plane and etcd machines of an EKS-A cluster.
+package nodes
+
+import (
+	"context"
+	"fmt"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const snowUbuntuDefaultUser = "ubuntu"
+
+// Role identifies the part of the cluster a node belongs to.
+type Role string
+
+const (
+	// ControlPlane nodes run the Kubernetes control plane and, unless the cluster
+	// uses an external etcd, a stacked etcd member.
+	ControlPlane Role = "control-plane"
+	// Etcd nodes run an external etcd member managed by etcdadm.
+	Etcd Role = "etcd"
+)
+
+// Node is a control plane or etcd machine reachable over SSH.
+type Node struct {
+	// Name is the name of the CAPI Machine backing the node.
+	Name     string
+	Address  string
+	Role     Role
+	OSFamily anywherev1.OSFamily
+	Username string
+}
+
+// MachineClient retrieves the CAPI Machines for a cluster.
+type MachineClient interface {
+	GetCAPIMachines(ctx context.Context, cluster *types.Cluster, clusterName string) ([]clusterv1.Machine, error)
+}
+
+// Lister builds the list of Nodes for a cluster from its CAPI Machines and its EKS-A machine configs.
+type Lister struct {
+	client MachineClient
+}
+
+// NewLister returns a new Lister.
+func NewLister(client MachineClient) *Lister {
+	return &Lister{client: client}
+}
+
+// ControlPlaneNodes returns the control plane nodes of the cluster.
+func (l *Lister) ControlPlaneNodes(ctx context.Context, managementCluster *types.Cluster, spec *cluster.Spec) ([]Node, error) {
+	return l.nodesForRole(ctx, managementCluster, spec, ControlPlane)
+}
+
+// EtcdNodes returns the nodes running etcd members. These are the external etcd nodes when
+// the cluster is configured with an external etcd and the control plane nodes otherwise.
+func (l *Lister) EtcdNodes(ctx context.Context, managementCluster *types.Cluster, spec *cluster.Spec) ([]Node, error) {
+	if spec.Cluster.Spec.ExternalEtcdConfiguration != nil {
+		return l.nodesForRole(ctx, managementCluster, spec, Etcd)
+	}
+
+	return l.ControlPlaneNodes(ctx, managementCluster, spec)
+}
+
+// EtcdOSFamily returns the OS family of the etcd nodes of the cluster, read from the machine config of the
+// external etcd or, without one, of the control plane.
+func EtcdOSFamily(spec *cluster.Spec) (anywherev1.OSFamily, error) {
+	role := ControlPlane
+	if spec.Cluster.Spec.ExternalEtcdConfiguration != nil {
+		role = Etcd
+	}
+
+	machineConfigName, _ := machineConfigAndLabelForRole(spec.Cluster, role)
+	osFamily, _, err := osFamilyAndUsername(spec.Config, machineConfigName)
+	return osFamily, err
+}
+
+func (l *Lister) nodesForRole(ctx context.Context, managementCluster *types.Cluster, spec *cluster.Spec, role Role) ([]Node, error) {
+	machineConfigName, label := machineConfigAndLabelForRole(spec.Cluster, role)
+	osFamily, username, err := osFamilyAndUsername(spec.Config, machineConfigName)
+	if err != nil {
+		return nil, err
+	}
+
+	machines, err := l.client.GetCAPIMachines(ctx, managementCluster, spec.Cluster.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	var nodes []Node
+	for _, m := range machines {
+		if _, ok := m.Labels[label]; !ok {
+			continue
+		}
+
+		address := machineAddress(m)
+		if address == "" {
+			return nil, fmt.Errorf("machine %s doesn't have an address yet", m.Name)
+		}
+
+		nodes = append(nodes, Node{
+			Name:     m.Name,
+			Address:  address,
+			Role:     role,
+			OSFamily: osFamily,
+			Username: username,
+		})
+	}
+
+	if len(nodes) == 0 {
+		return nil, fmt.Errorf("no %s machines found for cluster %s", role, spec.Cluster.Name)
+	}
+
+	return nodes, nil
+}
+
+func machineConfigAndLabelForRole(c *anywherev1.Cluster, role Role) (machineConfigName, label string) {
+	if role == Etcd {
+		return c.Spec.ExternalEtcdConfiguration.MachineGroupRef.Name, clusterv1.MachineEtcdClusterLabelName
+	}
+
+	var name string
+	if c.Spec.ControlPlaneConfiguration.MachineGroupRef != nil {
+		name = c.Spec.ControlPlaneConfiguration.MachineGroupRef.Name
+	}
+
+	return name, clusterv1.MachineControlPlaneLabel
+}
+
+// machineAddress returns the first external address of the machine, falling back
+// to the first address of any type.
+func machineAddress(m clusterv1.Machine) string {
+	for _, a := range m.Status.Addresses {
+		if a.Type == clusterv1.MachineExternalIP {
+			return a.Address
+		}
+	}
+
+	if len(m.Status.Addresses) > 0 {
+		return m.Status.Addresses[0].Address
+	}
+
+	return ""
+}
+
+// nolint:gocyclo
+func osFamilyAndUsername(config *cluster.Config, machineConfigName string) (anywherev1.OSFamily, string, error) {
+	switch {
+	case config.VSphereMachineConfigs[machineConfigName] != nil:
+		m := config.VSphereMachineConfigs[machineConfigName]
+		return m.OSFamily(), firstUser(m.Spec.Users), nil
+	case config.CloudStackMachineConfigs[machineConfigName] != nil:
+		m := config.CloudStackMachineConfigs[machineConfigName]
+		return m.OSFamily(), firstUser(m.Spec.Users), nil
+	case config.NutanixMachineConfigs[machineConfigName] != nil:
+		m := config.NutanixMachineConfigs[machineConfigName]
+		return m.OSFamily(), firstUser(m.Spec.Users), nil
+	case config.TinkerbellMachineConfigs[machineConfigName] != nil:
+		m := config.TinkerbellMachineConfigs[machineConfigName]
+		return m.OSFamily(), firstUser(m.Spec.Users), nil
+	case config.SnowMachineConfigs[machineConfigName] != nil:
+		m := config.SnowMachineConfigs[machineConfigName]
+		if m.OSFamily() == anywherev1.Bottlerocket {
+			return m.OSFamily(), constants.BottlerocketDefaultUser, nil
+		}
+		return m.OSFamily(), snowUbuntuDefaultUser, nil
+	}
+
+	return "", "", fmt.Errorf("machine config %s not found or its provider doesn't support SSH access", machineConfigName)
+}
+
+func firstUser(users []anywherev1.UserConfiguration) string {
+	if len(users) == 0 {
+		return ""
+	}
+
+	return users[0].Name
+}
@@ -0,0 +1,173 @@
+package nodes_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/nodes"
+	"github.com/aws/eks-anywhere/pkg/nodes/mocks"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+type listerTest struct {
+	*WithT
+	ctx               context.Context
+	client            *mocks.MockMachineClient
+	lister            *nodes.Lister
+	managementCluster *types.Cluster
+	spec              *cluster.Spec
+}
+
+func newListerTest(t *testing.T) *listerTest {
+	ctrl := gomock.NewController(t)
+	client := mocks.NewMockMachineClient(ctrl)
+	spec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = "my-cluster"
+		s.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef = &anywherev1.Ref{Name: "cp"}
+		s.VSphereMachineConfigs = map[string]*anywherev1.VSphereMachineConfig{
+			"cp": {
+				Spec: anywherev1.VSphereMachineConfigSpec{
+					OSFamily: anywherev1.Ubuntu,
+					Users:    []anywherev1.UserConfiguration{{Name: "capv"}},
+				},
+			},
+			"etcd": {
+				Spec: anywherev1.VSphereMachineConfigSpec{
+					OSFamily: anywherev1.Bottlerocket,
+					Users:    []anywherev1.UserConfiguration{{Name: "ec2-user"}},
+				},
+			},
+		}
+	})
+
+	return &listerTest{
+		WithT:             NewWithT(t),
+		ctx:               context.Background(),
+		client:            client,
+		lister:            nodes.NewLister(client),
+		managementCluster: &types.Cluster{Name: "mgmt", KubeconfigFile: "mgmt.kubeconfig"},
+		spec:              spec,
+	}
+}
+
+func machine(name string, label, address string) clusterv1.Machine {
+	m := clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{label: ""},
+		},
+	}
+	if address != "" {
+		m.Status.Addresses = clusterv1.MachineAddresses{
+			{Type: clusterv1.MachineInternalIP, Address: "10.0.0.1"},
+			{Type: clusterv1.MachineExternalIP, Address: address},
+		}
+	}
+
+	return m
+}
+
+func TestListerControlPlaneNodes(t *testing.T) {
+	tt := newListerTest(t)
+	tt.client.EXPECT().GetCAPIMachines(tt.ctx, tt.managementCluster, "my-cluster").Return([]clusterv1.Machine{
+		machine("cp-1", clusterv1.MachineControlPlaneLabel, "1.2.3.4"),
+		machine("md-1", clusterv1.MachineDeploymentNameLabel, "1.2.3.5"),
+	}, nil)
+
+	got, err := tt.lister.ControlPlaneNodes(tt.ctx, tt.managementCluster, tt.spec)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(got).To(ConsistOf(nodes.Node{
+		Name:     "cp-1",
+		Address:  "1.2.3.4",
+		Role:     nodes.ControlPlane,
+		OSFamily: anywherev1.Ubuntu,
+		Username: "capv",
+	}))
+}
+
+func TestListerEtcdNodesStacked(t *testing.T) {
+	tt := newListerTest(t)
+	tt.client.EXPECT().GetCAPIMachines(tt.ctx, tt.managementCluster, "my-cluster").Return([]clusterv1.Machine{
+		machine("cp-1", clusterv1.MachineControlPlaneLabel, "1.2.3.4"),
+	}, nil)
+
+	got, err := tt.lister.EtcdNodes(tt.ctx, tt.managementCluster, tt.spec)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(got).To(HaveLen(1))
+	tt.Expect(got[0].Role).To(Equal(nodes.ControlPlane))
+}
+
+func TestListerEtcdNodesExternal(t *testing.T) {
+	tt := newListerTest(t)
+	tt.spec.Cluster.Spec.ExternalEtcdConfiguration = &anywherev1.ExternalEtcdConfiguration{
+		MachineGroupRef: &anywherev1.Ref{Name: "etcd"},
+	}
+	tt.client.EXPECT().GetCAPIMachines(tt.ctx, tt.managementCluster, "my-cluster").Return([]clusterv1.Machine{
+		machine("cp-1", clusterv1.MachineControlPlaneLabel, "1.2.3.4"),
+		machine("etcd-1", clusterv1.MachineEtcdClusterLabelName, "1.2.3.6"),
+	}, nil)
+
+	got, err := tt.lister.EtcdNodes(tt.ctx, tt.managementCluster, tt.spec)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(got).To(ConsistOf(nodes.Node{
+		Name:     "etcd-1",
+		Address:  "1.2.3.6",
+		Role:     nodes.Etcd,
+		OSFamily: anywherev1.Bottlerocket,
+		Username: "ec2-user",
+	}))
+}
+
+func TestListerNodesMachineWithoutAddress(t *testing.T) {
+	tt := newListerTest(t)
+	tt.client.EXPECT().GetCAPIMachines(tt.ctx, tt.managementCluster, "my-cluster").Return([]clusterv1.Machine{
+		machine("cp-1", clusterv1.MachineControlPlaneLabel, ""),
+	}, nil)
+
+	_, err := tt.lister.ControlPlaneNodes(tt.ctx, tt.managementCluster, tt.spec)
+	tt.Expect(err).To(MatchError(ContainSubstring("machine cp-1 doesn't have an address yet")))
+}
+
+func TestListerNodesNoMachines(t *testing.T) {
+	tt := newListerTest(t)
+	tt.client.EXPECT().GetCAPIMachines(tt.ctx, tt.managementCluster, "my-cluster").Return(nil, nil)
+
+	_, err := tt.lister.ControlPlaneNodes(tt.ctx, tt.managementCluster, tt.spec)
+	tt.Expect(err).To(MatchError(ContainSubstring("no control-plane machines found for cluster my-cluster")))
+}
+
+func TestListerNodesClientError(t *testing.T) {
+	tt := newListerTest(t)
+	tt.client.EXPECT().GetCAPIMachines(tt.ctx, tt.managementCluster, "my-cluster").Return(nil, errors.New("failed"))
+
+	_, err := tt.lister.ControlPlaneNodes(tt.ctx, tt.managementCluster, tt.spec)
+	tt.Expect(err).To(MatchError(ContainSubstring("failed")))
+}
+
+func TestListerNodesUnsupportedProvider(t *testing.T) {
+	tt := newListerTest(t)
+	tt.spec.VSphereMachineConfigs = nil
+
+	_, err := tt.lister.ControlPlaneNodes(tt.ctx, tt.managementCluster, tt.spec)
+	tt.Expect(err).To(MatchError(ContainSubstring("machine config cp not found or its provider doesn't support SSH access")))
+}
+
+func TestEtcdOSFamily(t *testing.T) {
+	tt := newListerTest(t)
+
+	tt.Expect(nodes.EtcdOSFamily(tt.spec)).To(Equal(anywherev1.Ubuntu))
+
+	tt.spec.Cluster.Spec.ExternalEtcdConfiguration = &anywherev1.ExternalEtcdConfiguration{
+		MachineGroupRef: &anywherev1.Ref{Name: "etcd"},
+	}
+	tt.Expect(nodes.EtcdOSFamily(tt.spec)).To(Equal(anywherev1.Bottlerocket))
+}
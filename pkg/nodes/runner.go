@@ -0,0 +1,89 @@
+package nodes
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+// SSHClient runs commands on remote hosts over SSH.
+type SSHClient interface {
+	RunCommand(ctx context.Context, privateKeyPath, username, IP string, command ...string) (string, error)
+	RunCommandWithStdin(ctx context.Context, in []byte, privateKeyPath, username, IP string, command ...string) (string, error)
+}
+
+// Runner runs shell scripts as root on cluster nodes.
+type Runner struct {
+	ssh            SSHClient
+	privateKeyPath string
+	username       string
+}
+
+// RunnerOpt allows to customize a Runner on construction.
+type RunnerOpt func(*Runner)
+
+// WithUsername overrides the username from the node machine config.
+func WithUsername(username string) RunnerOpt {
+	return func(r *Runner) {
+		r.username = username
+	}
+}
+
+// NewRunner returns a new Runner that authenticates with the private key in privateKeyPath.
+func NewRunner(ssh SSHClient, privateKeyPath string, opts ...RunnerOpt) *Runner {
+	r := &Runner{
+		ssh:            ssh,
+		privateKeyPath: privateKeyPath,
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// Run runs script as root in the node host.
+func (r *Runner) Run(ctx context.Context, node Node, script string) (string, error) {
+	out, err := r.ssh.RunCommand(ctx, r.privateKeyPath, r.usernameFor(node), node.Address, rootCommand(node.OSFamily, script))
+	if err != nil {
+		return "", fmt.Errorf("running command in node %s: %v", node.Name, err)
+	}
+
+	return out, nil
+}
+
+// RunWithStdin runs script as root in the node host, sending in to its stdin.
+func (r *Runner) RunWithStdin(ctx context.Context, node Node, in []byte, script string) (string, error) {
+	out, err := r.ssh.RunCommandWithStdin(ctx, in, r.privateKeyPath, r.usernameFor(node), node.Address, rootCommand(node.OSFamily, script))
+	if err != nil {
+		return "", fmt.Errorf("running command in node %s: %v", node.Name, err)
+	}
+
+	return out, nil
+}
+
+func (r *Runner) usernameFor(node Node) string {
+	if r.username != "" {
+		return r.username
+	}
+
+	return node.Username
+}
+
+// rootCommand wraps script so it runs as root in the host. Bottlerocket SSH sessions land
+// in the admin container, so the script is run in the host namespaces through sheltie.
+func rootCommand(osFamily anywherev1.OSFamily, script string) string {
+	if osFamily == anywherev1.Bottlerocket {
+		return fmt.Sprintf("sudo sheltie bash -c %s", quote(script))
+	}
+
+	return fmt.Sprintf("sudo bash -c %s", quote(script))
+}
+
+// quote single quotes s for a POSIX shell.
+func quote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'"'"'`) + "'"
+}
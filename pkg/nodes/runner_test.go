@@ -0,0 +1,65 @@
+package nodes_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/nodes"
+	"github.com/aws/eks-anywhere/pkg/nodes/mocks"
+)
+
+func TestRunnerRunUbuntu(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	ssh := mocks.NewMockSSHClient(gomock.NewController(t))
+	r := nodes.NewRunner(ssh, "id_rsa")
+	node := nodes.Node{Name: "cp-1", Address: "1.2.3.4", OSFamily: anywherev1.Ubuntu, Username: "capv"}
+
+	ssh.EXPECT().RunCommand(ctx, "id_rsa", "capv", "1.2.3.4", `sudo bash -c 'echo '"'"'hi'"'"''`).Return("hi", nil)
+
+	g.Expect(r.Run(ctx, node, "echo 'hi'")).To(Equal("hi"))
+}
+
+func TestRunnerRunBottlerocketWithUsername(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	ssh := mocks.NewMockSSHClient(gomock.NewController(t))
+	r := nodes.NewRunner(ssh, "id_rsa", nodes.WithUsername("admin"))
+	node := nodes.Node{Name: "cp-1", Address: "1.2.3.4", OSFamily: anywherev1.Bottlerocket, Username: "ec2-user"}
+
+	ssh.EXPECT().RunCommand(ctx, "id_rsa", "admin", "1.2.3.4", "sudo sheltie bash -c 'hostname'").Return("cp-1", nil)
+
+	g.Expect(r.Run(ctx, node, "hostname")).To(Equal("cp-1"))
+}
+
+func TestRunnerRunError(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	ssh := mocks.NewMockSSHClient(gomock.NewController(t))
+	r := nodes.NewRunner(ssh, "id_rsa")
+	node := nodes.Node{Name: "cp-1", Address: "1.2.3.4", OSFamily: anywherev1.Ubuntu, Username: "capv"}
+
+	ssh.EXPECT().RunCommand(ctx, "id_rsa", "capv", "1.2.3.4", "sudo bash -c 'hostname'").Return("", errors.New("connection refused"))
+
+	_, err := r.Run(ctx, node, "hostname")
+	g.Expect(err).To(MatchError("running command in node cp-1: connection refused"))
+}
+
+func TestRunnerRunWithStdin(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	ssh := mocks.NewMockSSHClient(gomock.NewController(t))
+	r := nodes.NewRunner(ssh, "id_rsa")
+	node := nodes.Node{Name: "cp-1", Address: "1.2.3.4", OSFamily: anywherev1.Ubuntu, Username: "capv"}
+	in := []byte("data")
+
+	ssh.EXPECT().RunCommandWithStdin(ctx, in, "id_rsa", "capv", "1.2.3.4", "sudo bash -c 'cat > file'").Return("", nil)
+
+	_, err := r.RunWithStdin(ctx, node, in, "cat > file")
+	g.Expect(err).NotTo(HaveOccurred())
+}
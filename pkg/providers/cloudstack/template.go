@@ -206,6 +206,13 @@ func buildTemplateMapCP(clusterSpec *cluster.Spec) (map[string]interface{}, erro
 		values["registryMirrorMap"] = containerd.ToAPIEndpoints(registryMirror.NamespacedRegistryMap)
 		values["mirrorBase"] = registryMirror.BaseRegistry
 		values["insecureSkip"] = registryMirror.InsecureSkipVerify
+		if len(registryMirror.UpstreamMirrors) > 0 {
+			hosts, err := containerd.HostsFiles(registryMirror)
+			if err != nil {
+				return nil, err
+			}
+			values["registryMirrorHosts"] = hosts
+		}
 		values["publicMirror"] = containerd.ToAPIEndpoint(registryMirror.CoreEKSAMirror())
 		if len(registryMirror.CACertContent) > 0 {
 			values["registryCACert"] = registryMirror.CACertContent
@@ -353,6 +360,13 @@ func buildTemplateMapMD(clusterSpec *cluster.Spec, workerNodeGroupConfiguration
 		values["registryMirrorMap"] = containerd.ToAPIEndpoints(registryMirror.NamespacedRegistryMap)
 		values["mirrorBase"] = registryMirror.BaseRegistry
 		values["insecureSkip"] = registryMirror.InsecureSkipVerify
+		if len(registryMirror.UpstreamMirrors) > 0 {
+			hosts, err := containerd.HostsFiles(registryMirror)
+			if err != nil {
+				return nil, err
+			}
+			values["registryMirrorHosts"] = hosts
+		}
 		if len(registryMirror.CACertContent) > 0 {
 			values["registryCACert"] = registryMirror.CACertContent
 		}
@@ -634,6 +634,13 @@ func populateRegistryMirrorValues(clusterSpec *cluster.Spec, values map[string]i
 	values["registryMirrorMap"] = containerd.ToAPIEndpoints(registryMirror.NamespacedRegistryMap)
 	values["mirrorBase"] = registryMirror.BaseRegistry
 	values["insecureSkip"] = registryMirror.InsecureSkipVerify
+	if len(registryMirror.UpstreamMirrors) > 0 {
+		hosts, err := containerd.HostsFiles(registryMirror)
+		if err != nil {
+			return values, err
+		}
+		values["registryMirrorHosts"] = hosts
+	}
 	values["publicMirror"] = containerd.ToAPIEndpoint(registryMirror.CoreEKSAMirror())
 	if len(registryMirror.CACertContent) > 0 {
 		values["registryCACert"] = registryMirror.CACertContent
@@ -648,5 +655,13 @@ func populateRegistryMirrorValues(clusterSpec *cluster.Spec, values map[string]i
 		values["registryUsername"] = username
 		values["registryPassword"] = password
 	}
+
+	if upstreams := registryMirror.AuthenticatedUpstreams(); len(upstreams) > 0 {
+		credentials, err := config.UpstreamMirrorCredentials(upstreams)
+		if err != nil {
+			return values, err
+		}
+		values["registryUpstreamCredentials"] = credentials
+	}
 	return values, nil
 }
@@ -208,6 +208,13 @@ func buildTemplateMapCP(
 		values["mirrorBase"] = registryMirror.BaseRegistry
 		values["publicMirror"] = containerd.ToAPIEndpoint(registryMirror.CoreEKSAMirror())
 		values["insecureSkip"] = registryMirror.InsecureSkipVerify
+		if len(registryMirror.UpstreamMirrors) > 0 {
+			hosts, err := containerd.HostsFiles(registryMirror)
+			if err != nil {
+				return values, err
+			}
+			values["registryMirrorHosts"] = hosts
+		}
 		if len(registryMirror.CACertContent) > 0 {
 			values["registryCACert"] = registryMirror.CACertContent
 		}
@@ -221,6 +228,14 @@ func buildTemplateMapCP(
 			values["registryUsername"] = username
 			values["registryPassword"] = password
 		}
+
+		if upstreams := registryMirror.AuthenticatedUpstreams(); len(upstreams) > 0 {
+			credentials, err := config.UpstreamMirrorCredentials(upstreams)
+			if err != nil {
+				return values, err
+			}
+			values["registryUpstreamCredentials"] = credentials
+		}
 	}
 
 	if clusterSpec.Cluster.Spec.ExternalEtcdConfiguration != nil {
@@ -293,6 +308,13 @@ func buildTemplateMapMD(clusterSpec *cluster.Spec, workerNodeGroupMachineSpec v1
 		values["mirrorBase"] = registryMirror.BaseRegistry
 		values["publicMirror"] = containerd.ToAPIEndpoint(registryMirror.CoreEKSAMirror())
 		values["insecureSkip"] = registryMirror.InsecureSkipVerify
+		if len(registryMirror.UpstreamMirrors) > 0 {
+			hosts, err := containerd.HostsFiles(registryMirror)
+			if err != nil {
+				return values, err
+			}
+			values["registryMirrorHosts"] = hosts
+		}
 		if len(registryMirror.CACertContent) > 0 {
 			values["registryCACert"] = registryMirror.CACertContent
 		}
@@ -2,6 +2,7 @@ package vsphere
 
 import (
 	"fmt"
+	"strings"
 
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
@@ -12,6 +13,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/providers"
 	"github.com/aws/eks-anywhere/pkg/providers/common"
 	"github.com/aws/eks-anywhere/pkg/registrymirror"
+	"github.com/aws/eks-anywhere/pkg/registrymirror/bottlerocket"
 	"github.com/aws/eks-anywhere/pkg/registrymirror/containerd"
 	"github.com/aws/eks-anywhere/pkg/semver"
 	"github.com/aws/eks-anywhere/pkg/templater"
@@ -212,6 +214,20 @@ func buildTemplateMapCP(
 		values["registryMirrorMap"] = containerd.ToAPIEndpoints(registryMirror.NamespacedRegistryMap)
 		values["mirrorBase"] = registryMirror.BaseRegistry
 		values["insecureSkip"] = registryMirror.InsecureSkipVerify
+		if len(registryMirror.UpstreamMirrors) > 0 {
+			hosts, err := containerd.HostsFiles(registryMirror)
+			if err != nil {
+				return values, err
+			}
+			values["registryMirrorHosts"] = hosts
+		}
+		if len(registryMirror.UpstreamMirrors) > 0 && usesBottlerocket(clusterSpec) {
+			manifest, err := bottlerocket.Manifest(clusterSpec)
+			if err != nil {
+				return values, err
+			}
+			values["bottlerocketRegistryMirrors"] = strings.TrimSpace(strings.TrimSuffix(string(manifest), "\n---\n"))
+		}
 		values["publicMirror"] = containerd.ToAPIEndpoint(registryMirror.CoreEKSAMirror())
 		if len(registryMirror.CACertContent) > 0 {
 			values["registryCACert"] = registryMirror.CACertContent
@@ -226,6 +242,14 @@ func buildTemplateMapCP(
 			values["registryUsername"] = username
 			values["registryPassword"] = password
 		}
+
+		if upstreams := registryMirror.AuthenticatedUpstreams(); len(upstreams) > 0 {
+			credentials, err := config.UpstreamMirrorCredentials(upstreams)
+			if err != nil {
+				return values, err
+			}
+			values["registryUpstreamCredentials"] = credentials
+		}
 	}
 
 	if clusterSpec.Cluster.Spec.ProxyConfiguration != nil {
@@ -377,6 +401,13 @@ func buildTemplateMapMD(
 		values["registryMirrorMap"] = containerd.ToAPIEndpoints(registryMirror.NamespacedRegistryMap)
 		values["mirrorBase"] = registryMirror.BaseRegistry
 		values["insecureSkip"] = registryMirror.InsecureSkipVerify
+		if len(registryMirror.UpstreamMirrors) > 0 {
+			hosts, err := containerd.HostsFiles(registryMirror)
+			if err != nil {
+				return values, err
+			}
+			values["registryMirrorHosts"] = hosts
+		}
 		values["publicMirror"] = containerd.ToAPIEndpoint(registryMirror.CoreEKSAMirror())
 		if len(registryMirror.CACertContent) > 0 {
 			values["registryCACert"] = registryMirror.CACertContent
@@ -441,3 +472,13 @@ func buildTemplateMapMD(
 
 	return values, nil
 }
+
+// usesBottlerocket checks if any machine of the cluster runs Bottlerocket.
+func usesBottlerocket(clusterSpec *cluster.Spec) bool {
+	for _, mc := range clusterSpec.VSphereMachineConfigs {
+		if mc.Spec.OSFamily == anywherev1.Bottlerocket {
+			return true
+		}
+	}
+	return false
+}
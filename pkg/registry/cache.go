@@ -1,7 +1,14 @@
 package registry
 
-// Cache storage client for an OCI registry.
+import (
+	"crypto/x509"
+	"fmt"
+	"sync"
+)
+
+// Cache storage client for an OCI registry. It's safe for concurrent use.
 type Cache struct {
+	mu         sync.Mutex
 	registries map[string]StorageClient
 }
 
@@ -12,11 +19,17 @@ func NewCache() *Cache {
 	}
 }
 
-// Get cached registry client or make it.
+// Get cached registry client or make it. Hosts with the oci: prefix are OCI layout directories.
 func (cache *Cache) Get(context StorageContext) (StorageClient, error) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
 	aClient, found := cache.registries[context.host]
 	if !found {
-		aClient = NewOCIRegistry(context)
+		if IsOCILayout(context.host) {
+			aClient = NewOCILayout(context)
+		} else {
+			aClient = NewOCIRegistry(context)
+		}
 		err := aClient.Init()
 		if err != nil {
 			return nil, err
@@ -28,5 +41,18 @@ func (cache *Cache) Get(context StorageContext) (StorageClient, error) {
 
 // Set a client in the cache.
 func (cache *Cache) Set(registryName string, client StorageClient) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
 	cache.registries[registryName] = client
 }
+
+// Sources returns a SourceClientFunc that reads every artifact from the registry in its URI.
+func (cache *Cache) Sources(credentialStore *CredentialStore, certificates *x509.CertPool, insecure bool) SourceClientFunc {
+	return func(artifact Artifact) (StorageClient, error) {
+		client, err := cache.Get(NewStorageContext(artifact.Registry, credentialStore, certificates, insecure))
+		if err != nil {
+			return nil, fmt.Errorf("error with repository %s: %v", artifact.Registry, err)
+		}
+		return client, nil
+	}
+}
@@ -33,3 +33,17 @@ func TestCache_Get(t *testing.T) {
 
 	cache.Set("localhost", result)
 }
+
+func TestCache_Sources(t *testing.T) {
+	cache := registry.NewCache()
+	sources := cache.Sources(registry.NewCredentialStore(), &x509.CertPool{}, false)
+
+	result, err := sources(registry.NewArtifact("localhost", "eks-anywhere/kube-vip", "v0.5.5", ""))
+	assert.NoError(t, err)
+	cached, err := cache.Get(registry.NewStorageContext("localhost", nil, nil, false))
+	assert.NoError(t, err)
+	assert.Same(t, cached, result)
+
+	_, err = sources(registry.NewArtifact("!@#$", "eks-anywhere/kube-vip", "v0.5.5", ""))
+	assert.EqualError(t, err, "error with repository !@#$: error with registry <!@#$>: invalid reference: invalid registry")
+}
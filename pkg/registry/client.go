@@ -92,8 +92,7 @@ func (or *OCIRegistryClient) GetStorage(ctx context.Context, artifact Artifact)
 
 // Resolve the location of the source repository given the image.
 func (or *OCIRegistryClient) Resolve(ctx context.Context, srcStorage orasregistry.Repository, versionedImage string) (desc ocispec.Descriptor, err error) {
-	or.registry.Reference.Reference = versionedImage
-	return srcStorage.Resolve(ctx, or.registry.Reference.Reference)
+	return srcStorage.Resolve(ctx, versionedImage)
 }
 
 // FetchBytes a resource from the registry.
@@ -9,8 +9,9 @@ import (
 
 // CredentialStore for registry credentials such as ~/.docker/config.json.
 type CredentialStore struct {
-	directory  string
-	configFile *configfile.ConfigFile
+	directory   string
+	configFile  *configfile.ConfigFile
+	credentials map[string]auth.Credential
 }
 
 // NewCredentialStore create a credential store.
@@ -37,8 +38,22 @@ func (cs *CredentialStore) Init() (err error) {
 	return nil
 }
 
+// SetCredential sets the credential for a registry, taking precedence over the configuration files.
+func (cs *CredentialStore) SetCredential(registry, username, password string) {
+	if cs.credentials == nil {
+		cs.credentials = map[string]auth.Credential{}
+	}
+	cs.credentials[registry] = auth.Credential{
+		Username: username,
+		Password: password,
+	}
+}
+
 // Credential get an authentication credential for a given registry.
 func (cs *CredentialStore) Credential(registry string) (auth.Credential, error) {
+	if cred, ok := cs.credentials[registry]; ok {
+		return cred, nil
+	}
 	authConf, err := cs.configFile.GetCredentialsStore(registry).Get(registry)
 	if err != nil {
 		return auth.EmptyCredential, err
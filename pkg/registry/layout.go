@@ -0,0 +1,225 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/oci"
+	"oras.land/oras-go/v2/errdef"
+	orasregistry "oras.land/oras-go/v2/registry"
+)
+
+// OCILayoutScheme prefixes the location of an OCI image layout directory, e.g. oci:/mnt/usb/eks-a.
+const OCILayoutScheme = "oci:"
+
+// IsOCILayout returns true if the location is an OCI image layout directory instead of a registry.
+func IsOCILayout(location string) bool {
+	return strings.HasPrefix(location, OCILayoutScheme)
+}
+
+// OCILayoutClient storage client for an OCI image layout directory.
+// All the repositories are stored in the same layout: manifests are indexed by digest and
+// tags are recorded with the repository name, e.g. eks-anywhere/kube-vip:v0.5.5.
+type OCILayoutClient struct {
+	StorageContext
+	initialized sync.Once
+	store       *oci.Store
+}
+
+var _ StorageClient = (*OCILayoutClient)(nil)
+
+// NewOCILayout create an OCI image layout client. The host of the context is the
+// location of the layout, with or without the oci: prefix.
+func NewOCILayout(context StorageContext) *OCILayoutClient {
+	return &OCILayoutClient{
+		StorageContext: context,
+	}
+}
+
+// Init creates the layout directory if it doesn't exist and loads its index.
+func (ol *OCILayoutClient) Init() error {
+	var err error
+	onceFunc := func() {
+		dir := strings.TrimPrefix(ol.host, OCILayoutScheme)
+		ol.store, err = oci.New(dir)
+		if err != nil {
+			err = fmt.Errorf("error with OCI layout <%s>: %v", dir, err)
+		}
+	}
+	ol.initialized.Do(onceFunc)
+	return err
+}
+
+// GetHost for layout location.
+func (ol *OCILayoutClient) GetHost() string {
+	return ol.host
+}
+
+// SetProject for layout destination.
+func (ol *OCILayoutClient) SetProject(project string) {
+	ol.project = project
+}
+
+// Destination of this storage layout.
+func (ol *OCILayoutClient) Destination(image Artifact) string {
+	return path.Join(ol.project, image.Repository) + image.Version()
+}
+
+// GetStorage object based on repository.
+func (ol *OCILayoutClient) GetStorage(_ context.Context, artifact Artifact) (orasregistry.Repository, error) {
+	return &layoutRepository{
+		store:      ol.store,
+		repository: path.Join(ol.project, artifact.Repository),
+	}, nil
+}
+
+// Resolve the location of the source repository given the image.
+func (ol *OCILayoutClient) Resolve(ctx context.Context, srcStorage orasregistry.Repository, versionedImage string) (ocispec.Descriptor, error) {
+	return srcStorage.Resolve(ctx, versionedImage)
+}
+
+// FetchBytes a resource from the layout.
+func (ol *OCILayoutClient) FetchBytes(ctx context.Context, srcStorage orasregistry.Repository, artifact Artifact) (ocispec.Descriptor, []byte, error) {
+	return oras.FetchBytes(ctx, srcStorage, artifact.VersionedImage(), oras.DefaultFetchBytesOptions)
+}
+
+// FetchBlob get named blob.
+func (ol *OCILayoutClient) FetchBlob(ctx context.Context, srcStorage orasregistry.Repository, descriptor ocispec.Descriptor) ([]byte, error) {
+	return content.FetchAll(ctx, srcStorage, descriptor)
+}
+
+// CopyGraph copy manifest and all blobs to destination.
+func (ol *OCILayoutClient) CopyGraph(ctx context.Context, srcStorage orasregistry.Repository, srcRef string, dstStorage orasregistry.Repository, dstRef string) (ocispec.Descriptor, error) {
+	return oras.Copy(ctx, srcStorage, srcRef, dstStorage, dstRef, oras.CopyOptions{})
+}
+
+// Tag an image.
+func (ol *OCILayoutClient) Tag(ctx context.Context, dstStorage orasregistry.Repository, desc ocispec.Descriptor, tag string) error {
+	return dstStorage.Tag(ctx, desc, tag)
+}
+
+// layoutRepository is the view of a single repository of an OCI layout shared by all of them.
+type layoutRepository struct {
+	store      *oci.Store
+	repository string
+}
+
+var _ orasregistry.Repository = (*layoutRepository)(nil)
+
+// reference maps a reference to the repository to the reference in the layout index.
+// Digests are kept as they are and tags are prefixed with the repository name, so
+// public.ecr.aws/eks-anywhere/kube-vip:v0.5.5 and v0.5.5 are both eks-anywhere/kube-vip:v0.5.5.
+func (r *layoutRepository) reference(ref string) string {
+	if i := strings.LastIndex(ref, "@"); i >= 0 {
+		return ref[i+1:]
+	}
+	if isDigest(ref) {
+		return ref
+	}
+	if i := strings.LastIndex(ref, ":"); i > strings.LastIndex(ref, "/") {
+		ref = ref[i+1:]
+	}
+	return r.repository + ":" + ref
+}
+
+func isDigest(ref string) bool {
+	_, err := digest.Parse(ref)
+	return err == nil
+}
+
+func (r *layoutRepository) Fetch(ctx context.Context, target ocispec.Descriptor) (io.ReadCloser, error) {
+	return r.store.Fetch(ctx, target)
+}
+
+func (r *layoutRepository) Push(ctx context.Context, expected ocispec.Descriptor, content io.Reader) error {
+	return r.store.Push(ctx, expected, content)
+}
+
+func (r *layoutRepository) Exists(ctx context.Context, target ocispec.Descriptor) (bool, error) {
+	return r.store.Exists(ctx, target)
+}
+
+func (r *layoutRepository) Delete(_ context.Context, _ ocispec.Descriptor) error {
+	return errdef.ErrUnsupported
+}
+
+// Tag tags a manifest with a reference. Manifests are always indexed by digest when pushed,
+// so tagging with a digest only checks the manifest exists.
+func (r *layoutRepository) Tag(ctx context.Context, desc ocispec.Descriptor, reference string) error {
+	ref := r.reference(reference)
+	if isDigest(ref) {
+		exists, err := r.store.Exists(ctx, desc)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return fmt.Errorf("%s: %w", desc.Digest, errdef.ErrNotFound)
+		}
+		return nil
+	}
+	return r.store.Tag(ctx, desc, ref)
+}
+
+func (r *layoutRepository) Resolve(ctx context.Context, reference string) (ocispec.Descriptor, error) {
+	return r.store.Resolve(ctx, r.reference(reference))
+}
+
+func (r *layoutRepository) FetchReference(ctx context.Context, reference string) (ocispec.Descriptor, io.ReadCloser, error) {
+	desc, err := r.Resolve(ctx, reference)
+	if err != nil {
+		return ocispec.Descriptor{}, nil, err
+	}
+	rc, err := r.store.Fetch(ctx, desc)
+	if err != nil {
+		return ocispec.Descriptor{}, nil, err
+	}
+	return desc, rc, nil
+}
+
+func (r *layoutRepository) PushReference(ctx context.Context, expected ocispec.Descriptor, content io.Reader, reference string) error {
+	if err := r.store.Push(ctx, expected, content); err != nil && !errors.Is(err, errdef.ErrAlreadyExists) {
+		return err
+	}
+	return r.Tag(ctx, expected, reference)
+}
+
+func (r *layoutRepository) Referrers(_ context.Context, _ ocispec.Descriptor, _ string, _ func([]ocispec.Descriptor) error) error {
+	return errdef.ErrUnsupported
+}
+
+// Tags lists the tags of the repository, without the repository name.
+func (r *layoutRepository) Tags(ctx context.Context, last string, fn func(tags []string) error) error {
+	prefix := r.repository + ":"
+	if last != "" {
+		last = prefix + last
+	}
+	return r.store.Tags(ctx, last, func(tags []string) error {
+		var repoTags []string
+		for _, t := range tags {
+			if strings.HasPrefix(t, prefix) {
+				repoTags = append(repoTags, strings.TrimPrefix(t, prefix))
+			}
+		}
+		if len(repoTags) == 0 {
+			return nil
+		}
+		return fn(repoTags)
+	})
+}
+
+func (r *layoutRepository) Blobs() orasregistry.BlobStore {
+	return r
+}
+
+func (r *layoutRepository) Manifests() orasregistry.ManifestStore {
+	return r
+}
@@ -0,0 +1,137 @@
+package registry_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/eks-anywhere/pkg/registry"
+)
+
+func newLayout(t *testing.T, dir string) *registry.OCILayoutClient {
+	layout := registry.NewOCILayout(registry.NewStorageContext(registry.OCILayoutScheme+dir, nil, nil, false))
+	require.NoError(t, layout.Init())
+	return layout
+}
+
+func descriptorFor(mediaType string, data []byte) ocispec.Descriptor {
+	return ocispec.Descriptor{
+		MediaType: mediaType,
+		Digest:    digest.FromBytes(data),
+		Size:      int64(len(data)),
+	}
+}
+
+// pushImage pushes a single layer image to the layout and returns the digest of its manifest.
+func pushImage(t *testing.T, layout *registry.OCILayoutClient, artifact registry.Artifact) string {
+	repo, err := layout.GetStorage(ctx, artifact)
+	require.NoError(t, err)
+
+	config := []byte("{}")
+	layer := []byte("layer of " + artifact.Repository)
+	configDesc := descriptorFor(ocispec.MediaTypeImageConfig, config)
+	layerDesc := descriptorFor(ocispec.MediaTypeImageLayer, layer)
+	require.NoError(t, repo.Push(ctx, configDesc, bytes.NewReader(config)))
+	require.NoError(t, repo.Push(ctx, layerDesc, bytes.NewReader(layer)))
+
+	manifest, err := json.Marshal(ocispec.Manifest{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: ocispec.MediaTypeImageManifest,
+		Config:    configDesc,
+		Layers:    []ocispec.Descriptor{layerDesc},
+	})
+	require.NoError(t, err)
+	manifestDesc := descriptorFor(ocispec.MediaTypeImageManifest, manifest)
+	require.NoError(t, repo.PushReference(ctx, manifestDesc, bytes.NewReader(manifest), artifact.Tag))
+
+	return manifestDesc.Digest.String()
+}
+
+func TestIsOCILayout(t *testing.T) {
+	assert.True(t, registry.IsOCILayout("oci:/mnt/usb"))
+	assert.False(t, registry.IsOCILayout("public.ecr.aws"))
+}
+
+func TestOCILayoutClientDestination(t *testing.T) {
+	layout := newLayout(t, t.TempDir())
+	assert.Equal(t, srcArtifact.Repository+"@"+srcArtifact.Digest, layout.Destination(srcArtifact))
+
+	layout.SetProject("curated-packages/")
+	assert.Equal(t, "curated-packages/"+srcArtifact.Repository+"@"+srcArtifact.Digest, layout.Destination(srcArtifact))
+}
+
+func TestOCILayoutClientResolve(t *testing.T) {
+	layout := newLayout(t, t.TempDir())
+	artifact := registry.NewArtifact("public.ecr.aws", "eks-anywhere/kube-vip", "v0.5.5", "")
+	manifestDigest := pushImage(t, layout, artifact)
+
+	repo, err := layout.GetStorage(ctx, artifact)
+	require.NoError(t, err)
+	for _, ref := range []string{"v0.5.5", artifact.VersionedImage(), "public.ecr.aws/eks-anywhere/kube-vip@" + manifestDigest, manifestDigest} {
+		desc, err := layout.Resolve(ctx, repo, ref)
+		assert.NoError(t, err, ref)
+		assert.Equal(t, manifestDigest, desc.Digest.String(), ref)
+	}
+
+	// Tags are scoped to their repository.
+	other, err := layout.GetStorage(ctx, registry.NewArtifact("public.ecr.aws", "eks-anywhere/other", "", ""))
+	require.NoError(t, err)
+	_, err = layout.Resolve(ctx, other, "v0.5.5")
+	assert.Error(t, err)
+
+	var tags []string
+	assert.NoError(t, repo.Tags(ctx, "", func(t []string) error {
+		tags = append(tags, t...)
+		return nil
+	}))
+	assert.Equal(t, []string{"v0.5.5"}, tags)
+}
+
+func TestOCILayoutClientCopyBetweenLayouts(t *testing.T) {
+	src := newLayout(t, t.TempDir())
+	tagged := registry.NewArtifact("public.ecr.aws", "eks-anywhere/kube-vip", "v0.5.5", "")
+	tagged.Digest = pushImage(t, src, tagged)
+
+	dstDir := filepath.Join(t.TempDir(), "usb")
+	dst := newLayout(t, dstDir)
+	dst.SetProject("curated-packages")
+	m := registry.NewMirror(dst, func(registry.Artifact) (registry.StorageClient, error) { return src, nil })
+	require.NoError(t, m.Run(ctx, []registry.Artifact{tagged}))
+
+	// A new client reads the index saved to disk.
+	reopened := newLayout(t, dstDir)
+	reopened.SetProject("curated-packages")
+	repo, err := reopened.GetStorage(ctx, tagged)
+	require.NoError(t, err)
+	desc, err := reopened.Resolve(ctx, repo, "v0.5.5")
+	assert.NoError(t, err)
+	assert.Equal(t, tagged.Digest, desc.Digest.String())
+
+	_, data, err := reopened.FetchBytes(ctx, repo, tagged)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), ocispec.MediaTypeImageLayer)
+}
+
+func TestOCILayoutClientInitError(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "file")
+	require.NoError(t, os.WriteFile(file, []byte("not a directory"), 0o600))
+
+	layout := registry.NewOCILayout(registry.NewStorageContext(registry.OCILayoutScheme+file, nil, nil, false))
+	assert.ErrorContains(t, layout.Init(), "error with OCI layout <"+file+">")
+}
+
+func TestCacheGetOCILayout(t *testing.T) {
+	cache := registry.NewCache()
+	client, err := cache.Get(registry.NewStorageContext(registry.OCILayoutScheme+t.TempDir(), nil, nil, false))
+	assert.NoError(t, err)
+	_, ok := client.(*registry.OCILayoutClient)
+	assert.True(t, ok)
+}
@@ -0,0 +1,333 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const defaultMirrorConcurrency = 4
+
+// SourceClientFunc returns the storage client an artifact should be read from.
+type SourceClientFunc func(artifact Artifact) (StorageClient, error)
+
+// Mirror copies a set of artifacts to a destination registry with a bounded pool of workers.
+// Blobs the destination already has are not uploaded again, since CopyGraph only pushes the
+// nodes of the graph missing in the destination. The progress is recorded in an optional state
+// file, so an interrupted run only copies the artifacts that were not finished.
+type Mirror struct {
+	dst         StorageClient
+	sources     SourceClientFunc
+	concurrency int
+	stateFile   string
+	project     *string
+	verifier    *SignatureVerifier
+}
+
+// MirrorOpt allows to customize a Mirror.
+type MirrorOpt func(*Mirror)
+
+// WithConcurrency sets the maximum number of artifacts copied at the same time.
+func WithConcurrency(concurrency int) MirrorOpt {
+	return func(m *Mirror) {
+		if concurrency > 0 {
+			m.concurrency = concurrency
+		}
+	}
+}
+
+// WithStateFile sets the file where the mirror records the artifacts already copied.
+func WithStateFile(path string) MirrorOpt {
+	return func(m *Mirror) {
+		m.stateFile = path
+	}
+}
+
+// PhaseStateFile returns the state file of one phase of a copy made with several mirrors, e.g.
+// copy-packages-state-charts.json for the charts phase of copy-packages-state.json. Each mirror
+// removes its state file once it finishes, so mirrors must not share one.
+func PhaseStateFile(path, phase string) string {
+	if path == "" {
+		return ""
+	}
+	ext := filepath.Ext(path)
+	return fmt.Sprintf("%s-%s%s", strings.TrimSuffix(path, ext), phase, ext)
+}
+
+// WithDestinationProject sets the project of the destination the artifacts are copied to, e.g.
+// curated-packages/. The project is set when the mirror runs, so mirrors sharing a destination
+// can copy to different projects.
+func WithDestinationProject(project string) MirrorOpt {
+	return func(m *Mirror) {
+		m.project = &project
+	}
+}
+
+// WithVerifier makes the mirror verify the signature of every artifact in its source before
+// copying it, refusing the artifacts that aren't signed, and copy the signatures and SBOMs
+// attached to them.
+func WithVerifier(verifier *SignatureVerifier) MirrorOpt {
+	return func(m *Mirror) {
+		m.verifier = verifier
+	}
+}
+
+// NewMirror constructs a new Mirror that copies artifacts to dst.
+func NewMirror(dst StorageClient, sources SourceClientFunc, opts ...MirrorOpt) *Mirror {
+	m := &Mirror{
+		dst:         dst,
+		sources:     sources,
+		concurrency: defaultMirrorConcurrency,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// Run copies all the artifacts to the destination and, once they are all copied, verifies
+// the manifest digest of every artifact in the destination matches the one in the source bundle.
+// The state file is removed after a successful run.
+func (m *Mirror) Run(ctx context.Context, artifacts []Artifact) error {
+	if m.project != nil {
+		m.dst.SetProject(*m.project)
+	}
+
+	state, err := loadMirrorState(m.stateFile)
+	if err != nil {
+		return err
+	}
+
+	err = m.forEach(ctx, artifacts, func(ctx context.Context, artifact Artifact) error {
+		dst := m.dst.Destination(artifact)
+		if state.completed(dst, artifact.Digest) {
+			logger.V(4).Info("Artifact already mirrored, skipping", "destination", dst)
+			return nil
+		}
+
+		src, err := m.sources(artifact)
+		if err != nil {
+			return err
+		}
+
+		var attached []Artifact
+		if m.verifier != nil {
+			if attached, err = m.verifier.Verify(ctx, src, artifact); err != nil {
+				return fmt.Errorf("refusing %s: %v", artifact.VersionedImage(), err)
+			}
+		}
+
+		logger.V(3).Info("Mirroring artifact", "source", artifact.VersionedImage(), "destination", dst)
+		if err := Copy(ctx, src, m.dst, artifact); err != nil {
+			return fmt.Errorf("copying %s: %v", artifact.VersionedImage(), err)
+		}
+		for _, a := range attached {
+			if err := Copy(ctx, src, m.dst, a); err != nil {
+				return fmt.Errorf("copying %s: %v", a.VersionedImage(), err)
+			}
+		}
+
+		return state.complete(dst, artifact.Digest)
+	})
+	if err != nil {
+		return err
+	}
+
+	err = m.forEach(ctx, artifacts, func(ctx context.Context, artifact Artifact) error {
+		if err := m.verify(ctx, artifact); err != nil {
+			// Forget the artifact so the next run copies it again.
+			if stateErr := state.forget(m.dst.Destination(artifact)); stateErr != nil {
+				return stateErr
+			}
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return state.remove()
+}
+
+// verify checks the manifest the destination serves for an artifact is the one in the source bundle.
+// When the artifact has a tag, the tag is resolved, so a tag pointing to a different manifest is detected.
+func (m *Mirror) verify(ctx context.Context, artifact Artifact) error {
+	if artifact.Digest == "" {
+		return nil
+	}
+
+	dstStorage, err := m.dst.GetStorage(ctx, artifact)
+	if err != nil {
+		return fmt.Errorf("repository destination: %v", err)
+	}
+
+	ref := artifact
+	if ref.Tag != "" {
+		ref.Digest = ""
+	}
+	dst := m.dst.Destination(ref)
+
+	desc, err := m.dst.Resolve(ctx, dstStorage, dst)
+	if err != nil {
+		return fmt.Errorf("verifying %s: %v", dst, err)
+	}
+
+	if desc.Digest.String() != artifact.Digest {
+		return fmt.Errorf("verifying %s: digest %s doesn't match %s from source", dst, desc.Digest, artifact.Digest)
+	}
+
+	return nil
+}
+
+// forEach runs f for every artifact with at most m.concurrency calls running at the same time.
+// After the first failure no more artifacts are started and the errors of all the failed calls are returned.
+func (m *Mirror) forEach(ctx context.Context, artifacts []Artifact, f func(context.Context, Artifact) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	jobs := make(chan Artifact)
+	var mu sync.Mutex
+	var errs []error
+	var wg sync.WaitGroup
+
+	for i := 0; i < m.concurrency && i < len(artifacts); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for artifact := range jobs {
+				if err := f(ctx, artifact); err != nil {
+					mu.Lock()
+					errs = append(errs, err)
+					mu.Unlock()
+					cancel()
+				}
+			}
+		}()
+	}
+
+loop:
+	for _, artifact := range artifacts {
+		select {
+		case jobs <- artifact:
+		case <-ctx.Done():
+			break loop
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if len(errs) == 0 && ctx.Err() != nil {
+		// The parent context was cancelled before all the artifacts were processed.
+		return ctx.Err()
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+// mirrorState records the manifest digest of the artifacts already copied, by destination.
+type mirrorState struct {
+	mu        sync.Mutex
+	path      string
+	Artifacts map[string]string `json:"artifacts"`
+}
+
+func loadMirrorState(path string) (*mirrorState, error) {
+	state := &mirrorState{
+		path:      path,
+		Artifacts: map[string]string{},
+	}
+	if path == "" {
+		return state, nil
+	}
+
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading mirror state file: %v", err)
+	}
+
+	if err := json.Unmarshal(content, state); err != nil {
+		return nil, fmt.Errorf("parsing mirror state file %s: %v", path, err)
+	}
+	if state.Artifacts == nil {
+		state.Artifacts = map[string]string{}
+	}
+
+	return state, nil
+}
+
+func (s *mirrorState) completed(destination, digest string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	d, ok := s.Artifacts[destination]
+	return ok && d == digest
+}
+
+func (s *mirrorState) complete(destination, digest string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Artifacts[destination] = digest
+	return s.save()
+}
+
+func (s *mirrorState) forget(destination string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.Artifacts, destination)
+	return s.save()
+}
+
+func (s *mirrorState) remove() error {
+	if s.path == "" {
+		return nil
+	}
+	if err := os.Remove(s.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("removing mirror state file: %v", err)
+	}
+	return nil
+}
+
+// save writes the state to a temporary file and renames it, so an interruption
+// never leaves a truncated state file behind. It must be called holding the lock.
+func (s *mirrorState) save() error {
+	if s.path == "" {
+		return nil
+	}
+
+	content, err := json.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("marshalling mirror state: %v", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return fmt.Errorf("writing mirror state file: %v", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return fmt.Errorf("writing mirror state file: %v", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("writing mirror state file: %v", err)
+	}
+
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("writing mirror state file: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,169 @@
+package registry_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aws/eks-anywhere/pkg/registry"
+	"github.com/aws/eks-anywhere/pkg/registry/mocks"
+)
+
+var otherArtifact = registry.Artifact{
+	Registry:   "public.ecr.aws",
+	Repository: "l0g8r8j6/kube-vip/kube-vip-cloud-provider",
+	Tag:        "v0.0.2-eks-a-v0.0.0-dev-build.4452",
+	Digest:     "sha256:0c2eaa03b0ad9d8ad6c8cbfc1d7a6a3e8c6dbb8a4a4c1d9b6f7cba0a5f6e1c9b",
+}
+
+type mirrorTest struct {
+	srcClient *mocks.MockStorageClient
+	dstClient *mocks.MockStorageClient
+	srcRepo   *mocks.MockRepository
+	dstRepo   *mocks.MockRepository
+	stateFile string
+}
+
+func newMirrorTest(t *testing.T) *mirrorTest {
+	ctrl := gomock.NewController(t)
+	tt := &mirrorTest{
+		srcClient: mocks.NewMockStorageClient(ctrl),
+		dstClient: mocks.NewMockStorageClient(ctrl),
+		srcRepo:   mocks.NewMockRepository(ctrl),
+		dstRepo:   mocks.NewMockRepository(ctrl),
+		stateFile: filepath.Join(t.TempDir(), "mirror-state.json"),
+	}
+	tt.dstClient.EXPECT().Destination(gomock.Any()).DoAndReturn(func(a registry.Artifact) string {
+		return "registry.example.com/" + a.Repository + a.Version()
+	}).AnyTimes()
+	return tt
+}
+
+func (tt *mirrorTest) mirror(opts ...registry.MirrorOpt) *registry.Mirror {
+	opts = append([]registry.MirrorOpt{registry.WithStateFile(tt.stateFile)}, opts...)
+	return registry.NewMirror(tt.dstClient, func(registry.Artifact) (registry.StorageClient, error) {
+		return tt.srcClient, nil
+	}, opts...)
+}
+
+func (tt *mirrorTest) expectCopy(artifact registry.Artifact, err error) {
+	d := ocispec.Descriptor{Digest: digest.Digest(artifact.Digest)}
+	tt.srcClient.EXPECT().GetStorage(gomock.Any(), artifact).Return(tt.srcRepo, nil)
+	tt.dstClient.EXPECT().GetStorage(gomock.Any(), artifact).Return(tt.dstRepo, nil)
+	tt.srcClient.EXPECT().CopyGraph(gomock.Any(), tt.srcRepo, artifact.VersionedImage(), tt.dstRepo, "registry.example.com/"+artifact.Repository+artifact.Version()).Return(d, err)
+	if err == nil {
+		tt.dstClient.EXPECT().Tag(gomock.Any(), tt.dstRepo, d, artifact.Tag).Return(nil)
+	}
+}
+
+func (tt *mirrorTest) expectVerify(artifact registry.Artifact, dgst string) {
+	tt.dstClient.EXPECT().GetStorage(gomock.Any(), artifact).Return(tt.dstRepo, nil)
+	tt.dstClient.EXPECT().Resolve(gomock.Any(), tt.dstRepo, "registry.example.com/"+artifact.Repository+":"+artifact.Tag).
+		Return(ocispec.Descriptor{Digest: digest.Digest(dgst)}, nil)
+}
+
+func (tt *mirrorTest) writeState(t *testing.T, artifacts map[string]string) {
+	content, err := json.Marshal(map[string]interface{}{"artifacts": artifacts})
+	assert.NoError(t, err)
+	assert.NoError(t, os.WriteFile(tt.stateFile, content, 0o600))
+}
+
+func (tt *mirrorTest) readState(t *testing.T) map[string]string {
+	content, err := os.ReadFile(tt.stateFile)
+	assert.NoError(t, err)
+	state := struct {
+		Artifacts map[string]string `json:"artifacts"`
+	}{}
+	assert.NoError(t, json.Unmarshal(content, &state))
+	return state.Artifacts
+}
+
+func TestMirrorRun(t *testing.T) {
+	tt := newMirrorTest(t)
+	tt.expectCopy(srcArtifact, nil)
+	tt.expectCopy(otherArtifact, nil)
+	tt.expectVerify(srcArtifact, srcArtifact.Digest)
+	tt.expectVerify(otherArtifact, otherArtifact.Digest)
+
+	err := tt.mirror().Run(ctx, []registry.Artifact{srcArtifact, otherArtifact})
+	assert.NoError(t, err)
+	assert.NoFileExists(t, tt.stateFile)
+}
+
+func TestMirrorRunWithDestinationProject(t *testing.T) {
+	tt := newMirrorTest(t)
+	tt.dstClient.EXPECT().SetProject("curated-packages/")
+	tt.expectCopy(srcArtifact, nil)
+	tt.expectVerify(srcArtifact, srcArtifact.Digest)
+
+	err := tt.mirror(registry.WithDestinationProject("curated-packages/")).Run(ctx, []registry.Artifact{srcArtifact})
+	assert.NoError(t, err)
+}
+
+func TestMirrorRunResumesFromState(t *testing.T) {
+	tt := newMirrorTest(t)
+	tt.writeState(t, map[string]string{
+		"registry.example.com/" + srcArtifact.Repository + srcArtifact.Version(): srcArtifact.Digest,
+	})
+	tt.expectCopy(otherArtifact, nil)
+	tt.expectVerify(srcArtifact, srcArtifact.Digest)
+	tt.expectVerify(otherArtifact, otherArtifact.Digest)
+
+	err := tt.mirror().Run(ctx, []registry.Artifact{srcArtifact, otherArtifact})
+	assert.NoError(t, err)
+}
+
+func TestMirrorRunCopyErrorKeepsProgress(t *testing.T) {
+	tt := newMirrorTest(t)
+	tt.expectCopy(srcArtifact, nil)
+	tt.expectCopy(otherArtifact, fmt.Errorf("oops"))
+
+	err := tt.mirror(registry.WithConcurrency(1)).Run(ctx, []registry.Artifact{srcArtifact, otherArtifact})
+	assert.EqualError(t, err, fmt.Sprintf("copying %s: registry copy: oops", otherArtifact.VersionedImage()))
+	assert.Equal(t, map[string]string{
+		"registry.example.com/" + srcArtifact.Repository + srcArtifact.Version(): srcArtifact.Digest,
+	}, tt.readState(t))
+}
+
+func TestMirrorRunDigestMismatch(t *testing.T) {
+	tt := newMirrorTest(t)
+	wrongDigest := "sha256:1111111111111111111111111111111111111111111111111111111111111111"
+	tt.expectCopy(srcArtifact, nil)
+	tt.expectVerify(srcArtifact, wrongDigest)
+
+	err := tt.mirror().Run(ctx, []registry.Artifact{srcArtifact})
+	dst := "registry.example.com/" + srcArtifact.Repository + ":" + srcArtifact.Tag
+	assert.EqualError(t, err, fmt.Sprintf("verifying %s: digest %s doesn't match %s from source", dst, wrongDigest, srcArtifact.Digest))
+	assert.Empty(t, tt.readState(t))
+}
+
+func TestMirrorRunSourceError(t *testing.T) {
+	tt := newMirrorTest(t)
+	m := registry.NewMirror(tt.dstClient, func(registry.Artifact) (registry.StorageClient, error) {
+		return nil, fmt.Errorf("error with repository public.ecr.aws: oops")
+	})
+
+	err := m.Run(ctx, []registry.Artifact{srcArtifact})
+	assert.EqualError(t, err, "error with repository public.ecr.aws: oops")
+}
+
+func TestMirrorRunInvalidStateFile(t *testing.T) {
+	tt := newMirrorTest(t)
+	assert.NoError(t, os.WriteFile(tt.stateFile, []byte("{"), 0o600))
+
+	err := tt.mirror().Run(ctx, []registry.Artifact{srcArtifact})
+	assert.ErrorContains(t, err, "parsing mirror state file")
+}
+
+func TestPhaseStateFile(t *testing.T) {
+	assert.Equal(t, filepath.Join("dir", "copy-packages-state-charts.json"), registry.PhaseStateFile(filepath.Join("dir", "copy-packages-state.json"), "charts"))
+	assert.Equal(t, "state-images", registry.PhaseStateFile("state", "images"))
+	assert.Empty(t, registry.PhaseStateFile("", "images"))
+}
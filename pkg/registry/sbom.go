@@ -0,0 +1,47 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// FetchSBOM resolves an artifact, by digest when it's set, and returns its digest and the content of the SBOM attached to it
+// with cosign or as a referrer. The digest is returned even when the error is ErrSBOMNotFound.
+func FetchSBOM(ctx context.Context, client StorageClient, artifact Artifact) (string, []byte, error) {
+	repo, err := client.GetStorage(ctx, artifact)
+	if err != nil {
+		return "", nil, fmt.Errorf("repository source: %v", err)
+	}
+
+	desc, err := client.Resolve(ctx, repo, artifact.VersionedImage())
+	if err != nil {
+		return "", nil, fmt.Errorf("resolving %s: %v", artifact.VersionedImage(), err)
+	}
+	digest := desc.Digest.String()
+
+	sbomArtifacts, _, err := attachedSBOMs(ctx, client, repo, artifact, desc)
+	if err != nil {
+		return digest, nil, err
+	}
+
+	_, manifest, err := client.FetchBytes(ctx, repo, sbomArtifacts[0])
+	if err != nil {
+		return digest, nil, fmt.Errorf("fetching SBOM of %s: %v", artifact.VersionedImage(), err)
+	}
+	sbomManifest := ocispec.Manifest{}
+	if err = json.Unmarshal(manifest, &sbomManifest); err != nil {
+		return digest, nil, fmt.Errorf("parsing SBOM manifest of %s: %v", artifact.VersionedImage(), err)
+	}
+	if len(sbomManifest.Layers) == 0 {
+		return digest, nil, fmt.Errorf("SBOM manifest of %s doesn't have any layer", artifact.VersionedImage())
+	}
+	content, err := client.FetchBlob(ctx, repo, sbomManifest.Layers[0])
+	if err != nil {
+		return digest, nil, fmt.Errorf("fetching SBOM of %s: %v", artifact.VersionedImage(), err)
+	}
+
+	return digest, content, nil
+}
@@ -0,0 +1,48 @@
+package registry_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aws/eks-anywhere/pkg/registry"
+)
+
+func TestFetchSBOM(t *testing.T) {
+	tt := newSignatureTest(t)
+	tt.attachSBOM()
+	artifact := registry.NewArtifact("public.ecr.aws", "eks-anywhere/kube-vip", "v0.5.5", "")
+
+	digest, content, err := registry.FetchSBOM(tt.ctx, tt.client, artifact)
+	assert.NoError(t, err)
+	assert.Equal(t, tt.desc.Digest.String(), digest)
+	assert.Equal(t, `{"spdxVersion":"SPDX-2.3"}`, string(content))
+}
+
+func TestFetchSBOMByDigest(t *testing.T) {
+	tt := newSignatureTest(t)
+	tt.attachSBOM()
+	artifact := registry.NewArtifact("public.ecr.aws", "eks-anywhere/kube-vip", "", tt.desc.Digest.String())
+
+	digest, content, err := registry.FetchSBOM(tt.ctx, tt.client, artifact)
+	assert.NoError(t, err)
+	assert.Equal(t, tt.desc.Digest.String(), digest)
+	assert.Equal(t, `{"spdxVersion":"SPDX-2.3"}`, string(content))
+}
+
+func TestFetchSBOMNotFound(t *testing.T) {
+	tt := newSignatureTest(t)
+
+	digest, _, err := registry.FetchSBOM(tt.ctx, tt.client, tt.artifact)
+	assert.True(t, errors.Is(err, registry.ErrSBOMNotFound))
+	assert.Equal(t, tt.desc.Digest.String(), digest)
+}
+
+func TestFetchSBOMUnknownImage(t *testing.T) {
+	tt := newSignatureTest(t)
+	artifact := registry.NewArtifact("public.ecr.aws", "eks-anywhere/kube-vip", "v0.0.0", "")
+
+	_, _, err := registry.FetchSBOM(tt.ctx, tt.client, artifact)
+	assert.ErrorContains(t, err, "resolving public.ecr.aws/eks-anywhere/kube-vip:v0.0.0")
+}
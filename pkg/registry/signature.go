@@ -0,0 +1,499 @@
+package registry
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	_ "crypto/sha512" // registers SHA384 and SHA512 for JWS signatures
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"oras.land/oras-go/v2/errdef"
+	orasregistry "oras.land/oras-go/v2/registry"
+)
+
+const (
+	cosignSignatureAnnotation = "dev.cosignproject.cosign/signature"
+	cosignSignatureSuffix     = ".sig"
+	cosignSBOMSuffix          = ".sbom"
+
+	notationSignatureArtifactType = "application/vnd.cncf.notary.signature"
+	notationJWSMediaType          = "application/jose+json"
+)
+
+// ErrSBOMNotFound is returned when an artifact doesn't have an SBOM attached.
+var ErrSBOMNotFound = errors.New("no SBOM found")
+
+// sbomArtifactTypes are the artifact types of the SBOMs attached to an artifact as referrers.
+var sbomArtifactTypes = []string{
+	"application/spdx+json",
+	"application/vnd.cyclonedx+json",
+	"application/vnd.syft+json",
+}
+
+// SignatureVerifier verifies an artifact is signed with a public key, with cosign or notation.
+// Cosign signatures are read from the sha256-<digest>.sig tag of the artifact repository and
+// notation signatures from the referrers of the artifact.
+type SignatureVerifier struct {
+	publicKey   crypto.PublicKey
+	requireSBOM bool
+}
+
+// SignatureVerifierOpt allows to customize a SignatureVerifier.
+type SignatureVerifierOpt func(*SignatureVerifier)
+
+// WithRequiredSBOM makes the verifier also require an SBOM attached to every artifact,
+// signed with the same public key.
+func WithRequiredSBOM() SignatureVerifierOpt {
+	return func(v *SignatureVerifier) {
+		v.requireSBOM = true
+	}
+}
+
+// NewSignatureVerifier constructs a SignatureVerifier for the given public key.
+func NewSignatureVerifier(publicKey crypto.PublicKey, opts ...SignatureVerifierOpt) *SignatureVerifier {
+	v := &SignatureVerifier{
+		publicKey: publicKey,
+	}
+	for _, opt := range opts {
+		opt(v)
+	}
+	return v
+}
+
+// LoadPublicKey reads a PEM encoded public key or certificate from a file.
+func LoadPublicKey(keyFile string) (crypto.PublicKey, error) {
+	content, err := os.ReadFile(filepath.Clean(keyFile))
+	if err != nil {
+		return nil, fmt.Errorf("reading public key file %s: %v", keyFile, err)
+	}
+	return ParsePublicKey(content)
+}
+
+// ParsePublicKey parses a PEM encoded public key or certificate. ECDSA, RSA and ed25519 keys are supported.
+func ParsePublicKey(content []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(content)
+	if block == nil {
+		return nil, errors.New("parsing public key: no PEM data found")
+	}
+
+	var key crypto.PublicKey
+	switch block.Type {
+	case "PUBLIC KEY":
+		k, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("parsing public key: %v", err)
+		}
+		key = k
+	case "CERTIFICATE":
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("parsing public key certificate: %v", err)
+		}
+		key = cert.PublicKey
+	default:
+		return nil, fmt.Errorf("parsing public key: unsupported PEM type %s", block.Type)
+	}
+
+	switch key.(type) {
+	case *ecdsa.PublicKey, *rsa.PublicKey, ed25519.PublicKey:
+		return key, nil
+	default:
+		return nil, fmt.Errorf("parsing public key: unsupported key type %T", key)
+	}
+}
+
+// Verify checks the artifact in the storage client is signed with the public key of the verifier
+// and, when the artifact has a digest, that the storage serves that same manifest, so retagged or
+// tampered artifacts are refused. It returns the signatures and SBOMs attached to the artifact, so
+// they can be copied along with it.
+func (v *SignatureVerifier) Verify(ctx context.Context, client StorageClient, artifact Artifact) ([]Artifact, error) {
+	repo, err := client.GetStorage(ctx, artifact)
+	if err != nil {
+		return nil, fmt.Errorf("repository source: %v", err)
+	}
+
+	ref := artifact
+	if ref.Tag != "" {
+		ref.Digest = ""
+	}
+	desc, err := client.Resolve(ctx, repo, ref.VersionedImage())
+	if err != nil {
+		return nil, fmt.Errorf("resolving %s: %v", artifact.VersionedImage(), err)
+	}
+	if artifact.Digest != "" && desc.Digest.String() != artifact.Digest {
+		return nil, fmt.Errorf("artifact %s has digest %s instead of %s", artifact.VersionedImage(), desc.Digest, artifact.Digest)
+	}
+
+	attached, err := v.verifySignature(ctx, client, repo, artifact, desc)
+	if err != nil {
+		return nil, fmt.Errorf("verifying signature of %s: %v", artifact.VersionedImage(), err)
+	}
+
+	if v.requireSBOM {
+		sbom, err := v.verifySBOM(ctx, client, repo, artifact, desc)
+		if err != nil {
+			return nil, fmt.Errorf("verifying SBOM of %s: %v", artifact.VersionedImage(), err)
+		}
+		attached = append(attached, sbom...)
+	}
+
+	return attached, nil
+}
+
+// verifySignature looks for a valid cosign signature first and then for a valid notation one.
+func (v *SignatureVerifier) verifySignature(ctx context.Context, client StorageClient, repo orasregistry.Repository, artifact Artifact, desc ocispec.Descriptor) ([]Artifact, error) {
+	var errs []error
+
+	sig, err := v.verifyCosign(ctx, client, repo, artifact, desc)
+	if err == nil {
+		return []Artifact{sig}, nil
+	}
+	errs = append(errs, err)
+
+	sigs, err := v.verifyNotation(ctx, client, repo, artifact, desc)
+	if err == nil {
+		return sigs, nil
+	}
+	errs = append(errs, err)
+
+	return nil, utilerrors.NewAggregate(errs)
+}
+
+func attachedTag(desc ocispec.Descriptor, suffix string) string {
+	return strings.Replace(desc.Digest.String(), ":", "-", 1) + suffix
+}
+
+// cosignPayload is the part of the simple signing payload of cosign that identifies the signed image.
+type cosignPayload struct {
+	Critical struct {
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+	} `json:"critical"`
+}
+
+func (v *SignatureVerifier) verifyCosign(ctx context.Context, client StorageClient, repo orasregistry.Repository, artifact Artifact, desc ocispec.Descriptor) (Artifact, error) {
+	sigArtifact := NewArtifact(artifact.Registry, artifact.Repository, attachedTag(desc, cosignSignatureSuffix), "")
+	_, data, err := client.FetchBytes(ctx, repo, sigArtifact)
+	if errors.Is(err, errdef.ErrNotFound) {
+		return Artifact{}, errors.New("no cosign signature found")
+	}
+	if err != nil {
+		return Artifact{}, fmt.Errorf("fetching cosign signature: %v", err)
+	}
+
+	manifest := ocispec.Manifest{}
+	if err = json.Unmarshal(data, &manifest); err != nil {
+		return Artifact{}, fmt.Errorf("parsing cosign signature manifest: %v", err)
+	}
+
+	for _, layer := range manifest.Layers {
+		encoded, ok := layer.Annotations[cosignSignatureAnnotation]
+		if !ok {
+			continue
+		}
+		signature, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			continue
+		}
+		payload, err := client.FetchBlob(ctx, repo, layer)
+		if err != nil {
+			return Artifact{}, fmt.Errorf("fetching cosign signature payload: %v", err)
+		}
+		if err = verifyPayloadSignature(v.publicKey, payload, signature); err != nil {
+			continue
+		}
+
+		p := cosignPayload{}
+		if err = json.Unmarshal(payload, &p); err != nil {
+			return Artifact{}, fmt.Errorf("parsing cosign signature payload: %v", err)
+		}
+		if p.Critical.Image.DockerManifestDigest != desc.Digest.String() {
+			return Artifact{}, fmt.Errorf("cosign signature is for digest %s instead of %s", p.Critical.Image.DockerManifestDigest, desc.Digest)
+		}
+
+		return sigArtifact, nil
+	}
+
+	return Artifact{}, errors.New("no cosign signature matches the public key")
+}
+
+// jws is a JWS in JSON serialization, the envelope notation uses for signatures.
+type jws struct {
+	Payload   string `json:"payload"`
+	Protected string `json:"protected"`
+	Signature string `json:"signature"`
+}
+
+type jwsHeader struct {
+	Algorithm string `json:"alg"`
+}
+
+// notationPayload is the payload of a notation signature.
+type notationPayload struct {
+	TargetArtifact ocispec.Descriptor `json:"targetArtifact"`
+}
+
+func (v *SignatureVerifier) verifyNotation(ctx context.Context, client StorageClient, repo orasregistry.Repository, artifact Artifact, desc ocispec.Descriptor) ([]Artifact, error) {
+	referrers, err := listReferrers(ctx, repo, desc, notationSignatureArtifactType)
+	if err != nil {
+		return nil, fmt.Errorf("listing notation signatures: %v", err)
+	}
+	if len(referrers) == 0 {
+		return nil, errors.New("no notation signature found")
+	}
+
+	for _, r := range referrers {
+		sigArtifact := NewArtifact(artifact.Registry, artifact.Repository, "", r.Digest.String())
+		_, data, err := client.FetchBytes(ctx, repo, sigArtifact)
+		if err != nil {
+			return nil, fmt.Errorf("fetching notation signature: %v", err)
+		}
+		manifest := ocispec.Manifest{}
+		if err = json.Unmarshal(data, &manifest); err != nil {
+			return nil, fmt.Errorf("parsing notation signature manifest: %v", err)
+		}
+		if len(manifest.Layers) == 0 || manifest.Layers[0].MediaType != notationJWSMediaType {
+			continue
+		}
+		envelope, err := client.FetchBlob(ctx, repo, manifest.Layers[0])
+		if err != nil {
+			return nil, fmt.Errorf("fetching notation signature envelope: %v", err)
+		}
+
+		target, err := verifyJWS(v.publicKey, envelope)
+		if err != nil {
+			continue
+		}
+		if target.Digest != desc.Digest {
+			return nil, fmt.Errorf("notation signature is for digest %s instead of %s", target.Digest, desc.Digest)
+		}
+
+		return []Artifact{sigArtifact}, nil
+	}
+
+	return nil, errors.New("no notation signature matches the public key")
+}
+
+// verifyJWS verifies a notation JWS envelope and returns the artifact it signs.
+func verifyJWS(publicKey crypto.PublicKey, envelope []byte) (*ocispec.Descriptor, error) {
+	e := jws{}
+	if err := json.Unmarshal(envelope, &e); err != nil {
+		return nil, fmt.Errorf("parsing JWS envelope: %v", err)
+	}
+	rawHeader, err := base64.RawURLEncoding.DecodeString(e.Protected)
+	if err != nil {
+		return nil, fmt.Errorf("decoding JWS header: %v", err)
+	}
+	header := jwsHeader{}
+	if err = json.Unmarshal(rawHeader, &header); err != nil {
+		return nil, fmt.Errorf("parsing JWS header: %v", err)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(e.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("decoding JWS signature: %v", err)
+	}
+
+	signed := []byte(e.Protected + "." + e.Payload)
+	if err = verifyJWSSignature(publicKey, header.Algorithm, signed, signature); err != nil {
+		return nil, err
+	}
+
+	rawPayload, err := base64.RawURLEncoding.DecodeString(e.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("decoding JWS payload: %v", err)
+	}
+	payload := notationPayload{}
+	if err = json.Unmarshal(rawPayload, &payload); err != nil {
+		return nil, fmt.Errorf("parsing JWS payload: %v", err)
+	}
+
+	return &payload.TargetArtifact, nil
+}
+
+func verifyJWSSignature(publicKey crypto.PublicKey, algorithm string, signed, signature []byte) error {
+	var hash crypto.Hash
+	switch {
+	case strings.HasSuffix(algorithm, "256"):
+		hash = crypto.SHA256
+	case strings.HasSuffix(algorithm, "384"):
+		hash = crypto.SHA384
+	case strings.HasSuffix(algorithm, "512"):
+		hash = crypto.SHA512
+	default:
+		return fmt.Errorf("unsupported JWS algorithm %s", algorithm)
+	}
+	h := hash.New()
+	h.Write(signed)
+	digest := h.Sum(nil)
+
+	switch key := publicKey.(type) {
+	case *rsa.PublicKey:
+		if !strings.HasPrefix(algorithm, "PS") {
+			return fmt.Errorf("JWS algorithm %s doesn't match an RSA key", algorithm)
+		}
+		return rsa.VerifyPSS(key, hash, digest, signature, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+	case *ecdsa.PublicKey:
+		if !strings.HasPrefix(algorithm, "ES") || len(signature)%2 != 0 {
+			return fmt.Errorf("JWS algorithm %s doesn't match an ECDSA key", algorithm)
+		}
+		r := new(big.Int).SetBytes(signature[:len(signature)/2])
+		s := new(big.Int).SetBytes(signature[len(signature)/2:])
+		if !ecdsa.Verify(key, digest, r, s) {
+			return errors.New("invalid JWS signature")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported key type %T for JWS", publicKey)
+	}
+}
+
+// verifyPayloadSignature verifies a signature of the SHA256 hash of a payload, the way cosign signs.
+// ed25519 keys sign the payload itself.
+func verifyPayloadSignature(publicKey crypto.PublicKey, payload, signature []byte) error {
+	if key, ok := publicKey.(ed25519.PublicKey); ok {
+		if !ed25519.Verify(key, payload, signature) {
+			return errors.New("invalid signature")
+		}
+		return nil
+	}
+
+	digest := sha256.Sum256(payload)
+	switch key := publicKey.(type) {
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(key, digest[:], signature) {
+			return errors.New("invalid signature")
+		}
+		return nil
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature)
+	default:
+		return fmt.Errorf("unsupported key type %T", publicKey)
+	}
+}
+
+// verifySBOM checks the artifact has an SBOM attached with cosign or as a referrer, signed with the
+// public key of the verifier.
+func (v *SignatureVerifier) verifySBOM(ctx context.Context, client StorageClient, repo orasregistry.Repository, artifact Artifact, desc ocispec.Descriptor) ([]Artifact, error) {
+	sbomArtifacts, sbom, err := attachedSBOMs(ctx, client, repo, artifact, desc)
+	if err != nil {
+		return nil, err
+	}
+
+	var errs []error
+	for i, d := range sbom {
+		sigs, err := v.verifySignature(ctx, client, repo, sbomArtifacts[i], d)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		return append([]Artifact{sbomArtifacts[i]}, sigs...), nil
+	}
+
+	return nil, fmt.Errorf("no SBOM signed with the public key: %v", utilerrors.NewAggregate(errs))
+}
+
+// attachedSBOMs returns the SBOMs attached to an artifact with cosign or, if there isn't one,
+// as referrers, with their descriptors. The error is ErrSBOMNotFound if there isn't any.
+func attachedSBOMs(ctx context.Context, client StorageClient, repo orasregistry.Repository, artifact Artifact, desc ocispec.Descriptor) ([]Artifact, []ocispec.Descriptor, error) {
+	sbomArtifact := NewArtifact(artifact.Registry, artifact.Repository, attachedTag(desc, cosignSBOMSuffix), "")
+	sbomDesc, err := client.Resolve(ctx, repo, sbomArtifact.VersionedImage())
+	if err == nil {
+		return []Artifact{sbomArtifact}, []ocispec.Descriptor{sbomDesc}, nil
+	}
+	if !errors.Is(err, errdef.ErrNotFound) {
+		return nil, nil, fmt.Errorf("resolving SBOM: %v", err)
+	}
+
+	var artifacts []Artifact
+	var sbom []ocispec.Descriptor
+	for _, artifactType := range sbomArtifactTypes {
+		referrers, err := listReferrers(ctx, repo, desc, artifactType)
+		if err != nil {
+			return nil, nil, fmt.Errorf("listing SBOMs: %v", err)
+		}
+		for _, r := range referrers {
+			artifacts = append(artifacts, NewArtifact(artifact.Registry, artifact.Repository, "", r.Digest.String()))
+			sbom = append(sbom, r)
+		}
+	}
+	if len(sbom) == 0 {
+		return nil, nil, ErrSBOMNotFound
+	}
+
+	return artifacts, sbom, nil
+}
+
+// listReferrers returns the referrers of a manifest with the given artifact type. Repositories
+// without support for referrers, like OCI layouts, don't have any.
+func listReferrers(ctx context.Context, repo orasregistry.Repository, desc ocispec.Descriptor, artifactType string) ([]ocispec.Descriptor, error) {
+	lister, ok := repo.(orasregistry.ReferrerLister)
+	if !ok {
+		return nil, nil
+	}
+
+	var referrers []ocispec.Descriptor
+	err := lister.Referrers(ctx, desc, artifactType, func(r []ocispec.Descriptor) error {
+		referrers = append(referrers, r...)
+		return nil
+	})
+	if errors.Is(err, errdef.ErrUnsupported) || errors.Is(err, errdef.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return referrers, nil
+}
+
+// ArtifactsVerifier verifies the signatures of artifacts in the registries they are read from.
+type ArtifactsVerifier struct {
+	verifier *SignatureVerifier
+	sources  SourceClientFunc
+}
+
+// NewArtifactsVerifier constructs an ArtifactsVerifier that reads every artifact from the
+// storage client returned by sources.
+func NewArtifactsVerifier(verifier *SignatureVerifier, sources SourceClientFunc) *ArtifactsVerifier {
+	return &ArtifactsVerifier{
+		verifier: verifier,
+		sources:  sources,
+	}
+}
+
+// VerifyArtifacts verifies the signatures of all the artifacts and returns the errors of all
+// the artifacts that fail.
+func (a *ArtifactsVerifier) VerifyArtifacts(ctx context.Context, artifacts []Artifact) error {
+	var errs []error
+	for _, artifact := range artifacts {
+		if err := a.VerifyArtifact(ctx, artifact); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// VerifyArtifact verifies the signature of an artifact.
+func (a *ArtifactsVerifier) VerifyArtifact(ctx context.Context, artifact Artifact) error {
+	client, err := a.sources(artifact)
+	if err != nil {
+		return err
+	}
+	_, err = a.verifier.Verify(ctx, client, artifact)
+	return err
+}
@@ -0,0 +1,319 @@
+package registry_test
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/errdef"
+	orasregistry "oras.land/oras-go/v2/registry"
+
+	"github.com/aws/eks-anywhere/pkg/registry"
+	"github.com/aws/eks-anywhere/pkg/registry/mocks"
+)
+
+type signatureTest struct {
+	t        *testing.T
+	ctx      context.Context
+	client   registry.StorageClient
+	repo     orasregistry.Repository
+	key      *ecdsa.PrivateKey
+	artifact registry.Artifact
+	desc     ocispec.Descriptor
+}
+
+// newSignatureTest creates an OCI layout with an image to sign.
+func newSignatureTest(t *testing.T) *signatureTest {
+	tt := &signatureTest{
+		t:        t,
+		ctx:      context.Background(),
+		client:   registry.NewOCILayout(registry.NewStorageContext("oci:"+t.TempDir(), nil, nil, false)),
+		artifact: registry.NewArtifact("public.ecr.aws", "eks-anywhere/kube-vip", "v0.5.5", ""),
+	}
+	assert.NoError(t, tt.client.Init())
+
+	var err error
+	tt.key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	tt.repo, err = tt.client.GetStorage(tt.ctx, tt.artifact)
+	assert.NoError(t, err)
+
+	layer := tt.pushBlob("application/vnd.oci.image.layer.v1.tar", []byte("kube-vip"))
+	tt.desc = tt.pushManifest("", []ocispec.Descriptor{layer}, tt.artifact.Tag)
+	tt.artifact.Digest = tt.desc.Digest.String()
+	return tt
+}
+
+func (tt *signatureTest) pushBlob(mediaType string, blob []byte) ocispec.Descriptor {
+	desc := content.NewDescriptorFromBytes(mediaType, blob)
+	if err := tt.repo.Push(tt.ctx, desc, bytes.NewReader(blob)); err != nil && !errors.Is(err, errdef.ErrAlreadyExists) {
+		tt.t.Fatal(err)
+	}
+	return desc
+}
+
+func (tt *signatureTest) pushManifest(artifactType string, layers []ocispec.Descriptor, tag string) ocispec.Descriptor {
+	config := tt.pushBlob(ocispec.MediaTypeImageConfig, []byte("{}"))
+	if artifactType != "" {
+		config.MediaType = artifactType
+	}
+	manifest, err := json.Marshal(ocispec.Manifest{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Config:    config,
+		Layers:    layers,
+	})
+	assert.NoError(tt.t, err)
+	manifest = append([]byte(`{"schemaVersion":2,`), manifest[1:]...)
+
+	desc, err := oras.TagBytes(tt.ctx, tt.repo, ocispec.MediaTypeImageManifest, manifest, tag)
+	assert.NoError(tt.t, err)
+	return desc
+}
+
+func (tt *signatureTest) publicKey(key *ecdsa.PrivateKey) crypto.PublicKey {
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	assert.NoError(tt.t, err)
+	publicKey, err := registry.ParsePublicKey(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	assert.NoError(tt.t, err)
+	return publicKey
+}
+
+// cosignSign attaches a cosign signature of signedDigest to the manifest with desc.
+func (tt *signatureTest) cosignSign(desc ocispec.Descriptor, signedDigest digest.Digest, key *ecdsa.PrivateKey) {
+	payload := []byte(fmt.Sprintf(`{"critical":{"identity":{"docker-reference":"public.ecr.aws/eks-anywhere/kube-vip"},"image":{"docker-manifest-digest":"%s"},"type":"cosign container image signature"},"optional":null}`, signedDigest))
+	hash := sha256.Sum256(payload)
+	signature, err := ecdsa.SignASN1(rand.Reader, key, hash[:])
+	assert.NoError(tt.t, err)
+
+	layer := tt.pushBlob("application/vnd.dev.cosign.simplesigning.v1+json", payload)
+	layer.Annotations = map[string]string{"dev.cosignproject.cosign/signature": base64.StdEncoding.EncodeToString(signature)}
+	tt.pushManifest("", []ocispec.Descriptor{layer}, strings.Replace(desc.Digest.String(), ":", "-", 1)+".sig")
+}
+
+func (tt *signatureTest) attachSBOM() ocispec.Descriptor {
+	layer := tt.pushBlob("text/spdx+json", []byte(`{"spdxVersion":"SPDX-2.3"}`))
+	return tt.pushManifest("", []ocispec.Descriptor{layer}, strings.Replace(tt.desc.Digest.String(), ":", "-", 1)+".sbom")
+}
+
+func TestSignatureVerifierCosign(t *testing.T) {
+	tt := newSignatureTest(t)
+	tt.cosignSign(tt.desc, tt.desc.Digest, tt.key)
+	verifier := registry.NewSignatureVerifier(tt.publicKey(tt.key))
+
+	attached, err := verifier.Verify(tt.ctx, tt.client, tt.artifact)
+	assert.NoError(t, err)
+	assert.Equal(t, []registry.Artifact{
+		registry.NewArtifact("public.ecr.aws", "eks-anywhere/kube-vip", strings.Replace(tt.artifact.Digest, ":", "-", 1)+".sig", ""),
+	}, attached)
+}
+
+func TestSignatureVerifierUnsigned(t *testing.T) {
+	tt := newSignatureTest(t)
+	verifier := registry.NewSignatureVerifier(tt.publicKey(tt.key))
+
+	_, err := verifier.Verify(tt.ctx, tt.client, tt.artifact)
+	assert.ErrorContains(t, err, "verifying signature of public.ecr.aws/eks-anywhere/kube-vip@"+tt.artifact.Digest)
+	assert.ErrorContains(t, err, "no cosign signature found")
+	assert.ErrorContains(t, err, "no notation signature found")
+}
+
+func TestSignatureVerifierOtherKey(t *testing.T) {
+	tt := newSignatureTest(t)
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	tt.cosignSign(tt.desc, tt.desc.Digest, otherKey)
+	verifier := registry.NewSignatureVerifier(tt.publicKey(tt.key))
+
+	_, err = verifier.Verify(tt.ctx, tt.client, tt.artifact)
+	assert.ErrorContains(t, err, "no cosign signature matches the public key")
+}
+
+func TestSignatureVerifierSignatureForOtherDigest(t *testing.T) {
+	tt := newSignatureTest(t)
+	tt.cosignSign(tt.desc, digest.FromString("another image"), tt.key)
+	verifier := registry.NewSignatureVerifier(tt.publicKey(tt.key))
+
+	_, err := verifier.Verify(tt.ctx, tt.client, tt.artifact)
+	assert.ErrorContains(t, err, "cosign signature is for digest "+digest.FromString("another image").String())
+}
+
+func TestSignatureVerifierTamperedTag(t *testing.T) {
+	tt := newSignatureTest(t)
+	tt.cosignSign(tt.desc, tt.desc.Digest, tt.key)
+	expected := tt.artifact
+	layer := tt.pushBlob("application/vnd.oci.image.layer.v1.tar", []byte("tampered kube-vip"))
+	tampered := tt.pushManifest("", []ocispec.Descriptor{layer}, tt.artifact.Tag)
+	verifier := registry.NewSignatureVerifier(tt.publicKey(tt.key))
+
+	_, err := verifier.Verify(tt.ctx, tt.client, expected)
+	assert.EqualError(t, err, fmt.Sprintf("artifact %s has digest %s instead of %s", expected.VersionedImage(), tampered.Digest, expected.Digest))
+}
+
+func TestSignatureVerifierRequiredSBOM(t *testing.T) {
+	tt := newSignatureTest(t)
+	tt.cosignSign(tt.desc, tt.desc.Digest, tt.key)
+	sbom := tt.attachSBOM()
+	tt.cosignSign(sbom, sbom.Digest, tt.key)
+	verifier := registry.NewSignatureVerifier(tt.publicKey(tt.key), registry.WithRequiredSBOM())
+
+	attached, err := verifier.Verify(tt.ctx, tt.client, tt.artifact)
+	assert.NoError(t, err)
+	tag := strings.Replace(tt.artifact.Digest, ":", "-", 1)
+	assert.Equal(t, []registry.Artifact{
+		registry.NewArtifact("public.ecr.aws", "eks-anywhere/kube-vip", tag+".sig", ""),
+		registry.NewArtifact("public.ecr.aws", "eks-anywhere/kube-vip", tag+".sbom", ""),
+		registry.NewArtifact("public.ecr.aws", "eks-anywhere/kube-vip", strings.Replace(sbom.Digest.String(), ":", "-", 1)+".sig", ""),
+	}, attached)
+}
+
+func TestSignatureVerifierMissingSBOM(t *testing.T) {
+	tt := newSignatureTest(t)
+	tt.cosignSign(tt.desc, tt.desc.Digest, tt.key)
+	verifier := registry.NewSignatureVerifier(tt.publicKey(tt.key), registry.WithRequiredSBOM())
+
+	_, err := verifier.Verify(tt.ctx, tt.client, tt.artifact)
+	assert.EqualError(t, err, "verifying SBOM of "+tt.artifact.VersionedImage()+": no SBOM found")
+}
+
+func TestSignatureVerifierUnsignedSBOM(t *testing.T) {
+	tt := newSignatureTest(t)
+	tt.cosignSign(tt.desc, tt.desc.Digest, tt.key)
+	tt.attachSBOM()
+	verifier := registry.NewSignatureVerifier(tt.publicKey(tt.key), registry.WithRequiredSBOM())
+
+	_, err := verifier.Verify(tt.ctx, tt.client, tt.artifact)
+	assert.ErrorContains(t, err, "no SBOM signed with the public key")
+}
+
+// notationEnvelope creates a JWS envelope signing desc with the ES256 algorithm.
+func notationEnvelope(t *testing.T, key *ecdsa.PrivateKey, desc ocispec.Descriptor) []byte {
+	encode := base64.RawURLEncoding.EncodeToString
+	protected := encode([]byte(`{"alg":"ES256","cty":"application/vnd.cncf.notary.payload.v1+json"}`))
+	payloadJSON, err := json.Marshal(map[string]ocispec.Descriptor{"targetArtifact": desc})
+	assert.NoError(t, err)
+	payload := encode(payloadJSON)
+
+	hash := sha256.Sum256([]byte(protected + "." + payload))
+	r, s, err := ecdsa.Sign(rand.Reader, key, hash[:])
+	assert.NoError(t, err)
+	signature := make([]byte, 64)
+	r.FillBytes(signature[:32])
+	s.FillBytes(signature[32:])
+
+	envelope, err := json.Marshal(map[string]string{"payload": payload, "protected": protected, "signature": encode(signature)})
+	assert.NoError(t, err)
+	return envelope
+}
+
+func TestSignatureVerifierNotation(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	client := mocks.NewMockStorageClient(ctrl)
+	repo := mocks.NewMockRepository(ctrl)
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	assert.NoError(t, err)
+	keyFile := filepath.Join(t.TempDir(), "key.pub")
+	assert.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o600))
+	publicKey, err := registry.LoadPublicKey(keyFile)
+	assert.NoError(t, err)
+
+	artifact := registry.NewArtifact("public.ecr.aws", "eks-anywhere/kube-vip", "v0.5.5", "")
+	desc := ocispec.Descriptor{MediaType: ocispec.MediaTypeImageManifest, Digest: digest.FromString("kube-vip"), Size: 8}
+	sigDesc := ocispec.Descriptor{Digest: digest.FromString("signature")}
+	envelope := ocispec.Descriptor{MediaType: "application/jose+json", Digest: digest.FromString("envelope")}
+	sigManifest, err := json.Marshal(ocispec.Manifest{Layers: []ocispec.Descriptor{envelope}})
+	assert.NoError(t, err)
+	sigArtifact := registry.NewArtifact("public.ecr.aws", "eks-anywhere/kube-vip", "", sigDesc.Digest.String())
+
+	client.EXPECT().GetStorage(ctx, artifact).Return(repo, nil)
+	client.EXPECT().Resolve(ctx, repo, "public.ecr.aws/eks-anywhere/kube-vip:v0.5.5").Return(desc, nil)
+	client.EXPECT().FetchBytes(ctx, repo, gomock.Any()).Return(ocispec.Descriptor{}, nil, errdef.ErrNotFound)
+	repo.EXPECT().Referrers(ctx, desc, "application/vnd.cncf.notary.signature", gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ ocispec.Descriptor, _ string, fn func([]ocispec.Descriptor) error) error {
+			return fn([]ocispec.Descriptor{sigDesc})
+		},
+	)
+	client.EXPECT().FetchBytes(ctx, repo, sigArtifact).Return(sigDesc, sigManifest, nil)
+	client.EXPECT().FetchBlob(ctx, repo, envelope).Return(notationEnvelope(t, key, desc), nil)
+
+	attached, err := registry.NewSignatureVerifier(publicKey).Verify(ctx, client, artifact)
+	assert.NoError(t, err)
+	assert.Equal(t, []registry.Artifact{sigArtifact}, attached)
+}
+
+func TestParsePublicKeyInvalid(t *testing.T) {
+	_, err := registry.ParsePublicKey([]byte("not a key"))
+	assert.EqualError(t, err, "parsing public key: no PEM data found")
+
+	_, err = registry.ParsePublicKey(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte("key")}))
+	assert.EqualError(t, err, "parsing public key: unsupported PEM type PRIVATE KEY")
+}
+
+func TestArtifactsVerifierVerifyArtifacts(t *testing.T) {
+	tt := newSignatureTest(t)
+	tt.cosignSign(tt.desc, tt.desc.Digest, tt.key)
+	unsigned := registry.NewArtifact("public.ecr.aws", "eks-anywhere/unsigned", "v1", "")
+	repo, err := tt.client.GetStorage(tt.ctx, unsigned)
+	assert.NoError(t, err)
+	tt.repo = repo
+	tt.pushManifest("", nil, "v1")
+	verifier := registry.NewSignatureVerifier(tt.publicKey(tt.key))
+	sources := func(registry.Artifact) (registry.StorageClient, error) { return tt.client, nil }
+
+	err = registry.NewArtifactsVerifier(verifier, sources).VerifyArtifacts(tt.ctx, []registry.Artifact{tt.artifact, unsigned})
+	assert.ErrorContains(t, err, "verifying signature of public.ecr.aws/eks-anywhere/unsigned:v1")
+	assert.NotContains(t, err.Error(), "kube-vip")
+
+	err = registry.NewArtifactsVerifier(verifier, func(registry.Artifact) (registry.StorageClient, error) {
+		return nil, errors.New("no registry")
+	}).VerifyArtifacts(tt.ctx, []registry.Artifact{tt.artifact})
+	assert.EqualError(t, err, "no registry")
+}
+
+func TestMirrorWithVerifier(t *testing.T) {
+	tt := newSignatureTest(t)
+	tt.cosignSign(tt.desc, tt.desc.Digest, tt.key)
+	dst := registry.NewOCILayout(registry.NewStorageContext("oci:"+t.TempDir(), nil, nil, false))
+	assert.NoError(t, dst.Init())
+	sources := func(registry.Artifact) (registry.StorageClient, error) { return tt.client, nil }
+	mirror := registry.NewMirror(dst, sources, registry.WithVerifier(registry.NewSignatureVerifier(tt.publicKey(tt.key))))
+
+	assert.NoError(t, mirror.Run(tt.ctx, []registry.Artifact{tt.artifact}))
+
+	// The signature is copied too, so the destination can be verified.
+	_, err := registry.NewSignatureVerifier(tt.publicKey(tt.key)).Verify(tt.ctx, dst, tt.artifact)
+	assert.NoError(t, err)
+}
+
+func TestMirrorWithVerifierRefusesUnsigned(t *testing.T) {
+	tt := newSignatureTest(t)
+	dst := mocks.NewMockStorageClient(gomock.NewController(t))
+	dst.EXPECT().Destination(tt.artifact).Return("registry.example.com/eks-anywhere/kube-vip:v0.5.5").AnyTimes()
+	sources := func(registry.Artifact) (registry.StorageClient, error) { return tt.client, nil }
+	mirror := registry.NewMirror(dst, sources, registry.WithVerifier(registry.NewSignatureVerifier(tt.publicKey(tt.key))))
+
+	err := mirror.Run(tt.ctx, []registry.Artifact{tt.artifact})
+	assert.ErrorContains(t, err, "refusing "+tt.artifact.VersionedImage())
+}
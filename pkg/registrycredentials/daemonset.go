@@ -0,0 +1,264 @@
+package registrycredentials
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/registrymirror"
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
+)
+
+const (
+	// Name is the name of the DaemonSet that updates the registry credentials in the nodes
+	// and of the Secret it reads them from, both in the workload cluster.
+	Name = "eksa-registry-credentials"
+	// Namespace is the namespace of the registry credentials DaemonSet and Secret.
+	Namespace = constants.EksaSystemNamespace
+	// ChecksumAnnotation is set in the DaemonSet pods with a checksum of the credentials they apply,
+	// so a new rollout is triggered every time the credentials change.
+	ChecksumAnnotation = "anywhere.eks.amazonaws.com/registry-credentials-checksum"
+
+	appLabel              = "anywhere.eks.amazonaws.com/registry-credentials"
+	bottlerocketContainer = "bottlerocket-credentials-updater"
+	updaterContainerName  = "credentials-updater"
+	usernameKey           = "username"
+	passwordKey           = "password"
+	settingsKey           = "bottlerocket-settings"
+	hostRunVolume         = "host-run"
+	hostRunPath           = "/run"
+	hostRunMountPath      = "/host/run"
+	bottlerocketAPISocket = hostRunMountPath + "/api.sock"
+)
+
+// Objects returns the Secret with the registry mirror credentials and the DaemonSet that
+// pushes them to every node of the cluster, without rolling out the machines.
+// On Bottlerocket, which has no shell in the host, bottlerocketSettings are set with apiclient from the control
+// host container through the API socket of the node. They must be the complete container registry settings,
+// since apiclient replaces the credentials list as a whole. On other OSes, the containerd CRI registry auth
+// config is rewritten and containerd restarted, and the authorization header of the mirror hosts in the
+// containerd hosts directory is updated. Each init container skips the nodes of the other kind.
+func Objects(spec *cluster.Spec, username, password, bottlerocketSettings string) ([]client.Object, error) {
+	mirror := registrymirror.FromCluster(spec.Cluster)
+	if mirror == nil {
+		return nil, fmt.Errorf("cluster %s doesn't have a registry mirror configuration", spec.Cluster.Name)
+	}
+
+	return []client.Object{
+		secret(username, password, bottlerocketSettings),
+		daemonSet(spec, mirror.BaseRegistry, checksum(username, password, bottlerocketSettings)),
+	}, nil
+}
+
+func secret(username, password, bottlerocketSettings string) *corev1.Secret {
+	return &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       constants.SecretKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      Name,
+			Namespace: Namespace,
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			usernameKey: []byte(username),
+			passwordKey: []byte(password),
+			settingsKey: []byte(bottlerocketSettings),
+		},
+	}
+}
+
+func daemonSet(spec *cluster.Spec, mirrorBase, credentialsChecksum string) *appsv1.DaemonSet {
+	hostContainers := spec.VersionsBundle.BottleRocketHostContainers
+	labels := map[string]string{appLabel: spec.Cluster.Name}
+	maxUnavailable := intstr.FromString("25%")
+	env := []corev1.EnvVar{
+		{Name: "REGISTRY_MIRROR", Value: mirrorBase},
+		secretEnvVar("REGISTRY_USERNAME", usernameKey),
+		secretEnvVar("REGISTRY_PASSWORD", passwordKey),
+		{Name: "API_SOCKET", Value: bottlerocketAPISocket},
+	}
+	hostRunMount := []corev1.VolumeMount{{Name: hostRunVolume, MountPath: hostRunMountPath}}
+	hostPathDirectory := corev1.HostPathDirectory
+
+	return &appsv1.DaemonSet{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: appsv1.SchemeGroupVersion.String(),
+			Kind:       "DaemonSet",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      Name,
+			Namespace: Namespace,
+			Labels:    labels,
+		},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			UpdateStrategy: appsv1.DaemonSetUpdateStrategy{
+				Type: appsv1.RollingUpdateDaemonSetStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateDaemonSet{
+					MaxUnavailable: &maxUnavailable,
+				},
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+					Annotations: map[string]string{
+						ChecksumAnnotation: credentialsChecksum,
+					},
+				},
+				Spec: corev1.PodSpec{
+					HostPID: true,
+					Tolerations: []corev1.Toleration{
+						{
+							Operator: corev1.TolerationOpExists,
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name: hostRunVolume,
+							VolumeSource: corev1.VolumeSource{
+								HostPath: &corev1.HostPathVolumeSource{
+									Path: hostRunPath,
+									Type: &hostPathDirectory,
+								},
+							},
+						},
+					},
+					InitContainers: []corev1.Container{
+						{
+							Name:    bottlerocketContainer,
+							Image:   hostContainers.Control.VersionedImage(),
+							Command: []string{"sh", "-c", bottlerocketScript},
+							Env: []corev1.EnvVar{
+								secretEnvVar("SETTINGS", settingsKey),
+								{Name: "API_SOCKET", Value: bottlerocketAPISocket},
+							},
+							VolumeMounts: hostRunMount,
+							SecurityContext: &corev1.SecurityContext{
+								Privileged: ptr.Bool(true),
+							},
+						},
+						{
+							Name:  updaterContainerName,
+							Image: hostContainers.Admin.VersionedImage(),
+							// The environment is kept by nsenter, so the update script can read the credentials
+							// while running in the namespaces of the node's init process.
+							Command:      []string{"sh", "-c", nsenterScript, "sh", updateScript},
+							Env:          env,
+							VolumeMounts: hostRunMount,
+							SecurityContext: &corev1.SecurityContext{
+								Privileged: ptr.Bool(true),
+							},
+						},
+					},
+					Containers: []corev1.Container{
+						{
+							Name:  "pause",
+							Image: spec.VersionsBundle.KubeDistro.Pause.VersionedImage(),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func secretEnvVar(name, key string) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: Name},
+				Key:                  key,
+			},
+		},
+	}
+}
+
+func checksum(username, password, bottlerocketSettings string) string {
+	sum := sha256.Sum256([]byte(username + ":" + password + "\n" + bottlerocketSettings))
+	return hex.EncodeToString(sum[:])
+}
+
+// bottlerocketScript applies the container registry settings in SETTINGS with the settings API of the node,
+// if the node is a Bottlerocket one.
+const bottlerocketScript = `set -eu
+if [ ! -S "$API_SOCKET" ]; then
+  exit 0
+fi
+apiclient --socket-path "$API_SOCKET" set --json "$SETTINGS"
+`
+
+// nsenterScript runs the update script passed as first argument in the namespaces of the node's init process,
+// unless the node is a Bottlerocket one.
+const nsenterScript = `set -eu
+if [ -S "$API_SOCKET" ]; then
+  exit 0
+fi
+exec nsenter --target 1 --mount --uts --ipc --net sh -c "$1"
+`
+
+// updateScript applies the credentials in REGISTRY_USERNAME and REGISTRY_PASSWORD for the
+// registry mirror in REGISTRY_MIRROR in the containerd config of the node. Files are only rewritten,
+// and containerd restarted, when their content changes.
+const updateScript = `set -eu
+escape() { printf '%s' "$1" | sed -e 's/\\/\\\\/g' -e 's/"/\\"/g'; }
+export ESCAPED_USERNAME="$(escape "$REGISTRY_USERNAME")"
+export ESCAPED_PASSWORD="$(escape "$REGISTRY_PASSWORD")"
+
+export AUTH_SECTION="[plugins.\"io.containerd.grpc.v1.cri\".registry.configs.\"${REGISTRY_MIRROR}\".auth]"
+export HOST_PREFIX="[host.\"https://${REGISTRY_MIRROR}"
+export AUTHORIZATION="Basic $(printf '%s:%s' "$REGISTRY_USERNAME" "$REGISTRY_PASSWORD" | base64 -w0)"
+
+# update FILE rewrites the credentials in the registry mirror auth section of a containerd config
+# and the authorization header of the registry mirror hosts in a hosts.toml file.
+# It returns 1 if the file didn't change.
+update() {
+  awk '
+    function indent(line) { return substr(line, 1, match(line, /[^ \t]/) - 1) }
+    {
+      t = $0
+      sub(/^[ \t]+/, "", t)
+      if (t ~ /^\[/) {
+        auth = t == ENVIRON["AUTH_SECTION"]
+        header = index(t, ENVIRON["HOST_PREFIX"]) == 1 && t ~ /\.header\]$/ && substr(t, length(ENVIRON["HOST_PREFIX"]) + 1, 1) ~ /[\/"]/
+      }
+      if (auth && t ~ /^username *=/) $0 = indent($0) "username = \"" ENVIRON["ESCAPED_USERNAME"] "\""
+      if (auth && t ~ /^password *=/) $0 = indent($0) "password = \"" ENVIRON["ESCAPED_PASSWORD"] "\""
+      if (header && t ~ /^authorization *=/) $0 = indent($0) "authorization = \"" ENVIRON["AUTHORIZATION"] "\""
+      print
+    }' "$1" > "$1.eksa-new"
+  if cmp -s "$1" "$1.eksa-new"; then
+    rm -f "$1.eksa-new"
+    return 1
+  fi
+  cat "$1.eksa-new" > "$1"
+  rm -f "$1.eksa-new"
+}
+
+restart=false
+for f in /etc/containerd/config.toml /etc/containerd/config_append.toml; do
+  if [ -f "$f" ] && update "$f"; then
+    restart=true
+  fi
+done
+
+for f in /etc/containerd/certs.d/*/hosts.toml; do
+  if [ -f "$f" ]; then
+    update "$f" || true
+  fi
+done
+
+if [ "$restart" = true ]; then
+  systemctl restart containerd
+fi
+`
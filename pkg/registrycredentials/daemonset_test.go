@@ -0,0 +1,132 @@
+package registrycredentials_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/registrycredentials"
+	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+const settings = `{"container-registry":{"mirrors":{"public.ecr.aws":["https://1.2.3.4:443"]},"credentials":[{"registry":"1.2.3.4:443","username":"user","password":"pass"}]}}`
+
+func credentialsSpec() *cluster.Spec {
+	return test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = "my-cluster"
+		s.Cluster.Spec.RegistryMirrorConfiguration = &anywherev1.RegistryMirrorConfiguration{
+			Endpoint:     "1.2.3.4",
+			Port:         "443",
+			Authenticate: true,
+			CredentialsRef: &anywherev1.Ref{
+				Kind: constants.SecretKind,
+				Name: "mirror-credentials",
+			},
+		}
+		s.VersionsBundle.BottleRocketHostContainers = releasev1.BottlerocketHostContainersBundle{
+			Admin:   releasev1.Image{URI: "public.ecr.aws/bottlerocket/bottlerocket-admin:v0.10.1"},
+			Control: releasev1.Image{URI: "public.ecr.aws/bottlerocket/bottlerocket-control:v0.7.1"},
+		}
+		s.VersionsBundle.KubeDistro.Pause = releasev1.Image{URI: "public.ecr.aws/eks-distro/kubernetes/pause:v1.27.1-eks-1-27-4"}
+	})
+}
+
+func TestObjects(t *testing.T) {
+	g := NewWithT(t)
+
+	objs, err := registrycredentials.Objects(credentialsSpec(), "user", "pass", settings)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(objs).To(HaveLen(2))
+
+	secret, ok := objs[0].(*corev1.Secret)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(secret.Name).To(Equal("eksa-registry-credentials"))
+	g.Expect(secret.Namespace).To(Equal("eksa-system"))
+	g.Expect(secret.Data).To(Equal(map[string][]byte{
+		"username":              []byte("user"),
+		"password":              []byte("pass"),
+		"bottlerocket-settings": []byte(settings),
+	}))
+
+	ds, ok := objs[1].(*appsv1.DaemonSet)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(ds.Name).To(Equal("eksa-registry-credentials"))
+	g.Expect(ds.Namespace).To(Equal("eksa-system"))
+	g.Expect(ds.Spec.Selector.MatchLabels).To(Equal(ds.Spec.Template.Labels))
+
+	pod := ds.Spec.Template.Spec
+	g.Expect(pod.HostPID).To(BeTrue())
+	g.Expect(pod.Tolerations).To(ConsistOf(corev1.Toleration{Operator: corev1.TolerationOpExists}))
+	g.Expect(pod.Volumes).To(ConsistOf(HaveField("VolumeSource.HostPath.Path", "/run")))
+	g.Expect(pod.Containers).To(HaveLen(1))
+	g.Expect(pod.Containers[0].Image).To(Equal("public.ecr.aws/eks-distro/kubernetes/pause:v1.27.1-eks-1-27-4"))
+	g.Expect(pod.InitContainers).To(HaveLen(2))
+
+	bottlerocket := pod.InitContainers[0]
+	g.Expect(bottlerocket.Image).To(Equal("public.ecr.aws/bottlerocket/bottlerocket-control:v0.7.1"))
+	g.Expect(bottlerocket.Command[2]).To(ContainSubstring(`apiclient --socket-path "$API_SOCKET" set --json "$SETTINGS"`))
+	g.Expect(bottlerocket.Env).To(ConsistOf(
+		corev1.EnvVar{
+			Name: "SETTINGS",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "eksa-registry-credentials"},
+					Key:                  "bottlerocket-settings",
+				},
+			},
+		},
+		corev1.EnvVar{Name: "API_SOCKET", Value: "/host/run/api.sock"},
+	))
+
+	updater := pod.InitContainers[1]
+	g.Expect(updater.Image).To(Equal("public.ecr.aws/bottlerocket/bottlerocket-admin:v0.10.1"))
+	g.Expect(updater.Command[2]).To(ContainSubstring("exec nsenter --target 1"))
+	g.Expect(updater.Command[4]).NotTo(ContainSubstring("apiclient"))
+
+	g.Expect(updater.Env).To(ContainElements(
+		corev1.EnvVar{Name: "REGISTRY_MIRROR", Value: "1.2.3.4:443"},
+		corev1.EnvVar{Name: "API_SOCKET", Value: "/host/run/api.sock"},
+		corev1.EnvVar{
+			Name: "REGISTRY_PASSWORD",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "eksa-registry-credentials"},
+					Key:                  "password",
+				},
+			},
+		},
+	))
+
+	for _, c := range pod.InitContainers {
+		g.Expect(*c.SecurityContext.Privileged).To(BeTrue())
+		g.Expect(c.VolumeMounts).To(ConsistOf(corev1.VolumeMount{Name: "host-run", MountPath: "/host/run"}))
+	}
+}
+
+func TestObjectsChecksumChangesWithCredentials(t *testing.T) {
+	g := NewWithT(t)
+	checksum := func(username, password string) string {
+		objs, err := registrycredentials.Objects(credentialsSpec(), username, password, settings)
+		g.Expect(err).NotTo(HaveOccurred())
+		return objs[1].(*appsv1.DaemonSet).Spec.Template.Annotations[registrycredentials.ChecksumAnnotation]
+	}
+
+	g.Expect(checksum("user", "pass")).NotTo(BeEmpty())
+	g.Expect(checksum("user", "pass")).To(Equal(checksum("user", "pass")))
+	g.Expect(checksum("user", "pass")).NotTo(Equal(checksum("user", "rotated")))
+}
+
+func TestObjectsNoRegistryMirror(t *testing.T) {
+	g := NewWithT(t)
+	spec := credentialsSpec()
+	spec.Cluster.Spec.RegistryMirrorConfiguration = nil
+
+	_, err := registrycredentials.Objects(spec, "user", "pass", settings)
+	g.Expect(err).To(MatchError("cluster my-cluster doesn't have a registry mirror configuration"))
+}
@@ -0,0 +1,216 @@
+package reconciler
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	anywhereCluster "github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/config"
+	"github.com/aws/eks-anywhere/pkg/controller"
+	"github.com/aws/eks-anywhere/pkg/controller/clientutil"
+	"github.com/aws/eks-anywhere/pkg/controller/clusters"
+	"github.com/aws/eks-anywhere/pkg/controller/serverside"
+	"github.com/aws/eks-anywhere/pkg/registrycredentials"
+	"github.com/aws/eks-anywhere/pkg/registrymirror"
+	"github.com/aws/eks-anywhere/pkg/registrymirror/bottlerocket"
+)
+
+// RemoteClientRegistry defines methods for remote cluster controller clients.
+type RemoteClientRegistry interface {
+	GetClient(ctx context.Context, cluster client.ObjectKey) (client.Client, error)
+}
+
+// Reconciler pushes the registry mirror credentials from the Secret referenced in a cluster
+// RegistryMirrorConfiguration to its running nodes.
+type Reconciler struct {
+	client               client.Client
+	remoteClientRegistry RemoteClientRegistry
+}
+
+// New returns a new Reconciler.
+func New(client client.Client, remoteClientRegistry RemoteClientRegistry) *Reconciler {
+	return &Reconciler{
+		client:               client,
+		remoteClientRegistry: remoteClientRegistry,
+	}
+}
+
+// Reconcile applies the registry credentials Secret and DaemonSet to the cluster when its registry mirror
+// has a CredentialsRef and removes them if the reference has been removed.
+// It uses a controller.Result to indicate when requeues are needed.
+func (r *Reconciler) Reconcile(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster) (controller.Result, error) {
+	ref := credentialsRef(cluster)
+	if ref == nil {
+		return controller.Result{}, r.reconcileDisabled(ctx, log, cluster)
+	}
+
+	username, password, err := config.ReadCredentialsFromNamedSecret(ctx, r.client, ref.Name)
+	if err != nil {
+		conditions.MarkFalse(cluster, anywherev1.RegistryCredentialsRotatedCondition, anywherev1.RegistryCredentialsRotationFailedReason, clusterv1.ConditionSeverityWarning, "Reading credentials from secret %s: %s", ref.Name, err)
+		return controller.Result{}, err
+	}
+
+	clusterSpec, err := anywhereCluster.BuildSpec(ctx, clientutil.NewKubeClient(r.client), cluster)
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	result, err := clusters.CheckControlPlaneReady(ctx, r.client, log, cluster)
+	if err != nil {
+		return controller.Result{}, errors.Wrap(err, "checking controlplane ready")
+	}
+	if result.Return() {
+		return result, nil
+	}
+
+	settings, err := bottlerocket.SettingsWithCredentials(registrymirror.FromCluster(cluster), r.credentials(ctx, username, password))
+	if err != nil {
+		return controller.Result{}, errors.Wrap(err, "generating bottlerocket registry settings")
+	}
+
+	objs, err := registrycredentials.Objects(clusterSpec, username, password, settings)
+	if err != nil {
+		return controller.Result{}, errors.Wrap(err, "generating registry credentials objects")
+	}
+
+	rClient, err := r.remoteClientRegistry.GetClient(ctx, controller.CapiClusterObjectKey(cluster))
+	if err != nil {
+		return controller.Result{}, errors.Wrap(err, "getting workload cluster's client to reconcile registry credentials")
+	}
+
+	log.V(4).Info("Applying registry credentials", "secret", ref.Name)
+	if err := serverside.ReconcileObjects(ctx, rClient, objs); err != nil {
+		return controller.Result{}, errors.Wrap(err, "applying registry credentials")
+	}
+
+	if err := updateBottlerocketRegistryMirrors(ctx, rClient, settings); err != nil {
+		return controller.Result{}, err
+	}
+
+	if conditions.GetReason(cluster, anywherev1.RegistryCredentialsRotatedCondition) == anywherev1.RegistryCredentialsRotationFailedReason {
+		conditions.MarkFalse(cluster, anywherev1.RegistryCredentialsRotatedCondition, anywherev1.RegistryCredentialsRotationInProgressReason, clusterv1.ConditionSeverityInfo, "")
+	}
+
+	return controller.Result{}, nil
+}
+
+// credentials returns the rotated credentials for the registry mirror and the credentials of the upstream
+// registries from the registry credentials Secret for their mirrors.
+func (r *Reconciler) credentials(ctx context.Context, username, password string) bottlerocket.CredentialsFunc {
+	return func(upstream string, m registrymirror.Mirror) (string, string, error) {
+		if m.Base {
+			return username, password, nil
+		}
+		return config.ReadUpstreamMirrorCredentialsFromSecret(ctx, r.client, upstream)
+	}
+}
+
+// updateBottlerocketRegistryMirrors updates the settings of the Bottlerocket registry mirrors DaemonSet, if the
+// cluster has one, so it doesn't set the previous credentials again when its pods are restarted.
+func updateBottlerocketRegistryMirrors(ctx context.Context, rClient client.Client, settings string) error {
+	key := client.ObjectKey{Name: bottlerocket.Name, Namespace: bottlerocket.Namespace}
+	if err := rClient.Get(ctx, key, &corev1.Secret{}); apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Wrap(err, "reading bottlerocket registry mirrors settings")
+	}
+
+	if err := serverside.ReconcileObjects(ctx, rClient, []client.Object{bottlerocket.SettingsSecret(settings)}); err != nil {
+		return errors.Wrap(err, "updating bottlerocket registry mirrors settings")
+	}
+	return nil
+}
+
+// reconcileDisabled deletes the registry credentials DaemonSet and Secret if a CredentialsRef was
+// previously configured for the cluster. The RegistryCredentialsRotated condition is used to track this.
+// The credentials already in the nodes are kept.
+func (r *Reconciler) reconcileDisabled(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster) error {
+	if !conditions.Has(cluster, anywherev1.RegistryCredentialsRotatedCondition) {
+		return nil
+	}
+
+	rClient, err := r.remoteClientRegistry.GetClient(ctx, controller.CapiClusterObjectKey(cluster))
+	if err != nil {
+		return errors.Wrap(err, "getting workload cluster's client to remove registry credentials")
+	}
+
+	objectMeta := metav1.ObjectMeta{Name: registrycredentials.Name, Namespace: registrycredentials.Namespace}
+	log.Info("Deleting registry credentials daemonset")
+	for _, obj := range []client.Object{&appsv1.DaemonSet{ObjectMeta: objectMeta}, &corev1.Secret{ObjectMeta: objectMeta}} {
+		err := rClient.Delete(ctx, obj, client.PropagationPolicy(metav1.DeletePropagationBackground))
+		if err != nil && !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "deleting registry credentials %T", obj)
+		}
+	}
+
+	conditions.Delete(cluster, anywherev1.RegistryCredentialsRotatedCondition)
+
+	return nil
+}
+
+// UpdateStatus checks the rollout of the registry credentials DaemonSet and updates the
+// RegistryCredentialsRotated condition. It becomes true once every node runs the latest credentials,
+// so its last transition time tells when the latest rotation finished.
+func (r *Reconciler) UpdateStatus(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster) error {
+	if credentialsRef(cluster) == nil {
+		return nil
+	}
+
+	// Don't override the reason set by Reconcile.
+	if conditions.GetReason(cluster, anywherev1.RegistryCredentialsRotatedCondition) == anywherev1.RegistryCredentialsRotationFailedReason {
+		return nil
+	}
+
+	// While the control plane is not ready, the status of the previous rotation is kept as is.
+	if !conditions.IsTrue(cluster, anywherev1.ControlPlaneReadyCondition) {
+		if !conditions.Has(cluster, anywherev1.RegistryCredentialsRotatedCondition) {
+			conditions.MarkFalse(cluster, anywherev1.RegistryCredentialsRotatedCondition, anywherev1.ControlPlaneNotReadyReason, clusterv1.ConditionSeverityInfo, "")
+		}
+		return nil
+	}
+
+	rClient, err := r.remoteClientRegistry.GetClient(ctx, controller.CapiClusterObjectKey(cluster))
+	if err != nil {
+		return errors.Wrap(err, "getting workload cluster's client to update registry credentials status")
+	}
+
+	ds := &appsv1.DaemonSet{}
+	key := client.ObjectKey{Name: registrycredentials.Name, Namespace: registrycredentials.Namespace}
+	if err := rClient.Get(ctx, key, ds); apierrors.IsNotFound(err) {
+		conditions.MarkFalse(cluster, anywherev1.RegistryCredentialsRotatedCondition, anywherev1.RegistryCredentialsRotationInProgressReason, clusterv1.ConditionSeverityInfo, "Waiting for the registry credentials daemonset to be created")
+		return nil
+	} else if err != nil {
+		return errors.Wrap(err, "reading registry credentials daemonset")
+	}
+
+	status := ds.Status
+	if status.ObservedGeneration < ds.Generation || status.UpdatedNumberScheduled != status.DesiredNumberScheduled || status.NumberAvailable != status.DesiredNumberScheduled {
+		conditions.MarkFalse(cluster, anywherev1.RegistryCredentialsRotatedCondition, anywherev1.RegistryCredentialsRotationInProgressReason, clusterv1.ConditionSeverityInfo,
+			"Registry credentials updated in %d of %d nodes", status.UpdatedNumberScheduled, status.DesiredNumberScheduled)
+		return nil
+	}
+
+	if !conditions.IsTrue(cluster, anywherev1.RegistryCredentialsRotatedCondition) {
+		log.Info("Registry credentials rotated", "nodes", status.DesiredNumberScheduled)
+	}
+	conditions.MarkTrue(cluster, anywherev1.RegistryCredentialsRotatedCondition)
+
+	return nil
+}
+
+func credentialsRef(cluster *anywherev1.Cluster) *anywherev1.Ref {
+	if cluster.Spec.RegistryMirrorConfiguration == nil {
+		return nil
+	}
+	return cluster.Spec.RegistryMirrorConfiguration.CredentialsRef
+}
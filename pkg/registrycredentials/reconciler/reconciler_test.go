@@ -0,0 +1,305 @@
+package reconciler_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	eksdv1 "github.com/aws/eks-distro-build-tooling/release/api/v1alpha1"
+	"github.com/go-logr/logr"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/controller"
+	"github.com/aws/eks-anywhere/pkg/registrycredentials"
+	"github.com/aws/eks-anywhere/pkg/registrycredentials/reconciler"
+	"github.com/aws/eks-anywhere/pkg/registrycredentials/reconciler/mocks"
+	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+type reconcilerTest struct {
+	*WithT
+	ctx            context.Context
+	remoteClients  *mocks.MockRemoteClientRegistry
+	cluster        *anywherev1.Cluster
+	managementObjs []runtime.Object
+}
+
+func newReconcilerTest(t *testing.T) *reconcilerTest {
+	ctrl := gomock.NewController(t)
+	bundle := test.Bundle()
+	cluster := &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-cluster",
+			Namespace: "eksa-system",
+		},
+		Spec: anywherev1.ClusterSpec{
+			KubernetesVersion: "1.22",
+			BundlesRef: &anywherev1.BundlesRef{
+				Name:       bundle.Name,
+				Namespace:  bundle.Namespace,
+				APIVersion: bundle.APIVersion,
+			},
+			RegistryMirrorConfiguration: &anywherev1.RegistryMirrorConfiguration{
+				Endpoint:     "1.2.3.4",
+				Port:         "443",
+				Authenticate: true,
+				CredentialsRef: &anywherev1.Ref{
+					Kind: constants.SecretKind,
+					Name: "mirror-credentials",
+				},
+			},
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "mirror-credentials",
+			Namespace: constants.EksaSystemNamespace,
+		},
+		Data: map[string][]byte{
+			"username": []byte("user"),
+			"password": []byte("pass"),
+		},
+	}
+
+	return &reconcilerTest{
+		WithT:          NewWithT(t),
+		ctx:            context.Background(),
+		remoteClients:  mocks.NewMockRemoteClientRegistry(ctrl),
+		cluster:        cluster,
+		managementObjs: []runtime.Object{bundle, test.EksdRelease(), secret},
+	}
+}
+
+func (tt *reconcilerTest) reconciler() *reconciler.Reconciler {
+	scheme := runtime.NewScheme()
+	_ = anywherev1.AddToScheme(scheme)
+	_ = releasev1.AddToScheme(scheme)
+	_ = eksdv1.AddToScheme(scheme)
+	_ = clusterv1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(tt.managementObjs...).Build()
+
+	return reconciler.New(cl, tt.remoteClients)
+}
+
+func (tt *reconcilerTest) expectRemoteClient(objs ...runtime.Object) client.Client {
+	rClient := fake.NewClientBuilder().WithRuntimeObjects(objs...).Build()
+	tt.remoteClients.EXPECT().GetClient(tt.ctx, controller.CapiClusterObjectKey(tt.cluster)).Return(rClient, nil)
+	return rClient
+}
+
+func (tt *reconcilerTest) markControlPlaneReady() {
+	conditions.MarkTrue(tt.cluster, anywherev1.ControlPlaneReadyCondition)
+}
+
+func nullLog() logr.Logger {
+	return logr.New(logf.NullLogSink{})
+}
+
+func daemonSet(generation int64, status appsv1.DaemonSetStatus) *appsv1.DaemonSet {
+	return &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       registrycredentials.Name,
+			Namespace:  registrycredentials.Namespace,
+			Generation: generation,
+		},
+		Status: status,
+	}
+}
+
+func TestReconcileNoCredentialsRef(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.cluster.Spec.RegistryMirrorConfiguration.CredentialsRef = nil
+
+	result, err := tt.reconciler().Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+}
+
+func TestReconcileCredentialsRefRemoved(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.cluster.Spec.RegistryMirrorConfiguration.CredentialsRef = nil
+	conditions.MarkTrue(tt.cluster, anywherev1.RegistryCredentialsRotatedCondition)
+	ds := daemonSet(1, appsv1.DaemonSetStatus{})
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      registrycredentials.Name,
+			Namespace: registrycredentials.Namespace,
+		},
+	}
+	rClient := tt.expectRemoteClient(ds, secret)
+
+	_, err := tt.reconciler().Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(conditions.Has(tt.cluster, anywherev1.RegistryCredentialsRotatedCondition)).To(BeFalse())
+
+	err = rClient.Get(tt.ctx, client.ObjectKeyFromObject(ds), &appsv1.DaemonSet{})
+	tt.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	err = rClient.Get(tt.ctx, client.ObjectKeyFromObject(secret), &corev1.Secret{})
+	tt.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+}
+
+func TestReconcileCredentialsRefRemovedRemoteClientError(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.cluster.Spec.RegistryMirrorConfiguration = nil
+	conditions.MarkTrue(tt.cluster, anywherev1.RegistryCredentialsRotatedCondition)
+	tt.remoteClients.EXPECT().GetClient(tt.ctx, gomock.AssignableToTypeOf(client.ObjectKey{})).Return(nil, errors.New("unreachable"))
+
+	_, err := tt.reconciler().Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).To(MatchError(ContainSubstring("unreachable")))
+}
+
+func TestReconcileSecretNotFound(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.cluster.Spec.RegistryMirrorConfiguration.CredentialsRef.Name = "missing"
+
+	_, err := tt.reconciler().Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).To(MatchError(ContainSubstring("fetching registry auth secret")))
+	tt.Expect(conditions.IsFalse(tt.cluster, anywherev1.RegistryCredentialsRotatedCondition)).To(BeTrue())
+	tt.Expect(conditions.GetReason(tt.cluster, anywherev1.RegistryCredentialsRotatedCondition)).To(Equal(anywherev1.RegistryCredentialsRotationFailedReason))
+}
+
+func TestReconcileCAPIClusterNotFound(t *testing.T) {
+	tt := newReconcilerTest(t)
+
+	result, err := tt.reconciler().Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result.Return()).To(BeTrue())
+}
+
+func TestReconcileRemoteClientError(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.managementObjs = append(tt.managementObjs, test.CAPICluster(func(c *clusterv1.Cluster) {
+		c.Name = tt.cluster.Name
+	}))
+	tt.remoteClients.EXPECT().GetClient(tt.ctx, gomock.AssignableToTypeOf(client.ObjectKey{})).Return(nil, errors.New("unreachable"))
+
+	_, err := tt.reconciler().Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).To(MatchError(ContainSubstring("getting workload cluster's client to reconcile registry credentials")))
+}
+
+func TestReconcileUpstreamMirrorCredentialsNotFound(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.cluster.Spec.RegistryMirrorConfiguration.Mirrors = []anywherev1.UpstreamRegistryMirror{
+		{Upstream: "docker.io", Endpoint: "cache.internal/dockerhub", Authenticate: true},
+	}
+	tt.managementObjs = append(tt.managementObjs, test.CAPICluster(func(c *clusterv1.Cluster) {
+		c.Name = tt.cluster.Name
+	}))
+
+	_, err := tt.reconciler().Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).To(MatchError(ContainSubstring("generating bottlerocket registry settings: reading credentials of the docker.io registry mirror cache.internal/dockerhub")))
+}
+
+func TestReconcileApplyError(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.managementObjs = append(tt.managementObjs, test.CAPICluster(func(c *clusterv1.Cluster) {
+		c.Name = tt.cluster.Name
+	}))
+	// The fake client doesn't support server side apply
+	tt.expectRemoteClient()
+
+	_, err := tt.reconciler().Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).To(MatchError(ContainSubstring("applying registry credentials")))
+}
+
+func TestUpdateStatusNoCredentialsRef(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.cluster.Spec.RegistryMirrorConfiguration = nil
+
+	tt.Expect(tt.reconciler().UpdateStatus(tt.ctx, nullLog(), tt.cluster)).To(Succeed())
+	tt.Expect(conditions.Has(tt.cluster, anywherev1.RegistryCredentialsRotatedCondition)).To(BeFalse())
+}
+
+func TestUpdateStatusRotationFailed(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.markControlPlaneReady()
+	conditions.MarkFalse(tt.cluster, anywherev1.RegistryCredentialsRotatedCondition, anywherev1.RegistryCredentialsRotationFailedReason, clusterv1.ConditionSeverityWarning, "")
+
+	tt.Expect(tt.reconciler().UpdateStatus(tt.ctx, nullLog(), tt.cluster)).To(Succeed())
+	tt.Expect(conditions.GetReason(tt.cluster, anywherev1.RegistryCredentialsRotatedCondition)).To(Equal(anywherev1.RegistryCredentialsRotationFailedReason))
+}
+
+func TestUpdateStatusControlPlaneNotReady(t *testing.T) {
+	tt := newReconcilerTest(t)
+
+	tt.Expect(tt.reconciler().UpdateStatus(tt.ctx, nullLog(), tt.cluster)).To(Succeed())
+	tt.Expect(conditions.GetReason(tt.cluster, anywherev1.RegistryCredentialsRotatedCondition)).To(Equal(anywherev1.ControlPlaneNotReadyReason))
+}
+
+func TestUpdateStatusDaemonSetNotFound(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.markControlPlaneReady()
+	tt.expectRemoteClient()
+
+	tt.Expect(tt.reconciler().UpdateStatus(tt.ctx, nullLog(), tt.cluster)).To(Succeed())
+	tt.Expect(conditions.GetReason(tt.cluster, anywherev1.RegistryCredentialsRotatedCondition)).To(Equal(anywherev1.RegistryCredentialsRotationInProgressReason))
+}
+
+func TestUpdateStatusRolloutInProgress(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.markControlPlaneReady()
+	tt.expectRemoteClient(daemonSet(2, appsv1.DaemonSetStatus{
+		ObservedGeneration:     2,
+		DesiredNumberScheduled: 3,
+		UpdatedNumberScheduled: 1,
+		NumberAvailable:        3,
+	}))
+
+	tt.Expect(tt.reconciler().UpdateStatus(tt.ctx, nullLog(), tt.cluster)).To(Succeed())
+	tt.Expect(conditions.IsFalse(tt.cluster, anywherev1.RegistryCredentialsRotatedCondition)).To(BeTrue())
+	tt.Expect(conditions.GetReason(tt.cluster, anywherev1.RegistryCredentialsRotatedCondition)).To(Equal(anywherev1.RegistryCredentialsRotationInProgressReason))
+	tt.Expect(conditions.GetMessage(tt.cluster, anywherev1.RegistryCredentialsRotatedCondition)).To(Equal("Registry credentials updated in 1 of 3 nodes"))
+}
+
+func TestUpdateStatusRolloutNotObserved(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.markControlPlaneReady()
+	conditions.MarkTrue(tt.cluster, anywherev1.RegistryCredentialsRotatedCondition)
+	tt.expectRemoteClient(daemonSet(3, appsv1.DaemonSetStatus{
+		ObservedGeneration:     2,
+		DesiredNumberScheduled: 3,
+		UpdatedNumberScheduled: 3,
+		NumberAvailable:        3,
+	}))
+
+	tt.Expect(tt.reconciler().UpdateStatus(tt.ctx, nullLog(), tt.cluster)).To(Succeed())
+	tt.Expect(conditions.IsFalse(tt.cluster, anywherev1.RegistryCredentialsRotatedCondition)).To(BeTrue())
+}
+
+func TestUpdateStatusRotated(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.markControlPlaneReady()
+	tt.expectRemoteClient(daemonSet(2, appsv1.DaemonSetStatus{
+		ObservedGeneration:     2,
+		DesiredNumberScheduled: 3,
+		UpdatedNumberScheduled: 3,
+		NumberAvailable:        3,
+	}))
+
+	tt.Expect(tt.reconciler().UpdateStatus(tt.ctx, nullLog(), tt.cluster)).To(Succeed())
+	tt.Expect(conditions.IsTrue(tt.cluster, anywherev1.RegistryCredentialsRotatedCondition)).To(BeTrue())
+}
+
+func TestUpdateStatusRemoteClientError(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.markControlPlaneReady()
+	tt.remoteClients.EXPECT().GetClient(tt.ctx, gomock.AssignableToTypeOf(client.ObjectKey{})).Return(nil, errors.New("unreachable"))
+
+	err := tt.reconciler().UpdateStatus(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).To(MatchError(ContainSubstring("getting workload cluster's client to update registry credentials status")))
+}
@@ -0,0 +1,177 @@
+package bottlerocket
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/registrymirror"
+	"github.com/aws/eks-anywhere/pkg/templater"
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
+)
+
+const (
+	// Name is the name of the DaemonSet that applies the registry mirror settings to the Bottlerocket nodes
+	// and of the Secret it reads them from, both in the workload cluster.
+	Name = "eksa-bottlerocket-registry-mirrors"
+	// Namespace is the namespace of the registry mirror settings DaemonSet and Secret. It's created
+	// with the cluster, so they can be applied before any EKS Anywhere component.
+	Namespace = constants.KubeSystemNamespace
+	// ChecksumAnnotation is set in the DaemonSet pods with a checksum of the settings they apply,
+	// so a new rollout is triggered every time the settings change.
+	ChecksumAnnotation = "anywhere.eks.amazonaws.com/registry-mirrors-checksum"
+
+	appLabel         = "anywhere.eks.amazonaws.com/bottlerocket-registry-mirrors"
+	settingsKey      = "settings"
+	hostRunVolume    = "host-run"
+	hostRunPath      = "/run"
+	hostRunMountPath = "/host/run"
+	apiSocket        = hostRunMountPath + "/api.sock"
+)
+
+// Manifest returns the manifest of the Secret with the Bottlerocket settings of the registry mirrors and
+// the DaemonSet that sets them in every Bottlerocket node with apiclient, from the control host container
+// through the API socket of the node. Nodes with other OSes are skipped, since they are configured with
+// containerd hosts files.
+func Manifest(spec *cluster.Spec) ([]byte, error) {
+	mirror := registrymirror.FromCluster(spec.Cluster)
+	if mirror == nil {
+		return nil, fmt.Errorf("cluster %s doesn't have a registry mirror configuration", spec.Cluster.Name)
+	}
+
+	settings, err := Settings(mirror)
+	if err != nil {
+		return nil, err
+	}
+
+	return templater.ObjectsToYaml(secret(settings), daemonSet(spec, checksum(settings)))
+}
+
+// SettingsSecret returns the Secret with the Bottlerocket settings of the registry mirrors read by the
+// DaemonSet in Manifest, so they can be updated in the workload cluster.
+func SettingsSecret(settings string) *corev1.Secret {
+	s := secret(settings)
+	s.StringData = nil
+	s.Data = map[string][]byte{settingsKey: []byte(settings)}
+	return s
+}
+
+func secret(settings string) *corev1.Secret {
+	return &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       constants.SecretKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      Name,
+			Namespace: Namespace,
+		},
+		Type: corev1.SecretTypeOpaque,
+		StringData: map[string]string{
+			settingsKey: settings,
+		},
+	}
+}
+
+func daemonSet(spec *cluster.Spec, settingsChecksum string) *appsv1.DaemonSet {
+	labels := map[string]string{appLabel: spec.Cluster.Name}
+	maxUnavailable := intstr.FromString("25%")
+	hostPathDirectory := corev1.HostPathDirectory
+
+	return &appsv1.DaemonSet{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: appsv1.SchemeGroupVersion.String(),
+			Kind:       "DaemonSet",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      Name,
+			Namespace: Namespace,
+			Labels:    labels,
+		},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			UpdateStrategy: appsv1.DaemonSetUpdateStrategy{
+				Type: appsv1.RollingUpdateDaemonSetStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateDaemonSet{
+					MaxUnavailable: &maxUnavailable,
+				},
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+					Annotations: map[string]string{
+						ChecksumAnnotation: settingsChecksum,
+					},
+				},
+				Spec: corev1.PodSpec{
+					Tolerations: []corev1.Toleration{
+						{
+							Operator: corev1.TolerationOpExists,
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name: hostRunVolume,
+							VolumeSource: corev1.VolumeSource{
+								HostPath: &corev1.HostPathVolumeSource{
+									Path: hostRunPath,
+									Type: &hostPathDirectory,
+								},
+							},
+						},
+					},
+					InitContainers: []corev1.Container{
+						{
+							Name:    "settings-updater",
+							Image:   spec.VersionsBundle.BottleRocketHostContainers.Control.VersionedImage(),
+							Command: []string{"sh", "-c", settingsScript},
+							Env: []corev1.EnvVar{
+								{
+									Name: "SETTINGS",
+									ValueFrom: &corev1.EnvVarSource{
+										SecretKeyRef: &corev1.SecretKeySelector{
+											LocalObjectReference: corev1.LocalObjectReference{Name: Name},
+											Key:                  settingsKey,
+										},
+									},
+								},
+								{Name: "API_SOCKET", Value: apiSocket},
+							},
+							VolumeMounts: []corev1.VolumeMount{{Name: hostRunVolume, MountPath: hostRunMountPath}},
+							SecurityContext: &corev1.SecurityContext{
+								Privileged: ptr.Bool(true),
+							},
+						},
+					},
+					Containers: []corev1.Container{
+						{
+							Name:  "pause",
+							Image: spec.VersionsBundle.KubeDistro.Pause.VersionedImage(),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func checksum(settings string) string {
+	sum := sha256.Sum256([]byte(settings))
+	return hex.EncodeToString(sum[:])
+}
+
+// settingsScript applies the settings in SETTINGS with the settings API of the node, if the node is a
+// Bottlerocket one.
+const settingsScript = `set -eu
+if [ ! -S "$API_SOCKET" ]; then
+  exit 0
+fi
+apiclient --socket-path "$API_SOCKET" set --json "$SETTINGS"
+`
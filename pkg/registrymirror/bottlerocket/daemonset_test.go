@@ -0,0 +1,103 @@
+package bottlerocket_test
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/registrymirror/bottlerocket"
+	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+func mirrorsSpec() *cluster.Spec {
+	return test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = "my-cluster"
+		s.Cluster.Spec.RegistryMirrorConfiguration = &anywherev1.RegistryMirrorConfiguration{
+			Endpoint: "1.2.3.4",
+			Port:     "443",
+			Mirrors: []anywherev1.UpstreamRegistryMirror{
+				{Upstream: "docker.io", Endpoint: "cache.internal/dockerhub"},
+			},
+		}
+		s.VersionsBundle.BottleRocketHostContainers = releasev1.BottlerocketHostContainersBundle{
+			Control: releasev1.Image{URI: "public.ecr.aws/bottlerocket/bottlerocket-control:v0.7.1"},
+		}
+		s.VersionsBundle.KubeDistro.Pause = releasev1.Image{URI: "public.ecr.aws/eks-distro/kubernetes/pause:v1.27.1-eks-1-27-4"}
+	})
+}
+
+func splitManifest(manifest []byte) [][]byte {
+	return bytes.Split(bytes.TrimSuffix(manifest, []byte("\n---\n")), []byte("\n---\n"))
+}
+
+func TestManifest(t *testing.T) {
+	g := NewWithT(t)
+
+	manifest, err := bottlerocket.Manifest(mirrorsSpec())
+	g.Expect(err).NotTo(HaveOccurred())
+
+	docs := splitManifest(manifest)
+	g.Expect(docs).To(HaveLen(2))
+
+	secret := &corev1.Secret{}
+	g.Expect(yaml.Unmarshal(docs[0], secret)).To(Succeed())
+	g.Expect(secret.Name).To(Equal("eksa-bottlerocket-registry-mirrors"))
+	g.Expect(secret.Namespace).To(Equal("kube-system"))
+	g.Expect(secret.StringData["settings"]).To(MatchJSON(`{
+		"container-registry": {
+			"mirrors": {
+				"public.ecr.aws": ["https://1.2.3.4:443"],
+				"docker.io": ["https://cache.internal/v2/dockerhub"]
+			}
+		}
+	}`))
+
+	ds := &appsv1.DaemonSet{}
+	g.Expect(yaml.Unmarshal(docs[1], ds)).To(Succeed())
+	g.Expect(ds.Name).To(Equal("eksa-bottlerocket-registry-mirrors"))
+	g.Expect(ds.Namespace).To(Equal("kube-system"))
+	g.Expect(ds.Spec.Selector.MatchLabels).To(Equal(ds.Spec.Template.Labels))
+	g.Expect(ds.Spec.Template.Annotations).To(HaveKey(bottlerocket.ChecksumAnnotation))
+
+	pod := ds.Spec.Template.Spec
+	g.Expect(pod.InitContainers).To(HaveLen(1))
+	g.Expect(pod.InitContainers[0].Image).To(Equal("public.ecr.aws/bottlerocket/bottlerocket-control:v0.7.1"))
+	g.Expect(pod.InitContainers[0].Command[2]).To(ContainSubstring(`apiclient --socket-path "$API_SOCKET" set --json "$SETTINGS"`))
+	g.Expect(pod.InitContainers[0].Env[0].ValueFrom.SecretKeyRef.Name).To(Equal("eksa-bottlerocket-registry-mirrors"))
+	g.Expect(pod.Containers[0].Image).To(Equal("public.ecr.aws/eks-distro/kubernetes/pause:v1.27.1-eks-1-27-4"))
+}
+
+func TestManifestChecksumChangesWithSettings(t *testing.T) {
+	g := NewWithT(t)
+
+	spec := mirrorsSpec()
+	before, err := bottlerocket.Manifest(spec)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	spec.Cluster.Spec.RegistryMirrorConfiguration.Mirrors[0].Endpoint = "backup.internal"
+	after, err := bottlerocket.Manifest(spec)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	checksum := func(manifest []byte) string {
+		ds := &appsv1.DaemonSet{}
+		g.Expect(yaml.Unmarshal(splitManifest(manifest)[1], ds)).To(Succeed())
+		return ds.Spec.Template.Annotations[bottlerocket.ChecksumAnnotation]
+	}
+	g.Expect(checksum(after)).NotTo(Equal(checksum(before)))
+}
+
+func TestManifestNoRegistryMirror(t *testing.T) {
+	g := NewWithT(t)
+	spec := mirrorsSpec()
+	spec.Cluster.Spec.RegistryMirrorConfiguration = nil
+
+	_, err := bottlerocket.Manifest(spec)
+	g.Expect(err).To(MatchError("cluster my-cluster doesn't have a registry mirror configuration"))
+}
@@ -0,0 +1,131 @@
+package bottlerocket
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/registrymirror"
+	"github.com/aws/eks-anywhere/pkg/registrymirror/containerd"
+)
+
+type settings struct {
+	ContainerRegistry containerRegistry            `json:"container-registry"`
+	PKI               map[string]certificateBundle `json:"pki,omitempty"`
+}
+
+type containerRegistry struct {
+	Mirrors     map[string][]string `json:"mirrors"`
+	Credentials []credentials       `json:"credentials,omitempty"`
+}
+
+type credentials struct {
+	Registry string `json:"registry"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+type certificateBundle struct {
+	Data    string `json:"data"`
+	Trusted bool   `json:"trusted"`
+}
+
+var nonAlphanumeric = regexp.MustCompile("[^a-z0-9]+")
+
+// CredentialsFunc returns the username and password of a mirror of an upstream registry.
+type CredentialsFunc func(upstream string, mirror registrymirror.Mirror) (username, password string, err error)
+
+// Settings renders the Bottlerocket container registry settings for the registry mirror and the mirrors of
+// upstream registries, in the JSON accepted by apiclient set. Credentials are read from the env.
+func Settings(r *registrymirror.RegistryMirror) (string, error) {
+	return SettingsWithCredentials(r, func(upstream string, m registrymirror.Mirror) (string, string, error) {
+		return m.Credentials(upstream)
+	})
+}
+
+// SettingsWithCredentials renders the Bottlerocket container registry settings like Settings, with the
+// credentials returned by credentials.
+// The mirrors and credentials settings are lists that apiclient replaces as a whole, so they include the
+// registry mirror settings that the bootstrap already set, in the same way. The CA certificates of the
+// upstream mirrors are added as trusted certificate bundles.
+func SettingsWithCredentials(r *registrymirror.RegistryMirror, credentialsFor CredentialsFunc) (string, error) {
+	s := settings{
+		ContainerRegistry: containerRegistry{Mirrors: map[string][]string{}},
+		PKI:               map[string]certificateBundle{},
+	}
+	creds := map[string]credentials{}
+	addCredentials := func(c credentials) error {
+		if existing, ok := creds[c.Registry]; ok && existing != c {
+			return fmt.Errorf("registry mirror %s has credentials different from other mirrors in the same host", c.Registry)
+		}
+		creds[c.Registry] = c
+		return nil
+	}
+
+	if r.Auth {
+		username, password, err := credentialsFor(constants.DefaultCoreEKSARegistry, registrymirror.Mirror{Endpoint: r.BaseRegistry, Auth: true, Base: true})
+		if err != nil {
+			return "", fmt.Errorf("reading registry mirror credentials: %v", err)
+		}
+		// The bootstrap sets the registry mirror credentials for public.ecr.aws too, since host-ctr
+		// looks them up by the upstream registry.
+		for _, registry := range []string{constants.DefaultCoreEKSARegistry, containerd.ToAPIEndpoint(r.CoreEKSAMirror()), r.BaseRegistry} {
+			if err := addCredentials(credentials{Registry: registry, Username: username, Password: password}); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	caCerts := map[string]string{}
+	for upstream, mirrors := range r.Mirrors() {
+		for _, m := range mirrors {
+			s.ContainerRegistry.Mirrors[upstream] = append(s.ContainerRegistry.Mirrors[upstream], "https://"+containerd.ToAPIEndpoint(m.Endpoint))
+			if m.Base {
+				continue
+			}
+
+			host := strings.SplitN(m.Endpoint, "/", 2)[0]
+			if m.CACertContent != "" {
+				if content, ok := caCerts[host]; ok && content != m.CACertContent {
+					return "", fmt.Errorf("registry mirror %s has a CA certificate different from other mirrors in the same host", m.Endpoint)
+				}
+				caCerts[host] = m.CACertContent
+				s.PKI[certificateBundleName(host)] = certificateBundle{
+					Data:    base64.StdEncoding.EncodeToString([]byte(m.CACertContent)),
+					Trusted: true,
+				}
+			}
+
+			if m.Auth {
+				username, password, err := credentialsFor(upstream, m)
+				if err != nil {
+					return "", fmt.Errorf("reading credentials of the %s registry mirror %s: %v", upstream, m.Endpoint, err)
+				}
+				if err := addCredentials(credentials{Registry: host, Username: username, Password: password}); err != nil {
+					return "", err
+				}
+			}
+		}
+	}
+
+	for _, c := range creds {
+		s.ContainerRegistry.Credentials = append(s.ContainerRegistry.Credentials, c)
+	}
+	sort.Slice(s.ContainerRegistry.Credentials, func(i, j int) bool {
+		return s.ContainerRegistry.Credentials[i].Registry < s.ContainerRegistry.Credentials[j].Registry
+	})
+
+	b, err := json.Marshal(s)
+	if err != nil {
+		return "", fmt.Errorf("marshalling bottlerocket registry mirror settings: %v", err)
+	}
+	return string(b), nil
+}
+
+func certificateBundleName(host string) string {
+	return "registry-mirror-" + strings.Trim(nonAlphanumeric.ReplaceAllString(strings.ToLower(host), "-"), "-")
+}
@@ -0,0 +1,165 @@
+package bottlerocket_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/registrymirror"
+	"github.com/aws/eks-anywhere/pkg/registrymirror/bottlerocket"
+)
+
+func TestSettings(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv("REGISTRY_USERNAME", "username")
+	t.Setenv("REGISTRY_PASSWORD", "password")
+	t.Setenv("REGISTRY_USERNAME_QUAY_IO", "quay-username")
+	t.Setenv("REGISTRY_PASSWORD_QUAY_IO", "quay-password")
+	r := &registrymirror.RegistryMirror{
+		BaseRegistry: "harbor.eksa.demo:30003",
+		NamespacedRegistryMap: map[string]string{
+			constants.DefaultCoreEKSARegistry: "harbor.eksa.demo:30003/eks-anywhere",
+			"docker.io":                       "harbor.eksa.demo:30003/dockerhub",
+		},
+		Auth:          true,
+		CACertContent: "ca",
+		UpstreamMirrors: map[string][]registrymirror.Mirror{
+			"docker.io": {
+				{Endpoint: "cache.internal:5000/dockerhub", CACertContent: "cache-ca"},
+			},
+			"quay.io": {
+				{Endpoint: "cache.internal:5000/quay", CACertContent: "cache-ca", Auth: true},
+			},
+		},
+	}
+
+	settings, err := bottlerocket.Settings(r)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(json.Valid([]byte(settings))).To(BeTrue())
+	g.Expect(settings).To(MatchJSON(`{
+		"container-registry": {
+			"mirrors": {
+				"public.ecr.aws": ["https://harbor.eksa.demo:30003/v2/eks-anywhere"],
+				"docker.io": ["https://harbor.eksa.demo:30003/v2/dockerhub", "https://cache.internal:5000/v2/dockerhub"],
+				"quay.io": ["https://cache.internal:5000/v2/quay"]
+			},
+			"credentials": [
+				{"registry": "cache.internal:5000", "username": "quay-username", "password": "quay-password"},
+				{"registry": "harbor.eksa.demo:30003", "username": "username", "password": "password"},
+				{"registry": "harbor.eksa.demo:30003/v2/eks-anywhere", "username": "username", "password": "password"},
+				{"registry": "public.ecr.aws", "username": "username", "password": "password"}
+			]
+		},
+		"pki": {
+			"registry-mirror-cache-internal-5000": {"data": "Y2FjaGUtY2E=", "trusted": true}
+		}
+	}`))
+}
+
+func TestSettingsNoAuth(t *testing.T) {
+	g := NewWithT(t)
+	r := &registrymirror.RegistryMirror{
+		BaseRegistry: "harbor.eksa.demo:30003",
+		NamespacedRegistryMap: map[string]string{
+			constants.DefaultCoreEKSARegistry: "harbor.eksa.demo:30003",
+		},
+		UpstreamMirrors: map[string][]registrymirror.Mirror{
+			"ghcr.io": {{Endpoint: "cache.internal"}},
+		},
+	}
+
+	settings, err := bottlerocket.Settings(r)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(settings).To(MatchJSON(`{
+		"container-registry": {
+			"mirrors": {
+				"public.ecr.aws": ["https://harbor.eksa.demo:30003"],
+				"ghcr.io": ["https://cache.internal"]
+			}
+		}
+	}`))
+}
+
+func TestSettingsMissingCredentials(t *testing.T) {
+	g := NewWithT(t)
+	r := &registrymirror.RegistryMirror{
+		BaseRegistry: "harbor.eksa.demo:30003",
+		UpstreamMirrors: map[string][]registrymirror.Mirror{
+			"ghcr.io": {{Endpoint: "cache.internal", Auth: true}},
+		},
+	}
+
+	_, err := bottlerocket.Settings(r)
+	g.Expect(err).To(MatchError(ContainSubstring("reading credentials of the ghcr.io registry mirror cache.internal: please set REGISTRY_USERNAME_GHCR_IO env var")))
+}
+
+func TestSettingsDifferentCredentialsSameHost(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv("REGISTRY_USERNAME_GHCR_IO", "ghcr-username")
+	t.Setenv("REGISTRY_PASSWORD_GHCR_IO", "ghcr-password")
+	t.Setenv("REGISTRY_USERNAME_QUAY_IO", "quay-username")
+	t.Setenv("REGISTRY_PASSWORD_QUAY_IO", "quay-password")
+	r := &registrymirror.RegistryMirror{
+		BaseRegistry: "harbor.eksa.demo:30003",
+		UpstreamMirrors: map[string][]registrymirror.Mirror{
+			"ghcr.io": {{Endpoint: "cache.internal/ghcr", Auth: true}},
+			"quay.io": {{Endpoint: "cache.internal/quay", Auth: true}},
+		},
+	}
+
+	_, err := bottlerocket.Settings(r)
+	g.Expect(err).To(MatchError("registry mirror cache.internal has credentials different from other mirrors in the same host"))
+}
+
+func TestSettingsDifferentCACertsSameHost(t *testing.T) {
+	g := NewWithT(t)
+	r := &registrymirror.RegistryMirror{
+		BaseRegistry: "harbor.eksa.demo:30003",
+		UpstreamMirrors: map[string][]registrymirror.Mirror{
+			"ghcr.io": {{Endpoint: "cache.internal/ghcr", CACertContent: "ca-1"}},
+			"quay.io": {{Endpoint: "cache.internal/quay", CACertContent: "ca-2"}},
+		},
+	}
+
+	_, err := bottlerocket.Settings(r)
+	g.Expect(err).To(MatchError(ContainSubstring("has a CA certificate different from other mirrors in the same host")))
+}
+
+func TestSettingsWithCredentials(t *testing.T) {
+	g := NewWithT(t)
+	r := &registrymirror.RegistryMirror{
+		BaseRegistry: "harbor.eksa.demo:30003",
+		NamespacedRegistryMap: map[string]string{
+			constants.DefaultCoreEKSARegistry: "harbor.eksa.demo:30003/eks-anywhere",
+		},
+		Auth: true,
+		UpstreamMirrors: map[string][]registrymirror.Mirror{
+			"quay.io": {{Endpoint: "cache.internal/quay", Auth: true}},
+		},
+	}
+	credentials := func(upstream string, m registrymirror.Mirror) (string, string, error) {
+		if m.Base {
+			return "rotated-username", "rotated-password", nil
+		}
+		return upstream + "-username", upstream + "-password", nil
+	}
+
+	settings, err := bottlerocket.SettingsWithCredentials(r, credentials)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(settings).To(MatchJSON(`{
+		"container-registry": {
+			"mirrors": {
+				"public.ecr.aws": ["https://harbor.eksa.demo:30003/v2/eks-anywhere"],
+				"quay.io": ["https://cache.internal/v2/quay"]
+			},
+			"credentials": [
+				{"registry": "cache.internal", "username": "quay.io-username", "password": "quay.io-password"},
+				{"registry": "harbor.eksa.demo:30003", "username": "rotated-username", "password": "rotated-password"},
+				{"registry": "harbor.eksa.demo:30003/v2/eks-anywhere", "username": "rotated-username", "password": "rotated-password"},
+				{"registry": "public.ecr.aws", "username": "rotated-username", "password": "rotated-password"}
+			]
+		}
+	}`))
+}
@@ -0,0 +1,103 @@
+package containerd
+
+import (
+	"encoding/base64"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/aws/eks-anywhere/pkg/registrymirror"
+)
+
+// HostsDir is the directory containerd reads the configuration of the registry hosts from
+// when its registry config_path is set.
+const HostsDir = "/etc/containerd/certs.d"
+
+// dockerHubServer is the registry API endpoint of docker.io.
+const dockerHubServer = "registry-1.docker.io"
+
+// File is a file of the containerd registry hosts configuration.
+type File struct {
+	Path    string
+	Content string
+}
+
+// HostsFiles renders a hosts.toml file for every upstream registry with a mirror and the CA
+// certificates of the upstream mirrors. The registry mirror CA certificate isn't included since
+// it's rendered with the rest of the registry mirror configuration.
+// Mirrors are tried in order and images are pulled from the upstream registry when all of them fail.
+// Credentials of the upstream mirrors are read from the env, which the controller sets from the
+// registry-credentials Secret in the same way it does for the registry mirror credentials.
+func HostsFiles(r *registrymirror.RegistryMirror) ([]File, error) {
+	mirrors := r.Mirrors()
+	upstreams := make([]string, 0, len(mirrors))
+	for upstream := range mirrors {
+		upstreams = append(upstreams, upstream)
+	}
+	sort.Strings(upstreams)
+
+	caCerts := map[string]string{}
+	if r.CACertContent != "" {
+		caCerts[caCertPath(r.BaseRegistry)] = r.CACertContent
+	}
+
+	var hostsFiles, caFiles []File
+	for _, upstream := range upstreams {
+		b := &strings.Builder{}
+		fmt.Fprintf(b, "server = %q\n", "https://"+server(upstream))
+		for _, m := range mirrors[upstream] {
+			host := "https://" + ToAPIEndpoint(m.Endpoint)
+			fmt.Fprintf(b, "[host.%q]\n", host)
+			b.WriteString("  capabilities = [\"pull\", \"resolve\"]\n")
+			if strings.Contains(m.Endpoint, "/") {
+				b.WriteString("  override_path = true\n")
+			}
+			if m.CACertContent != "" {
+				caPath := caCertPath(strings.SplitN(m.Endpoint, "/", 2)[0])
+				if content, ok := caCerts[caPath]; !ok {
+					caCerts[caPath] = m.CACertContent
+					caFiles = append(caFiles, File{Path: caPath, Content: m.CACertContent})
+				} else if content != m.CACertContent {
+					return nil, fmt.Errorf("registry mirror %s has a CA certificate different from other mirrors in the same host", m.Endpoint)
+				}
+				fmt.Fprintf(b, "  ca = %q\n", caPath)
+			}
+			if m.InsecureSkipVerify {
+				b.WriteString("  skip_verify = true\n")
+			}
+			if m.Auth {
+				auth, err := basicAuth(upstream, m)
+				if err != nil {
+					return nil, err
+				}
+				fmt.Fprintf(b, "  [host.%q.header]\n    authorization = %q\n", host, auth)
+			}
+		}
+		hostsFiles = append(hostsFiles, File{
+			Path:    path.Join(HostsDir, upstream, "hosts.toml"),
+			Content: strings.TrimSuffix(b.String(), "\n"),
+		})
+	}
+
+	return append(hostsFiles, caFiles...), nil
+}
+
+func server(upstream string) string {
+	if upstream == "docker.io" {
+		return dockerHubServer
+	}
+	return upstream
+}
+
+func caCertPath(host string) string {
+	return path.Join(HostsDir, host, "ca.crt")
+}
+
+func basicAuth(upstream string, m registrymirror.Mirror) (string, error) {
+	username, password, err := m.Credentials(upstream)
+	if err != nil {
+		return "", fmt.Errorf("reading credentials of the %s registry mirror %s: %v", upstream, m.Endpoint, err)
+	}
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+password)), nil
+}
@@ -5,9 +5,11 @@ import (
 	urllib "net/url"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/config"
 	"github.com/aws/eks-anywhere/pkg/constants"
 )
 
@@ -24,6 +26,22 @@ type RegistryMirror struct {
 	// InsecureSkipVerify skips the registry certificate verification.
 	// Only use this solution for isolated testing or in a tightly controlled, air-gapped environment.
 	InsecureSkipVerify bool
+	// UpstreamMirrors stores the additional mirrors of upstream registries, sorted by priority.
+	UpstreamMirrors map[string][]Mirror
+}
+
+// Mirror is a registry mirror for an upstream registry.
+type Mirror struct {
+	// Endpoint is the address of the mirror with an optional port and namespace.
+	Endpoint string
+	// CACertContent defines the contents of the mirror CA certificate.
+	CACertContent string
+	// Auth should be marked as true if authentication is required for the mirror.
+	Auth bool
+	// InsecureSkipVerify skips the mirror certificate verification.
+	InsecureSkipVerify bool
+	// Base is true for the registry mirror endpoint, which uses the registry mirror credentials.
+	Base bool
 }
 
 var re = regexp.MustCompile(constants.DefaultCuratedPackagesRegistryRegex)
@@ -63,7 +81,79 @@ func FromClusterRegistryMirrorConfiguration(config *v1alpha1.RegistryMirrorConfi
 		Auth:                  config.Authenticate,
 		CACertContent:         config.CACertContent,
 		InsecureSkipVerify:    config.InsecureSkipVerify,
+		UpstreamMirrors:       upstreamMirrors(config.Mirrors),
+	}
+}
+
+func upstreamMirrors(mirrors []v1alpha1.UpstreamRegistryMirror) map[string][]Mirror {
+	if len(mirrors) == 0 {
+		return nil
+	}
+	sorted := make([]v1alpha1.UpstreamRegistryMirror, len(mirrors))
+	copy(sorted, mirrors)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Priority < sorted[j].Priority
+	})
+
+	upstreamMirrors := make(map[string][]Mirror)
+	for _, m := range sorted {
+		upstreamMirrors[m.Upstream] = append(upstreamMirrors[m.Upstream], Mirror{
+			Endpoint:           m.Endpoint,
+			CACertContent:      m.CACertContent,
+			Auth:               m.Authenticate,
+			InsecureSkipVerify: m.InsecureSkipVerify,
+		})
+	}
+	return upstreamMirrors
+}
+
+// Mirrors returns the mirrors of every upstream registry in the order they should be tried:
+// the registry mirror endpoint first, followed by the upstream mirrors sorted by priority.
+// The curated packages mapping isn't included since it matches a pattern instead of a registry.
+func (r *RegistryMirror) Mirrors() map[string][]Mirror {
+	mirrors := make(map[string][]Mirror)
+	for upstream, endpoint := range r.NamespacedRegistryMap {
+		if upstream == constants.DefaultCuratedPackagesRegistryRegex {
+			continue
+		}
+		mirrors[upstream] = []Mirror{{
+			Endpoint:           endpoint,
+			CACertContent:      r.CACertContent,
+			Auth:               r.Auth,
+			InsecureSkipVerify: r.InsecureSkipVerify,
+			Base:               true,
+		}}
+	}
+	for upstream, upstreamMirrors := range r.UpstreamMirrors {
+		mirrors[upstream] = append(mirrors[upstream], upstreamMirrors...)
+	}
+	return mirrors
+}
+
+// AuthenticatedUpstreams returns the sorted upstream registries with at least one upstream mirror
+// that requires authentication.
+func (r *RegistryMirror) AuthenticatedUpstreams() []string {
+	var upstreams []string
+	for upstream, mirrors := range r.UpstreamMirrors {
+		for _, m := range mirrors {
+			if m.Auth {
+				upstreams = append(upstreams, upstream)
+				break
+			}
+		}
+	}
+	sort.Strings(upstreams)
+	return upstreams
+}
+
+// Credentials returns the username and password of a mirror of the upstream registry from the env.
+// The registry mirror endpoint uses the registry mirror credentials and the upstream mirrors the
+// credentials of their upstream registry.
+func (m Mirror) Credentials(upstream string) (username, password string, err error) {
+	if m.Base {
+		return config.ReadCredentials()
 	}
+	return config.ReadUpstreamMirrorCredentials(upstream)
 }
 
 // CoreEKSAMirror returns the configured mirror for public.ecr.aws.
@@ -385,3 +385,90 @@ func TestReplaceRegistry(t *testing.T) {
 		})
 	}
 }
+
+func TestFromClusterRegistryMirrorConfigurationUpstreamMirrors(t *testing.T) {
+	g := NewWithT(t)
+	config := &v1alpha1.RegistryMirrorConfiguration{
+		Endpoint: "harbor.eksa.demo",
+		Port:     "30003",
+		Mirrors: []v1alpha1.UpstreamRegistryMirror{
+			{Upstream: "docker.io", Endpoint: "mirror.internal", Priority: 2},
+			{Upstream: "quay.io", Endpoint: "cache.internal:5000/quay", Authenticate: true},
+			{Upstream: "docker.io", Endpoint: "cache.internal:5000/dockerhub", Priority: 1, InsecureSkipVerify: true},
+			{Upstream: "docker.io", Endpoint: "backup.internal", Priority: 2, CACertContent: "ca"},
+		},
+	}
+
+	g.Expect(registrymirror.FromClusterRegistryMirrorConfiguration(config).UpstreamMirrors).To(Equal(map[string][]registrymirror.Mirror{
+		"docker.io": {
+			{Endpoint: "cache.internal:5000/dockerhub", InsecureSkipVerify: true},
+			{Endpoint: "mirror.internal"},
+			{Endpoint: "backup.internal", CACertContent: "ca"},
+		},
+		"quay.io": {
+			{Endpoint: "cache.internal:5000/quay", Auth: true},
+		},
+	}))
+}
+
+func TestRegistryMirrorMirrors(t *testing.T) {
+	g := NewWithT(t)
+	r := &registrymirror.RegistryMirror{
+		BaseRegistry: "harbor.eksa.demo:30003",
+		NamespacedRegistryMap: map[string]string{
+			constants.DefaultCoreEKSARegistry:             "harbor.eksa.demo:30003/eks-anywhere",
+			constants.DefaultCuratedPackagesRegistryRegex: "harbor.eksa.demo:30003/curated-packages",
+			"docker.io": "harbor.eksa.demo:30003/dockerhub",
+		},
+		Auth:          true,
+		CACertContent: "ca",
+		UpstreamMirrors: map[string][]registrymirror.Mirror{
+			"docker.io": {{Endpoint: "cache.internal:5000/dockerhub"}},
+			"quay.io":   {{Endpoint: "cache.internal:5000/quay"}},
+		},
+	}
+
+	g.Expect(r.Mirrors()).To(Equal(map[string][]registrymirror.Mirror{
+		constants.DefaultCoreEKSARegistry: {
+			{Endpoint: "harbor.eksa.demo:30003/eks-anywhere", CACertContent: "ca", Auth: true, Base: true},
+		},
+		"docker.io": {
+			{Endpoint: "harbor.eksa.demo:30003/dockerhub", CACertContent: "ca", Auth: true, Base: true},
+			{Endpoint: "cache.internal:5000/dockerhub"},
+		},
+		"quay.io": {
+			{Endpoint: "cache.internal:5000/quay"},
+		},
+	}))
+}
+
+func TestRegistryMirrorAuthenticatedUpstreams(t *testing.T) {
+	g := NewWithT(t)
+	r := &registrymirror.RegistryMirror{
+		UpstreamMirrors: map[string][]registrymirror.Mirror{
+			"quay.io":   {{Endpoint: "cache.internal:5000/quay", Auth: true}},
+			"docker.io": {{Endpoint: "cache.internal:5000/dockerhub"}, {Endpoint: "backup.internal", Auth: true}},
+			"ghcr.io":   {{Endpoint: "cache.internal:5000/ghcr"}},
+		},
+	}
+
+	g.Expect(r.AuthenticatedUpstreams()).To(Equal([]string{"docker.io", "quay.io"}))
+}
+
+func TestMirrorCredentials(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv("REGISTRY_USERNAME", "username")
+	t.Setenv("REGISTRY_PASSWORD", "password")
+	t.Setenv("REGISTRY_USERNAME_QUAY_IO", "quay-username")
+	t.Setenv("REGISTRY_PASSWORD_QUAY_IO", "quay-password")
+
+	username, password, err := registrymirror.Mirror{Base: true}.Credentials("quay.io")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(username).To(Equal("username"))
+	g.Expect(password).To(Equal("password"))
+
+	username, password, err = registrymirror.Mirror{}.Credentials("quay.io")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(username).To(Equal("quay-username"))
+	g.Expect(password).To(Equal("quay-password"))
+}
@@ -4,6 +4,7 @@ import (
 	"math"
 	"time"
 
+	"github.com/aws/eks-anywhere/pkg/events"
 	"github.com/aws/eks-anywhere/pkg/logger"
 )
 
@@ -88,6 +89,7 @@ func (r *Retrier) Retry(fn func() error) error {
 			return nil
 		}
 		logger.V(5).Info("Error happened during retry", "error", err, "retries", retries)
+		events.RetryAttemptFailed(retries, err)
 
 		retry, wait := r.retryPolicy(retries, err)
 		if !retry {
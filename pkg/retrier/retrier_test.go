@@ -1,10 +1,15 @@
 package retrier_test
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/aws/eks-anywhere/pkg/events"
 	"github.com/aws/eks-anywhere/pkg/retrier"
 )
 
@@ -197,3 +202,36 @@ func TestRetrierWithNilReceiver(t *testing.T) {
 		t.Errorf("Retrier didn't correctly handle nil receiver")
 	}
 }
+
+func TestRetryEmitsEvents(t *testing.T) {
+	buf := &bytes.Buffer{}
+	events.Set(events.NewEmitter(buf, "create"))
+	t.Cleanup(func() { events.Set(nil) })
+
+	r := retrier.NewWithMaxRetries(3, 0)
+	calls := 0
+	err := r.Retry(func() error {
+		calls += 1
+		if calls == 3 {
+			return nil
+		}
+		return fmt.Errorf("attempt %d failed", calls)
+	})
+	if err != nil {
+		t.Fatalf("Retrier.Retry() error = %v, want nil", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Retrier.Retry() emitted %d events, want 2", len(lines))
+	}
+	for i, line := range lines {
+		e := events.Event{}
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			t.Fatal(err)
+		}
+		if e.Type != events.Retry || e.Attempt != i+1 || e.Error != fmt.Sprintf("attempt %d failed", i+1) {
+			t.Fatalf("Retrier.Retry() event = %+v, want retry event for attempt %d", e, i+1)
+		}
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"sigs.k8s.io/yaml"
 
 	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/events"
 	"github.com/aws/eks-anywhere/pkg/filewriter"
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/providers"
@@ -111,13 +112,16 @@ func (pp *Profiler) logProfileSummary(taskName string) {
 
 // Manages Task execution.
 type taskRunner struct {
-	task           Task
-	writer         filewriter.FileWriter
-	withCheckpoint bool
+	task               Task
+	writer             filewriter.FileWriter
+	withCheckpoint     bool
+	checkpointFileName string
 }
 
 type TaskRunnerOpt func(*taskRunner)
 
+// WithCheckpointFile enables checkpoints. The checkpoint is saved after every completed task, so a
+// new run can restore the completed tasks and resume at the first one that didn't finish.
 func WithCheckpointFile() TaskRunnerOpt {
 	return func(t *taskRunner) {
 		logger.V(4).Info("Checkpoint feature enabled")
@@ -125,8 +129,20 @@ func WithCheckpointFile() TaskRunnerOpt {
 	}
 }
 
+// WithCheckpointFileName enables checkpoints and sets the name of the checkpoint file, which defaults to
+// <cluster-name>-checkpoint.yaml. It allows workflows that use the same task names to keep separate checkpoints.
+func WithCheckpointFileName(name string) TaskRunnerOpt {
+	return func(t *taskRunner) {
+		WithCheckpointFile()(t)
+		t.checkpointFileName = name
+	}
+}
+
 func (tr *taskRunner) RunTask(ctx context.Context, commandContext *CommandContext) error {
-	checkpointFileName := fmt.Sprintf("%s-checkpoint.yaml", commandContext.ClusterSpec.Cluster.Name)
+	checkpointFileName := tr.checkpointFileName
+	if checkpointFileName == "" {
+		checkpointFileName = fmt.Sprintf("%s-checkpoint.yaml", commandContext.ClusterSpec.Cluster.Name)
+	}
 	var checkpointInfo CheckpointInfo
 	var err error
 
@@ -145,8 +161,10 @@ func (tr *taskRunner) RunTask(ctx context.Context, commandContext *CommandContex
 	}
 
 	for task != nil {
-		if completedTask, ok := checkpointInfo.CompletedTasks[task.Name()]; ok {
-			logger.V(4).Info("Restoring task", "task_name", task.Name())
+		taskName := task.Name()
+		if completedTask, ok := checkpointInfo.CompletedTasks[taskName]; ok {
+			logger.V(4).Info("Restoring task", "task_name", taskName)
+			events.TaskRestore(commandContext.ClusterSpec.Cluster.Name, taskName)
 			nextTask, err := task.Restore(ctx, commandContext, completedTask)
 			if err != nil {
 				return fmt.Errorf("restoring checkpoint info: %v", err)
@@ -154,13 +172,21 @@ func (tr *taskRunner) RunTask(ctx context.Context, commandContext *CommandContex
 			task = nextTask
 			continue
 		}
-		logger.V(4).Info("Task start", "task_name", task.Name())
-		commandContext.Profiler.SetStartTask(task.Name())
+		logger.V(4).Info("Task start", "task_name", taskName)
+		events.TaskStart(commandContext.ClusterSpec.Cluster.Name, taskName)
+		previousError := commandContext.OriginalError
+		commandContext.Profiler.SetStartTask(taskName)
 		nextTask := task.Run(ctx, commandContext)
-		commandContext.Profiler.MarkDoneTask(task.Name())
-		commandContext.Profiler.logProfileSummary(task.Name())
+		commandContext.Profiler.MarkDoneTask(taskName)
+		commandContext.Profiler.logProfileSummary(taskName)
+		tr.emitTaskEnd(commandContext, taskName, previousError)
 		if commandContext.OriginalError == nil {
-			checkpointInfo.taskCompleted(task.Name(), task.Checkpoint())
+			checkpointInfo.taskCompleted(taskName, task.Checkpoint())
+			if tr.withCheckpoint {
+				if err := tr.saveCheckpoint(checkpointInfo, checkpointFileName); err != nil {
+					return err
+				}
+			}
 		}
 		task = nextTask
 	}
@@ -168,8 +194,22 @@ func (tr *taskRunner) RunTask(ctx context.Context, commandContext *CommandContex
 		if err := tr.saveCheckpoint(checkpointInfo, checkpointFileName); err != nil {
 			return err
 		}
+		return commandContext.OriginalError
+	}
+	if tr.withCheckpoint {
+		return tr.removeCheckpoint(commandContext, checkpointFileName)
 	}
-	return commandContext.OriginalError
+	return nil
+}
+
+// emitTaskEnd emits the end of a task. Tasks running after a failure, like the ones collecting
+// diagnostics, only fail if they set the error themselves.
+func (tr *taskRunner) emitTaskEnd(commandContext *CommandContext, taskName string, previousError error) {
+	var err error
+	if previousError == nil {
+		err = commandContext.OriginalError
+	}
+	events.TaskEnd(commandContext.ClusterSpec.Cluster.Name, taskName, commandContext.Profiler.Metrics()[taskName][taskName], err)
 }
 
 func taskRunnerFinalBlock(startTime time.Time) {
@@ -201,6 +241,16 @@ func (tr *taskRunner) saveCheckpoint(checkpointInfo CheckpointInfo, filename str
 	return nil
 }
 
+// removeCheckpoint deletes the checkpoint file once all the tasks have completed, so the next run starts from the beginning.
+func (tr *taskRunner) removeCheckpoint(commandContext *CommandContext, filename string) error {
+	checkpointFilePath := filepath.Join(commandContext.Writer.TempDir(), filename)
+	logger.V(4).Info("Removing checkpoint", "file", checkpointFilePath)
+	if err := os.Remove(checkpointFilePath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("removing task runner checkpoint: %v", err)
+	}
+	return nil
+}
+
 func (tr *taskRunner) setupCheckpointInfo(commandContext *CommandContext, checkpointFileName string) (CheckpointInfo, error) {
 	checkpointInfo := newCheckpointInfo()
 	if tr.withCheckpoint {
@@ -1,16 +1,24 @@
 package task_test
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/golang/mock/gomock"
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/events"
 	"github.com/aws/eks-anywhere/pkg/features"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
 	writermocks "github.com/aws/eks-anywhere/pkg/filewriter/mocks"
 	"github.com/aws/eks-anywhere/pkg/task"
 	mocktasks "github.com/aws/eks-anywhere/pkg/task/mocks"
@@ -21,13 +29,13 @@ func TestTaskRunnerRunTask(t *testing.T) {
 	tr := newTaskRunnerTest(t)
 
 	tr.taskA.EXPECT().Run(tr.ctx, tr.cmdContext).Return(tr.taskB).Times(1)
-	tr.taskA.EXPECT().Name().Return("taskA").Times(7)
+	tr.taskA.EXPECT().Name().Return("taskA").Times(2)
 	tr.taskA.EXPECT().Checkpoint()
 	tr.taskB.EXPECT().Run(tr.ctx, tr.cmdContext).Return(tr.taskC).Times(1)
-	tr.taskB.EXPECT().Name().Return("taskB").Times(7)
+	tr.taskB.EXPECT().Name().Return("taskB").Times(2)
 	tr.taskB.EXPECT().Checkpoint()
 	tr.taskC.EXPECT().Run(tr.ctx, tr.cmdContext).Return(nil).Times(1)
-	tr.taskC.EXPECT().Name().Return("taskC").Times(7)
+	tr.taskC.EXPECT().Name().Return("taskC").Times(2)
 	tr.taskC.EXPECT().Checkpoint()
 
 	type fields struct {
@@ -63,14 +71,16 @@ func TestTaskRunnerRunTaskWithCheckpointSecondRunSuccess(t *testing.T) {
 	tt := newTaskRunnerTest(t)
 
 	tt.taskA.EXPECT().Restore(tt.ctx, tt.cmdContext, gomock.Any()).Return(tt.taskB, nil)
-	tt.taskA.EXPECT().Name().Return("taskA").Times(2)
+	tt.taskA.EXPECT().Name().Return("taskA").Times(1)
 	tt.taskB.EXPECT().Run(tt.ctx, tt.cmdContext).Return(tt.taskC).Times(1)
-	tt.taskB.EXPECT().Name().Return("taskB").Times(6)
+	tt.taskB.EXPECT().Name().Return("taskB").Times(1)
 	tt.taskB.EXPECT().Checkpoint()
 	tt.taskC.EXPECT().Run(tt.ctx, tt.cmdContext).Return(nil).Times(1)
-	tt.taskC.EXPECT().Name().Return("taskC").Times(6)
+	tt.taskC.EXPECT().Name().Return("taskC").Times(1)
 	tt.taskC.EXPECT().Checkpoint()
-	tt.writer.EXPECT().TempDir().Return("testdata")
+	dir := checkpointDir(t, "testdata/test-cluster-checkpoint.yaml")
+	tt.writer.EXPECT().TempDir().Return(dir).Times(2)
+	tt.writer.EXPECT().Write("test-cluster-checkpoint.yaml", gomock.Any()).Times(2)
 
 	tasks := []task.Task{tt.taskA, tt.taskB, tt.taskC}
 
@@ -85,12 +95,70 @@ func TestTaskRunnerRunTaskWithCheckpointSecondRunSuccess(t *testing.T) {
 	}
 }
 
+func TestTaskRunnerRunTaskWithCheckpointSavesEveryCompletedTask(t *testing.T) {
+	tt := newTaskRunnerTest(t)
+	dir := t.TempDir()
+	checkpointFile := filepath.Join(dir, "test-cluster-checkpoint.yaml")
+
+	tt.taskA.EXPECT().Run(tt.ctx, tt.cmdContext).Return(tt.taskB)
+	tt.taskA.EXPECT().Name().Return("taskA").AnyTimes()
+	tt.taskA.EXPECT().Checkpoint().Return(&task.CompletedTask{Checkpoint: "a"})
+	tt.taskB.EXPECT().Run(tt.ctx, tt.cmdContext).DoAndReturn(func(_ context.Context, c *task.CommandContext) task.Task {
+		c.SetError(errors.New("task B failed"))
+		return nil
+	})
+	tt.taskB.EXPECT().Name().Return("taskB").AnyTimes()
+	tt.writer.EXPECT().TempDir().Return(dir)
+	tt.writer.EXPECT().Write("test-cluster-checkpoint.yaml", gomock.Any()).DoAndReturn(func(name string, content []byte, _ ...filewriter.FileOptionsFunc) (string, error) {
+		return checkpointFile, os.WriteFile(checkpointFile, content, 0o600)
+	}).Times(2)
+
+	runner := task.NewTaskRunner(tt.taskA, tt.writer, task.WithCheckpointFile())
+	if err := runner.RunTask(tt.ctx, tt.cmdContext); err == nil {
+		t.Fatal("Task.RunTask want err, got nil")
+	}
+
+	content, err := os.ReadFile(checkpointFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "completedTasks:\n  taskA:\n    checkpoint: a\n"
+	if string(content) != want {
+		t.Fatalf("checkpoint file = %s, want %s", content, want)
+	}
+}
+
+func TestTaskRunnerRunTaskWithCheckpointFileNameRemovedOnSuccess(t *testing.T) {
+	tt := newTaskRunnerTest(t)
+	dir := checkpointDir(t, "testdata/test-cluster-checkpoint.yaml")
+	if err := os.Rename(filepath.Join(dir, "test-cluster-checkpoint.yaml"), filepath.Join(dir, "test-cluster-delete-checkpoint.yaml")); err != nil {
+		t.Fatal(err)
+	}
+
+	tt.taskA.EXPECT().Restore(tt.ctx, tt.cmdContext, gomock.Any()).Return(tt.taskB, nil)
+	tt.taskA.EXPECT().Name().Return("taskA").AnyTimes()
+	tt.taskB.EXPECT().Run(tt.ctx, tt.cmdContext).Return(nil)
+	tt.taskB.EXPECT().Name().Return("taskB").AnyTimes()
+	tt.taskB.EXPECT().Checkpoint()
+	tt.writer.EXPECT().TempDir().Return(dir).Times(2)
+	tt.writer.EXPECT().Write("test-cluster-delete-checkpoint.yaml", gomock.Any())
+
+	runner := task.NewTaskRunner(tt.taskA, tt.writer, task.WithCheckpointFileName("test-cluster-delete-checkpoint.yaml"))
+	if err := runner.RunTask(tt.ctx, tt.cmdContext); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "test-cluster-delete-checkpoint.yaml")); !os.IsNotExist(err) {
+		t.Fatalf("checkpoint file should be removed after a successful run, got %v", err)
+	}
+}
+
 func TestTaskRunnerRunTaskWithCheckpointFirstRunFailed(t *testing.T) {
 	tt := newTaskRunnerTest(t)
 	tt.cmdContext.OriginalError = fmt.Errorf("error")
 
 	tt.taskA.EXPECT().Run(tt.ctx, tt.cmdContext).Return(nil)
-	tt.taskA.EXPECT().Name().Return("taskA").Times(5)
+	tt.taskA.EXPECT().Name().Return("taskA").Times(1)
 	tt.writer.EXPECT().TempDir()
 	tt.writer.EXPECT().Write(fmt.Sprintf("%s-checkpoint.yaml", tt.cmdContext.ClusterSpec.Cluster.Name), gomock.Any())
 
@@ -110,7 +178,7 @@ func TestTaskRunnerRunTaskWithCheckpointSecondRunRestoreFailure(t *testing.T) {
 	tt := newTaskRunnerTest(t)
 
 	tt.taskA.EXPECT().Restore(tt.ctx, tt.cmdContext, gomock.Any()).Return(nil, fmt.Errorf("error"))
-	tt.taskA.EXPECT().Name().Return("taskA").Times(2)
+	tt.taskA.EXPECT().Name().Return("taskA").Times(1)
 	tt.writer.EXPECT().TempDir().Return("testdata")
 
 	tasks := []task.Task{tt.taskA, tt.taskB, tt.taskC}
@@ -131,7 +199,7 @@ func TestTaskRunnerRunTaskWithCheckpointSaveFailed(t *testing.T) {
 	tt.cmdContext.OriginalError = fmt.Errorf("error")
 
 	tt.taskA.EXPECT().Run(tt.ctx, tt.cmdContext).Return(nil)
-	tt.taskA.EXPECT().Name().Return("taskA").Times(5)
+	tt.taskA.EXPECT().Name().Return("taskA").Times(1)
 	tt.writer.EXPECT().TempDir()
 	tt.writer.EXPECT().Write(fmt.Sprintf("%s-checkpoint.yaml", tt.cmdContext.ClusterSpec.Cluster.Name), gomock.Any()).Return("", fmt.Errorf("error"))
 
@@ -180,6 +248,19 @@ func TestUnmarshalTaskCheckpointSuccess(t *testing.T) {
 	}
 }
 
+// checkpointDir copies a checkpoint file to a temporary directory, since successful runs remove it.
+func checkpointDir(t *testing.T, file string) string {
+	dir := t.TempDir()
+	content, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, filepath.Base(file)), content, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
 type taskRunnerTest struct {
 	ctx        context.Context
 	cmdContext *task.CommandContext
@@ -215,3 +296,48 @@ func newTaskRunnerTest(t *testing.T) *taskRunnerTest {
 		writer:     writer,
 	}
 }
+
+func TestTaskRunnerRunTaskEmitsEvents(t *testing.T) {
+	tt := newTaskRunnerTest(t)
+	buf := &bytes.Buffer{}
+	events.Set(events.NewEmitter(buf, "create"))
+	t.Cleanup(func() { events.Set(nil) })
+
+	tt.taskA.EXPECT().Name().Return("taskA")
+	tt.taskA.EXPECT().Run(tt.ctx, tt.cmdContext).Return(tt.taskB)
+	tt.taskA.EXPECT().Checkpoint()
+	tt.taskB.EXPECT().Name().Return("taskB")
+	tt.taskB.EXPECT().Run(tt.ctx, tt.cmdContext).DoAndReturn(func(_ context.Context, c *task.CommandContext) task.Task {
+		c.SetError(errors.New("failed"))
+		return tt.taskC
+	})
+	tt.taskC.EXPECT().Name().Return("taskC")
+	tt.taskC.EXPECT().Run(tt.ctx, tt.cmdContext).Return(nil)
+	tt.writer.EXPECT().Write("test-cluster-checkpoint.yaml", gomock.Any())
+
+	runner := task.NewTaskRunner(tt.taskA, tt.writer)
+	if err := runner.RunTask(tt.ctx, tt.cmdContext); err == nil {
+		t.Fatalf("Task.RunTask want err, got nil")
+	}
+
+	var got []string
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		e := events.Event{}
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, fmt.Sprintf("%s %s %s %s", e.Type, e.Task, e.Result, e.Error))
+	}
+
+	want := []string{
+		"TaskStarted taskA  ",
+		"TaskFinished taskA Success ",
+		"TaskStarted taskB  ",
+		"TaskFinished taskB Failure failed",
+		"TaskStarted taskC  ",
+		"TaskFinished taskC Success ",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("RunTask() events = %v, want %v", got, want)
+	}
+}
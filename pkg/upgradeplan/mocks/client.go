@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/upgradeplan/plan.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
+// MockKubernetesClient is a mock of KubernetesClient interface.
+type MockKubernetesClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockKubernetesClientMockRecorder
+}
+
+// MockKubernetesClientMockRecorder is the mock recorder for MockKubernetesClient.
+type MockKubernetesClientMockRecorder struct {
+	mock *MockKubernetesClient
+}
+
+// NewMockKubernetesClient creates a new mock instance.
+func NewMockKubernetesClient(ctrl *gomock.Controller) *MockKubernetesClient {
+	mock := &MockKubernetesClient{ctrl: ctrl}
+	mock.recorder = &MockKubernetesClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKubernetesClient) EXPECT() *MockKubernetesClientMockRecorder {
+	return m.recorder
+}
+
+// GetObject mocks base method.
+func (m *MockKubernetesClient) GetObject(ctx context.Context, resourceType, name, namespace, kubeconfig string, obj runtime.Object) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetObject", ctx, resourceType, name, namespace, kubeconfig, obj)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetObject indicates an expected call of GetObject.
+func (mr *MockKubernetesClientMockRecorder) GetObject(ctx, resourceType, name, namespace, kubeconfig, obj interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetObject", reflect.TypeOf((*MockKubernetesClient)(nil).GetObject), ctx, resourceType, name, namespace, kubeconfig, obj)
+}
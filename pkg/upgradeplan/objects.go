@@ -0,0 +1,214 @@
+package upgradeplan
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	apiyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const lastAppliedAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// ChangeType is the kind of change to an object.
+type ChangeType string
+
+const (
+	// Added means the object doesn't exist in the cluster and it will be created.
+	Added ChangeType = "Added"
+	// Modified means the object exists in the cluster and it will be updated.
+	Modified ChangeType = "Modified"
+)
+
+// ObjectDiff is the change to a single object, with the unified diff between the
+// yaml of the object in the cluster and the new one.
+type ObjectDiff struct {
+	APIVersion string     `json:"apiVersion"`
+	Kind       string     `json:"kind"`
+	Namespace  string     `json:"namespace,omitempty"`
+	Name       string     `json:"name"`
+	Change     ChangeType `json:"change"`
+	Diff       string     `json:"diff,omitempty"`
+}
+
+// parseObjects reads all the objects in a yaml manifest, setting defaultNamespace to the ones without a namespace.
+func parseObjects(manifest []byte, defaultNamespace string) ([]*unstructured.Unstructured, error) {
+	if defaultNamespace == "" {
+		defaultNamespace = "default"
+	}
+
+	reader := apiyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(manifest)))
+	var objs []*unstructured.Unstructured
+	for {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		u := &unstructured.Unstructured{}
+		if err := yaml.Unmarshal(doc, &u.Object); err != nil {
+			return nil, err
+		}
+		if len(u.Object) == 0 {
+			continue
+		}
+		if u.GetNamespace() == "" {
+			u.SetNamespace(defaultNamespace)
+		}
+		objs = append(objs, u)
+	}
+
+	return objs, nil
+}
+
+// objectReader reads objects from the management cluster, caching them so every object is only read once.
+type objectReader struct {
+	client  KubernetesClient
+	cluster *types.Cluster
+	cache   map[string]*unstructured.Unstructured
+}
+
+func newObjectReader(client KubernetesClient, cluster *types.Cluster) *objectReader {
+	return &objectReader{
+		client:  client,
+		cluster: cluster,
+		cache:   map[string]*unstructured.Unstructured{},
+	}
+}
+
+// get returns the object with the given apiVersion, kind, namespace and name or nil if it doesn't exist.
+func (r *objectReader) get(ctx context.Context, apiVersion, kind, namespace, name string) (*unstructured.Unstructured, error) {
+	key := strings.Join([]string{apiVersion, kind, namespace, name}, "/")
+	if o, ok := r.cache[key]; ok {
+		return o, nil
+	}
+
+	o := &unstructured.Unstructured{}
+	err := r.client.GetObject(ctx, resourceType(apiVersion, kind), name, namespace, r.cluster.KubeconfigFile, o)
+	if apierrors.IsNotFound(err) {
+		o = nil
+	} else if err != nil {
+		return nil, fmt.Errorf("reading %s %s/%s: %v", kind, namespace, name, err)
+	}
+	r.cache[key] = o
+
+	return o, nil
+}
+
+// resourceType returns the fully qualified resource type for kubectl, to avoid ambiguities
+// between kinds with the same name in different groups.
+func resourceType(apiVersion, kind string) string {
+	group, version, found := strings.Cut(apiVersion, "/")
+	if !found {
+		return kind
+	}
+
+	return kind + "." + version + "." + group
+}
+
+func objectDiffs(ctx context.Context, current *objectReader, objs []*unstructured.Unstructured) ([]ObjectDiff, error) {
+	diffs := []ObjectDiff{}
+	for _, o := range objs {
+		// Secrets can't be compared without showing their data.
+		if o.GetKind() == "Secret" {
+			continue
+		}
+
+		c, err := current.get(ctx, o.GetAPIVersion(), o.GetKind(), o.GetNamespace(), o.GetName())
+		if err != nil {
+			return nil, err
+		}
+
+		diff := ObjectDiff{
+			APIVersion: o.GetAPIVersion(),
+			Kind:       o.GetKind(),
+			Namespace:  o.GetNamespace(),
+			Name:       o.GetName(),
+		}
+
+		var currentYaml []byte
+		if c == nil {
+			diff.Change = Added
+		} else {
+			diff.Change = Modified
+			if currentYaml, err = yaml.Marshal(normalize(lastApplied(c))); err != nil {
+				return nil, err
+			}
+		}
+
+		newYaml, err := yaml.Marshal(normalize(o))
+		if err != nil {
+			return nil, err
+		}
+
+		if bytes.Equal(currentYaml, newYaml) {
+			continue
+		}
+
+		diff.Diff, err = difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        difflib.SplitLines(string(currentYaml)),
+			B:        difflib.SplitLines(string(newYaml)),
+			FromFile: "current",
+			ToFile:   "new",
+			Context:  3,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("generating diff for %s %s/%s: %v", o.GetKind(), o.GetNamespace(), o.GetName(), err)
+		}
+		diffs = append(diffs, diff)
+	}
+
+	return diffs, nil
+}
+
+// lastApplied returns the object as it was last applied with kubectl, which doesn't include the fields
+// defaulted by the api server and controllers. If it's not available, the object is returned as it is.
+func lastApplied(o *unstructured.Unstructured) *unstructured.Unstructured {
+	config, ok := o.GetAnnotations()[lastAppliedAnnotation]
+	if !ok {
+		return o
+	}
+
+	applied := &unstructured.Unstructured{}
+	if err := applied.UnmarshalJSON([]byte(config)); err != nil {
+		return o
+	}
+	if applied.GetNamespace() == "" {
+		applied.SetNamespace(o.GetNamespace())
+	}
+
+	return applied
+}
+
+// normalize returns a copy of the object without status and the metadata set by the api server,
+// so the objects in the cluster can be compared with the ones generated by the CLI.
+func normalize(o *unstructured.Unstructured) map[string]interface{} {
+	n := o.DeepCopy()
+	delete(n.Object, "status")
+	for _, f := range []string{"creationTimestamp", "generation", "managedFields", "resourceVersion", "uid", "ownerReferences", "finalizers", "selfLink"} {
+		unstructured.RemoveNestedField(n.Object, "metadata", f)
+	}
+
+	annotations := n.GetAnnotations()
+	delete(annotations, lastAppliedAnnotation)
+	if len(annotations) == 0 {
+		unstructured.RemoveNestedField(n.Object, "metadata", "annotations")
+	} else {
+		n.SetAnnotations(annotations)
+	}
+
+	return n.Object
+}
@@ -0,0 +1,171 @@
+// Package upgradeplan analyzes the impact of upgrading a cluster to a new spec before running the upgrade:
+// the components that change version, the nodes that will be rolled out and why, and the
+// changes to the CAPI and EKS-A objects.
+package upgradeplan
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+	"github.com/aws/eks-anywhere/pkg/clustermanager"
+	"github.com/aws/eks-anywhere/pkg/clustermarshaller"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/gitops/argocd"
+	"github.com/aws/eks-anywhere/pkg/gitops/flux"
+	"github.com/aws/eks-anywhere/pkg/networking/cilium"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/templater"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+// KubernetesClient reads objects from a cluster.
+type KubernetesClient interface {
+	GetObject(ctx context.Context, resourceType, name, namespace, kubeconfig string, obj runtime.Object) error
+}
+
+// Plan is the impact analysis of upgrading a cluster.
+type Plan struct {
+	// Components are the core components that change version.
+	Components []types.ComponentChangeDiff `json:"components"`
+	// ControlPlane describes if the control plane nodes will be rolled out.
+	ControlPlane Rollout `json:"controlPlane"`
+	// WorkerNodeGroups describes, for every worker node group, if its nodes will be rolled out.
+	WorkerNodeGroups []Rollout `json:"workerNodeGroups"`
+	// CNIUpgrade is true if the CNI will be upgraded.
+	CNIUpgrade bool `json:"cniUpgrade"`
+	// CAPIMove is true if the CAPI objects will be moved to a bootstrap cluster to run the upgrade.
+	CAPIMove bool `json:"capiMove"`
+	// Diffs are the changes to the CAPI and EKS-A objects in the management cluster.
+	Diffs []ObjectDiff `json:"diffs"`
+}
+
+// UpToDate returns true if the upgrade wouldn't change anything in the cluster.
+func (p *Plan) UpToDate() bool {
+	if len(p.Components) > 0 || len(p.Diffs) > 0 || p.ControlPlane.Rolls || p.CNIUpgrade || p.CAPIMove {
+		return false
+	}
+
+	for _, r := range p.WorkerNodeGroups {
+		if r.Rolls || r.Removed {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Planner builds upgrade plans reading the current state of the cluster from its management cluster.
+type Planner struct {
+	client   KubernetesClient
+	provider providers.Provider
+}
+
+// NewPlanner constructs a new Planner. The provider is used to generate the new CAPI objects,
+// so it should only be allowed to read from the management cluster.
+func NewPlanner(client KubernetesClient, provider providers.Provider) *Planner {
+	return &Planner{
+		client:   client,
+		provider: provider,
+	}
+}
+
+// Plan builds the plan to upgrade a cluster from currentSpec to newSpec. managementCluster is the
+// cluster holding the CAPI and EKS-A objects of the cluster.
+func (p *Planner) Plan(ctx context.Context, managementCluster *types.Cluster, currentSpec, newSpec *cluster.Spec) (*Plan, error) {
+	plan := &Plan{
+		Components: componentsChangeDiff(currentSpec, newSpec, p.provider).ComponentReports,
+		CNIUpgrade: cniUpgrade(currentSpec, newSpec),
+	}
+
+	controlPlaneSpec, workersSpec, err := p.provider.GenerateCAPISpecForUpgrade(ctx, managementCluster, managementCluster, currentSpec, newSpec)
+	if err != nil {
+		return nil, fmt.Errorf("generating capi spec: %v", err)
+	}
+	capiObjs, err := parseObjects(templater.AppendYamlResources(controlPlaneSpec, workersSpec), constants.EksaSystemNamespace)
+	if err != nil {
+		return nil, fmt.Errorf("parsing capi spec: %v", err)
+	}
+
+	eksaSpec, err := clustermarshaller.MarshalClusterSpec(newSpec, p.provider.DatacenterConfig(newSpec), p.provider.MachineConfigs(newSpec))
+	if err != nil {
+		return nil, fmt.Errorf("marshalling eks-a cluster resources: %v", err)
+	}
+	eksaObjs, err := parseObjects(eksaSpec, newSpec.Cluster.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("parsing eks-a cluster resources: %v", err)
+	}
+
+	current := newObjectReader(p.client, managementCluster)
+	newObjs := newObjectSet(capiObjs)
+
+	if plan.ControlPlane, err = controlPlaneRollout(ctx, current, newObjs, newSpec); err != nil {
+		return nil, err
+	}
+	if plan.WorkerNodeGroups, err = workerNodeGroupsRollouts(ctx, current, newObjs, currentSpec, newSpec); err != nil {
+		return nil, err
+	}
+	if plan.Diffs, err = objectDiffs(ctx, current, append(capiObjs, eksaObjs...)); err != nil {
+		return nil, err
+	}
+
+	if newSpec.Cluster.IsSelfManaged() {
+		providerUpgradeNeeded, err := p.provider.UpgradeNeeded(ctx, newSpec, currentSpec, managementCluster)
+		if err != nil {
+			return nil, err
+		}
+		plan.CAPIMove = providerUpgradeNeeded || len(plan.Diffs) > 0
+	}
+
+	return plan, nil
+}
+
+func componentsChangeDiff(currentSpec, newSpec *cluster.Spec, provider providers.Provider) *types.ChangeDiff {
+	changeDiff := clustermanager.EksaChangeDiff(currentSpec, newSpec)
+	if changeDiff == nil {
+		changeDiff = &types.ChangeDiff{}
+	}
+	changeDiff.Append(flux.FluxChangeDiff(currentSpec, newSpec))
+	changeDiff.Append(argocd.ArgoCDChangeDiff(currentSpec, newSpec))
+	changeDiff.Append(clusterapi.CapiChangeDiff(currentSpec, newSpec, provider))
+	changeDiff.Append(cilium.ChangeDiff(currentSpec, newSpec))
+
+	return changeDiff
+}
+
+func cniUpgrade(currentSpec, newSpec *cluster.Spec) bool {
+	cni := newSpec.Cluster.Spec.ClusterNetwork.CNIConfig
+	switch {
+	case cni == nil:
+		return false
+	case cni.Cilium != nil:
+		return cni.Cilium.IsManaged() && cilium.ChangeDiff(currentSpec, newSpec) != nil
+	case cni.Kindnetd != nil:
+		return currentSpec.VersionsBundle.Kindnetd.Version != newSpec.VersionsBundle.Kindnetd.Version
+	default:
+		return false
+	}
+}
+
+// objectSet indexes objects by kind and name.
+type objectSet map[string]*unstructured.Unstructured
+
+func newObjectSet(objs []*unstructured.Unstructured) objectSet {
+	s := objectSet{}
+	for _, o := range objs {
+		s[objectSetKey(o.GetKind(), o.GetName())] = o
+	}
+	return s
+}
+
+func (s objectSet) get(kind, name string) *unstructured.Unstructured {
+	return s[objectSetKey(kind, name)]
+}
+
+func objectSetKey(kind, name string) string {
+	return kind + "/" + name
+}
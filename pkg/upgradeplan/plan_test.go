@@ -0,0 +1,360 @@
+package upgradeplan_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	providermocks "github.com/aws/eks-anywhere/pkg/providers/mocks"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/upgradeplan"
+	"github.com/aws/eks-anywhere/pkg/upgradeplan/mocks"
+)
+
+const newControlPlane = `apiVersion: controlplane.cluster.x-k8s.io/v1beta1
+kind: KubeadmControlPlane
+metadata:
+  name: my-cluster
+  namespace: eksa-system
+spec:
+  kubeadmConfigSpec:
+    clusterConfiguration:
+      imageRepository: public.ecr.aws/eks-distro/kubernetes
+  machineTemplate:
+    infrastructureRef:
+      apiVersion: infrastructure.cluster.x-k8s.io/v1beta1
+      kind: VSphereMachineTemplate
+      name: my-cluster-control-plane-template-2
+  version: v1.24.9-eks-1-24-7
+---
+apiVersion: infrastructure.cluster.x-k8s.io/v1beta1
+kind: VSphereMachineTemplate
+metadata:
+  name: my-cluster-control-plane-template-2
+  namespace: eksa-system
+spec:
+  template:
+    spec:
+      numCPUs: 2
+      template: /SDDC-Datacenter/vm/Templates/bottlerocket-1-24
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: my-cluster-vsphere-credentials
+  namespace: eksa-system
+stringData:
+  password: secret-password
+`
+
+const newWorkers = `apiVersion: cluster.x-k8s.io/v1beta1
+kind: MachineDeployment
+metadata:
+  name: my-cluster-md-0
+  namespace: eksa-system
+spec:
+  template:
+    spec:
+      bootstrap:
+        configRef:
+          name: my-cluster-md-0-template-1
+      infrastructureRef:
+        name: my-cluster-md-0-1
+      version: v1.23.16-eks-1-23-14
+---
+apiVersion: cluster.x-k8s.io/v1beta1
+kind: MachineDeployment
+metadata:
+  name: my-cluster-md-1
+  namespace: eksa-system
+spec:
+  template:
+    spec:
+      bootstrap:
+        configRef:
+          name: my-cluster-md-1-template-1
+      infrastructureRef:
+        name: my-cluster-md-1-1
+      version: v1.24.9-eks-1-24-7
+`
+
+const currentControlPlane = `apiVersion: controlplane.cluster.x-k8s.io/v1beta1
+kind: KubeadmControlPlane
+metadata:
+  name: my-cluster
+  namespace: eksa-system
+  resourceVersion: "1234"
+spec:
+  kubeadmConfigSpec:
+    clusterConfiguration:
+      imageRepository: public.ecr.aws/eks-distro/kubernetes
+  machineTemplate:
+    infrastructureRef:
+      apiVersion: infrastructure.cluster.x-k8s.io/v1beta1
+      kind: VSphereMachineTemplate
+      name: my-cluster-control-plane-template-1
+  version: v1.23.16-eks-1-23-14
+status:
+  ready: true
+`
+
+const currentControlPlaneTemplate = `apiVersion: infrastructure.cluster.x-k8s.io/v1beta1
+kind: VSphereMachineTemplate
+metadata:
+  name: my-cluster-control-plane-template-1
+  namespace: eksa-system
+spec:
+  template:
+    spec:
+      numCPUs: 2
+      template: /SDDC-Datacenter/vm/Templates/bottlerocket-1-23
+`
+
+// currentMachineDeployment has fields defaulted by the api server, which are ignored using the
+// last applied configuration.
+const currentMachineDeployment = `apiVersion: cluster.x-k8s.io/v1beta1
+kind: MachineDeployment
+metadata:
+  annotations:
+    kubectl.kubernetes.io/last-applied-configuration: '{"apiVersion":"cluster.x-k8s.io/v1beta1","kind":"MachineDeployment","metadata":{"name":"my-cluster-md-0","namespace":"eksa-system"},"spec":{"template":{"spec":{"bootstrap":{"configRef":{"name":"my-cluster-md-0-template-1"}},"infrastructureRef":{"name":"my-cluster-md-0-1"},"version":"v1.23.16-eks-1-23-14"}}}}'
+  name: my-cluster-md-0
+  namespace: eksa-system
+spec:
+  minReadySeconds: 0
+  template:
+    spec:
+      bootstrap:
+        configRef:
+          name: my-cluster-md-0-template-1
+      infrastructureRef:
+        name: my-cluster-md-0-1
+      nodeDeletionTimeout: 10s
+      version: v1.23.16-eks-1-23-14
+`
+
+type plannerTest struct {
+	*WithT
+	ctx               context.Context
+	client            *mocks.MockKubernetesClient
+	provider          *providermocks.MockProvider
+	managementCluster *types.Cluster
+	currentSpec       *cluster.Spec
+	newSpec           *cluster.Spec
+	objects           map[string]string
+}
+
+func newPlannerTest(t *testing.T) *plannerTest {
+	ctrl := gomock.NewController(t)
+	currentSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.TypeMeta = metav1.TypeMeta{APIVersion: anywherev1.GroupVersion.String(), Kind: anywherev1.ClusterKind}
+		s.Cluster.Name = "my-cluster"
+		s.Cluster.Spec.WorkerNodeGroupConfigurations = []anywherev1.WorkerNodeGroupConfiguration{{Name: "md-0"}, {Name: "md-old"}}
+	})
+	newSpec := currentSpec.DeepCopy()
+	newSpec.Cluster.Spec.WorkerNodeGroupConfigurations = []anywherev1.WorkerNodeGroupConfiguration{{Name: "md-0"}, {Name: "md-1"}}
+
+	tt := &plannerTest{
+		WithT:             NewWithT(t),
+		ctx:               context.Background(),
+		client:            mocks.NewMockKubernetesClient(ctrl),
+		provider:          providermocks.NewMockProvider(ctrl),
+		managementCluster: &types.Cluster{Name: "my-cluster", KubeconfigFile: "my-cluster.kubeconfig"},
+		currentSpec:       currentSpec,
+		newSpec:           newSpec,
+		objects: map[string]string{
+			"KubeadmControlPlane.v1beta1.controlplane.cluster.x-k8s.io/eksa-system/my-cluster":                               currentControlPlane,
+			"VSphereMachineTemplate.v1beta1.infrastructure.cluster.x-k8s.io/eksa-system/my-cluster-control-plane-template-1": currentControlPlaneTemplate,
+			"MachineDeployment.v1beta1.cluster.x-k8s.io/eksa-system/my-cluster-md-0":                                         currentMachineDeployment,
+		},
+	}
+
+	tt.client.EXPECT().GetObject(tt.ctx, gomock.Any(), gomock.Any(), gomock.Any(), "my-cluster.kubeconfig", gomock.Any()).DoAndReturn(
+		func(_ context.Context, resourceType, name, namespace, _ string, obj runtime.Object) error {
+			content, ok := tt.objects[resourceType+"/"+namespace+"/"+name]
+			if !ok {
+				return apierrors.NewNotFound(schema.GroupResource{Resource: resourceType}, name)
+			}
+			return yaml.Unmarshal([]byte(content), &obj.(*unstructured.Unstructured).Object)
+		},
+	).AnyTimes()
+
+	return tt
+}
+
+func (tt *plannerTest) expectProvider(controlPlane, workers string) {
+	datacenter := &anywherev1.DockerDatacenterConfig{
+		TypeMeta:   metav1.TypeMeta{APIVersion: anywherev1.GroupVersion.String(), Kind: anywherev1.DockerDatacenterKind},
+		ObjectMeta: metav1.ObjectMeta{Name: "my-cluster"},
+	}
+	tt.provider.EXPECT().ChangeDiff(tt.currentSpec, tt.newSpec).Return(nil)
+	tt.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, tt.managementCluster, tt.managementCluster, tt.currentSpec, tt.newSpec).Return([]byte(controlPlane), []byte(workers), nil)
+	tt.provider.EXPECT().DatacenterConfig(tt.newSpec).Return(datacenter)
+	tt.provider.EXPECT().MachineConfigs(tt.newSpec).Return([]providers.MachineConfig{})
+}
+
+func TestPlannerPlan(t *testing.T) {
+	tt := newPlannerTest(t)
+	tt.newSpec.VersionsBundle.Eksa.Version = "v0.15.0"
+	tt.expectProvider(newControlPlane, newWorkers)
+	tt.provider.EXPECT().UpgradeNeeded(tt.ctx, tt.newSpec, tt.currentSpec, tt.managementCluster).Return(false, nil)
+
+	plan, err := upgradeplan.NewPlanner(tt.client, tt.provider).Plan(tt.ctx, tt.managementCluster, tt.currentSpec, tt.newSpec)
+	tt.Expect(err).NotTo(HaveOccurred())
+
+	tt.Expect(plan.Components).To(ConsistOf(types.ComponentChangeDiff{ComponentName: "EKS-A", NewVersion: "v0.15.0"}))
+	tt.Expect(plan.UpToDate()).To(BeFalse())
+	tt.Expect(plan.CNIUpgrade).To(BeFalse())
+	tt.Expect(plan.CAPIMove).To(BeTrue())
+
+	tt.Expect(plan.ControlPlane.Name).To(Equal("control-plane"))
+	tt.Expect(plan.ControlPlane.Rolls).To(BeTrue())
+	tt.Expect(plan.ControlPlane.Reasons).To(HaveLen(3))
+	tt.Expect(plan.ControlPlane.Reasons[0]).To(Equal(upgradeplan.Reason{
+		Type:    upgradeplan.KubernetesVersionChanged,
+		Message: "kubernetes version changes from v1.23.16-eks-1-23-14 to v1.24.9-eks-1-24-7",
+	}))
+	tt.Expect(plan.ControlPlane.Reasons[1]).To(Equal(upgradeplan.Reason{
+		Type:    upgradeplan.OSImageChanged,
+		Message: "template changes from /SDDC-Datacenter/vm/Templates/bottlerocket-1-23 to /SDDC-Datacenter/vm/Templates/bottlerocket-1-24",
+	}))
+	tt.Expect(plan.ControlPlane.Reasons[2].Type).To(Equal(upgradeplan.MachineTemplateChanged))
+	tt.Expect(plan.ControlPlane.Reasons[2].Message).To(MatchRegexp(
+		`^machine template changes from my-cluster-control-plane-template-1 \(hash [0-9a-f]{10}\) to my-cluster-control-plane-template-2 \(hash [0-9a-f]{10}\)$`,
+	))
+
+	tt.Expect(plan.WorkerNodeGroups).To(Equal([]upgradeplan.Rollout{
+		{Name: "md-0"},
+		{
+			Name:  "md-1",
+			Rolls: true,
+			Reasons: []upgradeplan.Reason{
+				{Type: upgradeplan.NodeGroupAdded, Message: "worker node group md-1 will be created"},
+			},
+		},
+		{Name: "md-old", Removed: true},
+	}))
+
+	diffs := map[string]upgradeplan.ObjectDiff{}
+	for _, d := range plan.Diffs {
+		diffs[d.Kind+"/"+d.Name] = d
+	}
+	tt.Expect(diffs).To(HaveLen(5))
+	tt.Expect(diffs).NotTo(HaveKey("MachineDeployment/my-cluster-md-0"))
+	tt.Expect(diffs).NotTo(HaveKey("Secret/my-cluster-vsphere-credentials"))
+	tt.Expect(diffs["KubeadmControlPlane/my-cluster"].Change).To(Equal(upgradeplan.Modified))
+	tt.Expect(diffs["KubeadmControlPlane/my-cluster"].Diff).To(ContainSubstring("-  version: v1.23.16-eks-1-23-14\n"))
+	tt.Expect(diffs["KubeadmControlPlane/my-cluster"].Diff).To(ContainSubstring("+  version: v1.24.9-eks-1-24-7\n"))
+	tt.Expect(diffs["KubeadmControlPlane/my-cluster"].Diff).NotTo(ContainSubstring("resourceVersion"))
+	tt.Expect(diffs["KubeadmControlPlane/my-cluster"].Diff).NotTo(ContainSubstring("status"))
+	tt.Expect(diffs["VSphereMachineTemplate/my-cluster-control-plane-template-2"].Change).To(Equal(upgradeplan.Added))
+	tt.Expect(diffs["MachineDeployment/my-cluster-md-1"].Change).To(Equal(upgradeplan.Added))
+	tt.Expect(diffs["Cluster/my-cluster"].Change).To(Equal(upgradeplan.Added))
+	tt.Expect(diffs["Cluster/my-cluster"].Namespace).To(Equal("default"))
+	tt.Expect(diffs["DockerDatacenterConfig/my-cluster"].Change).To(Equal(upgradeplan.Added))
+}
+
+func TestPlannerPlanNoChanges(t *testing.T) {
+	tt := newPlannerTest(t)
+	tt.currentSpec.Cluster.Spec.WorkerNodeGroupConfigurations = []anywherev1.WorkerNodeGroupConfiguration{{Name: "md-0"}}
+	tt.newSpec.Cluster.Spec.WorkerNodeGroupConfigurations = []anywherev1.WorkerNodeGroupConfiguration{{Name: "md-0"}}
+	tt.newSpec.Cluster.Spec.ManagementCluster.Name = "mgmt"
+	tt.expectProvider(`apiVersion: controlplane.cluster.x-k8s.io/v1beta1
+kind: KubeadmControlPlane
+metadata:
+  name: my-cluster
+  namespace: eksa-system
+spec:
+  kubeadmConfigSpec:
+    clusterConfiguration:
+      imageRepository: public.ecr.aws/eks-distro/kubernetes
+  machineTemplate:
+    infrastructureRef:
+      apiVersion: infrastructure.cluster.x-k8s.io/v1beta1
+      kind: VSphereMachineTemplate
+      name: my-cluster-control-plane-template-1
+  version: v1.23.16-eks-1-23-14
+`, `apiVersion: cluster.x-k8s.io/v1beta1
+kind: MachineDeployment
+metadata:
+  name: my-cluster-md-0
+  namespace: eksa-system
+spec:
+  template:
+    spec:
+      bootstrap:
+        configRef:
+          name: my-cluster-md-0-template-1
+      infrastructureRef:
+        name: my-cluster-md-0-1
+      version: v1.23.16-eks-1-23-14
+`)
+	tt.objects["Cluster.v1alpha1.anywhere.eks.amazonaws.com/default/my-cluster"] = `apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: Cluster
+metadata:
+  name: my-cluster
+  namespace: default
+spec:
+  clusterNetwork:
+    pods: {}
+    services: {}
+  controlPlaneConfiguration: {}
+  datacenterRef: {}
+  managementCluster:
+    name: mgmt
+  workerNodeGroupConfigurations:
+  - name: md-0
+`
+	tt.objects["DockerDatacenterConfig.v1alpha1.anywhere.eks.amazonaws.com/default/my-cluster"] = `apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: DockerDatacenterConfig
+metadata:
+  name: my-cluster
+  namespace: default
+spec: {}
+`
+
+	plan, err := upgradeplan.NewPlanner(tt.client, tt.provider).Plan(tt.ctx, tt.managementCluster, tt.currentSpec, tt.newSpec)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(plan.Diffs).To(BeEmpty())
+	tt.Expect(plan.UpToDate()).To(BeTrue(), "plan should be up to date: %+v", plan)
+}
+
+func TestPlannerPlanControlPlaneNotFound(t *testing.T) {
+	tt := newPlannerTest(t)
+	delete(tt.objects, "KubeadmControlPlane.v1beta1.controlplane.cluster.x-k8s.io/eksa-system/my-cluster")
+	tt.expectProvider(newControlPlane, newWorkers)
+
+	_, err := upgradeplan.NewPlanner(tt.client, tt.provider).Plan(tt.ctx, tt.managementCluster, tt.currentSpec, tt.newSpec)
+	tt.Expect(err).To(MatchError("control plane my-cluster not found in the management cluster"))
+}
+
+func TestPlannerPlanGenerateCAPISpecError(t *testing.T) {
+	tt := newPlannerTest(t)
+	tt.provider.EXPECT().ChangeDiff(tt.currentSpec, tt.newSpec).Return(nil)
+	tt.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, tt.managementCluster, tt.managementCluster, tt.currentSpec, tt.newSpec).Return(nil, nil, errors.New("invalid template"))
+
+	_, err := upgradeplan.NewPlanner(tt.client, tt.provider).Plan(tt.ctx, tt.managementCluster, tt.currentSpec, tt.newSpec)
+	tt.Expect(err).To(MatchError("generating capi spec: invalid template"))
+}
+
+func TestPlannerPlanClientError(t *testing.T) {
+	tt := newPlannerTest(t)
+	client := mocks.NewMockKubernetesClient(gomock.NewController(t))
+	client.EXPECT().GetObject(tt.ctx, "KubeadmControlPlane.v1beta1.controlplane.cluster.x-k8s.io", "my-cluster", "eksa-system", "my-cluster.kubeconfig", gomock.Any()).Return(errors.New("connection refused"))
+	tt.expectProvider(newControlPlane, newWorkers)
+
+	_, err := upgradeplan.NewPlanner(client, tt.provider).Plan(tt.ctx, tt.managementCluster, tt.currentSpec, tt.newSpec)
+	tt.Expect(err).To(MatchError("reading KubeadmControlPlane eksa-system/my-cluster: connection refused"))
+}
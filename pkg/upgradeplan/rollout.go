@@ -0,0 +1,190 @@
+package upgradeplan
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+)
+
+// ReasonType is the cause of a rollout.
+type ReasonType string
+
+const (
+	// KubernetesVersionChanged means the nodes are rolled out to run a new Kubernetes version.
+	KubernetesVersionChanged ReasonType = "KubernetesVersionChanged"
+	// OSImageChanged means the nodes are rolled out to use a new OS image or template.
+	OSImageChanged ReasonType = "OSImageChanged"
+	// MachineTemplateChanged means the infrastructure machine template of the nodes changed.
+	MachineTemplateChanged ReasonType = "MachineTemplateChanged"
+	// BootstrapConfigChanged means the kubeadm configuration of the nodes changed.
+	BootstrapConfigChanged ReasonType = "BootstrapConfigChanged"
+	// NodeGroupAdded means the worker node group doesn't exist yet and its nodes will be created.
+	NodeGroupAdded ReasonType = "NodeGroupAdded"
+)
+
+// imageFields are the fields of the infrastructure machine templates, for all the providers,
+// that select the OS image of the machines.
+var imageFields = [][]string{
+	{"spec", "template", "spec", "template"},
+	{"spec", "template", "spec", "image"},
+	{"spec", "template", "spec", "amiID"},
+	{"spec", "template", "spec", "customImage"},
+	{"spec", "template", "spec", "templateOverride"},
+}
+
+// Rollout describes if the nodes of the control plane or a worker node group will be replaced.
+type Rollout struct {
+	Name    string   `json:"name"`
+	Rolls   bool     `json:"rolls"`
+	Removed bool     `json:"removed,omitempty"`
+	Reasons []Reason `json:"reasons,omitempty"`
+}
+
+// Reason is a cause of a rollout.
+type Reason struct {
+	Type    ReasonType `json:"type"`
+	Message string     `json:"message"`
+}
+
+func (r *Rollout) addReason(t ReasonType, format string, args ...interface{}) {
+	r.Rolls = true
+	r.Reasons = append(r.Reasons, Reason{Type: t, Message: fmt.Sprintf(format, args...)})
+}
+
+func controlPlaneRollout(ctx context.Context, current *objectReader, newObjs objectSet, spec *cluster.Spec) (Rollout, error) {
+	rollout := Rollout{Name: "control-plane"}
+	kcpName := clusterapi.KubeadmControlPlaneName(spec.Cluster)
+	newKCP := newObjs.get("KubeadmControlPlane", kcpName)
+	if newKCP == nil {
+		return rollout, nil
+	}
+
+	currentKCP, err := current.get(ctx, newKCP.GetAPIVersion(), newKCP.GetKind(), newKCP.GetNamespace(), newKCP.GetName())
+	if err != nil {
+		return rollout, err
+	}
+	if currentKCP == nil {
+		return rollout, fmt.Errorf("control plane %s not found in the management cluster", kcpName)
+	}
+	currentKCP = lastApplied(currentKCP)
+
+	compareVersions(&rollout, currentKCP, newKCP, "spec", "version")
+	if err := compareTemplates(ctx, &rollout, current, newObjs, currentKCP, newKCP, "spec", "machineTemplate", "infrastructureRef"); err != nil {
+		return rollout, err
+	}
+
+	if !reflect.DeepEqual(nestedField(currentKCP, "spec", "kubeadmConfigSpec"), nestedField(newKCP, "spec", "kubeadmConfigSpec")) {
+		rollout.addReason(BootstrapConfigChanged, "kubeadm config changed")
+	}
+
+	return rollout, nil
+}
+
+func workerNodeGroupsRollouts(ctx context.Context, current *objectReader, newObjs objectSet, currentSpec, newSpec *cluster.Spec) ([]Rollout, error) {
+	rollouts := make([]Rollout, 0, len(newSpec.Cluster.Spec.WorkerNodeGroupConfigurations))
+	newGroups := map[string]struct{}{}
+	for _, group := range newSpec.Cluster.Spec.WorkerNodeGroupConfigurations {
+		newGroups[group.Name] = struct{}{}
+		rollout := Rollout{Name: group.Name}
+
+		newMD := newObjs.get("MachineDeployment", clusterapi.MachineDeploymentName(newSpec.Cluster, group))
+		if newMD == nil {
+			rollouts = append(rollouts, rollout)
+			continue
+		}
+
+		currentMD, err := current.get(ctx, newMD.GetAPIVersion(), newMD.GetKind(), newMD.GetNamespace(), newMD.GetName())
+		if err != nil {
+			return nil, err
+		}
+		if currentMD == nil {
+			rollout.addReason(NodeGroupAdded, "worker node group %s will be created", group.Name)
+			rollouts = append(rollouts, rollout)
+			continue
+		}
+		currentMD = lastApplied(currentMD)
+
+		compareVersions(&rollout, currentMD, newMD, "spec", "template", "spec", "version")
+		if err := compareTemplates(ctx, &rollout, current, newObjs, currentMD, newMD, "spec", "template", "spec", "infrastructureRef"); err != nil {
+			return nil, err
+		}
+
+		currentConfig, _, _ := unstructured.NestedString(currentMD.Object, "spec", "template", "spec", "bootstrap", "configRef", "name")
+		newConfig, _, _ := unstructured.NestedString(newMD.Object, "spec", "template", "spec", "bootstrap", "configRef", "name")
+		if currentConfig != newConfig {
+			rollout.addReason(BootstrapConfigChanged, "kubeadm config template changed from %s to %s", currentConfig, newConfig)
+		}
+
+		rollouts = append(rollouts, rollout)
+	}
+
+	for _, group := range currentSpec.Cluster.Spec.WorkerNodeGroupConfigurations {
+		if _, ok := newGroups[group.Name]; !ok {
+			rollouts = append(rollouts, Rollout{Name: group.Name, Removed: true})
+		}
+	}
+
+	return rollouts, nil
+}
+
+func compareVersions(rollout *Rollout, currentObj, newObj *unstructured.Unstructured, fields ...string) {
+	currentVersion, _, _ := unstructured.NestedString(currentObj.Object, fields...)
+	newVersion, _, _ := unstructured.NestedString(newObj.Object, fields...)
+	if currentVersion != newVersion {
+		rollout.addReason(KubernetesVersionChanged, "kubernetes version changes from %s to %s", currentVersion, newVersion)
+	}
+}
+
+// compareTemplates checks if the infrastructure machine template referenced in refFields changed and, in that
+// case, if the OS image is different. The new template is looked up in the generated objects, since a new
+// template is only generated when the machine config changes.
+func compareTemplates(ctx context.Context, rollout *Rollout, current *objectReader, newObjs objectSet, currentObj, newObj *unstructured.Unstructured, refFields ...string) error {
+	currentRef, _, _ := unstructured.NestedStringMap(currentObj.Object, refFields...)
+	newRef, _, _ := unstructured.NestedStringMap(newObj.Object, refFields...)
+	if currentRef["name"] == newRef["name"] {
+		return nil
+	}
+
+	newTemplate := newObjs.get(newRef["kind"], newRef["name"])
+	currentTemplate, err := current.get(ctx, currentRef["apiVersion"], currentRef["kind"], currentObj.GetNamespace(), currentRef["name"])
+	if err != nil {
+		return err
+	}
+	if newTemplate == nil || currentTemplate == nil {
+		rollout.addReason(MachineTemplateChanged, "machine template changes from %s to %s", currentRef["name"], newRef["name"])
+		return nil
+	}
+	currentTemplate = lastApplied(currentTemplate)
+
+	for _, f := range imageFields {
+		currentImage := nestedField(currentTemplate, f...)
+		newImage := nestedField(newTemplate, f...)
+		if !reflect.DeepEqual(currentImage, newImage) {
+			rollout.addReason(OSImageChanged, "%s changes from %v to %v", f[len(f)-1], currentImage, newImage)
+		}
+	}
+
+	rollout.addReason(MachineTemplateChanged, "machine template changes from %s (hash %s) to %s (hash %s)",
+		currentRef["name"], specHash(currentTemplate), newRef["name"], specHash(newTemplate),
+	)
+
+	return nil
+}
+
+func nestedField(o *unstructured.Unstructured, fields ...string) interface{} {
+	v, _, _ := unstructured.NestedFieldNoCopy(o.Object, fields...)
+	return v
+}
+
+// specHash returns a short hash of the machine spec of a template, to identify its content independently of its name.
+func specHash(template *unstructured.Unstructured) string {
+	b, _ := json.Marshal(nestedField(template, "spec", "template", "spec"))
+	return fmt.Sprintf("%x", sha256.Sum256(b))[:10]
+}
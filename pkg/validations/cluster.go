@@ -8,6 +8,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/config"
+	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/providers"
 	"github.com/aws/eks-anywhere/pkg/types"
@@ -21,14 +22,28 @@ func ValidateOSForRegistryMirror(clusterSpec *cluster.Spec, provider providers.P
 	}
 
 	machineConfigs := provider.MachineConfigs(clusterSpec)
-	if !cluster.Spec.RegistryMirrorConfiguration.InsecureSkipVerify || machineConfigs == nil {
+	mirrors := len(cluster.Spec.RegistryMirrorConfiguration.Mirrors) > 0
+	if !(cluster.Spec.RegistryMirrorConfiguration.InsecureSkipVerify || mirrors) || machineConfigs == nil {
 		return nil
 	}
 
 	for _, mc := range machineConfigs {
-		if mc.OSFamily() == v1alpha1.Bottlerocket {
+		if mc.OSFamily() != v1alpha1.Bottlerocket {
+			continue
+		}
+		if cluster.Spec.RegistryMirrorConfiguration.InsecureSkipVerify {
 			return errors.New("InsecureSkipVerify is not supported for bottlerocket")
 		}
+		for _, m := range cluster.Spec.RegistryMirrorConfiguration.Mirrors {
+			if m.InsecureSkipVerify {
+				return fmt.Errorf("InsecureSkipVerify is not supported for bottlerocket in registry mirror %s", m.Endpoint)
+			}
+		}
+		// The bottlerocket bootstrap only configures a single registry mirror, the other mirrors are
+		// applied from the control plane resource sets, which only vSphere has.
+		if provider.Name() != constants.VSphereProviderName {
+			return fmt.Errorf("registry mirrors for upstream registries are not supported for bottlerocket with provider %s", provider.Name())
+		}
 	}
 	return nil
 }
@@ -76,6 +91,35 @@ func ValidateAuthenticationForRegistryMirror(clusterSpec *cluster.Spec) error {
 			return err
 		}
 	}
+	if cluster.Spec.RegistryMirrorConfiguration == nil {
+		return nil
+	}
+	for _, m := range cluster.Spec.RegistryMirrorConfiguration.Mirrors {
+		if !m.Authenticate {
+			continue
+		}
+		if _, _, err := config.ReadUpstreamMirrorCredentials(m.Upstream); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateGitOpsPullRequest checks the git provider of the GitOps configuration can open pull requests.
+// The generic git provider only pushes to the repository.
+func ValidateGitOpsPullRequest(clusterSpec *cluster.Spec) error {
+	switch {
+	case clusterSpec.ArgoCDConfig != nil:
+		if clusterSpec.ArgoCDConfig.Spec.Git != nil {
+			return errors.New("GitOps pull request mode is not supported by the generic git provider")
+		}
+	case clusterSpec.FluxConfig != nil:
+		if clusterSpec.FluxConfig.Spec.Git != nil {
+			return errors.New("GitOps pull request mode is not supported by the generic git provider")
+		}
+	default:
+		return errors.New("GitOps pull request mode requires a GitOps configuration")
+	}
 	return nil
 }
 
@@ -157,6 +157,81 @@ func TestValidateAuthenticationForRegistryMirrorAuthValid(t *testing.T) {
 	tt.Expect(validations.ValidateAuthenticationForRegistryMirror(tt.clusterSpec)).To(Succeed())
 }
 
+func TestValidateAuthenticationForRegistryMirrorUpstreamMirrorAuthInvalid(t *testing.T) {
+	tt := newTest(t, withTLS())
+	tt.clusterSpec.Cluster.Spec.RegistryMirrorConfiguration.Mirrors = []anywherev1.UpstreamRegistryMirror{
+		{Upstream: "quay.io", Endpoint: "cache.internal:5000/quay", Authenticate: true},
+	}
+
+	tt.Expect(validations.ValidateAuthenticationForRegistryMirror(tt.clusterSpec)).To(
+		MatchError(ContainSubstring("please set REGISTRY_USERNAME_QUAY_IO env var")))
+}
+
+func TestValidateAuthenticationForRegistryMirrorUpstreamMirrorAuthValid(t *testing.T) {
+	tt := newTest(t, withTLS())
+	tt.clusterSpec.Cluster.Spec.RegistryMirrorConfiguration.Mirrors = []anywherev1.UpstreamRegistryMirror{
+		{Upstream: "quay.io", Endpoint: "cache.internal:5000/quay", Authenticate: true},
+		{Upstream: "docker.io", Endpoint: "cache.internal:5000/dockerhub"},
+	}
+	t.Setenv("REGISTRY_USERNAME_QUAY_IO", "username")
+	t.Setenv("REGISTRY_PASSWORD_QUAY_IO", "password")
+
+	tt.Expect(validations.ValidateAuthenticationForRegistryMirror(tt.clusterSpec)).To(Succeed())
+}
+
+func TestValidateOSForRegistryMirrorUpstreamMirrorsBottlerocket(t *testing.T) {
+	tt := newTest(t, withTLS())
+	tt.clusterSpec.Cluster.Spec.RegistryMirrorConfiguration.Mirrors = []anywherev1.UpstreamRegistryMirror{
+		{Upstream: "docker.io", Endpoint: "cache.internal:5000/dockerhub"},
+	}
+	tt.provider.EXPECT().MachineConfigs(tt.clusterSpec).Return([]providers.MachineConfig{
+		&anywherev1.VSphereMachineConfig{
+			Spec: anywherev1.VSphereMachineConfigSpec{
+				OSFamily: anywherev1.Bottlerocket,
+			},
+		},
+	})
+
+	tt.provider.EXPECT().Name().Return("tinkerbell").Times(2)
+
+	tt.Expect(validations.ValidateOSForRegistryMirror(tt.clusterSpec, tt.provider)).To(
+		MatchError("registry mirrors for upstream registries are not supported for bottlerocket with provider tinkerbell"))
+}
+
+func TestValidateOSForRegistryMirrorUpstreamMirrorsBottlerocketVSphere(t *testing.T) {
+	tt := newTest(t, withTLS())
+	tt.clusterSpec.Cluster.Spec.RegistryMirrorConfiguration.Mirrors = []anywherev1.UpstreamRegistryMirror{
+		{Upstream: "docker.io", Endpoint: "cache.internal:5000/dockerhub"},
+	}
+	tt.provider.EXPECT().MachineConfigs(tt.clusterSpec).Return([]providers.MachineConfig{
+		&anywherev1.VSphereMachineConfig{
+			Spec: anywherev1.VSphereMachineConfigSpec{
+				OSFamily: anywherev1.Bottlerocket,
+			},
+		},
+	})
+	tt.provider.EXPECT().Name().Return("vsphere")
+
+	tt.Expect(validations.ValidateOSForRegistryMirror(tt.clusterSpec, tt.provider)).To(Succeed())
+}
+
+func TestValidateOSForRegistryMirrorUpstreamMirrorInsecureSkipVerifyBottlerocket(t *testing.T) {
+	tt := newTest(t, withTLS())
+	tt.clusterSpec.Cluster.Spec.RegistryMirrorConfiguration.Mirrors = []anywherev1.UpstreamRegistryMirror{
+		{Upstream: "docker.io", Endpoint: "cache.internal:5000/dockerhub", InsecureSkipVerify: true},
+	}
+	tt.provider.EXPECT().MachineConfigs(tt.clusterSpec).Return([]providers.MachineConfig{
+		&anywherev1.VSphereMachineConfig{
+			Spec: anywherev1.VSphereMachineConfigSpec{
+				OSFamily: anywherev1.Bottlerocket,
+			},
+		},
+	})
+
+	tt.Expect(validations.ValidateOSForRegistryMirror(tt.clusterSpec, tt.provider)).To(
+		MatchError("InsecureSkipVerify is not supported for bottlerocket in registry mirror cache.internal:5000/dockerhub"))
+}
+
 func TestValidateOSForRegistryMirrorNoRegistryMirror(t *testing.T) {
 	tt := newTest(t, withTLS())
 	tt.clusterSpec.Cluster.Spec.RegistryMirrorConfiguration = nil
@@ -414,3 +489,60 @@ func TestValidateManagementClusterBundlesVersionMissingBundlesRef(t *testing.T)
 	err := validations.ValidateManagementClusterBundlesVersion(ctx, tt.kubectl, mgmtCluster, tt.clusterSpec)
 	tt.Expect(err.Error()).To(Equal(wantErr))
 }
+
+func TestValidateGitOpsPullRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  func(*cluster.Spec)
+		wantErr string
+	}{
+		{
+			name: "no gitops",
+			config: func(s *cluster.Spec) {
+				s.FluxConfig = nil
+				s.ArgoCDConfig = nil
+			},
+			wantErr: "GitOps pull request mode requires a GitOps configuration",
+		},
+		{
+			name: "flux github",
+			config: func(s *cluster.Spec) {
+				s.FluxConfig = &anywherev1.FluxConfig{Spec: anywherev1.FluxConfigSpec{Github: &anywherev1.GithubProviderConfig{}}}
+			},
+		},
+		{
+			name: "flux generic git",
+			config: func(s *cluster.Spec) {
+				s.FluxConfig = &anywherev1.FluxConfig{Spec: anywherev1.FluxConfigSpec{Git: &anywherev1.GitProviderConfig{}}}
+			},
+			wantErr: "GitOps pull request mode is not supported by the generic git provider",
+		},
+		{
+			name: "argo cd gitlab",
+			config: func(s *cluster.Spec) {
+				s.ArgoCDConfig = &anywherev1.ArgoCDConfig{Spec: anywherev1.ArgoCDConfigSpec{Gitlab: &anywherev1.GitlabProviderConfig{}}}
+			},
+		},
+		{
+			name: "argo cd generic git",
+			config: func(s *cluster.Spec) {
+				s.ArgoCDConfig = &anywherev1.ArgoCDConfig{Spec: anywherev1.ArgoCDConfigSpec{Git: &anywherev1.GitProviderConfig{}}}
+			},
+			wantErr: "GitOps pull request mode is not supported by the generic git provider",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			spec := test.NewClusterSpec()
+			tc.config(spec)
+			err := validations.ValidateGitOpsPullRequest(spec)
+			if tc.wantErr == "" {
+				g.Expect(err).To(Succeed())
+			} else {
+				g.Expect(err).To(MatchError(tc.wantErr))
+			}
+		})
+	}
+}
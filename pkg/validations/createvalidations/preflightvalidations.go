@@ -41,6 +41,13 @@ func (v *CreateValidations) PreflightValidations(ctx context.Context) []validati
 				Err:         validations.ValidateAuthenticationForGitProvider(v.Opts.Spec, v.Opts.CliConfig),
 			}
 		},
+		func() *validations.ValidationResult {
+			return &validations.ValidationResult{
+				Name:        "validate image signatures",
+				Remediation: "make sure the images in the registry are the ones in the bundle and are signed with the public key",
+				Err:         validations.ValidateImageSignatures(ctx, v.Opts.Spec, v.Opts.ImageVerifier, v.Opts.PackageImages...),
+			}
+		},
 	}
 
 	if v.Opts.Spec.Cluster.IsManaged() {
@@ -9,11 +9,21 @@ import (
 )
 
 func ValidateAuthenticationForGitProvider(clusterSpec *cluster.Spec, cliConfig *config.CliConfig) error {
-	if clusterSpec.FluxConfig == nil || clusterSpec.FluxConfig.Spec.Git == nil {
+	fluxConfig := clusterSpec.FluxConfig
+	if clusterSpec.ArgoCDConfig != nil {
+		fluxConfig = clusterSpec.ArgoCDConfig.ConvertToFluxConfig()
+	}
+
+	if fluxConfig == nil || cliConfig == nil {
 		return nil
 	}
 
-	if cliConfig == nil {
+	// Flux bootstraps GitLab and Gitea over SSH, while Argo CD reads them over HTTPS with the access token.
+	if clusterSpec.ArgoCDConfig == nil && (fluxConfig.Spec.Gitlab != nil || fluxConfig.Spec.Gitea != nil) {
+		return validateDeployKeyKnownHosts(cliConfig)
+	}
+
+	if fluxConfig.Spec.Git == nil {
 		return nil
 	}
 
@@ -35,3 +45,17 @@ func ValidateAuthenticationForGitProvider(clusterSpec *cluster.Spec, cliConfig *
 
 	return nil
 }
+
+// validateDeployKeyKnownHosts checks the known hosts file used to verify the git server is set for the
+// providers that bootstrap Flux over SSH with a generated deploy key.
+func validateDeployKeyKnownHosts(cliConfig *config.CliConfig) error {
+	if cliConfig.GitKnownHostsFile == "" {
+		return fmt.Errorf("provide a path to an SSH known hosts file with an entry for the git server via the %s environment variable in order to use the GitLab or Gitea Flux provider", config.EksaGitKnownHostsFileEnv)
+	}
+
+	if !FileExistsAndIsNotEmpty(cliConfig.GitKnownHostsFile) {
+		return fmt.Errorf("SSH known hosts file does not exist at %v or is empty", cliConfig.GitKnownHostsFile)
+	}
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package validations_test
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
 	"testing"
@@ -163,3 +164,82 @@ func TestValidateGitOpsGitProviderNoAuthForWorkloadCluster(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateAuthenticationForGitProviderArgoCD(t *testing.T) {
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Spec.GitOpsRef = &v1alpha1.Ref{
+			Kind: v1alpha1.ArgoCDConfigKind,
+			Name: "argocdtest",
+		}
+		s.ArgoCDConfig = &v1alpha1.ArgoCDConfig{
+			Spec: v1alpha1.ArgoCDConfigSpec{
+				Git: &v1alpha1.GitProviderConfig{
+					RepositoryUrl: "testRepo",
+				},
+			},
+		}
+	})
+
+	err := validations.ValidateAuthenticationForGitProvider(clusterSpec, &config.CliConfig{})
+	wantErr := errors.New("provide a path to a private key file via the EKSA_GIT_PRIVATE_KEY in order to use the generic git Flux provider")
+	if !reflect.DeepEqual(err, wantErr) {
+		t.Errorf("got = %v, \nwant %v", err, wantErr)
+	}
+}
+
+func TestValidateAuthenticationForGitProviderDeployKey(t *testing.T) {
+	tests := []struct {
+		name      string
+		wantErr   error
+		gitlab    *v1alpha1.GitlabProviderConfig
+		gitea     *v1alpha1.GiteaProviderConfig
+		cliConfig *config.CliConfig
+	}{
+		{
+			name:      "GitLab without known hosts",
+			wantErr:   errors.New("provide a path to an SSH known hosts file with an entry for the git server via the EKSA_GIT_KNOWN_HOSTS environment variable in order to use the GitLab or Gitea Flux provider"),
+			gitlab:    &v1alpha1.GitlabProviderConfig{Owner: "platform", Repository: "fleet"},
+			cliConfig: &config.CliConfig{},
+		},
+		{
+			name:      "Gitea with empty known hosts",
+			wantErr:   errors.New("SSH known hosts file does not exist at testdata/git_empty_file or is empty"),
+			gitea:     &v1alpha1.GiteaProviderConfig{Hostname: "gitea.example.com", Owner: "platform", Repository: "fleet"},
+			cliConfig: &config.CliConfig{GitKnownHostsFile: "testdata/git_empty_file"},
+		},
+		{
+			name:      "GitLab with known hosts",
+			gitlab:    &v1alpha1.GitlabProviderConfig{Owner: "platform", Repository: "fleet"},
+			cliConfig: &config.CliConfig{GitKnownHostsFile: "testdata/git_nonempty_ssh_known_hosts"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+				s.FluxConfig = &v1alpha1.FluxConfig{
+					Spec: v1alpha1.FluxConfigSpec{Gitlab: tc.gitlab, Gitea: tc.gitea},
+				}
+			})
+
+			err := validations.ValidateAuthenticationForGitProvider(clusterSpec, tc.cliConfig)
+			if !reflect.DeepEqual(err, tc.wantErr) {
+				t.Errorf("got = %v, \nwant %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateAuthenticationForGitProviderArgoCDGitlab(t *testing.T) {
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.ArgoCDConfig = &v1alpha1.ArgoCDConfig{
+			Spec: v1alpha1.ArgoCDConfigSpec{
+				Gitlab: &v1alpha1.GitlabProviderConfig{Owner: "platform", Repository: "fleet"},
+			},
+		}
+	})
+
+	if err := validations.ValidateAuthenticationForGitProvider(clusterSpec, &config.CliConfig{}); err != nil {
+		t.Errorf("got = %v, want nil", err)
+	}
+}
@@ -0,0 +1,85 @@
+package validations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/registry"
+	"github.com/aws/eks-anywhere/pkg/registrymirror"
+	"github.com/aws/eks-anywhere/pkg/workflow"
+)
+
+// ImageVerifier verifies the signatures of images.
+type ImageVerifier interface {
+	VerifyArtifacts(ctx context.Context, artifacts []registry.Artifact) error
+}
+
+// ClusterImages returns the images used by a cluster with the digests in its bundle, pulled from the
+// registry mirror when the cluster has one.
+func ClusterImages(clusterSpec *cluster.Spec) []registry.Artifact {
+	mirror := registrymirror.FromCluster(clusterSpec.Cluster)
+	seen := map[string]bool{}
+	var images []registry.Artifact
+	for _, image := range clusterSpec.VersionsBundle.Images() {
+		if image.URI == "" {
+			continue
+		}
+		uri := mirror.ReplaceRegistry(image.URI)
+		if seen[uri] {
+			continue
+		}
+		seen[uri] = true
+		artifact := registry.NewArtifactFromURI(uri)
+		if image.ImageDigest != "" {
+			artifact.Digest = image.ImageDigest
+		}
+		images = append(images, artifact)
+	}
+	return images
+}
+
+// ValidateImageSignatures checks all the images of the cluster, and the curated packages images installed
+// with it, are signed and match the digests in their bundle, so unsigned or tampered images are refused.
+// It's skipped when there is no verifier.
+func ValidateImageSignatures(ctx context.Context, clusterSpec *cluster.Spec, verifier ImageVerifier, packageImages ...registry.Artifact) error {
+	if verifier == nil {
+		return nil
+	}
+	images := ClusterImages(clusterSpec)
+	mirror := registrymirror.FromCluster(clusterSpec.Cluster)
+	for _, image := range packageImages {
+		// The mirror can add a namespace to the registry, which belongs to the repository.
+		mirrored := registry.NewArtifactFromURI(mirror.ReplaceRegistry(image.VersionedImage()))
+		mirrored.Tag = image.Tag
+		images = append(images, mirrored)
+	}
+	if err := verifier.VerifyArtifacts(ctx, images); err != nil {
+		return fmt.Errorf("verifying image signatures: %v", err)
+	}
+	return nil
+}
+
+// ImageSignaturesHookRegistrar binds the image signatures validation to the create management
+// cluster workflow, so it runs before any cluster is created.
+type ImageSignaturesHookRegistrar struct {
+	spec          *cluster.Spec
+	verifier      ImageVerifier
+	packageImages []registry.Artifact
+}
+
+// NewImageSignaturesHookRegistrar creates an ImageSignaturesHookRegistrar instance.
+func NewImageSignaturesHookRegistrar(spec *cluster.Spec, verifier ImageVerifier, packageImages []registry.Artifact) ImageSignaturesHookRegistrar {
+	return ImageSignaturesHookRegistrar{
+		spec:          spec,
+		verifier:      verifier,
+		packageImages: packageImages,
+	}
+}
+
+// RegisterCreateManagementClusterHooks satisfies management.CreateClusterHookRegistrar.
+func (r ImageSignaturesHookRegistrar) RegisterCreateManagementClusterHooks(binder workflow.HookBinder) {
+	binder.BindPreWorkflowHook(workflow.TaskFunc(func(ctx context.Context) (context.Context, error) {
+		return ctx, ValidateImageSignatures(ctx, r.spec, r.verifier, r.packageImages...)
+	}))
+}
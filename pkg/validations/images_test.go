@@ -0,0 +1,130 @@
+package validations_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/registry"
+	"github.com/aws/eks-anywhere/pkg/validations"
+	"github.com/aws/eks-anywhere/pkg/validations/mocks"
+	"github.com/aws/eks-anywhere/pkg/workflow"
+	releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+func imagesClusterSpec() *cluster.Spec {
+	return test.NewClusterSpec(func(s *cluster.Spec) {
+		s.VersionsBundle.Cilium.Cilium = releasev1alpha1.Image{
+			URI:         "public.ecr.aws/isovalent/cilium:v1.9.13-eksa.2",
+			ImageDigest: "sha256:0f5ed3c6e7b5e6cb3ff8bda7a4b1e7a3ee16a4af1c6d6b1a95d9a1e7d1ab4e9c",
+		}
+	})
+}
+
+func TestClusterImages(t *testing.T) {
+	g := NewWithT(t)
+	images := validations.ClusterImages(imagesClusterSpec())
+
+	g.Expect(images).To(ConsistOf(registry.Artifact{
+		Registry:   "public.ecr.aws",
+		Repository: "isovalent/cilium",
+		Tag:        "v1.9.13-eksa.2",
+		Digest:     "sha256:0f5ed3c6e7b5e6cb3ff8bda7a4b1e7a3ee16a4af1c6d6b1a95d9a1e7d1ab4e9c",
+	}))
+}
+
+func TestClusterImagesRegistryMirror(t *testing.T) {
+	g := NewWithT(t)
+	spec := imagesClusterSpec()
+	spec.Cluster.Spec.RegistryMirrorConfiguration = &anywherev1.RegistryMirrorConfiguration{
+		Endpoint: "harbor.local",
+		Port:     "443",
+	}
+
+	images := validations.ClusterImages(spec)
+
+	g.Expect(images).To(ConsistOf(registry.Artifact{
+		Registry:   "harbor.local:443",
+		Repository: "isovalent/cilium",
+		Tag:        "v1.9.13-eksa.2",
+		Digest:     "sha256:0f5ed3c6e7b5e6cb3ff8bda7a4b1e7a3ee16a4af1c6d6b1a95d9a1e7d1ab4e9c",
+	}))
+}
+
+func TestValidateImageSignaturesNoVerifier(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(validations.ValidateImageSignatures(context.Background(), imagesClusterSpec(), nil)).To(Succeed())
+}
+
+func TestValidateImageSignatures(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	spec := imagesClusterSpec()
+	verifier := mocks.NewMockImageVerifier(gomock.NewController(t))
+	verifier.EXPECT().VerifyArtifacts(ctx, validations.ClusterImages(spec)).Return(nil)
+
+	g.Expect(validations.ValidateImageSignatures(ctx, spec, verifier)).To(Succeed())
+}
+
+func TestValidateImageSignaturesUnsigned(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	spec := imagesClusterSpec()
+	verifier := mocks.NewMockImageVerifier(gomock.NewController(t))
+	verifier.EXPECT().VerifyArtifacts(ctx, gomock.Any()).Return(errors.New("no cosign signature found"))
+
+	g.Expect(validations.ValidateImageSignatures(ctx, spec, verifier)).To(
+		MatchError("verifying image signatures: no cosign signature found"),
+	)
+}
+
+func TestValidateImageSignaturesPackageImagesOCINamespaces(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	spec := imagesClusterSpec()
+	spec.Cluster.Spec.RegistryMirrorConfiguration = &anywherev1.RegistryMirrorConfiguration{
+		Endpoint: "harbor.local",
+		Port:     "443",
+		OCINamespaces: []anywherev1.OCINamespace{
+			{Registry: "783794618700.dkr.ecr.us-west-2.amazonaws.com", Namespace: "curated-packages"},
+		},
+	}
+	packageImage := registry.Artifact{
+		Registry:   "783794618700.dkr.ecr.us-west-2.amazonaws.com",
+		Repository: "harbor/harbor-core",
+		Digest:     "sha256:bbbb",
+	}
+	mirroredPackageImage := registry.Artifact{
+		Registry:   "harbor.local:443",
+		Repository: "curated-packages/harbor/harbor-core",
+		Digest:     "sha256:bbbb",
+	}
+	verifier := mocks.NewMockImageVerifier(gomock.NewController(t))
+	verifier.EXPECT().VerifyArtifacts(ctx, append(validations.ClusterImages(spec), mirroredPackageImage)).Return(nil)
+
+	g.Expect(validations.ValidateImageSignatures(ctx, spec, verifier, packageImage)).To(Succeed())
+}
+
+func TestImageSignaturesHookRegistrar(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	spec := imagesClusterSpec()
+	packageImage := registry.Artifact{
+		Registry:   "783794618700.dkr.ecr.us-west-2.amazonaws.com",
+		Repository: "harbor/harbor-core",
+		Digest:     "sha256:bbbb",
+	}
+	verifier := mocks.NewMockImageVerifier(gomock.NewController(t))
+	verifier.EXPECT().VerifyArtifacts(gomock.Any(), append(validations.ClusterImages(spec), packageImage)).Return(errors.New("no cosign signature found"))
+
+	wflw := workflow.New(workflow.Config{})
+	validations.NewImageSignaturesHookRegistrar(spec, verifier, []registry.Artifact{packageImage}).RegisterCreateManagementClusterHooks(wflw)
+
+	g.Expect(wflw.Execute(ctx)).To(MatchError(ContainSubstring("verifying image signatures: no cosign signature found")))
+}
@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/validations/images.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	registry "github.com/aws/eks-anywhere/pkg/registry"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockImageVerifier is a mock of ImageVerifier interface.
+type MockImageVerifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockImageVerifierMockRecorder
+}
+
+// MockImageVerifierMockRecorder is the mock recorder for MockImageVerifier.
+type MockImageVerifierMockRecorder struct {
+	mock *MockImageVerifier
+}
+
+// NewMockImageVerifier creates a new mock instance.
+func NewMockImageVerifier(ctrl *gomock.Controller) *MockImageVerifier {
+	mock := &MockImageVerifier{ctrl: ctrl}
+	mock.recorder = &MockImageVerifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImageVerifier) EXPECT() *MockImageVerifierMockRecorder {
+	return m.recorder
+}
+
+// VerifyArtifacts mocks base method.
+func (m *MockImageVerifier) VerifyArtifacts(ctx context.Context, artifacts []registry.Artifact) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyArtifacts", ctx, artifacts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyArtifacts indicates an expected call of VerifyArtifacts.
+func (mr *MockImageVerifierMockRecorder) VerifyArtifacts(ctx, artifacts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyArtifacts", reflect.TypeOf((*MockImageVerifier)(nil).VerifyArtifacts), ctx, artifacts)
+}
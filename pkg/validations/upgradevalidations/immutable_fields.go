@@ -177,6 +177,14 @@ func ValidateGitOpsImmutableFields(ctx context.Context, k validations.KubectlCli
 			}
 		}
 
+		if prevGitOps.Spec.Gitlab != nil && !prevGitOps.Spec.Gitlab.Equal(clusterSpec.FluxConfig.Spec.Gitlab) {
+			return errors.New("fluxConfig spec.gitlab is immutable")
+		}
+
+		if prevGitOps.Spec.Gitea != nil && !prevGitOps.Spec.Gitea.Equal(clusterSpec.FluxConfig.Spec.Gitea) {
+			return errors.New("fluxConfig spec.gitea is immutable")
+		}
+
 		if prevGitOps.Spec.Branch != clusterSpec.FluxConfig.Spec.Branch {
 			return errors.New("fluxConfig spec.branch is immutable")
 		}
@@ -188,6 +196,16 @@ func ValidateGitOpsImmutableFields(ctx context.Context, k validations.KubectlCli
 		if prevGitOps.Spec.SystemNamespace != clusterSpec.FluxConfig.Spec.SystemNamespace {
 			return errors.New("fluxConfig spec.systemNamespace is immutable")
 		}
+
+	case v1alpha1.ArgoCDConfigKind:
+		prevArgoCD := &v1alpha1.ArgoCDConfig{}
+		if err := k.GetObject(ctx, "argocdconfigs.anywhere.eks.amazonaws.com", clusterSpec.Cluster.Spec.GitOpsRef.Name, clusterSpec.Cluster.Namespace, cluster.KubeconfigFile, prevArgoCD); err != nil {
+			return err
+		}
+
+		if !prevArgoCD.Spec.Equal(&clusterSpec.ArgoCDConfig.Spec) {
+			return errors.New("argoCDConfig spec is immutable")
+		}
 	}
 
 	return nil
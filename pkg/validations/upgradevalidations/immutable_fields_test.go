@@ -183,6 +183,42 @@ func TestValidateGitOpsImmutableFieldsFluxConfig(t *testing.T) {
 			},
 			wantErr: "fluxConfig spec.github.personal is immutable",
 		},
+		{
+			name: "gitlab hostname diff",
+			new: &v1alpha1.FluxConfig{
+				Spec: v1alpha1.FluxConfigSpec{
+					Gitlab: &v1alpha1.GitlabProviderConfig{
+						Hostname: "a",
+					},
+				},
+			},
+			old: &v1alpha1.FluxConfig{
+				Spec: v1alpha1.FluxConfigSpec{
+					Gitlab: &v1alpha1.GitlabProviderConfig{
+						Hostname: "b",
+					},
+				},
+			},
+			wantErr: "fluxConfig spec.gitlab is immutable",
+		},
+		{
+			name: "gitea repo diff",
+			new: &v1alpha1.FluxConfig{
+				Spec: v1alpha1.FluxConfigSpec{
+					Gitea: &v1alpha1.GiteaProviderConfig{
+						Repository: "a",
+					},
+				},
+			},
+			old: &v1alpha1.FluxConfig{
+				Spec: v1alpha1.FluxConfigSpec{
+					Gitea: &v1alpha1.GiteaProviderConfig{
+						Repository: "b",
+					},
+				},
+			},
+			wantErr: "fluxConfig spec.gitea is immutable",
+		},
 		{
 			name: "branch diff",
 			new: &v1alpha1.FluxConfig{
@@ -360,3 +396,60 @@ func TestValidateImmutableFields(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateGitOpsImmutableFieldsArgoCDConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(*v1alpha1.ArgoCDConfigSpec)
+		wantErr string
+	}{
+		{
+			name:   "no changes",
+			modify: func(s *v1alpha1.ArgoCDConfigSpec) {},
+		},
+		{
+			name: "repository diff",
+			modify: func(s *v1alpha1.ArgoCDConfigSpec) {
+				s.Github.Repository = "another-fleet"
+			},
+			wantErr: "argoCDConfig spec is immutable",
+		},
+		{
+			name: "branch diff",
+			modify: func(s *v1alpha1.ArgoCDConfigSpec) {
+				s.Branch = "dev"
+			},
+			wantErr: "argoCDConfig spec is immutable",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := newGitClientTest(t)
+			g.o.Spec.GitOpsRef.Kind = v1alpha1.ArgoCDConfigKind
+			g.s.Cluster.Spec.GitOpsRef.Kind = v1alpha1.ArgoCDConfigKind
+			old := &v1alpha1.ArgoCDConfig{
+				Spec: v1alpha1.ArgoCDConfigSpec{
+					Branch: "main",
+					Github: &v1alpha1.GithubProviderConfig{Owner: "janedoe", Repository: "fleet"},
+				},
+			}
+			g.s.ArgoCDConfig = old.DeepCopy()
+			tc.modify(&g.s.ArgoCDConfig.Spec)
+
+			g.k.EXPECT().GetObject(g.ctx, "argocdconfigs.anywhere.eks.amazonaws.com", g.s.Cluster.Spec.GitOpsRef.Name, "", "kubeconfig", &v1alpha1.ArgoCDConfig{}).DoAndReturn(
+				func(_ context.Context, _, _, _, _ string, obj *v1alpha1.ArgoCDConfig) error {
+					old.DeepCopyInto(obj)
+					return nil
+				},
+			)
+
+			err := upgradevalidations.ValidateGitOpsImmutableFields(g.ctx, g.k, g.c, g.s, g.o)
+			if tc.wantErr == "" {
+				g.Expect(err).To(Succeed())
+			} else {
+				g.Expect(err).To(MatchError(tc.wantErr))
+			}
+		})
+	}
+}
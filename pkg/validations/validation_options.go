@@ -5,6 +5,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/config"
 	"github.com/aws/eks-anywhere/pkg/crypto"
 	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/registry"
 	"github.com/aws/eks-anywhere/pkg/types"
 )
 
@@ -17,6 +18,8 @@ type Opts struct {
 	TLSValidator       TlsValidator
 	CliConfig          *config.CliConfig
 	SkippedValidations map[string]bool
+	ImageVerifier      ImageVerifier
+	PackageImages      []registry.Artifact
 }
 
 func (o *Opts) SetDefaults() {
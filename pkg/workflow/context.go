@@ -0,0 +1,52 @@
+package workflow
+
+import "context"
+
+// mergeContexts returns a context with the values of all contexts. Values are looked up in the
+// contexts in order, so when several contexts hold the same key the first one wins. Tasks that
+// run concurrently shouldn't set the same context values. Cancellation and deadline come from
+// the first context. If there are no contexts, parent is returned.
+func mergeContexts(parent context.Context, contexts ...context.Context) context.Context {
+	switch len(contexts) {
+	case 0:
+		return parent
+	case 1:
+		return contexts[0]
+	}
+
+	return mergedContext{Context: contexts[0], others: contexts[1:]}
+}
+
+type mergedContext struct {
+	context.Context
+	others []context.Context
+}
+
+func (c mergedContext) Value(key any) any {
+	if v := c.Context.Value(key); v != nil {
+		return v
+	}
+
+	for _, ctx := range c.others {
+		if v := ctx.Value(key); v != nil {
+			return v
+		}
+	}
+
+	return nil
+}
+
+// withCancellationOf returns a context with the values of values and the cancellation and deadline
+// of parent.
+func withCancellationOf(parent, values context.Context) context.Context {
+	return valuesContext{Context: parent, values: values}
+}
+
+type valuesContext struct {
+	context.Context
+	values context.Context
+}
+
+func (c valuesContext) Value(key any) any {
+	return c.values.Value(key)
+}
@@ -15,6 +15,10 @@ import (
 const (
 	CreateBootstrapCluster workflow.TaskName = "CreateBootstrapCluster"
 	CreateWorkloadCluster  workflow.TaskName = "CreateWorkloadCluster"
+	InstallCAPI            workflow.TaskName = "InstallCAPI"
+	InstallGitOps          workflow.TaskName = "InstallGitOps"
+	InstallPackages        workflow.TaskName = "InstallPackages"
+	MoveManagement         workflow.TaskName = "MoveManagement"
 	DeleteBootstrapCluster workflow.TaskName = "DeleteBootstrapCluster"
 )
 
@@ -25,9 +29,12 @@ type CreateClusterHookRegistrar interface {
 }
 
 // CreateCluster defines the configuration for a managment cluster creation workflow.
-// It executes tasks in the following order:
+// It executes tasks in the following order, each one once the tasks it depends on have completed:
 //  1. CreateBootstrapCluster
-//  2. DeleteBootstrapCluster
+//  2. CreateWorkloadCluster, depends on CreateBootstrapCluster
+//  3. InstallCAPI, InstallGitOps and InstallPackages, concurrently, depend on CreateWorkloadCluster
+//  4. MoveManagement, depends on InstallCAPI
+//  5. DeleteBootstrapCluster, depends on MoveManagement
 type CreateCluster struct {
 	// The spec used to construcft all other dependencies.
 	Spec *cluster.Spec
@@ -44,6 +51,18 @@ type CreateCluster struct {
 	// CNIInstaller installs a CNI in a Kubernetes cluster
 	CNIInstaller workload.CNIInstaller
 
+	// CAPIInstaller installs the Cluster API and provider components in the workload cluster.
+	CAPIInstaller workload.CAPIInstaller
+
+	// ManagementMover moves the Cluster API objects from the bootstrap to the workload cluster.
+	ManagementMover workload.ManagementMover
+
+	// GitOpsInstaller installs the GitOps controllers in the workload cluster.
+	GitOpsInstaller workload.GitOpsInstaller
+
+	// PackagesInstaller installs the curated packages in the workload cluster.
+	PackagesInstaller workload.PackagesInstaller
+
 	// FS is a file system abstraction used to write files.
 	FS filewriter.FileWriter
 
@@ -75,7 +94,7 @@ func (c CreateCluster) build() (*workflow.Workflow, error) {
 		r.RegisterCreateManagementClusterHooks(wflw)
 	}
 
-	err := wflw.AppendTask(CreateBootstrapCluster, bootstrap.CreateCluster{
+	err := wflw.AddTask(CreateBootstrapCluster, bootstrap.CreateCluster{
 		Spec:         c.Spec,
 		Options:      c.CreateBootstrapClusterOptions,
 		Bootstrapper: c.Bootstrapper,
@@ -84,18 +103,48 @@ func (c CreateCluster) build() (*workflow.Workflow, error) {
 		return nil, err
 	}
 
-	err = wflw.AppendTask(CreateWorkloadCluster, workload.Create{
+	err = wflw.AddTask(CreateWorkloadCluster, workload.Create{
 		Cluster: c.Cluster,
 		CNI:     c.CNIInstaller,
 		FS:      c.FS,
-	})
+	}, CreateBootstrapCluster)
+	if err != nil {
+		return nil, err
+	}
+
+	// The components installed in the workload cluster don't depend on each other, so they
+	// are installed concurrently.
+	err = wflw.AddTask(InstallCAPI, workload.InstallCAPI{
+		Installer: c.CAPIInstaller,
+	}, CreateWorkloadCluster)
+	if err != nil {
+		return nil, err
+	}
+
+	err = wflw.AddTask(InstallGitOps, workload.InstallGitOps{
+		Installer: c.GitOpsInstaller,
+	}, CreateWorkloadCluster)
+	if err != nil {
+		return nil, err
+	}
+
+	err = wflw.AddTask(InstallPackages, workload.InstallPackages{
+		Installer: c.PackagesInstaller,
+	}, CreateWorkloadCluster)
 	if err != nil {
 		return nil, err
 	}
 
-	err = wflw.AppendTask(DeleteBootstrapCluster, bootstrap.DeleteCluster{
+	err = wflw.AddTask(MoveManagement, workload.MoveManagement{
+		Mover: c.ManagementMover,
+	}, InstallCAPI)
+	if err != nil {
+		return nil, err
+	}
+
+	err = wflw.AddTask(DeleteBootstrapCluster, bootstrap.DeleteCluster{
 		Bootstrapper: c.Bootstrapper,
-	})
+	}, MoveManagement)
 	if err != nil {
 		return nil, err
 	}
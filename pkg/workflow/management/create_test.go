@@ -0,0 +1,142 @@
+package management_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/bootstrapper"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/workflow/management"
+	"github.com/aws/eks-anywhere/pkg/workflow/task/workload"
+)
+
+// fakeCreate implements the dependencies of the create management cluster workflow and records
+// the steps it runs, in order.
+type fakeCreate struct {
+	mu    sync.Mutex
+	steps []string
+
+	// installing is done once the CAPI, GitOps and packages installs have all started.
+	installing sync.WaitGroup
+}
+
+func newFakeCreate() *fakeCreate {
+	f := &fakeCreate{}
+	f.installing.Add(3)
+	return f
+}
+
+func (f *fakeCreate) record(step string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.steps = append(f.steps, step)
+}
+
+// waitForInstalls blocks until the CAPI, GitOps and packages installs have all started, so it only
+// returns if they run concurrently.
+func (f *fakeCreate) waitForInstalls(step string) error {
+	f.record(step)
+	f.installing.Done()
+
+	started := make(chan struct{})
+	go func() {
+		f.installing.Wait()
+		close(started)
+	}()
+
+	select {
+	case <-started:
+		return nil
+	case <-time.After(10 * time.Second):
+		return errors.New(step + " didn't run concurrently with the other installs")
+	}
+}
+
+func (f *fakeCreate) BootstrapClusterOpts(*cluster.Spec) ([]bootstrapper.BootstrapClusterOption, error) {
+	return nil, nil
+}
+
+func (f *fakeCreate) CreateBootstrapCluster(context.Context, *cluster.Spec, ...bootstrapper.BootstrapClusterOption) (*types.Cluster, error) {
+	f.record("CreateBootstrapCluster")
+	return &types.Cluster{Name: "bootstrap"}, nil
+}
+
+func (f *fakeCreate) DeleteBootstrapCluster(context.Context, *types.Cluster, constants.Operation, bool) error {
+	f.record("DeleteBootstrapCluster")
+	return nil
+}
+
+func (f *fakeCreate) CreateAsync(context.Context, *types.Cluster) error {
+	f.record("CreateWorkloadCluster")
+	return nil
+}
+
+func (f *fakeCreate) WriteKubeconfig(context.Context, io.Writer, *types.Cluster) error {
+	return nil
+}
+
+func (f *fakeCreate) WaitUntilControlPlaneAvailable(context.Context, *types.Cluster) error {
+	return nil
+}
+
+func (f *fakeCreate) WaitUntilReady(context.Context, *types.Cluster) error {
+	return nil
+}
+
+func (f *fakeCreate) GetName() string {
+	return "test-cluster"
+}
+
+func (f *fakeCreate) Install(context.Context, *types.Cluster) error {
+	return nil
+}
+
+func (f *fakeCreate) InstallCAPI(context.Context, *types.Cluster) error {
+	return f.waitForInstalls("InstallCAPI")
+}
+
+func (f *fakeCreate) MoveManagement(_ context.Context, from, to *types.Cluster) error {
+	f.record("MoveManagement " + from.Name + " -> " + to.Name)
+	return nil
+}
+
+func (f *fakeCreate) InstallCuratedPackages(context.Context) {
+	if err := f.waitForInstalls("InstallPackages"); err != nil {
+		f.record(err.Error())
+	}
+}
+
+func TestCreateClusterRunsInstallsConcurrently(t *testing.T) {
+	g := NewWithT(t)
+	_, writer := test.NewWriter(t)
+	f := newFakeCreate()
+
+	wflw := management.CreateCluster{
+		Spec:                          test.NewClusterSpec(),
+		CreateBootstrapClusterOptions: f,
+		Bootstrapper:                  f,
+		Cluster:                       f,
+		CNIInstaller:                  f,
+		CAPIInstaller:                 f,
+		ManagementMover:               f,
+		GitOpsInstaller: workload.GitOpsInstallerFunc(func(context.Context, *types.Cluster) error {
+			return f.waitForInstalls("InstallGitOps")
+		}),
+		PackagesInstaller: f,
+		FS:                writer,
+	}
+
+	g.Expect(wflw.Run(context.Background())).To(Succeed())
+	g.Expect(f.steps[:2]).To(Equal([]string{"CreateBootstrapCluster", "CreateWorkloadCluster"}))
+	g.Expect(f.steps[2:5]).To(ConsistOf("InstallCAPI", "InstallGitOps", "InstallPackages"))
+	g.Expect(f.steps[5:]).To(Equal([]string{"MoveManagement bootstrap -> test-cluster", "DeleteBootstrapCluster"}))
+}
@@ -31,4 +31,27 @@ func (fn TaskFunc) RunTask(ctx context.Context) (context.Context, error) {
 type namedTask struct {
 	Task
 	Name TaskName
+
+	// Dependencies are the tasks that need to complete before the task can run.
+	Dependencies []TaskName
+}
+
+func (t namedTask) dependenciesCompleted(completed map[TaskName]context.Context) bool {
+	for _, d := range t.Dependencies {
+		if _, ok := completed[d]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// context returns the context the task runs with, built from the contexts returned by its
+// dependencies. Tasks without dependencies run with the workflow context.
+func (t namedTask) context(workflowCtx context.Context, completed map[TaskName]context.Context) context.Context {
+	contexts := make([]context.Context, 0, len(t.Dependencies))
+	for _, d := range t.Dependencies {
+		contexts = append(contexts, completed[d])
+	}
+
+	return mergeContexts(workflowCtx, contexts...)
 }
@@ -0,0 +1,125 @@
+package workload
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/workflow/workflowcontext"
+)
+
+// CAPIInstaller installs the Cluster API and provider components in a cluster.
+type CAPIInstaller interface {
+	// InstallCAPI installs the Cluster API and provider components in cluster.
+	InstallCAPI(_ context.Context, cluster *types.Cluster) error
+}
+
+// ManagementMover moves the Cluster API objects of a cluster between management clusters.
+type ManagementMover interface {
+	// MoveManagement moves the Cluster API objects of the workload cluster from one management
+	// cluster to another, which must already have the Cluster API components.
+	MoveManagement(_ context.Context, from, to *types.Cluster) error
+}
+
+// GitOpsInstaller installs the GitOps controllers in a cluster and pushes its config to the
+// GitOps repository.
+type GitOpsInstaller interface {
+	// InstallGitOps installs the GitOps controllers in cluster.
+	InstallGitOps(_ context.Context, cluster *types.Cluster) error
+}
+
+// GitOpsInstallerFunc is a function that satisfies GitOpsInstaller.
+type GitOpsInstallerFunc func(_ context.Context, cluster *types.Cluster) error
+
+// InstallGitOps satisfies GitOpsInstaller.
+func (f GitOpsInstallerFunc) InstallGitOps(ctx context.Context, cluster *types.Cluster) error {
+	return f(ctx, cluster)
+}
+
+// PackagesInstaller installs the curated packages controller and packages.
+type PackagesInstaller interface {
+	// InstallCuratedPackages installs the curated packages controller and packages. Failures
+	// are reported to the user but don't fail the cluster creation.
+	InstallCuratedPackages(context.Context)
+}
+
+// InstallCAPI installs the Cluster API and provider components in the workload cluster, so it
+// can manage itself. It expects a workload cluster to be available in the context.
+type InstallCAPI struct {
+	// Installer installs the Cluster API and provider components.
+	Installer CAPIInstaller
+}
+
+// RunTask satisfies workflow.Task.
+func (t InstallCAPI) RunTask(ctx context.Context) (context.Context, error) {
+	workloadCluster := workflowcontext.WorkloadCluster(ctx)
+	if workloadCluster == nil {
+		return nil, fmt.Errorf("no workload cluster in context")
+	}
+
+	if err := t.Installer.InstallCAPI(ctx, workloadCluster); err != nil {
+		return nil, fmt.Errorf("installing cluster api in workload cluster: %v", err)
+	}
+
+	return ctx, nil
+}
+
+// MoveManagement moves the Cluster API objects of the workload cluster from the management cluster
+// to the workload cluster itself. It expects a management and a workload cluster to be available
+// in the context, and the Cluster API components to be installed in the workload cluster.
+type MoveManagement struct {
+	// Mover moves the Cluster API objects between clusters.
+	Mover ManagementMover
+}
+
+// RunTask satisfies workflow.Task.
+func (t MoveManagement) RunTask(ctx context.Context) (context.Context, error) {
+	management := workflowcontext.ManagementCluster(ctx)
+	if management == nil {
+		return nil, fmt.Errorf("no management cluster in context")
+	}
+
+	workloadCluster := workflowcontext.WorkloadCluster(ctx)
+	if workloadCluster == nil {
+		return nil, fmt.Errorf("no workload cluster in context")
+	}
+
+	if err := t.Mover.MoveManagement(ctx, management, workloadCluster); err != nil {
+		return nil, fmt.Errorf("moving cluster management to workload cluster: %v", err)
+	}
+
+	return ctx, nil
+}
+
+// InstallGitOps installs the GitOps controllers in the workload cluster. It expects a workload
+// cluster to be available in the context.
+type InstallGitOps struct {
+	// Installer installs the GitOps controllers.
+	Installer GitOpsInstaller
+}
+
+// RunTask satisfies workflow.Task.
+func (t InstallGitOps) RunTask(ctx context.Context) (context.Context, error) {
+	workloadCluster := workflowcontext.WorkloadCluster(ctx)
+	if workloadCluster == nil {
+		return nil, fmt.Errorf("no workload cluster in context")
+	}
+
+	if err := t.Installer.InstallGitOps(ctx, workloadCluster); err != nil {
+		return nil, fmt.Errorf("installing gitops in workload cluster: %v", err)
+	}
+
+	return ctx, nil
+}
+
+// InstallPackages installs the curated packages controller and packages in the workload cluster.
+type InstallPackages struct {
+	// Installer installs the curated packages.
+	Installer PackagesInstaller
+}
+
+// RunTask satisfies workflow.Task.
+func (t InstallPackages) RunTask(ctx context.Context) (context.Context, error) {
+	t.Installer.InstallCuratedPackages(ctx)
+	return ctx, nil
+}
@@ -12,15 +12,17 @@ type Config struct {
 	ErrorHandler ErrorHandler
 }
 
-// Workflow defines an abstract workflow that can execute a serialized set of tasks.
+// Workflow defines an abstract workflow that can execute a set of tasks. Tasks declare the tasks
+// they depend on and run as soon as all their dependencies have completed, so independent tasks
+// run concurrently.
 type Workflow struct {
 	Config
 
-	// tasks are the tasks to be run as part of the core workflow.
+	// tasks are the tasks to be run as part of the core workflow, in the order they were added.
 	tasks []namedTask
 
-	// taskNames is a map of tasks added with AppendTask. Its used to ensure unique task names so
-	// hooks aren't accidentally overwritten.
+	// taskNames is a map of tasks added with AppendTask or AddTask. Its used to ensure unique task
+	// names so hooks aren't accidentally overwritten.
 	taskNames map[TaskName]struct{}
 
 	preWorkflowHooks  []Task
@@ -45,18 +47,43 @@ func New(cfg Config) *Workflow {
 	return wflw
 }
 
-// AppendTask appends t to the list of workflow tasks. Task names must be unique within a workflow.
-// Duplicate names will receive an ErrDuplicateTaskName.
+// AppendTask appends t to the list of workflow tasks. The task depends on the previously added
+// task, so tasks added with AppendTask run one after another. Task names must be unique within a
+// workflow. Duplicate names will receive an ErrDuplicateTaskName.
 func (w *Workflow) AppendTask(name TaskName, t Task) error {
+	var dependencies []TaskName
+	if len(w.tasks) > 0 {
+		dependencies = append(dependencies, w.tasks[len(w.tasks)-1].Name)
+	}
+
+	return w.AddTask(name, t, dependencies...)
+}
+
+// AddTask adds t to the workflow tasks. t runs once all the tasks in dependencies have completed,
+// concurrently with any other task whose dependencies have completed. A task without dependencies
+// runs when the workflow starts. Dependencies must be added before the tasks that depend on them,
+// otherwise ErrUnknownDependency is returned. Task names must be unique within a workflow.
+// Duplicate names will receive an ErrDuplicateTaskName.
+func (w *Workflow) AddTask(name TaskName, t Task, dependencies ...TaskName) error {
 	if _, found := w.taskNames[name]; found {
 		return ErrDuplicateTaskName{name}
 	}
-	w.tasks = append(w.tasks, namedTask{Task: t, Name: name})
+	for _, d := range dependencies {
+		if _, found := w.taskNames[d]; !found {
+			return ErrUnknownDependency{Name: name, Dependency: d}
+		}
+	}
+	w.tasks = append(w.tasks, namedTask{Task: t, Name: name, Dependencies: dependencies})
 	w.taskNames[name] = struct{}{}
 	return nil
 }
 
-// Execute executes the workflow running any pre and post hooks registered for each task.
+// Execute executes the workflow running any pre and post hooks registered for each task. Task
+// hooks run in the same goroutine as their task. Each task receives the context returned by its
+// dependencies, merged when it has more than one, and the post workflow hooks receive the merged
+// contexts of the tasks no other task depends on. If a task or hook fails, no new tasks are
+// started, the context of the running tasks is cancelled and, once they return, the error handler
+// is called with the first error.
 func (w *Workflow) Execute(ctx context.Context) error {
 	var err error
 
@@ -64,25 +91,110 @@ func (w *Workflow) Execute(ctx context.Context) error {
 		return w.handleError(ctx, err)
 	}
 
-	for _, task := range w.tasks {
-		if ctx, err = w.runPreTaskHooks(ctx, task.Name); err != nil {
-			return w.handleError(ctx, err)
+	if ctx, err = w.runTasks(ctx); err != nil {
+		return w.handleError(ctx, err)
+	}
+
+	if ctx, err = runHooks(ctx, w.postWorkflowHooks); err != nil {
+		return w.handleError(ctx, err)
+	}
+
+	return nil
+}
+
+// taskResult is the outcome of running a task with its hooks.
+type taskResult struct {
+	name TaskName
+	ctx  context.Context
+	err  error
+}
+
+// runTasks runs every task once its dependencies have completed and returns the merged contexts
+// of the last tasks. On error, it cancels the tasks still running and returns the context and error
+// of the first task that failed. The returned context isn't cancelled, so it can be used to handle
+// the error.
+func (w *Workflow) runTasks(ctx context.Context) (context.Context, error) {
+	tasksCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(chan taskResult)
+	completed := make(map[TaskName]context.Context, len(w.tasks))
+	started := make(map[TaskName]struct{}, len(w.tasks))
+	running := 0
+
+	var failed *taskResult
+	for {
+		if failed == nil {
+			for _, task := range w.tasks {
+				if _, ok := started[task.Name]; ok || !task.dependenciesCompleted(completed) {
+					continue
+				}
+
+				started[task.Name] = struct{}{}
+				running++
+				go func(task namedTask, ctx context.Context) {
+					ctx, err := w.runTask(ctx, task)
+					results <- taskResult{name: task.Name, ctx: ctx, err: err}
+				}(task, task.context(tasksCtx, completed))
+			}
 		}
 
-		if ctx, err = task.RunTask(ctx); err != nil {
-			return w.handleError(ctx, err)
+		if running == 0 {
+			break
 		}
 
-		if ctx, err = w.runPostTaskHooks(ctx, task.Name); err != nil {
-			return w.handleError(ctx, err)
+		result := <-results
+		running--
+		if result.err != nil {
+			if failed == nil {
+				failed = &result
+				cancel()
+			}
+			continue
 		}
+		completed[result.name] = result.ctx
 	}
 
-	if ctx, err = runHooks(ctx, w.postWorkflowHooks); err != nil {
-		return w.handleError(ctx, err)
+	if failed != nil {
+		return withCancellationOf(ctx, failed.ctx), failed.err
 	}
 
-	return nil
+	return withCancellationOf(ctx, mergeContexts(tasksCtx, w.leafContexts(completed)...)), nil
+}
+
+// leafContexts returns the contexts of the tasks no other task depends on. These already
+// include the values set by the rest of tasks.
+func (w *Workflow) leafContexts(completed map[TaskName]context.Context) []context.Context {
+	dependedOn := make(map[TaskName]struct{}, len(w.tasks))
+	for _, task := range w.tasks {
+		for _, d := range task.Dependencies {
+			dependedOn[d] = struct{}{}
+		}
+	}
+
+	var contexts []context.Context
+	for _, task := range w.tasks {
+		if _, ok := dependedOn[task.Name]; !ok {
+			contexts = append(contexts, completed[task.Name])
+		}
+	}
+
+	return contexts
+}
+
+// runTask runs a task surrounded by its pre and post hooks.
+func (w *Workflow) runTask(ctx context.Context, task namedTask) (context.Context, error) {
+	var err error
+
+	if ctx, err = w.runPreTaskHooks(ctx, task.Name); err != nil {
+		return ctx, err
+	}
+
+	if ctx, err = task.RunTask(ctx); err != nil {
+		return ctx, err
+	}
+
+	return w.runPostTaskHooks(ctx, task.Name)
 }
 
 // BindPreWorkflowHook implements the HookBinder interface.
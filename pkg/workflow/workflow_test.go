@@ -348,3 +348,170 @@ func TestDuplicateTaskNames(t *testing.T) {
 	err = wflw.AppendTask(taskName, task2)
 	g.Expect(err).To(gomega.HaveOccurred())
 }
+
+type contextKey string
+
+func setValueTask(key contextKey, value string) workflow.TaskFunc {
+	return func(ctx context.Context) (context.Context, error) {
+		return context.WithValue(ctx, key, value), nil
+	}
+}
+
+func TestWorkflowExecuteConcurrentTasks(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	task2Started := make(chan struct{})
+	task3Started := make(chan struct{})
+	var task4Values, postWorkflowValues []any
+
+	wflw := workflow.New(workflow.Config{})
+	g.Expect(wflw.AddTask("task1", setValueTask("task1", "a"))).To(gomega.Succeed())
+	// task2 and task3 wait for each other, so they only complete if they run concurrently.
+	g.Expect(wflw.AddTask("task2", workflow.TaskFunc(func(ctx context.Context) (context.Context, error) {
+		close(task2Started)
+		<-task3Started
+		return context.WithValue(ctx, contextKey("task2"), "b"), nil
+	}), "task1")).To(gomega.Succeed())
+	g.Expect(wflw.AddTask("task3", workflow.TaskFunc(func(ctx context.Context) (context.Context, error) {
+		close(task3Started)
+		<-task2Started
+		return context.WithValue(ctx, contextKey("task3"), "c"), nil
+	}), "task1")).To(gomega.Succeed())
+	g.Expect(wflw.AddTask("task4", workflow.TaskFunc(func(ctx context.Context) (context.Context, error) {
+		task4Values = []any{ctx.Value(contextKey("task1")), ctx.Value(contextKey("task2")), ctx.Value(contextKey("task3"))}
+		return ctx, nil
+	}), "task2", "task3")).To(gomega.Succeed())
+	g.Expect(wflw.AddTask("task5", setValueTask("task5", "e"))).To(gomega.Succeed())
+
+	wflw.BindPostWorkflowHook(workflow.TaskFunc(func(ctx context.Context) (context.Context, error) {
+		postWorkflowValues = []any{ctx.Value(contextKey("task1")), ctx.Value(contextKey("task3")), ctx.Value(contextKey("task5"))}
+		return ctx, nil
+	}))
+
+	g.Expect(wflw.Execute(context.Background())).To(gomega.Succeed())
+	g.Expect(task4Values).To(gomega.Equal([]any{"a", "b", "c"}))
+	g.Expect(postWorkflowValues).To(gomega.Equal([]any{"a", "c", "e"}))
+}
+
+func TestWorkflowExecuteConcurrentTaskHooks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	g := gomega.NewWithT(t)
+
+	preTaskHook := NewMockTask(ctrl)
+	runPreTaskHook := preTaskHook.EXPECT().
+		RunTask(gomock.Any()).
+		Return(context.Background(), nil)
+
+	task := NewMockTask(ctrl)
+	runTask := task.EXPECT().
+		RunTask(gomock.Any()).
+		Return(context.Background(), nil)
+
+	postTaskHook := NewMockTask(ctrl)
+	runPostTaskHook := postTaskHook.EXPECT().
+		RunTask(gomock.Any()).
+		Return(context.Background(), nil)
+
+	gomock.InOrder(runPreTaskHook, runTask, runPostTaskHook)
+
+	wflw := workflow.New(workflow.Config{})
+	g.Expect(wflw.AddTask("task1", setValueTask("task1", "a"))).To(gomega.Succeed())
+	g.Expect(wflw.AddTask("task2", task)).To(gomega.Succeed())
+	wflw.BindPreTaskHook("task2", preTaskHook)
+	wflw.BindPostTaskHook("task2", postTaskHook)
+
+	g.Expect(wflw.Execute(context.Background())).To(gomega.Succeed())
+}
+
+func TestErroneousConcurrentTask(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	g := gomega.NewWithT(t)
+
+	expect := errors.New("expected error")
+	task1Failed := make(chan struct{})
+
+	// These shouldn't run.
+	task3 := NewMockTask(ctrl)
+	postWorkflowHook := NewMockTask(ctrl)
+
+	var handled []error
+	wflw := workflow.New(workflow.Config{
+		ErrorHandler: func(_ context.Context, err error) {
+			handled = append(handled, err)
+		},
+	})
+	g.Expect(wflw.AddTask("task1", workflow.TaskFunc(func(ctx context.Context) (context.Context, error) {
+		defer close(task1Failed)
+		return ctx, expect
+	}))).To(gomega.Succeed())
+	// task2 is already running when task1 fails, so it completes.
+	task2Completed := false
+	g.Expect(wflw.AddTask("task2", workflow.TaskFunc(func(ctx context.Context) (context.Context, error) {
+		<-task1Failed
+		task2Completed = true
+		return ctx, nil
+	}))).To(gomega.Succeed())
+	g.Expect(wflw.AddTask("task3", task3, "task1", "task2")).To(gomega.Succeed())
+	wflw.BindPostWorkflowHook(postWorkflowHook)
+
+	g.Expect(wflw.Execute(context.Background())).To(gomega.MatchError(expect))
+	g.Expect(task2Completed).To(gomega.BeTrue())
+	g.Expect(handled).To(gomega.Equal([]error{expect}))
+}
+
+func TestErroneousConcurrentTaskCancelsRunningTasks(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	expect := errors.New("expected error")
+	task2Started := make(chan struct{})
+
+	var handledCtxErr error
+	wflw := workflow.New(workflow.Config{
+		ErrorHandler: func(ctx context.Context, _ error) {
+			handledCtxErr = ctx.Err()
+		},
+	})
+	g.Expect(wflw.AddTask("task1", workflow.TaskFunc(func(ctx context.Context) (context.Context, error) {
+		<-task2Started
+		return ctx, expect
+	}))).To(gomega.Succeed())
+	// task2 only returns once its context is cancelled.
+	var task2Err error
+	g.Expect(wflw.AddTask("task2", workflow.TaskFunc(func(ctx context.Context) (context.Context, error) {
+		close(task2Started)
+		<-ctx.Done()
+		task2Err = ctx.Err()
+		return ctx, task2Err
+	}))).To(gomega.Succeed())
+
+	g.Expect(wflw.Execute(context.Background())).To(gomega.MatchError(expect))
+	g.Expect(task2Err).To(gomega.MatchError(context.Canceled))
+	g.Expect(handledCtxErr).NotTo(gomega.HaveOccurred())
+}
+
+func TestWorkflowExecutePostWorkflowHookContextNotCancelled(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	var hookCtxErr error
+	wflw := workflow.New(workflow.Config{})
+	g.Expect(wflw.AddTask("task1", setValueTask("task1", "a"))).To(gomega.Succeed())
+	wflw.BindPostWorkflowHook(workflow.TaskFunc(func(ctx context.Context) (context.Context, error) {
+		hookCtxErr = ctx.Err()
+		return ctx, nil
+	}))
+
+	g.Expect(wflw.Execute(context.Background())).To(gomega.Succeed())
+	g.Expect(hookCtxErr).NotTo(gomega.HaveOccurred())
+}
+
+func TestAddTaskUnknownDependency(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	g := gomega.NewWithT(t)
+
+	wflw := workflow.New(workflow.Config{})
+	g.Expect(wflw.AddTask("task1", NewMockTask(ctrl))).To(gomega.Succeed())
+
+	err := wflw.AddTask("task2", NewMockTask(ctrl), "task1", "task3")
+	g.Expect(err).To(gomega.MatchError(workflow.ErrUnknownDependency{Name: "task2", Dependency: "task3"}))
+	g.Expect(err).To(gomega.MatchError("task task2 depends on unknown task: task3"))
+}
@@ -0,0 +1,99 @@
+package workflows
+
+import (
+	"context"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/task"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+// bootstrapClusterRecovery is embedded in the tasks that use the bootstrap cluster. When a checkpoint is restored
+// and the bootstrap cluster created by the previous run doesn't exist anymore, the first of these tasks to run
+// recreates it before doing its own work. If the lost cluster already had CAPI installed or was holding the
+// management resources, these are installed again and restored from the backup taken before the move.
+type bootstrapClusterRecovery struct {
+	recreate          bool
+	installCAPI       bool
+	restoreManagement bool
+}
+
+// createBootstrapClusterFunc creates a bootstrap cluster and sets it in the command context.
+type createBootstrapClusterFunc func(ctx context.Context, commandContext *task.CommandContext) error
+
+// recovered returns the recovery for the next task once the task that installs CAPI or moves
+// the management resources has been restored.
+func (r bootstrapClusterRecovery) recovered(capiInstalled, managementMoved bool) bootstrapClusterRecovery {
+	return bootstrapClusterRecovery{
+		recreate:          r.recreate,
+		installCAPI:       r.recreate && (r.installCAPI || capiInstalled),
+		restoreManagement: r.recreate && (r.restoreManagement || managementMoved),
+	}
+}
+
+func (r bootstrapClusterRecovery) recover(ctx context.Context, commandContext *task.CommandContext, create createBootstrapClusterFunc) error {
+	if !r.recreate {
+		return nil
+	}
+
+	logger.Info("Recreating bootstrap cluster lost in previous run")
+	if err := create(ctx, commandContext); err != nil {
+		return err
+	}
+
+	if r.installCAPI {
+		logger.Info("Installing cluster-api providers on bootstrap cluster")
+		if err := commandContext.ClusterManager.InstallCAPI(ctx, commandContext.ClusterSpec, commandContext.BootstrapCluster, commandContext.Provider); err != nil {
+			return err
+		}
+	}
+
+	if r.restoreManagement {
+		logger.Info("Restoring management cluster resources in bootstrap cluster from backup", "backup", commandContext.ManagementClusterStateDir)
+		if err := commandContext.ClusterManager.RestoreCAPI(ctx, commandContext.BootstrapCluster, commandContext.WorkloadCluster.Name, commandContext.ManagementClusterStateDir); err != nil {
+			return err
+		}
+		commandContext.ManagementCluster = commandContext.BootstrapCluster
+	}
+
+	return nil
+}
+
+// restoreBootstrapCluster reads the bootstrap cluster saved in a checkpoint and sets it in the command context.
+// It returns false if the kind cluster backing it doesn't exist anymore.
+func restoreBootstrapCluster(ctx context.Context, commandContext *task.CommandContext, completedTask *task.CompletedTask) (bool, error) {
+	if completedTask == nil || completedTask.Checkpoint == nil {
+		return true, nil
+	}
+
+	bootstrapCluster := &types.Cluster{}
+	if err := task.UnmarshalTaskCheckpoint(completedTask.Checkpoint, bootstrapCluster); err != nil {
+		return false, err
+	}
+	commandContext.BootstrapCluster = bootstrapCluster
+
+	exists, err := commandContext.Bootstrapper.BootstrapClusterExists(ctx, bootstrapCluster)
+	if err != nil {
+		return false, err
+	}
+	if !exists {
+		logger.Info("Bootstrap cluster from previous run not found, it will be recreated", "cluster", bootstrapCluster.Name)
+	}
+
+	return exists, nil
+}
+
+// restoreManagementClusterStateDir reads the directory of the management resources backup saved in a checkpoint.
+func restoreManagementClusterStateDir(commandContext *task.CommandContext, completedTask *task.CompletedTask) error {
+	if completedTask == nil || completedTask.Checkpoint == nil {
+		return nil
+	}
+
+	var stateDir string
+	if err := task.UnmarshalTaskCheckpoint(completedTask.Checkpoint, &stateDir); err != nil {
+		return err
+	}
+	commandContext.ManagementClusterStateDir = stateDir
+
+	return nil
+}
@@ -71,6 +71,20 @@ func (c *Create) Run(ctx context.Context, clusterSpec *cluster.Spec, validator i
 	return err
 }
 
+// Validate runs the provider setup and the validations of the create without creating the cluster.
+// It's used by dry runs, which then render the manifests instead of applying them.
+func (c *Create) Validate(ctx context.Context, clusterSpec *cluster.Spec, validator interfaces.Validator) error {
+	commandContext := &task.CommandContext{
+		Provider:      c.provider,
+		GitOpsManager: c.gitOpsManager,
+		ClusterSpec:   clusterSpec,
+		Validations:   validator,
+	}
+	(&SetAndValidateTask{}).Run(ctx, commandContext)
+
+	return commandContext.OriginalError
+}
+
 // task related entities
 
 type CreateBootStrapClusterTask struct{}
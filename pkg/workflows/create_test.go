@@ -496,3 +496,27 @@ func TestCreateWorkloadClusterTaskRunPostCreateWorkloadClusterFailure(t *testing
 		t.Fatalf("expected error from task")
 	}
 }
+
+func TestCreateValidateSuccess(t *testing.T) {
+	test := newCreateTest(t)
+
+	test.expectSetup()
+	test.expectPreflightValidationsToPass()
+
+	if err := test.workflow.Validate(test.ctx, test.clusterSpec, test.validator); err != nil {
+		t.Fatalf("Create.Validate() err = %v, want err = nil", err)
+	}
+}
+
+func TestCreateValidateFailure(t *testing.T) {
+	test := newCreateTest(t)
+
+	test.provider.EXPECT().SetupAndValidateCreateCluster(test.ctx, test.clusterSpec).Return(errors.New("invalid datacenter"))
+	test.provider.EXPECT().Name()
+	test.gitOpsManager.EXPECT().Validations(test.ctx, test.clusterSpec)
+	test.expectPreflightValidationsToPass()
+
+	if err := test.workflow.Validate(test.ctx, test.clusterSpec, test.validator); err == nil {
+		t.Fatal("Create.Validate() err = nil, want err not nil")
+	}
+}
@@ -2,6 +2,9 @@ package workflows
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"path/filepath"
 
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/constants"
@@ -48,6 +51,7 @@ func (c *Delete) Run(ctx context.Context, workloadCluster *types.Cluster, cluste
 		Provider:        c.provider,
 		ClusterManager:  c.clusterManager,
 		GitOpsManager:   c.gitOpsManager,
+		Writer:          c.writer,
 		WorkloadCluster: workloadCluster,
 		ClusterSpec:     clusterSpec,
 	}
@@ -56,18 +60,28 @@ func (c *Delete) Run(ctx context.Context, workloadCluster *types.Cluster, cluste
 		commandContext.BootstrapCluster = clusterSpec.ManagementCluster
 	}
 
-	return task.NewTaskRunner(&setupAndValidate{}, c.writer).RunTask(ctx, commandContext)
+	checkpointFile := fmt.Sprintf("%s-delete-checkpoint.yaml", clusterSpec.Cluster.Name)
+	return task.NewTaskRunner(&setupAndValidate{}, c.writer, task.WithCheckpointFileName(checkpointFile)).RunTask(ctx, commandContext)
 }
 
 type setupAndValidate struct{}
 
-type createManagementCluster struct{}
+type createManagementCluster struct {
+	bootstrapCluster *types.Cluster
+}
 
-type installCAPI struct{}
+type installCAPI struct {
+	bootstrapClusterRecovery
+}
 
-type moveClusterManagement struct{}
+type moveClusterManagement struct {
+	bootstrapClusterRecovery
+	managementClusterStateDir string
+}
 
-type deleteWorkloadCluster struct{}
+type deleteWorkloadCluster struct {
+	bootstrapClusterRecovery
+}
 
 type cleanupGitRepo struct{}
 
@@ -90,11 +104,17 @@ func (s *setupAndValidate) Name() string {
 }
 
 func (s *setupAndValidate) Restore(ctx context.Context, commandContext *task.CommandContext, completedTask *task.CompletedTask) (task.Task, error) {
-	return nil, nil
+	if err := commandContext.Provider.SetupAndValidateDeleteCluster(ctx, commandContext.WorkloadCluster, commandContext.ClusterSpec); err != nil {
+		commandContext.SetError(err)
+		return nil, err
+	}
+	return &createManagementCluster{}, nil
 }
 
 func (s *setupAndValidate) Checkpoint() *task.CompletedTask {
-	return nil
+	return &task.CompletedTask{
+		Checkpoint: nil,
+	}
 }
 
 func (s *createManagementCluster) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
@@ -121,23 +141,56 @@ func (s *createManagementCluster) Run(ctx context.Context, commandContext *task.
 		commandContext.SetError(err)
 		return &CollectMgmtClusterDiagnosticsTask{}
 	}
+	s.bootstrapCluster = bootstrapCluster
 
 	return &installCAPI{}
 }
 
+// recreateDeleteBootstrapCluster creates a new management cluster to replace the one lost in a previous run.
+func recreateDeleteBootstrapCluster(ctx context.Context, commandContext *task.CommandContext) error {
+	bootstrapOptions, err := commandContext.Provider.BootstrapClusterOpts(commandContext.ClusterSpec)
+	if err != nil {
+		return err
+	}
+
+	bootstrapCluster, err := commandContext.Bootstrapper.CreateBootstrapCluster(ctx, commandContext.ClusterSpec, bootstrapOptions...)
+	if err != nil {
+		return err
+	}
+	commandContext.BootstrapCluster = bootstrapCluster
+
+	logger.Info("Provider specific pre-capi-install-setup on bootstrap cluster")
+	return commandContext.Provider.PreCAPIInstallOnBootstrap(ctx, bootstrapCluster, commandContext.ClusterSpec)
+}
+
 func (s *createManagementCluster) Name() string {
 	return "management-cluster-init"
 }
 
 func (s *createManagementCluster) Restore(ctx context.Context, commandContext *task.CommandContext, completedTask *task.CompletedTask) (task.Task, error) {
-	return nil, nil
+	if commandContext.BootstrapCluster != nil && commandContext.BootstrapCluster.ExistingManagement {
+		return &deleteWorkloadCluster{}, nil
+	}
+	exists, err := restoreBootstrapCluster(ctx, commandContext, completedTask)
+	if err != nil {
+		return nil, err
+	}
+	s.bootstrapCluster = commandContext.BootstrapCluster
+	return &installCAPI{bootstrapClusterRecovery{recreate: !exists}}, nil
 }
 
 func (s *createManagementCluster) Checkpoint() *task.CompletedTask {
-	return nil
+	return &task.CompletedTask{
+		Checkpoint: s.bootstrapCluster,
+	}
 }
 
 func (s *installCAPI) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
+	if err := s.recover(ctx, commandContext, recreateDeleteBootstrapCluster); err != nil {
+		commandContext.SetError(err)
+		return &CollectMgmtClusterDiagnosticsTask{}
+	}
+
 	logger.Info("Installing cluster-api providers on management cluster")
 	err := commandContext.ClusterManager.InstallCAPI(ctx, commandContext.ClusterSpec, commandContext.BootstrapCluster, commandContext.Provider)
 	if err != nil {
@@ -152,20 +205,35 @@ func (s *installCAPI) Name() string {
 }
 
 func (s *installCAPI) Restore(ctx context.Context, commandContext *task.CommandContext, completedTask *task.CompletedTask) (task.Task, error) {
-	return nil, nil
+	return &moveClusterManagement{bootstrapClusterRecovery: s.recovered(true, false)}, nil
 }
 
 func (s *installCAPI) Checkpoint() *task.CompletedTask {
-	return nil
+	return &task.CompletedTask{
+		Checkpoint: nil,
+	}
 }
 
 func (s *moveClusterManagement) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
+	if err := s.recover(ctx, commandContext, recreateDeleteBootstrapCluster); err != nil {
+		commandContext.SetError(err)
+		return &CollectMgmtClusterDiagnosticsTask{}
+	}
+
+	logger.Info("Backing up workload cluster's management resources before moving to management cluster")
+	err := commandContext.ClusterManager.BackupCAPI(ctx, commandContext.WorkloadCluster, commandContext.ManagementClusterStateDir)
+	if err != nil {
+		commandContext.SetError(err)
+		return &CollectDiagnosticsTask{}
+	}
+
 	logger.Info("Moving cluster management from workload cluster")
-	err := commandContext.ClusterManager.MoveCAPI(ctx, commandContext.WorkloadCluster, commandContext.BootstrapCluster, commandContext.WorkloadCluster.Name, commandContext.ClusterSpec, types.WithNodeRef())
+	err = commandContext.ClusterManager.MoveCAPI(ctx, commandContext.WorkloadCluster, commandContext.BootstrapCluster, commandContext.WorkloadCluster.Name, commandContext.ClusterSpec, types.WithNodeRef())
 	if err != nil {
 		commandContext.SetError(err)
 		return &CollectDiagnosticsTask{}
 	}
+	s.managementClusterStateDir = commandContext.ManagementClusterStateDir
 	return &deleteWorkloadCluster{}
 }
 
@@ -174,14 +242,24 @@ func (s *moveClusterManagement) Name() string {
 }
 
 func (s *moveClusterManagement) Restore(ctx context.Context, commandContext *task.CommandContext, completedTask *task.CompletedTask) (task.Task, error) {
-	return nil, nil
+	if err := restoreManagementClusterStateDir(commandContext, completedTask); err != nil {
+		return nil, err
+	}
+	return &deleteWorkloadCluster{s.recovered(false, true)}, nil
 }
 
 func (s *moveClusterManagement) Checkpoint() *task.CompletedTask {
-	return nil
+	return &task.CompletedTask{
+		Checkpoint: s.managementClusterStateDir,
+	}
 }
 
 func (s *deleteWorkloadCluster) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
+	if err := s.recover(ctx, commandContext, recreateDeleteBootstrapCluster); err != nil {
+		commandContext.SetError(err)
+		return &CollectMgmtClusterDiagnosticsTask{}
+	}
+
 	logger.Info("Deleting workload cluster")
 	err := commandContext.ClusterManager.DeleteCluster(ctx, commandContext.BootstrapCluster, commandContext.WorkloadCluster, commandContext.Provider, commandContext.ClusterSpec)
 	if err != nil {
@@ -197,11 +275,13 @@ func (s *deleteWorkloadCluster) Name() string {
 }
 
 func (s *deleteWorkloadCluster) Restore(ctx context.Context, commandContext *task.CommandContext, completedTask *task.CompletedTask) (task.Task, error) {
-	return nil, nil
+	return &cleanupGitRepo{}, nil
 }
 
 func (s *deleteWorkloadCluster) Checkpoint() *task.CompletedTask {
-	return nil
+	return &task.CompletedTask{
+		Checkpoint: nil,
+	}
 }
 
 func (s *cleanupGitRepo) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
@@ -220,11 +300,13 @@ func (s *cleanupGitRepo) Name() string {
 }
 
 func (s *cleanupGitRepo) Restore(ctx context.Context, commandContext *task.CommandContext, completedTask *task.CompletedTask) (task.Task, error) {
-	return nil, nil
+	return &deletePackageResources{}, nil
 }
 
 func (s *cleanupGitRepo) Checkpoint() *task.CompletedTask {
-	return nil
+	return &task.CompletedTask{
+		Checkpoint: nil,
+	}
 }
 
 func (s *deletePackageResources) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
@@ -251,11 +333,13 @@ func (s *deletePackageResources) Name() string {
 }
 
 func (s *deletePackageResources) Restore(ctx context.Context, commandContext *task.CommandContext, completedTask *task.CompletedTask) (task.Task, error) {
-	return nil, nil
+	return &deleteManagementCluster{}, nil
 }
 
 func (s *deletePackageResources) Checkpoint() *task.CompletedTask {
-	return nil
+	return &task.CompletedTask{
+		Checkpoint: nil,
+	}
 }
 
 func (s *deleteManagementCluster) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
@@ -267,6 +351,13 @@ func (s *deleteManagementCluster) Run(ctx context.Context, commandContext *task.
 		if err := commandContext.Bootstrapper.DeleteBootstrapCluster(ctx, commandContext.BootstrapCluster, constants.Delete, false); err != nil {
 			commandContext.SetError(err)
 		}
+
+		if commandContext.OriginalError == nil && commandContext.ManagementClusterStateDir != "" {
+			capiObjectFile := filepath.Join(commandContext.WorkloadCluster.Name, commandContext.ManagementClusterStateDir)
+			if err := os.RemoveAll(capiObjectFile); err != nil {
+				logger.Info(fmt.Sprintf("management cluster CAPI backup file not found: %v", err))
+			}
+		}
 		return nil
 	}
 	logger.Info("Bootstrap cluster information missing - skipping delete kind cluster")
@@ -285,5 +376,7 @@ func (s *deleteManagementCluster) Restore(ctx context.Context, commandContext *t
 }
 
 func (s *deleteManagementCluster) Checkpoint() *task.CompletedTask {
-	return nil
+	return &task.CompletedTask{
+		Checkpoint: nil,
+	}
 }
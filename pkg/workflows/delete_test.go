@@ -3,6 +3,7 @@ package workflows_test
 import (
 	"context"
 	"fmt"
+	"os"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -128,6 +129,7 @@ func (c *deleteTestSetup) expectCleanupGitRepo() {
 
 func (c *deleteTestSetup) expectMoveManagement() {
 	gomock.InOrder(
+		c.clusterManager.EXPECT().BackupCAPI(c.ctx, c.workloadCluster, gomock.Any()),
 		c.clusterManager.EXPECT().MoveCAPI(
 			c.ctx, c.workloadCluster, c.bootstrapCluster, c.workloadCluster.Name, c.clusterSpec, gomock.Any(),
 		),
@@ -206,3 +208,47 @@ func TestDeleteWorkloadDeletePackageResourceError(t *testing.T) {
 		t.Fatalf("Delete.Run() err = %v, want err = nil", err)
 	}
 }
+
+func TestDeleteWithCheckpointBootstrapClusterLost(t *testing.T) {
+	test := newDeleteTest(t)
+	checkpoint := `completedTasks:
+  setup-and-validate:
+    checkpoint: null
+  management-cluster-init:
+    checkpoint:
+      ExistingManagement: false
+      KubeconfigFile: ""
+      Name: bootstrap
+  install-capi:
+    checkpoint: null
+  cluster-management-move:
+    checkpoint: cluster-state-backup-test
+`
+	checkpointFile, err := test.writer.Write("cluster-name-delete-checkpoint.yaml", []byte(checkpoint))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	test.expectSetup()
+	test.bootstrapper.EXPECT().BootstrapClusterExists(test.ctx, test.bootstrapCluster).Return(false, nil)
+	gomock.InOrder(
+		test.provider.EXPECT().BootstrapClusterOpts(test.clusterSpec),
+		test.bootstrapper.EXPECT().CreateBootstrapCluster(test.ctx, test.clusterSpec).Return(test.bootstrapCluster, nil),
+		test.provider.EXPECT().PreCAPIInstallOnBootstrap(test.ctx, test.bootstrapCluster, test.clusterSpec),
+		test.clusterManager.EXPECT().InstallCAPI(test.ctx, test.clusterSpec, test.bootstrapCluster, test.provider),
+		test.clusterManager.EXPECT().RestoreCAPI(test.ctx, test.bootstrapCluster, test.workloadCluster.Name, "cluster-state-backup-test"),
+	)
+	test.expectNotToMoveManagement()
+	test.expectDeleteWorkload(test.bootstrapCluster)
+	test.expectCleanupGitRepo()
+	test.expectNotToDeletePackageResources()
+	test.expectDeleteBootstrap()
+
+	if err := test.run(); err != nil {
+		t.Fatalf("Delete.Run() err = %v, want err = nil", err)
+	}
+
+	if _, err := os.Stat(checkpointFile); !os.IsNotExist(err) {
+		t.Fatalf("checkpoint file should be removed after a successful run, got %v", err)
+	}
+}
@@ -14,10 +14,12 @@ import (
 type Bootstrapper interface {
 	CreateBootstrapCluster(ctx context.Context, clusterSpec *cluster.Spec, opts ...bootstrapper.BootstrapClusterOption) (*types.Cluster, error)
 	DeleteBootstrapCluster(context.Context, *types.Cluster, constants.Operation, bool) error
+	BootstrapClusterExists(ctx context.Context, cluster *types.Cluster) (bool, error)
 }
 
 type ClusterManager interface {
 	BackupCAPI(ctx context.Context, cluster *types.Cluster, managementStatePath string) error
+	RestoreCAPI(ctx context.Context, to *types.Cluster, clusterName, managementStatePath string) error
 	MoveCAPI(ctx context.Context, from, to *types.Cluster, clusterName string, clusterSpec *cluster.Spec, checkers ...types.NodeReadyChecker) error
 	CreateWorkloadCluster(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec, provider providers.Provider) (*types.Cluster, error)
 	PauseCAPIWorkloadClusters(ctx context.Context, managementCluster *types.Cluster) error
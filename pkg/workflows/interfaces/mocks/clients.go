@@ -40,6 +40,21 @@ func (m *MockBootstrapper) EXPECT() *MockBootstrapperMockRecorder {
 	return m.recorder
 }
 
+// BootstrapClusterExists mocks base method.
+func (m *MockBootstrapper) BootstrapClusterExists(arg0 context.Context, arg1 *types.Cluster) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BootstrapClusterExists", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BootstrapClusterExists indicates an expected call of BootstrapClusterExists.
+func (mr *MockBootstrapperMockRecorder) BootstrapClusterExists(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BootstrapClusterExists", reflect.TypeOf((*MockBootstrapper)(nil).BootstrapClusterExists), arg0, arg1)
+}
+
 // CreateBootstrapCluster mocks base method.
 func (m *MockBootstrapper) CreateBootstrapCluster(arg0 context.Context, arg1 *cluster.Spec, arg2 ...bootstrapper.BootstrapClusterOption) (*types.Cluster, error) {
 	m.ctrl.T.Helper()
@@ -357,6 +372,20 @@ func (mr *MockClusterManagerMockRecorder) PauseEKSAControllerReconcile(arg0, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PauseEKSAControllerReconcile", reflect.TypeOf((*MockClusterManager)(nil).PauseEKSAControllerReconcile), arg0, arg1, arg2, arg3)
 }
 
+// RestoreCAPI mocks base method.
+func (m *MockClusterManager) RestoreCAPI(arg0 context.Context, arg1 *types.Cluster, arg2, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreCAPI", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreCAPI indicates an expected call of RestoreCAPI.
+func (mr *MockClusterManagerMockRecorder) RestoreCAPI(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreCAPI", reflect.TypeOf((*MockClusterManager)(nil).RestoreCAPI), arg0, arg1, arg2, arg3)
+}
+
 // ResumeCAPIWorkloadClusters mocks base method.
 func (m *MockClusterManager) ResumeCAPIWorkloadClusters(arg0 context.Context, arg1 *types.Cluster) error {
 	m.ctrl.T.Helper()
@@ -9,7 +9,6 @@ import (
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/clustermarshaller"
 	"github.com/aws/eks-anywhere/pkg/constants"
-	"github.com/aws/eks-anywhere/pkg/features"
 	"github.com/aws/eks-anywhere/pkg/filewriter"
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/providers"
@@ -74,11 +73,23 @@ func (c *Upgrade) Run(ctx context.Context, clusterSpec *cluster.Spec, management
 		UpgradeChangeDiff: c.upgradeChangeDiff,
 		ForceCleanup:      forceCleanup,
 	}
-	if features.IsActive(features.CheckpointEnabled()) {
-		return task.NewTaskRunner(&setupAndValidateTasks{}, c.writer, task.WithCheckpointFile()).RunTask(ctx, commandContext)
+
+	return task.NewTaskRunner(&setupAndValidateTasks{}, c.writer, task.WithCheckpointFile()).RunTask(ctx, commandContext)
+}
+
+// Validate runs the provider setup and the validations of the upgrade without upgrading the cluster.
+// It's used by dry runs, which then render the manifests instead of applying them.
+func (c *Upgrade) Validate(ctx context.Context, clusterSpec *cluster.Spec, managementCluster *types.Cluster, validator interfaces.Validator) error {
+	commandContext := &task.CommandContext{
+		Provider:          c.provider,
+		ClusterManager:    c.clusterManager,
+		ManagementCluster: managementCluster,
+		ClusterSpec:       clusterSpec,
+		Validations:       validator,
 	}
+	(&setupAndValidateTasks{}).Run(ctx, commandContext)
 
-	return task.NewTaskRunner(&setupAndValidateTasks{}, c.writer).RunTask(ctx, commandContext)
+	return commandContext.OriginalError
 }
 
 type setupAndValidateTasks struct{}
@@ -91,7 +102,9 @@ type upgradeCoreComponents struct {
 	UpgradeChangeDiff *types.ChangeDiff
 }
 
-type upgradeNeeded struct{}
+type upgradeNeeded struct {
+	clusterUpgradeNeeded bool
+}
 
 type pauseEksaReconcile struct{}
 
@@ -99,13 +112,22 @@ type createBootstrapClusterTask struct {
 	bootstrapCluster *types.Cluster
 }
 
-type installCAPITask struct{}
+type installCAPITask struct {
+	bootstrapClusterRecovery
+}
 
-type moveManagementToBootstrapTask struct{}
+type moveManagementToBootstrapTask struct {
+	bootstrapClusterRecovery
+	managementClusterStateDir string
+}
 
-type moveManagementToWorkloadTask struct{}
+type moveManagementToWorkloadTask struct {
+	bootstrapClusterRecovery
+}
 
-type upgradeWorkloadClusterTask struct{}
+type upgradeWorkloadClusterTask struct {
+	bootstrapClusterRecovery
+}
 
 type deleteBootstrapClusterTask struct {
 	*CollectDiagnosticsTask
@@ -159,17 +181,17 @@ func (s *setupAndValidateTasks) Name() string {
 }
 
 func (s *setupAndValidateTasks) Restore(ctx context.Context, commandContext *task.CommandContext, completedTask *task.CompletedTask) (task.Task, error) {
-	if err := commandContext.Provider.SetupAndValidateUpgradeCluster(ctx, commandContext.ManagementCluster, commandContext.ClusterSpec, commandContext.CurrentClusterSpec); err != nil {
-		commandContext.SetError(err)
-		return nil, err
-	}
-	logger.Info(fmt.Sprintf("%s Provider setup is valid", commandContext.Provider.Name()))
 	currentSpec, err := commandContext.ClusterManager.GetCurrentClusterSpec(ctx, commandContext.ManagementCluster, commandContext.ClusterSpec.Cluster.Name)
 	if err != nil {
 		commandContext.SetError(err)
 		return nil, err
 	}
 	commandContext.CurrentClusterSpec = currentSpec
+	if err := commandContext.Provider.SetupAndValidateUpgradeCluster(ctx, commandContext.ManagementCluster, commandContext.ClusterSpec, commandContext.CurrentClusterSpec); err != nil {
+		commandContext.SetError(err)
+		return nil, err
+	}
+	logger.Info(fmt.Sprintf("%s Provider setup is valid", commandContext.Provider.Name()))
 	return &updateSecrets{}, nil
 }
 
@@ -309,6 +331,7 @@ func (s *upgradeNeeded) Run(ctx context.Context, commandContext *task.CommandCon
 		return nil
 	} else if upgradeNeeded {
 		logger.V(3).Info("Provider needs a cluster upgrade")
+		s.clusterUpgradeNeeded = true
 		return &createBootstrapClusterTask{}
 	}
 	diff, err := commandContext.ClusterManager.EKSAClusterSpecChanged(ctx, commandContext.ManagementCluster, newSpec)
@@ -322,6 +345,7 @@ func (s *upgradeNeeded) Run(ctx context.Context, commandContext *task.CommandCon
 		return &reconcileClusterDefinitions{eksaSpecDiff: false}
 	}
 
+	s.clusterUpgradeNeeded = true
 	return &createBootstrapClusterTask{}
 }
 
@@ -331,11 +355,21 @@ func (s *upgradeNeeded) Name() string {
 
 func (s *upgradeNeeded) Checkpoint() *task.CompletedTask {
 	return &task.CompletedTask{
-		Checkpoint: nil,
+		Checkpoint: s.clusterUpgradeNeeded,
 	}
 }
 
 func (s *upgradeNeeded) Restore(ctx context.Context, commandContext *task.CommandContext, completedTask *task.CompletedTask) (task.Task, error) {
+	// Checkpoints saved before the decision was recorded always continued with the upgrade.
+	s.clusterUpgradeNeeded = true
+	if completedTask != nil && completedTask.Checkpoint != nil {
+		if err := task.UnmarshalTaskCheckpoint(completedTask.Checkpoint, &s.clusterUpgradeNeeded); err != nil {
+			return nil, err
+		}
+	}
+	if !s.clusterUpgradeNeeded {
+		return &reconcileClusterDefinitions{eksaSpecDiff: false}, nil
+	}
 	return &createBootstrapClusterTask{}, nil
 }
 
@@ -397,20 +431,39 @@ func (s *createBootstrapClusterTask) Run(ctx context.Context, commandContext *ta
 		return &deleteBootstrapClusterTask{}
 	}
 
-	logger.Info("Provider specific pre-capi-install-setup on bootstrap cluster")
-	if err = commandContext.Provider.PreCAPIInstallOnBootstrap(ctx, bootstrapCluster, commandContext.ClusterSpec); err != nil {
+	if err = setupUpgradeBootstrapCluster(ctx, commandContext); err != nil {
 		commandContext.SetError(err)
 		return &CollectMgmtClusterDiagnosticsTask{}
 	}
+	s.bootstrapCluster = bootstrapCluster
+
+	return &installCAPITask{}
+}
+
+func setupUpgradeBootstrapCluster(ctx context.Context, commandContext *task.CommandContext) error {
+	logger.Info("Provider specific pre-capi-install-setup on bootstrap cluster")
+	if err := commandContext.Provider.PreCAPIInstallOnBootstrap(ctx, commandContext.BootstrapCluster, commandContext.ClusterSpec); err != nil {
+		return err
+	}
 
 	logger.Info("Provider specific post-setup")
-	if err = commandContext.Provider.PostBootstrapSetupUpgrade(ctx, commandContext.ClusterSpec.Cluster, bootstrapCluster); err != nil {
-		commandContext.SetError(err)
-		return &CollectMgmtClusterDiagnosticsTask{}
+	return commandContext.Provider.PostBootstrapSetupUpgrade(ctx, commandContext.ClusterSpec.Cluster, commandContext.BootstrapCluster)
+}
+
+// recreateUpgradeBootstrapCluster creates a new bootstrap cluster to replace the one lost in a previous run.
+func recreateUpgradeBootstrapCluster(ctx context.Context, commandContext *task.CommandContext) error {
+	bootstrapOptions, err := commandContext.Provider.BootstrapClusterOpts(commandContext.ClusterSpec)
+	if err != nil {
+		return err
 	}
-	s.bootstrapCluster = bootstrapCluster
 
-	return &installCAPITask{}
+	bootstrapCluster, err := commandContext.Bootstrapper.CreateBootstrapCluster(ctx, commandContext.ClusterSpec, bootstrapOptions...)
+	if err != nil {
+		return err
+	}
+	commandContext.BootstrapCluster = bootstrapCluster
+
+	return setupUpgradeBootstrapCluster(ctx, commandContext)
 }
 
 func (s *createBootstrapClusterTask) Name() string {
@@ -424,18 +477,23 @@ func (s *createBootstrapClusterTask) Checkpoint() *task.CompletedTask {
 }
 
 func (s *createBootstrapClusterTask) Restore(ctx context.Context, commandContext *task.CommandContext, completedTask *task.CompletedTask) (task.Task, error) {
-	s.bootstrapCluster = &types.Cluster{}
-	if err := task.UnmarshalTaskCheckpoint(completedTask.Checkpoint, s.bootstrapCluster); err != nil {
-		return nil, err
-	}
-	commandContext.BootstrapCluster = s.bootstrapCluster
 	if commandContext.ManagementCluster != nil && commandContext.ManagementCluster.ExistingManagement {
 		return &upgradeWorkloadClusterTask{}, nil
 	}
-	return &installCAPITask{}, nil
+	exists, err := restoreBootstrapCluster(ctx, commandContext, completedTask)
+	if err != nil {
+		return nil, err
+	}
+	s.bootstrapCluster = commandContext.BootstrapCluster
+	return &installCAPITask{bootstrapClusterRecovery{recreate: !exists}}, nil
 }
 
 func (s *installCAPITask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
+	if err := s.recover(ctx, commandContext, recreateUpgradeBootstrapCluster); err != nil {
+		commandContext.SetError(err)
+		return &CollectMgmtClusterDiagnosticsTask{}
+	}
+
 	logger.Info("Installing cluster-api providers on bootstrap cluster")
 	err := commandContext.ClusterManager.InstallCAPI(ctx, commandContext.ClusterSpec, commandContext.BootstrapCluster, commandContext.Provider)
 	if err != nil {
@@ -456,10 +514,15 @@ func (s *installCAPITask) Checkpoint() *task.CompletedTask {
 }
 
 func (s *installCAPITask) Restore(ctx context.Context, commandContext *task.CommandContext, completedTask *task.CompletedTask) (task.Task, error) {
-	return &moveManagementToBootstrapTask{}, nil
+	return &moveManagementToBootstrapTask{bootstrapClusterRecovery: s.recovered(true, false)}, nil
 }
 
 func (s *moveManagementToBootstrapTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
+	if err := s.recover(ctx, commandContext, recreateUpgradeBootstrapCluster); err != nil {
+		commandContext.SetError(err)
+		return &CollectMgmtClusterDiagnosticsTask{}
+	}
+
 	logger.Info("Backing up workload cluster's management resources before moving to bootstrap cluster")
 	err := commandContext.ClusterManager.BackupCAPI(ctx, commandContext.WorkloadCluster, commandContext.ManagementClusterStateDir)
 	if err != nil {
@@ -489,6 +552,7 @@ func (s *moveManagementToBootstrapTask) Run(ctx context.Context, commandContext
 	}
 
 	commandContext.ManagementCluster = commandContext.BootstrapCluster
+	s.managementClusterStateDir = commandContext.ManagementClusterStateDir
 	return &upgradeWorkloadClusterTask{}
 }
 
@@ -498,16 +562,24 @@ func (s *moveManagementToBootstrapTask) Name() string {
 
 func (s *moveManagementToBootstrapTask) Checkpoint() *task.CompletedTask {
 	return &task.CompletedTask{
-		Checkpoint: nil,
+		Checkpoint: s.managementClusterStateDir,
 	}
 }
 
 func (s *moveManagementToBootstrapTask) Restore(ctx context.Context, commandContext *task.CommandContext, completedTask *task.CompletedTask) (task.Task, error) {
+	if err := restoreManagementClusterStateDir(commandContext, completedTask); err != nil {
+		return nil, err
+	}
 	commandContext.ManagementCluster = commandContext.BootstrapCluster
-	return &upgradeWorkloadClusterTask{}, nil
+	return &upgradeWorkloadClusterTask{s.recovered(false, true)}, nil
 }
 
 func (s *upgradeWorkloadClusterTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
+	if err := s.recover(ctx, commandContext, recreateUpgradeBootstrapCluster); err != nil {
+		commandContext.SetError(err)
+		return &CollectMgmtClusterDiagnosticsTask{}
+	}
+
 	eksaManagementCluster := commandContext.WorkloadCluster
 	if commandContext.ManagementCluster != nil && commandContext.ManagementCluster.ExistingManagement {
 		eksaManagementCluster = commandContext.ManagementCluster
@@ -561,13 +633,18 @@ func (s *upgradeWorkloadClusterTask) Checkpoint() *task.CompletedTask {
 }
 
 func (s *upgradeWorkloadClusterTask) Restore(ctx context.Context, commandContext *task.CommandContext, completedTask *task.CompletedTask) (task.Task, error) {
-	return &moveManagementToWorkloadTask{}, nil
+	return &moveManagementToWorkloadTask{s.bootstrapClusterRecovery}, nil
 }
 
 func (s *moveManagementToWorkloadTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
 	if commandContext.ManagementCluster.ExistingManagement {
 		return &reconcileClusterDefinitions{eksaSpecDiff: true}
 	}
+	if err := s.recover(ctx, commandContext, recreateUpgradeBootstrapCluster); err != nil {
+		commandContext.SetError(err)
+		return &CollectMgmtClusterDiagnosticsTask{}
+	}
+
 	logger.Info("Moving cluster management from bootstrap to workload cluster")
 	err := commandContext.ClusterManager.MoveCAPI(ctx, commandContext.BootstrapCluster, commandContext.WorkloadCluster, commandContext.WorkloadCluster.Name, commandContext.ClusterSpec, types.WithNodeRef(), types.WithNodeHealthy())
 	if err != nil {
@@ -662,6 +739,9 @@ func (s *reconcileClusterDefinitions) Checkpoint() *task.CompletedTask {
 }
 
 func (s *reconcileClusterDefinitions) Restore(ctx context.Context, commandContext *task.CommandContext, completedTask *task.CompletedTask) (task.Task, error) {
+	if !s.eksaSpecDiff {
+		return nil, nil
+	}
 	return &writeClusterConfigTask{}, nil
 }
 
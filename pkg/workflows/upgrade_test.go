@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -14,7 +15,6 @@ import (
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/bootstrapper"
 	"github.com/aws/eks-anywhere/pkg/cluster"
-	"github.com/aws/eks-anywhere/pkg/features"
 	writermocks "github.com/aws/eks-anywhere/pkg/filewriter/mocks"
 	"github.com/aws/eks-anywhere/pkg/providers"
 	providermocks "github.com/aws/eks-anywhere/pkg/providers/mocks"
@@ -416,6 +416,18 @@ func (c *upgradeTestSetup) expectWriteCheckpointFile() {
 	)
 }
 
+// expectSaveCheckpoint expects the checkpoint to be saved after each of the completed tasks.
+func (c *upgradeTestSetup) expectSaveCheckpoint(completedTasks int) {
+	c.writer.EXPECT().Write(fmt.Sprintf("%s-checkpoint.yaml", c.newClusterSpec.Cluster.Name), gomock.Any()).Times(completedTasks)
+}
+
+// expectCheckpoints expects the checkpoint to be saved as the tasks complete and removed at the end,
+// for tests that don't check the checkpoint itself.
+func (c *upgradeTestSetup) expectCheckpoints() {
+	c.writer.EXPECT().TempDir().Return(c.t.TempDir()).AnyTimes()
+	c.writer.EXPECT().Write(fmt.Sprintf("%s-checkpoint.yaml", c.newClusterSpec.Cluster.Name), gomock.Any()).AnyTimes()
+}
+
 func (c *upgradeTestSetup) expectPreCoreComponentsUpgrade() {
 	c.provider.EXPECT().PreCoreComponentsUpgrade(gomock.Any(), gomock.Any(), gomock.Any())
 }
@@ -449,8 +461,8 @@ func (c *upgradeTestSetup) expectPreflightValidationsToPass() {
 }
 
 func TestSkipUpgradeRunSuccess(t *testing.T) {
-	os.Unsetenv(features.CheckpointEnabledEnvVar)
 	test := newUpgradeSelfManagedClusterTest(t)
+	test.expectCheckpoints()
 	test.expectSetup()
 	test.expectPreflightValidationsToPass()
 	test.expectUpdateSecrets(test.workloadCluster)
@@ -478,8 +490,8 @@ func TestSkipUpgradeRunSuccess(t *testing.T) {
 }
 
 func TestUpgradeRunSuccess(t *testing.T) {
-	os.Unsetenv(features.CheckpointEnabledEnvVar)
 	test := newUpgradeSelfManagedClusterTest(t)
+	test.expectCheckpoints()
 	test.expectSetup()
 	test.expectPreflightValidationsToPass()
 	test.expectUpdateSecrets(test.workloadCluster)
@@ -513,8 +525,8 @@ func TestUpgradeRunSuccess(t *testing.T) {
 }
 
 func TestUpgradeRunSuccessForceCleanup(t *testing.T) {
-	os.Unsetenv(features.CheckpointEnabledEnvVar)
 	test := newUpgradeSelfManagedClusterTest(t).WithForceCleanup()
+	test.expectCheckpoints()
 	test.expectSetup()
 	test.expectPreflightValidationsToPass()
 	test.expectUpdateSecrets(test.workloadCluster)
@@ -549,8 +561,8 @@ func TestUpgradeRunSuccessForceCleanup(t *testing.T) {
 }
 
 func TestUpgradeRunProviderNeedsUpgradeSuccess(t *testing.T) {
-	os.Unsetenv(features.CheckpointEnabledEnvVar)
 	test := newUpgradeSelfManagedClusterTest(t)
+	test.expectCheckpoints()
 	test.expectSetup()
 	test.expectPreflightValidationsToPass()
 	test.expectUpdateSecrets(test.workloadCluster)
@@ -583,8 +595,8 @@ func TestUpgradeRunProviderNeedsUpgradeSuccess(t *testing.T) {
 }
 
 func TestUpgradeWorkloadRunFailedForceCleanupBootstrap(t *testing.T) {
-	os.Unsetenv(features.CheckpointEnabledEnvVar)
 	test := newUpgradeSelfManagedClusterTest(t).WithForceCleanup()
+	test.expectCheckpoints()
 	test.expectSetup()
 	test.expectPreflightValidationsToPass()
 	test.expectUpdateSecrets(test.workloadCluster)
@@ -596,7 +608,6 @@ func TestUpgradeWorkloadRunFailedForceCleanupBootstrap(t *testing.T) {
 	test.expectPauseGitOpsReconcile(test.workloadCluster)
 	test.expectForceCleanupBootstrapError()
 	test.expectPreCoreComponentsUpgrade()
-	test.expectWriteCheckpointFile()
 
 	err := test.run()
 	if err == nil {
@@ -605,8 +616,8 @@ func TestUpgradeWorkloadRunFailedForceCleanupBootstrap(t *testing.T) {
 }
 
 func TestUpgradeRunFailedUpgrade(t *testing.T) {
-	os.Unsetenv(features.CheckpointEnabledEnvVar)
 	test := newUpgradeSelfManagedClusterTest(t)
+	test.expectCheckpoints()
 	test.expectSetup()
 	test.expectPreflightValidationsToPass()
 	test.expectUpdateSecrets(test.workloadCluster)
@@ -621,7 +632,6 @@ func TestUpgradeRunFailedUpgrade(t *testing.T) {
 	test.expectPrepareUpgradeWorkload(test.bootstrapCluster, test.workloadCluster)
 	test.expectUpgradeWorkloadToReturn(test.bootstrapCluster, test.workloadCluster, errors.New("failed upgrading"))
 	test.expectSaveLogs(test.workloadCluster)
-	test.expectWriteCheckpointFile()
 	test.expectPreCoreComponentsUpgrade()
 
 	err := test.run()
@@ -631,8 +641,8 @@ func TestUpgradeRunFailedUpgrade(t *testing.T) {
 }
 
 func TestUpgradeRunFailedBackupManagementUpgrade(t *testing.T) {
-	os.Unsetenv(features.CheckpointEnabledEnvVar)
 	test := newUpgradeSelfManagedClusterTest(t)
+	test.expectCheckpoints()
 	test.expectSetup()
 	test.expectPreflightValidationsToPass()
 	test.expectUpdateSecrets(test.workloadCluster)
@@ -645,7 +655,6 @@ func TestUpgradeRunFailedBackupManagementUpgrade(t *testing.T) {
 	test.expectCreateBootstrap()
 	test.expectBackupManagementToBootstrapFailed()
 	test.expectSaveLogs(test.workloadCluster)
-	test.expectWriteCheckpointFile()
 	test.expectPreCoreComponentsUpgrade()
 
 	err := test.run()
@@ -655,8 +664,8 @@ func TestUpgradeRunFailedBackupManagementUpgrade(t *testing.T) {
 }
 
 func TestUpgradeWorkloadRunSuccess(t *testing.T) {
-	os.Unsetenv(features.CheckpointEnabledEnvVar)
 	test := newUpgradeManagedClusterTest(t)
+	test.expectCheckpoints()
 	test.expectSetup()
 	test.expectPreflightValidationsToPass()
 	test.expectUpdateSecrets(test.managementCluster)
@@ -689,9 +698,6 @@ func TestUpgradeWorkloadRunSuccess(t *testing.T) {
 }
 
 func TestUpgradeWithCheckpointFirstRunFailed(t *testing.T) {
-	features.ClearCache()
-	t.Setenv(features.CheckpointEnabledEnvVar, "true")
-
 	test := newUpgradeSelfManagedClusterTest(t)
 	test.writer.EXPECT().TempDir()
 	test.expectSetupToFail()
@@ -704,9 +710,6 @@ func TestUpgradeWithCheckpointFirstRunFailed(t *testing.T) {
 }
 
 func TestUpgradeWithCheckpointSecondRunSuccess(t *testing.T) {
-	features.ClearCache()
-	t.Setenv(features.CheckpointEnabledEnvVar, "true")
-
 	test := newUpgradeSelfManagedClusterTest(t)
 	test.writer.EXPECT().TempDir()
 	test.expectSetup()
@@ -723,6 +726,7 @@ func TestUpgradeWithCheckpointSecondRunSuccess(t *testing.T) {
 	test.expectPrepareUpgradeWorkload(test.bootstrapCluster, test.workloadCluster)
 	test.expectUpgradeWorkloadToReturn(test.bootstrapCluster, test.workloadCluster, errors.New("failed upgrading"))
 	test.expectSaveLogs(test.workloadCluster)
+	test.expectSaveCheckpoint(9)
 	test.expectWriteCheckpointFile()
 	test.expectPreCoreComponentsUpgrade()
 
@@ -732,8 +736,10 @@ func TestUpgradeWithCheckpointSecondRunSuccess(t *testing.T) {
 	}
 
 	test2 := newUpgradeSelfManagedClusterTest(t)
-	test2.writer.EXPECT().TempDir().Return("testdata")
+	test2.writer.EXPECT().TempDir().Return(checkpointDir(t, "testdata/cluster-name-checkpoint.yaml")).Times(2)
 	test2.expectSetup()
+	test2.bootstrapper.EXPECT().BootstrapClusterExists(test2.ctx, test2.bootstrapCluster).Return(true, nil)
+	test2.expectSaveCheckpoint(5)
 	test2.expectUpgradeWorkload(test2.bootstrapCluster, test2.workloadCluster)
 	test2.expectMoveManagementToWorkload()
 	test2.expectWriteClusterConfig()
@@ -753,3 +759,107 @@ func TestUpgradeWithCheckpointSecondRunSuccess(t *testing.T) {
 		t.Fatalf("Upgrade.Run() err = %v, want nil", err)
 	}
 }
+
+func TestUpgradeWithCheckpointSecondRunBootstrapClusterLost(t *testing.T) {
+	test := newUpgradeSelfManagedClusterTest(t)
+	test.writer.EXPECT().TempDir().Return(checkpointDir(t, "testdata/cluster-name-checkpoint.yaml")).Times(2)
+	test.expectSetup()
+	test.bootstrapper.EXPECT().BootstrapClusterExists(test.ctx, test.bootstrapCluster).Return(false, nil)
+	gomock.InOrder(
+		test.provider.EXPECT().BootstrapClusterOpts(test.newClusterSpec),
+		test.bootstrapper.EXPECT().CreateBootstrapCluster(test.ctx, test.newClusterSpec).Return(test.bootstrapCluster, nil),
+		test.provider.EXPECT().PreCAPIInstallOnBootstrap(test.ctx, test.bootstrapCluster, test.newClusterSpec),
+		test.provider.EXPECT().PostBootstrapSetupUpgrade(test.ctx, test.newClusterSpec.Cluster, test.bootstrapCluster),
+		test.clusterManager.EXPECT().InstallCAPI(test.ctx, test.newClusterSpec, test.bootstrapCluster, test.provider),
+		test.clusterManager.EXPECT().RestoreCAPI(test.ctx, test.bootstrapCluster, test.workloadCluster.Name, gomock.Any()),
+	)
+	test.expectSaveCheckpoint(5)
+	test.expectUpgradeWorkload(test.bootstrapCluster, test.workloadCluster)
+	test.expectMoveManagementToWorkload()
+	test.expectWriteClusterConfig()
+	test.expectDeleteBootstrap()
+	test.expectDatacenterConfig()
+	test.expectMachineConfigs()
+	test.expectCreateEKSAResources(test.workloadCluster)
+	test.expectInstallEksdManifest(test.workloadCluster)
+	test.expectResumeEKSAControllerReconcile(test.workloadCluster)
+	test.expectUpdateGitEksaSpec()
+	test.expectForceReconcileGitRepo(test.workloadCluster)
+	test.expectResumeGitOpsReconcile(test.workloadCluster)
+	test.expectPostBootstrapDeleteForUpgrade()
+
+	if err := test.run(); err != nil {
+		t.Fatalf("Upgrade.Run() err = %v, want nil", err)
+	}
+}
+
+func TestUpgradeWithCheckpointNoUpgradeNeeded(t *testing.T) {
+	test := newUpgradeSelfManagedClusterTest(t)
+	dir := checkpointDir(t, "testdata/cluster-name-checkpoint.yaml")
+	checkpoint := `completedTasks:
+  setup-and-validate:
+    checkpoint: null
+  update-secrets:
+    checkpoint: null
+  ensure-etcd-capi-components-exist:
+    checkpoint: null
+  pause-controllers-reconcile:
+    checkpoint: null
+  upgrade-core-components:
+    checkpoint:
+      components: []
+  upgrade-needed:
+    checkpoint: false
+`
+	if err := os.WriteFile(filepath.Join(dir, "cluster-name-checkpoint.yaml"), []byte(checkpoint), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	test.writer.EXPECT().TempDir().Return(dir).Times(2)
+	test.expectSetup()
+	test.expectSaveCheckpoint(1)
+	test.expectCreateBootstrapNotToBeCalled()
+	test.expectDatacenterConfig()
+	test.expectMachineConfigs()
+	test.expectCreateEKSAResources(test.workloadCluster)
+	test.expectInstallEksdManifest(test.workloadCluster)
+	test.expectResumeEKSAControllerReconcile(test.workloadCluster)
+	test.expectUpdateGitEksaSpec()
+	test.expectForceReconcileGitRepo(test.workloadCluster)
+	test.expectResumeGitOpsReconcile(test.workloadCluster)
+
+	if err := test.run(); err != nil {
+		t.Fatalf("Upgrade.Run() err = %v, want nil", err)
+	}
+}
+
+// checkpointDir copies a checkpoint file to a temporary directory, since successful runs remove it.
+func checkpointDir(t *testing.T, file string) string {
+	dir := t.TempDir()
+	content, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, filepath.Base(file)), content, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestUpgradeValidateSuccess(t *testing.T) {
+	test := newUpgradeSelfManagedClusterTest(t)
+	test.expectSetup()
+	test.expectPreflightValidationsToPass()
+
+	if err := test.workflow.Validate(test.ctx, test.newClusterSpec, test.managementCluster, test.validator); err != nil {
+		t.Fatalf("Upgrade.Validate() err = %v, want err = nil", err)
+	}
+}
+
+func TestUpgradeValidateFailure(t *testing.T) {
+	test := newUpgradeSelfManagedClusterTest(t)
+	test.expectSetupToFail()
+
+	if err := test.workflow.Validate(test.ctx, test.newClusterSpec, test.managementCluster, test.validator); err == nil {
+		t.Fatal("Upgrade.Validate() err = nil, want err not nil")
+	}
+}
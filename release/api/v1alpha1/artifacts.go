@@ -76,6 +76,9 @@ func (vb *VersionsBundle) Manifests() map[string][]*string {
 			&vb.ExternalEtcdController.Components.URI,
 			&vb.ExternalEtcdController.Metadata.URI,
 		},
+		"argo-cd": {
+			&vb.ArgoCD.Manifest.URI,
+		},
 		"eks-distro": {
 			&vb.EksD.Components,
 			&vb.EksD.EksDReleaseUrl,
@@ -160,6 +163,19 @@ func (vb *VersionsBundle) NutanixImages() []Image {
 	return i
 }
 
+// ArgoCDImages returns the Argo CD images, when the bundle has them.
+func (vb *VersionsBundle) ArgoCDImages() []Image {
+	if vb.ArgoCD.ArgoCD.URI == "" {
+		return nil
+	}
+
+	return []Image{
+		vb.ArgoCD.ArgoCD,
+		vb.ArgoCD.Dex,
+		vb.ArgoCD.Redis,
+	}
+}
+
 func (vb *VersionsBundle) SharedImages() []Image {
 	return []Image{
 		vb.Bootstrap.Controller,
@@ -205,6 +221,7 @@ func (vb *VersionsBundle) Images() []Image {
 		vb.SnowImages(),
 		vb.TinkerbellImages(),
 		vb.NutanixImages(),
+		vb.ArgoCDImages(),
 	}
 
 	size := 0
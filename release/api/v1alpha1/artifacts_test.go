@@ -125,3 +125,18 @@ func TestVersionsBundleSnowImages(t *testing.T) {
 		})
 	}
 }
+
+func TestVersionsBundleArgoCDImages(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect((&v1alpha1.VersionsBundle{}).ArgoCDImages()).To(BeEmpty())
+
+	vb := &v1alpha1.VersionsBundle{
+		ArgoCD: v1alpha1.ArgoCDBundle{
+			ArgoCD: v1alpha1.Image{Name: "argocd", URI: "argocd-uri"},
+			Dex:    v1alpha1.Image{Name: "dex", URI: "dex-uri"},
+			Redis:  v1alpha1.Image{Name: "redis", URI: "redis-uri"},
+		},
+	}
+	g.Expect(vb.ArgoCDImages()).To(Equal([]v1alpha1.Image{vb.ArgoCD.ArgoCD, vb.ArgoCD.Dex, vb.ArgoCD.Redis}))
+	g.Expect(vb.Images()).To(ContainElements(vb.ArgoCD.ArgoCD, vb.ArgoCD.Dex, vb.ArgoCD.Redis))
+}
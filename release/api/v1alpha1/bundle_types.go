@@ -81,6 +81,7 @@ type VersionsBundle struct {
 	Haproxy                    HaproxyBundle                    `json:"haproxy,omitempty"`
 	Snow                       SnowBundle                       `json:"snow,omitempty"`
 	Nutanix                    NutanixBundle                    `json:"nutanix,omitempty"`
+	ArgoCD                     ArgoCDBundle                     `json:"argoCd,omitempty"`
 	// This field has been deprecated
 	Aws *AwsBundle `json:"aws,omitempty"`
 }
@@ -241,6 +242,15 @@ type FluxBundle struct {
 	NotificationController Image  `json:"notificationController"`
 }
 
+// ArgoCDBundle has the install manifest and images of Argo CD.
+type ArgoCDBundle struct {
+	Version  string   `json:"version,omitempty"`
+	ArgoCD   Image    `json:"argoCd"`
+	Dex      Image    `json:"dex"`
+	Redis    Image    `json:"redis"`
+	Manifest Manifest `json:"manifest"`
+}
+
 type PackageBundle struct {
 	Version        string `json:"version,omitempty"`
 	Controller     Image  `json:"packageController"`
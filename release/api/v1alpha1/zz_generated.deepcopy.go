@@ -81,6 +81,25 @@ func (in *Archive) DeepCopy() *Archive {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDBundle) DeepCopyInto(out *ArgoCDBundle) {
+	*out = *in
+	in.ArgoCD.DeepCopyInto(&out.ArgoCD)
+	in.Dex.DeepCopyInto(&out.Dex)
+	in.Redis.DeepCopyInto(&out.Redis)
+	out.Manifest = in.Manifest
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDBundle.
+func (in *ArgoCDBundle) DeepCopy() *ArgoCDBundle {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDBundle)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AwsBundle) DeepCopyInto(out *AwsBundle) {
 	*out = *in
@@ -997,6 +1016,7 @@ func (in *VersionsBundle) DeepCopyInto(out *VersionsBundle) {
 	in.Haproxy.DeepCopyInto(&out.Haproxy)
 	in.Snow.DeepCopyInto(&out.Snow)
 	in.Nutanix.DeepCopyInto(&out.Nutanix)
+	in.ArgoCD.DeepCopyInto(&out.ArgoCD)
 	if in.Aws != nil {
 		in, out := &in.Aws, &out.Aws
 		*out = new(AwsBundle)
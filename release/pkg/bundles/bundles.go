@@ -106,6 +106,11 @@ func GetVersionsBundles(r *releasetypes.ReleaseConfig, imageDigests map[string]s
 		return nil, errors.Wrapf(err, "Error getting bundle for Flux controllers")
 	}
 
+	argoCDBundle, err := GetArgoCDBundle(r, imageDigests)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error getting bundle for Argo CD")
+	}
+
 	etcdadmBootstrapBundle, err := GetEtcdadmBootstrapBundle(r, imageDigests)
 	if err != nil {
 		return nil, errors.Wrapf(err, "Error getting bundle for external Etcdadm bootstrap")
@@ -199,6 +204,7 @@ func GetVersionsBundles(r *releasetypes.ReleaseConfig, imageDigests map[string]s
 			Haproxy:                    haproxyBundle,
 			Snow:                       snowBundle,
 			Nutanix:                    nutanixBundle,
+			ArgoCD:                     argoCDBundle,
 		}
 		versionsBundles = append(versionsBundles, versionsBundle)
 	}